@@ -12,20 +12,26 @@ import (
 
 	"github.com/wonny/aegis/v13/backend/internal/api"
 	"github.com/wonny/aegis/v13/backend/internal/api/handlers"
-	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
 	"github.com/wonny/aegis/v13/backend/internal/external/kis"
-	"github.com/wonny/aegis/v13/backend/internal/external/krx"
 	"github.com/wonny/aegis/v13/backend/internal/external/naver"
 	"github.com/wonny/aegis/v13/backend/internal/forecast"
 	"github.com/wonny/aegis/v13/backend/internal/portfolio"
+	"github.com/wonny/aegis/v13/backend/internal/queue"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/bars"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/feed"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data"
-	"github.com/wonny/aegis/v13/backend/internal/s0_data/collector"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/quality"
 	"github.com/wonny/aegis/v13/backend/internal/s1_universe"
+	"github.com/wonny/aegis/v13/backend/internal/scheduler"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/database"
 	"github.com/wonny/aegis/v13/backend/pkg/httputil"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // apiCmd represents the api command
@@ -39,13 +45,41 @@ var apiCmd = &cobra.Command{
 - 데이터 조회 엔드포인트 제공
 - KIS 거래 API 엔드포인트 제공
 
+인증 (API_AUTH_ENABLED=true, production 필수):
+- Authorization: Bearer <key> 또는 X-API-Key: <key> (키 발급: quant auth key create)
+- 역할: GET = read_only, 변경 요청 = trader, 운영 제어(수집/작업 큐/스케줄러/감사 로그) = admin
+- 변경 요청(POST/PUT/PATCH/DELETE)은 거부된 요청 포함 audit.api_audit_log에 기록
+- CORS/WebSocket Origin은 API_CORS_ORIGINS에 등록된 출처만 허용
+
+메트릭 (METRICS_ENABLED=true):
+- Prometheus /metrics를 API_METRICS_PORT(기본 9090)에 별도 노출 (인증 없음, 내부망 전용)
+- scheduler/worker는 SCHEDULER_METRICS_PORT(9091)/WORKER_METRICS_PORT(9092) 사용
+
 Endpoints:
-  GET  /health                      - Health check
+  GET  /health                      - Health check (인증 없음)
+
+  Auth:
+  GET  /api/auth/me                 - 현재 키 이름/역할
+  GET  /api/audit                   - 변경 요청 감사 로그 (?actor=&since=&limit=, admin)
 
   Data:
   GET  /api/data/quality            - 품질 스냅샷 조회
   GET  /api/data/universe           - Universe 조회
-  POST /api/data/collect            - 데이터 수집 트리거
+  POST /api/data/collect            - 데이터 수집 작업 적재 (202, quant worker가 실행)
+
+  Jobs:
+  GET  /api/jobs                    - 큐 작업 목록 (?status=&type=&limit=)
+  GET  /api/jobs/{id}               - 작업 상태/결과 조회
+  POST /api/jobs/{id}/cancel        - 대기 중 작업 취소
+  POST /api/jobs/{id}/retry         - dead/cancelled 작업 재적재
+
+  Scheduler:
+  GET  /api/scheduler/jobs                - 등록 작업/일시정지/최근 실행 (리더 스케줄러 동작 여부 포함)
+  GET  /api/scheduler/jobs/{name}/runs    - 작업 실행 이력 (?limit=)
+  POST /api/scheduler/jobs/{name}/run     - 즉시 실행 요청 (202, 리더 스케줄러가 실행)
+  POST /api/scheduler/jobs/{name}/pause   - 작업 일시정지
+  POST /api/scheduler/jobs/{name}/resume  - 작업 재개
+  GET  /api/scheduler/requests/{id}       - 실행 요청 상태와 실행 결과
 
   Trading (KIS):
   GET  /api/trading/balance         - 잔고 조회
@@ -55,6 +89,10 @@ Endpoints:
   GET  /api/trading/orders/filled   - 체결 주문
   POST /api/trading/orders          - 주문 실행
   DELETE /api/trading/orders        - 주문 취소
+  GET  /api/trading/kill-switch     - 킬 스위치 상태/변경 이력
+  POST /api/trading/kill-switch/activate   - 킬 스위치 발동 (모든 신규 주문 차단, cancel_open: 미체결 취소)
+  POST /api/trading/kill-switch/deactivate - 킬 스위치 해제 (admin)
+  GET  /api/trading/pretrade-checks - 주문 검사 결과 (?blocked=true&limit=)
   GET  /api/trading/price           - 현재가 조회
 
   WebSocket:
@@ -62,6 +100,9 @@ Endpoints:
   POST /api/trading/ws/subscribe    - 실시간 구독
   POST /api/trading/ws/unsubscribe  - 구독 해제
 
+  Stream:
+  GET  /api/v1/stream/prices        - 실시간 가격 스트림 (WebSocket/SSE, ?codes=005930,000660)
+
   Exit Monitoring:
   PATCH /api/trading/positions/{stock_code}/exit-monitoring - 청산 모니터링 설정
   GET   /api/trading/exit-monitoring                        - 모니터링 상태 조회
@@ -129,15 +170,14 @@ func runAPIServer(cmd *cobra.Command, args []string) error {
 
 	// 5. Create external API clients
 	naverClient := naver.NewClient(httpClient, log)
-	dartClient := dart.NewClient(cfg.DART.APIKey, log)
-	krxClient := krx.NewClient(httpClient, log)
 
 	// 6. Create repositories
 	dataRepo := s0_data.NewRepository(db.Pool)
 	universeRepo := s1_universe.NewRepository(db.Pool)
 	portfolioRepo := portfolio.NewRepository(db.Pool)
-	priceRepo := s0_data.NewPriceRepository(db.Pool)
+	priceRepo := s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisRaw)
 	investorFlowRepo := s0_data.NewInvestorFlowRepository(db.Pool)
+	barRepo := bars.NewRepository(db.Pool)
 
 	// 7. Create quality gate
 	qualityConfig := quality.Config{
@@ -150,19 +190,55 @@ func runAPIServer(cmd *cobra.Command, args []string) error {
 	}
 	qualityGate := quality.NewQualityGate(db.Pool, qualityConfig)
 
-	// 8. Create collector
-	col := collector.NewCollector(naverClient, dartClient, krxClient, dataRepo, log)
+	// 8. Create job queue (수집 요청은 quant worker가 실행)
+	jobQueue := queue.NewRepository(db.Pool)
+
+	// 9. Create KIS client (토큰은 ops.kis_credentials에 저장, 요청 예산은 WebSocket/폴러와 공유)
+	kisClient := newKISClient(cfg, config.KISProcessAPI, httpClient, db.Pool, log)
+	kisSessionCtx, stopKISSession := context.WithCancel(context.Background())
+	defer stopKISSession()
+	go kisClient.Session().Run(kisSessionCtx)
+
+	// 10. Create price cache (shared tick stream for /api/v1/stream/prices)
+	priceCache := cache.NewPriceCache(60*time.Second, log)
+	priceCache.ExportMetrics()
 
-	// 9. Create KIS client
-	kisClient := kis.NewClient(cfg.KIS, httpClient, log)
+	// 10.1. Aggregate realtime ticks into 1m/5m bars (data.intraday_bars)
+	barCtx, stopBars := context.WithCancel(context.Background())
+	barsDone := make(chan struct{})
+	barAggregator := bars.NewAggregator(priceCache, barRepo, log)
+	go func() {
+		defer close(barsDone)
+		barAggregator.Start(barCtx)
+	}()
+
+	// 10.2. Create order book cache (ASK1/BID1, daily spread stats → data.daily_spread_stats)
+	orderBookCache := cache.NewOrderBookCache(10*time.Second, log)
+	spreadRecorder := cache.NewSpreadRecorder(orderBookCache, s0_data.NewSpreadRepository(db.Pool), log)
+	spreadDone := make(chan struct{})
+	go func() {
+		defer close(spreadDone)
+		spreadRecorder.Start(barCtx)
+	}()
 
-	// 10. Create KIS WebSocket client (optional - only if HTS ID is set)
+	// 10.3. Create KIS WebSocket client (optional - only if HTS ID is set)
 	var kisWSClient *kis.WSClient
 	if cfg.KIS.HtsID != "" {
 		kisWSClient = kis.NewWSClient(cfg.KIS, log)
+		kisWSClient.SetSession(kisClient.Session())
 		kisWSClient.SetHtsID(cfg.KIS.HtsID)
 
-		// Connect WebSocket in background
+		// 실시간 체결가 → PriceCache → 스트림 구독자
+		kisWSClient.OnTick(func(tick *kis.TickData) {
+			priceCache.Update(feed.TickFromKIS(tick))
+		})
+
+		// 실시간 호가 → OrderBookCache
+		kisWSClient.OnOrderBook(func(book *kis.OrderBookData) {
+			orderBookCache.Update(feed.OrderBookFromKIS(book))
+		})
+
+		// Connect WebSocket in background (이후 끊김 시 자동 재연결 + 구독 복원)
 		go func() {
 			if err := kisWSClient.Connect(context.Background()); err != nil {
 				log.WithError(err).Warn("Failed to connect KIS WebSocket")
@@ -177,16 +253,43 @@ func runAPIServer(cmd *cobra.Command, args []string) error {
 	forecastPredictor := forecast.NewPredictor(forecastRepo, log.Zerolog())
 
 	// 12. Create handlers
-	dataHandler := handlers.NewDataHandler(dataRepo, universeRepo, col, qualityGate, log)
-	tradingHandler := handlers.NewTradingHandler(kisClient, kisWSClient, portfolioRepo, log)
+	dataHandler := handlers.NewDataHandler(dataRepo, universeRepo, qualityGate, jobQueue, log)
+	execRepo := execution.NewRepository(db.Pool)
+	preTrade := execution.NewPreTradeChecker(execRepo, execution.DefaultPreTradeConfig(), log)
+	preTrade.SetBuyingPowerProvider(kisClient)
+	// 계좌별 매수 가능 금액 (KIS_ACCOUNTS, 기본 계좌 외)
+	for _, accountID := range cfg.KIS.AccountIDs() {
+		if accountID == config.DefaultAccountID {
+			continue
+		}
+		accountClient, err := newKISAccountClient(cfg, config.KISProcessAPI, accountID, httpClient, db.Pool, log)
+		if err != nil {
+			log.WithError(err).WithField("account_id", accountID).Warn("Failed to create KIS client for account")
+			continue
+		}
+		go accountClient.Session().Run(kisSessionCtx)
+		preTrade.SetAccountBuyingPower(accountID, accountClient)
+	}
+	// 12.1. Exit monitor: 보유 종목 청산 규칙을 실시간 체결로 평가 (KIS 계좌 설정 시)
+	if cfg.KIS.AppKey != "" {
+		if _, err := startExitMonitor(kisSessionCtx, cfg, db, kisClient, kisWSClient, priceCache, preTrade, log); err != nil {
+			log.WithError(err).Warn("Exit monitor not started")
+		}
+	}
+	tradingHandler := handlers.NewTradingHandler(kisClient, kisWSClient, portfolioRepo, execRepo, preTrade, log)
 	stocklistHandler := handlers.NewStocklistHandler(portfolioRepo, log)
-	stockHandler := handlers.NewStockHandler(priceRepo, investorFlowRepo, dataRepo, log)
+	stockHandler := handlers.NewStockHandler(priceRepo, investorFlowRepo, dataRepo, barRepo, log)
 	rankingHandler := handlers.NewRankingHandler(db.Pool, naverClient, log)
 	pipelineHandler := handlers.NewPipelineHandler(db.Pool, log)
-	forecastHandler := handlers.NewForecastHandler(forecastRepo, priceRepo, forecastDetector, forecastPredictor, forecastAggregator, log)
+	forecastHandler := handlers.NewForecastHandler(forecastRepo, priceRepo.WithBasis(contracts.PriceBasisAdjusted), forecastDetector, forecastPredictor, forecastAggregator, log)
+	streamHandler := handlers.NewStreamHandler(priceCache, cfg.API.CORSOrigins, log)
+	jobHandler := handlers.NewJobHandler(jobQueue, log)
+	schedulerHandler := handlers.NewSchedulerHandler(scheduler.NewRepository(db.Pool), log)
+	authRepo := auth.NewRepository(db.Pool)
+	authHandler := handlers.NewAuthHandler(authRepo, log)
 
 	// 13. Create router
-	router := api.NewRouter(dataHandler, tradingHandler, stocklistHandler, stockHandler, rankingHandler, pipelineHandler, forecastHandler, log)
+	router := api.NewRouter(dataHandler, tradingHandler, stocklistHandler, stockHandler, rankingHandler, pipelineHandler, forecastHandler, streamHandler, jobHandler, schedulerHandler, authHandler, authRepo, cfg.API, log)
 
 	// 14. Create server
 	server := api.New(cfg, log, router)
@@ -198,19 +301,48 @@ func runAPIServer(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
+	// 15.1. Prometheus /metrics (API_METRICS_PORT, API 포트와 분리)
+	metricsServer := metrics.StartServer(cfg, metrics.ProcessAPI, log)
+
 	log.Info("API server started successfully")
 	fmt.Printf("\n✅ Server running on http://localhost:%s\n", cfg.Port)
+	if metricsServer != nil {
+		fmt.Printf("📈 Metrics on http://localhost:%s/metrics\n", metricsServer.Port())
+	}
+	if !cfg.API.AuthEnabled {
+		fmt.Println("\n⚠️  API authentication disabled (API_AUTH_ENABLED=false)")
+	}
 	fmt.Println("\nAvailable endpoints:")
 	fmt.Println("  GET  /health")
 	fmt.Println("  GET  /api/data/quality")
 	fmt.Println("  GET  /api/data/universe")
 	fmt.Println("  POST /api/data/collect")
+	fmt.Println("\nAuth endpoints:")
+	fmt.Println("  GET  /api/auth/me")
+	fmt.Println("  GET  /api/audit")
+	fmt.Println("\nJob endpoints:")
+	fmt.Println("  GET  /api/jobs")
+	fmt.Println("  GET  /api/jobs/{id}")
+	fmt.Println("  POST /api/jobs/{id}/cancel")
+	fmt.Println("  POST /api/jobs/{id}/retry")
+	fmt.Println("\nScheduler endpoints:")
+	fmt.Println("  GET  /api/scheduler/jobs")
+	fmt.Println("  GET  /api/scheduler/jobs/{name}/runs")
+	fmt.Println("  POST /api/scheduler/jobs/{name}/run")
+	fmt.Println("  POST /api/scheduler/jobs/{name}/pause")
+	fmt.Println("  POST /api/scheduler/jobs/{name}/resume")
 	fmt.Println("\nTrading endpoints:")
 	fmt.Println("  GET  /api/trading/balance")
 	fmt.Println("  GET  /api/trading/positions")
 	fmt.Println("  GET  /api/trading/orders")
 	fmt.Println("  POST /api/trading/orders")
 	fmt.Println("  GET  /api/trading/price?stock_code=005930")
+	fmt.Println("  GET  /api/trading/kill-switch")
+	fmt.Println("  POST /api/trading/kill-switch/activate")
+	fmt.Println("  POST /api/trading/kill-switch/deactivate")
+	fmt.Println("  GET  /api/trading/pretrade-checks")
+	fmt.Println("\nStream endpoints:")
+	fmt.Println("  GET  /api/v1/stream/prices?codes=005930 (WebSocket/SSE)")
 	fmt.Println("\nStocklist endpoints:")
 	fmt.Println("  GET    /api/v1/watchlist")
 	fmt.Println("  GET    /api/v1/watchlist/{category}")
@@ -230,8 +362,18 @@ func runAPIServer(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
-		return fmt.Errorf("server shutdown failed: %w", err)
+	shutdownErr := server.Shutdown(ctx)
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("Metrics server shutdown failed")
+	}
+
+	// Flush in-progress bars before closing the database
+	stopBars()
+	<-barsDone
+	<-spreadDone
+
+	if shutdownErr != nil {
+		return fmt.Errorf("server shutdown failed: %w", shutdownErr)
 	}
 
 	log.Info("Server stopped")
@@ -23,7 +23,9 @@ var auditCmd = &cobra.Command{
 
 명령어:
   montecarlo   Monte Carlo 시뮬레이션 실행
-  risk-report  리스크 리포트 생성`,
+  risk-report  리스크 리포트 생성
+  cashflow     입출금 원장 관리 (TWR/MWR 보정)
+  ledger       실현손익 원장 (FIFO 랏 매칭)`,
 }
 
 var (
@@ -137,7 +139,7 @@ func runAuditMonteCarlo(cmd *cobra.Command, args []string) error {
 
 	// 포트폴리오 수익률 조회 (S7에서 데이터 조립)
 	auditRepo := audit.NewRepository(db.Pool)
-	priceRepo := s0_data.NewPriceRepository(db.Pool)
+	priceRepo := s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisAdjusted)
 
 	toDate := time.Now()
 	var weights map[string]float64
@@ -319,7 +321,7 @@ func runAuditRiskReport(cmd *cobra.Command, args []string) error {
 
 	// 리포터 초기화
 	auditRepo := audit.NewRepository(db.Pool)
-	priceRepo := s0_data.NewPriceRepository(db.Pool)
+	priceRepo := s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisAdjusted)
 	engine := risk.NewEngine(risk.DefaultRiskLimits(), risk.DefaultMonteCarloConfig(), log)
 	reporter := audit.NewRiskReporter(engine, auditRepo, log.Zerolog())
 
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/audit"
+)
+
+var (
+	// cashflow 플래그
+	cashFlowDate   string
+	cashFlowType   string
+	cashFlowAmount float64
+	cashFlowStock  string
+	cashFlowMemo   string
+	cashFlowFrom   string
+	cashFlowTo     string
+)
+
+var auditCashFlowCmd = &cobra.Command{
+	Use:   "cashflow",
+	Short: "입출금 원장 관리 (TWR/MWR 보정용)",
+	Long: `외부 입출금/배당 원장을 관리합니다.
+
+원장의 확정(CONFIRMED) 항목만 성과 계산(TWR/MWR, 자산곡선)에 반영됩니다.
+스냅샷 저장 시 설명되지 않는 잔고 급변은 DETECTED/PENDING 항목으로 기록되며,
+confirm 또는 reject로 처리합니다.
+
+Example:
+  go run ./cmd/quant audit cashflow add --type deposit --amount 10000000 --date 2024-01-15
+  go run ./cmd/quant audit cashflow add --type withdrawal --amount 5000000
+  go run ./cmd/quant audit cashflow add --type dividend --amount 36100 --stock 005930
+  go run ./cmd/quant audit cashflow list --from 2024-01-01
+  go run ./cmd/quant audit cashflow confirm 12
+  go run ./cmd/quant audit cashflow reject 13`,
+}
+
+var auditCashFlowAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "입출금/배당 항목 추가",
+	RunE:  runAuditCashFlowAdd,
+}
+
+var auditCashFlowListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "원장 조회",
+	RunE:  runAuditCashFlowList,
+}
+
+var auditCashFlowConfirmCmd = &cobra.Command{
+	Use:   "confirm [id]",
+	Short: "감지된 항목 확정 (수익률 계산에 반영)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuditCashFlowResolve(cmd, args[0], audit.CashFlowConfirmed)
+	},
+}
+
+var auditCashFlowRejectCmd = &cobra.Command{
+	Use:   "reject [id]",
+	Short: "감지된 항목 기각 (실제 손익으로 간주)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuditCashFlowResolve(cmd, args[0], audit.CashFlowRejected)
+	},
+}
+
+func init() {
+	auditCmd.AddCommand(auditCashFlowCmd)
+	auditCashFlowCmd.AddCommand(auditCashFlowAddCmd)
+	auditCashFlowCmd.AddCommand(auditCashFlowListCmd)
+	auditCashFlowCmd.AddCommand(auditCashFlowConfirmCmd)
+	auditCashFlowCmd.AddCommand(auditCashFlowRejectCmd)
+
+	auditCashFlowAddCmd.Flags().StringVar(&cashFlowDate, "date", "", "일자 (YYYY-MM-DD, 기본: 오늘)")
+	auditCashFlowAddCmd.Flags().StringVar(&cashFlowType, "type", "", "유형 (deposit, withdrawal, dividend)")
+	auditCashFlowAddCmd.Flags().Float64Var(&cashFlowAmount, "amount", 0, "금액 (양수, 출금은 자동으로 음수 처리)")
+	auditCashFlowAddCmd.Flags().StringVar(&cashFlowStock, "stock", "", "종목코드 (dividend 전용)")
+	auditCashFlowAddCmd.Flags().StringVar(&cashFlowMemo, "memo", "", "메모")
+	auditCashFlowAddCmd.MarkFlagRequired("type")
+	auditCashFlowAddCmd.MarkFlagRequired("amount")
+
+	auditCashFlowListCmd.Flags().StringVar(&cashFlowFrom, "from", "", "시작일 (YYYY-MM-DD, 기본: 30일 전)")
+	auditCashFlowListCmd.Flags().StringVar(&cashFlowTo, "to", "", "종료일 (YYYY-MM-DD, 기본: 오늘)")
+}
+
+func runAuditCashFlowAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	date := time.Now()
+	if cashFlowDate != "" {
+		parsed, err := time.Parse("2006-01-02", cashFlowDate)
+		if err != nil {
+			return fmt.Errorf("invalid date: %w", err)
+		}
+		date = parsed
+	}
+
+	flowType := audit.CashFlowType(strings.ToUpper(cashFlowType))
+	amount := cashFlowAmount
+	if flowType == audit.CashFlowWithdrawal && amount > 0 {
+		amount = -amount
+	}
+
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	analyzer := audit.NewAnalyzer(audit.NewRepository(db.Pool), log)
+	flow := &audit.CashFlow{
+		Date:      date,
+		Type:      flowType,
+		Amount:    amount,
+		StockCode: cashFlowStock,
+		Memo:      cashFlowMemo,
+	}
+	if err := analyzer.RecordCashFlow(ctx, flow); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Recorded cash flow #%d: %s %s %.0f\n",
+		flow.ID, flow.Date.Format("2006-01-02"), flow.Type, flow.Amount)
+	return nil
+}
+
+func runAuditCashFlowList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+	if cashFlowFrom != "" {
+		parsed, err := time.Parse("2006-01-02", cashFlowFrom)
+		if err != nil {
+			return fmt.Errorf("invalid from date: %w", err)
+		}
+		from = parsed
+	}
+	if cashFlowTo != "" {
+		parsed, err := time.Parse("2006-01-02", cashFlowTo)
+		if err != nil {
+			return fmt.Errorf("invalid to date: %w", err)
+		}
+		to = parsed
+	}
+
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	analyzer := audit.NewAnalyzer(audit.NewRepository(db.Pool), log)
+	flows, err := analyzer.ListCashFlows(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=== Cash Flows %s ~ %s ===\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	fmt.Printf("%-6s %-10s %-10s %15s %-8s %-8s %-9s %s\n", "ID", "Date", "Type", "Amount", "Stock", "Source", "Status", "Memo")
+	for _, f := range flows {
+		fmt.Printf("%-6d %-10s %-10s %15.0f %-8s %-8s %-9s %s\n",
+			f.ID, f.Date.Format("2006-01-02"), f.Type, f.Amount, f.StockCode, f.Source, f.Status, f.Memo)
+	}
+	fmt.Printf("\nTotal: %d entries\n", len(flows))
+	return nil
+}
+
+func runAuditCashFlowResolve(cmd *cobra.Command, idArg string, status audit.CashFlowStatus) error {
+	id, err := strconv.ParseInt(idArg, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	analyzer := audit.NewAnalyzer(audit.NewRepository(db.Pool), log)
+	if err := analyzer.ResolveCashFlow(cmd.Context(), id, status); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Cash flow #%d → %s\n", id, status)
+	return nil
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/audit"
+)
+
+var (
+	// ledger 플래그
+	ledgerFrom string
+	ledgerTo   string
+)
+
+var auditLedgerCmd = &cobra.Command{
+	Use:   "ledger",
+	Short: "실현손익 원장 (FIFO 랏 매칭)",
+	Long: `체결 내역(execution.trades)으로부터 실현손익 원장을 관리합니다.
+
+매도 체결은 종목별 매수 랏에 FIFO로 매칭되며, 분할 청산(TP1/TP2/TP3)은
+랏별로 나뉘어 보유기간/수수료/세금/청산사유와 함께 기록됩니다.
+성과 분석의 승률/손익비/평균 손익은 완결 라운드트립 기준으로 계산됩니다.
+
+Example:
+  go run ./cmd/quant audit ledger rebuild
+  go run ./cmd/quant audit ledger lots --from 2024-01-01
+  go run ./cmd/quant audit ledger strategies --from 2024-01-01 --to 2024-06-30`,
+}
+
+var auditLedgerRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "체결 내역 전체로 원장 재구성",
+	RunE:  runAuditLedgerRebuild,
+}
+
+var auditLedgerLotsCmd = &cobra.Command{
+	Use:   "lots",
+	Short: "청산 랏 조회",
+	RunE:  runAuditLedgerLots,
+}
+
+var auditLedgerStrategiesCmd = &cobra.Command{
+	Use:   "strategies",
+	Short: "전략 버전별 실현손익",
+	RunE:  runAuditLedgerStrategies,
+}
+
+func init() {
+	auditCmd.AddCommand(auditLedgerCmd)
+	auditLedgerCmd.AddCommand(auditLedgerRebuildCmd)
+	auditLedgerCmd.AddCommand(auditLedgerLotsCmd)
+	auditLedgerCmd.AddCommand(auditLedgerStrategiesCmd)
+
+	for _, c := range []*cobra.Command{auditLedgerLotsCmd, auditLedgerStrategiesCmd} {
+		c.Flags().StringVar(&ledgerFrom, "from", "", "시작일 (YYYY-MM-DD, 기본: 30일 전)")
+		c.Flags().StringVar(&ledgerTo, "to", "", "종료일 (YYYY-MM-DD, 기본: 오늘)")
+	}
+}
+
+func runAuditLedgerRebuild(cmd *cobra.Command, args []string) error {
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	analyzer := audit.NewAnalyzer(audit.NewRepository(db.Pool), log)
+	result, err := analyzer.RebuildTradeLedger(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Trade ledger rebuilt: %d closed lots, %d open lots, %d unmatched sells\n",
+		len(result.Closed), len(result.Open), len(result.Unmatched))
+	for _, u := range result.Unmatched {
+		fmt.Printf("  ⚠️  unmatched sell #%d %s %d주 @ %.0f (%s)\n",
+			u.TradeID, u.Code, u.Quantity, u.Price, u.Time.Format("2006-01-02"))
+	}
+	return nil
+}
+
+func runAuditLedgerLots(cmd *cobra.Command, args []string) error {
+	from, to, err := parseLedgerPeriod()
+	if err != nil {
+		return err
+	}
+
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	analyzer := audit.NewAnalyzer(audit.NewRepository(db.Pool), log)
+	lots, err := analyzer.GetClosedLots(cmd.Context(), from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=== Closed Lots %s ~ %s ===\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	fmt.Printf("%-8s %-10s %-10s %10s %10s %6s %5s %10s %12s %-12s %s\n",
+		"Code", "Entry", "Exit", "EntryPx", "ExitPx", "Qty", "Days", "Cost", "NetPnL", "Reason", "Strategy")
+	total := 0.0
+	for _, l := range lots {
+		fmt.Printf("%-8s %-10s %-10s %10.0f %10.0f %6d %5d %10.0f %12.0f %-12s %s\n",
+			l.Code, l.EntryTime.Format("2006-01-02"), l.ExitTime.Format("2006-01-02"),
+			l.EntryPrice, l.ExitPrice, l.Quantity, l.HoldingDays,
+			l.BuyFee+l.SellFee+l.Tax, l.NetPnL, l.ExitReason, l.StrategyVersion)
+		total += l.NetPnL
+	}
+	fmt.Printf("\nTotal: %d lots, net realized P&L %.0f\n", len(lots), total)
+	return nil
+}
+
+func runAuditLedgerStrategies(cmd *cobra.Command, args []string) error {
+	from, to, err := parseLedgerPeriod()
+	if err != nil {
+		return err
+	}
+
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	analyzer := audit.NewAnalyzer(audit.NewRepository(db.Pool), log)
+	summaries, err := analyzer.GetRealizedPnLByStrategy(cmd.Context(), from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=== Realized P&L by Strategy %s ~ %s ===\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	fmt.Printf("%-16s %6s %8s %14s %10s %10s %14s %6s %6s\n",
+		"Strategy", "Lots", "Qty", "Gross", "Fees", "Tax", "Net", "Wins", "Losses")
+	for _, s := range summaries {
+		version := s.StrategyVersion
+		if version == "" {
+			version = "(unknown)"
+		}
+		fmt.Printf("%-16s %6d %8d %14.0f %10.0f %10.0f %14.0f %6d %6d\n",
+			version, s.ClosedLots, s.Quantity, s.GrossPnL, s.Fees, s.Tax, s.NetPnL, s.Wins, s.Losses)
+	}
+	return nil
+}
+
+// parseLedgerPeriod parses --from/--to (기본: 최근 30일)
+func parseLedgerPeriod() (time.Time, time.Time, error) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+	if ledgerFrom != "" {
+		parsed, err := time.Parse("2006-01-02", ledgerFrom)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %w", err)
+		}
+		from = parsed
+	}
+	if ledgerTo != "" {
+		parsed, err := time.Parse("2006-01-02", ledgerTo)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %w", err)
+		}
+		to = parsed
+	}
+	return from, to, nil
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+)
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "API 키 및 감사 로그 관리",
+	Long: `API 서버 인증 키와 변경 요청 감사 로그를 관리합니다.
+
+역할 (상위 역할은 하위 역할 권한 포함):
+  read_only  - 조회(GET) 전용
+  trader     - 주문/청산/리스크 설정 등 변경 요청
+  admin      - 데이터 수집, 작업 큐, 스케줄러 제어, 감사 로그 조회
+
+Subcommands:
+  key create  - API 키 발급 (평문 키는 발급 시 한 번만 출력)
+  key list    - API 키 목록
+  key revoke  - API 키 폐기
+  audit       - 변경 요청 감사 로그 조회
+
+Example:
+  go run ./cmd/quant auth key create --name dashboard --role read_only
+  go run ./cmd/quant auth key revoke dashboard
+  go run ./cmd/quant auth audit --actor dashboard --limit 20`,
+}
+
+var (
+	authKeyCmd = &cobra.Command{
+		Use:   "key",
+		Short: "API 키 관리",
+	}
+
+	authKeyCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "API 키 발급",
+		RunE:  runAuthKeyCreate,
+	}
+
+	authKeyListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "API 키 목록",
+		RunE:  runAuthKeyList,
+	}
+
+	authKeyRevokeCmd = &cobra.Command{
+		Use:   "revoke [name]",
+		Short: "API 키 폐기",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runAuthKeyRevoke,
+	}
+
+	authAuditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "변경 요청 감사 로그 조회",
+		RunE:  runAuthAudit,
+	}
+)
+
+var (
+	// auth 플래그
+	authKeyName    string
+	authKeyRole    string
+	authAuditActor string
+	authAuditSince string
+	authAuditLimit int
+)
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authKeyCmd)
+	authCmd.AddCommand(authAuditCmd)
+	authKeyCmd.AddCommand(authKeyCreateCmd)
+	authKeyCmd.AddCommand(authKeyListCmd)
+	authKeyCmd.AddCommand(authKeyRevokeCmd)
+
+	authKeyCreateCmd.Flags().StringVar(&authKeyName, "name", "", "키 이름 (예: dashboard, trading-bot)")
+	authKeyCreateCmd.Flags().StringVar(&authKeyRole, "role", string(auth.RoleReadOnly), "역할 (read_only, trader, admin)")
+	authKeyCreateCmd.MarkFlagRequired("name")
+
+	authAuditCmd.Flags().StringVar(&authAuditActor, "actor", "", "키 이름으로 필터")
+	authAuditCmd.Flags().StringVar(&authAuditSince, "since", "", "시작일 (YYYY-MM-DD)")
+	authAuditCmd.Flags().IntVar(&authAuditLimit, "limit", 50, "최대 건수")
+}
+
+func runAuthKeyCreate(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	plain, key, err := auth.NewRepository(db.Pool).CreateKey(cmd.Context(), authKeyName, auth.Role(authKeyRole))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ API key created: %s (role: %s, id: %d)\n", key.Name, key.Role, key.ID)
+	fmt.Println()
+	fmt.Printf("  %s\n", plain)
+	fmt.Println()
+	fmt.Println("⚠️  Store this key now; it cannot be shown again")
+	fmt.Println("   Use: Authorization: Bearer <key>  (or X-API-Key: <key>)")
+	return nil
+}
+
+func runAuthKeyList(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	keys, err := auth.NewRepository(db.Pool).ListKeys(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		fmt.Println("No API keys")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tROLE\tCREATED\tLAST USED\tSTATUS")
+	for _, k := range keys {
+		status := "active"
+		if k.RevokedAt != nil {
+			status = "revoked " + k.RevokedAt.Local().Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%d\t%s\taeg_%s\t%s\t%s\t%s\t%s\n",
+			k.ID, k.Name, k.Prefix, k.Role,
+			k.CreatedAt.Local().Format("2006-01-02 15:04"),
+			formatOptionalTime(k.LastUsedAt), status)
+	}
+	return w.Flush()
+}
+
+func runAuthKeyRevoke(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	revoked, err := auth.NewRepository(db.Pool).RevokeKey(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return fmt.Errorf("no active API key named %s", args[0])
+	}
+
+	fmt.Printf("🔒 API key %s revoked\n", args[0])
+	return nil
+}
+
+func runAuthAudit(cmd *cobra.Command, args []string) error {
+	filter := auth.AuditFilter{Actor: authAuditActor, Limit: authAuditLimit}
+	if authAuditSince != "" {
+		since, err := time.ParseInLocation("2006-01-02", authAuditSince, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date (expected YYYY-MM-DD): %w", err)
+		}
+		filter.Since = since
+	}
+
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	entries, err := auth.NewRepository(db.Pool).ListAudit(cmd.Context(), filter)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No audit entries")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tACTOR\tROLE\tMETHOD\tPATH\tSTATUS\tDURATION")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%dms\n",
+			e.OccurredAt.Local().Format("2006-01-02 15:04:05"),
+			e.Actor, e.Role, e.Method, e.Path, e.Status, e.DurationMs)
+	}
+	return w.Flush()
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -1,13 +1,18 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/wonny/aegis/v13/backend/internal/backtest"
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/database"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
@@ -43,11 +48,16 @@ Flags:
   --rebalance   리밸런싱 주기 (일, 기본: 7일)
   --commission  수수료율 (기본: 0.0015 = 0.15%)
   --slippage    슬리피지율 (기본: 0.001 = 0.1%)
+  --exits       ATR 청산 규칙(TP1~3/손절/StopFloor/HWM) 적용
+  --exit-config 청산 규칙 YAML (ExitRulesConfig 필드, 미지정 항목은 기본값)
+  --intrabar    봉 내부 경로 가정 (OLHC: 보수적, OHLC: 낙관적)
+  --minute-bars 저장된 1분봉(data.intraday_bars)이 있는 날은 분봉으로 청산 재현
 
 Example:
   go run ./cmd/quant backtest run --from 2023-01-01 --to 2023-12-31
   go run ./cmd/quant backtest run --capital 100000000 --rebalance 7
-  go run ./cmd/quant backtest run --from 2023-01-01 --commission 0.002`,
+  go run ./cmd/quant backtest run --from 2023-01-01 --commission 0.002
+  go run ./cmd/quant backtest run --from 2023-01-01 --exits --exit-config exit_b.yaml`,
 		RunE: runBacktest,
 	}
 
@@ -58,6 +68,11 @@ Example:
 	backtestRebalance  int
 	backtestCommission float64
 	backtestSlippage   float64
+	backtestExits      bool
+	backtestExitConfig string
+	backtestIntrabar   string
+	backtestMinuteBars bool
+	backtestRawPrices  bool
 )
 
 func init() {
@@ -71,6 +86,11 @@ func init() {
 	backtestRunCmd.Flags().IntVar(&backtestRebalance, "rebalance", 7, "리밸런싱 주기 (일)")
 	backtestRunCmd.Flags().Float64Var(&backtestCommission, "commission", 0.0015, "수수료율")
 	backtestRunCmd.Flags().Float64Var(&backtestSlippage, "slippage", 0.001, "슬리피지율")
+	backtestRunCmd.Flags().BoolVar(&backtestExits, "exits", false, "ATR 청산 규칙 적용")
+	backtestRunCmd.Flags().StringVar(&backtestExitConfig, "exit-config", "", "청산 규칙 YAML 경로 (--exits 포함)")
+	backtestRunCmd.Flags().StringVar(&backtestIntrabar, "intrabar", string(backtest.PathOLHC), "봉 내부 경로 가정 (OLHC|OHLC)")
+	backtestRunCmd.Flags().BoolVar(&backtestMinuteBars, "minute-bars", false, "저장된 1분봉으로 청산 재현 (없는 날은 일봉)")
+	backtestRunCmd.Flags().BoolVar(&backtestRawPrices, "raw-prices", false, "원주가로 체결/평가 (기본: 수정주가)")
 
 	backtestRunCmd.MarkFlagRequired("from")
 }
@@ -98,10 +118,19 @@ func runBacktest(cmd *cobra.Command, args []string) error {
 	fmt.Printf("💰 Initial Capital: %s원\n", formatNumber(backtestCapital))
 	fmt.Printf("🔄 Rebalance: %d days\n", backtestRebalance)
 	fmt.Printf("💸 Commission: %.2f%%\n", backtestCommission*100)
-	fmt.Printf("📉 Slippage: %.2f%%\n\n", backtestSlippage*100)
+	fmt.Printf("📉 Slippage: %.2f%%\n", backtestSlippage*100)
+
+	exitRules, err := loadBacktestExitRules()
+	if err != nil {
+		return err
+	}
+	if exitRules != nil {
+		fmt.Printf("🚪 Exit Rules: ON (intrabar %s)\n", strings.ToUpper(backtestIntrabar))
+	}
+	fmt.Println()
 
 	// Initialize dependencies
-	engine, err := initBacktestEngine()
+	engine, err := initBacktestEngine(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("init backtest engine: %w", err)
 	}
@@ -114,6 +143,8 @@ func runBacktest(cmd *cobra.Command, args []string) error {
 		RebalanceDays:  backtestRebalance,
 		Commission:     backtestCommission,
 		Slippage:       backtestSlippage,
+		ExitRules:      exitRules,
+		IntrabarPath:   backtest.IntrabarPath(strings.ToUpper(backtestIntrabar)),
 	}
 
 	fmt.Println("🚀 Starting backtest...")
@@ -131,7 +162,36 @@ func runBacktest(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func initBacktestEngine() (*backtest.Engine, error) {
+// loadBacktestExitRules builds exit rules from flags (nil = exits disabled)
+func loadBacktestExitRules() (*contracts.ExitRulesConfig, error) {
+	if !backtestExits && backtestExitConfig == "" {
+		return nil, nil
+	}
+
+	switch backtest.IntrabarPath(strings.ToUpper(backtestIntrabar)) {
+	case backtest.PathOLHC, backtest.PathOHLC:
+	default:
+		return nil, fmt.Errorf("invalid --intrabar: %s (OLHC|OHLC)", backtestIntrabar)
+	}
+
+	rules := contracts.DefaultExitRulesConfig()
+	if backtestExitConfig == "" {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(backtestExitConfig)
+	if err != nil {
+		return nil, fmt.Errorf("read exit config: %w", err)
+	}
+	// 기본값 위에 덮어쓰기 → 비교할 파라미터만 지정 가능
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("parse exit config: %w", err)
+	}
+
+	return rules, nil
+}
+
+func initBacktestEngine(ctx context.Context) (*backtest.Engine, error) {
 	// 1. Load config
 	cfg, err := config.Load()
 	if err != nil {
@@ -153,11 +213,23 @@ func initBacktestEngine() (*backtest.Engine, error) {
 		return nil, fmt.Errorf("init orchestrator: %w", err)
 	}
 
-	// 5. Create simulator
-	simulator := backtest.NewSimulator(db.Pool, log)
+	// 5. Create simulator (기본: 수정주가 → 분할/권리락 갭이 손익에 섞이지 않음)
+	priceBasis := contracts.PriceBasisAdjusted
+	if backtestRawPrices {
+		priceBasis = contracts.PriceBasisRaw
+	}
+	simulator := backtest.NewSimulator(db.Pool, priceBasis, log)
 
 	// 6. Create backtest engine
 	engine := backtest.NewEngine(orchestrator, simulator, log)
+	cal, err := calendar.NewRepository(db.Pool).Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load trading calendar: %w", err)
+	}
+	engine.SetTradingCalendar(cal)
+	if backtestMinuteBars {
+		engine.SetBarProviders(backtest.NewDBBarProvider(db.Pool, priceBasis), backtest.NewDBIntradayBarProvider(db.Pool, priceBasis))
+	}
 
 	return engine, nil
 }
@@ -229,6 +301,10 @@ func printBacktestResult(result *backtest.Result) {
 	fmt.Printf("Total Slippage:   %s원\n", formatNumber(result.TotalSlippage))
 	fmt.Println()
 
+	if result.ExitStats != nil {
+		printExitStats(result.ExitStats)
+	}
+
 	// Equity Curve (last 10 points)
 	fmt.Println("📈 Equity Curve (Last 10 Days)")
 	startIdx := len(result.EquityCurve) - 10
@@ -254,3 +330,27 @@ func printBacktestResult(result *backtest.Result) {
 	}
 	fmt.Println()
 }
+
+func printExitStats(stats []backtest.ExitReasonStats) {
+	fmt.Println("🚪 Exits by Reason")
+	if len(stats) == 0 {
+		fmt.Println("No exit rules triggered")
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("%-20s %6s %10s %6s %8s %9s %8s %16s\n",
+		"Reason", "Count", "Shares", "Gaps", "WinRate", "AvgRet", "AvgHold", "P&L")
+	for _, st := range stats {
+		fmt.Printf("%-20s %6d %10d %6d %7.1f%% %+8.2f%% %7.1fd %15s원\n",
+			st.Reason,
+			st.Count,
+			st.Shares,
+			st.GapCount,
+			float64(st.WinCount)/float64(st.Count)*100,
+			st.AvgReturnPct,
+			st.AvgHoldingDays,
+			formatNumber(st.RealizedPnL))
+	}
+	fmt.Println()
+}
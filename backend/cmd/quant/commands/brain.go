@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	"github.com/wonny/aegis/v13/backend/internal/s1_universe"
 	"github.com/wonny/aegis/v13/backend/internal/s2_signals"
 	"github.com/wonny/aegis/v13/backend/internal/selection"
+	"github.com/wonny/aegis/v13/backend/internal/strategyconfig"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/database"
 	"github.com/wonny/aegis/v13/backend/pkg/httputil"
@@ -185,6 +187,11 @@ func initOrchestrator() (*brain.Orchestrator, error) {
 
 	// 8. Create S1: Universe Builder
 	// Note: MinMarketCap은 억 단위, MinVolume은 백만 단위로 지정
+	// 전략 파일(config/strategy/*.yaml)이 정의하는 필터는 대표 전략 값으로 덮어씀
+	registry, err := loadStrategyRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
 	universeConfig := s1_universe.Config{
 		MinMarketCap:   0, // 임시로 0으로 설정 (market_cap 데이터 부족)
 		MinVolume:      0, // 임시로 0으로 설정
@@ -193,6 +200,7 @@ func initOrchestrator() (*brain.Orchestrator, error) {
 		ExcludeHalt:    true,
 		ExcludeSPAC:    true,
 	}
+	applyStrategyUniverse(&universeConfig, registry.Strategies()[0].Config)
 	universeBuilder := s1_universe.NewBuilder(db.Pool, universeConfig)
 
 	// 9. Create S2: Signal Builder
@@ -209,7 +217,7 @@ func initOrchestrator() (*brain.Orchestrator, error) {
 	eventCalc := s2_signals.NewEventCalculator(log)
 
 	// Create data repositories for signals
-	priceRepo := s0_data.NewPriceRepository(db.Pool)
+	priceRepo := s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisAdjusted) // 모멘텀/기술 지표는 수정주가
 	flowRepo := s0_data.NewInvestorFlowRepository(db.Pool)
 	financialRepo := s0_data.NewFinancialRepository(db.Pool)
 	disclosureRepo := s0_data.NewDisclosureRepository(db.Pool)
@@ -265,8 +273,17 @@ func initOrchestrator() (*brain.Orchestrator, error) {
 		SlippageBps:    10,                        // 0.1%
 		SplitThreshold: 50_000_000,
 		MaxOrderSize:   50_000_000,
+		BuyPriceRef:    execution.PriceRefASK1, // execution.limit_policy.buy (지정가 전환 시)
+		SellPriceRef:   execution.PriceRefBID1, // execution.limit_policy.sell
 	}
 	executionPlanner := execution.NewPlanner(nil, executionConfig, log) // nil broker for dry run
+	// ASK1/BID1 지정가: 실시간 호가 → 기록된 평균 스프레드 (장 마감 후 의사결정)
+	quotes := execution.NewSpreadQuoteProvider(nil, s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisRaw),
+		s0_data.NewSpreadRepository(db.Pool), universeConfig.SpreadLookbackDays, log)
+	executionPlanner.SetQuoteProvider(quotes)
+	// 킬 스위치/가격제한폭/금액 한도를 통과하지 못할 주문은 계획에서 제외
+	preTradeChecker := execution.NewPreTradeChecker(executionRepo, execution.DefaultPreTradeConfig(), log)
+	executionPlanner.SetPreTradeChecker(preTradeChecker)
 
 	// 14. Create S7: Performance Analyzer
 	performanceAnalyzer := audit.NewAnalyzer(auditRepo, log)
@@ -291,6 +308,12 @@ func initOrchestrator() (*brain.Orchestrator, error) {
 		log,
 	)
 
+	// 16. Strategies: config/strategy/*.yaml 전략별 S4~S6 (계좌 배분)
+	if err := strategyconfig.NewRepository(db.Pool).Sync(context.Background(), accountRecords(cfg), registry); err != nil {
+		return nil, fmt.Errorf("sync strategy registry: %w", err)
+	}
+	orchestrator.SetStrategies(buildStrategies(registry, preTradeChecker, quotes, log))
+
 	return orchestrator, nil
 }
 
@@ -334,13 +357,23 @@ func printRunResult(result *brain.RunResult) {
 			result.TargetPortfolio.Cash*100)
 	}
 	if result.ExecutionPlan != nil {
-		fmt.Printf("Execution: %d orders\n", len(result.ExecutionPlan.Orders))
+		fmt.Printf("Execution: %d orders (netted legs: %d)\n",
+			len(result.ExecutionPlan.Orders), len(result.ExecutionPlan.Netted))
+	}
+	for _, s := range result.Strategies {
+		positions := 0
+		if s.TargetPortfolio != nil {
+			positions = len(s.TargetPortfolio.Positions)
+		}
+		fmt.Printf("  Strategy %s [%s]: capital=%s원, %d positions, %d orders\n",
+			s.Binding.StrategyID, s.Binding.AccountID, formatNumber(s.Capital), positions, len(s.Orders))
 	}
-	if result.PerformanceReport != nil {
-		fmt.Printf("Performance: Return=%.2f%%, Sharpe=%.2f, MDD=%.2f%%\n",
-			result.PerformanceReport.TotalReturn*100,
-			result.PerformanceReport.Sharpe,
-			result.PerformanceReport.MaxDrawdown*100)
+	for _, report := range result.PerformanceReports {
+		fmt.Printf("Performance [%s]: Return=%.2f%%, Sharpe=%.2f, MDD=%.2f%%\n",
+			report.AccountID,
+			report.TotalReturn*100,
+			report.Sharpe,
+			report.MaxDrawdown*100)
 	}
 }
 
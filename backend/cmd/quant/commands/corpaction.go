@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/corpaction"
+)
+
+var (
+	// corpaction 플래그
+	corpActionCode string
+	corpActionFrom string
+	corpActionTo   string
+	corpActionFull bool
+)
+
+var corpActionCmd = &cobra.Command{
+	Use:   "corpaction",
+	Short: "기업행위 원장 및 수정주가 계수 관리",
+	Long: `액면분할/무상증자/유상증자/현금배당 원장을 관리하고 수정계수를 계산합니다.
+
+원장 입력 경로:
+  - DART 무상증자결정 공시 자동 수집 (sync-dart)
+  - 로컬 CSV 임포트 (import) — 분할/병합/유상증자/배당
+
+수정주가는 data.daily_prices_adjusted 뷰로 제공되며,
+원장 변경 후 rebuild로 계수를 갱신해야 반영됩니다.
+
+Example:
+  go run ./cmd/quant corpaction import actions.csv
+  go run ./cmd/quant corpaction sync-dart --from 2024-01-01 --to 2024-06-30
+  go run ./cmd/quant corpaction rebuild
+  go run ./cmd/quant corpaction list --code 005930`,
+}
+
+var corpActionImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "CSV 파일로 기업행위 등록",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCorpActionImport,
+}
+
+var corpActionSyncDARTCmd = &cobra.Command{
+	Use:   "sync-dart",
+	Short: "DART 공시로 기업행위 수집",
+	RunE:  runCorpActionSyncDART,
+}
+
+var corpActionRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "수정계수 계산",
+	RunE:  runCorpActionRebuild,
+}
+
+var corpActionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "기업행위 원장 조회",
+	RunE:  runCorpActionList,
+}
+
+func init() {
+	rootCmd.AddCommand(corpActionCmd)
+	corpActionCmd.AddCommand(corpActionImportCmd)
+	corpActionCmd.AddCommand(corpActionSyncDARTCmd)
+	corpActionCmd.AddCommand(corpActionRebuildCmd)
+	corpActionCmd.AddCommand(corpActionListCmd)
+
+	corpActionSyncDARTCmd.Flags().StringVar(&corpActionFrom, "from", "", "공시 시작일 (YYYY-MM-DD, 기본: 7일 전)")
+	corpActionSyncDARTCmd.Flags().StringVar(&corpActionTo, "to", "", "공시 종료일 (YYYY-MM-DD, 기본: 오늘)")
+	corpActionRebuildCmd.Flags().BoolVar(&corpActionFull, "full", false, "기존 계수 삭제 후 전체 재계산")
+	corpActionListCmd.Flags().StringVar(&corpActionCode, "code", "", "종목코드 (기본: 전체)")
+}
+
+func runCorpActionImport(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("open import file: %w", err)
+	}
+	defer f.Close()
+
+	actions, err := corpaction.ParseImport(f)
+	if err != nil {
+		return fmt.Errorf("parse import file: %w", err)
+	}
+
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := corpaction.NewRepository(db.Pool)
+	if err := repo.UpsertActions(cmd.Context(), actions); err != nil {
+		return err
+	}
+
+	result, err := corpaction.NewEngine(repo, log).Rebuild(cmd.Context(), false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Imported %d actions, %d factors computed, %d skipped\n",
+		len(actions), result.Computed, result.Skipped)
+	printRebuildErrors(result)
+	return nil
+}
+
+func runCorpActionSyncDART(cmd *cobra.Command, args []string) error {
+	to := time.Now()
+	from := to.AddDate(0, 0, -7)
+	if corpActionFrom != "" {
+		parsed, err := time.Parse("2006-01-02", corpActionFrom)
+		if err != nil {
+			return fmt.Errorf("invalid from date: %w", err)
+		}
+		from = parsed
+	}
+	if corpActionTo != "" {
+		parsed, err := time.Parse("2006-01-02", corpActionTo)
+		if err != nil {
+			return fmt.Errorf("invalid to date: %w", err)
+		}
+		to = parsed
+	}
+
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := corpaction.NewRepository(db.Pool)
+	sync := corpaction.NewDARTSync(dart.NewClient(cfg.DART.APIKey, log), repo, log)
+	result, err := sync.Sync(cmd.Context(), from, to)
+	if err != nil {
+		return err
+	}
+
+	rebuild, err := corpaction.NewEngine(repo, log).Rebuild(cmd.Context(), false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ DART sync: %d bonus issues imported, %d factors computed\n", result.Imported, rebuild.Computed)
+	if len(result.Manual) > 0 {
+		fmt.Printf("\n⚠️  %d disclosures need manual import:\n", len(result.Manual))
+		for _, m := range result.Manual {
+			fmt.Printf("  %s %-12s %s\n    %s\n", m.Code, m.CorpName, m.Title, m.URL)
+		}
+	}
+	printRebuildErrors(rebuild)
+	return nil
+}
+
+func runCorpActionRebuild(cmd *cobra.Command, args []string) error {
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	result, err := corpaction.NewEngine(corpaction.NewRepository(db.Pool), log).Rebuild(cmd.Context(), corpActionFull)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Adjustment factors: %d computed, %d skipped\n", result.Computed, result.Skipped)
+	printRebuildErrors(result)
+	return nil
+}
+
+func runCorpActionList(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	actions, err := corpaction.NewRepository(db.Pool).ListActions(cmd.Context(), corpActionCode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-8s %-10s %-14s %10s %10s %10s %-7s %s\n",
+		"Code", "ExDate", "Type", "Ratio", "SubPrice", "Dividend", "Source", "Memo")
+	for _, a := range actions {
+		fmt.Printf("%-8s %-10s %-14s %10.4f %10.0f %10.0f %-7s %s\n",
+			a.Code, a.ExDate.Format("2006-01-02"), a.Type,
+			a.Ratio, a.SubscriptionPrice, a.DividendAmount, a.Source, a.Memo)
+	}
+	fmt.Printf("\nTotal: %d actions\n", len(actions))
+	return nil
+}
+
+func printRebuildErrors(result *corpaction.RebuildResult) {
+	for _, e := range result.Errors {
+		fmt.Printf("  ⚠️  %s\n", e)
+	}
+}
@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/audit"
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/internal/notify"
+	"github.com/wonny/aegis/v13/backend/internal/portfolio"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/stockmaster"
+	"github.com/wonny/aegis/v13/backend/internal/selection"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/database"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
+)
+
+// exitPositionSyncInterval 보유 종목 → 청산 모니터 동기화 주기
+const exitPositionSyncInterval = 5 * time.Minute
+
+// kisPriceProvider adapts the KIS client to execution.PriceProvider (체결 끊김 시 폴링 fallback)
+type kisPriceProvider struct {
+	client *kis.Client
+}
+
+func (p kisPriceProvider) GetCurrentPrice(ctx context.Context, code string) (float64, error) {
+	price, err := p.client.GetCurrentPrice(ctx, code)
+	if err != nil {
+		return 0, err
+	}
+	return price.ClosePrice, nil
+}
+
+// kisOrderSubmitter adapts the KIS client to execution.OrderSubmitter (청산 시장가 매도)
+type kisOrderSubmitter struct {
+	client *kis.Client
+}
+
+func (s kisOrderSubmitter) SubmitOrder(ctx context.Context, order *contracts.Order) (*execution.OrderResult, error) {
+	side := kis.OrderSideSell
+	if order.Side == contracts.OrderSideBuy {
+		side = kis.OrderSideBuy
+	}
+
+	start := time.Now()
+	result, err := s.client.PlaceOrder(ctx, kis.PlaceOrderRequest{
+		StockCode: order.Code,
+		Side:      side,
+		Type:      kis.OrderTypeMarket,
+		Quantity:  int64(order.Qty),
+	})
+	switch {
+	case err != nil:
+		metrics.ObserveOrderSubmit(string(side), "error", start)
+		return nil, err
+	case !result.Success:
+		metrics.ObserveOrderSubmit(string(side), "rejected", start)
+		return nil, fmt.Errorf("order rejected: %s", result.Message)
+	}
+	metrics.ObserveOrderSubmit(string(side), "accepted", start)
+
+	return &execution.OrderResult{
+		OrderID:   result.OrderNo,
+		Status:    contracts.StatusSubmitted,
+		Message:   result.Message,
+		Timestamp: result.OrderTime,
+	}, nil
+}
+
+// startExitMonitor builds the exit monitor for broker holdings and feeds it realtime ticks
+// 청산 규칙은 대표(첫) 전략의 exit 섹션, 전략 설정이 없으면 기본값
+func startExitMonitor(
+	ctx context.Context,
+	cfg *config.Config,
+	db *database.DB,
+	kisClient *kis.Client,
+	kisWSClient *kis.WSClient,
+	priceCache *cache.PriceCache,
+	preTrade *execution.PreTradeChecker,
+	log *logger.Logger,
+) (*execution.PositionMonitor, error) {
+	rules := contracts.DefaultExitRulesConfig()
+	accountID := contracts.DefaultAccountID
+
+	registry, err := loadStrategyRegistry(cfg)
+	if err != nil {
+		log.WithError(err).Warn("Strategy registry unavailable, exit monitor uses default rules")
+	} else {
+		primary := registry.Strategies()[0]
+		primary.Config.Exit.ApplyTo(rules)
+		primary.Config.Exit.TimeEvent.ApplyTo(rules)
+		accountID = primary.Binding().AccountID
+	}
+
+	monitor := execution.NewPositionMonitor(
+		rules,
+		kisPriceProvider{client: kisClient},
+		execution.NewDBATRProvider(db.Pool, contracts.PriceBasisAdjusted),
+		db.Pool,
+		log,
+	)
+	monitor.SetPreTradeChecker(preTrade)
+	monitor.SetAccountID(accountID)
+	// 청산 신호 → KIS 시장가 매도 (autoSell)
+	monitor.SetOrderSubmitter(kisOrderSubmitter{client: kisClient})
+	// 청산 주문 기록 (exit_reason → 실현손익 원장 청산 사유)
+	monitor.SetOrderStore(execution.NewRepository(db.Pool))
+	// 청산 신호/청산 차단 알림은 outbox에 적재 (스케줄러/워커의 디스패처가 발송)
+	// 알림 초기화 실패로 청산 감시를 멈추지 않음
+	if notifier, err := notify.New(cfg, db.Pool, log); err != nil {
+		log.WithError(err).Warn("Notifier unavailable, exit signals will not be alerted")
+	} else {
+		monitor.SetNotifier(notifier)
+	}
+	// 시간/이벤트 청산: 최신 랭킹, DART 공시, 상장 상태
+	monitor.SetEventProviders(
+		selection.NewRepository(db.Pool),
+		s0_data.NewDisclosureRepository(db.Pool),
+		s0_data.NewRepository(db.Pool),
+	)
+	// 관리종목 지정: KRX 일별 상태 이력 (ADMIN_ISSUE)
+	monitor.SetStockFlagsProvider(stockmaster.NewRepository(db.Pool))
+	// 보유기간 거래일 산정: KRX 캘린더 (로드 실패 시 평일 기준)
+	if cal, err := calendar.NewRepository(db.Pool).Load(ctx); err != nil {
+		log.WithError(err).Warn("Trading calendar unavailable, holding days counted on weekdays")
+	} else {
+		monitor.SetTradingCalendar(cal)
+	}
+
+	// 실시간 체결 → 청산 평가 (가격 캐시 구독, 피드 goroutine 비차단)
+	monitor.SubscribeTicks(priceCache)
+
+	if err := monitor.Start(ctx); err != nil {
+		return nil, fmt.Errorf("start exit monitor: %w", err)
+	}
+
+	syncer := &exitPositionSyncer{
+		monitor:   monitor,
+		client:    kisClient,
+		ws:        kisWSClient,
+		toggles:   portfolio.NewRepository(db.Pool),
+		auditRepo: audit.NewRepository(db.Pool).ForAccount(accountID),
+		logger:    log,
+	}
+	go syncer.Run(ctx)
+
+	return monitor, nil
+}
+
+// exitMonitoringToggles provides the per-stock exit monitoring toggle (portfolio.exit_monitoring)
+type exitMonitoringToggles interface {
+	GetExitMonitoringAll(ctx context.Context) ([]portfolio.ExitMonitoringStatus, error)
+}
+
+// exitPositionSyncer keeps the monitor's positions in line with broker holdings
+// PATCH /trading/positions/{code}/exit-monitoring으로 끈 종목은 감시하지 않음
+type exitPositionSyncer struct {
+	monitor   *execution.PositionMonitor
+	client    *kis.Client
+	ws        *kis.WSClient // nil 가능 → 폴링 fallback만 사용
+	toggles   exitMonitoringToggles
+	auditRepo *audit.Repository
+	logger    *logger.Logger
+}
+
+// Run syncs holdings immediately and then every exitPositionSyncInterval
+func (s *exitPositionSyncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(exitPositionSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(ctx); err != nil {
+			s.logger.WithError(err).Warn("Failed to sync exit monitor positions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync adds newly held stocks and removes sold-out or disabled stocks (모니터 중인 포지션 상태는 유지)
+func (s *exitPositionSyncer) sync(ctx context.Context) error {
+	holdings, err := s.client.GetPositions(ctx)
+	if err != nil {
+		return fmt.Errorf("get positions: %w", err)
+	}
+
+	statuses, err := s.toggles.GetExitMonitoringAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get exit monitoring toggles: %w", err)
+	}
+	disabled := make(map[string]bool)
+	for _, st := range statuses {
+		if !st.Enabled {
+			disabled[st.StockCode] = true
+		}
+	}
+
+	monitored := make(map[string]bool)
+	for _, pos := range s.monitor.GetPositions() {
+		monitored[pos.Code] = true
+	}
+
+	held := make(map[string]bool, len(holdings))
+	var entryTimes map[string]time.Time
+	added := make([]string, 0)
+	today := startOfDay(time.Now())
+	for _, h := range holdings {
+		if h.Quantity <= 0 {
+			continue
+		}
+		held[h.StockCode] = true
+		if monitored[h.StockCode] || disabled[h.StockCode] {
+			continue
+		}
+		// 청산 주문 후 체결 대기 중인 종목은 재등록하지 않음 (같은 청산 반복 방지)
+		// 전일 이전 청산이 아직 보유 중이면 미체결로 보고 다시 감시
+		if since, ok := s.monitor.ExitPendingSince(h.StockCode); ok {
+			if !since.Before(today) {
+				continue
+			}
+			s.logger.WithField("code", h.StockCode).Warn("Exit order not filled by next session, monitoring again")
+			s.monitor.ClearExitPending(h.StockCode)
+		}
+
+		if entryTimes == nil {
+			entryTimes = s.entryTimes(ctx)
+		}
+		entryTime, ok := entryTimes[h.StockCode]
+		if !ok {
+			entryTime = time.Now()
+		}
+
+		pos := &contracts.MonitoredPosition{
+			ID:              fmt.Sprintf("%s_%s", h.StockCode, entryTime.Format("20060102")),
+			Code:            h.StockCode,
+			Name:            h.StockName,
+			EntryPrice:      h.AvgBuyPrice,
+			InitialQuantity: int(h.Quantity),
+			EntryTime:       entryTime,
+		}
+		if err := s.monitor.AddPosition(ctx, pos); err != nil {
+			return fmt.Errorf("add position %s: %w", h.StockCode, err)
+		}
+		added = append(added, h.StockCode)
+	}
+
+	for code := range monitored {
+		if !held[code] || disabled[code] {
+			s.monitor.RemovePosition(code)
+		}
+	}
+	for _, code := range s.monitor.ExitPendingCodes() {
+		if !held[code] {
+			s.monitor.ClearExitPending(code) // 매도 체결 확인
+		}
+	}
+
+	if len(added) > 0 && s.ws != nil {
+		if err := s.ws.Subscribe(added...); err != nil {
+			s.logger.WithError(err).Warn("Failed to subscribe held stocks to realtime feed")
+		}
+	}
+	return nil
+}
+
+// startOfDay returns local midnight of t
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// entryTimes returns the earliest open-lot entry time per stock from the trade ledger
+// 원장에 없는 종목(원장 이전 보유분)은 동기화 시각을 진입 시각으로 사용
+func (s *exitPositionSyncer) entryTimes(ctx context.Context) map[string]time.Time {
+	times := make(map[string]time.Time)
+
+	fills, err := s.auditRepo.GetFills(ctx)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to load fills for entry times")
+		return times
+	}
+
+	for _, lot := range audit.MatchFIFO(fills).Open {
+		if lot.AccountID != s.auditRepo.AccountID() {
+			continue
+		}
+		if t, ok := times[lot.Code]; !ok || lot.EntryTime.Before(t) {
+			times[lot.Code] = lot.EntryTime
+		}
+	}
+	return times
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/wonny/aegis/v13/backend/internal/external/krx"
 	"github.com/wonny/aegis/v13/backend/internal/external/naver"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/collector"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/database"
@@ -45,14 +46,21 @@ var fetcherCollectCmd = &cobra.Command{
 소스:
   kis    - 한국투자증권 API (시세, 체결)
   dart   - DART 공시 데이터
-  naver  - Naver Finance
+  naver  - Naver Finance (기본값)
   all    - 모든 소스
 
+가격/수급은 종목별 체크포인트 이후의 누락 거래일만 수집합니다 (증분).
+--backfill은 --from 이후 전체 이력의 갭(누락 거래일)을 탐지해 그 구간만 다시 받습니다.
+실패 구간은 data.collection_failures에 기록되어 다음 실행 때 백오프 후 재시도됩니다.
+재시도 한도를 넘은(dead) 구간은 증분 수집에서 제외되고, --backfill 실행 시 창 안의 구간만 다시 시도합니다.
+
 Example:
   go run ./cmd/quant fetcher collect kis
   go run ./cmd/quant fetcher collect dart
-  go run ./cmd/quant fetcher collect all`,
-	Args: cobra.ExactArgs(1),
+  go run ./cmd/quant fetcher collect all
+  go run ./cmd/quant fetcher collect --backfill --from 2024-01-01
+  go run ./cmd/quant fetcher collect naver --backfill --datasets prices --codes 005930,000660`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runFetcherCollect,
 }
 
@@ -72,7 +80,12 @@ Example:
 
 var (
 	// Fetcher flags
-	fetcherAsync bool
+	fetcherAsync    bool
+	fetcherBackfill bool
+	fetcherFrom     string
+	fetcherDatasets string
+	fetcherCodes    string
+	fetcherWorkers  int
 )
 
 func init() {
@@ -82,6 +95,11 @@ func init() {
 
 	// Flags
 	fetcherCollectCmd.Flags().BoolVar(&fetcherAsync, "async", false, "비동기 수집 (큐에 작업 추가)")
+	fetcherCollectCmd.Flags().BoolVar(&fetcherBackfill, "backfill", false, "전체 이력 갭 탐지 후 누락 구간만 수집")
+	fetcherCollectCmd.Flags().StringVar(&fetcherFrom, "from", "", "백필 시작일 (YYYY-MM-DD, 기본: 1년 전)")
+	fetcherCollectCmd.Flags().StringVar(&fetcherDatasets, "datasets", "prices,investor_flow", "대상 데이터셋 (콤마 구분)")
+	fetcherCollectCmd.Flags().StringVar(&fetcherCodes, "codes", "", "종목코드 (콤마 구분, 기본: 전체)")
+	fetcherCollectCmd.Flags().IntVar(&fetcherWorkers, "workers", 5, "동시 수집 작업자 수")
 }
 
 // runFetcherMarketCap collects market cap data from Naver Finance
@@ -105,11 +123,15 @@ func runFetcherMarketCap(cmd *cobra.Command, args []string) error {
 }
 
 func runFetcherCollect(cmd *cobra.Command, args []string) error {
-	source := args[0]
+	source := "naver"
+	if len(args) > 0 {
+		source = args[0]
+	}
 
 	fmt.Printf("=== Aegis v13 Data Fetcher ===\n\n")
 	fmt.Printf("Source: %s\n", source)
-	fmt.Printf("Mode: %s\n\n", getMode(fetcherAsync))
+	fmt.Printf("Mode: %s\n", getMode(fetcherAsync))
+	fmt.Printf("Plan: %s\n\n", getPlanMode(fetcherBackfill))
 
 	switch source {
 	case "kis":
@@ -127,6 +149,22 @@ func runFetcherCollect(cmd *cobra.Command, args []string) error {
 
 // initCollector initializes all dependencies and returns a collector
 func initCollector() (*collector.Collector, context.Context, error) {
+	deps, ctx, err := initCollectorDeps()
+	if err != nil {
+		return nil, ctx, err
+	}
+	return deps.collector, ctx, nil
+}
+
+// collectorDeps bundles the collector with its gap-aware planner
+type collectorDeps struct {
+	collector *collector.Collector
+	planner   *collector.Planner
+	calendar  *calendar.Syncer
+}
+
+// initCollectorDeps initializes the collector, planner and calendar syncer
+func initCollectorDeps() (*collectorDeps, context.Context, error) {
 	ctx := context.Background()
 
 	// 1. Load config
@@ -154,11 +192,17 @@ func initCollector() (*collector.Collector, context.Context, error) {
 
 	// 6. Create repository
 	repo := s0_data.NewRepository(db.Pool)
+	calendarRepo := calendar.NewRepository(db.Pool)
 
-	// 7. Create collector
+	// 7. Create collector + planner
 	col := collector.NewCollector(naverClient, dartClient, krxClient, repo, log)
+	planner := collector.NewPlanner(col, s0_data.NewCheckpointRepository(db.Pool), calendarRepo, log)
 
-	return col, ctx, nil
+	return &collectorDeps{
+		collector: col,
+		planner:   planner,
+		calendar:  calendar.NewSyncer(krxClient, calendarRepo, log),
+	}, ctx, nil
 }
 
 func collectKIS() error {
@@ -235,36 +279,20 @@ func collectNaver() error {
 	fmt.Println("🔍 Naver Finance 데이터 수집 시작...")
 	PrintSeparator()
 
-	// Initialize collector
-	col, ctx, err := initCollector()
+	deps, ctx, err := initCollectorDeps()
 	if err != nil {
 		return fmt.Errorf("init collector: %w", err)
 	}
 
-	// Date range (last 30 days)
-	to := time.Now()
-	from := to.AddDate(0, 0, -30)
-
-	fmt.Printf("\n기간: %s ~ %s\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
-	fmt.Printf("작업자 수: 5\n\n")
-
-	cfg := collector.Config{Workers: 5}
-
-	// 1. Fetch prices
-	fmt.Println("📊 가격 데이터 수집 중...")
-	if _, err := col.FetchAllPrices(ctx, from, to, cfg); err != nil {
-		return fmt.Errorf("fetch prices: %w", err)
-	}
-
-	// 2. Fetch investor flow
-	fmt.Println("📈 투자자 수급 데이터 수집 중...")
-	if _, err := col.FetchAllInvestorFlow(ctx, from, to, cfg); err != nil {
-		return fmt.Errorf("fetch investor flow: %w", err)
+	// 1-2. 가격/수급 (갭 수집)
+	fmt.Println("\n📊 가격/수급 데이터 갭 수집 중...")
+	if err := runPlannedCollection(ctx, deps); err != nil {
+		return err
 	}
 
 	// 3. Fetch market caps
 	fmt.Println("💰 시가총액 데이터 수집 중...")
-	if err := col.FetchMarketCaps(ctx); err != nil {
+	if err := deps.collector.FetchMarketCaps(ctx); err != nil {
 		return fmt.Errorf("fetch market caps: %w", err)
 	}
 
@@ -278,44 +306,31 @@ func collectAll() error {
 	fmt.Println("🚀 전체 소스 데이터 수집 시작...")
 	PrintSeparator()
 
-	// Initialize collector
-	col, ctx, err := initCollector()
+	deps, ctx, err := initCollectorDeps()
 	if err != nil {
 		return fmt.Errorf("init collector: %w", err)
 	}
-
-	// Date range (last 30 days)
-	to := time.Now()
-	from := to.AddDate(0, 0, -30)
-
-	fmt.Printf("\n기간: %s ~ %s\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
-	fmt.Printf("작업자 수: 5\n\n")
-
-	cfg := collector.Config{Workers: 5}
+	col := deps.collector
 
 	// 1. Naver Finance
-	fmt.Println("📊 [1/4] 가격 데이터 수집 중...")
-	if _, err := col.FetchAllPrices(ctx, from, to, cfg); err != nil {
-		fmt.Printf("⚠️  가격 수집 실패: %v\n", err)
+	fmt.Println("\n📊 [1/3] 가격/수급 데이터 갭 수집 중...")
+	if err := runPlannedCollection(ctx, deps); err != nil {
+		fmt.Printf("⚠️  가격/수급 수집 실패: %v\n", err)
 	}
 
-	fmt.Println("📈 [2/4] 투자자 수급 데이터 수집 중...")
-	if _, err := col.FetchAllInvestorFlow(ctx, from, to, cfg); err != nil {
-		fmt.Printf("⚠️  수급 수집 실패: %v\n", err)
-	}
-
-	fmt.Println("💰 [3/4] 시가총액 데이터 수집 중...")
+	fmt.Println("💰 [2/3] 시가총액 데이터 수집 중...")
 	if err := col.FetchMarketCaps(ctx); err != nil {
 		fmt.Printf("⚠️  시가총액 수집 실패: %v\n", err)
 	}
 
 	// 2. KRX Market Trends
-	fmt.Println("📉 [4/4] KRX 시장 지표 수집 중...")
+	fmt.Println("📉 [3/3] KRX 시장 지표 수집 중...")
 	if err := col.FetchMarketTrends(ctx); err != nil {
 		fmt.Printf("⚠️  시장 지표 수집 실패: %v\n", err)
 	}
 
 	// 3. DART Disclosures
+	to := time.Now()
 	dartFrom := to.AddDate(0, 0, -7)
 	fmt.Println("\n📄 [추가] DART 공시 데이터 수집 중...")
 	fmt.Printf("   기간: %s ~ %s\n", dartFrom.Format("2006-01-02"), to.Format("2006-01-02"))
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
+	"github.com/wonny/aegis/v13/backend/pkg/httputil"
+)
+
+var (
+	// calendar 플래그
+	calendarFrom string
+	calendarTo   string
+)
+
+var fetcherCalendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "KRX 거래일 캘린더 관리",
+	Long: `KRX 개장/휴장일 캘린더(data.trading_calendar)를 관리합니다.
+
+과거 구간은 KOSPI 지수 일별 시세로 판정하고, 미래 휴장일(설·추석·임시공휴일)은 holiday로 등록합니다.
+등록되지 않은 날짜는 주말·고정 공휴일 규칙으로 판정합니다.
+
+Example:
+  go run ./cmd/quant fetcher calendar sync --from 2022-01-01
+  go run ./cmd/quant fetcher calendar holiday 2027-02-08 설날
+  go run ./cmd/quant fetcher calendar list`,
+}
+
+var fetcherCalendarSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "KRX 지수 시세로 개장/휴장일 동기화",
+	RunE:  runFetcherCalendarSync,
+}
+
+var fetcherCalendarHolidayCmd = &cobra.Command{
+	Use:   "holiday DATE [NAME]",
+	Short: "휴장일 수동 등록",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE:  runFetcherCalendarHoliday,
+}
+
+var fetcherCalendarListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "등록된 휴장일 조회",
+	RunE:  runFetcherCalendarList,
+}
+
+func init() {
+	fetcherCmd.AddCommand(fetcherCalendarCmd)
+	fetcherCalendarCmd.AddCommand(fetcherCalendarSyncCmd)
+	fetcherCalendarCmd.AddCommand(fetcherCalendarHolidayCmd)
+	fetcherCalendarCmd.AddCommand(fetcherCalendarListCmd)
+
+	fetcherCalendarSyncCmd.Flags().StringVar(&calendarFrom, "from", "", "시작일 (YYYY-MM-DD, 기본: 1년 전)")
+	fetcherCalendarSyncCmd.Flags().StringVar(&calendarTo, "to", "", "종료일 (YYYY-MM-DD, 기본: 오늘)")
+}
+
+func runFetcherCalendarSync(cmd *cobra.Command, args []string) error {
+	to := time.Now()
+	if calendarTo != "" {
+		parsed, err := time.Parse("2006-01-02", calendarTo)
+		if err != nil {
+			return fmt.Errorf("invalid --to %q: %w", calendarTo, err)
+		}
+		to = parsed
+	}
+	from := to.AddDate(-1, 0, 0)
+	if calendarFrom != "" {
+		parsed, err := time.Parse("2006-01-02", calendarFrom)
+		if err != nil {
+			return fmt.Errorf("invalid --from %q: %w", calendarFrom, err)
+		}
+		from = parsed
+	}
+
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	krxClient := krx.NewClient(httputil.New(cfg, log), log)
+	syncer := calendar.NewSyncer(krxClient, calendar.NewRepository(db.Pool), log)
+
+	result, err := syncer.Sync(cmd.Context(), from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Trading calendar synced (%s ~ %s): %d open, %d closed\n",
+		from.Format("2006-01-02"), to.Format("2006-01-02"), result.Open, result.Closed)
+	return nil
+}
+
+func runFetcherCalendarHoliday(cmd *cobra.Command, args []string) error {
+	date, err := time.Parse("2006-01-02", args[0])
+	if err != nil {
+		return fmt.Errorf("invalid date %q: %w", args[0], err)
+	}
+	name := ""
+	if len(args) > 1 {
+		name = args[1]
+	}
+
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	day := calendar.Day{Date: date, IsOpen: false, Name: name, Source: calendar.SourceManual}
+	if err := calendar.NewRepository(db.Pool).SaveDays(cmd.Context(), []calendar.Day{day}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Holiday registered: %s %s\n", date.Format("2006-01-02"), name)
+	return nil
+}
+
+func runFetcherCalendarList(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	days, err := calendar.NewRepository(db.Pool).ListDays(cmd.Context(), true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-12s %-8s %s\n", "DATE", "SOURCE", "NAME")
+	for _, d := range days {
+		fmt.Printf("%-12s %-8s %s\n", d.Date.Format("2006-01-02"), d.Source, d.Name)
+	}
+	fmt.Printf("\n%d closed days registered\n", len(days))
+	return nil
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/financials"
+	"github.com/wonny/aegis/v13/backend/pkg/database"
+)
+
+var (
+	// financials 플래그
+	financialsFromYear int
+	financialsToYear   int
+	financialsCodes    string
+	financialsRefresh  bool
+	financialsDays     int
+)
+
+var fetcherFinancialsCmd = &cobra.Command{
+	Use:   "financials",
+	Short: "DART 정기보고서 재무제표 수집",
+	Long: `DART 정기보고서(분기/반기/사업보고서) 재무제표를 수집하고
+공시 접수일 기준 point-in-time fundamentals(ROE_TTM, OP_MARGIN_TTM, PER, PBR)를 산출합니다.
+
+연결재무제표를 우선 수집하며, 연결이 없는 회사는 별도재무제표를 사용합니다.
+최초 실행 전 corpcodes로 DART 고유번호를 동기화해야 합니다.
+
+Example:
+  go run ./cmd/quant fetcher financials corpcodes
+  go run ./cmd/quant fetcher financials backfill --from-year 2021 --to-year 2024
+  go run ./cmd/quant fetcher financials backfill --codes 005930,000660 --refresh
+  go run ./cmd/quant fetcher financials recent --days 7
+  go run ./cmd/quant fetcher financials recompute`,
+}
+
+var fetcherFinancialsCorpCodesCmd = &cobra.Command{
+	Use:   "corpcodes",
+	Short: "DART 고유번호(corp_code) 동기화",
+	RunE:  runFetcherFinancialsCorpCodes,
+}
+
+var fetcherFinancialsBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "연도 범위 재무제표 일괄 수집",
+	RunE:  runFetcherFinancialsBackfill,
+}
+
+var fetcherFinancialsRecentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "최근 제출된 정기보고서 수집",
+	RunE:  runFetcherFinancialsRecent,
+}
+
+var fetcherFinancialsRecomputeCmd = &cobra.Command{
+	Use:   "recompute",
+	Short: "저장된 재무제표로 fundamentals 재산출",
+	RunE:  runFetcherFinancialsRecompute,
+}
+
+func init() {
+	fetcherCmd.AddCommand(fetcherFinancialsCmd)
+	fetcherFinancialsCmd.AddCommand(fetcherFinancialsCorpCodesCmd)
+	fetcherFinancialsCmd.AddCommand(fetcherFinancialsBackfillCmd)
+	fetcherFinancialsCmd.AddCommand(fetcherFinancialsRecentCmd)
+	fetcherFinancialsCmd.AddCommand(fetcherFinancialsRecomputeCmd)
+
+	thisYear := time.Now().Year()
+	fetcherFinancialsBackfillCmd.Flags().IntVar(&financialsFromYear, "from-year", thisYear-2, "시작 사업연도")
+	fetcherFinancialsBackfillCmd.Flags().IntVar(&financialsToYear, "to-year", thisYear, "종료 사업연도")
+	fetcherFinancialsBackfillCmd.Flags().BoolVar(&financialsRefresh, "refresh", false, "저장된 분기도 다시 수집 (정정공시 반영)")
+	fetcherFinancialsRecentCmd.Flags().IntVar(&financialsDays, "days", 3, "최근 N일 제출분")
+	for _, c := range []*cobra.Command{fetcherFinancialsBackfillCmd, fetcherFinancialsRecomputeCmd} {
+		c.Flags().StringVar(&financialsCodes, "codes", "", "종목코드 (콤마 구분, 기본: 전체)")
+	}
+}
+
+func runFetcherFinancialsCorpCodes(cmd *cobra.Command, args []string) error {
+	col, db, err := initFinancialsCollector()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	updated, err := col.SyncCorpCodes(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ DART corp codes synced: %d stocks updated\n", updated)
+	return nil
+}
+
+func runFetcherFinancialsBackfill(cmd *cobra.Command, args []string) error {
+	if financialsFromYear > financialsToYear {
+		return fmt.Errorf("from-year %d is after to-year %d", financialsFromYear, financialsToYear)
+	}
+
+	col, db, err := initFinancialsCollector()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	result, err := col.CollectYears(cmd.Context(), parseFinancialsCodes(), financialsFromYear, financialsToYear, financialsRefresh)
+	if err != nil {
+		return err
+	}
+
+	printFinancialsResult(result)
+	return nil
+}
+
+func runFetcherFinancialsRecent(cmd *cobra.Command, args []string) error {
+	col, db, err := initFinancialsCollector()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	to := time.Now()
+	result, err := col.CollectFilings(cmd.Context(), to.AddDate(0, 0, -financialsDays), to)
+	if err != nil {
+		return err
+	}
+
+	printFinancialsResult(result)
+	return nil
+}
+
+func runFetcherFinancialsRecompute(cmd *cobra.Command, args []string) error {
+	col, db, err := initFinancialsCollector()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	result, err := col.RecomputeFundamentals(cmd.Context(), parseFinancialsCodes())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Fundamentals recomputed for %d stocks\n", result.Recomputed)
+	return nil
+}
+
+// initFinancialsCollector initializes the DART financial statement collector
+func initFinancialsCollector() (*financials.Collector, *database.DB, error) {
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dartClient := dart.NewClient(cfg.DART.APIKey, log)
+	return financials.NewCollector(dartClient, financials.NewRepository(db.Pool), log), db, nil
+}
+
+func parseFinancialsCodes() []string {
+	if financialsCodes == "" {
+		return nil
+	}
+	codes := make([]string, 0)
+	for _, c := range strings.Split(financialsCodes, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}
+
+func printFinancialsResult(result *financials.CollectResult) {
+	fmt.Printf("✅ Financial statements: %d requests, %d saved, %d without data, %d stocks recomputed\n",
+		result.Requested, result.Saved, result.NoData, result.Recomputed)
+	for _, e := range result.Errors {
+		fmt.Printf("  ⚠️  %s\n", e)
+	}
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/s0_data"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/collector"
+)
+
+var (
+	// failures 플래그
+	failuresDataset   string
+	failuresStatus    string
+	failuresResetDead bool
+)
+
+var fetcherFailuresCmd = &cobra.Command{
+	Use:   "failures",
+	Short: "수집 실패/미수집 구간 조회",
+	Long: `data.collection_failures에 기록된 재시도 대기(pending) 및 포기(dead) 구간을 조회합니다.
+
+dead 구간은 증분/백필 계획에서 제외됩니다. --reset-dead로 다시 대기열에 넣을 수 있습니다.
+
+Example:
+  go run ./cmd/quant fetcher failures
+  go run ./cmd/quant fetcher failures --dataset prices --status dead
+  go run ./cmd/quant fetcher failures --reset-dead`,
+	RunE: runFetcherFailures,
+}
+
+func init() {
+	fetcherCmd.AddCommand(fetcherFailuresCmd)
+
+	fetcherFailuresCmd.Flags().StringVar(&failuresDataset, "dataset", "", "데이터셋 (prices, investor_flow)")
+	fetcherFailuresCmd.Flags().StringVar(&failuresStatus, "status", "", "상태 (pending, dead)")
+	fetcherFailuresCmd.Flags().BoolVar(&failuresResetDead, "reset-dead", false, "dead 구간을 pending으로 되돌림")
+}
+
+// runPlannedCollection runs the gap-aware planner with the collect flags
+func runPlannedCollection(ctx context.Context, deps *collectorDeps) error {
+	opts := collector.PlanOptions{
+		Backfill:  fetcherBackfill,
+		RetryDead: fetcherBackfill, // 백필은 수동 갭 복구: dead 구간도 재시도
+		Codes:     splitCSV(fetcherCodes),
+		Workers:   fetcherWorkers,
+		To:        time.Now(),
+	}
+	for _, ds := range splitCSV(fetcherDatasets) {
+		switch collector.Dataset(ds) {
+		case collector.DatasetPrices, collector.DatasetInvestorFlow:
+			opts.Datasets = append(opts.Datasets, collector.Dataset(ds))
+		default:
+			return fmt.Errorf("unknown dataset: %s (valid: prices, investor_flow)", ds)
+		}
+	}
+
+	// 캘린더: 백필은 전체 구간, 증분은 최근 구간만 동기화 (실패 시 주말·고정 공휴일 규칙으로 진행)
+	calFrom := opts.To.AddDate(0, 0, -45)
+	if opts.Backfill {
+		opts.From = opts.To.AddDate(-1, 0, 0)
+		if fetcherFrom != "" {
+			from, err := time.Parse("2006-01-02", fetcherFrom)
+			if err != nil {
+				return fmt.Errorf("invalid --from %q: %w", fetcherFrom, err)
+			}
+			opts.From = from
+		}
+		calFrom = opts.From
+	}
+	if _, err := deps.calendar.Sync(ctx, calFrom, opts.To); err != nil {
+		fmt.Printf("⚠️  거래일 캘린더 동기화 실패 (기본 규칙 사용): %v\n", err)
+	}
+
+	results, err := deps.planner.Run(ctx, opts)
+	for _, r := range results {
+		fmt.Printf("  [%s] 대상 %d, 최신 %d, 수집 %d, 일부 %d, 실패 %d (누락 %d일, 저장 %d행)\n",
+			r.Dataset, r.Stocks, r.UpToDate, r.Fetched, r.Partial, r.Failed, r.MissingDays, r.Rows)
+	}
+	if err != nil {
+		return fmt.Errorf("planned collection: %w", err)
+	}
+	return nil
+}
+
+func runFetcherFailures(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := s0_data.NewCheckpointRepository(db.Pool)
+
+	if failuresResetDead {
+		n, err := repo.ResetDeadFailures(cmd.Context(), failuresDataset)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %d dead ranges re-queued\n", n)
+		return nil
+	}
+
+	failures, err := repo.ListFailures(cmd.Context(), failuresDataset, failuresStatus)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		fmt.Println("✅ No collection failures")
+		return nil
+	}
+
+	fmt.Printf("%-14s %-8s %-10s %-10s %-8s %-4s %-16s %s\n", "DATASET", "CODE", "FROM", "TO", "STATUS", "TRY", "NEXT RETRY", "ERROR")
+	for _, f := range failures {
+		fmt.Printf("%-14s %-8s %-10s %-10s %-8s %-4d %-16s %s\n",
+			f.Dataset, f.StockCode, f.From.Format("2006-01-02"), f.To.Format("2006-01-02"),
+			f.Status, f.Attempts, f.NextRetryAt.Local().Format("01-02 15:04"), f.LastError)
+	}
+	return nil
+}
+
+func getPlanMode(backfill bool) string {
+	if backfill {
+		return "Backfill (gap repair)"
+	}
+	return "Incremental (since checkpoint)"
+}
+
+func splitCSV(s string) []string {
+	out := make([]string, 0)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
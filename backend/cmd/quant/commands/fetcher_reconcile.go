@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+	"github.com/wonny/aegis/v13/backend/internal/external/naver"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/reconcile"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/httputil"
+)
+
+var (
+	// reconcile 플래그
+	reconcileDate     string
+	reconcileSample   int
+	reconcileCodes    string
+	reconcilePriority string
+	reconcileApply    bool
+	reconcileWithKIS  bool
+	reconcileField    string
+	reconcileLimit    int
+)
+
+var fetcherReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Naver/KIS/KRX 교차 소스 가격·시총 대사",
+	Long: `저장된 일봉(data.daily_prices)과 시가총액(data.market_cap)을 Naver, KIS, KRX와 비교합니다.
+
+KRX 전종목 시세는 1회 호출로 전 종목을 비교하고, Naver/KIS는 종목별 호출이라 --sample 종목만 비교합니다.
+허용오차를 넘는 필드는 audit.source_discrepancies에 기록되며, 승자는 --priority 순서로 정합니다.
+--apply 지정 시 승자와 다른 저장값을 승자 값으로 교정합니다.
+
+Example:
+  go run ./cmd/quant fetcher reconcile
+  go run ./cmd/quant fetcher reconcile --date 2026-10-16 --sample 0 --kis
+  go run ./cmd/quant fetcher reconcile --codes 005930,000660 --priority KRX,NAVER --apply
+  go run ./cmd/quant fetcher reconcile report --date 2026-10-16 --field close`,
+	RunE: runFetcherReconcile,
+}
+
+var fetcherReconcileReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "대사 결과 리포트 (필드별/종목별 불일치)",
+	RunE:  runFetcherReconcileReport,
+}
+
+func init() {
+	fetcherCmd.AddCommand(fetcherReconcileCmd)
+	fetcherReconcileCmd.AddCommand(fetcherReconcileReportCmd)
+
+	fetcherReconcileCmd.PersistentFlags().StringVar(&reconcileDate, "date", "", "대사 기준일 (YYYY-MM-DD, 기본: 마지막 마감 거래일)")
+	fetcherReconcileCmd.Flags().IntVar(&reconcileSample, "sample", reconcile.DefaultConfig().SampleSize, "Naver/KIS 표본 종목 수 (0 = 전체)")
+	fetcherReconcileCmd.Flags().StringVar(&reconcileCodes, "codes", "", "대상 종목 (쉼표 구분, 지정 시 표본 없이 전체 비교)")
+	fetcherReconcileCmd.Flags().StringVar(&reconcilePriority, "priority", "KRX,KIS,NAVER", "승자 선택 소스 우선순위")
+	fetcherReconcileCmd.Flags().BoolVar(&reconcileApply, "apply", false, "저장값을 승자 값으로 교정")
+	fetcherReconcileCmd.Flags().BoolVar(&reconcileWithKIS, "kis", false, "KIS 일별 시세 비교 포함")
+
+	fetcherReconcileReportCmd.Flags().StringVar(&reconcileField, "field", "", "필드 필터 (open, high, low, close, volume, market_cap)")
+	fetcherReconcileCmd.PersistentFlags().IntVar(&reconcileLimit, "limit", 30, "종목별 출력 건수")
+}
+
+func runFetcherReconcile(cmd *cobra.Command, args []string) error {
+	priority, err := reconcile.ParsePriority(reconcilePriority)
+	if err != nil {
+		return err
+	}
+
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	date, err := getReconcileDate(cmd.Context(), db.Pool)
+	if err != nil {
+		return err
+	}
+
+	httpClient := httputil.New(cfg, log)
+	var kisClient *kis.Client
+	if reconcileWithKIS {
+		if cfg.KIS.AppKey == "" {
+			return fmt.Errorf("--kis requires KIS_APP_KEY")
+		}
+		kisClient = newKISClient(cfg, config.KISProcessCLI, httpClient, db.Pool, log)
+	}
+
+	repo := reconcile.NewRepository(db.Pool)
+	reconciler := reconcile.NewReconciler(
+		naver.NewClient(httpClient, log), kisClient, krx.NewClient(httpClient, log), repo, log,
+	)
+
+	rcfg := reconcile.DefaultConfig()
+	rcfg.Priority = priority
+	rcfg.SampleSize = reconcileSample
+
+	run, discrepancies, err := reconciler.Run(cmd.Context(), reconcile.Options{
+		Date:   date,
+		Codes:  splitCSV(reconcileCodes),
+		Config: rcfg,
+		Apply:  reconcileApply,
+	})
+	if err != nil {
+		return err
+	}
+
+	printReconcileReport(run, discrepancies, rcfg, reconcileLimit)
+	return nil
+}
+
+func runFetcherReconcileReport(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	date, err := getReconcileDate(cmd.Context(), db.Pool)
+	if err != nil {
+		return err
+	}
+
+	repo := reconcile.NewRepository(db.Pool)
+	run, err := repo.GetRun(cmd.Context(), date)
+	if err != nil {
+		return err
+	}
+	if run == nil {
+		return fmt.Errorf("no reconciliation run on %s", date.Format("2006-01-02"))
+	}
+
+	discrepancies, err := repo.ListDiscrepancies(cmd.Context(), date, reconcileField)
+	if err != nil {
+		return err
+	}
+
+	rcfg := reconcile.DefaultConfig()
+	rcfg.Priority = run.Priority
+	printReconcileReport(run, discrepancies, rcfg, reconcileLimit)
+	return nil
+}
+
+// printReconcileReport prints per-field counts and the worst stocks
+func printReconcileReport(run *reconcile.Run, discrepancies []reconcile.Discrepancy, cfg reconcile.Config, limit int) {
+	fmt.Printf("📊 Source reconciliation %s\n", run.Date.Format("2006-01-02"))
+	fmt.Printf("  Sources: %s (priority %s)\n", joinSources(run.Sources), joinSources(run.Priority))
+	fmt.Printf("  Compared %d stocks (sampled %d), %d discrepancies, %d stored mismatches, %d applied\n\n",
+		run.StocksCompared, run.SampledStocks, run.Discrepancies, run.StoredMismatches, run.Applied)
+
+	if len(discrepancies) == 0 {
+		fmt.Println("✅ All sources agree within tolerance")
+		return
+	}
+
+	fmt.Printf("%-12s %-8s %-8s %s\n", "FIELD", "STOCKS", "STORED", "DISAGREEING SOURCES")
+	for _, s := range reconcile.Summarize(discrepancies, cfg) {
+		parts := make([]string, 0, len(s.BySource))
+		for src, n := range s.BySource {
+			parts = append(parts, fmt.Sprintf("%s=%d", src, n))
+		}
+		sort.Strings(parts)
+		fmt.Printf("%-12s %-8d %-8d %s\n", s.Field, s.Stocks, s.StoredMismatch, strings.Join(parts, " "))
+	}
+
+	sorted := append([]reconcile.Discrepancy(nil), discrepancies...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].MaxDiffPct > sorted[j].MaxDiffPct })
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	fmt.Printf("\n%-8s %-12s %-8s %-16s %-9s %s\n", "CODE", "FIELD", "WINNER", "WINNER VALUE", "MAX DIFF", "VALUES")
+	for _, d := range sorted {
+		srcs := make([]string, 0, len(d.Values))
+		for src := range d.Values {
+			srcs = append(srcs, string(src))
+		}
+		sort.Strings(srcs)
+		parts := make([]string, 0, len(srcs))
+		for _, src := range srcs {
+			parts = append(parts, fmt.Sprintf("%s=%.0f", src, d.Values[reconcile.Source(src)]))
+		}
+		mark := ""
+		if d.Applied {
+			mark = " (applied)"
+		}
+		fmt.Printf("%-8s %-12s %-8s %-16.0f %-8.2f%% %s%s\n",
+			d.Code, d.Field, d.Winner, d.WinnerValue, d.MaxDiffPct*100, strings.Join(parts, " "), mark)
+	}
+}
+
+// getReconcileDate parses --date, defaulting to the last closed KRX session
+func getReconcileDate(ctx context.Context, pool *pgxpool.Pool) (time.Time, error) {
+	if reconcileDate != "" {
+		date, err := time.Parse("2006-01-02", reconcileDate)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --date %q: %w", reconcileDate, err)
+		}
+		return date, nil
+	}
+
+	cal, err := calendar.NewRepository(pool).Load(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cal.LastClosedSession(time.Now()), nil
+}
+
+func joinSources(sources []reconcile.Source) string {
+	parts := make([]string, len(sources))
+	for i, s := range sources {
+		parts[i] = string(s)
+	}
+	return strings.Join(parts, ",")
+}
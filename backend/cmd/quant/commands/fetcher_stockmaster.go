@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/stockmaster"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/database"
+	"github.com/wonny/aegis/v13/backend/pkg/httputil"
+)
+
+var (
+	// stockmaster 플래그
+	stockMasterDate       string
+	stockMasterWithKIS    bool
+	stockMasterSkipMaster bool
+)
+
+var fetcherStockMasterCmd = &cobra.Command{
+	Use:   "stockmaster",
+	Short: "KRX 종목 마스터 및 시장조치 플래그 수집",
+	Long: `KRX 전종목 기본정보(상장일, 시장, 업종, 소속부)로 data.stocks를 동기화하고
+기준일의 시장조치 플래그(거래정지, 관리종목, 투자주의환기 등)를 data.stock_status_history에 저장합니다.
+
+마스터에서 사라진 종목은 상장폐지(delisted)로 처리됩니다.
+--kis 지정 시 종목별 KIS 현재가 조회로 투자주의/경고/위험 지정까지 보강합니다.
+
+Example:
+  go run ./cmd/quant fetcher stockmaster
+  go run ./cmd/quant fetcher stockmaster --date 2026-10-16 --kis
+  go run ./cmd/quant fetcher stockmaster --statuses-only`,
+	RunE: runFetcherStockMaster,
+}
+
+func init() {
+	fetcherCmd.AddCommand(fetcherStockMasterCmd)
+
+	fetcherStockMasterCmd.Flags().StringVar(&stockMasterDate, "date", "", "기준일 (YYYY-MM-DD, 기본: 오늘)")
+	fetcherStockMasterCmd.Flags().BoolVar(&stockMasterWithKIS, "kis", false, "KIS 현재가 조회로 투자경고/위험 플래그 보강")
+	fetcherStockMasterCmd.Flags().BoolVar(&stockMasterSkipMaster, "statuses-only", false, "마스터 동기화 생략, 플래그만 수집")
+}
+
+func runFetcherStockMaster(cmd *cobra.Command, args []string) error {
+	date := time.Now()
+	if stockMasterDate != "" {
+		parsed, err := time.Parse("2006-01-02", stockMasterDate)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", stockMasterDate, err)
+		}
+		date = parsed
+	}
+
+	col, db, err := initStockMasterCollector(stockMasterWithKIS)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if !stockMasterSkipMaster {
+		master, err := col.SyncMaster(cmd.Context(), date)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Stock master synced: %d listed, %d delisted\n", master.Listed, master.Delisted)
+	}
+
+	result, err := col.CollectStatuses(cmd.Context(), date, stockMasterWithKIS)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Stock statuses (%s): %d stocks\n", date.Format("2006-01-02"), result.Stocks)
+	flags := make([]string, 0, len(result.Flagged))
+	for f := range result.Flagged {
+		flags = append(flags, f)
+	}
+	sort.Strings(flags)
+	for _, f := range flags {
+		fmt.Printf("  %-20s %d\n", f, result.Flagged[f])
+	}
+	for _, e := range result.Errors {
+		fmt.Printf("  ⚠️  %s\n", e)
+	}
+	return nil
+}
+
+// initStockMasterCollector initializes the KRX stock master collector
+func initStockMasterCollector(withKIS bool) (*stockmaster.Collector, *database.DB, error) {
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpClient := httputil.New(cfg, log)
+	krxClient := krx.NewClient(httpClient, log)
+
+	var kisClient *kis.Client
+	if withKIS {
+		if cfg.KIS.AppKey == "" {
+			db.Close()
+			return nil, nil, fmt.Errorf("--kis requires KIS_APP_KEY")
+		}
+		kisClient = newKISClient(cfg, config.KISProcessCLI, httpClient, db.Pool, log)
+	}
+
+	return stockmaster.NewCollector(krxClient, kisClient, stockmaster.NewRepository(db.Pool), log), db, nil
+}
@@ -198,7 +198,7 @@ func runForecastDetect(cmd *cobra.Command, args []string) error {
 
 	// 저장소
 	forecastRepo := forecast.NewRepository(db.Pool)
-	priceRepo := s0_data.NewPriceRepository(db.Pool)
+	priceRepo := s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisAdjusted)
 
 	// 감지기
 	detector := forecast.NewDetector(log)
@@ -278,7 +278,7 @@ func runForecastFillForward(cmd *cobra.Command, args []string) error {
 
 	// 저장소
 	forecastRepo := forecast.NewRepository(db.Pool)
-	priceRepo := s0_data.NewPriceRepository(db.Pool)
+	priceRepo := s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisAdjusted)
 
 	// 추적기
 	tracker := forecast.NewTracker(log)
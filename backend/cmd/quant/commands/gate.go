@@ -8,7 +8,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
 	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/internal/notify"
 	"github.com/wonny/aegis/v13/backend/internal/risk"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
@@ -130,7 +132,6 @@ func runGateTest(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	defer db.Close()
-	_ = cfg
 
 	// 샘플 포트폴리오
 	fmt.Println("\n🧪 Using sample portfolio for test")
@@ -158,7 +159,7 @@ func runGateTest(cmd *cobra.Command, args []string) error {
 	engine := risk.NewEngine(risk.DefaultRiskLimits(), risk.DefaultMonteCarloConfig(), log)
 
 	// 가격 데이터 어댑터 생성
-	priceRepo := s0_data.NewPriceRepository(db.Pool)
+	priceRepo := s0_data.NewPriceRepository(db.Pool, contracts.PriceBasisAdjusted)
 	priceAdapter := &priceRepoAdapter{repo: priceRepo}
 
 	// 게이트 생성
@@ -169,6 +170,13 @@ func runGateTest(cmd *cobra.Command, args []string) error {
 	}
 	gate := execution.NewRiskGate(engine, execRepo, priceAdapter, log, gateConfig)
 
+	// 차단 알림은 outbox에 적재 (스케줄러/워커의 디스패처가 발송)
+	notifier, err := notify.New(cfg, db.Pool, log)
+	if err != nil {
+		return fmt.Errorf("init notifier: %w", err)
+	}
+	gate.SetNotifier(notifier)
+
 	// 게이트 체크 실행
 	fmt.Println("\n🔍 Running gate check...")
 	input := execution.GateCheckInput{
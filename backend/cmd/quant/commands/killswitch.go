@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/httputil"
+)
+
+// killSwitchCmd represents the killswitch command
+var killSwitchCmd = &cobra.Command{
+	Use:   "killswitch",
+	Short: "전역 킬 스위치 (모든 신규 주문 차단)",
+	Long: `전역 킬 스위치를 발동/해제합니다.
+
+발동 중에는 수동 주문(API), S6 Planner, 청산 모니터의 모든 신규 주문이
+pre-trade check에서 차단됩니다. 상태는 execution.kill_switch에 저장되어
+API 서버/스케줄러 등 모든 프로세스에 즉시 적용됩니다.
+
+Subcommands:
+  on      - 킬 스위치 발동 (--cancel-open: 미체결 주문 일괄 취소)
+  off     - 킬 스위치 해제
+  status  - 현재 상태와 최근 변경 이력
+
+Example:
+  go run ./cmd/quant killswitch on --reason "broker outage" --cancel-open
+  go run ./cmd/quant killswitch off --reason "resolved"
+  go run ./cmd/quant killswitch status`,
+}
+
+var (
+	killSwitchOnCmd = &cobra.Command{
+		Use:   "on",
+		Short: "킬 스위치 발동",
+		RunE:  runKillSwitchOn,
+	}
+
+	killSwitchOffCmd = &cobra.Command{
+		Use:   "off",
+		Short: "킬 스위치 해제",
+		RunE:  runKillSwitchOff,
+	}
+
+	killSwitchStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "킬 스위치 상태 조회",
+		RunE:  runKillSwitchStatus,
+	}
+)
+
+var (
+	// killswitch 플래그
+	killSwitchReason     string
+	killSwitchCancelOpen bool
+)
+
+func init() {
+	rootCmd.AddCommand(killSwitchCmd)
+	killSwitchCmd.AddCommand(killSwitchOnCmd)
+	killSwitchCmd.AddCommand(killSwitchOffCmd)
+	killSwitchCmd.AddCommand(killSwitchStatusCmd)
+
+	killSwitchOnCmd.Flags().StringVar(&killSwitchReason, "reason", "", "발동 사유")
+	killSwitchOnCmd.Flags().BoolVar(&killSwitchCancelOpen, "cancel-open", false, "미체결 주문 일괄 취소 (KIS)")
+	killSwitchOnCmd.MarkFlagRequired("reason")
+
+	killSwitchOffCmd.Flags().StringVar(&killSwitchReason, "reason", "", "해제 사유")
+	killSwitchOffCmd.MarkFlagRequired("reason")
+}
+
+func runKillSwitchOn(cmd *cobra.Command, args []string) error {
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var canceller execution.OpenOrderCanceller
+	if killSwitchCancelOpen {
+		if cfg.KIS.AppKey == "" {
+			return fmt.Errorf("--cancel-open requires KIS_APP_KEY")
+		}
+		canceller = newKISClient(cfg, config.KISProcessCLI, httputil.New(cfg, log), db.Pool, log)
+	}
+
+	result, err := execution.ActivateKillSwitch(cmd.Context(), execution.NewRepository(db.Pool), canceller, killSwitchReason, cliRequester(), log)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🛑 Kill switch ACTIVE: all new orders are blocked")
+	if killSwitchCancelOpen {
+		fmt.Printf("   Cancelled open orders: %d\n", len(result.CancelledOrders))
+		for _, orderNo := range result.CancelledOrders {
+			fmt.Printf("   - %s\n", orderNo)
+		}
+		if result.CancelError != "" {
+			fmt.Printf("⚠️  Some orders could not be cancelled: %s\n", result.CancelError)
+		}
+	}
+	return nil
+}
+
+func runKillSwitchOff(cmd *cobra.Command, args []string) error {
+	_, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := execution.DeactivateKillSwitch(cmd.Context(), execution.NewRepository(db.Pool), killSwitchReason, cliRequester(), log); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Kill switch released: new orders allowed (pre-trade checks still apply)")
+	return nil
+}
+
+func runKillSwitchStatus(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := execution.NewRepository(db.Pool)
+	ks, err := repo.GetKillSwitch(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if ks.Active {
+		fmt.Printf("🛑 ACTIVE since %s by %s: %s\n", ks.UpdatedAt.Local().Format("2006-01-02 15:04:05"), ks.UpdatedBy, ks.Reason)
+	} else {
+		fmt.Println("✅ Inactive (orders allowed)")
+	}
+
+	events, err := repo.ListKillSwitchEvents(cmd.Context(), 10)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	fmt.Println("\nRecent changes:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tSTATE\tBY\tCANCELLED\tREASON")
+	for _, e := range events {
+		state := "off"
+		if e.Active {
+			state = "ON"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+			e.CreatedAt.Local().Format("2006-01-02 15:04:05"), state, e.ChangedBy, e.CancelledOrders, e.Reason)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/httputil"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// kisCmd represents the kis command
+var kisCmd = &cobra.Command{
+	Use:   "kis",
+	Short: "KIS 세션(토큰/요청 예산) 관리",
+	Long: `KIS 접근토큰/웹소켓 접속키와 요청 예산을 조회합니다.
+
+토큰과 접속키는 ops.kis_credentials에 저장되어 api/scheduler/worker 프로세스와
+재시작 간에 재사용되고, 만료 전(KIS_TOKEN_REFRESH_BEFORE)에 선제 갱신됩니다.
+
+Subcommands:
+  status  - 저장된 자격 증명 만료/발급 횟수, 요청 예산
+
+Example:
+  go run ./cmd/quant kis status`,
+}
+
+var kisStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "KIS 세션 상태",
+	RunE:  runKISStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(kisCmd)
+	kisCmd.AddCommand(kisStatusCmd)
+}
+
+// newKISClient creates a KIS client whose session is persisted in ops.kis_credentials
+// 요청 예산은 프로세스별 몫 (config.KISProcess*)
+func newKISClient(cfg *config.Config, process string, httpClient *httputil.Client, pool *pgxpool.Pool, log *logger.Logger) *kis.Client {
+	kisCfg := cfg.KIS.ForProcess(process)
+	client := kis.NewClient(kisCfg, httpClient, log)
+	client.SetSession(kis.NewSession(kisCfg, kis.NewCredentialRepository(pool), log))
+	return client
+}
+
+// newKISAccountClient creates a KIS client bound to a configured account (KIS_ACCOUNTS)
+func newKISAccountClient(cfg *config.Config, process, accountID string, httpClient *httputil.Client, pool *pgxpool.Pool, log *logger.Logger) (*kis.Client, error) {
+	kisCfg, err := cfg.KIS.ForAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+	accountCfg := *cfg
+	accountCfg.KIS = kisCfg
+	return newKISClient(&accountCfg, process, httpClient, pool, log), nil
+}
+
+func runKISStatus(cmd *cobra.Command, args []string) error {
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if cfg.KIS.AppKey == "" {
+		return fmt.Errorf("KIS_APP_KEY is not set")
+	}
+
+	creds, err := kis.NewCredentialRepository(db.Pool).List(cmd.Context(), kis.AppKeyHash(cfg.KIS.AppKey))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Credentials:")
+	if len(creds) == 0 {
+		fmt.Println("  (none issued yet)")
+	}
+	now := time.Now()
+	for _, c := range creds {
+		state := "valid"
+		if !c.Valid(now) {
+			state = "expired"
+		}
+		expires := "-"
+		if !c.ExpiresAt.IsZero() {
+			expires = fmt.Sprintf("%s (%s)", c.ExpiresAt.Local().Format("2006-01-02 15:04:05"), c.ExpiresAt.Sub(now).Round(time.Minute))
+		}
+		fmt.Printf("  %-13s %-8s expires %s, issued %d/%d on %s\n",
+			c.Kind, state, expires, c.IssueCount, cfg.KIS.TokenDailyLimit, c.IssueDay)
+	}
+
+	fmt.Println("\nRequest budget (req/sec, per process):")
+	fmt.Printf("  %-10s %8s %8s %8s\n", "process", kis.ClassOrder, kis.ClassAccount, kis.ClassQuote)
+	for _, process := range []string{config.KISProcessAPI, config.KISProcessScheduler, config.KISProcessWorker, config.KISProcessCLI} {
+		limits := kis.NewSession(cfg.KIS.ForProcess(process), nil, log).Budget().Limits()
+		fmt.Printf("  %-10s %8.1f %8.1f %8.1f\n", process, limits[kis.ClassOrder], limits[kis.ClassAccount], limits[kis.ClassQuote])
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/notify"
+)
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "알림 채널/outbox 관리",
+	Long: `알림(Telegram, Slack, 웹훅, 이메일) 채널을 점검하고 outbox를 조회합니다.
+
+알림은 ops.notification_outbox에 채널별로 적재되고,
+스케줄러/워커 프로세스의 디스패처가 발송합니다 (실패 시 백오프 재시도, 소진 시 dead).
+
+Subcommands:
+  test    - 채널로 테스트 메시지 즉시 발송
+  outbox  - outbox 조회
+
+Example:
+  go run ./cmd/quant notify test --channel telegram
+  go run ./cmd/quant notify outbox --status dead`,
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "테스트 메시지 발송",
+	RunE:  runNotifyTest,
+}
+
+var notifyOutboxCmd = &cobra.Command{
+	Use:   "outbox",
+	Short: "outbox 조회",
+	RunE:  runNotifyOutbox,
+}
+
+var (
+	// Notify flags
+	notifyTestChannel   string
+	notifyOutboxStatus  string
+	notifyOutboxChannel string
+	notifyOutboxLimit   int
+)
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.AddCommand(notifyTestCmd)
+	notifyCmd.AddCommand(notifyOutboxCmd)
+
+	notifyTestCmd.Flags().StringVar(&notifyTestChannel, "channel", "", "채널 (telegram, slack, webhook, email; 생략 시 설정된 전체)")
+
+	notifyOutboxCmd.Flags().StringVar(&notifyOutboxStatus, "status", "", "상태 필터 (pending, sending, sent, dead)")
+	notifyOutboxCmd.Flags().StringVar(&notifyOutboxChannel, "channel", "", "채널 필터")
+	notifyOutboxCmd.Flags().IntVar(&notifyOutboxLimit, "limit", 30, "출력 건수")
+}
+
+func runNotifyTest(cmd *cobra.Command, args []string) error {
+	cfg, log, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	svc, err := notify.New(cfg, db.Pool, log)
+	if err != nil {
+		return err
+	}
+
+	channels := svc.Channels()
+	if notifyTestChannel != "" {
+		channels = []string{notifyTestChannel}
+	}
+	if len(channels) == 0 {
+		return fmt.Errorf("no notification channels configured")
+	}
+
+	failed := 0
+	for _, ch := range channels {
+		if err := svc.SendTest(cmd.Context(), ch); err != nil {
+			fmt.Printf("❌ %-9s %v\n", ch, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ %-9s sent\n", ch)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channels failed", failed, len(channels))
+	}
+	return nil
+}
+
+func runNotifyOutbox(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	list, err := notify.NewRepository(db.Pool).List(cmd.Context(), notify.OutboxFilter{
+		Status:  notify.OutboxStatus(notifyOutboxStatus),
+		Channel: notifyOutboxChannel,
+		Limit:   notifyOutboxLimit,
+	})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Println("No notifications")
+		return nil
+	}
+
+	fmt.Printf("%-8s %-20s %-9s %-8s %-8s %-19s %s\n", "ID", "EVENT", "CHANNEL", "STATUS", "ATTEMPT", "CREATED", "TITLE / ERROR")
+	for _, e := range list {
+		detail := e.Title
+		if e.LastError != "" {
+			detail += " ← " + e.LastError
+		}
+		fmt.Printf("%-8d %-20s %-9s %-8s %d/%-6d %-19s %s\n",
+			e.ID, e.EventType, e.Channel, e.Status, e.Attempts, e.MaxAttempts,
+			e.CreatedAt.Local().Format("2006-01-02 15:04:05"), detail)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -10,12 +11,19 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
 	"github.com/wonny/aegis/v13/backend/internal/external/krx"
 	"github.com/wonny/aegis/v13/backend/internal/external/naver"
+	"github.com/wonny/aegis/v13/backend/internal/notify"
+	"github.com/wonny/aegis/v13/backend/internal/queue"
 	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/collector"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/financials"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/quality"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/reconcile"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/stockmaster"
 	"github.com/wonny/aegis/v13/backend/internal/s1_universe"
 	"github.com/wonny/aegis/v13/backend/internal/scheduler"
 	"github.com/wonny/aegis/v13/backend/internal/scheduler/jobs"
@@ -23,6 +31,7 @@ import (
 	"github.com/wonny/aegis/v13/backend/pkg/database"
 	"github.com/wonny/aegis/v13/backend/pkg/httputil"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // schedulerCmd represents the scheduler command
@@ -32,20 +41,24 @@ var schedulerCmd = &cobra.Command{
 	Long: `스케줄러를 시작하거나 작업을 관리합니다.
 
 이 명령어는:
-- 스케줄러 데몬 시작
+- 스케줄러 데몬 시작 (여러 인스턴스 중 advisory lock을 잡은 리더만 실행)
 - 등록된 작업 조회
-- 작업 실행 이력 조회
+- 작업 실행 이력 조회 (ops.scheduler_runs, 재시작 후에도 유지)
+- 작업 즉시 실행/일시정지/재개 (API와 같은 경로로 리더 스케줄러에 요청)
 
 Subcommands:
   start   - 스케줄러 시작
   list    - 등록된 작업 목록
-  run     - 특정 작업 즉시 실행
+  run     - 특정 작업 즉시 실행 요청
+  pause   - 작업 일시정지
+  resume  - 작업 재개
   status  - 작업 실행 상태 조회
 
 Example:
   go run ./cmd/quant scheduler start
   go run ./cmd/quant scheduler list
-  go run ./cmd/quant scheduler run data_collection`,
+  go run ./cmd/quant scheduler run data_collection --wait
+  go run ./cmd/quant scheduler pause price_collection`,
 }
 
 var (
@@ -54,15 +67,29 @@ var (
 		Short: "스케줄러 시작",
 		Long: `스케줄러를 시작하고 등록된 모든 작업을 스케줄합니다.
 
-등록되는 작업:
-- data_collection: 매일 오후 4시 (전체 데이터 수집)
-- price_collection: 평일 9-15시 매시간 (가격 데이터)
-- investor_flow: 매일 오후 5시 (투자자 수급)
+등록되는 작업 ([거래일] = KRX 휴장일 건너뜀):
+- data_collection: 오후 4시 [거래일] (전체 데이터 수집, 가격/수급은 누락 거래일만)
+- price_collection: 평일 9-15시 매시간 [거래일] (가격 데이터)
+- investor_flow: 오후 5시 [거래일] (투자자 수급 누락분)
 - disclosure_collection: 6시간마다 (공시 데이터)
-- universe_generation: 매일 오후 6시 (Universe 생성)
-- forecast_pipeline: 매일 오후 6시 30분 (이벤트 감지/예측)
+- financial_statement_collection: 매일 오후 7시 (DART 정기보고서 재무제표)
+- stock_master_sync: 평일 오후 5시 30분 [거래일] (KRX 종목 마스터/시장조치 플래그)
+- source_reconciliation: data_collection 이후 [거래일] (Naver/KIS/KRX 교차 대사)
+- universe_generation: data_collection, stock_master_sync 이후 [거래일] (Universe 생성)
+- selection_pipeline: universe_generation, investor_flow 이후, 17:00(의사결정 시각) 이전 실행 안 함 [거래일] (S0-S7, 실행 계획만)
+- forecast_pipeline: data_collection 이후 [거래일] (이벤트 감지/예측)
 - cache_cleanup: 5분마다 (캐시 정리)
 
+선행 작업이 실패하거나 건너뛰면 후속 작업은 사유와 함께 SKIPPED로 기록됩니다.
+작업마다 실행 기한(재시도 포함)이 있으며, 초과 시 취소되고 실패로 기록됩니다.
+
+cache_cleanup을 제외한 작업은 ops.job_queue에 적재되고 quant worker가 실행합니다.
+--inline 지정 시 큐 없이 스케줄러 프로세스에서 직접 실행합니다.
+
+여러 인스턴스를 띄우면 PostgreSQL advisory lock을 잡은 한 인스턴스만 cron을 실행하고,
+나머지는 대기하다 리더가 종료되면 인계받습니다. 실행 이력은 ops.scheduler_runs에 저장되며
+리더는 수동 실행 요청과 일시정지 변경을 5초마다 반영합니다.
+
 스케줄러는 Ctrl+C로 종료할 수 있습니다.`,
 		RunE: runScheduler,
 	}
@@ -75,9 +102,31 @@ var (
 
 	schedulerRunCmd = &cobra.Command{
 		Use:   "run [job_name]",
-		Short: "특정 작업 즉시 실행",
+		Short: "특정 작업 즉시 실행 요청",
+		Long: `리더 스케줄러에 즉시 실행을 요청합니다 (POST /api/scheduler/jobs/{name}/run과 동일).
+
+수동 실행은 휴장일/시작 시각/일시정지를 무시하며, 성공하면 후속 작업도 실행됩니다.
+--wait 지정 시 실행이 끝날 때까지 기다려 결과를 출력합니다.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runJob,
+	}
+
+	schedulerPauseCmd = &cobra.Command{
+		Use:   "pause [job_name]",
+		Short: "작업 일시정지 (cron/선행 작업 트리거 시 SKIPPED)",
 		Args:  cobra.ExactArgs(1),
-		RunE:  runJob,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setJobPaused(cmd.Context(), args[0], true)
+		},
+	}
+
+	schedulerResumeCmd = &cobra.Command{
+		Use:   "resume [job_name]",
+		Short: "작업 재개",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setJobPaused(cmd.Context(), args[0], false)
+		},
 	}
 
 	schedulerStatusCmd = &cobra.Command{
@@ -87,50 +136,99 @@ var (
 	}
 )
 
+var (
+	// scheduler 플래그
+	schedulerInline  bool
+	schedulerRunWait bool
+)
+
 func init() {
 	rootCmd.AddCommand(schedulerCmd)
+	schedulerCmd.PersistentFlags().BoolVar(&schedulerInline, "inline", false, "작업을 큐에 적재하지 않고 스케줄러 프로세스에서 직접 실행")
 	schedulerCmd.AddCommand(schedulerStartCmd)
 	schedulerCmd.AddCommand(schedulerListCmd)
 	schedulerCmd.AddCommand(schedulerRunCmd)
+	schedulerCmd.AddCommand(schedulerPauseCmd)
+	schedulerCmd.AddCommand(schedulerResumeCmd)
 	schedulerCmd.AddCommand(schedulerStatusCmd)
+
+	schedulerRunCmd.Flags().BoolVar(&schedulerRunWait, "wait", false, "실행이 끝날 때까지 대기")
 }
 
 func runScheduler(cmd *cobra.Command, args []string) error {
 	fmt.Println("=== Aegis v13 Scheduler ===")
 
 	// Initialize dependencies
-	sched, err := initScheduler()
+	sched, env, err := initScheduler()
 	if err != nil {
 		return fmt.Errorf("init scheduler: %w", err)
 	}
+	defer env.db.Close()
+
+	// Prometheus /metrics (대기 중인 인스턴스도 노출)
+	metricsServer := metrics.StartServer(env.cfg, metrics.ProcessScheduler, env.log)
+	defer metricsServer.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 알림 outbox 디스패처 (대기 중인 인스턴스도 발송, SKIP LOCKED로 중복 없음)
+	go env.notifier.Run(ctx)
+	if env.kis != nil {
+		go env.kis.Session().Run(ctx)
+	}
+
+	// Leader election: 한 인스턴스만 cron 실행
+	leader := scheduler.NewLeader(env.db.Pool, sched.InstanceID(), env.log)
+	acquired, err := leader.TryAcquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire leader lock: %w", err)
+	}
+	if !acquired {
+		fmt.Println("\n⏳ Another scheduler is the leader, standing by (Ctrl+C to stop)")
+		if err := leader.Acquire(ctx); err != nil {
+			fmt.Println("\nScheduler stopped before becoming leader")
+			return nil
+		}
+		// 대기 중 이전 리더가 남긴 이력 반영
+		if err := sched.Restore(ctx); err != nil {
+			leader.Release()
+			return fmt.Errorf("restore scheduler state: %w", err)
+		}
+	}
+	defer leader.Release()
 
 	// Start scheduler
 	sched.Start()
 
-	fmt.Println("\n✅ Scheduler started successfully")
+	fmt.Printf("\n✅ Scheduler started successfully (leader: %s)\n", sched.InstanceID())
 	fmt.Println("\nRegistered jobs:")
 	for _, jobName := range sched.GetAllJobs() {
 		fmt.Printf("  - %s\n", jobName)
 	}
 	fmt.Println("\nPress Ctrl+C to stop")
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-	<-quit
+	// Wait for interrupt signal or lost leadership
+	var lostErr error
+	select {
+	case <-ctx.Done():
+	case <-leader.Lost():
+		lostErr = fmt.Errorf("scheduler leader lock lost")
+	}
 
 	fmt.Println("\nShutting down scheduler...")
 	sched.Stop()
 	fmt.Println("Scheduler stopped")
 
-	return nil
+	return lostErr
 }
 
 func listJobs(cmd *cobra.Command, args []string) error {
-	sched, err := initScheduler()
+	sched, env, err := initScheduler()
 	if err != nil {
 		return fmt.Errorf("init scheduler: %w", err)
 	}
+	defer env.db.Close()
 
 	jobs := sched.GetAllJobs()
 
@@ -143,28 +241,116 @@ func listJobs(cmd *cobra.Command, args []string) error {
 }
 
 func runJob(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	jobName := args[0]
 
-	fmt.Printf("Running job: %s\n", jobName)
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := scheduler.NewRepository(db.Pool)
 
-	sched, err := initScheduler()
+	id, err := repo.RequestRun(ctx, jobName, cliRequester())
+	if err == scheduler.ErrJobNotFound {
+		return fmt.Errorf("job %s not registered (start the scheduler once to register jobs)", jobName)
+	}
 	if err != nil {
-		return fmt.Errorf("init scheduler: %w", err)
+		return fmt.Errorf("request run: %w", err)
+	}
+
+	fmt.Printf("Run requested: %s (request #%d)\n", jobName, id)
+	if leader, err := repo.LeaderActive(ctx); err == nil && !leader {
+		fmt.Println("⚠️  No scheduler is running; the job starts when a scheduler becomes leader")
+	}
+
+	if !schedulerRunWait {
+		return nil
+	}
+	return waitRunRequest(ctx, repo, id)
+}
+
+// waitRunRequest polls a run request until its run finishes
+func waitRunRequest(ctx context.Context, repo *scheduler.Repository, id int64) error {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	started := false
+	for {
+		req, err := repo.GetRunRequest(ctx, id)
+		if err != nil {
+			return err
+		}
+		if req.Status == "rejected" {
+			return fmt.Errorf("run request #%d rejected: %s", id, req.Message)
+		}
+
+		if req.Status == "started" {
+			run, err := repo.GetRunByRequest(ctx, id)
+			if err != nil {
+				return err
+			}
+			if run != nil && !started {
+				fmt.Printf("Started by %s at %s\n", req.ClaimedBy, run.StartTime.Format("2006-01-02 15:04:05"))
+				started = true
+			}
+			if run != nil && run.Status != scheduler.RunRunning {
+				fmt.Printf("Finished: %s (attempts %d, %s)\n", run.Status, run.Attempts, run.Duration)
+				for k, v := range run.Output {
+					fmt.Printf("   %s: %v\n", k, v)
+				}
+				if run.Status == scheduler.RunFailed {
+					return fmt.Errorf("job %s failed: %s", run.JobName, run.Error)
+				}
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 	}
+}
+
+// setJobPaused pauses or resumes a job for the leader scheduler
+func setJobPaused(ctx context.Context, jobName string, paused bool) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
 
-	if err := sched.RunJob(jobName); err != nil {
-		return fmt.Errorf("run job: %w", err)
+	err = scheduler.NewRepository(db.Pool).SetPaused(ctx, jobName, paused, cliRequester())
+	if err == scheduler.ErrJobNotFound {
+		return fmt.Errorf("job %s not registered", jobName)
+	}
+	if err != nil {
+		return err
 	}
 
-	fmt.Println("Job started (running in background)")
+	if paused {
+		fmt.Printf("⏸  Job %s paused\n", jobName)
+	} else {
+		fmt.Printf("▶️  Job %s resumed\n", jobName)
+	}
 	return nil
 }
 
+// cliRequester identifies CLI requests in ops tables
+func cliRequester() string {
+	host, _ := os.Hostname()
+	return "cli@" + host
+}
+
 func showStatus(cmd *cobra.Command, args []string) error {
-	sched, err := initScheduler()
+	sched, env, err := initScheduler()
 	if err != nil {
 		return fmt.Errorf("init scheduler: %w", err)
 	}
+	defer env.db.Close()
 
 	stats := sched.GetJobStats()
 
@@ -174,9 +360,14 @@ func showStatus(cmd *cobra.Command, args []string) error {
 	for jobName, stat := range stats {
 		fmt.Printf("📊 %s\n", jobName)
 		fmt.Printf("   Schedule: %s\n", stat.Schedule)
+		fmt.Printf("   Timeout: %s\n", stat.Timeout)
+		if stat.Paused {
+			fmt.Printf("   Paused: yes\n")
+		}
 		fmt.Printf("   Total Runs: %d\n", stat.TotalRuns)
 		fmt.Printf("   Success: %d (%.1f%%)\n", stat.SuccessCount, stat.SuccessRate*100)
 		fmt.Printf("   Failures: %d\n", stat.FailureCount)
+		fmt.Printf("   Skipped: %d\n", stat.SkippedCount)
 
 		if stat.LastRun != nil {
 			fmt.Printf("   Last Run: %s\n", stat.LastRun.Format("2006-01-02 15:04:05"))
@@ -190,13 +381,84 @@ func showStatus(cmd *cobra.Command, args []string) error {
 			fmt.Printf("   Last Failure: %s\n", stat.LastFailure.Format("2006-01-02 15:04:05"))
 		}
 
+		if stat.LastSkipReason != "" {
+			fmt.Printf("   Last Skipped: %s\n", stat.LastSkipReason)
+		}
+
 		fmt.Println()
 	}
 
 	return nil
 }
 
-func initScheduler() (*scheduler.Scheduler, error) {
+// schedulerEnv holds the jobs shared by the scheduler (enqueue) and the worker (execute)
+type schedulerEnv struct {
+	cfg       *config.Config
+	log       *logger.Logger
+	db        *database.DB
+	calendar  *calendar.Repository
+	collector *collector.Collector
+	notifier  *notify.Service
+	kis       *kis.Client    // nil: KIS 키 미설정
+	jobs      []scheduledJob // 큐를 거쳐 워커에서 실행 (선행 작업이 먼저 오도록 정렬)
+	inline    []scheduledJob // 프로세스 메모리를 다루는 작업 (항상 스케줄러에서 직접 실행)
+}
+
+// scheduledJob pairs a job with its dependencies, calendar gating and deadline
+type scheduledJob struct {
+	job  scheduler.Job
+	opts scheduler.JobOptions
+}
+
+// jobList returns the queue-executed jobs (worker registry)
+func (e *schedulerEnv) jobList() []scheduler.Job {
+	list := make([]scheduler.Job, len(e.jobs))
+	for i, sj := range e.jobs {
+		list[i] = sj.job
+	}
+	return list
+}
+
+// initScheduler registers all jobs and restores persisted history/pause state
+func initScheduler() (*scheduler.Scheduler, *schedulerEnv, error) {
+	env, err := buildSchedulerEnv(config.KISProcessScheduler)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sched := scheduler.New(env.log)
+	sched.SetCalendar(env.calendar)
+	sched.SetStore(scheduler.NewRepository(env.db.Pool))
+	sched.SetNotifier(env.notifier)
+	q := queue.NewRepository(env.db.Pool)
+
+	for _, sj := range env.jobs {
+		job := sj.job
+		if !schedulerInline {
+			job = jobs.NewEnqueuedJob(job, q, env.log)
+		}
+		if err := sched.AddJob(job, sj.opts); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, sj := range env.inline {
+		if err := sched.AddJob(sj.job, sj.opts); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := sched.Restore(ctx); err != nil {
+		return nil, nil, fmt.Errorf("restore scheduler state: %w", err)
+	}
+
+	return sched, env, nil
+}
+
+// buildSchedulerEnv wires clients, collectors and jobs
+// process: KIS 요청 예산 몫 (scheduler/worker)
+func buildSchedulerEnv(process string) (*schedulerEnv, error) {
 	// 1. Load config
 	cfg, err := config.Load()
 	if err != nil {
@@ -220,11 +482,23 @@ func initScheduler() (*scheduler.Scheduler, error) {
 	dartClient := dart.NewClient(cfg.DART.APIKey, log)
 	krxClient := krx.NewClient(httpClient, log)
 
+	// KIS는 선택: 키가 없으면 KRX 시세 기반 플래그만 수집하고 대사에서 KIS 비교 생략
+	var kisClient *kis.Client
+	if cfg.KIS.AppKey != "" {
+		kisClient = newKISClient(cfg, process, httpClient, db.Pool, log)
+	}
+
 	// 6. Create repositories
 	dataRepo := s0_data.NewRepository(db.Pool)
 
-	// 7. Create collector
+	// 7. Create collectors
 	col := collector.NewCollector(naverClient, dartClient, krxClient, dataRepo, log)
+	calendarRepo := calendar.NewRepository(db.Pool)
+	planner := collector.NewPlanner(col, s0_data.NewCheckpointRepository(db.Pool), calendarRepo, log)
+	calendarSyncer := calendar.NewSyncer(krxClient, calendarRepo, log)
+	financialCol := financials.NewCollector(dartClient, financials.NewRepository(db.Pool), log)
+	stockMasterCol := stockmaster.NewCollector(krxClient, kisClient, stockmaster.NewRepository(db.Pool), log)
+	reconciler := reconcile.NewReconciler(naverClient, kisClient, krxClient, reconcile.NewRepository(db.Pool), log)
 
 	// 8. Create quality gate
 	qualityConfig := quality.Config{
@@ -239,6 +513,11 @@ func initScheduler() (*scheduler.Scheduler, error) {
 
 	// 9. Create universe builder
 	// Note: MinMarketCap은 억 단위, MinVolume은 백만 단위로 지정
+	// 전략 파일(config/strategy/*.yaml)이 정의하는 필터는 대표 전략 값으로 덮어씀
+	registry, err := loadStrategyRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
 	universeConfig := s1_universe.Config{
 		MinMarketCap:   100, // 100억 원 (억 단위)
 		MinVolume:      100, // 1억 원 (백만 단위)
@@ -247,22 +526,92 @@ func initScheduler() (*scheduler.Scheduler, error) {
 		ExcludeHalt:    true,
 		ExcludeSPAC:    true,
 	}
+	applyStrategyUniverse(&universeConfig, registry.Strategies()[0].Config)
 	universeBuilder := s1_universe.NewBuilder(db.Pool, universeConfig)
 
 	// 10. Create price cache
 	priceCache := cache.NewPriceCache(60*time.Second, log)
 
-	// 11. Create scheduler
-	sched := scheduler.New(log)
+	// 11. Create notifier (작업/파이프라인 실패 알림)
+	notifier, err := notify.New(cfg, db.Pool, log)
+	if err != nil {
+		return nil, fmt.Errorf("init notifier: %w", err)
+	}
 
-	// 12. Register jobs
-	sched.AddJob(jobs.NewDataCollectionJob(col, cfg, log))
-	sched.AddJob(jobs.NewPriceCollectionJob(col, cfg, log))
-	sched.AddJob(jobs.NewInvestorFlowJob(col, cfg, log))
-	sched.AddJob(jobs.NewDisclosureJob(col, log))
-	sched.AddJob(jobs.NewUniverseJob(universeBuilder, qualityGate, log))
-	sched.AddJob(jobs.NewForecastJob(db.Pool, log))
-	sched.AddJob(jobs.NewCacheCleanupJob(priceCache, log))
+	// 12. Create decision pipeline (S0-S7, dry run)
+	orchestrator, err := initOrchestrator()
+	if err != nil {
+		return nil, fmt.Errorf("init orchestrator: %w", err)
+	}
+	orchestrator.SetNotifier(notifier)
+
+	// 의사결정 시각(meta.decision_time_local)과 계좌별 자본은 전략/계좌 레지스트리 기준
+	decisionTime := strategyDecisionTime(registry)
+	accountCapital, err := strategyAccountCapital(cfg, registry)
+	if err != nil {
+		return nil, err
+	}
 
-	return sched, nil
+	// 13. Create jobs
+	// 의존 관계: data_collection → universe_generation(+ stock_master_sync) → selection_pipeline(+ investor_flow)
+	//           data_collection → source_reconciliation, forecast_pipeline
+	return &schedulerEnv{
+		cfg:       cfg,
+		log:       log,
+		db:        db,
+		calendar:  calendarRepo,
+		collector: col,
+		notifier:  notifier,
+		kis:       kisClient,
+		jobs: []scheduledJob{
+			{jobs.NewDataCollectionJob(col, planner, calendarSyncer, cfg, log), scheduler.JobOptions{
+				TradingDaysOnly: true,
+				Timeout:         2 * time.Hour,
+			}},
+			{jobs.NewPriceCollectionJob(col, cfg, log), scheduler.JobOptions{
+				TradingDaysOnly: true,
+				Timeout:         50 * time.Minute, // 다음 정시 실행 전 종료
+			}},
+			{jobs.NewInvestorFlowJob(planner, cfg, log), scheduler.JobOptions{
+				TradingDaysOnly: true,
+				Timeout:         time.Hour,
+			}},
+			{jobs.NewDisclosureJob(col, log), scheduler.JobOptions{
+				Timeout: 30 * time.Minute,
+			}},
+			{jobs.NewFinancialStatementJob(financialCol, log), scheduler.JobOptions{
+				Timeout: 2 * time.Hour,
+			}},
+			{jobs.NewStockMasterJob(stockMasterCol, log), scheduler.JobOptions{
+				TradingDaysOnly: true,
+				Timeout:         30 * time.Minute,
+			}},
+			{jobs.NewReconciliationJob(reconciler, calendarRepo, log), scheduler.JobOptions{
+				DependsOn:       []string{"data_collection"},
+				TradingDaysOnly: true,
+				Timeout:         time.Hour,
+			}},
+			{jobs.NewUniverseJob(universeBuilder, qualityGate, log), scheduler.JobOptions{
+				DependsOn:       []string{"data_collection", "stock_master_sync"},
+				TradingDaysOnly: true,
+				Timeout:         30 * time.Minute,
+			}},
+			{jobs.NewPipelineJob(orchestrator, accountCapital, decisionTime, getGitSHA(), log), scheduler.JobOptions{
+				DependsOn:       []string{"universe_generation", "investor_flow"},
+				TradingDaysOnly: true,
+				NotBefore:       decisionTime,
+				Timeout:         time.Hour,
+			}},
+			{jobs.NewForecastJob(db.Pool, log), scheduler.JobOptions{
+				DependsOn:       []string{"data_collection"},
+				TradingDaysOnly: true,
+				Timeout:         time.Hour,
+			}},
+		},
+		inline: []scheduledJob{
+			{jobs.NewCacheCleanupJob(priceCache, log), scheduler.JobOptions{
+				Timeout: time.Minute,
+			}},
+		},
+	}, nil
 }
@@ -0,0 +1,252 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/audit"
+	"github.com/wonny/aegis/v13/backend/internal/brain"
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/internal/portfolio"
+	"github.com/wonny/aegis/v13/backend/internal/s1_universe"
+	"github.com/wonny/aegis/v13/backend/internal/selection"
+	"github.com/wonny/aegis/v13/backend/internal/strategyconfig"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// strategyCmd represents the strategy command
+var strategyCmd = &cobra.Command{
+	Use:   "strategy",
+	Short: "전략/계좌 레지스트리",
+	Long: `config/strategy/*.yaml 전략과 KIS 계좌 배분을 조회합니다.
+
+각 전략 파일의 account.id / account.capital_pct로 계좌와 자본 비율을 지정합니다.
+같은 계좌 전략들의 capital_pct 합은 1.0 이하여야 합니다.
+
+Example:
+  go run ./cmd/quant strategy list
+  go run ./cmd/quant strategy holdings`,
+}
+
+var (
+	strategyListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "전략 목록 (설정 + DB 등록 상태)",
+		RunE:  runStrategyList,
+	}
+
+	strategyHoldingsCmd = &cobra.Command{
+		Use:   "holdings",
+		Short: "전략별 보유 종목 (거래 원장 기준)",
+		RunE:  runStrategyHoldings,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(strategyCmd)
+	strategyCmd.AddCommand(strategyListCmd)
+	strategyCmd.AddCommand(strategyHoldingsCmd)
+}
+
+func runStrategyList(cmd *cobra.Command, args []string) error {
+	cfg, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	registry, err := loadStrategyRegistry(cfg)
+	if err != nil {
+		return err
+	}
+
+	repo := strategyconfig.NewRepository(db.Pool)
+	if err := repo.Sync(cmd.Context(), accountRecords(cfg), registry); err != nil {
+		return fmt.Errorf("sync strategy registry: %w", err)
+	}
+	records, err := repo.ListStrategies(cmd.Context(), false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=== Strategies (%s) ===\n", cfg.StrategyDir)
+	fmt.Printf("%-24s %-12s %8s %-10s %-8s %-12s %s\n",
+		"Strategy", "Account", "Capital", "Version", "Enabled", "Hash", "Path")
+	for _, r := range records {
+		fmt.Printf("%-24s %-12s %7.1f%% %-10s %-8v %-12s %s\n",
+			r.ID, r.AccountID, r.CapitalPct*100, r.Version, r.Enabled, shortHash(r.ConfigHash), r.ConfigPath)
+	}
+	return nil
+}
+
+func runStrategyHoldings(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	fills, err := audit.NewRepository(db.Pool).GetFills(cmd.Context())
+	if err != nil {
+		return err
+	}
+	holdings := audit.SummarizeOpenLots(audit.MatchFIFO(fills).Open)
+
+	fmt.Println("=== Holdings by Strategy ===")
+	fmt.Printf("%-12s %-24s %-8s %8s %12s %14s\n", "Account", "Strategy", "Code", "Qty", "AvgPrice", "Cost")
+	for _, h := range holdings {
+		strategyID := h.StrategyID
+		if strategyID == "" {
+			strategyID = "(untagged)"
+		}
+		fmt.Printf("%-12s %-24s %-8s %8d %12.0f %14.0f\n",
+			h.AccountID, strategyID, h.Code, h.Quantity, h.AvgPrice, h.Cost)
+	}
+	fmt.Printf("\nTotal: %d positions\n", len(holdings))
+	return nil
+}
+
+// loadStrategyRegistry loads config/strategy/*.yaml and validates account bindings
+func loadStrategyRegistry(cfg *config.Config) (*strategyconfig.Registry, error) {
+	strategies, err := strategyconfig.LoadDir(cfg.StrategyDir)
+	if err != nil {
+		return nil, fmt.Errorf("load strategies: %w", err)
+	}
+	registry, err := strategyconfig.NewRegistry(strategies, cfg.KIS.AccountIDs())
+	if err != nil {
+		return nil, fmt.Errorf("strategy registry: %w", err)
+	}
+	return registry, nil
+}
+
+// buildStrategies creates S4~S6 components for each registered strategy
+// 사전 검증기(킬 스위치/일일 한도)는 전략 간 공유
+func buildStrategies(registry *strategyconfig.Registry, preTrade *execution.PreTradeChecker, quotes execution.QuoteProvider, log *logger.Logger) []brain.Strategy {
+	result := make([]brain.Strategy, 0, len(registry.Strategies()))
+	for _, s := range registry.Strategies() {
+		planner := execution.NewPlanner(nil, strategyExecutionConfig(s.Config), log) // nil broker for dry run
+		planner.SetPreTradeChecker(preTrade)
+		planner.SetQuoteProvider(quotes)
+
+		result = append(result, brain.Strategy{
+			Binding:   s.Binding(),
+			Ranker:    selection.NewRanker(strategyWeights(s.Config), log),
+			Portfolio: portfolio.NewConstructor(strategyPortfolioConfig(s.Config), portfolio.DefaultConstraints(), log),
+			Planner:   planner,
+		})
+	}
+	return result
+}
+
+// applyStrategyUniverse overrides the universe filters owned by the strategy file
+// 유니버스는 전략 간 공유 → 대표(첫) 전략 값 사용
+func applyStrategyUniverse(u *s1_universe.Config, c *strategyconfig.Config) {
+	u.ExcludeKRXFlags = c.Universe.ExcludeKRXFlags
+	u.MaxSpreadPct = c.Universe.Filters.Spread.MaxPct
+}
+
+// strategyWeights maps ranking.weights_pct to ranker weights
+func strategyWeights(c *strategyconfig.Config) selection.WeightConfig {
+	w := c.Ranking.WeightsPct
+	return selection.WeightConfig{
+		Momentum:  float64(w.Momentum) / 100,
+		Flow:      float64(w.Flow) / 100,
+		Technical: float64(w.Technical) / 100,
+		Event:     float64(w.Event) / 100,
+		Value:     float64(w.Value) / 100,
+		Quality:   float64(w.Quality) / 100,
+	}
+}
+
+// strategyPortfolioConfig maps the portfolio section to the constructor config
+func strategyPortfolioConfig(c *strategyconfig.Config) portfolio.PortfolioConfig {
+	p := c.Portfolio
+	tiers := make([]portfolio.TierConfig, len(p.Weighting.Tiers))
+	for i, t := range p.Weighting.Tiers {
+		tiers[i] = portfolio.TierConfig{Count: t.Count, WeightEach: t.WeightEachPct}
+	}
+	return portfolio.PortfolioConfig{
+		MaxPositions:  p.Holdings.Max,
+		MinPositions:  p.Holdings.Min,
+		MaxWeight:     p.Allocation.PositionMaxPct,
+		MinWeight:     p.Allocation.PositionMinPct,
+		CashReserve:   p.Allocation.CashTargetPct,
+		SectorMaxPct:  p.Allocation.SectorMaxPct,
+		TurnoverLimit: p.Allocation.TurnoverDailyMaxPct,
+		WeightingMode: strings.ToLower(p.Weighting.Method),
+		Tiers:         tiers,
+	}
+}
+
+// strategyExecutionConfig maps execution.limit_policy to the planner config
+// Note: broker가 nil이므로 시장가 주문 유지 (limit_policy는 지정가 전환 시 참조)
+func strategyExecutionConfig(c *strategyconfig.Config) execution.ExecutionConfig {
+	return execution.ExecutionConfig{
+		OrderType:      contracts.OrderTypeMarket,
+		SlippageBps:    10,
+		SplitThreshold: 50_000_000,
+		MaxOrderSize:   50_000_000,
+		BuyPriceRef:    c.Execution.LimitPolicy.Buy,
+		SellPriceRef:   c.Execution.LimitPolicy.Sell,
+	}
+}
+
+// strategyAccountCapital returns each strategy account's capital (KIS_CAPITAL / KIS_ACCOUNT_<ID>_CAPITAL)
+// 자본 미설정 계좌에 전략이 배정되어 있으면 오류
+func strategyAccountCapital(cfg *config.Config, registry *strategyconfig.Registry) (map[string]int64, error) {
+	capital := make(map[string]int64)
+	for _, s := range registry.Strategies() {
+		accountID := s.Binding().AccountID
+		acc, ok := cfg.KIS.Account(accountID)
+		if !ok || acc.Capital <= 0 {
+			return nil, fmt.Errorf("strategy %s: capital of account %q is not configured (KIS_CAPITAL or KIS_ACCOUNT_<ID>_CAPITAL)", s.ID(), accountID)
+		}
+		capital[accountID] = acc.Capital
+	}
+	return capital, nil
+}
+
+// strategyDecisionTime returns the latest meta.decision_time_local among strategies
+// 모든 전략을 한 번에 실행하므로 가장 늦은 의사결정 시각 이후에 실행
+func strategyDecisionTime(registry *strategyconfig.Registry) string {
+	latest := ""
+	for _, s := range registry.Strategies() {
+		if t := s.Config.Meta.DecisionTimeLocal; t > latest {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// accountRecords returns the configured KIS accounts for ops.accounts (계좌번호 마스킹)
+func accountRecords(cfg *config.Config) []strategyconfig.AccountRecord {
+	records := make([]strategyconfig.AccountRecord, 0, len(cfg.KIS.Accounts))
+	for _, acc := range cfg.KIS.Accounts {
+		records = append(records, strategyconfig.AccountRecord{
+			ID:              acc.ID,
+			AccountNoMasked: maskAccountNo(acc.AccountNo),
+			IsVirtual:       cfg.KIS.IsVirtual,
+		})
+	}
+	return records
+}
+
+// maskAccountNo keeps only the last 4 digits of an account number
+func maskAccountNo(accountNo string) string {
+	if len(accountNo) <= 4 {
+		return strings.Repeat("*", len(accountNo))
+	}
+	return strings.Repeat("*", len(accountNo)-4) + accountNo[len(accountNo)-4:]
+}
+
+// shortHash returns the first 12 characters of a config hash
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -1,13 +1,20 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/wonny/aegis/v13/backend/internal/queue"
+	"github.com/wonny/aegis/v13/backend/internal/scheduler/jobs"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // workerCmd represents the worker command
@@ -17,14 +24,22 @@ var workerCmd = &cobra.Command{
 	Long: `큐 기반 백그라운드 작업을 처리하는 워커입니다.
 
 이 워커는:
-- PostgreSQL 기반 job queue에서 작업 가져오기
-- 데이터 수집 작업 실행
-- 실패한 작업 재시도
+- PostgreSQL 기반 job queue(ops.job_queue)에서 FOR UPDATE SKIP LOCKED로 작업 임대
+- 스케줄러 작업(scheduled_job)과 API 수집 요청(collect_data) 실행
+- 실패한 작업 백오프 재시도, 재시도 소진 시 dead-letter
 - Graceful shutdown 지원
 
+Subcommands:
+  start   - 워커 시작
+  list    - 큐 작업 조회
+  retry   - dead/cancelled 작업 재적재
+  cancel  - 작업 취소 (실행 중이면 취소 요청)
+
 Example:
   go run ./cmd/quant worker start
-  go run ./cmd/quant worker start --concurrency 5`,
+  go run ./cmd/quant worker start --concurrency 5
+  go run ./cmd/quant worker list --status dead
+  go run ./cmd/quant worker retry 42`,
 }
 
 // workerStartCmd represents the start subcommand
@@ -35,9 +50,9 @@ var workerStartCmd = &cobra.Command{
 
 Features:
 - 동시 실행 작업 수 제어 (--concurrency)
-- Graceful shutdown (Ctrl+C)
-- 자동 재시도 (실패한 작업)
-- Health check
+- 가시성 타임아웃 (--visibility): 실행 중 heartbeat로 연장, 워커가 죽으면 만료 후 다른 워커가 회수
+- Graceful shutdown (Ctrl+C): 새 임대 중단 후 실행 중 작업 대기 (--shutdown-timeout 초과 시 취소 후 큐로 반환)
+- 자동 재시도 (30초부터 2배씩, 최대 30분 백오프)
 
 Example:
   go run ./cmd/quant worker start
@@ -45,175 +60,194 @@ Example:
 	RunE: runWorkerStart,
 }
 
+var workerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "큐 작업 조회",
+	RunE:  runWorkerList,
+}
+
+var workerRetryCmd = &cobra.Command{
+	Use:   "retry [job_id]",
+	Short: "dead/cancelled 작업 재적재",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkerRetry,
+}
+
+var workerCancelCmd = &cobra.Command{
+	Use:   "cancel [job_id]",
+	Short: "작업 취소 (대기 중이면 즉시, 실행 중이면 워커가 중단)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkerCancel,
+}
+
 var (
 	// Worker flags
-	workerConcurrency int
+	workerConcurrency     int
+	workerVisibility      time.Duration
+	workerShutdownTimeout time.Duration
+	workerListStatus      string
+	workerListType        string
+	workerListLimit       int
 )
 
 func init() {
 	rootCmd.AddCommand(workerCmd)
 	workerCmd.AddCommand(workerStartCmd)
+	workerCmd.AddCommand(workerListCmd)
+	workerCmd.AddCommand(workerRetryCmd)
+	workerCmd.AddCommand(workerCancelCmd)
 
 	// Flags
-	workerStartCmd.Flags().IntVar(&workerConcurrency, "concurrency", 3, "동시 실행 작업 수")
+	def := queue.DefaultWorkerConfig()
+	workerStartCmd.Flags().IntVar(&workerConcurrency, "concurrency", def.Concurrency, "동시 실행 작업 수")
+	workerStartCmd.Flags().DurationVar(&workerVisibility, "visibility", def.VisibilityTimeout, "작업 임대 가시성 타임아웃")
+	workerStartCmd.Flags().DurationVar(&workerShutdownTimeout, "shutdown-timeout", def.ShutdownTimeout, "종료 시 실행 중 작업 대기 시간")
+
+	workerListCmd.Flags().StringVar(&workerListStatus, "status", "", "상태 필터 (queued, running, done, dead, cancelled)")
+	workerListCmd.Flags().StringVar(&workerListType, "type", "", "작업 타입 필터 (scheduled_job, collect_data)")
+	workerListCmd.Flags().IntVar(&workerListLimit, "limit", 30, "출력 건수")
 }
 
 func runWorkerStart(cmd *cobra.Command, args []string) error {
 	fmt.Println("=== Aegis v13 Background Worker ===")
-	fmt.Printf("Concurrency: %d workers\n", workerConcurrency)
-	fmt.Printf("Queue: PostgreSQL\n\n")
-
-	// Setup signal handling for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
-	// Worker loop (placeholder)
-	fmt.Println("🚀 Worker started")
+	// 스케줄러와 같은 작업 구성을 사용
+	env, err := buildSchedulerEnv(config.KISProcessWorker)
+	if err != nil {
+		return fmt.Errorf("init worker: %w", err)
+	}
+	defer env.db.Close()
+
+	cfg := queue.DefaultWorkerConfig()
+	cfg.Concurrency = workerConcurrency
+	cfg.VisibilityTimeout = workerVisibility
+	cfg.ShutdownTimeout = workerShutdownTimeout
+
+	worker := queue.NewWorker(queue.NewRepository(env.db.Pool), cfg, env.log)
+	worker.Register(queue.TypeScheduledJob, jobs.NewScheduledJobHandler(env.jobList()))
+	worker.Register(queue.TypeCollectData, jobs.NewCollectDataHandler(env.collector, env.log))
+
+	fmt.Printf("Worker ID: %s\n", worker.ID())
+	fmt.Printf("Concurrency: %d workers\n", cfg.Concurrency)
+	fmt.Printf("Queue: PostgreSQL (ops.job_queue)\n")
+	fmt.Println("\nJob types:")
+	for _, t := range worker.Types() {
+		fmt.Printf("  - %s\n", t)
+	}
+	fmt.Println("\n🚀 Worker started")
 	fmt.Println("   Press Ctrl+C to stop gracefully")
 
-	// Simulate worker processing
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	jobID := 1
-	for {
-		select {
-		case <-sigChan:
-			fmt.Println("\n⚠️  Shutdown signal received")
-			fmt.Println("   Waiting for in-flight jobs to complete...")
-			time.Sleep(2 * time.Second)
-			fmt.Println("✅ Worker stopped gracefully")
-			return nil
-
-		case <-ticker.C:
-			// Simulate job processing with clear separation
-			processJob(jobID)
-			jobID++
-		}
+	// Prometheus /metrics (수집/파이프라인 지표는 작업을 실행하는 워커에서 노출)
+	metricsServer := metrics.StartServer(env.cfg, metrics.ProcessWorker, env.log)
+	defer metricsServer.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 파이프라인 실패 알림은 워커에서 발생하므로 워커도 outbox를 발송
+	go env.notifier.Run(ctx)
+	if env.kis != nil {
+		go env.kis.Session().Run(ctx)
 	}
-}
 
-func processJob(jobID int) {
-	startTime := time.Now()
-	timestamp := startTime.Format("15:04:05")
-	jobType := getJobType(jobID)
-	jobTag := getJobTag(jobType)
-
-	// Build job metadata
-	meta := JobMetadata{
-		JobID:     jobID,
-		JobType:   jobType,
-		Tag:       jobTag,
-		Timestamp: timestamp,
+	if err := worker.Run(ctx); err != nil {
+		return err
 	}
 
-	// Add period for ranking jobs
-	if jobType == "Fetch Ranking Data" {
-		meta.Period = GetCurrentPeriod()
-		meta.Symbols = "all"
+	fmt.Println("\n🛑 Worker stopped")
+	return nil
+}
+
+func runWorkerList(cmd *cobra.Command, args []string) error {
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
 	}
+	defer db.Close()
 
-	// Print job header (common format)
-	PrintJobHeader(meta)
+	repo := queue.NewRepository(db.Pool)
 
-	// Execute job steps with progress tracking
-	steps := getJobSteps(jobType)
-	totalSteps := len(steps)
+	stats, err := repo.Stats(cmd.Context())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Queue: queued=%d running=%d done=%d dead=%d cancelled=%d\n\n",
+		stats[queue.StatusQueued], stats[queue.StatusRunning], stats[queue.StatusDone],
+		stats[queue.StatusDead], stats[queue.StatusCancelled])
+
+	list, err := repo.List(cmd.Context(), queue.ListFilter{
+		Status: queue.Status(workerListStatus),
+		Type:   workerListType,
+		Limit:  workerListLimit,
+	})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Println("No jobs")
+		return nil
+	}
 
-	for i, step := range steps {
-		currentStep := i + 1
-		PrintProgress(jobTag, step, currentStep, totalSteps)
-		SimulateProcessing(200)
+	fmt.Printf("%-8s %-14s %-10s %-8s %-19s %s\n", "ID", "TYPE", "STATUS", "ATTEMPT", "CREATED", "PAYLOAD / ERROR")
+	for _, job := range list {
+		detail := string(job.Payload)
+		if job.LastError != "" {
+			detail += " ← " + job.LastError
+		}
+		fmt.Printf("%-8d %-14s %-10s %d/%-6d %-19s %s\n",
+			job.ID, job.Type, job.Status, job.Attempts, job.MaxAttempts,
+			job.CreatedAt.Local().Format("2006-01-02 15:04:05"), detail)
 	}
+	return nil
+}
 
-	// Print job completion (common format)
-	duration := time.Since(startTime).Seconds()
-	PrintJobCompletion(jobID, duration)
+func runWorkerRetry(cmd *cobra.Command, args []string) error {
+	return updateQueueJob(cmd.Context(), args[0], "retried", func(ctx context.Context, repo *queue.Repository, id int64) (bool, error) {
+		return repo.Retry(ctx, id)
+	})
+}
 
-	// Warning message
-	PrintWarning("실제 구현 필요: internal/queue/worker.go")
+func runWorkerCancel(cmd *cobra.Command, args []string) error {
+	return updateQueueJob(cmd.Context(), args[0], "cancelled", func(ctx context.Context, repo *queue.Repository, id int64) (bool, error) {
+		ok, err := repo.Cancel(ctx, id)
+		if ok {
+			if job, getErr := repo.Get(ctx, id); getErr == nil && job != nil && job.Status == queue.StatusRunning {
+				fmt.Printf("Job %d is running; the worker will stop it at the next progress sync\n", id)
+			}
+		}
+		return ok, err
+	})
 }
 
-func getJobType(jobID int) string {
-	types := []string{
-		"Collect KIS Prices",
-		"Collect DART Reports",
-		"Collect Naver Data",
-		"Fetch Ranking Data",
-		"Process Signals",
-		"Update Rankings",
+// updateQueueJob applies a state change to one job by ID
+func updateQueueJob(ctx context.Context, arg, verb string, fn func(context.Context, *queue.Repository, int64) (bool, error)) error {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid job id %q", arg)
 	}
-	return types[(jobID-1)%len(types)]
-}
 
-func getJobSteps(jobType string) []string {
-	switch jobType {
-	case "Collect KIS Prices":
-		return []string{
-			"Connecting to KIS API...",
-			"Fetching real-time prices...",
-			"Parsing response data...",
-			"Saving to database...",
-		}
-	case "Collect DART Reports":
-		return []string{
-			"Connecting to DART API...",
-			"Fetching company reports...",
-			"Extracting financial data...",
-			"Saving to database...",
-		}
-	case "Collect Naver Data":
-		return []string{
-			"Connecting to Naver...",
-			"Fetching investor trends...",
-			"Parsing HTML data...",
-			"Saving to database...",
-		}
-	case "Fetch Ranking Data":
-		return []string{
-			"Fetched trading/KOSPI: 100 items",
-			"Fetched trading/KOSDAQ: 100 items",
-			"Fetched quantHigh/KOSPI: 100 items",
-			"Fetched quantHigh/KOSDAQ: 100 items",
-			"Fetched quantLow/KOSPI: 100 items",
-			"Fetched quantLow/KOSDAQ: 100 items",
-			"Fetched priceTop/KOSPI: 100 items",
-			"Fetched priceTop/KOSDAQ: 100 items",
-		}
-	case "Process Signals":
-		return []string{
-			"Loading price data...",
-			"Calculating momentum signals...",
-			"Calculating technical signals...",
-			"Saving signal results...",
+	_, _, db, err := initAuditDeps()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := queue.NewRepository(db.Pool)
+	ok, err := fn(ctx, repo, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		job, err := repo.Get(ctx, id)
+		if err != nil {
+			return err
 		}
-	case "Update Rankings":
-		return []string{
-			"Loading signals...",
-			"Calculating composite scores...",
-			"Ranking stocks...",
-			"Updating rankings table...",
+		if job == nil {
+			return fmt.Errorf("job %d not found", id)
 		}
-	default:
-		return []string{"Processing..."}
+		return fmt.Errorf("job %d is %s, cannot be %s", id, job.Status, verb)
 	}
-}
 
-func getJobTag(jobType string) string {
-	switch jobType {
-	case "Collect KIS Prices":
-		return "KIS"
-	case "Collect DART Reports":
-		return "DART"
-	case "Collect Naver Data":
-		return "Naver"
-	case "Fetch Ranking Data":
-		return "Ranking"
-	case "Process Signals":
-		return "Signals"
-	case "Update Rankings":
-		return "Rankings"
-	default:
-		return "Worker"
-	}
+	fmt.Printf("✅ Job %d %s\n", id, verb)
+	return nil
 }
@@ -0,0 +1,262 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+const (
+	maxRequestBody = 1 << 20   // 변경 요청 본문 상한 (1MB)
+	maxAuditBody   = 64 * 1024 // 감사 로그에 저장하는 본문 상한
+)
+
+// routeRoles overrides the default role of a route ("METHOD /template")
+// 기본: GET = read_only, 그 외 = trader
+var routeRoles = map[string]auth.Role{
+	// 분석 계산만 수행 (조회 권한으로 허용)
+	"POST /api/forecast/analyze/{symbol}": auth.RoleReadOnly,
+
+	// 운영 제어
+	"POST /api/data/collect":                 auth.RoleAdmin,
+	"POST /api/data/collect/{id}/cancel":     auth.RoleAdmin,
+	"POST /api/jobs/{id}/cancel":             auth.RoleAdmin,
+	"POST /api/jobs/{id}/retry":              auth.RoleAdmin,
+	"POST /api/scheduler/jobs/{name}/run":    auth.RoleAdmin,
+	"POST /api/scheduler/jobs/{name}/pause":  auth.RoleAdmin,
+	"POST /api/scheduler/jobs/{name}/resume": auth.RoleAdmin,
+	"GET /api/audit":                         auth.RoleAdmin,
+
+	// 킬 스위치: 발동은 trader, 해제는 admin
+	"POST /api/trading/kill-switch/deactivate": auth.RoleAdmin,
+}
+
+// queryKeyRoutes accept ?api_key= because browsers cannot set headers on WebSocket/EventSource
+var queryKeyRoutes = map[string]bool{
+	"/api/v1/stream/prices":         true,
+	"/api/data/collect/{id}/stream": true,
+}
+
+// requiredRole returns the role needed for a route
+// ⭐ SSOT: 라우트 권한 정책은 이 함수에서만
+func requiredRole(method, template string) auth.Role {
+	if role, ok := routeRoles[method+" "+template]; ok {
+		return role
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return auth.RoleReadOnly
+	}
+	return auth.RoleTrader
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// authMiddleware authenticates API keys, enforces route roles and audits mutating requests
+// 변경 요청은 거부된 경우(401/403)를 포함해 모두 audit.api_audit_log에 기록
+func authMiddleware(keys *auth.Repository, cfg config.APIConfig, log *logger.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			template := routeTemplate(r)
+			if template == "/health" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			mutating := isMutating(r.Method)
+
+			var requestBody []byte
+			var recorder *responseRecorder
+			if mutating {
+				body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBody))
+				if err != nil {
+					writeAuthError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+					return
+				}
+				requestBody = body
+				r.Body = io.NopCloser(bytes.NewReader(body))
+
+				recorder = &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+				w = recorder
+			}
+
+			principal, status, message := authenticate(r, keys, cfg, template, log)
+			if principal != nil {
+				r = r.WithContext(auth.WithPrincipal(r.Context(), principal))
+			}
+
+			if mutating {
+				// 핸들러 panic도 기록 (recoveryMiddleware가 500 응답)
+				defer func() {
+					p := recover()
+					entry := &auth.AuditEntry{
+						Actor:        "anonymous",
+						Method:       r.Method,
+						Path:         r.URL.Path,
+						Query:        r.URL.RawQuery,
+						RemoteAddr:   r.RemoteAddr,
+						RequestBody:  auditBody(requestBody),
+						Status:       recorder.status,
+						ResponseBody: auditBody(recorder.body.Bytes()),
+						DurationMs:   time.Since(start).Milliseconds(),
+					}
+					if p != nil {
+						entry.Status = http.StatusInternalServerError
+					}
+					if principal != nil {
+						entry.KeyID = principal.KeyID
+						entry.Actor = principal.Name
+						entry.Role = principal.Role
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					defer cancel()
+					if err := keys.RecordAudit(ctx, entry); err != nil {
+						log.WithError(err).WithFields(map[string]interface{}{
+							"method": r.Method,
+							"path":   r.URL.Path,
+							"actor":  entry.Actor,
+							"status": entry.Status,
+						}).Error("Failed to write API audit log")
+					}
+
+					if p != nil {
+						panic(p)
+					}
+				}()
+			}
+
+			if status != 0 {
+				writeAuthError(w, status, message)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authenticate resolves the caller; status != 0 means the request is rejected
+func authenticate(r *http.Request, keys *auth.Repository, cfg config.APIConfig, template string, log *logger.Logger) (*auth.Principal, int, string) {
+	required := requiredRole(r.Method, template)
+
+	// 인증 비활성 (development): 로컬 관리자
+	if !cfg.AuthEnabled {
+		return &auth.Principal{Name: "local", Role: auth.RoleAdmin}, 0, ""
+	}
+
+	token := bearerToken(r)
+	if token == "" && queryKeyRoutes[template] {
+		token = r.URL.Query().Get("api_key")
+	}
+	if token == "" {
+		return nil, http.StatusUnauthorized, "API key required"
+	}
+
+	key, err := keys.Authenticate(r.Context(), token)
+	if err != nil {
+		log.WithError(err).Error("Failed to authenticate API key")
+		return nil, http.StatusInternalServerError, "Failed to authenticate"
+	}
+	if key == nil {
+		return nil, http.StatusUnauthorized, "Invalid or revoked API key"
+	}
+
+	principal := &auth.Principal{KeyID: key.ID, Name: key.Name, Role: key.Role}
+	if !key.Role.Allows(required) {
+		return principal, http.StatusForbidden, fmt.Sprintf("Role %s cannot access this endpoint (requires %s)", key.Role, required)
+	}
+	return principal, 0, ""
+}
+
+// bearerToken reads "Authorization: Bearer <key>" or "X-API-Key: <key>"
+func bearerToken(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(h, "Bearer "))
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
+
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return r.URL.Path
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return r.URL.Path
+	}
+	return template
+}
+
+// auditBody stores JSON as-is, other bodies as a JSON string, and truncates large bodies
+func auditBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	if len(body) > maxAuditBody {
+		truncated, _ := json.Marshal(map[string]interface{}{
+			"truncated": true,
+			"size":      len(body),
+			"prefix":    string(body[:maxAuditBody]),
+		})
+		return truncated
+	}
+	if json.Valid(body) {
+		return body
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+func writeAuthError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": message,
+	})
+}
+
+// responseRecorder captures the status and (bounded) body of a mutating request
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if !rr.wroteHeader {
+		rr.status = status
+		rr.wroteHeader = true
+	}
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.wroteHeader = true
+	if remaining := maxAuditBody + 1 - rr.body.Len(); remaining > 0 {
+		if len(b) > remaining {
+			rr.body.Write(b[:remaining])
+		} else {
+			rr.body.Write(b)
+		}
+	}
+	return rr.ResponseWriter.Write(b)
+}
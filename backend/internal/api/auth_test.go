@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+func TestRequiredRole(t *testing.T) {
+	tests := []struct {
+		method   string
+		template string
+		want     auth.Role
+	}{
+		{"GET", "/api/trading/balance", auth.RoleReadOnly},
+		{"POST", "/api/trading/orders", auth.RoleTrader},
+		{"DELETE", "/api/trading/orders", auth.RoleTrader},
+		{"PATCH", "/api/trading/positions/{stock_code}/exit-monitoring", auth.RoleTrader},
+		{"POST", "/api/data/collect", auth.RoleAdmin},
+		{"POST", "/api/data/collect/{id}/cancel", auth.RoleAdmin},
+		{"GET", "/api/data/collect/{id}/stream", auth.RoleReadOnly},
+		{"POST", "/api/scheduler/jobs/{name}/pause", auth.RoleAdmin},
+		{"GET", "/api/audit", auth.RoleAdmin},
+		{"POST", "/api/forecast/analyze/{symbol}", auth.RoleReadOnly},
+		{"POST", "/api/trading/kill-switch/activate", auth.RoleTrader},
+		{"POST", "/api/trading/kill-switch/deactivate", auth.RoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.want, requiredRole(tt.method, tt.template))
+		})
+	}
+}
+
+func TestAuditBody(t *testing.T) {
+	assert.Nil(t, auditBody(nil))
+	assert.JSONEq(t, `{"stock_code":"005930","qty":10}`, string(auditBody([]byte(`{"stock_code":"005930","qty":10}`))))
+	assert.JSONEq(t, `"qty=10"`, string(auditBody([]byte("qty=10"))))
+
+	large := auditBody([]byte(strings.Repeat("a", maxAuditBody+10)))
+	assert.Contains(t, string(large), `"truncated":true`)
+}
+
+func TestCORSHandler(t *testing.T) {
+	handler := corsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), []string{"https://dash.example.com"})
+
+	tests := []struct {
+		origin string
+		want   string
+	}{
+		{"https://dash.example.com", "https://dash.example.com"},
+		{"https://evil.example.com", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/api/trading/orders", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, tt.want, rec.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func TestAuthMiddleware_RequiresKey(t *testing.T) {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+
+	r := mux.NewRouter()
+	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+	r.HandleFunc("/api/trading/balance", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+	r.Use(authMiddleware(nil, config.APIConfig{AuthEnabled: true}, log))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/trading/balance", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 형식이 다른 키는 DB 조회 없이 거부
+	req := httptest.NewRequest(http.MethodGet, "/api/trading/balance", nil)
+	req.Header.Set("Authorization", "Bearer not-a-key")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
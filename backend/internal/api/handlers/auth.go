@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// AuthHandler handles caller identity and audit log endpoints
+// API 키 발급/폐기는 CLI(quant auth key)에서만
+type AuthHandler struct {
+	repo   *auth.Repository
+	logger *logger.Logger
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(repo *auth.Repository, log *logger.Logger) *AuthHandler {
+	return &AuthHandler{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+// Me returns the authenticated caller and role
+// GET /api/auth/me
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	principal := auth.FromContext(r.Context())
+	if principal == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+	respondJSON(w, http.StatusOK, principal)
+}
+
+// ListAudit returns mutating API requests, newest first
+// GET /api/audit?actor=dashboard&since=2026-10-01&limit=100
+func (h *AuthHandler) ListAudit(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := auth.AuditFilter{
+		Actor: q.Get("actor"),
+		Limit: 100,
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 1000 {
+			respondError(w, http.StatusBadRequest, "Invalid limit (1-1000)")
+			return
+		}
+		filter.Limit = n
+	}
+
+	if v := q.Get("since"); v != "" {
+		since, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid 'since' date format (expected YYYY-MM-DD)")
+			return
+		}
+		filter.Since = since
+	}
+
+	entries, err := h.repo.ListAudit(r.Context(), filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list audit log")
+		respondError(w, http.StatusInternalServerError, "Failed to list audit log")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"entries": entries,
+	})
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/wonny/aegis/v13/backend/internal/queue"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/collector"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/quality"
@@ -17,8 +20,8 @@ import (
 type DataHandler struct {
 	dataRepo     *s0_data.Repository
 	universeRepo *s1_universe.Repository
-	collector    *collector.Collector
 	qualityGate  *quality.QualityGate
+	queue        *queue.Repository
 	logger       *logger.Logger
 }
 
@@ -26,15 +29,15 @@ type DataHandler struct {
 func NewDataHandler(
 	dataRepo *s0_data.Repository,
 	universeRepo *s1_universe.Repository,
-	col *collector.Collector,
 	qualityGate *quality.QualityGate,
+	jobQueue *queue.Repository,
 	log *logger.Logger,
 ) *DataHandler {
 	return &DataHandler{
 		dataRepo:     dataRepo,
 		universeRepo: universeRepo,
-		collector:    col,
 		qualityGate:  qualityGate,
+		queue:        jobQueue,
 		logger:       log,
 	}
 }
@@ -83,11 +86,12 @@ type CollectResponse struct {
 	Status  string      `json:"status"`
 	Message string      `json:"message"`
 	Type    string      `json:"type"`
+	JobID   int64       `json:"job_id,omitempty"`
 	Results interface{} `json:"results,omitempty"`
 }
 
-// Collect triggers data collection
-// POST /api/data/collect
+// Collect enqueues a data collection job for `quant worker`
+// POST /api/data/collect → 202 Accepted {job_id}, 진행 상태는 GET /api/data/collect/{id} (또는 /stream)
 func (h *DataHandler) Collect(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -103,120 +107,229 @@ func (h *DataHandler) Collect(w http.ResponseWriter, r *http.Request) {
 		req.Type = "all"
 	}
 
-	// Parse date range
-	var from, to time.Time
-	var err error
+	payload := queue.CollectDataPayload{Type: req.Type, From: req.From, To: req.To}
+	from, to, err := payload.Range(time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if req.From != "" {
-		from, err = time.Parse("2006-01-02", req.From)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid 'from' date format (expected YYYY-MM-DD)")
-			return
-		}
-	} else {
-		// Default: last 30 days
-		from = time.Now().AddDate(0, 0, -30)
+	// 범위를 고정해 재시도 시에도 같은 구간을 수집
+	payload.From = from.Format("2006-01-02")
+	payload.To = to.Format("2006-01-02")
+
+	jobID, created, err := h.queue.Enqueue(ctx, queue.TypeCollectData, payload, queue.EnqueueOptions{
+		Priority: queue.PriorityHigh,
+		DedupKey: fmt.Sprintf("%s:%s:%s:%s", queue.TypeCollectData, payload.Type, payload.From, payload.To),
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to enqueue data collection")
+		respondError(w, http.StatusInternalServerError, "Failed to enqueue data collection")
+		return
 	}
 
-	if req.To != "" {
-		to, err = time.Parse("2006-01-02", req.To)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid 'to' date format (expected YYYY-MM-DD)")
-			return
+	h.logger.WithFields(map[string]interface{}{
+		"type":    payload.Type,
+		"from":    payload.From,
+		"to":      payload.To,
+		"job_id":  jobID,
+		"created": created,
+	}).Info("Data collection enqueued")
+
+	message := "Data collection queued"
+	if !created {
+		message = "Same collection is already queued or running"
+	}
+	respondJSON(w, http.StatusAccepted, CollectResponse{
+		Status:  string(queue.StatusQueued),
+		Message: message,
+		Type:    payload.Type,
+		JobID:   jobID,
+	})
+}
+
+// collectStreamPollInterval is how often the progress stream re-reads the job
+const collectStreamPollInterval = time.Second
+
+// CollectStatus is a data collection job with its request and per-stock progress
+type CollectStatus struct {
+	JobID             int64                       `json:"job_id"`
+	Status            queue.Status                `json:"status"`
+	Request           queue.CollectDataPayload    `json:"request"`
+	Progress          *collector.ProgressSnapshot `json:"progress,omitempty"`
+	Attempts          int                         `json:"attempts"`
+	MaxAttempts       int                         `json:"max_attempts"`
+	LastError         string                      `json:"last_error,omitempty"`
+	CancelRequestedAt *time.Time                  `json:"cancel_requested_at,omitempty"`
+	CreatedAt         time.Time                   `json:"created_at"`
+	StartedAt         *time.Time                  `json:"started_at,omitempty"`
+	FinishedAt        *time.Time                  `json:"finished_at,omitempty"`
+}
+
+// newCollectStatus converts a collect_data job (완료 후에는 결과에 저장된 최종 진행률 사용)
+func newCollectStatus(job *queue.Job) CollectStatus {
+	status := CollectStatus{
+		JobID:             job.ID,
+		Status:            job.Status,
+		Attempts:          job.Attempts,
+		MaxAttempts:       job.MaxAttempts,
+		LastError:         job.LastError,
+		CancelRequestedAt: job.CancelRequestedAt,
+		CreatedAt:         job.CreatedAt,
+		StartedAt:         job.StartedAt,
+		FinishedAt:        job.FinishedAt,
+	}
+	json.Unmarshal(job.Payload, &status.Request)
+
+	raw := job.Progress
+	if job.Status == queue.StatusDone && len(job.Result) > 0 {
+		raw = job.Result
+	}
+	if len(raw) > 0 {
+		var progress collector.ProgressSnapshot
+		if json.Unmarshal(raw, &progress) == nil {
+			status.Progress = &progress
 		}
-	} else {
-		to = time.Now()
 	}
+	return status
+}
 
-	h.logger.WithFields(map[string]interface{}{
-		"type": req.Type,
-		"from": from.Format("2006-01-02"),
-		"to":   to.Format("2006-01-02"),
-	}).Info("Data collection triggered")
+// getCollectJob loads a collect_data job by path ID (writes 400/404/500 and returns nil on failure)
+func (h *DataHandler) getCollectJob(w http.ResponseWriter, r *http.Request) *queue.Job {
+	id, ok := parseJobID(w, r)
+	if !ok {
+		return nil
+	}
+
+	job, err := h.queue.Get(r.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get collection job")
+		respondError(w, http.StatusInternalServerError, "Failed to get collection job")
+		return nil
+	}
+	if job == nil || job.Type != queue.TypeCollectData {
+		respondError(w, http.StatusNotFound, "Collection job not found")
+		return nil
+	}
+	return job
+}
 
-	// Perform collection based on type
-	cfg := collector.Config{Workers: 5}
+// GetCollect returns the status and per-stock progress of a collection job
+// GET /api/data/collect/{id}
+func (h *DataHandler) GetCollect(w http.ResponseWriter, r *http.Request) {
+	job := h.getCollectJob(w, r)
+	if job == nil {
+		return
+	}
 
-	switch req.Type {
-	case "prices":
-		results, err := h.collector.FetchAllPrices(ctx, from, to, cfg)
-		if err != nil {
-			h.logger.WithError(err).Error("Failed to collect prices")
-			respondError(w, http.StatusInternalServerError, "Failed to collect prices")
-			return
+	respondJSON(w, http.StatusOK, newCollectStatus(job))
+}
+
+// CancelCollect cancels a collection job (대기 중이면 즉시, 실행 중이면 워커가 다음 진행률 저장 때 중단)
+// POST /api/data/collect/{id}/cancel
+func (h *DataHandler) CancelCollect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	job := h.getCollectJob(w, r)
+	if job == nil {
+		return
+	}
+
+	changed, err := h.queue.Cancel(ctx, job.ID)
+	if err != nil {
+		h.logger.WithError(err).WithField("job_id", job.ID).Error("Failed to cancel collection job")
+		respondError(w, http.StatusInternalServerError, "Failed to cancel collection job")
+		return
+	}
+	if !changed {
+		respondError(w, http.StatusConflict, "Cannot cancel collection job in status "+string(job.Status))
+		return
+	}
+
+	if job, err = h.queue.Get(ctx, job.ID); err != nil || job == nil {
+		h.logger.WithError(err).Error("Failed to get collection job")
+		respondError(w, http.StatusInternalServerError, "Failed to get collection job")
+		return
+	}
+
+	h.logger.WithField("job_id", job.ID).Info("Collection cancel requested via API")
+	respondJSON(w, http.StatusOK, newCollectStatus(job))
+}
+
+// StreamCollect streams collection progress as Server-Sent Events until the job finishes
+// GET /api/data/collect/{id}/stream → event: progress (변경 시), event: done (종료 상태)
+func (h *DataHandler) StreamCollect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	job := h.getCollectJob(w, r)
+	if job == nil {
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	// 서버 WriteTimeout(15s)이 장기 연결을 끊지 않도록 해제
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.WithError(err).Debug("Failed to clear write deadline for SSE")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, data []byte) error {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
 		}
-		respondJSON(w, http.StatusOK, CollectResponse{
-			Status:  "success",
-			Message: "Price data collected",
-			Type:    req.Type,
-			Results: results,
-		})
-
-	case "investor":
-		results, err := h.collector.FetchAllInvestorFlow(ctx, from, to, cfg)
+		return rc.Flush()
+	}
+
+	poll := time.NewTicker(collectStreamPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	var last []byte
+	for {
+		data, err := json.Marshal(newCollectStatus(job))
 		if err != nil {
-			h.logger.WithError(err).Error("Failed to collect investor flow")
-			respondError(w, http.StatusInternalServerError, "Failed to collect investor flow")
 			return
 		}
-		respondJSON(w, http.StatusOK, CollectResponse{
-			Status:  "success",
-			Message: "Investor flow data collected",
-			Type:    req.Type,
-			Results: results,
-		})
-
-	case "disclosure":
-		if err := h.collector.FetchDisclosures(ctx, from, to); err != nil {
-			h.logger.WithError(err).Error("Failed to collect disclosures")
-			respondError(w, http.StatusInternalServerError, "Failed to collect disclosures")
+		if job.Status.IsTerminal() {
+			send("done", data)
 			return
 		}
-		respondJSON(w, http.StatusOK, CollectResponse{
-			Status:  "success",
-			Message: "Disclosure data collected",
-			Type:    req.Type,
-		})
-
-	case "market_caps":
-		if err := h.collector.FetchMarketCaps(ctx); err != nil {
-			h.logger.WithError(err).Error("Failed to collect market caps")
-			respondError(w, http.StatusInternalServerError, "Failed to collect market caps")
-			return
+		if !bytes.Equal(data, last) {
+			if err := send("progress", data); err != nil {
+				return
+			}
+			last = data
 		}
-		respondJSON(w, http.StatusOK, CollectResponse{
-			Status:  "success",
-			Message: "Market cap data collected",
-			Type:    req.Type,
-		})
-
-	case "all":
-		// Collect all data
-		if err := h.collector.FetchAll(ctx, from, to, cfg); err != nil {
-			h.logger.WithError(err).Error("Failed to collect all data")
-			respondError(w, http.StatusInternalServerError, "Failed to collect all data")
+
+		select {
+		case <-ctx.Done():
 			return
+		case <-heartbeat.C:
+			// 주석 라인: 프록시 유휴 타임아웃 방지
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+			continue
+		case <-poll.C:
 		}
 
-		// Also collect market caps and disclosures
-		if err := h.collector.FetchMarketCaps(ctx); err != nil {
-			h.logger.WithError(err).Warn("Failed to collect market caps during 'all'")
+		next, err := h.queue.Get(ctx, job.ID)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.WithError(err).WithField("job_id", job.ID).Warn("Failed to poll collection job")
+			}
+			continue
 		}
-
-		dartFrom := to.AddDate(0, 0, -7)
-		if err := h.collector.FetchDisclosures(ctx, dartFrom, to); err != nil {
-			h.logger.WithError(err).Warn("Failed to collect disclosures during 'all'")
+		if next != nil {
+			job = next
 		}
-
-		respondJSON(w, http.StatusOK, CollectResponse{
-			Status:  "success",
-			Message: "All data collected",
-			Type:    req.Type,
-		})
-
-	default:
-		respondError(w, http.StatusBadRequest, "Invalid collection type (valid: all, prices, investor, disclosure, market_caps)")
-		return
 	}
 }
 
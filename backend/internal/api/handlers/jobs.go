@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/wonny/aegis/v13/backend/internal/queue"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// JobHandler handles job queue API endpoints
+// ⭐ SSOT: 작업 큐 API 핸들러는 이 구조체에서만
+type JobHandler struct {
+	queue  *queue.Repository
+	logger *logger.Logger
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobQueue *queue.Repository, log *logger.Logger) *JobHandler {
+	return &JobHandler{
+		queue:  jobQueue,
+		logger: log,
+	}
+}
+
+// ListJobs returns recent jobs with per-status counts
+// GET /api/jobs?status=dead&type=collect_data&limit=50
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	limit := 50
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 500 {
+			respondError(w, http.StatusBadRequest, "Invalid limit (1-500)")
+			return
+		}
+		limit = n
+	}
+
+	jobs, err := h.queue.List(ctx, queue.ListFilter{
+		Status: queue.Status(q.Get("status")),
+		Type:   q.Get("type"),
+		Limit:  limit,
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list jobs")
+		respondError(w, http.StatusInternalServerError, "Failed to list jobs")
+		return
+	}
+
+	stats, err := h.queue.Stats(ctx)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get job stats")
+		respondError(w, http.StatusInternalServerError, "Failed to list jobs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"jobs":  jobs,
+		"stats": stats,
+	})
+}
+
+// GetJob returns one job
+// GET /api/jobs/{id}
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseJobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.queue.Get(r.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get job")
+		respondError(w, http.StatusInternalServerError, "Failed to get job")
+		return
+	}
+	if job == nil {
+		respondError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, job)
+}
+
+// CancelJob cancels a queued job, or requests cancellation of a running one
+// POST /api/jobs/{id}/cancel
+func (h *JobHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "cancel", h.queue.Cancel)
+}
+
+// RetryJob requeues a dead or cancelled job
+// POST /api/jobs/{id}/retry
+func (h *JobHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "retry", h.queue.Retry)
+}
+
+// transition applies a state change and returns the updated job
+// 409: 상태상 불가, 또는 같은 dedup_key 작업이 대기/실행 중
+func (h *JobHandler) transition(w http.ResponseWriter, r *http.Request, action string, fn func(ctx context.Context, id int64) (bool, error)) {
+	ctx := r.Context()
+
+	id, ok := parseJobID(w, r)
+	if !ok {
+		return
+	}
+
+	changed, err := fn(ctx, id)
+	if errors.Is(err, queue.ErrActiveDuplicate) {
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).WithField("job_id", id).Errorf("Failed to %s job", action)
+		respondError(w, http.StatusInternalServerError, "Failed to "+action+" job")
+		return
+	}
+
+	job, err := h.queue.Get(ctx, id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get job")
+		respondError(w, http.StatusInternalServerError, "Failed to get job")
+		return
+	}
+	if job == nil {
+		respondError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	if !changed {
+		respondError(w, http.StatusConflict, "Cannot "+action+" job in status "+string(job.Status))
+		return
+	}
+
+	h.logger.WithField("job_id", id).Infof("Job %s requested via API", action)
+	respondJSON(w, http.StatusOK, job)
+}
+
+func parseJobID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid job ID")
+		return 0, false
+	}
+	return id, true
+}
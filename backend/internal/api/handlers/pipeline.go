@@ -97,6 +97,8 @@ type PortfolioItem struct {
 	Reason       string  `json:"reason"`
 	CurrentPrice float64 `json:"currentPrice"`
 	ChangeRate   float64 `json:"changeRate"`
+	StrategyID   string  `json:"strategyId"`
+	AccountID    string  `json:"accountId"`
 }
 
 // GetUniverse returns universe stocks (S1)
@@ -647,15 +649,17 @@ func (h *PipelineHandler) GetRanking(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetPortfolio returns target portfolio positions (S5)
-// GET /api/v1/pipeline/portfolio
+// GET /api/v1/pipeline/portfolio?strategy=korea_equity_v13 (미지정 시 전체 전략)
 func (h *PipelineHandler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	strategy := r.URL.Query().Get("strategy")
 
 	// Get latest target date
 	var latestDate time.Time
 	err := h.pool.QueryRow(ctx, `
 		SELECT MAX(target_date) FROM portfolio.target_positions
-	`).Scan(&latestDate)
+		WHERE $1 = '' OR strategy_id = $1
+	`, strategy).Scan(&latestDate)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get latest target date")
 		respondError(w, http.StatusInternalServerError, "Failed to get latest target date")
@@ -684,15 +688,17 @@ func (h *PipelineHandler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
 			CASE
 				WHEN lp.prev_close > 0 THEN ((lp.close_price - lp.prev_close) / lp.prev_close * 100)::float8
 				ELSE 0::float8
-			END as change_rate
+			END as change_rate,
+			p.strategy_id,
+			p.account_id
 		FROM portfolio.target_positions p
 		JOIN data.stocks s ON p.stock_code = s.code
 		LEFT JOIN latest_prices lp ON p.stock_code = lp.stock_code
-		WHERE p.target_date = $1
-		ORDER BY p.weight DESC
+		WHERE p.target_date = $1 AND ($2 = '' OR p.strategy_id = $2)
+		ORDER BY p.strategy_id, p.weight DESC
 	`
 
-	rows, err := h.pool.Query(ctx, query, latestDate)
+	rows, err := h.pool.Query(ctx, query, latestDate, strategy)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to query portfolio")
 		respondError(w, http.StatusInternalServerError, "Query error")
@@ -714,6 +720,8 @@ func (h *PipelineHandler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
 			&item.Reason,
 			&item.CurrentPrice,
 			&item.ChangeRate,
+			&item.StrategyID,
+			&item.AccountID,
 		)
 		if err != nil {
 			h.logger.WithError(err).Error("Failed to scan portfolio item")
@@ -723,6 +731,30 @@ func (h *PipelineHandler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
 		items = append(items, item)
 	}
 
+	// 비중은 전략 자본 기준 → 전략별 합계/현금 비중
+	type strategyWeight struct {
+		StrategyID  string  `json:"strategyId"`
+		AccountID   string  `json:"accountId"`
+		Count       int     `json:"count"`
+		TotalWeight float64 `json:"totalWeight"`
+		Cash        float64 `json:"cash"`
+	}
+	strategies := make([]*strategyWeight, 0)
+	byStrategy := make(map[string]*strategyWeight)
+	for _, item := range items {
+		sw, ok := byStrategy[item.StrategyID]
+		if !ok {
+			sw = &strategyWeight{StrategyID: item.StrategyID, AccountID: item.AccountID}
+			byStrategy[item.StrategyID] = sw
+			strategies = append(strategies, sw)
+		}
+		sw.Count++
+		sw.TotalWeight += item.Weight
+	}
+	for _, sw := range strategies {
+		sw.Cash = 1.0 - sw.TotalWeight
+	}
+
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
 		"data": map[string]interface{}{
@@ -730,6 +762,7 @@ func (h *PipelineHandler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
 			"count":       len(items),
 			"totalWeight": totalWeight,
 			"cash":        1.0 - totalWeight,
+			"strategies":  strategies,
 			"positions":   items,
 		},
 	})
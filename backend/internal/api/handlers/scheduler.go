@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+	"github.com/wonny/aegis/v13/backend/internal/scheduler"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// SchedulerHandler handles scheduler API endpoints
+// API 서버는 스케줄러를 직접 실행하지 않고 ops 테이블로 리더 스케줄러에 요청 (quant scheduler run/pause/resume과 같은 경로)
+type SchedulerHandler struct {
+	repo   *scheduler.Repository
+	logger *logger.Logger
+}
+
+// NewSchedulerHandler creates a new scheduler handler
+func NewSchedulerHandler(repo *scheduler.Repository, log *logger.Logger) *SchedulerHandler {
+	return &SchedulerHandler{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+// RunJobResponse represents a queued manual run
+type RunJobResponse struct {
+	Status       string `json:"status"`
+	Message      string `json:"message"`
+	RequestID    int64  `json:"request_id"`
+	LeaderActive bool   `json:"leader_active"`
+}
+
+// ListJobs returns registered jobs with pause state and latest run
+// GET /api/scheduler/jobs
+func (h *SchedulerHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jobs, err := h.repo.ListJobs(ctx)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list scheduler jobs")
+		respondError(w, http.StatusInternalServerError, "Failed to list scheduler jobs")
+		return
+	}
+
+	leader, err := h.repo.LeaderActive(ctx)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to check scheduler leader")
+		respondError(w, http.StatusInternalServerError, "Failed to list scheduler jobs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"leader_active": leader,
+		"jobs":          jobs,
+	})
+}
+
+// ListRuns returns the run history of a job, newest first
+// GET /api/scheduler/jobs/{name}/runs?limit=50
+func (h *SchedulerHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 500 {
+			respondError(w, http.StatusBadRequest, "Invalid limit (1-500)")
+			return
+		}
+		limit = n
+	}
+
+	runs, err := h.repo.ListRuns(r.Context(), name, limit)
+	if err == scheduler.ErrJobNotFound {
+		respondError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).WithField("job", name).Error("Failed to list job runs")
+		respondError(w, http.StatusInternalServerError, "Failed to list job runs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"job":  name,
+		"runs": runs,
+	})
+}
+
+// RunJob requests an immediate run from the leader scheduler
+// POST /api/scheduler/jobs/{name}/run
+func (h *SchedulerHandler) RunJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := mux.Vars(r)["name"]
+
+	id, err := h.repo.RequestRun(ctx, name, auth.Actor(ctx))
+	if err == scheduler.ErrJobNotFound {
+		respondError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).WithField("job", name).Error("Failed to request job run")
+		respondError(w, http.StatusInternalServerError, "Failed to request job run")
+		return
+	}
+
+	leader, err := h.repo.LeaderActive(ctx)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to check scheduler leader")
+	}
+
+	message := "Run requested; the leader scheduler will start it shortly"
+	if !leader {
+		message = "Run requested; no scheduler is running, it will start when one becomes leader"
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"job":        name,
+		"request_id": id,
+	}).Info("Job run requested via API")
+
+	respondJSON(w, http.StatusAccepted, RunJobResponse{
+		Status:       "pending",
+		Message:      message,
+		RequestID:    id,
+		LeaderActive: leader,
+	})
+}
+
+// GetRunRequest returns a run request and the run it started
+// GET /api/scheduler/requests/{id}
+func (h *SchedulerHandler) GetRunRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	req, err := h.repo.GetRunRequest(ctx, id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get run request")
+		respondError(w, http.StatusInternalServerError, "Failed to get run request")
+		return
+	}
+	if req == nil {
+		respondError(w, http.StatusNotFound, "Run request not found")
+		return
+	}
+
+	run, err := h.repo.GetRunByRequest(ctx, id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get run for request")
+		respondError(w, http.StatusInternalServerError, "Failed to get run request")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"request": req,
+		"run":     run,
+	})
+}
+
+// PauseJob stops cron/upstream triggers of a job (수동 실행은 가능)
+// POST /api/scheduler/jobs/{name}/pause
+func (h *SchedulerHandler) PauseJob(w http.ResponseWriter, r *http.Request) {
+	h.setPaused(w, r, true)
+}
+
+// ResumeJob re-enables a paused job
+// POST /api/scheduler/jobs/{name}/resume
+func (h *SchedulerHandler) ResumeJob(w http.ResponseWriter, r *http.Request) {
+	h.setPaused(w, r, false)
+}
+
+func (h *SchedulerHandler) setPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	name := mux.Vars(r)["name"]
+
+	err := h.repo.SetPaused(r.Context(), name, paused, auth.Actor(r.Context()))
+	if err == scheduler.ErrJobNotFound {
+		respondError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).WithField("job", name).Error("Failed to change job pause state")
+		respondError(w, http.StatusInternalServerError, "Failed to change job pause state")
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"job":    name,
+		"paused": paused,
+	}).Info("Job pause state changed via API")
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"job":    name,
+		"paused": paused,
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/bars"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
 )
@@ -17,15 +19,17 @@ type StockHandler struct {
 	priceRepo        *s0_data.PriceRepository
 	investorFlowRepo *s0_data.InvestorFlowRepository
 	dataRepo         *s0_data.Repository
+	barRepo          *bars.Repository
 	logger           *logger.Logger
 }
 
 // NewStockHandler creates a new stock handler
-func NewStockHandler(priceRepo *s0_data.PriceRepository, investorFlowRepo *s0_data.InvestorFlowRepository, dataRepo *s0_data.Repository, log *logger.Logger) *StockHandler {
+func NewStockHandler(priceRepo *s0_data.PriceRepository, investorFlowRepo *s0_data.InvestorFlowRepository, dataRepo *s0_data.Repository, barRepo *bars.Repository, log *logger.Logger) *StockHandler {
 	return &StockHandler{
 		priceRepo:        priceRepo,
 		investorFlowRepo: investorFlowRepo,
 		dataRepo:         dataRepo,
+		barRepo:          barRepo,
 		logger:           log,
 	}
 }
@@ -41,7 +45,8 @@ type DailyPriceResponse struct {
 }
 
 // GetDailyPrices returns daily price data for a stock
-// GET /api/stocks/{code}/daily?days=365
+// GET /api/stocks/{code}/daily?days=365&adjusted=true
+// adjusted=true: 수정주가 (기본: 원주가)
 func (h *StockHandler) GetDailyPrices(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -65,7 +70,11 @@ func (h *StockHandler) GetDailyPrices(w http.ResponseWriter, r *http.Request) {
 	from := to.AddDate(0, 0, -days)
 
 	// Get prices from repository
-	prices, err := h.priceRepo.GetByCodeAndDateRange(ctx, code, from, to)
+	priceRepo := h.priceRepo
+	if adjusted, _ := strconv.ParseBool(r.URL.Query().Get("adjusted")); adjusted {
+		priceRepo = h.priceRepo.WithBasis(contracts.PriceBasisAdjusted)
+	}
+	prices, err := priceRepo.GetByCodeAndDateRange(ctx, code, from, to)
 	if err != nil {
 		h.logger.WithError(err).WithFields(map[string]interface{}{
 			"code": code,
@@ -88,6 +97,63 @@ func (h *StockHandler) GetDailyPrices(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"basis":   priceRepo.Basis(),
+		"data":    result,
+	})
+}
+
+// GetIntradayBars returns intraday bars aggregated from realtime ticks
+// GET /api/stocks/{code}/intraday?interval=1&date=2024-03-04
+func (h *StockHandler) GetIntradayBars(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	code := mux.Vars(r)["code"]
+
+	if code == "" {
+		respondError(w, http.StatusBadRequest, "stock code is required")
+		return
+	}
+
+	// Parse interval parameter (default: 1분)
+	interval := 1
+	if intervalStr := r.URL.Query().Get("interval"); intervalStr != "" {
+		i, err := strconv.Atoi(intervalStr)
+		if err != nil || (i != 1 && i != 5) {
+			respondError(w, http.StatusBadRequest, "interval must be 1 or 5")
+			return
+		}
+		interval = i
+	}
+
+	// Parse date parameter (default: 오늘, KST)
+	kst, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		kst = time.FixedZone("KST", 9*60*60)
+	}
+	date := time.Now().In(kst)
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		date, err = time.ParseInLocation("2006-01-02", dateStr, kst)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid date format (YYYY-MM-DD)")
+			return
+		}
+	}
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, kst)
+
+	result, err := h.barRepo.GetBars(ctx, code, interval, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"code":     code,
+			"interval": interval,
+		}).Error("Failed to get intraday bars")
+		respondError(w, http.StatusInternalServerError, "Failed to retrieve intraday bars")
+		return
+	}
+	if result == nil {
+		result = []bars.Bar{}
+	}
+
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
 		"data":    result,
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+const (
+	streamHeartbeatInterval = 15 * time.Second
+	streamWriteWait         = 10 * time.Second
+	streamPongWait          = 2 * streamHeartbeatInterval
+	streamMaxCodes          = 200
+)
+
+// Stream message types
+const (
+	StreamMessageSnapshot  = "snapshot"
+	StreamMessageTick      = "tick"
+	StreamMessageHeartbeat = "heartbeat"
+)
+
+// StreamMessage is the envelope sent over WebSocket / SSE
+type StreamMessage struct {
+	Type    string              `json:"type"`
+	Tick    *realtime.PriceTick `json:"tick,omitempty"`
+	Dropped uint64              `json:"dropped,omitempty"` // 느린 소비자로 인해 버려진 틱 누적 수
+	Time    time.Time           `json:"time"`
+}
+
+// StreamHandler streams PriceCache ticks to clients
+// ⭐ SSOT: 실시간 가격 스트리밍 API는 이 구조체에서만
+// KIS/Naver를 다시 호출하지 않고 PriceCache 구독(Subscribe)만 사용
+type StreamHandler struct {
+	priceCache *cache.PriceCache
+	upgrader   websocket.Upgrader
+	logger     *logger.Logger
+}
+
+// NewStreamHandler creates a new stream handler
+// allowedOrigins: corsHandler와 같은 허용 목록 (API_CORS_ORIGINS)
+func NewStreamHandler(priceCache *cache.PriceCache, allowedOrigins []string, log *logger.Logger) *StreamHandler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[origin] = true
+	}
+
+	return &StreamHandler{
+		priceCache: priceCache,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			// CORS와 동일 정책 (Origin 없는 비브라우저 클라이언트는 허용)
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || allowed["*"] || allowed[origin]
+			},
+		},
+		logger: log,
+	}
+}
+
+// StreamPrices streams real-time prices via WebSocket or Server-Sent Events
+// GET /api/v1/stream/prices?codes=005930,000660&snapshot=true
+// - Upgrade: websocket 헤더가 있으면 WebSocket, 그 외에는 SSE (text/event-stream)
+// - codes 생략 또는 "*" → 전체 종목
+// - snapshot=false → 캐시에 있는 현재가 스냅샷 생략
+func (h *StreamHandler) StreamPrices(w http.ResponseWriter, r *http.Request) {
+	if h.priceCache == nil {
+		respondError(w, http.StatusServiceUnavailable, "price stream not available")
+		return
+	}
+
+	codes := parseStreamCodes(r.URL.Query().Get("codes"))
+	if len(codes) > streamMaxCodes {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("too many codes (max %d)", streamMaxCodes))
+		return
+	}
+	withSnapshot := r.URL.Query().Get("snapshot") != "false"
+
+	if websocket.IsWebSocketUpgrade(r) {
+		h.serveWebSocket(w, r, codes, withSnapshot)
+		return
+	}
+	h.serveSSE(w, r, codes, withSnapshot)
+}
+
+// serveWebSocket streams ticks over a WebSocket connection
+func (h *StreamHandler) serveWebSocket(w http.ResponseWriter, r *http.Request, codes []string, withSnapshot bool) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade가 이미 에러 응답을 작성함
+		h.logger.WithError(err).Warn("Failed to upgrade price stream")
+		return
+	}
+	defer conn.Close()
+
+	sub := h.priceCache.Subscribe(cache.DefaultSubscriptionBuffer, codes...)
+	defer sub.Close()
+
+	h.logStream("websocket", sub, "Price stream opened")
+	defer h.logStream("websocket", sub, "Price stream closed")
+
+	// Read loop: 클라이언트 메시지는 무시, 연결 종료/pong만 감지
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn.SetReadLimit(1024)
+		conn.SetReadDeadline(time.Now().Add(streamPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(msg StreamMessage) error {
+		conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+		return conn.WriteJSON(msg)
+	}
+
+	if withSnapshot {
+		for _, tick := range h.snapshot(codes) {
+			if err := write(StreamMessage{Type: StreamMessageSnapshot, Tick: tick, Time: time.Now()}); err != nil {
+				return
+			}
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case tick, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			if err := write(StreamMessage{Type: StreamMessageTick, Tick: tick, Time: time.Now()}); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+			if err := write(StreamMessage{Type: StreamMessageHeartbeat, Dropped: sub.Dropped(), Time: time.Now()}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// serveSSE streams ticks as Server-Sent Events
+func (h *StreamHandler) serveSSE(w http.ResponseWriter, r *http.Request, codes []string, withSnapshot bool) {
+	rc := http.NewResponseController(w)
+
+	// 서버 WriteTimeout(15s)이 장기 연결을 끊지 않도록 해제
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.WithError(err).Debug("Failed to clear write deadline for SSE")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		h.logger.WithError(err).Warn("Streaming not supported by response writer")
+		return
+	}
+
+	sub := h.priceCache.Subscribe(cache.DefaultSubscriptionBuffer, codes...)
+	defer sub.Close()
+
+	h.logStream("sse", sub, "Price stream opened")
+	defer h.logStream("sse", sub, "Price stream closed")
+
+	send := func(msg StreamMessage) error {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Type, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	if withSnapshot {
+		for _, tick := range h.snapshot(codes) {
+			if err := send(StreamMessage{Type: StreamMessageSnapshot, Tick: tick, Time: time.Now()}); err != nil {
+				return
+			}
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case tick, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			if err := send(StreamMessage{Type: StreamMessageTick, Tick: tick, Time: time.Now()}); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := send(StreamMessage{Type: StreamMessageHeartbeat, Dropped: sub.Dropped(), Time: time.Now()}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns cached prices for the requested codes (all when wildcard)
+func (h *StreamHandler) snapshot(codes []string) []*realtime.PriceTick {
+	var prices map[string]*realtime.PriceTick
+	if len(codes) == 0 {
+		prices = h.priceCache.GetAll()
+	} else {
+		prices = h.priceCache.GetMany(codes)
+	}
+
+	ticks := make([]*realtime.PriceTick, 0, len(prices))
+	for _, tick := range prices {
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
+
+// logStream logs stream lifecycle events
+func (h *StreamHandler) logStream(transport string, sub *cache.Subscription, msg string) {
+	h.logger.WithFields(map[string]interface{}{
+		"transport": transport,
+		"codes":     sub.Codes(),
+		"dropped":   sub.Dropped(),
+	}).Info(msg)
+}
+
+// parseStreamCodes parses a comma-separated code list (empty or "*" = wildcard)
+func parseStreamCodes(raw string) []string {
+	var codes []string
+	for _, code := range strings.Split(raw, ",") {
+		code = strings.TrimSpace(code)
+		if code == cache.WildcardSymbol {
+			return nil
+		}
+		if code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
 	"github.com/wonny/aegis/v13/backend/internal/external/kis"
 	"github.com/wonny/aegis/v13/backend/internal/portfolio"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // TradingHandler handles trading-related API endpoints
@@ -18,15 +20,19 @@ type TradingHandler struct {
 	kisClient     *kis.Client
 	kisWSClient   *kis.WSClient
 	portfolioRepo *portfolio.Repository
+	execRepo      *execution.Repository
+	preTrade      *execution.PreTradeChecker
 	logger        *logger.Logger
 }
 
 // NewTradingHandler creates a new trading handler
-func NewTradingHandler(kisClient *kis.Client, kisWSClient *kis.WSClient, portfolioRepo *portfolio.Repository, log *logger.Logger) *TradingHandler {
+func NewTradingHandler(kisClient *kis.Client, kisWSClient *kis.WSClient, portfolioRepo *portfolio.Repository, execRepo *execution.Repository, preTrade *execution.PreTradeChecker, log *logger.Logger) *TradingHandler {
 	return &TradingHandler{
 		kisClient:     kisClient,
 		kisWSClient:   kisWSClient,
 		portfolioRepo: portfolioRepo,
+		execRepo:      execRepo,
+		preTrade:      preTrade,
 		logger:        log,
 	}
 }
@@ -77,7 +83,7 @@ func (h *TradingHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
 		result[i] = PositionWithMonitoring{
 			Position:              pos,
 			Market:                marketMap[pos.StockCode],
-			ExitMonitoringEnabled: monitoringMap[pos.StockCode],
+			ExitMonitoringEnabled: exitMonitoringEnabled(monitoringMap, pos.StockCode),
 		}
 	}
 
@@ -137,7 +143,7 @@ func (h *TradingHandler) GetPositions(w http.ResponseWriter, r *http.Request) {
 		result[i] = PositionWithMonitoring{
 			Position:              pos,
 			Market:                marketMap[pos.StockCode],
-			ExitMonitoringEnabled: monitoringMap[pos.StockCode],
+			ExitMonitoringEnabled: exitMonitoringEnabled(monitoringMap, pos.StockCode),
 		}
 	}
 
@@ -265,7 +271,24 @@ func (h *TradingHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 		orderType = kis.OrderTypeMarket
 	}
 
+	// Pre-trade checks (킬 스위치, 가격제한폭, 금액 한도, ADTV, 중복, 매수 가능 금액)
+	check, err := h.authorizeOrder(ctx, req)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to run pre-trade checks")
+		respondError(w, http.StatusInternalServerError, "Failed to run pre-trade checks")
+		return
+	}
+	if !check.Passed {
+		respondJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":      "Order blocked by pre-trade checks",
+			"violations": check.Violations,
+			"notional":   check.Notional,
+		})
+		return
+	}
+
 	// Place order
+	submitStart := time.Now()
 	result, err := h.kisClient.PlaceOrder(ctx, kis.PlaceOrderRequest{
 		StockCode: req.StockCode,
 		Side:      orderSide,
@@ -273,6 +296,7 @@ func (h *TradingHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 		Quantity:  req.Quantity,
 		Price:     req.Price,
 	})
+	metrics.ObserveOrderSubmit(req.Side, orderSubmitResult(result, err), submitStart)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to place order")
 		respondError(w, http.StatusInternalServerError, "Failed to place order")
@@ -289,6 +313,18 @@ func (h *TradingHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, result)
 }
 
+// orderSubmitResult labels a broker response for the order latency metric
+func orderSubmitResult(result *kis.PlaceOrderResult, err error) string {
+	switch {
+	case err != nil:
+		return "error"
+	case !result.Success:
+		return "rejected"
+	default:
+		return "accepted"
+	}
+}
+
 // CancelOrderRequest represents an order cancellation request
 type CancelOrderRequest struct {
 	OrderNo string `json:"order_no"`
@@ -428,15 +464,18 @@ func (h *TradingHandler) GetWebSocketStatus(w http.ResponseWriter, r *http.Reque
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"connected":     h.kisWSClient.IsConnected(),
-		"subscriptions": h.kisWSClient.GetSubscriptions(),
-		"count":         h.kisWSClient.SubscriptionCount(),
+		"connected":                h.kisWSClient.IsConnected(),
+		"subscriptions":            h.kisWSClient.GetSubscriptions(),
+		"order_book_subscriptions": h.kisWSClient.GetOrderBookSubscriptions(),
+		"count":                    h.kisWSClient.SubscriptionCount(),
+		"health":                   h.kisWSClient.Health(),
 	})
 }
 
 // SubscribeRequest represents a WebSocket subscription request
 type SubscribeRequest struct {
-	Symbols []string `json:"symbols"`
+	Symbols   []string `json:"symbols"`
+	OrderBook bool     `json:"order_book,omitempty"` // 호가(H0STASP0)도 함께 구독/해제
 }
 
 // Subscribe subscribes to real-time tick data
@@ -464,10 +503,19 @@ func (h *TradingHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.OrderBook {
+		if err := h.kisWSClient.SubscribeOrderBook(req.Symbols...); err != nil {
+			h.logger.WithError(err).Error("Failed to subscribe order book")
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"status":        "subscribed",
-		"symbols":       req.Symbols,
-		"subscriptions": h.kisWSClient.GetSubscriptions(),
+		"status":                   "subscribed",
+		"symbols":                  req.Symbols,
+		"subscriptions":            h.kisWSClient.GetSubscriptions(),
+		"order_book_subscriptions": h.kisWSClient.GetOrderBookSubscriptions(),
 	})
 }
 
@@ -496,10 +544,19 @@ func (h *TradingHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.OrderBook {
+		if err := h.kisWSClient.UnsubscribeOrderBook(req.Symbols...); err != nil {
+			h.logger.WithError(err).Error("Failed to unsubscribe order book")
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"status":        "unsubscribed",
-		"symbols":       req.Symbols,
-		"subscriptions": h.kisWSClient.GetSubscriptions(),
+		"status":                   "unsubscribed",
+		"symbols":                  req.Symbols,
+		"subscriptions":            h.kisWSClient.GetSubscriptions(),
+		"order_book_subscriptions": h.kisWSClient.GetOrderBookSubscriptions(),
 	})
 }
 
@@ -507,6 +564,12 @@ func (h *TradingHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
 // Exit Monitoring
 // ============================================================
 
+// exitMonitoringEnabled reports the per-stock toggle (설정 행이 없으면 감시, 테이블 기본값과 동일)
+func exitMonitoringEnabled(statuses map[string]bool, code string) bool {
+	enabled, ok := statuses[code]
+	return !ok || enabled
+}
+
 // UpdateExitMonitoringRequest represents exit monitoring update request
 type UpdateExitMonitoringRequest struct {
 	Enabled bool `json:"enabled"`
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
+)
+
+// ============================================================
+// Pre-trade Checks & Kill Switch
+// ============================================================
+
+// authorizeOrder runs pre-trade checks for a manual order and records the decision
+// 통과 기록은 브로커 전송 실패 시에도 남음 (일일 한도는 보수적으로 계산)
+func (h *TradingHandler) authorizeOrder(ctx context.Context, req PlaceOrderRequest) (*execution.PreTradeResult, error) {
+	order := contracts.Order{
+		Code:      req.StockCode,
+		Side:      contracts.OrderSideBuy,
+		Qty:       int(req.Quantity),
+		Price:     float64(req.Price),
+		OrderType: contracts.OrderTypeLimit,
+	}
+	if req.Side == "sell" {
+		order.Side = contracts.OrderSideSell
+	}
+
+	var refPrice float64
+	if req.Type == "market" {
+		order.OrderType = contracts.OrderTypeMarket
+		order.Price = 0
+
+		// 시장가 주문 금액 환산 (실패 시 전일 종가)
+		if price, err := h.kisClient.GetCurrentPrice(ctx, req.StockCode); err == nil {
+			refPrice = price.ClosePrice
+		} else {
+			h.logger.WithError(err).WithField("stock_code", req.StockCode).Warn("Failed to get current price for pre-trade check")
+		}
+	}
+
+	return h.preTrade.Authorize(ctx, &execution.PreTradeOrder{
+		Order:    order,
+		Source:   execution.PreTradeSourceManual,
+		Actor:    auth.Actor(ctx),
+		RefPrice: refPrice,
+	})
+}
+
+// KillSwitchRequest represents a kill switch change
+type KillSwitchRequest struct {
+	Reason     string `json:"reason"`
+	CancelOpen bool   `json:"cancel_open"` // 발동 시 미체결 주문 일괄 취소
+}
+
+// GetKillSwitch returns the kill switch state and recent changes
+// GET /api/trading/kill-switch
+func (h *TradingHandler) GetKillSwitch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ks, err := h.execRepo.GetKillSwitch(ctx)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get kill switch")
+		respondError(w, http.StatusInternalServerError, "Failed to get kill switch")
+		return
+	}
+
+	events, err := h.execRepo.ListKillSwitchEvents(ctx, 20)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list kill switch events")
+		respondError(w, http.StatusInternalServerError, "Failed to get kill switch")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"kill_switch": ks,
+		"events":      events,
+	})
+}
+
+// ActivateKillSwitch blocks all new orders (수동/Planner/청산 모니터)
+// POST /api/trading/kill-switch/activate
+func (h *TradingHandler) ActivateKillSwitch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, ok := decodeKillSwitchRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var canceller execution.OpenOrderCanceller
+	if req.CancelOpen {
+		canceller = h.kisClient
+	}
+
+	result, err := execution.ActivateKillSwitch(ctx, h.execRepo, canceller, req.Reason, auth.Actor(ctx), h.logger)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to activate kill switch")
+		respondError(w, http.StatusInternalServerError, "Failed to activate kill switch")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// DeactivateKillSwitch re-enables new orders
+// POST /api/trading/kill-switch/deactivate
+func (h *TradingHandler) DeactivateKillSwitch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, ok := decodeKillSwitchRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := execution.DeactivateKillSwitch(ctx, h.execRepo, req.Reason, auth.Actor(ctx), h.logger)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to deactivate kill switch")
+		respondError(w, http.StatusInternalServerError, "Failed to deactivate kill switch")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func decodeKillSwitchRequest(w http.ResponseWriter, r *http.Request) (KillSwitchRequest, bool) {
+	var req KillSwitchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return req, false
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		respondError(w, http.StatusBadRequest, "reason is required")
+		return req, false
+	}
+	return req, true
+}
+
+// GetPreTradeChecks returns recent pre-trade decisions
+// GET /api/trading/pretrade-checks?blocked=true&limit=50
+func (h *TradingHandler) GetPreTradeChecks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	blockedOnly := q.Get("blocked") == "true"
+
+	limit := 50
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 500 {
+			respondError(w, http.StatusBadRequest, "Invalid limit (1-500)")
+			return
+		}
+		limit = n
+	}
+
+	checks, err := h.execRepo.ListPreTradeChecks(r.Context(), blockedOnly, limit)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list pre-trade checks")
+		respondError(w, http.StatusInternalServerError, "Failed to list pre-trade checks")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"checks": checks,
+		"count":  len(checks),
+	})
+}
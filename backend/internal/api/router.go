@@ -8,12 +8,14 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/wonny/aegis/v13/backend/internal/api/handlers"
+	"github.com/wonny/aegis/v13/backend/internal/auth"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
 )
 
 // NewRouter creates and configures the HTTP router
 // ⭐ SSOT: 라우팅 설정은 이 함수에서만
-func NewRouter(dataHandler *handlers.DataHandler, tradingHandler *handlers.TradingHandler, stocklistHandler *handlers.StocklistHandler, stockHandler *handlers.StockHandler, rankingHandler *handlers.RankingHandler, pipelineHandler *handlers.PipelineHandler, forecastHandler *handlers.ForecastHandler, log *logger.Logger) http.Handler {
+func NewRouter(dataHandler *handlers.DataHandler, tradingHandler *handlers.TradingHandler, stocklistHandler *handlers.StocklistHandler, stockHandler *handlers.StockHandler, rankingHandler *handlers.RankingHandler, pipelineHandler *handlers.PipelineHandler, forecastHandler *handlers.ForecastHandler, streamHandler *handlers.StreamHandler, jobHandler *handlers.JobHandler, schedulerHandler *handlers.SchedulerHandler, authHandler *handlers.AuthHandler, authRepo *auth.Repository, apiCfg config.APIConfig, log *logger.Logger) http.Handler {
 	r := mux.NewRouter()
 
 	// Health check
@@ -26,8 +28,29 @@ func NewRouter(dataHandler *handlers.DataHandler, tradingHandler *handlers.Tradi
 	api.HandleFunc("/data/quality", dataHandler.GetQuality).Methods("GET")
 	api.HandleFunc("/data/universe", dataHandler.GetUniverse).Methods("GET")
 	api.HandleFunc("/data/collect", dataHandler.Collect).Methods("POST")
+	api.HandleFunc("/data/collect/{id}", dataHandler.GetCollect).Methods("GET")
+	api.HandleFunc("/data/collect/{id}/stream", dataHandler.StreamCollect).Methods("GET")
+	api.HandleFunc("/data/collect/{id}/cancel", dataHandler.CancelCollect).Methods("POST")
 	api.HandleFunc("/data/stats", dataHandler.GetDataStats).Methods("GET")
 
+	// Auth / audit endpoints (키 발급은 quant auth key)
+	api.HandleFunc("/auth/me", authHandler.Me).Methods("GET")
+	api.HandleFunc("/audit", authHandler.ListAudit).Methods("GET")
+
+	// Job queue endpoints
+	api.HandleFunc("/jobs", jobHandler.ListJobs).Methods("GET")
+	api.HandleFunc("/jobs/{id}", jobHandler.GetJob).Methods("GET")
+	api.HandleFunc("/jobs/{id}/cancel", jobHandler.CancelJob).Methods("POST")
+	api.HandleFunc("/jobs/{id}/retry", jobHandler.RetryJob).Methods("POST")
+
+	// Scheduler endpoints (리더 스케줄러에 ops 테이블로 요청)
+	api.HandleFunc("/scheduler/jobs", schedulerHandler.ListJobs).Methods("GET")
+	api.HandleFunc("/scheduler/jobs/{name}/runs", schedulerHandler.ListRuns).Methods("GET")
+	api.HandleFunc("/scheduler/jobs/{name}/run", schedulerHandler.RunJob).Methods("POST")
+	api.HandleFunc("/scheduler/jobs/{name}/pause", schedulerHandler.PauseJob).Methods("POST")
+	api.HandleFunc("/scheduler/jobs/{name}/resume", schedulerHandler.ResumeJob).Methods("POST")
+	api.HandleFunc("/scheduler/requests/{id}", schedulerHandler.GetRunRequest).Methods("GET")
+
 	// Trading endpoints (KIS API)
 	api.HandleFunc("/trading/balance", tradingHandler.GetBalance).Methods("GET")
 	api.HandleFunc("/trading/positions", tradingHandler.GetPositions).Methods("GET")
@@ -39,6 +62,12 @@ func NewRouter(dataHandler *handlers.DataHandler, tradingHandler *handlers.Tradi
 	api.HandleFunc("/trading/price", tradingHandler.GetCurrentPrice).Methods("GET")
 	api.HandleFunc("/trading/prices", tradingHandler.GetPrices).Methods("GET")
 
+	// Pre-trade checks & kill switch
+	api.HandleFunc("/trading/kill-switch", tradingHandler.GetKillSwitch).Methods("GET")
+	api.HandleFunc("/trading/kill-switch/activate", tradingHandler.ActivateKillSwitch).Methods("POST")
+	api.HandleFunc("/trading/kill-switch/deactivate", tradingHandler.DeactivateKillSwitch).Methods("POST")
+	api.HandleFunc("/trading/pretrade-checks", tradingHandler.GetPreTradeChecks).Methods("GET")
+
 	// WebSocket management endpoints
 	api.HandleFunc("/trading/ws/status", tradingHandler.GetWebSocketStatus).Methods("GET")
 	api.HandleFunc("/trading/ws/subscribe", tradingHandler.Subscribe).Methods("POST")
@@ -50,6 +79,7 @@ func NewRouter(dataHandler *handlers.DataHandler, tradingHandler *handlers.Tradi
 
 	// Stock data endpoints
 	api.HandleFunc("/stocks/{code}/daily", stockHandler.GetDailyPrices).Methods("GET")
+	api.HandleFunc("/stocks/{code}/intraday", stockHandler.GetIntradayBars).Methods("GET")
 	api.HandleFunc("/stocks/{code}/investor-trading", stockHandler.GetInvestorTrading).Methods("GET")
 	api.HandleFunc("/stocks/{code}/description", stockHandler.GetStockDescription).Methods("GET")
 
@@ -71,6 +101,9 @@ func NewRouter(dataHandler *handlers.DataHandler, tradingHandler *handlers.Tradi
 	api.HandleFunc("/v1/pipeline/ranking", pipelineHandler.GetRanking).Methods("GET")
 	api.HandleFunc("/v1/pipeline/portfolio", pipelineHandler.GetPortfolio).Methods("GET")
 
+	// Stream endpoints - v1 API (WebSocket / SSE)
+	api.HandleFunc("/v1/stream/prices", streamHandler.StreamPrices).Methods("GET")
+
 	// Forecast endpoints
 	api.HandleFunc("/forecast/analyze/{symbol}", forecastHandler.AnalyzeForecast).Methods("POST")
 	api.HandleFunc("/forecast/events/{symbol}", forecastHandler.GetEvents).Methods("GET")
@@ -78,9 +111,10 @@ func NewRouter(dataHandler *handlers.DataHandler, tradingHandler *handlers.Tradi
 	// Apply middleware (order matters: CORS must wrap everything)
 	r.Use(loggingMiddleware(log))
 	r.Use(recoveryMiddleware(log))
+	r.Use(authMiddleware(authRepo, apiCfg, log))
 
 	// CORS must wrap the entire router to handle OPTIONS before route matching
-	return corsHandler(r)
+	return corsHandler(r, apiCfg.CORSOrigins)
 }
 
 // healthCheckHandler returns server health status
@@ -136,13 +170,23 @@ func recoveryMiddleware(log *logger.Logger) mux.MiddlewareFunc {
 }
 
 // corsHandler wraps the router to handle CORS before route matching
-func corsHandler(next http.Handler) http.Handler {
+// 허용 목록(API_CORS_ORIGINS)에 있는 Origin에만 CORS 헤더를 돌려줌 ("*" = 전체 허용)
+func corsHandler(next http.Handler, origins []string) http.Handler {
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers for all requests
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "86400")
+		// Set CORS headers for allowed origins
+		origin := r.Header.Get("Origin")
+		if origin != "" && (allowed["*"] || allowed[origin]) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.Header().Set("Access-Control-Max-Age", "86400")
+		}
+		w.Header().Add("Vary", "Origin")
 
 		// Handle preflight requests BEFORE route matching
 		if r.Method == http.MethodOptions {
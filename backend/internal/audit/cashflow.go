@@ -0,0 +1,166 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// CashFlowType represents a cash flow category
+type CashFlowType string
+
+const (
+	CashFlowDeposit    CashFlowType = "DEPOSIT"    // 외부 입금 (+)
+	CashFlowWithdrawal CashFlowType = "WITHDRAWAL" // 외부 출금 (-)
+	CashFlowDividend   CashFlowType = "DIVIDEND"   // 배당 수령 (+, 수익으로 계상)
+)
+
+// CashFlowSource represents how a cash flow entered the ledger
+type CashFlowSource string
+
+const (
+	CashFlowSourceManual   CashFlowSource = "MANUAL"
+	CashFlowSourceDetected CashFlowSource = "DETECTED"
+)
+
+// CashFlowStatus represents ledger entry status
+type CashFlowStatus string
+
+const (
+	CashFlowConfirmed CashFlowStatus = "CONFIRMED"
+	CashFlowPending   CashFlowStatus = "PENDING"
+	CashFlowRejected  CashFlowStatus = "REJECTED"
+)
+
+// 잔고 급변 감지 기준: |설명되지 않은 변동| >= max(최소금액, 전일 평가액 × 비율)
+const (
+	unexplainedJumpMinAmount = 100000.0
+	unexplainedJumpPct       = 0.005
+)
+
+// CashFlow represents a cash flow ledger entry
+// ⭐ SSOT: 외부 입출금/배당 원장 구조
+type CashFlow struct {
+	ID        int64          `json:"id"`
+	Date      time.Time      `json:"date"`
+	Type      CashFlowType   `json:"type"`
+	Amount    float64        `json:"amount"` // 입금/배당 +, 출금 -
+	StockCode string         `json:"stock_code,omitempty"`
+	Source    CashFlowSource `json:"source"`
+	Status    CashFlowStatus `json:"status"`
+	Memo      string         `json:"memo,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// IsExternal reports whether the flow moves capital in/out of the account
+// 배당은 포트폴리오 수익이므로 외부 현금흐름이 아님
+func (f CashFlow) IsExternal() bool {
+	return f.Type == CashFlowDeposit || f.Type == CashFlowWithdrawal
+}
+
+// Validate checks type/sign consistency
+func (f CashFlow) Validate() error {
+	switch f.Type {
+	case CashFlowDeposit, CashFlowDividend:
+		if f.Amount <= 0 {
+			return fmt.Errorf("%s amount must be positive: %.0f", f.Type, f.Amount)
+		}
+	case CashFlowWithdrawal:
+		if f.Amount >= 0 {
+			return fmt.Errorf("%s amount must be negative: %.0f", f.Type, f.Amount)
+		}
+	default:
+		return fmt.Errorf("unknown cash flow type: %s", f.Type)
+	}
+
+	if f.Type == CashFlowDividend && f.StockCode == "" {
+		return fmt.Errorf("dividend requires stock code")
+	}
+	if f.Date.IsZero() {
+		return fmt.Errorf("cash flow date is required")
+	}
+	return nil
+}
+
+// RecordCashFlow adds a manual ledger entry
+func (a *Analyzer) RecordCashFlow(ctx context.Context, flow *CashFlow) error {
+	if flow.Source == "" {
+		flow.Source = CashFlowSourceManual
+	}
+	if flow.Status == "" {
+		flow.Status = CashFlowConfirmed
+	}
+	if err := flow.Validate(); err != nil {
+		return fmt.Errorf("invalid cash flow: %w", err)
+	}
+
+	if err := a.repository.SaveCashFlow(ctx, flow); err != nil {
+		return fmt.Errorf("failed to save cash flow: %w", err)
+	}
+
+	a.logger.WithFields(map[string]interface{}{
+		"id":     flow.ID,
+		"date":   flow.Date.Format("2006-01-02"),
+		"type":   flow.Type,
+		"amount": flow.Amount,
+		"status": flow.Status,
+	}).Info("Cash flow recorded")
+
+	return nil
+}
+
+// ListCashFlows returns ledger entries for a period (all statuses)
+func (a *Analyzer) ListCashFlows(ctx context.Context, startDate, endDate time.Time) ([]CashFlow, error) {
+	return a.repository.GetCashFlows(ctx, startDate, endDate, false)
+}
+
+// ResolveCashFlow confirms or rejects a pending (detected) entry
+// 확정 시 해당 일자 이후 수익률 계산에 반영 (Analyze/GetEquityCurve는 원장 기준으로 재계산)
+func (a *Analyzer) ResolveCashFlow(ctx context.Context, id int64, status CashFlowStatus) error {
+	if status != CashFlowConfirmed && status != CashFlowRejected {
+		return fmt.Errorf("invalid status: %s", status)
+	}
+	return a.repository.UpdateCashFlowStatus(ctx, id, status)
+}
+
+// detectUnexplainedJump records a PENDING flow when the value change is not explained
+// by position P&L, dividends and confirmed flows
+func (a *Analyzer) detectUnexplainedJump(ctx context.Context, snapshot *DailySnapshot, prev *DailySnapshot, explained float64) {
+	if err := a.repository.DeletePendingDetectedCashFlows(ctx, snapshot.Date); err != nil {
+		a.logger.WithError(err).Warn("Failed to clear previously detected cash flows")
+	}
+
+	residual := snapshot.TotalValue - prev.TotalValue - explained
+	threshold := math.Max(unexplainedJumpMinAmount, prev.TotalValue*unexplainedJumpPct)
+	if math.Abs(residual) < threshold {
+		return
+	}
+
+	flowType := CashFlowDeposit
+	if residual < 0 {
+		flowType = CashFlowWithdrawal
+	}
+
+	flow := &CashFlow{
+		Date:   snapshot.Date,
+		Type:   flowType,
+		Amount: math.Round(residual),
+		Source: CashFlowSourceDetected,
+		Status: CashFlowPending,
+		Memo: fmt.Sprintf("unexplained change: value %.0f → %.0f, explained %.0f",
+			prev.TotalValue, snapshot.TotalValue, explained),
+	}
+
+	if err := a.repository.SaveCashFlow(ctx, flow); err != nil {
+		a.logger.WithError(err).Error("Failed to record detected cash flow")
+		return
+	}
+
+	a.logger.WithFields(map[string]interface{}{
+		"id":        flow.ID,
+		"date":      snapshot.Date.Format("2006-01-02"),
+		"residual":  residual,
+		"threshold": threshold,
+	}).Warn("Unexplained balance jump detected, pending cash flow recorded")
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
 )
 
@@ -29,10 +30,14 @@ type PerformanceReport struct {
 	Period      string    `json:"period"`
 	StartDate   time.Time `json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
+	AccountID   string    `json:"account_id"`
+	StrategyID  string    `json:"strategy_id,omitempty"` // 빈 값 = 계좌 전체
 
-	// 수익률
-	TotalReturn  float64 `json:"total_return"`
-	AnnualReturn float64 `json:"annual_return"`
+	// 수익률 (TotalReturn = 시간가중 TWR, 외부 입출금 영향 제외)
+	TotalReturn         float64 `json:"total_return"`
+	AnnualReturn        float64 `json:"annual_return"`
+	MoneyWeightedReturn float64 `json:"money_weighted_return"` // 금액가중 MWR (IRR, 기간 수익률)
+	NetFlow             float64 `json:"net_flow"`              // 기간 외부 순입금
 
 	// 리스크 지표
 	Volatility  float64 `json:"volatility"`
@@ -40,7 +45,9 @@ type PerformanceReport struct {
 	Sortino     float64 `json:"sortino"`
 	MaxDrawdown float64 `json:"max_drawdown"`
 
-	// 트레이딩 지표
+	// 트레이딩 지표 (실현손익 원장의 완결 라운드트립 기준)
+	TradeCount   int     `json:"trade_count"`
+	RealizedPnL  float64 `json:"realized_pnl"`
 	WinRate      float64 `json:"win_rate"`
 	AvgWin       float64 `json:"avg_win"`
 	AvgLoss      float64 `json:"avg_loss"`
@@ -54,19 +61,27 @@ type PerformanceReport struct {
 
 // Analyze performs performance analysis for a period
 func (a *Analyzer) Analyze(ctx context.Context, period string) (*PerformanceReport, error) {
-	report := &PerformanceReport{Period: period}
+	report := &PerformanceReport{Period: period, AccountID: a.repository.AccountID()}
 
 	// 기간 파싱
 	startDate, endDate := a.parsePeriod(period)
 	report.StartDate = startDate
 	report.EndDate = endDate
 
-	// 일별 수익률 조회
-	dailyReturns, err := a.repository.GetDailyReturns(ctx, startDate, endDate)
+	// 일별 수익률 조회 (입출금 원장 기준 보정)
+	base, points, err := a.getReturnSeries(ctx, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get daily returns: %w", err)
 	}
 
+	dailyReturns := make([]float64, 0, len(points))
+	for i, p := range points {
+		if i == 0 && base == nil {
+			continue // 기준 스냅샷 없음 → 첫 날은 수익률 없음
+		}
+		dailyReturns = append(dailyReturns, p.Return)
+	}
+
 	// 데이터가 없으면 빈 리포트 반환 (신규 시스템이라 데이터 없을 수 있음)
 	if len(dailyReturns) == 0 {
 		a.logger.WithFields(map[string]interface{}{
@@ -78,6 +93,7 @@ func (a *Analyzer) Analyze(ctx context.Context, period string) (*PerformanceRepo
 	// 수익률 계산
 	report.TotalReturn = a.calculateTotalReturn(dailyReturns)
 	report.AnnualReturn = a.annualize(report.TotalReturn, len(dailyReturns))
+	report.MoneyWeightedReturn, report.NetFlow = a.calculateMoneyWeighted(ctx, base, points)
 
 	// 리스크 지표
 	report.Volatility = a.calculateVolatility(dailyReturns)
@@ -91,6 +107,10 @@ func (a *Analyzer) Analyze(ctx context.Context, period string) (*PerformanceRepo
 		return nil, fmt.Errorf("failed to get trades: %w", err)
 	}
 
+	report.TradeCount = len(trades)
+	for _, t := range trades {
+		report.RealizedPnL += t.PnL
+	}
 	report.WinRate = a.calculateWinRate(trades)
 	report.AvgWin, report.AvgLoss = a.calculateAvgWinLoss(trades)
 	report.ProfitFactor = a.calculateProfitFactor(trades)
@@ -103,6 +123,7 @@ func (a *Analyzer) Analyze(ctx context.Context, period string) (*PerformanceRepo
 	a.logger.WithFields(map[string]interface{}{
 		"period":        period,
 		"total_return":  report.TotalReturn,
+		"mwr":           report.MoneyWeightedReturn,
 		"sharpe":        report.Sharpe,
 		"max_drawdown":  report.MaxDrawdown,
 		"win_rate":      report.WinRate,
@@ -141,6 +162,36 @@ func (a *Analyzer) calculateTotalReturn(dailyReturns []float64) float64 {
 	return cumReturn - 1.0
 }
 
+// calculateMoneyWeighted calculates period MWR and net external flow
+func (a *Analyzer) calculateMoneyWeighted(ctx context.Context, base *DailySnapshot, points []ReturnPoint) (float64, float64) {
+	if len(points) == 0 {
+		return 0, 0
+	}
+
+	start, startValue := points[0].Date, points[0].Value
+	if base != nil {
+		start, startValue = base.Date, base.TotalValue
+	}
+	last := points[len(points)-1]
+
+	netFlow := 0.0
+	for _, p := range points {
+		netFlow += p.NetFlow
+	}
+
+	flows, err := a.repository.GetCashFlows(ctx, start, last.Date, true)
+	if err != nil {
+		a.logger.WithError(err).Warn("Failed to get cash flows for MWR")
+		return 0, netFlow
+	}
+
+	mwr, ok := MoneyWeightedReturn(start, startValue, last.Date, last.Value, flows)
+	if !ok {
+		return 0, netFlow
+	}
+	return mwr, netFlow
+}
+
 // annualize converts return to annualized return
 func (a *Analyzer) annualize(totalReturn float64, days int) float64 {
 	if days == 0 {
@@ -320,13 +371,21 @@ func (a *Analyzer) calculateBeta(dailyReturns []float64, startDate, endDate time
 	return 1.0 // Market beta
 }
 
-// Trade represents a closed trade
+// Trade represents a closed trade (completed round trip of one buy lot)
+// PnL은 수수료/세금 차감 후 순손익
 type Trade struct {
-	Code      string
-	EntryDate time.Time
-	ExitDate  time.Time
-	EntryPrice float64
-	ExitPrice  float64
-	Quantity  int
-	PnL       float64
+	Code            string
+	StrategyID      string
+	AccountID       string
+	StrategyVersion string
+	EntryDate       time.Time
+	ExitDate        time.Time
+	EntryPrice      float64
+	ExitPrice       float64 // 분할 청산 시 수량 가중 평균
+	Quantity        int
+	HoldingDays     int
+	Fees            float64
+	Tax             float64
+	PnL             float64
+	ExitReason      contracts.ExitReason
 }
@@ -8,17 +8,33 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
 )
 
 // Repository handles audit data persistence
 // ⭐ SSOT: Audit 데이터 저장/조회는 여기서만
+// 스냅샷/현금흐름/실현손익/성과는 계좌 단위 (NewRepository = default 계좌, ForAccount로 전환)
 type Repository struct {
-	pool *pgxpool.Pool
+	pool      *pgxpool.Pool
+	accountID string
 }
 
-// NewRepository creates a new audit repository
+// NewRepository creates a new audit repository for the default account
 func NewRepository(pool *pgxpool.Pool) *Repository {
-	return &Repository{pool: pool}
+	return &Repository{pool: pool, accountID: contracts.DefaultAccountID}
+}
+
+// ForAccount returns a repository scoped to the given account
+func (r *Repository) ForAccount(accountID string) *Repository {
+	if accountID == "" {
+		accountID = contracts.DefaultAccountID
+	}
+	return &Repository{pool: r.pool, accountID: accountID}
+}
+
+// AccountID returns the account the repository is scoped to
+func (r *Repository) AccountID() string {
+	return r.accountID
 }
 
 // SaveSnapshot saves daily snapshot to database
@@ -30,19 +46,21 @@ func (r *Repository) SaveSnapshot(ctx context.Context, snapshot *DailySnapshot)
 
 	query := `
 		INSERT INTO audit.daily_snapshots (
-			date, total_value, cash, positions, daily_return, cum_return
-		) VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (date) DO UPDATE SET
+			date, total_value, cash, positions, net_flow, daily_pnl, daily_return, cum_return, account_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (account_id, date) DO UPDATE SET
 			total_value = EXCLUDED.total_value,
 			cash = EXCLUDED.cash,
 			positions = EXCLUDED.positions,
+			net_flow = EXCLUDED.net_flow,
+			daily_pnl = EXCLUDED.daily_pnl,
 			daily_return = EXCLUDED.daily_return,
 			cum_return = EXCLUDED.cum_return
 	`
 
 	_, err = r.pool.Exec(ctx, query,
 		snapshot.Date, snapshot.TotalValue, snapshot.Cash, positionsJSON,
-		snapshot.DailyReturn, snapshot.CumReturn,
+		snapshot.NetFlow, snapshot.DailyPnL, snapshot.DailyReturn, snapshot.CumReturn, r.accountID,
 	)
 
 	if err != nil {
@@ -55,17 +73,17 @@ func (r *Repository) SaveSnapshot(ctx context.Context, snapshot *DailySnapshot)
 // GetSnapshot retrieves snapshot for a specific date
 func (r *Repository) GetSnapshot(ctx context.Context, date time.Time) (*DailySnapshot, error) {
 	query := `
-		SELECT date, total_value, cash, positions, daily_return, cum_return
+		SELECT date, total_value, cash, positions, COALESCE(net_flow, 0), COALESCE(daily_pnl, 0), daily_return, cum_return
 		FROM audit.daily_snapshots
-		WHERE date = $1
+		WHERE date = $1 AND account_id = $2
 	`
 
 	var snapshot DailySnapshot
 	var positionsJSON []byte
 
-	err := r.pool.QueryRow(ctx, query, date).Scan(
+	err := r.pool.QueryRow(ctx, query, date, r.accountID).Scan(
 		&snapshot.Date, &snapshot.TotalValue, &snapshot.Cash, &positionsJSON,
-		&snapshot.DailyReturn, &snapshot.CumReturn,
+		&snapshot.NetFlow, &snapshot.DailyPnL, &snapshot.DailyReturn, &snapshot.CumReturn,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -85,9 +103,9 @@ func (r *Repository) GetSnapshot(ctx context.Context, date time.Time) (*DailySna
 // GetPreviousSnapshot retrieves the snapshot before given date
 func (r *Repository) GetPreviousSnapshot(ctx context.Context, date time.Time) (*DailySnapshot, error) {
 	query := `
-		SELECT date, total_value, cash, positions, daily_return, cum_return
+		SELECT date, total_value, cash, positions, COALESCE(net_flow, 0), COALESCE(daily_pnl, 0), daily_return, cum_return
 		FROM audit.daily_snapshots
-		WHERE date < $1
+		WHERE date < $1 AND account_id = $2
 		ORDER BY date DESC
 		LIMIT 1
 	`
@@ -95,9 +113,9 @@ func (r *Repository) GetPreviousSnapshot(ctx context.Context, date time.Time) (*
 	var snapshot DailySnapshot
 	var positionsJSON []byte
 
-	err := r.pool.QueryRow(ctx, query, date).Scan(
+	err := r.pool.QueryRow(ctx, query, date, r.accountID).Scan(
 		&snapshot.Date, &snapshot.TotalValue, &snapshot.Cash, &positionsJSON,
-		&snapshot.DailyReturn, &snapshot.CumReturn,
+		&snapshot.NetFlow, &snapshot.DailyPnL, &snapshot.DailyReturn, &snapshot.CumReturn,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -117,13 +135,13 @@ func (r *Repository) GetPreviousSnapshot(ctx context.Context, date time.Time) (*
 // GetSnapshotHistory retrieves snapshots for a date range
 func (r *Repository) GetSnapshotHistory(ctx context.Context, startDate, endDate time.Time) ([]DailySnapshot, error) {
 	query := `
-		SELECT date, total_value, cash, positions, daily_return, cum_return
+		SELECT date, total_value, cash, positions, COALESCE(net_flow, 0), COALESCE(daily_pnl, 0), daily_return, cum_return
 		FROM audit.daily_snapshots
-		WHERE date BETWEEN $1 AND $2
+		WHERE date BETWEEN $1 AND $2 AND account_id = $3
 		ORDER BY date ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, startDate, endDate)
+	rows, err := r.pool.Query(ctx, query, startDate, endDate, r.accountID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query snapshots: %w", err)
 	}
@@ -137,7 +155,7 @@ func (r *Repository) GetSnapshotHistory(ctx context.Context, startDate, endDate
 
 		err := rows.Scan(
 			&snapshot.Date, &snapshot.TotalValue, &snapshot.Cash, &positionsJSON,
-			&snapshot.DailyReturn, &snapshot.CumReturn,
+			&snapshot.NetFlow, &snapshot.DailyPnL, &snapshot.DailyReturn, &snapshot.CumReturn,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
@@ -162,11 +180,11 @@ func (r *Repository) GetDailyReturns(ctx context.Context, startDate, endDate tim
 	query := `
 		SELECT daily_return
 		FROM audit.daily_snapshots
-		WHERE date BETWEEN $1 AND $2
+		WHERE date BETWEEN $1 AND $2 AND account_id = $3
 		ORDER BY date ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, startDate, endDate)
+	rows, err := r.pool.Query(ctx, query, startDate, endDate, r.accountID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query daily returns: %w", err)
 	}
@@ -189,11 +207,250 @@ func (r *Repository) GetDailyReturns(ctx context.Context, startDate, endDate tim
 	return returns, nil
 }
 
+// SaveCashFlow inserts a cash flow ledger entry (sets flow.ID)
+func (r *Repository) SaveCashFlow(ctx context.Context, flow *CashFlow) error {
+	query := `
+		INSERT INTO audit.cash_flows (
+			flow_date, flow_type, amount, stock_code, source, status, memo, account_id
+		) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8)
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		flow.Date, string(flow.Type), flow.Amount, flow.StockCode,
+		string(flow.Source), string(flow.Status), flow.Memo, r.accountID,
+	).Scan(&flow.ID, &flow.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert cash flow: %w", err)
+	}
+
+	return nil
+}
+
+// GetCashFlows retrieves ledger entries dated within [startDate, endDate]
+func (r *Repository) GetCashFlows(ctx context.Context, startDate, endDate time.Time, confirmedOnly bool) ([]CashFlow, error) {
+	query := `
+		SELECT id, flow_date, flow_type, amount, COALESCE(stock_code, ''),
+		       source, status, COALESCE(memo, ''), created_at
+		FROM audit.cash_flows
+		WHERE flow_date BETWEEN $1::date AND $2::date
+		  AND ($3 = false OR status = 'CONFIRMED')
+		  AND account_id = $4
+		ORDER BY flow_date ASC, id ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, startDate, endDate, confirmedOnly, r.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cash flows: %w", err)
+	}
+	defer rows.Close()
+
+	flows := make([]CashFlow, 0)
+	for rows.Next() {
+		var f CashFlow
+		var flowType, source, status string
+		if err := rows.Scan(
+			&f.ID, &f.Date, &flowType, &f.Amount, &f.StockCode,
+			&source, &status, &f.Memo, &f.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan cash flow: %w", err)
+		}
+		f.Type = CashFlowType(flowType)
+		f.Source = CashFlowSource(source)
+		f.Status = CashFlowStatus(status)
+		flows = append(flows, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return flows, nil
+}
+
+// UpdateCashFlowStatus confirms or rejects a ledger entry
+func (r *Repository) UpdateCashFlowStatus(ctx context.Context, id int64, status CashFlowStatus) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE audit.cash_flows
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1
+	`, id, string(status))
+	if err != nil {
+		return fmt.Errorf("failed to update cash flow: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("cash flow not found: %d", id)
+	}
+
+	return nil
+}
+
+// DeletePendingDetectedCashFlows removes unresolved detected entries for a date
+// (스냅샷 재실행 시 중복 감지 방지)
+func (r *Repository) DeletePendingDetectedCashFlows(ctx context.Context, date time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM audit.cash_flows
+		WHERE flow_date = $1::date AND source = 'DETECTED' AND status = 'PENDING'
+		  AND account_id = $2
+	`, date, r.accountID)
+	if err != nil {
+		return fmt.Errorf("failed to delete detected cash flows: %w", err)
+	}
+
+	return nil
+}
+
 // GetTrades retrieves closed trades for a period
+// 마지막 청산이 기간 내인 매수 랏의 전체 매칭 내역을 읽어 완결 라운드트립으로 묶음
 func (r *Repository) GetTrades(ctx context.Context, startDate, endDate time.Time) ([]Trade, error) {
-	// TODO: Implement actual trade retrieval
-	// For now, return empty slice
-	return []Trade{}, nil
+	query := closedLotSelect + `
+		WHERE account_id = $3 AND buy_trade_id IN (
+			SELECT buy_trade_id
+			FROM audit.closed_lots
+			WHERE account_id = $3
+			GROUP BY buy_trade_id
+			HAVING MAX(exit_time)::date BETWEEN $1 AND $2
+		)
+		ORDER BY exit_time ASC, id ASC
+	`
+
+	lots, err := r.queryClosedLots(ctx, query, startDate, endDate, r.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	return BuildRoundTrips(lots), nil
+}
+
+// GetFills retrieves all executions with order attribution (FIFO 매칭 입력)
+func (r *Repository) GetFills(ctx context.Context) ([]Fill, error) {
+	query := `
+		SELECT t.id, t.stock_code, t.trade_action, t.trade_time, t.trade_price, t.trade_qty,
+		       COALESCE(t.commission, 0), COALESCE(t.tax, 0),
+		       COALESCE(o.exit_reason, ''), COALESCE(o.strategy_version, ''),
+		       o.account_id, o.strategy_id
+		FROM execution.trades t
+		JOIN execution.orders o ON o.id = t.order_id
+		UNION ALL
+		-- 전략 간 상계(NETTED): 브로커 체결 없이 상계가로 전략 간 이전 (음수 ID, 수수료 없음)
+		SELECT -o.id, o.stock_code, o.order_action, o.created_at, o.order_price, o.order_qty,
+		       0, 0, COALESCE(o.exit_reason, ''), COALESCE(o.strategy_version, ''),
+		       o.account_id, o.strategy_id
+		FROM execution.orders o
+		WHERE o.status = 'NETTED'
+		ORDER BY 4 ASC, 1 ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fills: %w", err)
+	}
+	defer rows.Close()
+
+	fills := make([]Fill, 0)
+	for rows.Next() {
+		var f Fill
+		if err := rows.Scan(
+			&f.TradeID, &f.Code, &f.Side, &f.Time, &f.Price, &f.Quantity,
+			&f.Commission, &f.Tax, &f.ExitReason, &f.StrategyVersion,
+			&f.AccountID, &f.StrategyID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan fill: %w", err)
+		}
+		fills = append(fills, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return fills, nil
+}
+
+// ReplaceClosedLots replaces the trade ledger with a freshly matched set
+func (r *Repository) ReplaceClosedLots(ctx context.Context, lots []ClosedLot) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM audit.closed_lots"); err != nil {
+		return fmt.Errorf("failed to clear closed lots: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"audit", "closed_lots"},
+		[]string{
+			"buy_trade_id", "sell_trade_id", "stock_code", "strategy_version",
+			"entry_time", "exit_time", "entry_price", "exit_price", "entry_qty", "quantity",
+			"holding_days", "buy_fee", "sell_fee", "tax", "gross_pnl", "net_pnl", "exit_reason",
+			"account_id", "strategy_id",
+		},
+		pgx.CopyFromSlice(len(lots), func(i int) ([]interface{}, error) {
+			l := lots[i]
+			return []interface{}{
+				l.BuyTradeID, l.SellTradeID, l.Code, l.StrategyVersion,
+				l.EntryTime, l.ExitTime, l.EntryPrice, l.ExitPrice, l.EntryQty, l.Quantity,
+				l.HoldingDays, l.BuyFee, l.SellFee, l.Tax, l.GrossPnL, l.NetPnL, string(l.ExitReason),
+				l.AccountID, l.StrategyID,
+			}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to copy closed lots: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetClosedLots retrieves closed lots with exit time in the period
+func (r *Repository) GetClosedLots(ctx context.Context, startDate, endDate time.Time) ([]ClosedLot, error) {
+	query := closedLotSelect + `
+		WHERE exit_time::date BETWEEN $1 AND $2 AND account_id = $3
+		ORDER BY exit_time ASC, id ASC
+	`
+
+	lots, err := r.queryClosedLots(ctx, query, startDate, endDate, r.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closed lots: %w", err)
+	}
+	return lots, nil
+}
+
+const closedLotSelect = `
+		SELECT buy_trade_id, sell_trade_id, stock_code, strategy_version,
+		       entry_time, exit_time, entry_price, exit_price, entry_qty, quantity,
+		       holding_days, buy_fee, sell_fee, tax, gross_pnl, net_pnl, exit_reason,
+		       account_id, strategy_id
+		FROM audit.closed_lots`
+
+func (r *Repository) queryClosedLots(ctx context.Context, query string, args ...interface{}) ([]ClosedLot, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := make([]ClosedLot, 0)
+	for rows.Next() {
+		var l ClosedLot
+		if err := rows.Scan(
+			&l.BuyTradeID, &l.SellTradeID, &l.Code, &l.StrategyVersion,
+			&l.EntryTime, &l.ExitTime, &l.EntryPrice, &l.ExitPrice, &l.EntryQty, &l.Quantity,
+			&l.HoldingDays, &l.BuyFee, &l.SellFee, &l.Tax, &l.GrossPnL, &l.NetPnL, &l.ExitReason,
+			&l.AccountID, &l.StrategyID,
+		); err != nil {
+			return nil, err
+		}
+		lots = append(lots, l)
+	}
+
+	return lots, rows.Err()
 }
 
 // SavePerformanceReport saves performance report to database
@@ -202,9 +459,9 @@ func (r *Repository) SavePerformanceReport(ctx context.Context, report *Performa
 		INSERT INTO audit.performance_reports (
 			report_date, period_start, period_end, total_return, benchmark_return,
 			alpha, beta, sharpe_ratio, volatility, max_drawdown,
-			win_rate, avg_win, avg_loss, profit_factor
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-		ON CONFLICT (report_date) DO UPDATE SET
+			win_rate, avg_win, avg_loss, profit_factor, account_id, strategy_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (report_date, account_id, strategy_id) DO UPDATE SET
 			period_start = EXCLUDED.period_start,
 			period_end = EXCLUDED.period_end,
 			total_return = EXCLUDED.total_return,
@@ -225,6 +482,7 @@ func (r *Repository) SavePerformanceReport(ctx context.Context, report *Performa
 		report.TotalReturn, report.Benchmark, report.Alpha, report.Beta,
 		report.Sharpe, report.Volatility, report.MaxDrawdown,
 		report.WinRate, report.AvgWin, report.AvgLoss, report.ProfitFactor,
+		r.accountID, report.StrategyID,
 	)
 
 	if err != nil {
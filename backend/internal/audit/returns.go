@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ReturnPoint represents a cash-flow-adjusted daily return
+type ReturnPoint struct {
+	Date      time.Time `json:"date"`
+	Value     float64   `json:"value"`
+	NetFlow   float64   `json:"net_flow"`   // 외부 순입금 (확정분)
+	PnL       float64   `json:"pnl"`        // 평가액 변동 - 순입금
+	Return    float64   `json:"return"`     // 일일 TWR (Modified Dietz)
+	CumReturn float64   `json:"cum_return"` // 누적 TWR 배수 (시작 1.0)
+}
+
+// BuildReturnSeries computes time-weighted returns from snapshots and the cash flow ledger
+// ⭐ SSOT: 현금흐름 보정 수익률 계산은 여기서만
+// base는 기간 직전 스냅샷 (nil이면 첫 스냅샷이 기준, 수익률 0)
+// flows 중 확정된 외부 입출금만 반영, (이전 스냅샷 일자, 당일] 구간에 귀속
+func BuildReturnSeries(base *DailySnapshot, snapshots []DailySnapshot, flows []CashFlow) []ReturnPoint {
+	points := make([]ReturnPoint, 0, len(snapshots))
+	if len(snapshots) == 0 {
+		return points
+	}
+
+	prev := base
+	cum := 1.0
+	for i := range snapshots {
+		s := &snapshots[i]
+		point := ReturnPoint{Date: s.Date, Value: s.TotalValue, CumReturn: cum}
+
+		if prev != nil {
+			point.NetFlow = netExternalFlow(flows, prev.Date, s.Date)
+			point.PnL = s.TotalValue - prev.TotalValue - point.NetFlow
+			point.Return = modifiedDietz(prev.TotalValue, s.TotalValue, point.NetFlow)
+			cum *= 1 + point.Return
+			point.CumReturn = cum
+		}
+
+		points = append(points, point)
+		prev = s
+	}
+
+	return points
+}
+
+// modifiedDietz returns the daily return with the flow weighted at mid-day
+// r = (V1 - V0 - F) / (V0 + 0.5F)
+func modifiedDietz(prevValue, currValue, flow float64) float64 {
+	denom := prevValue + 0.5*flow
+	if denom <= 0 {
+		return 0
+	}
+	return (currValue - prevValue - flow) / denom
+}
+
+// netExternalFlow sums confirmed external flows dated in (after, upTo]
+func netExternalFlow(flows []CashFlow, after, upTo time.Time) float64 {
+	from := dateKey(after)
+	to := dateKey(upTo)
+
+	sum := 0.0
+	for _, f := range flows {
+		if !f.IsExternal() || f.Status != CashFlowConfirmed {
+			continue
+		}
+		d := dateKey(f.Date)
+		if d > from && d <= to {
+			sum += f.Amount
+		}
+	}
+	return sum
+}
+
+// dividendsOn returns confirmed dividends by stock dated in (after, upTo]
+func dividendsOn(flows []CashFlow, after, upTo time.Time) map[string]float64 {
+	from := dateKey(after)
+	to := dateKey(upTo)
+
+	result := make(map[string]float64)
+	for _, f := range flows {
+		if f.Type != CashFlowDividend || f.Status != CashFlowConfirmed {
+			continue
+		}
+		d := dateKey(f.Date)
+		if d > from && d <= to {
+			result[f.StockCode] += f.Amount
+		}
+	}
+	return result
+}
+
+// dateKey normalizes a DATE value (YYYY-MM-DD)
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// MoneyWeightedReturn computes the period MWR (IRR) from start/end values and external flows
+// 투자자 관점: 시작 평가액과 입금은 투입(-), 종료 평가액은 회수(+)
+// 반환값은 기간 수익률 (연환산 IRR을 기간으로 환산), 해가 없으면 ok=false
+func MoneyWeightedReturn(start time.Time, startValue float64, end time.Time, endValue float64, flows []CashFlow) (float64, bool) {
+	years := end.Sub(start).Hours() / 24 / 365
+	if years <= 0 || startValue <= 0 {
+		return 0, false
+	}
+
+	type flowAt struct {
+		amount float64
+		t      float64 // 시작 이후 경과 연수
+	}
+	external := make([]flowAt, 0)
+	from, to := dateKey(start), dateKey(end)
+	for _, f := range flows {
+		if !f.IsExternal() || f.Status != CashFlowConfirmed {
+			continue
+		}
+		d := dateKey(f.Date)
+		if d > from && d <= to {
+			external = append(external, flowAt{amount: f.Amount, t: f.Date.Sub(start).Hours() / 24 / 365})
+		}
+	}
+
+	// g(r) = VT - V0(1+r)^T - Σ F_i(1+r)^(T-t_i), 기말 기준 미래가치
+	g := func(r float64) float64 {
+		v := endValue - startValue*math.Pow(1+r, years)
+		for _, f := range external {
+			v -= f.amount * math.Pow(1+r, years-f.t)
+		}
+		return v
+	}
+
+	lo, hi := -0.9999, 100.0
+	glo, ghi := g(lo), g(hi)
+	if math.IsNaN(glo) || math.IsNaN(ghi) || glo*ghi > 0 {
+		return 0, false
+	}
+
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		gm := g(mid)
+		if math.Abs(gm) < 1e-6 {
+			lo, hi = mid, mid
+			break
+		}
+		if (gm > 0) == (glo > 0) {
+			lo, glo = mid, gm
+		} else {
+			hi = mid
+		}
+	}
+
+	irr := (lo + hi) / 2
+	return math.Pow(1+irr, years) - 1, true
+}
+
+// attributePositionPnL fills per-position daily P&L components
+// 가격(평가손익 변동) + 실현 + 배당 = 일일 손익, 전량 매도/배당만 있는 종목은 수량 0 항목으로 추가
+// 매도가는 체결 원장이 없으므로 당일 현재가(전량 매도 시 전일 가격)로 근사
+func attributePositionPnL(prev []PositionSnapshot, curr []PositionSnapshot, dividends map[string]float64) []PositionSnapshot {
+	prevByCode := make(map[string]PositionSnapshot, len(prev))
+	for _, p := range prev {
+		prevByCode[p.Code] = p
+	}
+
+	result := make([]PositionSnapshot, 0, len(curr))
+	seen := make(map[string]bool, len(curr))
+
+	for _, c := range curr {
+		p, hadPrev := prevByCode[c.Code]
+		if hadPrev {
+			c.PricePnL, c.RealizedPnL = splitPnL(&p, &c)
+		} else {
+			c.PricePnL, c.RealizedPnL = splitPnL(nil, &c)
+		}
+		c.DividendPnL = dividends[c.Code]
+		c.DailyPnL = c.PricePnL + c.RealizedPnL + c.DividendPnL
+
+		result = append(result, c)
+		seen[c.Code] = true
+	}
+
+	// 전량 매도된 종목
+	for _, p := range prev {
+		if seen[p.Code] || p.Quantity == 0 {
+			continue
+		}
+		closed := PositionSnapshot{Code: p.Code, Name: p.Name, Price: p.Price}
+		closed.PricePnL, closed.RealizedPnL = splitPnL(&p, &closed)
+		closed.DividendPnL = dividends[p.Code]
+		closed.DailyPnL = closed.PricePnL + closed.RealizedPnL + closed.DividendPnL
+
+		result = append(result, closed)
+		seen[p.Code] = true
+	}
+
+	// 미보유 종목 배당 (배당락 이후 매도 등)
+	codes := make([]string, 0)
+	for code := range dividends {
+		if !seen[code] {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		result = append(result, PositionSnapshot{
+			Code:        code,
+			DividendPnL: dividends[code],
+			DailyPnL:    dividends[code],
+		})
+	}
+
+	return result
+}
+
+// splitPnL returns (Δ평가손익, 실현손익) for one position
+func splitPnL(prev *PositionSnapshot, curr *PositionSnapshot) (float64, float64) {
+	if prev == nil {
+		return (curr.Price - costBasis(curr.AvgPrice, curr.Price)) * float64(curr.Quantity), 0
+	}
+
+	// 평단가 미기록(구 스냅샷) → 당일 평단가 또는 전일 가격으로 대체
+	prevAvg := prev.AvgPrice
+	if prevAvg == 0 {
+		prevAvg = prev.Price
+		if curr.Quantity > 0 && curr.AvgPrice > 0 {
+			prevAvg = curr.AvgPrice
+		}
+	}
+	currAvg := curr.AvgPrice
+	if currAvg == 0 {
+		currAvg = prevAvg
+	}
+
+	exitPrice := curr.Price
+	if curr.Quantity == 0 {
+		exitPrice = prev.Price
+	}
+
+	soldQty := prev.Quantity - curr.Quantity
+	realized := 0.0
+	if soldQty > 0 {
+		realized = float64(soldQty) * (exitPrice - prevAvg)
+	}
+
+	unrealizedPrev := (prev.Price - prevAvg) * float64(prev.Quantity)
+	unrealizedCurr := (curr.Price - currAvg) * float64(curr.Quantity)
+
+	return unrealizedCurr - unrealizedPrev, realized
+}
+
+// costBasis returns avg price, or price when unknown
+func costBasis(avgPrice, price float64) float64 {
+	if avgPrice > 0 {
+		return avgPrice
+	}
+	return price
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBuildReturnSeries_ExcludesExternalFlows(t *testing.T) {
+	base := &DailySnapshot{Date: day(1), TotalValue: 10_000_000}
+	snapshots := []DailySnapshot{
+		{Date: day(2), TotalValue: 10_100_000}, // +1%
+		{Date: day(3), TotalValue: 15_100_000}, // 5,000,000 입금, 손익 0
+		{Date: day(4), TotalValue: 14_600_000}, // 출금 -1,000,000 + 손익 500,000
+	}
+	flows := []CashFlow{
+		{Date: day(3), Type: CashFlowDeposit, Amount: 5_000_000, Status: CashFlowConfirmed},
+		{Date: day(4), Type: CashFlowWithdrawal, Amount: -1_000_000, Status: CashFlowConfirmed},
+		{Date: day(4), Type: CashFlowDeposit, Amount: 9_999_999, Status: CashFlowPending},                      // 미확정 → 무시
+		{Date: day(4), Type: CashFlowDividend, Amount: 50_000, StockCode: "005930", Status: CashFlowConfirmed}, // 수익
+	}
+
+	points := BuildReturnSeries(base, snapshots, flows)
+	require.Len(t, points, 3)
+
+	assert.InDelta(t, 0.01, points[0].Return, 1e-9)
+	assert.Equal(t, 0.0, points[0].NetFlow)
+
+	assert.Equal(t, 5_000_000.0, points[1].NetFlow)
+	assert.InDelta(t, 0.0, points[1].PnL, 1e-6)
+	assert.InDelta(t, 0.0, points[1].Return, 1e-9)
+
+	assert.Equal(t, -1_000_000.0, points[2].NetFlow)
+	assert.InDelta(t, 500_000.0, points[2].PnL, 1e-6)
+	assert.InDelta(t, 500_000.0/(15_100_000-500_000), points[2].Return, 1e-9)
+
+	assert.InDelta(t, 1.01*(1+points[2].Return), points[2].CumReturn, 1e-9)
+}
+
+func TestBuildReturnSeries_NoBase(t *testing.T) {
+	points := BuildReturnSeries(nil, []DailySnapshot{
+		{Date: day(2), TotalValue: 1_000_000},
+		{Date: day(3), TotalValue: 1_050_000},
+	}, nil)
+
+	require.Len(t, points, 2)
+	assert.Equal(t, 0.0, points[0].Return)
+	assert.Equal(t, 1.0, points[0].CumReturn)
+	assert.InDelta(t, 0.05, points[1].Return, 1e-9)
+}
+
+func TestMoneyWeightedReturn(t *testing.T) {
+	start := day(1)
+	end := start.AddDate(1, 0, 0)
+
+	// 입출금 없음 → MWR = 단순 수익률
+	mwr, ok := MoneyWeightedReturn(start, 10_000_000, end, 11_000_000, nil)
+	require.True(t, ok)
+	assert.InDelta(t, 0.10, mwr, 1e-4)
+
+	// 기말 직전 입금은 투자 기간이 짧아 MWR에 거의 영향 없음
+	flows := []CashFlow{
+		{Date: end.AddDate(0, 0, -1), Type: CashFlowDeposit, Amount: 10_000_000, Status: CashFlowConfirmed},
+	}
+	mwr, ok = MoneyWeightedReturn(start, 10_000_000, end, 21_000_000, flows)
+	require.True(t, ok)
+	assert.InDelta(t, 0.10, mwr, 1e-3)
+
+	_, ok = MoneyWeightedReturn(start, 0, end, 1_000, nil)
+	assert.False(t, ok)
+}
+
+func TestAttributePositionPnL(t *testing.T) {
+	prev := []PositionSnapshot{
+		{Code: "005930", Quantity: 10, AvgPrice: 100, Price: 110}, // 일부 매도
+		{Code: "000660", Quantity: 5, AvgPrice: 200, Price: 210},  // 전량 매도
+		{Code: "035420", Quantity: 10, AvgPrice: 100, Price: 110}, // 추가 매수
+	}
+	curr := []PositionSnapshot{
+		{Code: "005930", Quantity: 4, AvgPrice: 100, Price: 112},
+		{Code: "035420", Quantity: 20, AvgPrice: 106, Price: 112},
+		{Code: "051910", Quantity: 1, AvgPrice: 500, Price: 510}, // 신규
+	}
+	dividends := map[string]float64{"005930": 361, "068270": 100}
+
+	result := attributePositionPnL(prev, curr, dividends)
+	byCode := make(map[string]PositionSnapshot)
+	for _, p := range result {
+		byCode[p.Code] = p
+	}
+	require.Len(t, result, 5)
+
+	// 10주 × (112-110) = 20 = 가격 -52 + 실현 72
+	samsung := byCode["005930"]
+	assert.InDelta(t, 72.0, samsung.RealizedPnL, 1e-9)
+	assert.InDelta(t, -52.0, samsung.PricePnL, 1e-9)
+	assert.InDelta(t, 361.0, samsung.DividendPnL, 1e-9)
+	assert.InDelta(t, 381.0, samsung.DailyPnL, 1e-9)
+
+	// 전량 매도 (매도가 = 전일 가격 근사) → 일일 손익 0, 누적 평가익이 실현으로 전환
+	hynix := byCode["000660"]
+	assert.Equal(t, 0, hynix.Quantity)
+	assert.InDelta(t, 50.0, hynix.RealizedPnL, 1e-9)
+	assert.InDelta(t, 0.0, hynix.DailyPnL, 1e-9)
+
+	// 기존 10주 × 2 + 신규 10주 (112 매수) 0
+	assert.InDelta(t, 20.0, byCode["035420"].DailyPnL, 1e-9)
+	assert.InDelta(t, 10.0, byCode["051910"].PricePnL, 1e-9)
+	assert.InDelta(t, 100.0, byCode["068270"].DividendPnL, 1e-9)
+}
@@ -14,19 +14,26 @@ type DailySnapshot struct {
 	TotalValue  float64             `json:"total_value"`
 	Cash        float64             `json:"cash"`
 	Positions   []PositionSnapshot  `json:"positions"`
-	DailyReturn float64             `json:"daily_return"`
+	NetFlow     float64             `json:"net_flow"`     // 외부 순입금 (확정분)
+	DailyPnL    float64             `json:"daily_pnl"`    // 평가액 변동 - 순입금
+	DailyReturn float64             `json:"daily_return"` // 일일 TWR (Modified Dietz)
 	CumReturn   float64             `json:"cum_return"`
 }
 
 // PositionSnapshot represents position snapshot
+// DailyPnL = PricePnL(평가손익 변동) + RealizedPnL + DividendPnL
 type PositionSnapshot struct {
-	Code     string  `json:"code"`
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
-	Value    float64 `json:"value"`
-	Weight   float64 `json:"weight"`
-	DailyPnL float64 `json:"daily_pnl"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Quantity    int     `json:"quantity"`
+	AvgPrice    float64 `json:"avg_price"`
+	Price       float64 `json:"price"`
+	Value       float64 `json:"value"`
+	Weight      float64 `json:"weight"`
+	DailyPnL    float64 `json:"daily_pnl"`
+	PricePnL    float64 `json:"price_pnl"`
+	RealizedPnL float64 `json:"realized_pnl"`
+	DividendPnL float64 `json:"dividend_pnl"`
 }
 
 // SaveSnapshot saves daily portfolio snapshot
@@ -53,24 +60,49 @@ func (a *Analyzer) SaveSnapshot(ctx context.Context, broker execution.Broker) er
 
 	// 포지션 스냅샷
 	for _, h := range holdings {
+		weight := 0.0
+		if snapshot.TotalValue > 0 {
+			weight = h.MarketValue / snapshot.TotalValue
+		}
 		snapshot.Positions = append(snapshot.Positions, PositionSnapshot{
 			Code:     h.Code,
 			Name:     h.Name,
 			Quantity: h.Qty,
+			AvgPrice: h.AvgPrice,
 			Price:    h.CurrentPrice,
 			Value:    h.MarketValue,
-			Weight:   h.MarketValue / snapshot.TotalValue,
-			DailyPnL: 0, // TODO: Calculate daily PnL
+			Weight:   weight,
 		})
 	}
 
-	// 일일 수익률 계산
+	// 일일 수익률 계산 (외부 입출금 제외한 TWR)
 	prevSnapshot, err := a.repository.GetPreviousSnapshot(ctx, snapshot.Date)
-	if err == nil && prevSnapshot != nil {
-		if prevSnapshot.TotalValue > 0 {
-			snapshot.DailyReturn = (snapshot.TotalValue - prevSnapshot.TotalValue) / prevSnapshot.TotalValue
-			snapshot.CumReturn = prevSnapshot.CumReturn * (1 + snapshot.DailyReturn)
+	if err != nil {
+		return fmt.Errorf("failed to get previous snapshot: %w", err)
+	}
+
+	if prevSnapshot != nil {
+		flows, err := a.repository.GetCashFlows(ctx, prevSnapshot.Date, snapshot.Date, true)
+		if err != nil {
+			return fmt.Errorf("failed to get cash flows: %w", err)
 		}
+
+		points := BuildReturnSeries(prevSnapshot, []DailySnapshot{*snapshot}, flows)
+		snapshot.NetFlow = points[0].NetFlow
+		snapshot.DailyPnL = points[0].PnL
+		snapshot.DailyReturn = points[0].Return
+		snapshot.CumReturn = prevSnapshot.CumReturn * (1 + snapshot.DailyReturn)
+
+		// 종목별 손익 분해 (가격/실현/배당)
+		snapshot.Positions = attributePositionPnL(prevSnapshot.Positions, snapshot.Positions,
+			dividendsOn(flows, prevSnapshot.Date, snapshot.Date))
+
+		// 잔고 급변 감지: 종목 손익 + 확정 입출금으로 설명되지 않는 변동 → PENDING 원장 기록
+		explained := snapshot.NetFlow
+		for _, p := range snapshot.Positions {
+			explained += p.DailyPnL
+		}
+		a.detectUnexplainedJump(ctx, snapshot, prevSnapshot, explained)
 	} else {
 		// 첫 스냅샷
 		snapshot.DailyReturn = 0
@@ -85,6 +117,7 @@ func (a *Analyzer) SaveSnapshot(ctx context.Context, broker execution.Broker) er
 	a.logger.WithFields(map[string]interface{}{
 		"date":         snapshot.Date.Format("2006-01-02"),
 		"total_value":  snapshot.TotalValue,
+		"net_flow":     snapshot.NetFlow,
 		"daily_return": snapshot.DailyReturn,
 		"positions":    len(snapshot.Positions),
 	}).Info("Snapshot saved")
@@ -103,29 +136,56 @@ func (a *Analyzer) GetSnapshotHistory(ctx context.Context, startDate, endDate ti
 }
 
 // GetEquityCurve generates equity curve from snapshots
+// 저장된 daily_return 대신 현재 원장(확정 입출금) 기준으로 재계산 → 사후 확정된 입출금도 반영
 func (a *Analyzer) GetEquityCurve(ctx context.Context, startDate, endDate time.Time) ([]EquityPoint, error) {
-	snapshots, err := a.repository.GetSnapshotHistory(ctx, startDate, endDate)
+	_, points, err := a.getReturnSeries(ctx, startDate, endDate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get snapshot history: %w", err)
+		return nil, err
 	}
 
-	curve := make([]EquityPoint, 0, len(snapshots))
-	for _, s := range snapshots {
+	curve := make([]EquityPoint, 0, len(points))
+	for _, p := range points {
 		curve = append(curve, EquityPoint{
-			Date:       s.Date,
-			Value:      s.TotalValue,
-			Return:     s.DailyReturn,
-			CumReturn:  s.CumReturn,
+			Date:      p.Date,
+			Value:     p.Value,
+			NetFlow:   p.NetFlow,
+			Return:    p.Return,
+			CumReturn: p.CumReturn,
 		})
 	}
 
 	return curve, nil
 }
 
+// getReturnSeries loads snapshots (with the preceding base) and confirmed flows for a period
+func (a *Analyzer) getReturnSeries(ctx context.Context, startDate, endDate time.Time) (*DailySnapshot, []ReturnPoint, error) {
+	base, err := a.repository.GetPreviousSnapshot(ctx, startDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base snapshot: %w", err)
+	}
+
+	snapshots, err := a.repository.GetSnapshotHistory(ctx, startDate, endDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get snapshot history: %w", err)
+	}
+
+	flowStart := startDate
+	if base != nil {
+		flowStart = base.Date
+	}
+	flows, err := a.repository.GetCashFlows(ctx, flowStart, endDate, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cash flows: %w", err)
+	}
+
+	return base, BuildReturnSeries(base, snapshots, flows), nil
+}
+
 // EquityPoint represents a point on equity curve
 type EquityPoint struct {
 	Date      time.Time `json:"date"`
 	Value     float64   `json:"value"`
+	NetFlow   float64   `json:"net_flow"`
 	Return    float64   `json:"return"`
 	CumReturn float64   `json:"cum_return"`
 }
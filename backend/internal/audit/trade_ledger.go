@@ -0,0 +1,450 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+// Fill represents one execution (execution.trades row) with order attribution
+type Fill struct {
+	TradeID         int64                `json:"trade_id"`
+	Code            string               `json:"code"`
+	Side            contracts.OrderSide  `json:"side"`
+	Time            time.Time            `json:"time"`
+	Price           float64              `json:"price"`
+	Quantity        int                  `json:"quantity"`
+	Commission      float64              `json:"commission"`
+	Tax             float64              `json:"tax"`
+	ExitReason      contracts.ExitReason `json:"exit_reason,omitempty"`      // SELL 주문의 청산 사유
+	StrategyVersion string               `json:"strategy_version,omitempty"` // 주문 생성 전략 버전
+	StrategyID      string               `json:"strategy_id,omitempty"`      // 주문 생성 전략 (수동/청산 주문은 빈 값)
+	AccountID       string               `json:"account_id,omitempty"`       // 주문 계좌 (빈 값 = default)
+}
+
+// ClosedLot represents a (buy lot, sell fill) match
+// ⭐ SSOT: 실현손익 원장 단위 (FIFO 매칭 결과)
+type ClosedLot struct {
+	BuyTradeID      int64                `json:"buy_trade_id"`
+	SellTradeID     int64                `json:"sell_trade_id"`
+	Code            string               `json:"code"`
+	StrategyVersion string               `json:"strategy_version"` // 매수 랏 기준
+	StrategyID      string               `json:"strategy_id"`      // 매수 랏 기준
+	AccountID       string               `json:"account_id"`
+	EntryTime       time.Time            `json:"entry_time"`
+	ExitTime        time.Time            `json:"exit_time"`
+	EntryPrice      float64              `json:"entry_price"`
+	ExitPrice       float64              `json:"exit_price"`
+	EntryQty        int                  `json:"entry_qty"` // 원 매수 랏 수량
+	Quantity        int                  `json:"quantity"`  // 매칭 수량
+	HoldingDays     int                  `json:"holding_days"`
+	BuyFee          float64              `json:"buy_fee"`
+	SellFee         float64              `json:"sell_fee"`
+	Tax             float64              `json:"tax"`
+	GrossPnL        float64              `json:"gross_pnl"`
+	NetPnL          float64              `json:"net_pnl"`
+	ExitReason      contracts.ExitReason `json:"exit_reason"`
+}
+
+// OpenLot represents the unmatched remainder of a buy fill
+type OpenLot struct {
+	TradeID         int64     `json:"trade_id"`
+	Code            string    `json:"code"`
+	StrategyVersion string    `json:"strategy_version"`
+	StrategyID      string    `json:"strategy_id"`
+	AccountID       string    `json:"account_id"`
+	EntryTime       time.Time `json:"entry_time"`
+	EntryPrice      float64   `json:"entry_price"`
+	EntryQty        int       `json:"entry_qty"`
+	Remaining       int       `json:"remaining"`
+	Fee             float64   `json:"fee"` // 잔여 수량에 배분된 매수 수수료
+}
+
+// StrategyHolding represents a strategy's open position derived from the ledger
+type StrategyHolding struct {
+	AccountID  string  `json:"account_id"`
+	StrategyID string  `json:"strategy_id"`
+	Code       string  `json:"code"`
+	Quantity   int     `json:"quantity"`
+	AvgPrice   float64 `json:"avg_price"`
+	Cost       float64 `json:"cost"`
+}
+
+// LedgerResult holds the FIFO matching output
+type LedgerResult struct {
+	Closed    []ClosedLot `json:"closed"`
+	Open      []OpenLot   `json:"open"`
+	Unmatched []Fill      `json:"unmatched"` // 매수 이력 없는 매도 잔량 (원장 이전 보유분 등)
+}
+
+// StrategyPnL represents realized P&L aggregated by account, strategy and strategy version
+type StrategyPnL struct {
+	AccountID       string  `json:"account_id"`
+	StrategyID      string  `json:"strategy_id"`
+	StrategyVersion string  `json:"strategy_version"`
+	ClosedLots      int     `json:"closed_lots"`
+	Quantity        int     `json:"quantity"`
+	GrossPnL        float64 `json:"gross_pnl"`
+	Fees            float64 `json:"fees"`
+	Tax             float64 `json:"tax"`
+	NetPnL          float64 `json:"net_pnl"`
+	Wins            int     `json:"wins"`
+	Losses          int     `json:"losses"`
+}
+
+// MatchFIFO matches sell fills to buy lots first-in-first-out per account and stock
+// ⭐ SSOT: 매수 랏 ↔ 매도 매칭 규칙은 여기서만
+// 분할 청산(TP1/TP2/TP3)은 랏별 ClosedLot으로 나뉘며, 수수료/세금은 수량 비율로 안분
+// 전략 주문 매도는 같은 전략 랏 → 전략 미지정 랏 순, 전략 미지정 매도(수동/청산)는 계좌 내 전체 랏 FIFO
+func MatchFIFO(fills []Fill) *LedgerResult {
+	sorted := make([]Fill, len(fills))
+	copy(sorted, fills)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Time.Equal(sorted[j].Time) {
+			return sorted[i].Time.Before(sorted[j].Time)
+		}
+		return sorted[i].TradeID < sorted[j].TradeID
+	})
+
+	result := &LedgerResult{
+		Closed:    make([]ClosedLot, 0),
+		Open:      make([]OpenLot, 0),
+		Unmatched: make([]Fill, 0),
+	}
+	type lotKey struct{ account, code string }
+	queues := make(map[lotKey][]*OpenLot)
+
+	for _, f := range sorted {
+		if f.Quantity <= 0 {
+			continue
+		}
+		account := f.AccountID
+		if account == "" {
+			account = contracts.DefaultAccountID
+		}
+		key := lotKey{account, f.Code}
+
+		switch f.Side {
+		case contracts.OrderSideBuy:
+			queues[key] = append(queues[key], &OpenLot{
+				TradeID:         f.TradeID,
+				Code:            f.Code,
+				StrategyVersion: f.StrategyVersion,
+				StrategyID:      f.StrategyID,
+				AccountID:       account,
+				EntryTime:       f.Time,
+				EntryPrice:      f.Price,
+				EntryQty:        f.Quantity,
+				Remaining:       f.Quantity,
+				Fee:             f.Commission,
+			})
+
+		case contracts.OrderSideSell:
+			remaining := f.Quantity
+			sellFee, tax := f.Commission, f.Tax
+			queue := queues[key]
+
+			for _, lot := range matchOrder(queue, f.StrategyID) {
+				if remaining == 0 {
+					break
+				}
+				qty := min(remaining, lot.Remaining)
+
+				buyFee := prorate(lot.Fee, qty, lot.Remaining)
+				lotSellFee := prorate(sellFee, qty, remaining)
+				lotTax := prorate(tax, qty, remaining)
+				gross := (f.Price - lot.EntryPrice) * float64(qty)
+
+				result.Closed = append(result.Closed, ClosedLot{
+					BuyTradeID:      lot.TradeID,
+					SellTradeID:     f.TradeID,
+					Code:            f.Code,
+					StrategyVersion: lot.StrategyVersion,
+					StrategyID:      lot.StrategyID,
+					AccountID:       account,
+					EntryTime:       lot.EntryTime,
+					ExitTime:        f.Time,
+					EntryPrice:      lot.EntryPrice,
+					ExitPrice:       f.Price,
+					EntryQty:        lot.EntryQty,
+					Quantity:        qty,
+					HoldingDays:     holdingDays(lot.EntryTime, f.Time),
+					BuyFee:          buyFee,
+					SellFee:         lotSellFee,
+					Tax:             lotTax,
+					GrossPnL:        gross,
+					NetPnL:          gross - buyFee - lotSellFee - lotTax,
+					ExitReason:      f.ExitReason,
+				})
+
+				lot.Fee -= buyFee
+				lot.Remaining -= qty
+				sellFee -= lotSellFee
+				tax -= lotTax
+				remaining -= qty
+			}
+
+			open := queue[:0]
+			for _, lot := range queue {
+				if lot.Remaining > 0 {
+					open = append(open, lot)
+				}
+			}
+			queues[key] = open
+
+			if remaining > 0 {
+				unmatched := f
+				unmatched.Quantity = remaining
+				unmatched.Commission = sellFee
+				unmatched.Tax = tax
+				result.Unmatched = append(result.Unmatched, unmatched)
+			}
+		}
+	}
+
+	keys := make([]lotKey, 0, len(queues))
+	for key := range queues {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].account != keys[j].account {
+			return keys[i].account < keys[j].account
+		}
+		return keys[i].code < keys[j].code
+	})
+	for _, key := range keys {
+		for _, lot := range queues[key] {
+			result.Open = append(result.Open, *lot)
+		}
+	}
+
+	return result
+}
+
+// matchOrder returns the lots a sell of strategyID may close, in matching order
+// 전략 미지정 매도 → 전체 FIFO / 전략 매도 → 같은 전략 랏 FIFO, 이어서 전략 미지정 랏 FIFO
+func matchOrder(queue []*OpenLot, strategyID string) []*OpenLot {
+	if strategyID == "" {
+		return queue
+	}
+	lots := make([]*OpenLot, 0, len(queue))
+	for _, lot := range queue {
+		if lot.StrategyID == strategyID {
+			lots = append(lots, lot)
+		}
+	}
+	for _, lot := range queue {
+		if lot.StrategyID == "" {
+			lots = append(lots, lot)
+		}
+	}
+	return lots
+}
+
+// prorate returns the share of amount for qty out of total (마지막 배분에서 잔액 전부)
+func prorate(amount float64, qty, total int) float64 {
+	if total <= 0 || qty >= total {
+		return amount
+	}
+	return amount * float64(qty) / float64(total)
+}
+
+// holdingDays returns calendar days between entry and exit dates
+func holdingDays(entry, exit time.Time) int {
+	e := time.Date(entry.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, time.UTC)
+	x := time.Date(exit.Year(), exit.Month(), exit.Day(), 0, 0, 0, 0, time.UTC)
+	return int(x.Sub(e).Hours() / 24)
+}
+
+// BuildRoundTrips groups closed lots by buy lot into completed round trips
+// 매수 랏 전량이 청산된 경우만 포함 (분할 청산 중인 랏은 제외)
+// 청산가는 수량 가중 평균, 청산 사유/보유기간은 마지막 청산 기준
+func BuildRoundTrips(lots []ClosedLot) []Trade {
+	type group struct {
+		lots      []ClosedLot
+		closedQty int
+	}
+	groups := make(map[int64]*group)
+	order := make([]int64, 0)
+	for _, l := range lots {
+		g, ok := groups[l.BuyTradeID]
+		if !ok {
+			g = &group{}
+			groups[l.BuyTradeID] = g
+			order = append(order, l.BuyTradeID)
+		}
+		g.lots = append(g.lots, l)
+		g.closedQty += l.Quantity
+	}
+
+	trades := make([]Trade, 0, len(groups))
+	for _, id := range order {
+		g := groups[id]
+		first := g.lots[0]
+		if g.closedQty < first.EntryQty {
+			continue
+		}
+
+		last := first
+		proceeds, net, fees, tax := 0.0, 0.0, 0.0, 0.0
+		for _, l := range g.lots {
+			if l.ExitTime.After(last.ExitTime) {
+				last = l
+			}
+			proceeds += l.ExitPrice * float64(l.Quantity)
+			net += l.NetPnL
+			fees += l.BuyFee + l.SellFee
+			tax += l.Tax
+		}
+
+		trades = append(trades, Trade{
+			Code:            first.Code,
+			StrategyID:      first.StrategyID,
+			AccountID:       first.AccountID,
+			StrategyVersion: first.StrategyVersion,
+			EntryDate:       first.EntryTime,
+			ExitDate:        last.ExitTime,
+			EntryPrice:      first.EntryPrice,
+			ExitPrice:       proceeds / float64(g.closedQty),
+			Quantity:        g.closedQty,
+			HoldingDays:     last.HoldingDays,
+			Fees:            fees,
+			Tax:             tax,
+			PnL:             net,
+			ExitReason:      last.ExitReason,
+		})
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].ExitDate.Before(trades[j].ExitDate)
+	})
+	return trades
+}
+
+// SummarizeByStrategy aggregates realized P&L per account, strategy and strategy version
+func SummarizeByStrategy(lots []ClosedLot) []StrategyPnL {
+	type pnlKey struct{ account, strategy, version string }
+	byVersion := make(map[pnlKey]*StrategyPnL)
+	for _, l := range lots {
+		key := pnlKey{l.AccountID, l.StrategyID, l.StrategyVersion}
+		s, ok := byVersion[key]
+		if !ok {
+			s = &StrategyPnL{AccountID: l.AccountID, StrategyID: l.StrategyID, StrategyVersion: l.StrategyVersion}
+			byVersion[key] = s
+		}
+		s.ClosedLots++
+		s.Quantity += l.Quantity
+		s.GrossPnL += l.GrossPnL
+		s.Fees += l.BuyFee + l.SellFee
+		s.Tax += l.Tax
+		s.NetPnL += l.NetPnL
+		if l.NetPnL > 0 {
+			s.Wins++
+		} else if l.NetPnL < 0 {
+			s.Losses++
+		}
+	}
+
+	result := make([]StrategyPnL, 0, len(byVersion))
+	for _, s := range byVersion {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AccountID != result[j].AccountID {
+			return result[i].AccountID < result[j].AccountID
+		}
+		if result[i].StrategyID != result[j].StrategyID {
+			return result[i].StrategyID < result[j].StrategyID
+		}
+		return result[i].StrategyVersion < result[j].StrategyVersion
+	})
+	return result
+}
+
+// SummarizeOpenLots aggregates open lots into per-strategy holdings (계좌 × 전략 × 종목)
+// 같은 계좌의 전략별 보유 수량/평단 — 브로커 잔고는 계좌 합계만 제공
+func SummarizeOpenLots(lots []OpenLot) []StrategyHolding {
+	type holdingKey struct{ account, strategy, code string }
+	byKey := make(map[holdingKey]*StrategyHolding)
+	keys := make([]holdingKey, 0)
+	for _, l := range lots {
+		key := holdingKey{l.AccountID, l.StrategyID, l.Code}
+		h, ok := byKey[key]
+		if !ok {
+			h = &StrategyHolding{AccountID: l.AccountID, StrategyID: l.StrategyID, Code: l.Code}
+			byKey[key] = h
+			keys = append(keys, key)
+		}
+		h.Quantity += l.Remaining
+		h.Cost += l.EntryPrice * float64(l.Remaining)
+	}
+
+	holdings := make([]StrategyHolding, 0, len(keys))
+	for _, key := range keys {
+		h := byKey[key]
+		if h.Quantity > 0 {
+			h.AvgPrice = h.Cost / float64(h.Quantity)
+		}
+		holdings = append(holdings, *h)
+	}
+	sort.Slice(holdings, func(i, j int) bool {
+		a, b := holdings[i], holdings[j]
+		if a.AccountID != b.AccountID {
+			return a.AccountID < b.AccountID
+		}
+		if a.StrategyID != b.StrategyID {
+			return a.StrategyID < b.StrategyID
+		}
+		return a.Code < b.Code
+	})
+	return holdings
+}
+
+// RebuildTradeLedger rebuilds audit.closed_lots from all executions
+// FIFO 매칭은 전체 이력이 필요하므로 증분이 아닌 전체 재구성
+func (a *Analyzer) RebuildTradeLedger(ctx context.Context) (*LedgerResult, error) {
+	fills, err := a.repository.GetFills(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fills: %w", err)
+	}
+
+	result := MatchFIFO(fills)
+	if err := a.repository.ReplaceClosedLots(ctx, result.Closed); err != nil {
+		return nil, fmt.Errorf("failed to save closed lots: %w", err)
+	}
+
+	for _, u := range result.Unmatched {
+		a.logger.WithFields(map[string]interface{}{
+			"trade_id": u.TradeID,
+			"code":     u.Code,
+			"quantity": u.Quantity,
+			"time":     u.Time.Format(time.RFC3339),
+		}).Warn("Sell fill without matching buy lot")
+	}
+
+	a.logger.WithFields(map[string]interface{}{
+		"fills":     len(fills),
+		"closed":    len(result.Closed),
+		"open":      len(result.Open),
+		"unmatched": len(result.Unmatched),
+	}).Info("Trade ledger rebuilt")
+
+	return result, nil
+}
+
+// GetClosedLots returns closed lots with exit time in the period
+func (a *Analyzer) GetClosedLots(ctx context.Context, startDate, endDate time.Time) ([]ClosedLot, error) {
+	return a.repository.GetClosedLots(ctx, startDate, endDate)
+}
+
+// GetRealizedPnLByStrategy returns realized P&L per strategy version for the period
+func (a *Analyzer) GetRealizedPnLByStrategy(ctx context.Context, startDate, endDate time.Time) ([]StrategyPnL, error) {
+	lots, err := a.repository.GetClosedLots(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeByStrategy(lots), nil
+}
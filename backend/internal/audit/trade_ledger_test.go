@@ -0,0 +1,289 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+func at(d, hour int) time.Time {
+	return time.Date(2024, 3, d, hour, 0, 0, 0, time.UTC)
+}
+
+func TestMatchFIFO_PartialExits(t *testing.T) {
+	fills := []Fill{
+		// 입력 순서와 무관하게 시각 순 매칭
+		{TradeID: 3, Code: "005930", Side: contracts.OrderSideSell, Time: at(8, 10), Price: 110, Quantity: 50,
+			Commission: 50, Tax: 100, ExitReason: contracts.ExitReasonTP1},
+		{TradeID: 1, Code: "005930", Side: contracts.OrderSideBuy, Time: at(1, 9), Price: 100, Quantity: 40,
+			Commission: 40, StrategyVersion: "v1"},
+		{TradeID: 2, Code: "005930", Side: contracts.OrderSideBuy, Time: at(4, 9), Price: 105, Quantity: 60,
+			Commission: 60, StrategyVersion: "v2"},
+		{TradeID: 4, Code: "005930", Side: contracts.OrderSideSell, Time: at(11, 14), Price: 95, Quantity: 50,
+			Commission: 25, Tax: 50, ExitReason: contracts.ExitReasonHardStop},
+	}
+
+	result := MatchFIFO(fills)
+	require.Len(t, result.Closed, 3)
+	assert.Empty(t, result.Open)
+	assert.Empty(t, result.Unmatched)
+
+	// 매도 #3 (50주) → 랏 #1 전량 40 + 랏 #2 10
+	first := result.Closed[0]
+	assert.Equal(t, int64(1), first.BuyTradeID)
+	assert.Equal(t, 40, first.Quantity)
+	assert.Equal(t, 7, first.HoldingDays)
+	assert.Equal(t, "v1", first.StrategyVersion)
+	assert.Equal(t, contracts.ExitReasonTP1, first.ExitReason)
+	assert.InDelta(t, 400.0, first.GrossPnL, 1e-9)
+	assert.InDelta(t, 40.0, first.BuyFee, 1e-9)
+	assert.InDelta(t, 40.0, first.SellFee, 1e-9)
+	assert.InDelta(t, 80.0, first.Tax, 1e-9)
+	assert.InDelta(t, 400.0-40-40-80, first.NetPnL, 1e-9)
+
+	second := result.Closed[1]
+	assert.Equal(t, int64(2), second.BuyTradeID)
+	assert.Equal(t, 10, second.Quantity)
+	assert.Equal(t, 60, second.EntryQty)
+	assert.InDelta(t, 10.0, second.BuyFee, 1e-9)
+	assert.InDelta(t, 10.0, second.SellFee, 1e-9)
+	assert.InDelta(t, 20.0, second.Tax, 1e-9)
+
+	// 매도 #4 (50주) → 랏 #2 잔량 50, 잔여 매수 수수료 전부
+	third := result.Closed[2]
+	assert.Equal(t, int64(2), third.BuyTradeID)
+	assert.Equal(t, 50, third.Quantity)
+	assert.Equal(t, 7, third.HoldingDays)
+	assert.Equal(t, contracts.ExitReasonHardStop, third.ExitReason)
+	assert.InDelta(t, -500.0, third.GrossPnL, 1e-9)
+	assert.InDelta(t, 50.0, third.BuyFee, 1e-9)
+	assert.InDelta(t, -500.0-50-25-50, third.NetPnL, 1e-9)
+}
+
+func TestMatchFIFO_OpenAndUnmatched(t *testing.T) {
+	fills := []Fill{
+		{TradeID: 1, Code: "000660", Side: contracts.OrderSideSell, Time: at(1, 9), Price: 200, Quantity: 5, Tax: 10},
+		{TradeID: 2, Code: "000660", Side: contracts.OrderSideBuy, Time: at(2, 9), Price: 190, Quantity: 10, Commission: 20},
+		{TradeID: 3, Code: "000660", Side: contracts.OrderSideSell, Time: at(3, 9), Price: 195, Quantity: 4},
+		{TradeID: 4, Code: "035420", Side: contracts.OrderSideBuy, Time: at(3, 9), Price: 50, Quantity: 3},
+	}
+
+	result := MatchFIFO(fills)
+
+	require.Len(t, result.Unmatched, 1)
+	assert.Equal(t, int64(1), result.Unmatched[0].TradeID)
+	assert.Equal(t, 5, result.Unmatched[0].Quantity)
+
+	require.Len(t, result.Closed, 1)
+	assert.InDelta(t, 8.0, result.Closed[0].BuyFee, 1e-9)
+
+	require.Len(t, result.Open, 2)
+	assert.Equal(t, "000660", result.Open[0].Code)
+	assert.Equal(t, 6, result.Open[0].Remaining)
+	assert.InDelta(t, 12.0, result.Open[0].Fee, 1e-9)
+	assert.Equal(t, "035420", result.Open[1].Code)
+	assert.Equal(t, 3, result.Open[1].Remaining)
+}
+
+func TestBuildRoundTrips(t *testing.T) {
+	lots := []ClosedLot{
+		// 랏 1: TP1 30주 + TP2 70주 → 완결
+		{BuyTradeID: 1, Code: "005930", StrategyVersion: "v1", EntryTime: at(1, 9), ExitTime: at(5, 9),
+			EntryPrice: 100, ExitPrice: 110, EntryQty: 100, Quantity: 30, HoldingDays: 4,
+			BuyFee: 3, SellFee: 3, Tax: 6, NetPnL: 288, ExitReason: contracts.ExitReasonTP1},
+		{BuyTradeID: 1, Code: "005930", StrategyVersion: "v1", EntryTime: at(1, 9), ExitTime: at(9, 9),
+			EntryPrice: 100, ExitPrice: 120, EntryQty: 100, Quantity: 70, HoldingDays: 8,
+			BuyFee: 7, SellFee: 7, Tax: 14, NetPnL: 1372, ExitReason: contracts.ExitReasonTP2},
+		// 랏 2: 일부만 청산 → 제외
+		{BuyTradeID: 2, Code: "000660", EntryTime: at(2, 9), ExitTime: at(6, 9),
+			EntryPrice: 200, ExitPrice: 190, EntryQty: 10, Quantity: 5, NetPnL: -50},
+	}
+
+	trades := BuildRoundTrips(lots)
+	require.Len(t, trades, 1)
+
+	trade := trades[0]
+	assert.Equal(t, "005930", trade.Code)
+	assert.Equal(t, 100, trade.Quantity)
+	assert.InDelta(t, 117.0, trade.ExitPrice, 1e-9)
+	assert.InDelta(t, 1660.0, trade.PnL, 1e-9)
+	assert.InDelta(t, 20.0, trade.Fees, 1e-9)
+	assert.InDelta(t, 20.0, trade.Tax, 1e-9)
+	assert.Equal(t, 8, trade.HoldingDays)
+	assert.Equal(t, contracts.ExitReasonTP2, trade.ExitReason)
+	assert.Equal(t, at(9, 9), trade.ExitDate)
+}
+
+func TestSummarizeByStrategy(t *testing.T) {
+	lots := []ClosedLot{
+		{StrategyVersion: "v2", Quantity: 10, GrossPnL: 100, BuyFee: 1, SellFee: 1, Tax: 2, NetPnL: 96},
+		{StrategyVersion: "v1", Quantity: 5, GrossPnL: -50, BuyFee: 1, SellFee: 1, Tax: 1, NetPnL: -53},
+		{StrategyVersion: "v2", Quantity: 20, GrossPnL: -10, BuyFee: 2, SellFee: 2, Tax: 4, NetPnL: -18},
+	}
+
+	summaries := SummarizeByStrategy(lots)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, "v1", summaries[0].StrategyVersion)
+	assert.Equal(t, 1, summaries[0].Losses)
+
+	v2 := summaries[1]
+	assert.Equal(t, 2, v2.ClosedLots)
+	assert.Equal(t, 30, v2.Quantity)
+	assert.InDelta(t, 90.0, v2.GrossPnL, 1e-9)
+	assert.InDelta(t, 6.0, v2.Fees, 1e-9)
+	assert.InDelta(t, 78.0, v2.NetPnL, 1e-9)
+	assert.Equal(t, 1, v2.Wins)
+	assert.Equal(t, 1, v2.Losses)
+}
+
+func TestMatchFIFO_StrategiesAndAccounts(t *testing.T) {
+	fills := []Fill{
+		{TradeID: 1, Code: "005930", Side: contracts.OrderSideBuy, Time: at(1, 9), Price: 100, Quantity: 10,
+			StrategyID: "momentum", AccountID: "main"},
+		{TradeID: 2, Code: "005930", Side: contracts.OrderSideBuy, Time: at(2, 9), Price: 110, Quantity: 10,
+			StrategyID: "value", AccountID: "main"},
+		{TradeID: 3, Code: "005930", Side: contracts.OrderSideBuy, Time: at(2, 9), Price: 120, Quantity: 10,
+			StrategyID: "value", AccountID: "isa"},
+		// value 전략 매도: 먼저 산 momentum 랏이 아니라 value 랏과 매칭
+		{TradeID: 4, Code: "005930", Side: contracts.OrderSideSell, Time: at(3, 10), Price: 130, Quantity: 5,
+			StrategyID: "value", AccountID: "main"},
+		// 전략 간 상계: momentum → value 이전 (음수 ID, 수수료 없음)
+		{TradeID: -10, Code: "005930", Side: contracts.OrderSideSell, Time: at(4, 8), Price: 125, Quantity: 4,
+			StrategyID: "momentum", AccountID: "main"},
+		{TradeID: -11, Code: "005930", Side: contracts.OrderSideBuy, Time: at(4, 8), Price: 125, Quantity: 4,
+			StrategyID: "value", AccountID: "main"},
+		// 수동 매도: 계좌 내 전체 FIFO (momentum 잔량 6부터)
+		{TradeID: 5, Code: "005930", Side: contracts.OrderSideSell, Time: at(5, 10), Price: 140, Quantity: 8,
+			AccountID: "main"},
+	}
+
+	result := MatchFIFO(fills)
+	assert.Empty(t, result.Unmatched)
+	require.Len(t, result.Closed, 4)
+
+	assert.Equal(t, int64(2), result.Closed[0].BuyTradeID)
+	assert.Equal(t, "value", result.Closed[0].StrategyID)
+	assert.InDelta(t, 100.0, result.Closed[0].GrossPnL, 1e-9)
+
+	assert.Equal(t, int64(1), result.Closed[1].BuyTradeID)
+	assert.Equal(t, "momentum", result.Closed[1].StrategyID)
+	assert.Equal(t, 4, result.Closed[1].Quantity)
+	assert.InDelta(t, 100.0, result.Closed[1].GrossPnL, 1e-9)
+
+	assert.Equal(t, int64(1), result.Closed[2].BuyTradeID)
+	assert.Equal(t, 6, result.Closed[2].Quantity)
+	assert.Equal(t, int64(2), result.Closed[3].BuyTradeID)
+	assert.Equal(t, 2, result.Closed[3].Quantity)
+
+	holdings := SummarizeOpenLots(result.Open)
+	require.Len(t, holdings, 2)
+	assert.Equal(t, StrategyHolding{AccountID: "isa", StrategyID: "value", Code: "005930",
+		Quantity: 10, AvgPrice: 120, Cost: 1200}, holdings[0])
+	main := holdings[1]
+	assert.Equal(t, "main", main.AccountID)
+	assert.Equal(t, "value", main.StrategyID)
+	assert.Equal(t, 7, main.Quantity, "3 remaining of lot #2 + 4 transferred")
+	assert.InDelta(t, (3*110.0+4*125.0)/7, main.AvgPrice, 1e-9)
+
+	summaries := SummarizeByStrategy(result.Closed)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "momentum", summaries[0].StrategyID)
+	assert.Equal(t, "value", summaries[1].StrategyID)
+}
+
+// memOrderLedger mimics execution.orders/trades: fills join their order by broker order_id (Repository.SaveExecution / GetFills)
+type memOrderLedger struct {
+	mu     sync.Mutex
+	orders map[string]contracts.Order
+	fills  []Fill
+}
+
+func (l *memOrderLedger) SaveOrder(ctx context.Context, order *contracts.Order) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.orders[order.ID] = *order
+	return nil
+}
+
+func (l *memOrderLedger) saveExecution(orderID string, qty int, price float64, at time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	o, ok := l.orders[orderID]
+	if !ok {
+		return fmt.Errorf("order not found: %s", orderID)
+	}
+	l.fills = append(l.fills, Fill{
+		TradeID: int64(len(l.fills) + 1), Code: o.Code, Side: o.Side, Time: at, Price: price, Quantity: qty,
+		ExitReason: o.ExitReason, StrategyID: o.StrategyID, AccountID: o.Account(),
+	})
+	return nil
+}
+
+func (l *memOrderLedger) orderCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.orders)
+}
+
+type fixedPrice float64
+
+func (p fixedPrice) GetCurrentPrice(ctx context.Context, code string) (float64, error) {
+	return float64(p), nil
+}
+
+type brokerNo string
+
+func (b brokerNo) SubmitOrder(ctx context.Context, order *contracts.Order) (*execution.OrderResult, error) {
+	return &execution.OrderResult{OrderID: string(b), Status: contracts.StatusSubmitted}, nil
+}
+
+func TestMatchFIFO_ExitReasonFromMonitorOrder(t *testing.T) {
+	ledger := &memOrderLedger{orders: make(map[string]contracts.Order)}
+	entry := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, ledger.SaveOrder(context.Background(), &contracts.Order{
+		ID: "B1", Code: "005930", Side: contracts.OrderSideBuy, Qty: 100, Price: 10000,
+	}))
+	require.NoError(t, ledger.saveExecution("B1", 100, 10000, entry))
+
+	cfg := contracts.DefaultExitRulesConfig()
+	cfg.TickDriven = false
+	cfg.DelistingExit = false
+	cfg.EventCheckIntervalSeconds = 0
+	cfg.CheckIntervalSeconds = 1
+
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	monitor := execution.NewPositionMonitor(cfg, fixedPrice(9000), nil, nil, log)
+	monitor.SetOrderStore(ledger)
+	monitor.SetOrderSubmitter(brokerNo("0000777"))
+	require.NoError(t, monitor.AddPosition(context.Background(), &contracts.MonitoredPosition{
+		ID: "P1", Code: "005930", EntryPrice: 10000, InitialQuantity: 100, ATRPercent: 2.0, EntryTime: entry,
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, monitor.Start(ctx))
+	require.Eventually(t, func() bool { return ledger.orderCount() == 2 }, 3*time.Second, 10*time.Millisecond)
+	cancel()
+
+	// 브로커 체결 통보 → 주문번호로 청산 주문 행에 연결
+	require.NoError(t, ledger.saveExecution("0000777", 100, 9000, time.Now()))
+
+	reason := monitor.GetRecentSignals(1)[0].Reason
+	require.NotEmpty(t, reason)
+
+	result := MatchFIFO(ledger.fills)
+	require.Len(t, result.Closed, 1)
+	assert.Equal(t, reason, result.Closed[0].ExitReason)
+	assert.Equal(t, 100, result.Closed[0].Quantity)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Role is an API access level
+type Role string
+
+const (
+	RoleReadOnly Role = "read_only" // 조회만
+	RoleTrader   Role = "trader"    // 주문/청산 모니터링/관심종목 변경
+	RoleAdmin    Role = "admin"     // 데이터 수집, 작업 큐/스케줄러 제어, 감사 로그 조회
+)
+
+// Roles lists valid roles from least to most privileged
+var Roles = []Role{RoleReadOnly, RoleTrader, RoleAdmin}
+
+// rank orders roles (admin ⊃ trader ⊃ read_only)
+func (r Role) rank() int {
+	for i, role := range Roles {
+		if r == role {
+			return i
+		}
+	}
+	return -1
+}
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	return r.rank() >= 0
+}
+
+// Allows reports whether r grants access to routes requiring the given role
+// ⭐ SSOT: 역할 포함 관계는 이 함수에서만
+func (r Role) Allows(required Role) bool {
+	return r.Valid() && r.rank() >= required.rank()
+}
+
+// APIKey is a stored API key (평문 키는 발급 시 한 번만 노출, DB에는 SHA-256 해시만 저장)
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       Role       `json:"role"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// keyPrefix marks Aegis API keys ("aeg_<prefix>_<secret>")
+const keyPrefix = "aeg_"
+
+// GenerateKey creates a new random key and returns it with its display prefix and hash
+func GenerateKey() (plain, prefix, hash string, err error) {
+	buf := make([]byte, 28)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("generate api key: %w", err)
+	}
+
+	encoded := hex.EncodeToString(buf)
+	prefix = encoded[:8]
+	plain = keyPrefix + prefix + "_" + encoded[8:]
+	return plain, prefix, HashKey(plain), nil
+}
+
+// HashKey returns the stored hash of a key
+// 키 자체가 224비트 난수라 느린 해시(bcrypt) 없이 SHA-256으로 충분하고, 해시로 바로 조회 가능
+func HashKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// LooksLikeKey is a cheap format check before hitting the database
+func LooksLikeKey(plain string) bool {
+	return strings.HasPrefix(plain, keyPrefix) && len(plain) == len(keyPrefix)+8+1+48
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	KeyID int64  `json:"key_id,omitempty"` // 0 = 인증 비활성 (development)
+	Name  string `json:"name"`
+	Role  Role   `json:"role"`
+}
+
+type principalKey struct{}
+
+// WithPrincipal stores the caller in the request context
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the caller (nil if unauthenticated)
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// Actor names the caller for ops/audit records ("api:<key name>")
+func Actor(ctx context.Context) string {
+	if p := FromContext(ctx); p != nil {
+		return "api:" + p.Name
+	}
+	return "api:anonymous"
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Allows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{RoleReadOnly, RoleReadOnly, true},
+		{RoleReadOnly, RoleTrader, false},
+		{RoleTrader, RoleReadOnly, true},
+		{RoleTrader, RoleAdmin, false},
+		{RoleAdmin, RoleTrader, true},
+		{Role("superuser"), RoleReadOnly, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"→"+string(tt.required), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.role.Allows(tt.required))
+		})
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	plain, prefix, hash, err := GenerateKey()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(plain, "aeg_"+prefix+"_"))
+	assert.True(t, LooksLikeKey(plain))
+	assert.Equal(t, HashKey(plain), hash)
+	assert.Len(t, hash, 64)
+	assert.NotContains(t, hash, plain)
+
+	other, _, _, err := GenerateKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, plain, other)
+
+	assert.False(t, LooksLikeKey("Bearer something"))
+	assert.False(t, LooksLikeKey(plain[:len(plain)-1]))
+}
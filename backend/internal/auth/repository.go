@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditEntry is one mutating API request (audit.api_audit_log, append-only)
+type AuditEntry struct {
+	ID           int64           `json:"id"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	KeyID        int64           `json:"key_id,omitempty"`
+	Actor        string          `json:"actor"`
+	Role         Role            `json:"role,omitempty"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Query        string          `json:"query,omitempty"`
+	RemoteAddr   string          `json:"remote_addr"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	Status       int             `json:"status"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"` // 주문 API는 브로커 응답 포함
+	DurationMs   int64           `json:"duration_ms"`
+}
+
+// AuditFilter narrows ListAudit results
+type AuditFilter struct {
+	Actor string
+	Since time.Time
+	Limit int
+}
+
+// Repository stores API keys and the API audit log
+// ⭐ SSOT: API 키/감사 로그 저장은 이 Repository에서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new auth repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// CreateKey issues a key and returns the plaintext (다시 조회할 수 없음)
+func (r *Repository) CreateKey(ctx context.Context, name string, role Role) (string, *APIKey, error) {
+	if !role.Valid() {
+		return "", nil, fmt.Errorf("invalid role %q (valid: read_only, trader, admin)", role)
+	}
+
+	plain, prefix, hash, err := GenerateKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := &APIKey{Name: name, Prefix: prefix, Role: role}
+	err = r.pool.QueryRow(ctx, `
+		INSERT INTO ops.api_keys (name, key_prefix, key_hash, role)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, name, prefix, hash, string(role)).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create api key %s: %w", name, err)
+	}
+	return plain, key, nil
+}
+
+// Authenticate returns the active key for a plaintext key (nil if unknown or revoked)
+func (r *Repository) Authenticate(ctx context.Context, plain string) (*APIKey, error) {
+	if !LooksLikeKey(plain) {
+		return nil, nil
+	}
+
+	key, err := scanKey(r.pool.QueryRow(ctx, `
+		UPDATE ops.api_keys SET last_used_at = NOW()
+		WHERE key_hash = $1 AND revoked_at IS NULL
+		RETURNING id, name, key_prefix, role, created_at, last_used_at, revoked_at
+	`, HashKey(plain)))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("authenticate api key: %w", err)
+	}
+	return key, nil
+}
+
+// ListKeys returns all keys including revoked ones
+func (r *Repository) ListKeys(ctx context.Context) ([]APIKey, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, name, key_prefix, role, created_at, last_used_at, revoked_at
+		FROM ops.api_keys
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]APIKey, 0)
+	for rows.Next() {
+		key, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeKey revokes an active key by name; false if no active key matched
+func (r *Repository) RevokeKey(ctx context.Context, name string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE ops.api_keys SET revoked_at = NOW()
+		WHERE name = $1 AND revoked_at IS NULL
+	`, name)
+	if err != nil {
+		return false, fmt.Errorf("revoke api key %s: %w", name, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// RecordAudit appends a mutating request to the audit log
+func (r *Repository) RecordAudit(ctx context.Context, e *AuditEntry) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO audit.api_audit_log (
+			key_id, actor, role, method, path, query, remote_addr,
+			request_body, status, response_body, duration_ms
+		) VALUES (NULLIF($1, 0), $2, NULLIF($3, ''), $4, $5, NULLIF($6, ''), $7, $8, $9, $10, $11)
+	`, e.KeyID, e.Actor, string(e.Role), e.Method, e.Path, e.Query, e.RemoteAddr,
+		nullJSON(e.RequestBody), e.Status, nullJSON(e.ResponseBody), e.DurationMs)
+	if err != nil {
+		return fmt.Errorf("record audit %s %s: %w", e.Method, e.Path, err)
+	}
+	return nil
+}
+
+// ListAudit returns audit entries, newest first
+func (r *Repository) ListAudit(ctx context.Context, f AuditFilter) ([]AuditEntry, error) {
+	if f.Limit <= 0 {
+		f.Limit = 100
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, occurred_at, COALESCE(key_id, 0), actor, COALESCE(role, ''), method, path,
+		       COALESCE(query, ''), remote_addr, request_body, status, response_body, duration_ms
+		FROM audit.api_audit_log
+		WHERE ($1 = '' OR actor = $1)
+		  AND ($2::timestamptz IS NULL OR occurred_at >= $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`, f.Actor, nullTime(f.Since), f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]AuditEntry, 0)
+	for rows.Next() {
+		var e AuditEntry
+		var role string
+		var reqBody, respBody []byte
+		if err := rows.Scan(&e.ID, &e.OccurredAt, &e.KeyID, &e.Actor, &role, &e.Method, &e.Path,
+			&e.Query, &e.RemoteAddr, &reqBody, &e.Status, &respBody, &e.DurationMs); err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		e.Role = Role(role)
+		e.RequestBody = reqBody
+		e.ResponseBody = respBody
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func scanKey(row pgx.Row) (*APIKey, error) {
+	var key APIKey
+	var role string
+	if err := row.Scan(&key.ID, &key.Name, &key.Prefix, &role, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt); err != nil {
+		return nil, err
+	}
+	key.Role = Role(role)
+	return &key, nil
+}
+
+func nullJSON(body json.RawMessage) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+	return []byte(body)
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/wonny/aegis/v13/backend/internal/brain"
 	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
 )
 
@@ -17,6 +18,11 @@ type Engine struct {
 	orchestrator *brain.Orchestrator
 	simulator    *Simulator
 	logger       *logger.Logger
+
+	// 청산 규칙 재생 (Config.ExitRules 설정 시)
+	bars     BarProvider
+	intraday IntradayBarProvider
+	calendar execution.TradingCalendar
 }
 
 // Config holds backtest configuration
@@ -27,6 +33,11 @@ type Config struct {
 	RebalanceDays  int  // Rebalancing frequency (e.g., 7 for weekly)
 	Commission     float64 // Commission rate (e.g., 0.0015 for 0.15%)
 	Slippage       float64 // Slippage rate (e.g., 0.001 for 0.1%)
+
+	// ExitRules 설정 시 보유 포지션에 ATR 청산 규칙(TP/손절/StopFloor/HWM) 적용
+	// nil이면 리밸런싱만 수행 (기존 동작)
+	ExitRules    *contracts.ExitRulesConfig
+	IntrabarPath IntrabarPath // 기본 PathOLHC (보수적)
 }
 
 // Result holds backtest results
@@ -66,6 +77,10 @@ type Result struct {
 
 	// Daily runs
 	DailyRuns []*brain.RunResult
+
+	// Exit rule replay (Config.ExitRules 설정 시)
+	ExitFills []ExitFill
+	ExitStats []ExitReasonStats
 }
 
 // EquityPoint represents a point in the equity curve
@@ -85,9 +100,24 @@ func NewEngine(
 		orchestrator: orchestrator,
 		simulator:    simulator,
 		logger:       logger,
+		bars:         NewDBBarProvider(simulator.db, simulator.priceBasis),
 	}
 }
 
+// SetBarProviders overrides bar sources used for exit replay
+// intraday는 nil 가능 (일봉만 사용)
+func (e *Engine) SetBarProviders(bars BarProvider, intraday IntradayBarProvider) {
+	if bars != nil {
+		e.bars = bars
+	}
+	e.intraday = intraday
+}
+
+// SetTradingCalendar sets the KRX calendar used to count holding days in exit replay
+func (e *Engine) SetTradingCalendar(cal execution.TradingCalendar) {
+	e.calendar = cal
+}
+
 // Run executes a backtest simulation
 func (e *Engine) Run(ctx context.Context, config Config) (*Result, error) {
 	e.logger.WithFields(map[string]interface{}{
@@ -111,6 +141,18 @@ func (e *Engine) Run(ctx context.Context, config Config) (*Result, error) {
 	// Initialize simulator
 	e.simulator.Initialize(config.InitialCapital)
 
+	var exitSim *ExitSimulator
+	if config.ExitRules != nil {
+		exitSim = NewExitSimulator(e.bars, e.intraday, ExitSimConfig{
+			Rules:      config.ExitRules,
+			Path:       config.IntrabarPath,
+			Commission: config.Commission,
+			Slippage:   config.Slippage,
+			Calendar:   e.calendar,
+		}, e.logger)
+		result.ExitFills = make([]ExitFill, 0)
+	}
+
 	// Run pipeline for each trading day
 	currentDate := config.StartDate
 	daysSinceRebalance := 0
@@ -125,6 +167,11 @@ func (e *Engine) Run(ctx context.Context, config Config) (*Result, error) {
 
 		tradingDays++
 
+		// 청산 규칙은 리밸런싱 전에 적용 (장중 발생 → 종가 리밸런싱)
+		if exitSim != nil {
+			result.ExitFills = append(result.ExitFills, e.processExits(ctx, exitSim, currentDate, config)...)
+		}
+
 		// Check if it's a rebalancing day
 		shouldRebalance := daysSinceRebalance >= config.RebalanceDays
 		if shouldRebalance {
@@ -159,6 +206,10 @@ func (e *Engine) Run(ctx context.Context, config Config) (*Result, error) {
 			daysSinceRebalance++
 		}
 
+		if exitSim != nil {
+			e.syncExitPositions(ctx, exitSim, currentDate)
+		}
+
 		// Update portfolio value (mark to market)
 		equity := e.simulator.GetEquity()
 		returnPct := float64(equity-result.InitialCapital) / float64(result.InitialCapital)
@@ -180,6 +231,9 @@ func (e *Engine) Run(ctx context.Context, config Config) (*Result, error) {
 	result.FinalCapital = e.simulator.GetEquity()
 
 	e.calculateMetrics(result)
+	if exitSim != nil {
+		result.ExitStats = SummarizeExits(result.ExitFills, config.Commission, config.Slippage)
+	}
 
 	e.logger.WithFields(map[string]interface{}{
 		"duration":       result.Duration.Seconds(),
@@ -193,6 +247,64 @@ func (e *Engine) Run(ctx context.Context, config Config) (*Result, error) {
 	return result, nil
 }
 
+// processExits replays exit rules for the day and executes fills in the simulator
+func (e *Engine) processExits(ctx context.Context, exitSim *ExitSimulator, date time.Time, config Config) []ExitFill {
+	fills, err := exitSim.ProcessDay(ctx, date)
+	if err != nil {
+		e.logger.WithFields(map[string]interface{}{
+			"date":  date.Format("2006-01-02"),
+			"error": err.Error(),
+		}).Warn("Exit replay failed")
+	}
+
+	executed := make([]ExitFill, 0, len(fills))
+	for _, fill := range fills {
+		trade, err := e.simulator.ExecuteExit(fill, config.Commission, config.Slippage)
+		if err != nil {
+			e.logger.WithFields(map[string]interface{}{
+				"code":   fill.Code,
+				"reason": fill.Reason,
+				"error":  err.Error(),
+			}).Warn("Exit execution failed in simulation")
+			exitSim.ClosePosition(fill.Code)
+			continue
+		}
+		fill.Qty = trade.Shares
+		executed = append(executed, fill)
+	}
+
+	return executed
+}
+
+// syncExitPositions aligns tracked exit positions with simulator holdings
+// - 신규 보유: 진입 (당일 종가 기준, 다음 거래일부터 평가)
+// - 추가 매수: 평균단가/수량으로 상태 재설정
+// - 리밸런싱 매도: 잔량 반영
+func (e *Engine) syncExitPositions(ctx context.Context, exitSim *ExitSimulator, date time.Time) {
+	positions := e.simulator.GetPositions()
+
+	for code, pos := range positions {
+		tracked, ok := exitSim.TrackedQuantity(code)
+		switch {
+		case !ok || pos.Shares > tracked:
+			if err := exitSim.OpenPosition(ctx, code, pos.Shares, pos.AvgPrice, date); err != nil {
+				e.logger.WithFields(map[string]interface{}{
+					"code":  code,
+					"error": err.Error(),
+				}).Warn("Failed to track position for exit replay")
+			}
+		case pos.Shares < tracked:
+			exitSim.ReducePosition(code, tracked-pos.Shares)
+		}
+	}
+
+	for _, code := range exitSim.TrackedCodes() {
+		if _, held := positions[code]; !held {
+			exitSim.ClosePosition(code)
+		}
+	}
+}
+
 // calculateMetrics calculates performance metrics from equity curve
 func (e *Engine) calculateMetrics(result *Result) {
 	if len(result.EquityCurve) == 0 {
@@ -0,0 +1,551 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// =============================================================================
+// Bars
+// =============================================================================
+
+// Bar represents an OHLCV bar (daily or intraday)
+type Bar struct {
+	Time   time.Time
+	Open   int64
+	High   int64
+	Low    int64
+	Close  int64
+	Volume int64
+}
+
+// BarProvider provides daily bars for exit replay
+type BarProvider interface {
+	GetDailyBars(ctx context.Context, code string, from, to time.Time) ([]Bar, error)
+}
+
+// IntradayBarProvider provides stored intraday bars for a single trading day
+// 저장된 분봉이 없으면 빈 슬라이스 반환 → 일봉으로 대체
+type IntradayBarProvider interface {
+	GetIntradayBars(ctx context.Context, code string, date time.Time) ([]Bar, error)
+}
+
+// DBBarProvider reads daily bars from data.daily_prices (raw or adjusted)
+type DBBarProvider struct {
+	pool  *pgxpool.Pool
+	basis contracts.PriceBasis
+}
+
+// NewDBBarProvider creates a new DB bar provider
+func NewDBBarProvider(pool *pgxpool.Pool, basis contracts.PriceBasis) *DBBarProvider {
+	return &DBBarProvider{pool: pool, basis: basis}
+}
+
+// GetDailyBars retrieves daily bars within [from, to]
+func (p *DBBarProvider) GetDailyBars(ctx context.Context, code string, from, to time.Time) ([]Bar, error) {
+	query := fmt.Sprintf(`
+		SELECT trade_date, open_price, high_price, low_price, close_price, volume
+		FROM %s
+		WHERE stock_code = $1 AND trade_date BETWEEN $2 AND $3
+		ORDER BY trade_date ASC
+	`, p.basis.Table())
+
+	rows, err := p.pool.Query(ctx, query, code, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query daily bars: %w", err)
+	}
+	defer rows.Close()
+
+	var bars []Bar
+	for rows.Next() {
+		var b Bar
+		var open, high, low, close float64
+		if err := rows.Scan(&b.Time, &open, &high, &low, &close, &b.Volume); err != nil {
+			return nil, fmt.Errorf("scan daily bar: %w", err)
+		}
+		b.Open, b.High, b.Low, b.Close = int64(open), int64(high), int64(low), int64(close)
+		bars = append(bars, b)
+	}
+	return bars, rows.Err()
+}
+
+// DBIntradayBarProvider reads 1-minute bars from data.intraday_bars
+// (realtime/bars.Aggregator가 실시간 체결로 생성한 분봉)
+// 분봉은 원주가로 저장되므로 수정주가 기준이면 당일 누적 수정계수를 곱해 일봉과 맞춤
+type DBIntradayBarProvider struct {
+	pool  *pgxpool.Pool
+	basis contracts.PriceBasis
+}
+
+// NewDBIntradayBarProvider creates a new DB intraday bar provider
+func NewDBIntradayBarProvider(pool *pgxpool.Pool, basis contracts.PriceBasis) *DBIntradayBarProvider {
+	return &DBIntradayBarProvider{pool: pool, basis: basis}
+}
+
+// GetIntradayBars retrieves 1-minute bars for the trading day (KST)
+func (p *DBIntradayBarProvider) GetIntradayBars(ctx context.Context, code string, date time.Time) ([]Bar, error) {
+	query := `
+		SELECT bar_time, open_price, high_price, low_price, close_price, volume
+		FROM data.intraday_bars
+		WHERE stock_code = $1
+		  AND interval_minutes = 1
+		  AND (bar_time AT TIME ZONE 'Asia/Seoul')::date = $2::date
+		ORDER BY bar_time ASC
+	`
+	if p.basis == contracts.PriceBasisAdjusted {
+		query = `
+			SELECT b.bar_time,
+			       ROUND(b.open_price * a.price_factor)::BIGINT,
+			       ROUND(b.high_price * a.price_factor)::BIGINT,
+			       ROUND(b.low_price * a.price_factor)::BIGINT,
+			       ROUND(b.close_price * a.price_factor)::BIGINT,
+			       ROUND(b.volume * a.volume_factor)::BIGINT
+			FROM data.intraday_bars b
+			CROSS JOIN LATERAL data.cumulative_adjustment(b.stock_code, $2::date) a
+			WHERE b.stock_code = $1
+			  AND b.interval_minutes = 1
+			  AND (b.bar_time AT TIME ZONE 'Asia/Seoul')::date = $2::date
+			ORDER BY b.bar_time ASC
+		`
+	}
+
+	rows, err := p.pool.Query(ctx, query, code, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("query intraday bars: %w", err)
+	}
+	defer rows.Close()
+
+	var bars []Bar
+	for rows.Next() {
+		var b Bar
+		if err := rows.Scan(&b.Time, &b.Open, &b.High, &b.Low, &b.Close, &b.Volume); err != nil {
+			return nil, fmt.Errorf("scan intraday bar: %w", err)
+		}
+		bars = append(bars, b)
+	}
+	return bars, rows.Err()
+}
+
+// =============================================================================
+// Exit Simulator
+// ⭐ SSOT: 청산 규칙 판정은 execution.PositionMonitor 상태 머신을 그대로 재사용
+// =============================================================================
+
+// IntrabarPath 봉 내부 가격 경로 가정
+type IntrabarPath string
+
+const (
+	// PathOLHC 보수적 가정: 시가 → 저가 → 고가 → 종가 (손절이 익절보다 먼저)
+	PathOLHC IntrabarPath = "OLHC"
+	// PathOHLC 낙관적 가정: 시가 → 고가 → 저가 → 종가 (비교용)
+	PathOHLC IntrabarPath = "OHLC"
+)
+
+// ExitSimConfig holds exit replay configuration
+type ExitSimConfig struct {
+	Rules      *contracts.ExitRulesConfig
+	Path       IntrabarPath
+	ATRPeriod  int                       // 진입 시 ATR 계산 기간 (기본 14)
+	Commission float64                   // 청산 수수료율
+	Slippage   float64                   // 청산 슬리피지율 (불리한 방향)
+	Calendar   execution.TradingCalendar // 보유기간 거래일 산정 (nil → 평일 기준)
+}
+
+// ExitFill represents a simulated exit execution
+type ExitFill struct {
+	Code        string
+	Date        time.Time
+	Reason      contracts.ExitReason
+	Qty         int64
+	Price       int64 // 슬리피지 반영 전 체결 가격
+	EntryPrice  int64
+	EntryDate   time.Time
+	HoldingDays int
+	Gapped      bool // 시가 갭으로 트리거 가격보다 불리하게 체결
+	Intraday    bool // 분봉 기준 판정 여부
+}
+
+// ExitReasonStats aggregates exits by reason
+type ExitReasonStats struct {
+	Reason         contracts.ExitReason
+	Count          int
+	Shares         int64
+	GapCount       int
+	RealizedPnL    int64
+	AvgReturnPct   float64
+	AvgHoldingDays float64
+	WinCount       int
+}
+
+// simPosition tracks a replayed position
+type simPosition struct {
+	pos       *contracts.MonitoredPosition
+	entryDate time.Time
+}
+
+// ExitSimulator replays ExitRulesConfig over historical bars
+type ExitSimulator struct {
+	monitor  *execution.PositionMonitor
+	bars     BarProvider
+	intraday IntradayBarProvider
+	config   ExitSimConfig
+	logger   *logger.Logger
+
+	positions map[string]*simPosition
+	fills     []ExitFill
+}
+
+// NewExitSimulator creates a new exit simulator
+// intraday는 nil 가능 (일봉만 사용)
+func NewExitSimulator(bars BarProvider, intraday IntradayBarProvider, config ExitSimConfig, log *logger.Logger) *ExitSimulator {
+	if config.Rules == nil {
+		config.Rules = contracts.DefaultExitRulesConfig()
+	}
+	if config.Path == "" {
+		config.Path = PathOLHC
+	}
+	if config.ATRPeriod <= 0 {
+		config.ATRPeriod = 14
+	}
+
+	monitor := execution.NewPositionMonitor(config.Rules, nil, nil, nil, log)
+	if config.Calendar != nil {
+		monitor.SetTradingCalendar(config.Calendar)
+	}
+
+	return &ExitSimulator{
+		monitor:   monitor,
+		bars:      bars,
+		intraday:  intraday,
+		config:    config,
+		logger:    log,
+		positions: make(map[string]*simPosition),
+		fills:     make([]ExitFill, 0),
+	}
+}
+
+// Reset clears all tracked positions and fills
+func (s *ExitSimulator) Reset() {
+	s.positions = make(map[string]*simPosition)
+	s.fills = make([]ExitFill, 0)
+}
+
+// OpenPosition starts tracking a position entered at the close of entryDate
+// 기존 포지션이 있으면 평균단가/수량 기준으로 상태를 재설정
+func (s *ExitSimulator) OpenPosition(ctx context.Context, code string, qty int64, entryPrice int64, entryDate time.Time) error {
+	if qty <= 0 || entryPrice <= 0 {
+		return fmt.Errorf("invalid position %s: qty=%d price=%d", code, qty, entryPrice)
+	}
+
+	pos := &contracts.MonitoredPosition{
+		ID:              fmt.Sprintf("BT-%s-%s", code, entryDate.Format("20060102")),
+		Code:            code,
+		EntryPrice:      entryPrice,
+		InitialQuantity: int(qty),
+		EntryTime:       entryDate,
+	}
+
+	// ATR%: 진입일까지의 일봉으로 계산 (미래 데이터 사용 금지)
+	history, err := s.bars.GetDailyBars(ctx, code, entryDate.AddDate(0, 0, -s.config.ATRPeriod*3), entryDate)
+	if err == nil {
+		if atr := CalculateATR(history, s.config.ATRPeriod); atr > 0 {
+			pos.ATRPercent = atr / float64(entryPrice) * 100
+		}
+	}
+	if pos.ATRPercent == 0 {
+		pos.ATRPercent = 2.0 // PositionMonitor와 동일한 기본값
+	}
+
+	s.monitor.InitPosition(ctx, pos)
+	s.positions[code] = &simPosition{pos: pos, entryDate: entryDate}
+	return nil
+}
+
+// ReducePosition reflects a non-exit sell (e.g. rebalance) on a tracked position
+func (s *ExitSimulator) ReducePosition(code string, qty int64) {
+	sp, ok := s.positions[code]
+	if !ok {
+		return
+	}
+
+	sp.pos.RemainingQuantity -= int(qty)
+	if sp.pos.RemainingQuantity <= 0 {
+		delete(s.positions, code)
+	}
+}
+
+// ClosePosition stops tracking a position
+func (s *ExitSimulator) ClosePosition(code string) {
+	delete(s.positions, code)
+}
+
+// TrackedQuantity returns remaining quantity of a tracked position
+func (s *ExitSimulator) TrackedQuantity(code string) (int64, bool) {
+	sp, ok := s.positions[code]
+	if !ok {
+		return 0, false
+	}
+	return int64(sp.pos.RemainingQuantity), true
+}
+
+// TrackedCodes returns codes of all tracked positions
+func (s *ExitSimulator) TrackedCodes() []string {
+	codes := make([]string, 0, len(s.positions))
+	for code := range s.positions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ProcessDay replays one trading day for all tracked positions
+// 진입 당일(종가 진입)은 평가하지 않음, 봉이 없는 종목(거래정지 등)은 건너뜀
+func (s *ExitSimulator) ProcessDay(ctx context.Context, date time.Time) ([]ExitFill, error) {
+	var dayFills []ExitFill
+	for _, code := range s.TrackedCodes() { // 정렬 → 재현성
+		if err := ctx.Err(); err != nil {
+			return dayFills, err
+		}
+
+		sp := s.positions[code]
+		if !date.After(sp.entryDate) {
+			continue
+		}
+
+		bars, intraday, err := s.barsForDay(ctx, code, date)
+		if err != nil {
+			// 한 종목의 데이터 오류로 나머지 종목 평가를 중단하지 않음
+			s.logger.WithFields(map[string]interface{}{
+				"code":  code,
+				"date":  date.Format("2006-01-02"),
+				"error": err.Error(),
+			}).Warn("Skipping exit replay for position")
+			continue
+		}
+
+		fills := s.replayBars(sp, bars, date, intraday)
+		dayFills = append(dayFills, fills...)
+
+		if sp.pos.RemainingQuantity <= 0 || sp.pos.State == contracts.PositionStateClosed {
+			delete(s.positions, code)
+		}
+	}
+
+	s.fills = append(s.fills, dayFills...)
+	return dayFills, nil
+}
+
+// barsForDay returns intraday bars when stored, otherwise the daily bar
+func (s *ExitSimulator) barsForDay(ctx context.Context, code string, date time.Time) ([]Bar, bool, error) {
+	if s.intraday != nil {
+		bars, err := s.intraday.GetIntradayBars(ctx, code, date)
+		if err != nil {
+			return nil, false, fmt.Errorf("get intraday bars %s: %w", code, err)
+		}
+		if len(bars) > 0 {
+			return bars, true, nil
+		}
+	}
+
+	bars, err := s.bars.GetDailyBars(ctx, code, date, date)
+	if err != nil {
+		return nil, false, fmt.Errorf("get daily bar %s: %w", code, err)
+	}
+	return bars, false, nil
+}
+
+// replayBars walks each bar along the configured intrabar path
+func (s *ExitSimulator) replayBars(sp *simPosition, bars []Bar, date time.Time, intraday bool) []ExitFill {
+	var fills []ExitFill
+
+	for _, bar := range bars {
+		for i, price := range s.pathPoints(bar) {
+			isOpen := i == 0
+
+			// 한 가격점에서 여러 단계가 연속 발동할 수 있음 (예: TP1 → TP2, 1차 → 2차 손절)
+			for guard := 0; guard < 8 && sp.pos.RemainingQuantity > 0; guard++ {
+				signals, err := s.monitor.EvaluatePrice(sp.pos, price, bar.Time)
+				if err != nil || len(signals) == 0 {
+					break
+				}
+
+				signal := signals[0]
+				trigger := s.monitor.TriggerPrice(sp.pos, signal.Reason)
+				fillPrice, gapped := fillPriceFor(signal.Reason, trigger, price, isOpen, bar)
+
+				fills = append(fills, ExitFill{
+					Code:        sp.pos.Code,
+					Date:        date,
+					Reason:      signal.Reason,
+					Qty:         int64(signal.SellQuantity),
+					Price:       fillPrice,
+					EntryPrice:  sp.pos.EntryPrice,
+					EntryDate:   sp.entryDate,
+					HoldingDays: int(date.Sub(sp.entryDate).Hours() / 24),
+					Gapped:      gapped,
+					Intraday:    intraday,
+				})
+
+				if s.monitor.ApplyExit(sp.pos, signal) {
+					return fills
+				}
+			}
+		}
+	}
+
+	// 시간 기반 규칙 (최대 보유기간/정체 포지션)은 종가 기준 판정·체결
+	if len(bars) > 0 && sp.pos.RemainingQuantity > 0 {
+		last := bars[len(bars)-1]
+		if signal := s.monitor.EvaluateTimeRules(sp.pos, last.Close, date); signal != nil {
+			fills = append(fills, ExitFill{
+				Code:        sp.pos.Code,
+				Date:        date,
+				Reason:      signal.Reason,
+				Qty:         int64(signal.SellQuantity),
+				Price:       last.Close,
+				EntryPrice:  sp.pos.EntryPrice,
+				EntryDate:   sp.entryDate,
+				HoldingDays: int(date.Sub(sp.entryDate).Hours() / 24),
+				Intraday:    intraday,
+			})
+			s.monitor.ApplyExit(sp.pos, signal)
+		}
+	}
+
+	return fills
+}
+
+// pathPoints returns the ordered price points within a bar
+func (s *ExitSimulator) pathPoints(bar Bar) []int64 {
+	if s.config.Path == PathOHLC {
+		return []int64{bar.Open, bar.High, bar.Low, bar.Close}
+	}
+	return []int64{bar.Open, bar.Low, bar.High, bar.Close}
+}
+
+// fillPriceFor determines the fill price for a triggered exit
+// - 시가에서 발동: 갭이므로 시가 체결 (손절은 트리거보다 불리, 익절은 유리)
+// - 장중 발동: 가격이 연속적으로 움직였다고 보고 트리거 가격 체결 (봉 범위로 제한)
+func fillPriceFor(reason contracts.ExitReason, trigger, price int64, isOpen bool, bar Bar) (int64, bool) {
+	if trigger <= 0 {
+		return price, false
+	}
+
+	isStop := reason != contracts.ExitReasonTP1 && reason != contracts.ExitReasonTP2 && reason != contracts.ExitReasonTP3
+	if isOpen {
+		if isStop && price < trigger {
+			return price, true
+		}
+		if !isStop && price > trigger {
+			return price, false
+		}
+	}
+
+	fill := trigger
+	if fill > bar.High {
+		fill = bar.High
+	}
+	if fill < bar.Low {
+		fill = bar.Low
+	}
+	return fill, false
+}
+
+// Fills returns all simulated exit fills
+func (s *ExitSimulator) Fills() []ExitFill {
+	return s.fills
+}
+
+// Report aggregates fills by exit reason (net of commission and slippage)
+func (s *ExitSimulator) Report() []ExitReasonStats {
+	return SummarizeExits(s.fills, s.config.Commission, s.config.Slippage)
+}
+
+// SummarizeExits aggregates exit fills per ExitReason
+func SummarizeExits(fills []ExitFill, commission, slippage float64) []ExitReasonStats {
+	byReason := make(map[contracts.ExitReason]*ExitReasonStats)
+	returnSum := make(map[contracts.ExitReason]float64)
+	holdingSum := make(map[contracts.ExitReason]int)
+
+	for _, f := range fills {
+		st, ok := byReason[f.Reason]
+		if !ok {
+			st = &ExitReasonStats{Reason: f.Reason}
+			byReason[f.Reason] = st
+		}
+
+		netPrice := float64(f.Price) * (1 - slippage)
+		proceeds := netPrice*float64(f.Qty) - netPrice*float64(f.Qty)*commission
+		cost := float64(f.EntryPrice * f.Qty)
+		pnl := int64(proceeds - cost)
+
+		st.Count++
+		st.Shares += f.Qty
+		st.RealizedPnL += pnl
+		if f.Gapped {
+			st.GapCount++
+		}
+		if pnl > 0 {
+			st.WinCount++
+		}
+		if cost > 0 {
+			returnSum[f.Reason] += (proceeds - cost) / cost
+		}
+		holdingSum[f.Reason] += f.HoldingDays
+	}
+
+	stats := make([]ExitReasonStats, 0, len(byReason))
+	for reason, st := range byReason {
+		st.AvgReturnPct = returnSum[reason] / float64(st.Count) * 100
+		st.AvgHoldingDays = float64(holdingSum[reason]) / float64(st.Count)
+		stats = append(stats, *st)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Reason < stats[j].Reason })
+
+	return stats
+}
+
+// CalculateATR computes the simple average true range over the last period bars
+func CalculateATR(bars []Bar, period int) float64 {
+	if len(bars) < 2 || period <= 0 {
+		return 0
+	}
+
+	var trs []float64
+	for i := 1; i < len(bars); i++ {
+		prevClose := bars[i-1].Close
+		tr := float64(bars[i].High - bars[i].Low)
+		if v := float64(abs64(bars[i].High - prevClose)); v > tr {
+			tr = v
+		}
+		if v := float64(abs64(bars[i].Low - prevClose)); v > tr {
+			tr = v
+		}
+		trs = append(trs, tr)
+	}
+
+	if len(trs) > period {
+		trs = trs[len(trs)-period:]
+	}
+
+	sum := 0.0
+	for _, tr := range trs {
+		sum += tr
+	}
+	return sum / float64(len(trs))
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,222 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// fakeBars serves bars from memory, keyed by code
+type fakeBars struct {
+	daily    map[string][]Bar
+	intraday map[string][]Bar
+}
+
+func (f *fakeBars) GetDailyBars(ctx context.Context, code string, from, to time.Time) ([]Bar, error) {
+	var bars []Bar
+	for _, b := range f.daily[code] {
+		if !b.Time.Before(from) && !b.Time.After(to) {
+			bars = append(bars, b)
+		}
+	}
+	return bars, nil
+}
+
+func (f *fakeBars) GetIntradayBars(ctx context.Context, code string, date time.Time) ([]Bar, error) {
+	var bars []Bar
+	for _, b := range f.intraday[code] {
+		if b.Time.Format("2006-01-02") == date.Format("2006-01-02") {
+			bars = append(bars, b)
+		}
+	}
+	return bars, nil
+}
+
+func day(d int) time.Time {
+	return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+// history builds flat bars (range 200 → ATR 2% at price 10,000) ending at entry day
+func history(entry time.Time) []Bar {
+	var bars []Bar
+	for i := 20; i >= 0; i-- {
+		bars = append(bars, Bar{Time: entry.AddDate(0, 0, -i), Open: 10000, High: 10100, Low: 9900, Close: 10000})
+	}
+	return bars
+}
+
+func newTestExitSimulator(t *testing.T, bars *fakeBars, path IntrabarPath) *ExitSimulator {
+	t.Helper()
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	return NewExitSimulator(bars, bars, ExitSimConfig{Path: path}, log)
+}
+
+func TestExitSimulator_IntrabarPath(t *testing.T) {
+	// 한 봉에서 TP1(10,600)과 1차 손절(9,700)이 모두 터치됨
+	wide := Bar{Time: day(22), Open: 10000, High: 10700, Low: 9600, Close: 10000}
+
+	tests := []struct {
+		name       string
+		path       IntrabarPath
+		wantReason contracts.ExitReason
+		wantPrice  int64
+	}{
+		{"conservative OLHC hits stop first", PathOLHC, contracts.ExitReasonFirstStop, 9700},
+		{"optimistic OHLC hits TP1 first", PathOHLC, contracts.ExitReasonTP1, 10600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars := &fakeBars{daily: map[string][]Bar{"005930": append(history(day(21)), wide)}}
+			sim := newTestExitSimulator(t, bars, tt.path)
+			ctx := context.Background()
+
+			require.NoError(t, sim.OpenPosition(ctx, "005930", 100, 10000, day(21)))
+
+			fills, err := sim.ProcessDay(ctx, day(22))
+			require.NoError(t, err)
+			require.NotEmpty(t, fills)
+			assert.Equal(t, tt.wantReason, fills[0].Reason)
+			assert.Equal(t, tt.wantPrice, fills[0].Price)
+			assert.False(t, fills[0].Gapped)
+		})
+	}
+}
+
+func TestExitSimulator_GapDownFillsAtOpen(t *testing.T) {
+	gap := Bar{Time: day(22), Open: 9400, High: 9600, Low: 9300, Close: 9500}
+	bars := &fakeBars{daily: map[string][]Bar{"005930": append(history(day(21)), gap)}}
+	sim := newTestExitSimulator(t, bars, PathOLHC)
+	ctx := context.Background()
+
+	require.NoError(t, sim.OpenPosition(ctx, "005930", 100, 10000, day(21)))
+
+	fills, err := sim.ProcessDay(ctx, day(22))
+	require.NoError(t, err)
+	require.Len(t, fills, 1)
+	assert.Equal(t, contracts.ExitReasonSecondStop, fills[0].Reason)
+	assert.Equal(t, int64(9400), fills[0].Price)
+	assert.Equal(t, int64(100), fills[0].Qty)
+	assert.True(t, fills[0].Gapped)
+
+	_, tracked := sim.TrackedQuantity("005930")
+	assert.False(t, tracked)
+}
+
+func TestExitSimulator_SkipsEntryDay(t *testing.T) {
+	bars := &fakeBars{daily: map[string][]Bar{"005930": history(day(21))}}
+	sim := newTestExitSimulator(t, bars, PathOLHC)
+	ctx := context.Background()
+
+	require.NoError(t, sim.OpenPosition(ctx, "005930", 100, 9000, day(21)))
+
+	fills, err := sim.ProcessDay(ctx, day(21))
+	require.NoError(t, err)
+	assert.Empty(t, fills)
+}
+
+func TestExitSimulator_PrefersIntradayBars(t *testing.T) {
+	// 일봉으로는 손절, 분봉으로는 TP1이 먼저 발생
+	daily := Bar{Time: day(22), Open: 10000, High: 10700, Low: 9600, Close: 10000}
+	minute := []Bar{
+		{Time: day(22).Add(9 * time.Hour), Open: 10000, High: 10650, Low: 10000, Close: 10600},
+		{Time: day(22).Add(10 * time.Hour), Open: 10600, High: 10600, Low: 10500, Close: 10500},
+	}
+	bars := &fakeBars{
+		daily:    map[string][]Bar{"005930": append(history(day(21)), daily)},
+		intraday: map[string][]Bar{"005930": minute},
+	}
+	sim := newTestExitSimulator(t, bars, PathOLHC)
+	ctx := context.Background()
+
+	require.NoError(t, sim.OpenPosition(ctx, "005930", 100, 10000, day(21)))
+
+	fills, err := sim.ProcessDay(ctx, day(22))
+	require.NoError(t, err)
+	require.Len(t, fills, 1)
+	assert.Equal(t, contracts.ExitReasonTP1, fills[0].Reason)
+	assert.True(t, fills[0].Intraday)
+	assert.Equal(t, int64(25), fills[0].Qty)
+
+	remaining, tracked := sim.TrackedQuantity("005930")
+	assert.True(t, tracked)
+	assert.Equal(t, int64(75), remaining)
+}
+
+func TestSummarizeExits(t *testing.T) {
+	fills := []ExitFill{
+		{Reason: contracts.ExitReasonTP1, Qty: 10, Price: 11000, EntryPrice: 10000, HoldingDays: 2},
+		{Reason: contracts.ExitReasonTP1, Qty: 10, Price: 10800, EntryPrice: 10000, HoldingDays: 4},
+		{Reason: contracts.ExitReasonSecondStop, Qty: 20, Price: 9400, EntryPrice: 10000, HoldingDays: 1, Gapped: true},
+	}
+
+	stats := SummarizeExits(fills, 0, 0)
+	require.Len(t, stats, 2)
+
+	byReason := make(map[contracts.ExitReason]ExitReasonStats)
+	for _, st := range stats {
+		byReason[st.Reason] = st
+	}
+
+	tp1 := byReason[contracts.ExitReasonTP1]
+	assert.Equal(t, 2, tp1.Count)
+	assert.Equal(t, int64(20), tp1.Shares)
+	assert.Equal(t, int64(18000), tp1.RealizedPnL)
+	assert.InDelta(t, 9.0, tp1.AvgReturnPct, 0.001)
+	assert.InDelta(t, 3.0, tp1.AvgHoldingDays, 0.001)
+	assert.Equal(t, 2, tp1.WinCount)
+
+	stop := byReason[contracts.ExitReasonSecondStop]
+	assert.Equal(t, int64(-12000), stop.RealizedPnL)
+	assert.Equal(t, 1, stop.GapCount)
+	assert.Equal(t, 0, stop.WinCount)
+}
+
+func TestCalculateATR(t *testing.T) {
+	bars := []Bar{
+		{Close: 100},
+		{High: 110, Low: 100, Close: 105}, // TR = 10
+		{High: 108, Low: 95, Close: 96},   // TR = 13
+		{High: 120, Low: 110, Close: 115}, // TR = max(10, 24, 14) = 24
+	}
+
+	assert.InDelta(t, (10.0+13.0+24.0)/3, CalculateATR(bars, 14), 0.001)
+	assert.InDelta(t, (13.0+24.0)/2, CalculateATR(bars, 2), 0.001)
+	assert.Equal(t, 0.0, CalculateATR(bars[:1], 14))
+}
+
+func TestExitSimulator_MaxHoldingExitsAtClose(t *testing.T) {
+	daily := history(day(21))
+	for d := 22; d <= 28; d++ {
+		daily = append(daily, Bar{Time: day(d), Open: 10000, High: 10100, Low: 9900, Close: 10050})
+	}
+	bars := &fakeBars{daily: map[string][]Bar{"005930": daily}}
+
+	rules := contracts.DefaultExitRulesConfig()
+	rules.MaxHoldingDays = 3
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	sim := NewExitSimulator(bars, nil, ExitSimConfig{Rules: rules}, log)
+	ctx := context.Background()
+
+	require.NoError(t, sim.OpenPosition(ctx, "005930", 100, 10000, day(21))) // 목요일
+
+	var fills []ExitFill
+	for d := 22; d <= 28; d++ {
+		dayFills, err := sim.ProcessDay(ctx, day(d))
+		require.NoError(t, err)
+		fills = append(fills, dayFills...)
+	}
+
+	require.Len(t, fills, 1)
+	assert.Equal(t, contracts.ExitReasonMaxHolding, fills[0].Reason)
+	assert.Equal(t, day(26), fills[0].Date) // 금·월·화 → 3거래일
+	assert.Equal(t, int64(10050), fills[0].Price)
+	assert.Equal(t, int64(100), fills[0].Qty)
+}
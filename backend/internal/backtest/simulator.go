@@ -15,8 +15,9 @@ import (
 // Simulator simulates order execution in backtesting
 // ⭐ SSOT: 백테스팅 시뮬레이션은 여기서만
 type Simulator struct {
-	db     *pgxpool.Pool
-	logger *logger.Logger
+	db         *pgxpool.Pool
+	priceBasis contracts.PriceBasis // 체결가/평가 기준 (수정주가 권장: 분할/권리락 갭 제외)
+	logger     *logger.Logger
 
 	// Current state
 	cash      int64
@@ -50,6 +51,8 @@ type Trade struct {
 	Slippage    int64
 	PnL         int64 // For sell orders
 	ReturnPct   float64
+	Date        time.Time
+	ExitReason  contracts.ExitReason // 청산 규칙에 의한 매도일 때만 설정
 }
 
 // Stats holds simulation statistics
@@ -62,10 +65,11 @@ type Stats struct {
 }
 
 // NewSimulator creates a new trading simulator
-func NewSimulator(db *pgxpool.Pool, logger *logger.Logger) *Simulator {
+func NewSimulator(db *pgxpool.Pool, priceBasis contracts.PriceBasis, logger *logger.Logger) *Simulator {
 	return &Simulator{
-		db:        db,
-		logger:    logger,
+		db:         db,
+		priceBasis: priceBasis,
+		logger:     logger,
 		positions: make(map[string]*Position),
 		trades:    make([]Trade, 0),
 	}
@@ -128,6 +132,7 @@ func (s *Simulator) executeOrder(ctx context.Context, order contracts.Order, com
 		Value:      totalValue,
 		Commission: commission,
 		Slippage:   slippageCost,
+		Date:       order.CreatedAt,
 	}
 
 	if order.Side == contracts.OrderSideBuy {
@@ -207,6 +212,79 @@ func (s *Simulator) executeOrder(ctx context.Context, order contracts.Order, com
 	return nil
 }
 
+// ExecuteExit executes an exit-rule sell at the given fill price
+// ⭐ 가격은 ExitSimulator가 결정 (DB 종가 조회 없음)
+func (s *Simulator) ExecuteExit(fill ExitFill, commissionRate, slippageRate float64) (Trade, error) {
+	pos, exists := s.positions[fill.Code]
+	if !exists {
+		return Trade{}, fmt.Errorf("no position to exit: %s", fill.Code)
+	}
+
+	qty := fill.Qty
+	if qty > pos.Shares {
+		qty = pos.Shares
+	}
+	if qty <= 0 {
+		return Trade{}, fmt.Errorf("invalid exit quantity for %s: %d", fill.Code, fill.Qty)
+	}
+
+	actualPrice := int64(float64(fill.Price) * (1.0 - slippageRate))
+	totalValue := actualPrice * qty
+	commission := int64(math.Ceil(float64(totalValue) * commissionRate))
+	slippageCost := (fill.Price - actualPrice) * qty
+
+	proceeds := totalValue - commission
+	costBasis := (pos.CostBasis * qty) / pos.Shares
+	pnl := proceeds - costBasis
+
+	trade := Trade{
+		Code:       fill.Code,
+		Direction:  string(contracts.OrderSideSell),
+		Shares:     qty,
+		Price:      actualPrice,
+		Value:      totalValue,
+		Commission: commission,
+		Slippage:   slippageCost,
+		PnL:        pnl,
+		ReturnPct:  float64(pnl) / float64(costBasis),
+		Date:       fill.Date,
+		ExitReason: fill.Reason,
+	}
+
+	s.cash += proceeds
+	pos.Shares -= qty
+	pos.CostBasis -= costBasis
+	if pos.Shares == 0 {
+		delete(s.positions, fill.Code)
+	}
+
+	s.totalTrades++
+	if pnl > 0 {
+		s.winningTrades++
+	} else if pnl < 0 {
+		s.losingTrades++
+	}
+	s.trades = append(s.trades, trade)
+	s.totalCommission += commission
+	s.totalSlippage += slippageCost
+
+	return trade, nil
+}
+
+// GetPositions returns a snapshot of current positions
+func (s *Simulator) GetPositions() map[string]Position {
+	positions := make(map[string]Position, len(s.positions))
+	for code, pos := range s.positions {
+		positions[code] = *pos
+	}
+	return positions
+}
+
+// GetTrades returns all recorded trades
+func (s *Simulator) GetTrades() []Trade {
+	return s.trades
+}
+
 // GetEquity returns current total equity (cash + positions)
 func (s *Simulator) GetEquity() int64 {
 	// TODO: Mark positions to market
@@ -232,13 +310,13 @@ func (s *Simulator) GetStats() Stats {
 
 // getCurrentPrice retrieves the closing price for a stock on a given date
 func (s *Simulator) getCurrentPrice(ctx context.Context, code string, date time.Time) (int64, error) {
-	query := `
-		SELECT close
-		FROM data.daily_prices
+	query := fmt.Sprintf(`
+		SELECT close_price
+		FROM %s
 		WHERE stock_code = $1
 		  AND trade_date = $2
 		LIMIT 1
-	`
+	`, s.priceBasis.Table())
 
 	var price int64
 	err := s.db.QueryRow(ctx, query, code, date).Scan(&price)
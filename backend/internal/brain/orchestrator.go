@@ -2,18 +2,21 @@ package brain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/wonny/aegis/v13/backend/internal/audit"
 	"github.com/wonny/aegis/v13/backend/internal/contracts"
 	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/internal/notify"
 	"github.com/wonny/aegis/v13/backend/internal/portfolio"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/quality"
 	"github.com/wonny/aegis/v13/backend/internal/s1_universe"
 	"github.com/wonny/aegis/v13/backend/internal/s2_signals"
 	"github.com/wonny/aegis/v13/backend/internal/selection"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // Orchestrator coordinates the entire 7-stage pipeline
@@ -38,17 +41,23 @@ type Orchestrator struct {
 	executionRepo  *execution.Repository
 	auditRepo      *audit.Repository
 
-	logger *logger.Logger
+	strategies []Strategy    // S4~S6 전략별 실행 (nil이면 단일 default 전략)
+	notifier   notify.Emitter // 실패 알림 (nil이면 생략)
+	logger     *logger.Logger
 }
 
+// ErrQualityGateFailed is returned (wrapped) when S0 rejects the day's data
+var ErrQualityGateFailed = errors.New("quality gate failed")
+
 // RunConfig holds configuration for a pipeline run
 type RunConfig struct {
 	Date           time.Time
 	RunID          string
 	GitSHA         string
 	FeatureVersion string
-	Capital        int64 // Available capital
-	DryRun         bool  // If true, skip execution stage
+	Capital        int64            // Available capital (AccountCapital에 없는 계좌의 자본)
+	AccountCapital map[string]int64 // 계좌별 자본 (전략 자본 = 계좌 자본 × capital_pct)
+	DryRun         bool             // If true, skip execution stage
 }
 
 // RunResult holds the results of a complete pipeline run
@@ -64,10 +73,12 @@ type RunResult struct {
 	Universe           *contracts.Universe
 	SignalSet          *contracts.SignalSet
 	ScreenedStocks     []string
-	RankedStocks       []contracts.RankedStock
-	TargetPortfolio    *contracts.TargetPortfolio
-	ExecutionPlan      *contracts.ExecutionPlan
-	PerformanceReport  *audit.PerformanceReport
+	RankedStocks       []contracts.RankedStock    // 대표(첫) 전략
+	TargetPortfolio    *contracts.TargetPortfolio // 대표(첫) 전략
+	Strategies         []*StrategyResult          // 전략별 S4~S6 결과
+	ExecutionPlan      *contracts.ExecutionPlan   // 전체 전략, 전략 간 상계 후
+	PerformanceReport  *audit.PerformanceReport   // 대표(첫) 전략 계좌
+	PerformanceReports []*audit.PerformanceReport // 계좌별
 	Duration           time.Duration
 }
 
@@ -110,6 +121,11 @@ func NewOrchestrator(
 	}
 }
 
+// SetNotifier sets the emitter for pipeline/data quality failure alerts
+func (o *Orchestrator) SetNotifier(notifier notify.Emitter) {
+	o.notifier = notifier
+}
+
 // Run executes the complete 7-stage pipeline
 // S0 → S1 → S2 → S3 → S4 → S5 → S6 → S7
 func (o *Orchestrator) Run(ctx context.Context, config RunConfig) (*RunResult, error) {
@@ -133,14 +149,30 @@ func (o *Orchestrator) Run(ctx context.Context, config RunConfig) (*RunResult, e
 		"dry_run":         config.DryRun,
 	}).Info("Starting pipeline run")
 
+	// Metrics: 성공한 단계는 stageDone에서, 실패한 단계와 전체 결과는 종료 시 기록
+	stage, stageStart := "S0", time.Now()
+	stageDone := func(next string, items int) {
+		metrics.ObserveStage(stage, stageStart, nil, items)
+		stage, stageStart = next, time.Now()
+	}
+	defer func() {
+		if result.Error != nil {
+			metrics.ObserveStage(stage, stageStart, result.Error, 0)
+			o.notifyFailure(ctx, config, stage, result)
+		}
+		metrics.ObservePipelineRun(result.Error)
+	}()
+
 	// S0: Data Quality Gate
 	qualitySnapshot, err := o.runS0(ctx, config)
 	if err != nil {
+		result.QualitySnapshot = qualitySnapshot // 품질 미달이면 알림에 사용
 		result.Error = fmt.Errorf("S0 failed: %w", err)
 		return result, result.Error
 	}
 	result.QualitySnapshot = qualitySnapshot
 	result.CompletedStages = append(result.CompletedStages, "S0:Quality")
+	stageDone("S1", len(qualitySnapshot.Quarantined))
 
 	// S1: Universe Generation
 	universe, err := o.runS1(ctx, config, qualitySnapshot)
@@ -150,6 +182,7 @@ func (o *Orchestrator) Run(ctx context.Context, config RunConfig) (*RunResult, e
 	}
 	result.Universe = universe
 	result.CompletedStages = append(result.CompletedStages, "S1:Universe")
+	stageDone("S2", universe.TotalCount)
 
 	// S2: Signal Generation
 	signalSet, err := o.runS2(ctx, config, universe)
@@ -159,6 +192,7 @@ func (o *Orchestrator) Run(ctx context.Context, config RunConfig) (*RunResult, e
 	}
 	result.SignalSet = signalSet
 	result.CompletedStages = append(result.CompletedStages, "S2:Signals")
+	stageDone("S3", len(signalSet.Signals))
 
 	// S3: Screening
 	screened, err := o.runS3(ctx, config, universe.Stocks, signalSet)
@@ -168,46 +202,73 @@ func (o *Orchestrator) Run(ctx context.Context, config RunConfig) (*RunResult, e
 	}
 	result.ScreenedStocks = screened
 	result.CompletedStages = append(result.CompletedStages, "S3:Screener")
+	stageDone("S4", len(screened))
+
+	// S4~S6: 전략별 실행 (계좌 자본 × capital_pct)
+	strategies := o.activeStrategies()
+	for _, strategy := range strategies {
+		result.Strategies = append(result.Strategies, &StrategyResult{
+			Binding: strategy.Binding,
+			Capital: config.capitalFor(strategy.Binding),
+		})
+	}
 
 	// S4: Ranking
-	ranked, err := o.runS4(ctx, config, screened, signalSet)
-	if err != nil {
-		result.Error = fmt.Errorf("S4 failed: %w", err)
-		return result, result.Error
+	rankedCount := 0
+	for i, strategy := range strategies {
+		ranked, err := o.runS4(ctx, config, strategy, i == 0, screened, signalSet)
+		if err != nil {
+			result.Error = fmt.Errorf("S4 failed (%s): %w", strategy.Binding.StrategyID, err)
+			return result, result.Error
+		}
+		result.Strategies[i].RankedStocks = ranked
+		rankedCount += len(ranked)
 	}
-	result.RankedStocks = ranked
+	result.RankedStocks = result.Strategies[0].RankedStocks
 	result.CompletedStages = append(result.CompletedStages, "S4:Ranker")
+	stageDone("S5", rankedCount)
 
 	// S5: Portfolio Construction
-	targetPortfolio, err := o.runS5(ctx, config, ranked, config.Capital)
-	if err != nil {
-		result.Error = fmt.Errorf("S5 failed: %w", err)
-		return result, result.Error
+	positionCount := 0
+	for i, strategy := range strategies {
+		sr := result.Strategies[i]
+		targetPortfolio, err := o.runS5(ctx, config, strategy, sr.RankedStocks, sr.Capital)
+		if err != nil {
+			result.Error = fmt.Errorf("S5 failed (%s): %w", strategy.Binding.StrategyID, err)
+			return result, result.Error
+		}
+		sr.TargetPortfolio = targetPortfolio
+		positionCount += len(targetPortfolio.Positions)
 	}
-	result.TargetPortfolio = targetPortfolio
+	result.TargetPortfolio = result.Strategies[0].TargetPortfolio
 	result.CompletedStages = append(result.CompletedStages, "S5:Portfolio")
+	stageDone("S6", positionCount)
 
 	// S6: Execution Planning (skip if dry run)
 	if !config.DryRun {
-		executionPlan, err := o.runS6(ctx, config, targetPortfolio)
+		executionPlan, err := o.runS6(ctx, config, strategies, result.Strategies)
 		if err != nil {
 			result.Error = fmt.Errorf("S6 failed: %w", err)
 			return result, result.Error
 		}
 		result.ExecutionPlan = executionPlan
 		result.CompletedStages = append(result.CompletedStages, "S6:Execution")
+		stageDone("S7", executionPlan.TotalOrders())
 	} else {
 		o.logger.Info("Skipping S6:Execution (dry run mode)")
+		stage = "S7"
 	}
 
 	// S7: Performance Analysis
-	performanceReport, err := o.runS7(ctx, config)
+	performanceReports, err := o.runS7(ctx, config, accountIDs(strategies))
 	if err != nil {
 		result.Error = fmt.Errorf("S7 failed: %w", err)
 		return result, result.Error
 	}
-	result.PerformanceReport = performanceReport
+	result.PerformanceReports = performanceReports
+	result.PerformanceReport = performanceReports[0]
 	result.CompletedStages = append(result.CompletedStages, "S7:Audit")
+	stageDone("", 0)
 
 	// Mark success
 	result.Success = true
@@ -222,6 +283,24 @@ func (o *Orchestrator) Run(ctx context.Context, config RunConfig) (*RunResult, e
 	return result, nil
 }
 
+// notifyFailure emits a data quality alert for S0 rejections, a pipeline failure alert otherwise
+// 알림 실패는 파이프라인 결과에 영향 없음 (로그만)
+func (o *Orchestrator) notifyFailure(ctx context.Context, config RunConfig, stage string, result *RunResult) {
+	if o.notifier == nil {
+		return
+	}
+
+	event := notify.PipelineFailedEvent(config.RunID, config.Date, stage, result.Error)
+	if errors.Is(result.Error, ErrQualityGateFailed) && result.QualitySnapshot != nil {
+		event = notify.DataQualityFailedEvent(result.QualitySnapshot)
+	}
+
+	// 취소로 실패한 경우에도 알림은 적재
+	if err := o.notifier.Notify(context.WithoutCancel(ctx), event); err != nil {
+		o.logger.WithError(err).WithField("run_id", config.RunID).Warn("Failed to queue pipeline failure notification")
+	}
+}
+
 // runS0 executes S0: Data Quality Gate
 func (o *Orchestrator) runS0(ctx context.Context, config RunConfig) (*contracts.DataQualitySnapshot, error) {
 	o.logger.Info("Running S0: Data Quality Gate")
@@ -232,7 +311,7 @@ func (o *Orchestrator) runS0(ctx context.Context, config RunConfig) (*contracts.
 	}
 
 	if !snapshot.IsValid() {
-		return nil, fmt.Errorf("quality gate failed: score=%.2f", snapshot.QualityScore)
+		return snapshot, fmt.Errorf("%w: score=%.2f", ErrQualityGateFailed, snapshot.QualityScore)
 	}
 
 	// Save snapshot
@@ -243,6 +322,8 @@ func (o *Orchestrator) runS0(ctx context.Context, config RunConfig) (*contracts.
 	o.logger.WithFields(map[string]interface{}{
 		"quality_score": snapshot.QualityScore,
 		"passed":        snapshot.Passed,
+		"anomalies":     len(snapshot.Anomalies),
+		"quarantined":   len(snapshot.Quarantined),
 	}).Info("S0 completed")
 
 	return snapshot, nil
@@ -308,29 +389,34 @@ func (o *Orchestrator) runS3(ctx context.Context, config RunConfig, stocks []str
 	return screened, nil
 }
 
-// runS4 executes S4: Ranking
-func (o *Orchestrator) runS4(ctx context.Context, config RunConfig, stocks []string, signals *contracts.SignalSet) ([]contracts.RankedStock, error) {
-	o.logger.Info("Running S4: Ranking")
+// runS4 executes S4: Ranking for a strategy
+// selection.ranking_results는 날짜 단위 → 대표 전략(primary) 랭킹만 저장
+func (o *Orchestrator) runS4(ctx context.Context, config RunConfig, strategy Strategy, primary bool, stocks []string, signals *contracts.SignalSet) ([]contracts.RankedStock, error) {
+	o.logger.WithField("strategy", strategy.Binding.StrategyID).Info("Running S4: Ranking")
 
-	ranked, err := o.ranker.Rank(ctx, stocks, signals)
+	ranked, err := strategy.Ranker.Rank(ctx, stocks, signals)
 	if err != nil {
 		return nil, fmt.Errorf("ranking: %w", err)
 		}
 
 	// Save ranking results
-	if err := o.selectionRepo.SaveRankingResults(ctx, config.Date, ranked); err != nil {
-		return nil, fmt.Errorf("save ranking: %w", err)
+	if primary {
+		if err := o.selectionRepo.SaveRankingResults(ctx, config.Date, ranked); err != nil {
+			return nil, fmt.Errorf("save ranking: %w", err)
+		}
 	}
 
 	// Log result (handle empty case)
 	if len(ranked) > 0 {
 		o.logger.WithFields(map[string]interface{}{
+			"strategy":      strategy.Binding.StrategyID,
 			"ranked_stocks": len(ranked),
 			"top_code":      ranked[0].Code,
 			"top_score":     ranked[0].TotalScore,
 		}).Info("S4 completed")
 	} else {
 		o.logger.WithFields(map[string]interface{}{
+			"strategy":      strategy.Binding.StrategyID,
 			"ranked_stocks": 0,
 		}).Warn("S4 completed with no ranked stocks")
 	}
@@ -338,17 +424,19 @@ func (o *Orchestrator) runS4(ctx context.Context, config RunConfig, stocks []str
 	return ranked, nil
 }
 
-// runS5 executes S5: Portfolio Construction
-// ⭐ P0 수정: capital을 totalValue로 Construct에 전달
-func (o *Orchestrator) runS5(ctx context.Context, config RunConfig, ranked []contracts.RankedStock, capital int64) (*contracts.TargetPortfolio, error) {
-	o.logger.Info("Running S5: Portfolio Construction")
+// runS5 executes S5: Portfolio Construction for a strategy
+// ⭐ P0 수정: capital을 totalValue로 Construct에 전달 (전략 배분 자본)
+func (o *Orchestrator) runS5(ctx context.Context, config RunConfig, strategy Strategy, ranked []contracts.RankedStock, capital int64) (*contracts.TargetPortfolio, error) {
+	o.logger.WithField("strategy", strategy.Binding.StrategyID).Info("Running S5: Portfolio Construction")
 
 	// Build target portfolio using Constructor.Construct
 	// capital을 전달하여 TargetValue 계산 (TargetValue = Weight × capital)
-	targetPortfolio, err := o.portfolioBuilder.Construct(ctx, ranked, capital)
+	targetPortfolio, err := strategy.Portfolio.Construct(ctx, ranked, capital)
 	if err != nil {
 		return nil, fmt.Errorf("portfolio construct: %w", err)
 	}
+	targetPortfolio.StrategyID = strategy.Binding.StrategyID
+	targetPortfolio.AccountID = strategy.Binding.AccountID
 
 	// Save target portfolio
 	if err := o.portfolioRepo.SaveTargetPortfolio(ctx, targetPortfolio); err != nil {
@@ -356,6 +444,9 @@ func (o *Orchestrator) runS5(ctx context.Context, config RunConfig, ranked []con
 	}
 
 	o.logger.WithFields(map[string]interface{}{
+		"strategy":    strategy.Binding.StrategyID,
+		"account":     strategy.Binding.AccountID,
+		"capital":     capital,
 		"stocks":      len(targetPortfolio.Positions),
 		"cash_target": targetPortfolio.Cash,
 	}).Info("S5 completed")
@@ -364,61 +455,136 @@ func (o *Orchestrator) runS5(ctx context.Context, config RunConfig, ranked []con
 }
 
 // runS6 executes S6: Execution Planning
-func (o *Orchestrator) runS6(ctx context.Context, config RunConfig, targetPortfolio *contracts.TargetPortfolio) (*contracts.ExecutionPlan, error) {
+// 전략별로 주문을 만든 뒤 같은 계좌 × 종목의 전략 간 반대 주문을 상계 (self-crossing 방지)
+func (o *Orchestrator) runS6(ctx context.Context, config RunConfig, strategies []Strategy, results []*StrategyResult) (*contracts.ExecutionPlan, error) {
 	o.logger.Info("Running S6: Execution Planning")
 
-	// Create execution plan using Planner.Plan
-	orders, err := o.executionPlanner.Plan(ctx, targetPortfolio)
-	if err != nil {
-		return nil, fmt.Errorf("create execution plan: %w", err)
+	all := make([]contracts.Order, 0)
+	for i, strategy := range strategies {
+		// Create execution plan using Planner.Plan
+		orders, err := strategy.Planner.Plan(ctx, results[i].TargetPortfolio)
+		if err != nil {
+			return nil, fmt.Errorf("create execution plan (%s): %w", strategy.Binding.StrategyID, err)
+		}
+
+		// Tag orders (generate order ID if not set)
+		for j := range orders {
+			if orders[j].ID == "" {
+				orders[j].ID = fmt.Sprintf("%s_%s_order_%d", config.RunID, strategy.Binding.StrategyID, j+1)
+			}
+			if orders[j].StrategyVersion == "" {
+				orders[j].StrategyVersion = strategy.Binding.Version
+			}
+			if orders[j].StrategyVersion == "" {
+				orders[j].StrategyVersion = config.FeatureVersion
+			}
+			orders[j].StrategyID = strategy.Binding.StrategyID
+			orders[j].AccountID = strategy.Binding.AccountID
+		}
+		results[i].Orders = orders
+		all = append(all, orders...)
 	}
 
+	net, netted := execution.NetOrders(all, o.referencePrices(ctx, strategies, all))
+
 	executionPlan := &contracts.ExecutionPlan{
 		ID:        fmt.Sprintf("plan_%s", config.RunID),
 		Date:      config.Date,
-		Orders:    orders,
+		Orders:    net,
+		Netted:    netted,
 		CreatedAt: time.Now(),
 	}
 
-	// Save each order (generate order ID if not set)
+	// Save orders and netted legs (상계 leg는 NETTED 상태로 원장 귀속용)
 	for i := range executionPlan.Orders {
-		if executionPlan.Orders[i].ID == "" {
-			executionPlan.Orders[i].ID = fmt.Sprintf("%s_order_%d", config.RunID, i+1)
-		}
 		if err := o.executionRepo.SaveOrder(ctx, &executionPlan.Orders[i]); err != nil {
 			return nil, fmt.Errorf("save order: %w", err)
 		}
 	}
+	for i := range executionPlan.Netted {
+		if err := o.executionRepo.SaveOrder(ctx, &executionPlan.Netted[i]); err != nil {
+			return nil, fmt.Errorf("save netted order: %w", err)
+		}
+	}
 
 	o.logger.WithFields(map[string]interface{}{
-		"orders": len(executionPlan.Orders),
+		"strategies": len(strategies),
+		"orders":     len(executionPlan.Orders),
+		"netted":     len(executionPlan.Netted),
 	}).Info("S6 completed")
 
 	return executionPlan, nil
 }
 
-// runS7 executes S7: Performance Analysis
-func (o *Orchestrator) runS7(ctx context.Context, config RunConfig) (*audit.PerformanceReport, error) {
-	o.logger.Info("Running S7: Performance Analysis")
+// referencePrices looks up current prices for stocks with market orders (상계가 기준)
+// 조회 실패 종목은 제외 → 양쪽 시장가 쌍은 상계하지 않고 그대로 전송
+func (o *Orchestrator) referencePrices(ctx context.Context, strategies []Strategy, orders []contracts.Order) map[string]float64 {
+	planners := make(map[string]*execution.Planner, len(strategies))
+	for _, s := range strategies {
+		planners[s.Binding.StrategyID] = s.Planner
+	}
 
-	// Analyze performance for the last month (period format: "1M")
-	report, err := o.performanceAnalyzer.Analyze(ctx, "1M")
-	if err != nil {
-		return nil, fmt.Errorf("performance analysis: %w", err)
+	prices := make(map[string]float64)
+	for _, order := range orders {
+		if order.Price > 0 {
+			continue
+		}
+		if _, ok := prices[order.Code]; ok {
+			continue
+		}
+		planner := planners[order.StrategyID]
+		if planner == nil {
+			continue
+		}
+		price, err := planner.ReferencePrice(ctx, order.Code)
+		if err != nil {
+			o.logger.WithFields(map[string]interface{}{
+				"code":  order.Code,
+				"error": err.Error(),
+			}).Warn("Failed to get reference price, market orders will not be netted")
+			continue
+		}
+		prices[order.Code] = price
 	}
+	return prices
+}
+
+// runS7 executes S7: Performance Analysis per account
+func (o *Orchestrator) runS7(ctx context.Context, config RunConfig, accounts []string) ([]*audit.PerformanceReport, error) {
+	o.logger.Info("Running S7: Performance Analysis")
 
-	// Save performance report
-	if err := o.auditRepo.SavePerformanceReport(ctx, report); err != nil {
-		return nil, fmt.Errorf("save performance report: %w", err)
+	// 실현손익 원장 재구성 (전 계좌 체결 + 전략 간 상계, 승률/손익비는 원장의 라운드트립 기준)
+	if _, err := o.performanceAnalyzer.RebuildTradeLedger(ctx); err != nil {
+		o.logger.WithError(err).Warn("Failed to rebuild trade ledger, trade statistics may be stale")
 	}
 
-	o.logger.WithFields(map[string]interface{}{
-		"return": report.TotalReturn,
-		"sharpe": report.Sharpe,
-		"mdd":    report.MaxDrawdown,
-	}).Info("S7 completed")
+	reports := make([]*audit.PerformanceReport, 0, len(accounts))
+	for _, account := range accounts {
+		repo := o.auditRepo.ForAccount(account)
+		analyzer := audit.NewAnalyzer(repo, o.logger)
+
+		// Analyze performance for the last month (period format: "1M")
+		report, err := analyzer.Analyze(ctx, "1M")
+		if err != nil {
+			return nil, fmt.Errorf("performance analysis (%s): %w", account, err)
+		}
+
+		// Save performance report
+		if err := repo.SavePerformanceReport(ctx, report); err != nil {
+			return nil, fmt.Errorf("save performance report (%s): %w", account, err)
+		}
+
+		o.logger.WithFields(map[string]interface{}{
+			"account": account,
+			"return":  report.TotalReturn,
+			"sharpe":  report.Sharpe,
+			"mdd":     report.MaxDrawdown,
+		}).Info("S7 completed")
+
+		reports = append(reports, report)
+	}
 
-	return report, nil
+	return reports, nil
 }
 
 // GenerateRunID generates a unique run ID
@@ -0,0 +1,71 @@
+package brain
+
+import (
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/execution"
+	"github.com/wonny/aegis/v13/backend/internal/portfolio"
+	"github.com/wonny/aegis/v13/backend/internal/selection"
+)
+
+// Strategy holds one strategy's S4~S6 components and its account binding
+// S0~S3 (품질/유니버스/시그널/스크리닝)은 전략 공통, S4~S6은 전략별 설정으로 실행
+type Strategy struct {
+	Binding   contracts.StrategyBinding
+	Ranker    *selection.Ranker
+	Portfolio *portfolio.Constructor
+	Planner   *execution.Planner
+}
+
+// StrategyResult holds one strategy's S4~S6 results
+type StrategyResult struct {
+	Binding         contracts.StrategyBinding
+	Capital         int64 // 계좌 자본 × capital_pct
+	RankedStocks    []contracts.RankedStock
+	TargetPortfolio *contracts.TargetPortfolio
+	Orders          []contracts.Order // 상계 전 주문
+}
+
+// SetStrategies sets the strategies to run (config/strategy/*.yaml 레지스트리 순서, 첫 전략이 대표)
+// 미설정 시 생성자 컴포넌트로 default 계좌 단일 전략 실행
+func (o *Orchestrator) SetStrategies(strategies []Strategy) {
+	o.strategies = strategies
+}
+
+// activeStrategies returns the configured strategies or the single default strategy
+func (o *Orchestrator) activeStrategies() []Strategy {
+	if len(o.strategies) > 0 {
+		return o.strategies
+	}
+	return []Strategy{{
+		Binding: contracts.StrategyBinding{
+			StrategyID: contracts.DefaultStrategyID,
+			AccountID:  contracts.DefaultAccountID,
+			CapitalPct: 1.0,
+		},
+		Ranker:    o.ranker,
+		Portfolio: o.portfolioBuilder,
+		Planner:   o.executionPlanner,
+	}}
+}
+
+// capitalFor returns the strategy's capital (계좌 자본 미지정 시 RunConfig.Capital)
+func (c RunConfig) capitalFor(binding contracts.StrategyBinding) int64 {
+	accountCapital, ok := c.AccountCapital[binding.AccountID]
+	if !ok {
+		accountCapital = c.Capital
+	}
+	return binding.Capital(accountCapital)
+}
+
+// accountIDs returns the distinct accounts of the strategies in order
+func accountIDs(strategies []Strategy) []string {
+	seen := make(map[string]bool)
+	ids := make([]string, 0)
+	for _, s := range strategies {
+		if !seen[s.Binding.AccountID] {
+			seen[s.Binding.AccountID] = true
+			ids = append(ids, s.Binding.AccountID)
+		}
+	}
+	return ids
+}
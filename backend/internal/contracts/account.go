@@ -0,0 +1,23 @@
+package contracts
+
+// DefaultAccountID is the account used when no account is specified
+// (pkg/config.DefaultAccountID와 동일, KIS_ACCOUNTS 미설정 시 유일한 계좌)
+const DefaultAccountID = "default"
+
+// DefaultStrategyID is the strategy used when no strategy registry is configured
+// (단일 전략 시절 config/strategy/korea_equity_v13.yaml, migration 044 backfill 값)
+const DefaultStrategyID = "korea_equity_v13"
+
+// StrategyBinding binds a strategy to an account and its share of the account capital
+// ⭐ SSOT: 전략 ↔ 계좌 배분 (config/strategy/*.yaml account 섹션)
+type StrategyBinding struct {
+	StrategyID string  `json:"strategy_id"`
+	Version    string  `json:"version"`
+	AccountID  string  `json:"account_id"`
+	CapitalPct float64 `json:"capital_pct"` // 계좌 자본 중 배분 비율 (0.0 ~ 1.0)
+}
+
+// Capital returns the strategy's share of the account capital
+func (b StrategyBinding) Capital(accountCapital int64) int64 {
+	return int64(float64(accountCapital) * b.CapitalPct)
+}
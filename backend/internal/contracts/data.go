@@ -11,6 +11,11 @@ type DataQualitySnapshot struct {
 	Coverage     map[string]float64 `json:"coverage"`      // 데이터별 커버리지
 	QualityScore float64            `json:"quality_score"` // 0.0 ~ 1.0
 	Passed       bool               `json:"passed"`        // 품질 검증 통과 여부
+
+	// 레코드 단위 이상치 (커버리지와 별개)
+	// 격리 종목은 S1에서 제외되고, 나머지 종목은 정상 진행
+	Anomalies   []DataAnomaly     `json:"anomalies,omitempty"`
+	Quarantined map[string]string `json:"quarantined,omitempty"` // 종목코드 → 격리 사유
 }
 
 // IsValid checks if the data quality snapshot meets minimum requirements
@@ -19,6 +24,11 @@ func (d *DataQualitySnapshot) IsValid() bool {
 	return d.QualityScore >= 0.2 && d.ValidStocks > 0
 }
 
+// QuarantineReason returns why a stock is quarantined, or "" if it is not
+func (d *DataQualitySnapshot) QuarantineReason(code string) string {
+	return d.Quarantined[code]
+}
+
 // CoverageRate returns the average coverage rate across all data types
 func (d *DataQualitySnapshot) CoverageRate() float64 {
 	if len(d.Coverage) == 0 {
@@ -32,3 +42,39 @@ func (d *DataQualitySnapshot) CoverageRate() float64 {
 
 	return total / float64(len(d.Coverage))
 }
+
+// AnomalyType classifies a per-record data anomaly
+type AnomalyType string
+
+// ⭐ SSOT: S0 레코드 이상치 유형
+const (
+	AnomalyOHLC           AnomalyType = "OHLC_INCONSISTENT" // 고가/저가가 시가·종가 범위를 벗어남 또는 0 이하 가격
+	AnomalyZeroVolume     AnomalyType = "ZERO_VOLUME"       // 거래정지 아닌 종목의 거래량 0
+	AnomalyPriceJump      AnomalyType = "PRICE_JUMP"        // 기업행위 없이 가격제한폭(±30%) 초과
+	AnomalyDuplicateRow   AnomalyType = "DUPLICATE_ROW"     // 직전 거래일 일봉과 OHLCV 완전 동일
+	AnomalyFlowOutOfRange AnomalyType = "FLOW_OUT_OF_RANGE" // 투자자 순매수 수량이 거래량 초과 (단위 오류)
+	AnomalyStaleMarketCap AnomalyType = "STALE_MARKET_CAP"  // 시가총액 갱신 지연
+)
+
+// DataAnomaly is one anomaly found for a stock on the snapshot date
+type DataAnomaly struct {
+	Code   string      `json:"code"`
+	Type   AnomalyType `json:"type"`
+	Detail string      `json:"detail"`
+}
+
+// SpreadStat represents daily bid-ask spread statistics for a stock
+// ⭐ SSOT: 호가 스프레드 일별 통계 (S1 spread 필터 입력)
+// SpreadPct = (ask1-bid1)/((ask1+bid1)/2), 정규장 호가 스냅샷 표본 기준
+type SpreadStat struct {
+	Code           string    `json:"code"`
+	Date           time.Time `json:"date"`
+	AvgSpreadPct   float64   `json:"avg_spread_pct"`
+	MinSpreadPct   float64   `json:"min_spread_pct"`
+	MaxSpreadPct   float64   `json:"max_spread_pct"`
+	SampleCount    int       `json:"sample_count"`
+	AvgBid1Qty     int64     `json:"avg_bid1_qty"`
+	AvgAsk1Qty     int64     `json:"avg_ask1_qty"`
+	AvgTotalBidQty int64     `json:"avg_total_bid_qty"`
+	AvgTotalAskQty int64     `json:"avg_total_ask_qty"`
+}
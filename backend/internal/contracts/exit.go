@@ -46,6 +46,23 @@ type ExitRulesConfig struct {
 
 	// 모니터링 주기
 	CheckIntervalSeconds int `json:"check_interval_seconds" yaml:"check_interval_seconds"`
+
+	// 실시간 체결 기반 평가 (PriceCache 구독)
+	TickDriven       bool `json:"tick_driven" yaml:"tick_driven"`               // 체결마다 청산 규칙 평가
+	StaleTickSeconds int  `json:"stale_tick_seconds" yaml:"stale_tick_seconds"` // 마지막 체결 후 N초 경과 시 폴링 fallback
+
+	// ===== 시간 기반 청산 (0 = 비활성) =====
+	MaxHoldingDays   int     `json:"max_holding_days" yaml:"max_holding_days"`     // 최대 보유 거래일
+	StaleDays        int     `json:"stale_days" yaml:"stale_days"`                 // N 거래일 경과 후
+	StaleBandPercent float64 `json:"stale_band_percent" yaml:"stale_band_percent"` // |수익률| < band%면 정체 포지션 청산
+
+	// ===== 이벤트 기반 청산 =====
+	RankExitTopK              int     `json:"rank_exit_top_k" yaml:"rank_exit_top_k"`                         // 최신 랭킹 Top-K 이탈 시 청산 (0 = 비활성)
+	DisclosureExit            bool    `json:"disclosure_exit" yaml:"disclosure_exit"`                         // 부정 공시 청산
+	DisclosureImpactThreshold float64 `json:"disclosure_impact_threshold" yaml:"disclosure_impact_threshold"` // 이벤트 임팩트 ≤ threshold (-0.7)
+	DisclosureLookbackHours   int     `json:"disclosure_lookback_hours" yaml:"disclosure_lookback_hours"`     // 공시 조회 기간
+	DelistingExit             bool    `json:"delisting_exit" yaml:"delisting_exit"`                           // 상장폐지/관리종목 지정 전 강제 청산
+	EventCheckIntervalSeconds int     `json:"event_check_interval_seconds" yaml:"event_check_interval_seconds"`
 }
 
 // DefaultExitRulesConfig 기본 청산 규칙 설정 반환
@@ -88,6 +105,16 @@ func DefaultExitRulesConfig() *ExitRulesConfig {
 
 		// 모니터링
 		CheckIntervalSeconds: 30,
+
+		// 실시간: 체결 기반 평가, 10초 이상 체결 없으면 폴링
+		TickDriven:       true,
+		StaleTickSeconds: 10,
+
+		// 시간/이벤트: 상장폐지 위험만 기본 활성화, 나머지는 전략 설정에서 지정
+		DisclosureImpactThreshold: -0.7,
+		DisclosureLookbackHours:   24,
+		DelistingExit:             true,
+		EventCheckIntervalSeconds: 300,
 	}
 }
 
@@ -124,6 +151,33 @@ const (
 	ExitReasonStopFloor  ExitReason = "STOP_FLOOR"  // 스탑 플로어 (손익분기점+0.6%)
 	ExitReasonHWMTrail   ExitReason = "HWM_TRAIL"   // HWM 트레일링 스탑
 	ExitReasonManual     ExitReason = "MANUAL"      // 수동 청산
+
+	// 시간/이벤트 기반 (전량 청산)
+	ExitReasonMaxHolding ExitReason = "MAX_HOLDING"         // 최대 보유기간 초과
+	ExitReasonStale      ExitReason = "STALE_POSITION"      // N일 경과 후 수익률 정체
+	ExitReasonRankDrop   ExitReason = "RANK_DROP"           // 최신 랭킹 Top-K 이탈
+	ExitReasonDisclosure ExitReason = "NEGATIVE_DISCLOSURE" // 부정 공시 (DART)
+	ExitReasonDelisting  ExitReason = "DELISTING_RISK"      // 상장폐지 예정/관리종목 지정
+)
+
+// IsRiskReducing reports stop-loss and forced exits (손절/트레일링 스탑/부정 공시/상장폐지 위험)
+// 주문 금액·ADTV·중복 한도로 막으면 손실이 커지므로 사전 검사에서 면제 (킬 스위치는 적용)
+func (r ExitReason) IsRiskReducing() bool {
+	switch r {
+	case ExitReasonHardStop, ExitReasonFirstStop, ExitReasonSecondStop, ExitReasonStopFloor, ExitReasonHWMTrail,
+		ExitReasonDisclosure, ExitReasonDelisting:
+		return true
+	}
+	return false
+}
+
+// ExitEvalSource 청산 신호를 만든 가격 평가 경로
+type ExitEvalSource string
+
+const (
+	ExitEvalTick  ExitEvalSource = "TICK"  // 실시간 체결 (PriceCache 구독)
+	ExitEvalPoll  ExitEvalSource = "POLL"  // 주기적 현재가 조회 (fallback)
+	ExitEvalEvent ExitEvalSource = "EVENT" // 시간/이벤트 규칙 (보유기간, 랭킹, 공시, 상장폐지)
 )
 
 // ExitSignal 청산 신호
@@ -140,6 +194,9 @@ type ExitSignal struct {
 	IsPartial    bool       `json:"is_partial"` // 분할 청산 여부
 	Message      string     `json:"message"`
 	TriggeredAt  time.Time  `json:"triggered_at"`
+
+	EvalSource ExitEvalSource `json:"eval_source"`          // TICK | POLL | EVENT
+	LatencyMs  float64        `json:"latency_ms,omitempty"` // 체결 시각 → 신호 생성 지연 (TICK만)
 }
 
 // MonitoredPosition 모니터링 중인 포지션
@@ -15,6 +15,12 @@ type Order struct {
 	Status    Status    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// 실현손익 원장 귀속 정보
+	ExitReason      ExitReason `json:"exit_reason,omitempty"`      // SELL 전용: 청산 사유
+	StrategyVersion string     `json:"strategy_version,omitempty"` // 주문 생성 전략 버전
+	StrategyID      string     `json:"strategy_id,omitempty"`      // 주문 생성 전략 (수동/청산 주문은 빈 값 가능)
+	AccountID       string     `json:"account_id,omitempty"`       // 주문 계좌 (빈 값 = DefaultAccountID)
 }
 
 // OrderSide represents buy or sell
@@ -42,8 +48,17 @@ const (
 	StatusFilled    Status = "FILLED"
 	StatusCanceled  Status = "CANCELED"
 	StatusRejected  Status = "REJECTED"
+	StatusNetted    Status = "NETTED" // 같은 계좌 전략 간 상계 (브로커 전송 안 함)
 )
 
+// Account returns the order account, DefaultAccountID if unset
+func (o *Order) Account() string {
+	if o.AccountID == "" {
+		return DefaultAccountID
+	}
+	return o.AccountID
+}
+
 // IsMarketOrder checks if the order is a market order
 func (o *Order) IsMarketOrder() bool {
 	return o.OrderType == OrderTypeMarket
@@ -59,7 +74,8 @@ func (o *Order) IsFilled() bool {
 type ExecutionPlan struct {
 	ID        string    `json:"id"`
 	Date      time.Time `json:"date"`
-	Orders    []Order   `json:"orders"`
+	Orders    []Order   `json:"orders"`           // 브로커 전송 대상 (전략 간 상계 후)
+	Netted    []Order   `json:"netted,omitempty"` // 전략 간 상계 leg (NETTED, 전송 안 함)
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -5,9 +5,11 @@ import "time"
 // TargetPortfolio represents the target portfolio passed from S5 to S6
 // ⭐ SSOT: S5 → S6 목표 포트폴리오 전달
 type TargetPortfolio struct {
-	Date      time.Time        `json:"date"`
-	Positions []TargetPosition `json:"positions"`
-	Cash      float64          `json:"cash"` // 목표 현금 비중 (0.0 ~ 1.0)
+	Date       time.Time        `json:"date"`
+	StrategyID string           `json:"strategy_id,omitempty"`
+	AccountID  string           `json:"account_id,omitempty"`
+	Positions  []TargetPosition `json:"positions"`
+	Cash       float64          `json:"cash"` // 목표 현금 비중 (0.0 ~ 1.0)
 }
 
 // TargetPosition represents a target position in the portfolio
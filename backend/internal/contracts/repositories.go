@@ -27,6 +27,23 @@ type Price struct {
 	Volume int64
 }
 
+// PriceBasis selects raw or corporate-action adjusted daily prices
+// ⭐ SSOT: 원주가/수정주가 선택은 소비자가 명시적으로 지정
+type PriceBasis string
+
+const (
+	PriceBasisRaw      PriceBasis = "RAW"      // 수집 원본 (data.daily_prices)
+	PriceBasisAdjusted PriceBasis = "ADJUSTED" // 액면분할/무상·유상증자/배당 역산 수정주가 (data.daily_prices_adjusted)
+)
+
+// Table returns the relation holding daily prices for the basis
+func (b PriceBasis) Table() string {
+	if b == PriceBasisAdjusted {
+		return "data.daily_prices_adjusted"
+	}
+	return "data.daily_prices"
+}
+
 // InvestorFlowRepository manages investor flow (수급) data
 type InvestorFlowRepository interface {
 	GetByCodeAndDate(ctx context.Context, code string, date time.Time) (*InvestorFlow, error)
@@ -63,7 +80,8 @@ type Financial struct {
 	Assets    int64   // 자산총계
 	Equity    int64   // 자본총계
 	Debt      int64   // 부채총계
-	ROE       float64 // Return on Equity
+	ROE       float64 // Return on Equity (TTM 우선, %)
+	OpMargin  float64 // 영업이익률 (TTM, %)
 	DebtRatio float64 // 부채비율
 	PER       float64 // Price to Earnings Ratio
 	PBR       float64 // Price to Book Ratio
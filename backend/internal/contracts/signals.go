@@ -51,6 +51,7 @@ type SignalDetails struct {
 
 	// Quality
 	ROE       float64 `json:"roe"`
+	OpMargin  float64 `json:"op_margin"`
 	DebtRatio float64 `json:"debt_ratio"`
 
 	// Flow (수급)
@@ -31,3 +31,36 @@ func (u *Universe) IsExcluded(code string) (bool, string) {
 func (u *Universe) Count() int {
 	return len(u.Stocks)
 }
+
+// KRXFlag represents a KRX market-measure designation on a stock
+// ⭐ SSOT: universe.exclude_krx_flags 열거형
+type KRXFlag string
+
+const (
+	KRXFlagTradingHalt       KRXFlag = "TRADING_HALT"       // 거래정지 (매매거래정지·임시정지 포함)
+	KRXFlagAdminIssue        KRXFlag = "ADMIN_ISSUE"        // 관리종목
+	KRXFlagManagement        KRXFlag = "MANAGEMENT"         // 투자유의/투자주의환기종목
+	KRXFlagInvestmentCaution KRXFlag = "INVESTMENT_CAUTION" // 투자주의
+	KRXFlagInvestmentWarning KRXFlag = "INVESTMENT_WARNING" // 투자경고
+	KRXFlagInvestmentDanger  KRXFlag = "INVESTMENT_DANGER"  // 투자위험
+)
+
+// KnownKRXFlags lists every supported designation
+var KnownKRXFlags = []KRXFlag{
+	KRXFlagTradingHalt,
+	KRXFlagAdminIssue,
+	KRXFlagManagement,
+	KRXFlagInvestmentCaution,
+	KRXFlagInvestmentWarning,
+	KRXFlagInvestmentDanger,
+}
+
+// IsKnownKRXFlag checks whether s is a supported designation
+func IsKnownKRXFlag(s string) bool {
+	for _, f := range KnownKRXFlags {
+		if string(f) == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,292 @@
+// Package execution - exit_events.go
+// 시간/이벤트 기반 청산 규칙
+// - 시간: 최대 보유기간 초과, N일 경과 후 수익률 정체
+// - 이벤트: 최신 랭킹 Top-K 이탈, 부정 공시(DART), 상장폐지 예정, 관리종목 지정(KRX 상태/지정 공시)
+// - 모든 신호는 가격 기반 청산과 동일하게 executeExit(중복 방지/신호 이력/알림)로 처리
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/s2_signals"
+)
+
+// RankingProvider 최신 랭킹 조회 (selection.Repository)
+type RankingProvider interface {
+	GetLatestRankingResults(ctx context.Context, limit int) ([]contracts.RankedStock, time.Time, error)
+}
+
+// DisclosureProvider 공시 조회 (s0_data.DisclosureRepository)
+type DisclosureProvider interface {
+	GetByCodeAndDateRange(ctx context.Context, code string, from, to time.Time) ([]*contracts.Disclosure, error)
+}
+
+// StockStatusProvider 종목 상장 상태 조회 (s0_data.Repository)
+type StockStatusProvider interface {
+	GetStockStatus(ctx context.Context, code string) (string, *time.Time, error)
+}
+
+// StockFlagsProvider 종목 KRX 지정 상태 조회 (stockmaster.Repository, data.stock_status_history)
+type StockFlagsProvider interface {
+	GetLatestFlags(ctx context.Context, code string, asOf time.Time, maxAgeDays int) ([]contracts.KRXFlag, error)
+}
+
+// TradingCalendar KRX 개장일 판정 (calendar.Calendar)
+type TradingCalendar interface {
+	IsTradingDay(date time.Time) bool
+}
+
+// stockFlagsMaxAgeDays 이보다 오래된 상태 행은 무시 (수집 중단 시 과거 지정으로 청산하지 않음)
+const stockFlagsMaxAgeDays = 7
+
+// SetEventProviders 이벤트 기반 청산에 필요한 데이터 소스 설정 (nil 가능 → 해당 규칙 비활성)
+func (pm *PositionMonitor) SetEventProviders(ranking RankingProvider, disclosures DisclosureProvider, status StockStatusProvider) {
+	pm.rankingProvider = ranking
+	pm.disclosureProvider = disclosures
+	pm.statusProvider = status
+}
+
+// SetTradingCalendar 보유기간 산정용 KRX 거래일 캘린더 설정 (nil → 평일 기준)
+func (pm *PositionMonitor) SetTradingCalendar(cal TradingCalendar) {
+	pm.calendar = cal
+}
+
+// SetStockFlagsProvider 관리종목 지정 판정용 KRX 상태 소스 설정 (nil 가능 → 공시로만 판정)
+func (pm *PositionMonitor) SetStockFlagsProvider(flags StockFlagsProvider) {
+	pm.flagsProvider = flags
+}
+
+// hasEventRules 시간/이벤트 규칙 활성 여부
+func (pm *PositionMonitor) hasEventRules() bool {
+	c := pm.config
+	return c.MaxHoldingDays > 0 || c.StaleDays > 0 || c.RankExitTopK > 0 || c.DisclosureExit || c.DelistingExit
+}
+
+// =============================================================================
+// Time Rules
+// =============================================================================
+
+// EvaluateTimeRules 시간 기반 청산 조건 평가 (락 없음)
+// 백테스트(backtest.ExitSimulator)도 동일한 판정을 사용
+func (pm *PositionMonitor) EvaluateTimeRules(pos *contracts.MonitoredPosition, price int64, at time.Time) *contracts.ExitSignal {
+	if pos.RemainingQuantity <= 0 || pos.EntryTime.IsZero() || pos.EntryPrice <= 0 {
+		return nil
+	}
+
+	days := tradingDaysSince(pos.EntryTime, at, pm.calendar)
+
+	// 1. 최대 보유기간
+	if pm.config.MaxHoldingDays > 0 && days >= pm.config.MaxHoldingDays {
+		return pm.createEventExitSignal(pos, price, contracts.ExitReasonMaxHolding,
+			fmt.Sprintf("최대 보유기간 초과: %d거래일 (한도 %d일)", days, pm.config.MaxHoldingDays))
+	}
+
+	// 2. 정체 포지션: N일 경과 후 수익률이 ±band% 이내
+	if pm.config.StaleDays > 0 && days >= pm.config.StaleDays && price > 0 {
+		pnl := float64(price-pos.EntryPrice) / float64(pos.EntryPrice) * 100
+		if math.Abs(pnl) < pm.config.StaleBandPercent {
+			return pm.createEventExitSignal(pos, price, contracts.ExitReasonStale,
+				fmt.Sprintf("정체 포지션: %d거래일 경과, 수익률 %+.1f%% (±%.1f%% 이내)", days, pnl, pm.config.StaleBandPercent))
+		}
+	}
+
+	return nil
+}
+
+// tradingDaysSince 진입일 다음날부터 at까지의 거래일 수
+// cal이 nil이면 평일 기준 (휴장일 미반영)
+func tradingDaysSince(entry, at time.Time, cal TradingCalendar) int {
+	start := time.Date(entry.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := 0
+	for d := start.AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if cal != nil {
+			if cal.IsTradingDay(d) {
+				days++
+			}
+			continue
+		}
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
+
+// =============================================================================
+// Event Rules
+// =============================================================================
+
+// CheckEventRules 전체 포지션의 시간/이벤트 청산 조건 평가 후 실행
+func (pm *PositionMonitor) CheckEventRules(ctx context.Context) []*contracts.ExitSignal {
+	now := time.Now()
+
+	// 랭킹은 1회만 조회
+	topK, rankDate := pm.loadTopK(ctx)
+
+	// DB 조회 중 체결 처리를 막지 않도록 포지션 스냅샷으로 평가
+	pm.mu.RLock()
+	snapshots := make([]contracts.MonitoredPosition, 0, len(pm.positions))
+	for _, pos := range pm.positions {
+		snapshots = append(snapshots, *pos)
+	}
+	pm.mu.RUnlock()
+
+	var signals []*contracts.ExitSignal
+	for i := range snapshots {
+		pos := &snapshots[i]
+
+		signal, err := pm.evaluateEventRules(ctx, pos, topK, rankDate, now)
+		if err != nil {
+			pm.logger.WithFields(map[string]interface{}{
+				"code":  pos.Code,
+				"error": err.Error(),
+			}).Warn("Error evaluating event exit rules")
+			continue
+		}
+		if signal == nil {
+			continue
+		}
+
+		pm.logger.WithFields(map[string]interface{}{
+			"code":     signal.Code,
+			"reason":   signal.Reason,
+			"quantity": signal.SellQuantity,
+			"pnl":      signal.PnLPercent,
+			"message":  signal.Message,
+		}).Info("Exit signal generated from event rule")
+
+		pm.executeExit(ctx, signal)
+		signals = append(signals, signal)
+	}
+
+	return signals
+}
+
+// evaluateEventRules 포지션 1건 평가 (우선순위: 상장폐지 > 관리종목 > 부정 공시 > 시간 > 랭킹 이탈)
+func (pm *PositionMonitor) evaluateEventRules(ctx context.Context, pos *contracts.MonitoredPosition, topK map[string]bool, rankDate time.Time, now time.Time) (*contracts.ExitSignal, error) {
+	price := pos.CurrentPrice
+
+	// 1. 상장폐지 예정/상장폐지 상태
+	if pm.config.DelistingExit && pm.statusProvider != nil {
+		status, delistingDate, err := pm.statusProvider.GetStockStatus(ctx, pos.Code)
+		if err != nil {
+			return nil, fmt.Errorf("get stock status: %w", err)
+		}
+		if delistingDate != nil || status == "delisted" {
+			msg := fmt.Sprintf("상장폐지 위험: status=%s", status)
+			if delistingDate != nil {
+				msg = fmt.Sprintf("상장폐지 예정: %s", delistingDate.Format("2006-01-02"))
+			}
+			return pm.createEventExitSignal(pos, price, contracts.ExitReasonDelisting, msg), nil
+		}
+	}
+
+	// 2. 관리종목 지정 (KRX 상태 이력)
+	if pm.config.DelistingExit && pm.flagsProvider != nil {
+		flags, err := pm.flagsProvider.GetLatestFlags(ctx, pos.Code, now, stockFlagsMaxAgeDays)
+		if err != nil {
+			return nil, fmt.Errorf("get stock flags: %w", err)
+		}
+		for _, f := range flags {
+			if f == contracts.KRXFlagAdminIssue {
+				return pm.createEventExitSignal(pos, price, contracts.ExitReasonDelisting, "관리종목 지정 (KRX 상태)"), nil
+			}
+		}
+	}
+
+	// 3. 공시: 상장폐지 위험/관리종목 지정 또는 부정 공시
+	if (pm.config.DisclosureExit || pm.config.DelistingExit) && pm.disclosureProvider != nil {
+		from := now.Add(-time.Duration(pm.config.DisclosureLookbackHours) * time.Hour)
+		if pos.EntryTime.After(from) {
+			from = pos.EntryTime // 진입 전 공시는 이미 선정 단계에서 반영됨
+		}
+
+		disclosures, err := pm.disclosureProvider.GetByCodeAndDateRange(ctx, pos.Code, from, now)
+		if err != nil {
+			return nil, fmt.Errorf("get disclosures: %w", err)
+		}
+
+		for _, d := range disclosures {
+			if pm.config.DelistingExit && s2_signals.IsDelistingRiskDisclosure(d.Title) {
+				return pm.createEventExitSignal(pos, price, contracts.ExitReasonDelisting,
+					fmt.Sprintf("상장폐지 위험 공시: %s", d.Title)), nil
+			}
+			if pm.config.DelistingExit && s2_signals.IsAdminDesignationDisclosure(d.Title) {
+				return pm.createEventExitSignal(pos, price, contracts.ExitReasonDelisting,
+					fmt.Sprintf("관리종목 지정 공시: %s", d.Title)), nil
+			}
+
+			if !pm.config.DisclosureExit {
+				continue
+			}
+			eventType, impact := s2_signals.ClassifyDisclosure(d.Title)
+			if impact <= pm.config.DisclosureImpactThreshold {
+				return pm.createEventExitSignal(pos, price, contracts.ExitReasonDisclosure,
+					fmt.Sprintf("부정 공시(%s, %.1f): %s", eventType, impact, d.Title)), nil
+			}
+		}
+	}
+
+	// 4. 시간 기반
+	if signal := pm.EvaluateTimeRules(pos, price, now); signal != nil {
+		return signal, nil
+	}
+
+	// 5. 랭킹 Top-K 이탈 (진입 이후 생성된 랭킹 기준)
+	if len(topK) > 0 && !topK[pos.Code] && rankDate.After(pos.EntryTime) {
+		return pm.createEventExitSignal(pos, price, contracts.ExitReasonRankDrop,
+			fmt.Sprintf("랭킹 Top-%d 이탈 (%s)", pm.config.RankExitTopK, rankDate.Format("2006-01-02"))), nil
+	}
+
+	return nil, nil
+}
+
+// loadTopK 최신 랭킹 Top-K 종목 집합
+func (pm *PositionMonitor) loadTopK(ctx context.Context) (map[string]bool, time.Time) {
+	if pm.config.RankExitTopK <= 0 || pm.rankingProvider == nil {
+		return nil, time.Time{}
+	}
+
+	ranked, rankDate, err := pm.rankingProvider.GetLatestRankingResults(ctx, pm.config.RankExitTopK)
+	if err != nil {
+		pm.logger.WithFields(map[string]interface{}{
+			"error": err.Error(),
+		}).Warn("Failed to load latest ranking, skipping rank exit rule")
+		return nil, time.Time{}
+	}
+
+	topK := make(map[string]bool, len(ranked))
+	for _, r := range ranked {
+		topK[r.Code] = true
+	}
+	return topK, rankDate
+}
+
+// createEventExitSignal 시간/이벤트 기반 전량 청산 신호
+func (pm *PositionMonitor) createEventExitSignal(pos *contracts.MonitoredPosition, price int64, reason contracts.ExitReason, message string) *contracts.ExitSignal {
+	pnl := pos.UnrealizedPnL
+	if price > 0 {
+		pnl = float64(price-pos.EntryPrice) / float64(pos.EntryPrice) * 100
+	}
+
+	return &contracts.ExitSignal{
+		PositionID:   pos.ID,
+		Code:         pos.Code,
+		Name:         pos.Name,
+		Reason:       reason,
+		CurrentPrice: price,
+		EntryPrice:   pos.EntryPrice,
+		PnLPercent:   pnl,
+		SellQuantity: pos.RemainingQuantity,
+		IsPartial:    false,
+		Message:      fmt.Sprintf("%s, 잔량 %d주 전량 매도", message, pos.RemainingQuantity),
+		TriggeredAt:  time.Now(),
+		EvalSource:   contracts.ExitEvalEvent,
+	}
+}
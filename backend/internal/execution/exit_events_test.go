@@ -0,0 +1,257 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+type fakeRanking struct {
+	codes []string
+	date  time.Time
+}
+
+func (f *fakeRanking) GetLatestRankingResults(ctx context.Context, limit int) ([]contracts.RankedStock, time.Time, error) {
+	var ranked []contracts.RankedStock
+	for i, code := range f.codes {
+		if i >= limit {
+			break
+		}
+		ranked = append(ranked, contracts.RankedStock{Code: code, Rank: i + 1})
+	}
+	return ranked, f.date, nil
+}
+
+type fakeDisclosures struct {
+	titles map[string][]string
+}
+
+func (f *fakeDisclosures) GetByCodeAndDateRange(ctx context.Context, code string, from, to time.Time) ([]*contracts.Disclosure, error) {
+	var result []*contracts.Disclosure
+	for _, title := range f.titles[code] {
+		result = append(result, &contracts.Disclosure{Code: code, Date: to, Title: title})
+	}
+	return result, nil
+}
+
+type fakeStatus struct {
+	delisting map[string]time.Time
+}
+
+func (f *fakeStatus) GetStockStatus(ctx context.Context, code string) (string, *time.Time, error) {
+	if d, ok := f.delisting[code]; ok {
+		return "active", &d, nil
+	}
+	return "active", nil, nil
+}
+
+type fakeFlags struct {
+	flags map[string][]contracts.KRXFlag
+}
+
+func (f *fakeFlags) GetLatestFlags(ctx context.Context, code string, asOf time.Time, maxAgeDays int) ([]contracts.KRXFlag, error) {
+	return f.flags[code], nil
+}
+
+func newEventTestMonitor(cfg *contracts.ExitRulesConfig) *PositionMonitor {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	return NewPositionMonitor(cfg, nil, nil, nil, log)
+}
+
+func testPosition(code string, entry time.Time, price int64) *contracts.MonitoredPosition {
+	return &contracts.MonitoredPosition{
+		ID:                "P-" + code,
+		Code:              code,
+		EntryPrice:        10000,
+		CurrentPrice:      price,
+		InitialQuantity:   100,
+		RemainingQuantity: 100,
+		State:             contracts.PositionStateOpen,
+		EntryTime:         entry,
+	}
+}
+
+func TestTradingDaysSince(t *testing.T) {
+	friday := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, tradingDaysSince(friday, friday, nil))
+	assert.Equal(t, 0, tradingDaysSince(friday, friday.AddDate(0, 0, 2), nil)) // 일요일
+	assert.Equal(t, 1, tradingDaysSince(friday, friday.AddDate(0, 0, 3), nil)) // 월요일
+	assert.Equal(t, 5, tradingDaysSince(friday, friday.AddDate(0, 0, 7), nil)) // 다음 금요일
+}
+
+func TestTradingDaysSince_SkipsKRXHolidays(t *testing.T) {
+	// 2024-02-09(금)~02-12(월) 설 연휴 휴장
+	cal := calendar.New([]calendar.Day{
+		{Date: time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC), IsOpen: false, Name: "설날"},
+		{Date: time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC), IsOpen: false, Name: "대체공휴일"},
+	})
+	entry := time.Date(2024, 2, 7, 15, 0, 0, 0, time.UTC) // 수요일
+
+	assert.Equal(t, 1, tradingDaysSince(entry, time.Date(2024, 2, 12, 10, 0, 0, 0, time.UTC), cal)) // 02-08만
+	assert.Equal(t, 2, tradingDaysSince(entry, time.Date(2024, 2, 13, 10, 0, 0, 0, time.UTC), cal))
+	assert.Equal(t, 5, tradingDaysSince(entry, time.Date(2024, 2, 16, 10, 0, 0, 0, time.UTC), cal))
+	assert.Equal(t, 7, tradingDaysSince(entry, time.Date(2024, 2, 16, 10, 0, 0, 0, time.UTC), nil)) // 평일 기준
+
+	// 최대 보유기간: 휴장일은 보유일로 세지 않음
+	cfg := contracts.DefaultExitRulesConfig()
+	cfg.MaxHoldingDays = 7
+	pm := newEventTestMonitor(cfg)
+	at := time.Date(2024, 2, 16, 10, 0, 0, 0, time.UTC)
+	assert.NotNil(t, pm.EvaluateTimeRules(testPosition("005930", entry, 10500), 10500, at))
+
+	pm.SetTradingCalendar(cal)
+	assert.Nil(t, pm.EvaluateTimeRules(testPosition("005930", entry, 10500), 10500, at))
+}
+
+func TestEvaluateTimeRules(t *testing.T) {
+	entry := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC) // 월요일
+
+	cfg := contracts.DefaultExitRulesConfig()
+	cfg.MaxHoldingDays = 10
+	cfg.StaleDays = 5
+	cfg.StaleBandPercent = 2.0
+	pm := newEventTestMonitor(cfg)
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		price int64
+		want  contracts.ExitReason
+	}{
+		{"too early", entry.AddDate(0, 0, 3), 10050, ""},
+		{"stale after 5 days", entry.AddDate(0, 0, 7), 10100, contracts.ExitReasonStale},
+		{"moving position is not stale", entry.AddDate(0, 0, 7), 10500, ""},
+		{"max holding", entry.AddDate(0, 0, 14), 10500, contracts.ExitReasonMaxHolding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signal := pm.EvaluateTimeRules(testPosition("005930", entry, tt.price), tt.price, tt.at)
+			if tt.want == "" {
+				assert.Nil(t, signal)
+				return
+			}
+			require.NotNil(t, signal)
+			assert.Equal(t, tt.want, signal.Reason)
+			assert.Equal(t, 100, signal.SellQuantity)
+			assert.False(t, signal.IsPartial)
+			assert.Equal(t, contracts.ExitEvalEvent, signal.EvalSource)
+		})
+	}
+}
+
+func TestEvaluateEventRules(t *testing.T) {
+	now := time.Now()
+	entry := now.AddDate(0, 0, -3)
+
+	cfg := contracts.DefaultExitRulesConfig()
+	cfg.RankExitTopK = 2
+	cfg.DisclosureExit = true
+	pm := newEventTestMonitor(cfg)
+	pm.SetEventProviders(
+		&fakeRanking{codes: []string{"000660", "005930", "035420"}, date: now},
+		&fakeDisclosures{titles: map[string][]string{
+			"111111": {"주요사항보고서(소송등의제기)"},
+			"222222": {"기타경영사항(자율공시)", "주권매매거래정지(상장적격성 실질심사)"},
+			"333333": {"현금ㆍ현물배당결정"},
+			"555555": {"주권매매거래정지(주식분할)"},
+		}},
+		&fakeStatus{delisting: map[string]time.Time{"444444": now.AddDate(0, 0, 10)}},
+	)
+
+	topK, rankDate := pm.loadTopK(context.Background())
+
+	tests := []struct {
+		code string
+		want contracts.ExitReason
+	}{
+		{"005930", ""}, // Top-2 유지
+		{"111111", contracts.ExitReasonDisclosure}, // 소송 (-0.7)
+		{"222222", contracts.ExitReasonDelisting},  // 상장적격성 실질심사
+		{"333333", contracts.ExitReasonRankDrop},   // 긍정 공시, 랭킹 이탈
+		{"444444", contracts.ExitReasonDelisting},  // 상장폐지 예정일
+		{"555555", contracts.ExitReasonRankDrop},   // 주식분할 거래정지는 상장폐지 위험 아님
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			signal, err := pm.evaluateEventRules(context.Background(), testPosition(tt.code, entry, 10000), topK, rankDate, now)
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, signal)
+				return
+			}
+			require.NotNil(t, signal)
+			assert.Equal(t, tt.want, signal.Reason)
+		})
+	}
+}
+
+func TestEvaluateEventRules_IgnoresRankingBeforeEntry(t *testing.T) {
+	now := time.Now()
+
+	cfg := contracts.DefaultExitRulesConfig()
+	cfg.RankExitTopK = 1
+	pm := newEventTestMonitor(cfg)
+	pm.SetEventProviders(&fakeRanking{codes: []string{"000660"}, date: now.AddDate(0, 0, -1)}, nil, nil)
+
+	topK, rankDate := pm.loadTopK(context.Background())
+	signal, err := pm.evaluateEventRules(context.Background(), testPosition("005930", now, 10000), topK, rankDate, now)
+	require.NoError(t, err)
+	assert.Nil(t, signal)
+}
+
+func TestEvaluateEventRules_AdminDesignationForcesExit(t *testing.T) {
+	now := time.Now()
+	entry := now.AddDate(0, 0, -3)
+
+	cfg := contracts.DefaultExitRulesConfig()
+	pm := newEventTestMonitor(cfg)
+	pm.SetEventProviders(nil, &fakeDisclosures{titles: map[string][]string{
+		"222222": {"관리종목지정(감사의견 거절)"},
+		"333333": {"관리종목 지정 해제"},
+	}}, nil)
+	pm.SetStockFlagsProvider(&fakeFlags{flags: map[string][]contracts.KRXFlag{
+		"111111": {contracts.KRXFlagAdminIssue},
+		"444444": {contracts.KRXFlagInvestmentCaution},
+	}})
+
+	tests := []struct {
+		code string
+		want contracts.ExitReason
+	}{
+		{"111111", contracts.ExitReasonDelisting}, // stock_status_history ADMIN_ISSUE
+		{"222222", contracts.ExitReasonDelisting}, // 관리종목 지정 공시
+		{"333333", ""}, // 지정 해제 공시
+		{"444444", ""}, // 투자주의는 청산 사유 아님
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			signal, err := pm.evaluateEventRules(context.Background(), testPosition(tt.code, entry, 10000), nil, time.Time{}, now)
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, signal)
+				return
+			}
+			require.NotNil(t, signal)
+			assert.Equal(t, tt.want, signal.Reason)
+			assert.Contains(t, signal.Message, "관리종목 지정")
+		})
+	}
+
+	// DelistingExit 비활성 → 관리종목도 청산하지 않음
+	cfg.DelistingExit = false
+	signal, err := pm.evaluateEventRules(context.Background(), testPosition("111111", entry, 10000), nil, time.Time{}, now)
+	require.NoError(t, err)
+	assert.Nil(t, signal)
+}
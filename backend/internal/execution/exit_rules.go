@@ -15,6 +15,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
 )
 
@@ -28,26 +29,28 @@ type ATRProvider interface {
 }
 
 // DBATRProvider DB에서 ATR 계산
+// 청산 트리거는 최근가 기준이므로 수정주가(PriceBasisAdjusted) 사용 권장 (분할/권리락 갭 제외)
 type DBATRProvider struct {
-	pool *pgxpool.Pool
+	pool  *pgxpool.Pool
+	basis contracts.PriceBasis
 }
 
 // NewDBATRProvider 새 DB ATR Provider 생성
-func NewDBATRProvider(pool *pgxpool.Pool) *DBATRProvider {
-	return &DBATRProvider{pool: pool}
+func NewDBATRProvider(pool *pgxpool.Pool, basis contracts.PriceBasis) *DBATRProvider {
+	return &DBATRProvider{pool: pool, basis: basis}
 }
 
 // GetATR ATR14 계산 (True Range의 14일 SMA)
 func (p *DBATRProvider) GetATR(ctx context.Context, code string, period int) (float64, error) {
-	query := `
+	query := fmt.Sprintf(`
 		WITH daily_data AS (
 			SELECT
 				trade_date,
-				high,
-				low,
-				close,
-				LAG(close) OVER (ORDER BY trade_date) as prev_close
-			FROM data.daily_prices
+				high_price AS high,
+				low_price AS low,
+				close_price AS close,
+				LAG(close_price) OVER (ORDER BY trade_date) as prev_close
+			FROM %s
 			WHERE stock_code = $1
 			ORDER BY trade_date DESC
 			LIMIT $2 + 1
@@ -64,7 +67,7 @@ func (p *DBATRProvider) GetATR(ctx context.Context, code string, period int) (fl
 			LIMIT $2
 		)
 		SELECT COALESCE(AVG(true_range), 0) FROM true_ranges
-	`
+	`, p.basis.Table())
 
 	var atr float64
 	err := p.pool.QueryRow(ctx, query, code, period).Scan(&atr)
@@ -93,6 +96,16 @@ type ExitNotifier interface {
 	NotifyExitSignal(ctx context.Context, signal *contracts.ExitSignal) error
 }
 
+// OrderStore 청산 주문 기록 인터페이스 (execution.Repository)
+type OrderStore interface {
+	SaveOrder(ctx context.Context, order *contracts.Order) error
+}
+
+// OrderSubmitter 청산 매도 주문 전송 인터페이스 (Broker의 주문 전송 부분)
+type OrderSubmitter interface {
+	SubmitOrder(ctx context.Context, order *contracts.Order) (*OrderResult, error)
+}
+
 // =============================================================================
 // Position Monitor
 // ⭐ SSOT: 포지션 모니터링 및 청산 신호 생성은 여기서만
@@ -104,6 +117,10 @@ type PositionMonitor struct {
 	priceFunc   PriceProvider
 	atrProvider ATRProvider
 	notifier    ExitNotifier
+	preTrade    *PreTradeChecker // nil 가능 → 주문 검사 생략
+	orders      OrderStore       // nil 가능 → 청산 주문 기록 생략
+	submitter   OrderSubmitter   // nil 가능 → 알림만 (수동 매도)
+	accountID   string           // 청산 주문 계좌 (빈 값 = default)
 	pool        *pgxpool.Pool
 	logger      *logger.Logger
 
@@ -113,6 +130,19 @@ type PositionMonitor struct {
 	stopCh        chan struct{}
 	isRunning     bool
 	autoSell      bool
+	exitPending   map[string]time.Time // 완전 청산 주문 후 브로커 보유 해소 대기 (code → 청산 시각)
+
+	// 실시간 체결 기반 평가 (exit_tick.go)
+	tickSub    *cache.Subscription
+	lastTickAt map[string]time.Time
+	tickStats  *tickStats
+
+	// 시간/이벤트 기반 청산 데이터 소스 (exit_events.go)
+	rankingProvider    RankingProvider
+	disclosureProvider DisclosureProvider
+	statusProvider     StockStatusProvider
+	flagsProvider      StockFlagsProvider
+	calendar           TradingCalendar
 }
 
 // NewPositionMonitor 새 포지션 모니터 생성
@@ -136,6 +166,9 @@ func NewPositionMonitor(
 		recentSignals: make([]*contracts.ExitSignal, 0, 50),
 		stopCh:        make(chan struct{}),
 		autoSell:      true,
+		exitPending:   make(map[string]time.Time),
+		lastTickAt:    make(map[string]time.Time),
+		tickStats:     newTickStats(),
 	}
 }
 
@@ -144,6 +177,26 @@ func (pm *PositionMonitor) SetNotifier(notifier ExitNotifier) {
 	pm.notifier = notifier
 }
 
+// SetPreTradeChecker 청산 주문 검사 설정 (킬 스위치 발동 시 청산 주문도 차단)
+func (pm *PositionMonitor) SetPreTradeChecker(checker *PreTradeChecker) {
+	pm.preTrade = checker
+}
+
+// SetOrderStore 청산 주문 기록 설정 (실현손익 원장의 청산 사유 귀속)
+func (pm *PositionMonitor) SetOrderStore(store OrderStore) {
+	pm.orders = store
+}
+
+// SetOrderSubmitter 청산 매도 주문 전송 설정 (autoSell이 켜져 있을 때만 전송)
+func (pm *PositionMonitor) SetOrderSubmitter(submitter OrderSubmitter) {
+	pm.submitter = submitter
+}
+
+// SetAccountID 청산 주문 계좌 설정 (계좌별 매수 가능 금액/중복 판정 기준)
+func (pm *PositionMonitor) SetAccountID(accountID string) {
+	pm.accountID = accountID
+}
+
 // SetAutoSell 자동 매도 설정
 func (pm *PositionMonitor) SetAutoSell(enabled bool) {
 	pm.autoSell = enabled
@@ -161,6 +214,25 @@ func (pm *PositionMonitor) AddPosition(ctx context.Context, pos *contracts.Monit
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
+	pm.InitPosition(ctx, pos)
+	pm.positions[pos.Code] = pos
+
+	pm.logger.WithFields(map[string]interface{}{
+		"code":        pos.Code,
+		"entry_price": pos.EntryPrice,
+		"quantity":    pos.InitialQuantity,
+		"atr_percent": pos.ATRPercent,
+		"tp1_trigger": pos.TP1TriggerPrice,
+		"tp2_trigger": pos.TP2TriggerPrice,
+		"tp3_trigger": pos.TP3TriggerPrice,
+	}).Info("Position added for monitoring")
+
+	return nil
+}
+
+// InitPosition 포지션 상태/트리거 가격 초기화 (모니터 등록 없음)
+// 백테스트(backtest.ExitSimulator)도 동일한 초기화를 사용
+func (pm *PositionMonitor) InitPosition(ctx context.Context, pos *contracts.MonitoredPosition) {
 	// 기본 초기화
 	pos.ReferencePrice = pos.EntryPrice
 	pos.HighestPrice = pos.EntryPrice
@@ -189,20 +261,6 @@ func (pm *PositionMonitor) AddPosition(ctx context.Context, pos *contracts.Monit
 		}
 		pm.calculateTriggerPrices(pos)
 	}
-
-	pm.positions[pos.Code] = pos
-
-	pm.logger.WithFields(map[string]interface{}{
-		"code":        pos.Code,
-		"entry_price": pos.EntryPrice,
-		"quantity":    pos.InitialQuantity,
-		"atr_percent": pos.ATRPercent,
-		"tp1_trigger": pos.TP1TriggerPrice,
-		"tp2_trigger": pos.TP2TriggerPrice,
-		"tp3_trigger": pos.TP3TriggerPrice,
-	}).Info("Position added for monitoring")
-
-	return nil
 }
 
 // calculateTriggerPrices ATR 기반 트리거 가격 계산
@@ -238,11 +296,38 @@ func (pm *PositionMonitor) RemovePosition(code string) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 	delete(pm.positions, code)
+	delete(pm.lastTickAt, code)
 	pm.logger.WithFields(map[string]interface{}{
 		"code": code,
 	}).Info("Position removed from monitoring")
 }
 
+// ExitPendingSince 완전 청산 후 브로커 보유가 남아 있는 종목의 청산 시각 (재등록 방지)
+func (pm *PositionMonitor) ExitPendingSince(code string) (time.Time, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	since, ok := pm.exitPending[code]
+	return since, ok
+}
+
+// ExitPendingCodes 청산 대기 종목 목록
+func (pm *PositionMonitor) ExitPendingCodes() []string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	codes := make([]string, 0, len(pm.exitPending))
+	for code := range pm.exitPending {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// ClearExitPending 청산 대기 해제 (브로커 보유 해소 또는 미체결 만료)
+func (pm *PositionMonitor) ClearExitPending(code string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	delete(pm.exitPending, code)
+}
+
 // GetPositions 모니터링 중인 포지션 목록
 func (pm *PositionMonitor) GetPositions() []*contracts.MonitoredPosition {
 	pm.mu.RLock()
@@ -285,7 +370,19 @@ func (pm *PositionMonitor) CheckPosition(ctx context.Context, pos *contracts.Mon
 	}
 
 	// float64 → int64 변환 (한국 주식 가격은 정수)
-	currentPrice := int64(currentPriceFloat)
+	signals, err := pm.evaluatePosition(pos, int64(currentPriceFloat), time.Now())
+	for _, signal := range signals {
+		signal.EvalSource = contracts.ExitEvalPoll
+	}
+	return signals, err
+}
+
+// evaluatePosition 주어진 가격으로 포지션 상태 갱신 후 청산 조건 평가
+// 폴링(CheckPosition)과 실시간 체결(handleTick)이 공유하는 단일 평가 경로
+func (pm *PositionMonitor) evaluatePosition(pos *contracts.MonitoredPosition, currentPrice int64, priceTime time.Time) ([]*contracts.ExitSignal, error) {
+	if currentPrice <= 0 {
+		return nil, fmt.Errorf("invalid price for %s: %d", pos.Code, currentPrice)
+	}
 
 	// 포지션 상태 업데이트
 	pos.CurrentPrice = currentPrice
@@ -294,7 +391,7 @@ func (pm *PositionMonitor) CheckPosition(ctx context.Context, pos *contracts.Mon
 	// 최고가 업데이트 (HWM)
 	if currentPrice > pos.HighestPrice {
 		pos.HighestPrice = currentPrice
-		pos.HighestPriceTime = time.Now()
+		pos.HighestPriceTime = priceTime
 
 		// TP3 이후: HWM 트레일링 스탑 갱신
 		if pos.State == contracts.PositionStateTP3Done {
@@ -371,6 +468,39 @@ func (pm *PositionMonitor) checkPositionATRBased(pos *contracts.MonitoredPositio
 	return signals, nil
 }
 
+// EvaluatePrice 주어진 가격/시각으로 청산 조건 평가 (모니터 등록 없이, 락 없음)
+// 백테스트에서 과거 가격 경로를 재생할 때 사용
+func (pm *PositionMonitor) EvaluatePrice(pos *contracts.MonitoredPosition, price int64, at time.Time) ([]*contracts.ExitSignal, error) {
+	signals, err := pm.evaluatePosition(pos, price, at)
+	for _, signal := range signals {
+		signal.TriggeredAt = at
+	}
+	return signals, err
+}
+
+// TriggerPrice 청산 사유별 트리거 가격 (현재 포지션 상태 기준)
+func (pm *PositionMonitor) TriggerPrice(pos *contracts.MonitoredPosition, reason contracts.ExitReason) int64 {
+	switch reason {
+	case contracts.ExitReasonTP1:
+		return pos.TP1TriggerPrice
+	case contracts.ExitReasonTP2:
+		return pos.TP2TriggerPrice
+	case contracts.ExitReasonTP3:
+		return pos.TP3TriggerPrice
+	case contracts.ExitReasonFirstStop:
+		return int64(float64(pos.EntryPrice) * (1 + pm.config.FirstStopPercent/100))
+	case contracts.ExitReasonSecondStop:
+		return int64(float64(pos.EntryPrice) * (1 + pm.config.SecondStopPercent/100))
+	case contracts.ExitReasonHardStop:
+		return int64(float64(pos.EntryPrice) * (1 + pm.config.HardStopPercent/100))
+	case contracts.ExitReasonStopFloor:
+		return pos.StopFloorPrice
+	case contracts.ExitReasonHWMTrail:
+		return pos.TrailStopPrice
+	}
+	return 0
+}
+
 // updateTrailStopPrice HWM 트레일링 스탑 가격 갱신
 func (pm *PositionMonitor) updateTrailStopPrice(pos *contracts.MonitoredPosition) {
 	atr := pos.ATRPercent / 100
@@ -547,36 +677,26 @@ func (pm *PositionMonitor) createHWMTrailSignal(pos *contracts.MonitoredPosition
 // Batch Operations
 // =============================================================================
 
-// CheckAllPositions 모든 포지션 체크
+// CheckAllPositions 모든 포지션 체크 (현재가 조회는 락 밖에서 수행)
 func (pm *PositionMonitor) CheckAllPositions(ctx context.Context) ([]*contracts.ExitSignal, error) {
-	pm.mu.Lock()
-	defer pm.mu.Unlock()
-
-	var allSignals []*contracts.ExitSignal
-
-	for _, pos := range pm.positions {
-		signals, err := pm.CheckPosition(ctx, pos)
-		if err != nil {
-			pm.logger.WithFields(map[string]interface{}{
-				"code":  pos.Code,
-				"error": err.Error(),
-			}).Warn("Error checking position")
-			continue
-		}
+	pm.mu.RLock()
+	codes := make([]string, 0, len(pm.positions))
+	for code := range pm.positions {
+		codes = append(codes, code)
+	}
+	pm.mu.RUnlock()
 
-		for _, signal := range signals {
-			allSignals = append(allSignals, signal)
-			pm.logger.WithFields(map[string]interface{}{
-				"code":     signal.Code,
-				"reason":   signal.Reason,
-				"quantity": signal.SellQuantity,
-				"pnl":      signal.PnLPercent,
-				"state":    pos.State,
-			}).Info("Exit signal generated")
-		}
+	signals := pm.pollPositions(ctx, codes)
+	for _, signal := range signals {
+		pm.logger.WithFields(map[string]interface{}{
+			"code":     signal.Code,
+			"reason":   signal.Reason,
+			"quantity": signal.SellQuantity,
+			"pnl":      signal.PnLPercent,
+		}).Info("Exit signal generated")
 	}
 
-	return allSignals, nil
+	return signals, nil
 }
 
 // =============================================================================
@@ -602,12 +722,28 @@ func (pm *PositionMonitor) Start(ctx context.Context) error {
 		"second_stop":  pm.config.SecondStopPercent,
 		"stop_floor":   pm.config.StopFloorBuffer,
 		"trail_range":  fmt.Sprintf("%.0f%%-%.0f%%", pm.config.TrailMinPercent, pm.config.TrailMaxPercent),
+		"tick_driven":  pm.config.TickDriven,
+		"stale_tick_s": pm.config.StaleTickSeconds,
+		"max_hold_d":   pm.config.MaxHoldingDays,
+		"rank_top_k":   pm.config.RankExitTopK,
+		"disclosure":   pm.config.DisclosureExit,
+		"delisting":    pm.config.DelistingExit,
 	}).Info("Starting position monitor")
 
 	go func() {
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
+		defer pm.closeTicks()
+
+		// 시간/이벤트 규칙은 별도 주기로 평가 (DB 조회 포함)
+		var eventC <-chan time.Time
+		if pm.hasEventRules() && pm.config.EventCheckIntervalSeconds > 0 {
+			eventTicker := time.NewTicker(time.Duration(pm.config.EventCheckIntervalSeconds) * time.Second)
+			defer eventTicker.Stop()
+			eventC = eventTicker.C
+		}
 
+		ticks := pm.tickC()
 		for {
 			select {
 			case <-ctx.Done():
@@ -618,7 +754,21 @@ func (pm *PositionMonitor) Start(ctx context.Context) error {
 				pm.isRunning = false
 				pm.logger.Info("Position monitor stopped")
 				return
+			case tick, ok := <-ticks:
+				if !ok {
+					ticks = nil // 구독 해제됨 → 폴링만 사용
+					continue
+				}
+				pm.handleTick(ctx, tick)
+			case <-eventC:
+				pm.CheckEventRules(ctx)
 			case <-ticker.C:
+				// 실시간 모드: 체결이 끊긴(stale) 종목만 폴링 fallback
+				if pm.config.TickDriven {
+					pm.checkStalePositions(ctx)
+					continue
+				}
+
 				signals, err := pm.CheckAllPositions(ctx)
 				if err != nil {
 					pm.logger.WithFields(map[string]interface{}{
@@ -681,6 +831,22 @@ func (pm *PositionMonitor) executeExit(ctx context.Context, signal *contracts.Ex
 	// 신호 저장
 	pm.addSignal(signal)
 
+	// 주문 검사: 차단되면 알림/상태 반영 안 함 (중복 억제 구간 이후 재평가)
+	if !pm.authorizeExit(ctx, signal) {
+		return
+	}
+
+	// 매도 주문 전송 (autoSell), 전송 실패 시 상태 유지 + 차단 알림 (중복 억제 구간 이후 재평가)
+	result, ok := pm.submitExit(ctx, signal)
+	if !ok {
+		return
+	}
+
+	// 전송한 주문 기록 (브로커 주문번호 키): 체결 → exit_reason → closed_lots 청산 사유
+	if result != nil {
+		pm.saveExitOrder(ctx, signal, result)
+	}
+
 	// 알림 발송
 	if pm.notifier != nil {
 		if err := pm.notifier.NotifyExitSignal(ctx, signal); err != nil {
@@ -695,6 +861,129 @@ func (pm *PositionMonitor) executeExit(ctx context.Context, signal *contracts.Ex
 	pm.updatePositionState(signal)
 }
 
+// submitExit 청산 매도를 시장가로 전송 (autoSell 꺼짐/전송기 없음 → 전송 없이 알림만, 수동 매도)
+func (pm *PositionMonitor) submitExit(ctx context.Context, signal *contracts.ExitSignal) (*OrderResult, bool) {
+	if !pm.autoSell || pm.submitter == nil {
+		return nil, true
+	}
+
+	result, err := pm.submitter.SubmitOrder(ctx, &contracts.Order{
+		Code:       signal.Code,
+		Name:       signal.Name,
+		Side:       contracts.OrderSideSell,
+		Qty:        signal.SellQuantity,
+		OrderType:  contracts.OrderTypeMarket,
+		ExitReason: signal.Reason,
+		AccountID:  pm.accountID,
+	})
+	if err != nil {
+		pm.logger.WithFields(map[string]interface{}{
+			"code":   signal.Code,
+			"reason": signal.Reason,
+			"error":  err.Error(),
+		}).Error("Failed to submit exit order")
+		pm.notifyBlockedExit(ctx, signal, "broker order failed: "+err.Error())
+		return nil, false
+	}
+
+	pm.logger.WithFields(map[string]interface{}{
+		"code":     signal.Code,
+		"reason":   signal.Reason,
+		"quantity": signal.SellQuantity,
+		"order_id": result.OrderID,
+	}).Info("Exit order submitted")
+	return result, true
+}
+
+// saveExitOrder 전송한 청산 매도 주문을 사유와 함께 기록 (실패해도 청산 진행)
+// 주문 ID = 브로커 주문번호 → 체결(execution.trades)이 order_id로 이 행에 붙어 청산 사유가 원장에 전달됨
+func (pm *PositionMonitor) saveExitOrder(ctx context.Context, signal *contracts.ExitSignal, result *OrderResult) {
+	if pm.orders == nil {
+		return
+	}
+
+	now := time.Now()
+	order := &contracts.Order{
+		ID:         result.OrderID,
+		Code:       signal.Code,
+		Name:       signal.Name,
+		Side:       contracts.OrderSideSell,
+		Qty:        signal.SellQuantity,
+		OrderType:  contracts.OrderTypeMarket,
+		Status:     contracts.StatusSubmitted,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ExitReason: signal.Reason,
+		AccountID:  pm.accountID,
+	}
+	if err := pm.orders.SaveOrder(ctx, order); err != nil {
+		pm.logger.WithFields(map[string]interface{}{
+			"code":     signal.Code,
+			"reason":   signal.Reason,
+			"order_id": result.OrderID,
+			"error":    err.Error(),
+		}).Error("Failed to save exit order")
+	}
+}
+
+// authorizeExit 청산 주문 검사 (시장가 매도, 현재가로 금액 환산)
+// 검사 오류 시 차단 (fail closed) + 차단 알림, 손절/강제 청산은 금액·유동성 한도 면제 (PreTradeChecker)
+func (pm *PositionMonitor) authorizeExit(ctx context.Context, signal *contracts.ExitSignal) bool {
+	if pm.preTrade == nil {
+		return true
+	}
+
+	result, err := pm.preTrade.Authorize(ctx, &PreTradeOrder{
+		Order: contracts.Order{
+			Code:       signal.Code,
+			Name:       signal.Name,
+			Side:       contracts.OrderSideSell,
+			Qty:        signal.SellQuantity,
+			OrderType:  contracts.OrderTypeMarket,
+			ExitReason: signal.Reason,
+			AccountID:  pm.accountID,
+		},
+		Source:   PreTradeSourceExitMonitor,
+		Actor:    "exit_monitor",
+		RefPrice: float64(signal.CurrentPrice),
+	})
+	if err != nil {
+		pm.logger.WithFields(map[string]interface{}{
+			"code":   signal.Code,
+			"reason": signal.Reason,
+			"error":  err.Error(),
+		}).Error("Pre-trade check failed, exit not executed")
+		pm.notifyBlockedExit(ctx, signal, "pre-trade check failed: "+err.Error())
+		return false
+	}
+
+	if !result.Passed {
+		pm.logger.WithFields(map[string]interface{}{
+			"code":       signal.Code,
+			"reason":     signal.Reason,
+			"violations": result.Violations,
+		}).Warn("Exit blocked by pre-trade checks")
+		pm.notifyBlockedExit(ctx, signal, result.Message())
+		return false
+	}
+	return true
+}
+
+// notifyBlockedExit 차단된 청산도 알림 (수동 대응 필요)
+func (pm *PositionMonitor) notifyBlockedExit(ctx context.Context, signal *contracts.ExitSignal, cause string) {
+	if pm.notifier == nil {
+		return
+	}
+	blocked := *signal
+	blocked.Message = fmt.Sprintf("청산 차단 (%s)", cause)
+	if err := pm.notifier.NotifyExitSignal(ctx, &blocked); err != nil {
+		pm.logger.WithFields(map[string]interface{}{
+			"code":  signal.Code,
+			"error": err.Error(),
+		}).Error("Failed to send blocked exit notification")
+	}
+}
+
 // addSignal 청산 신호 추가
 func (pm *PositionMonitor) addSignal(signal *contracts.ExitSignal) {
 	pm.mu.Lock()
@@ -707,6 +996,7 @@ func (pm *PositionMonitor) addSignal(signal *contracts.ExitSignal) {
 }
 
 // updatePositionState 포지션 상태 업데이트
+// 완전 청산은 모니터에서 빼고 청산 대기로 표시 (브로커 보유가 사라질 때까지 재등록/재청산 방지)
 func (pm *PositionMonitor) updatePositionState(signal *contracts.ExitSignal) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
@@ -716,6 +1006,14 @@ func (pm *PositionMonitor) updatePositionState(signal *contracts.ExitSignal) {
 		return
 	}
 
+	if pm.ApplyExit(pos, signal) {
+		delete(pm.positions, signal.Code)
+		pm.exitPending[signal.Code] = time.Now()
+	}
+}
+
+// ApplyExit 청산 신호를 포지션 상태 머신에 반영, 완전 청산 여부 반환
+func (pm *PositionMonitor) ApplyExit(pos *contracts.MonitoredPosition, signal *contracts.ExitSignal) bool {
 	switch signal.Reason {
 	case contracts.ExitReasonTP1:
 		pos.State = contracts.PositionStateTP1Done
@@ -747,19 +1045,21 @@ func (pm *PositionMonitor) updatePositionState(signal *contracts.ExitSignal) {
 	case contracts.ExitReasonFirstStop:
 		pos.FirstStopTriggered = true
 
-	case contracts.ExitReasonSecondStop, contracts.ExitReasonStopFloor, contracts.ExitReasonHWMTrail:
+	case contracts.ExitReasonSecondStop, contracts.ExitReasonStopFloor, contracts.ExitReasonHWMTrail,
+		contracts.ExitReasonMaxHolding, contracts.ExitReasonStale, contracts.ExitReasonRankDrop,
+		contracts.ExitReasonDisclosure, contracts.ExitReasonDelisting:
 		pos.State = contracts.PositionStateClosed
 	}
 
 	// 잔량 업데이트
 	if signal.IsPartial {
 		pos.RemainingQuantity -= signal.SellQuantity
-		if pos.RemainingQuantity <= 0 {
-			delete(pm.positions, signal.Code)
-		}
-	} else {
-		delete(pm.positions, signal.Code)
+		return pos.RemainingQuantity <= 0
 	}
+
+	pos.RemainingQuantity = 0
+	pos.State = contracts.PositionStateClosed
+	return true
 }
 
 // GetConfig 현재 설정 반환
@@ -0,0 +1,293 @@
+// Package execution - exit_tick.go
+// 실시간 체결 기반 청산 평가
+// - PriceCache 구독(WebSocket/REST 체결)으로 보유 종목만 즉시 평가
+// - 체결이 StaleTickSeconds 이상 끊긴 종목은 PriceProvider 폴링으로 fallback
+// - 체결 시각 → 신호 생성 지연(latency) 측정
+package execution
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
+)
+
+const (
+	// tickBufferSize 모니터 구독 버퍼 크기 (초과 시 오래된 틱 drop, 폴링이 보완)
+	tickBufferSize = 1024
+
+	// latencySampleSize 지연 통계 계산에 사용하는 최근 샘플 수
+	latencySampleSize = 500
+)
+
+// =============================================================================
+// Tick Subscription
+// =============================================================================
+
+// SubscribeTicks 가격 캐시 전체 종목 구독 (보유 종목 필터는 handleTick에서)
+// Start 전에 호출, 모니터 종료 시 구독 해제
+func (pm *PositionMonitor) SubscribeTicks(priceCache *cache.PriceCache) {
+	pm.tickSub = priceCache.Subscribe(tickBufferSize, cache.WildcardSymbol)
+}
+
+// tickC 구독 채널 (미구독 시 nil → select에서 무시)
+func (pm *PositionMonitor) tickC() <-chan *realtime.PriceTick {
+	if pm.tickSub == nil {
+		return nil
+	}
+	return pm.tickSub.C()
+}
+
+// closeTicks 구독 해제
+func (pm *PositionMonitor) closeTicks() {
+	if pm.tickSub != nil {
+		pm.tickSub.Close()
+	}
+}
+
+// handleTick 체결 1건에 대해 청산 규칙 평가 (모니터 goroutine에서만 호출)
+func (pm *PositionMonitor) handleTick(ctx context.Context, tick *realtime.PriceTick) {
+	if !pm.config.TickDriven || tick == nil || tick.Price <= 0 || tick.IsStale {
+		return
+	}
+
+	pm.mu.Lock()
+	pos, ok := pm.positions[tick.Code]
+	if !ok {
+		pm.mu.Unlock()
+		return
+	}
+
+	pm.lastTickAt[tick.Code] = time.Now()
+	pm.tickStats.recordTick(tick.Timestamp)
+
+	signals, err := pm.evaluatePosition(pos, tick.Price, tick.Timestamp)
+	state := pos.State
+	pm.mu.Unlock()
+
+	if err != nil {
+		pm.logger.WithFields(map[string]interface{}{
+			"code":  tick.Code,
+			"error": err.Error(),
+		}).Warn("Error evaluating tick")
+		return
+	}
+
+	for _, signal := range signals {
+		latency := time.Since(tick.Timestamp)
+		signal.EvalSource = contracts.ExitEvalTick
+		signal.LatencyMs = float64(latency.Microseconds()) / 1000
+		pm.tickStats.recordSignal(contracts.ExitEvalTick, latency)
+
+		pm.logger.WithFields(map[string]interface{}{
+			"code":       signal.Code,
+			"reason":     signal.Reason,
+			"quantity":   signal.SellQuantity,
+			"pnl":        signal.PnLPercent,
+			"state":      state,
+			"source":     tick.Source,
+			"latency_ms": signal.LatencyMs,
+		}).Info("Exit signal generated from tick")
+
+		pm.executeExit(ctx, signal)
+	}
+}
+
+// checkStalePositions 체결이 끊긴 종목만 현재가 폴링으로 평가 (fallback)
+func (pm *PositionMonitor) checkStalePositions(ctx context.Context) {
+	staleAfter := time.Duration(pm.config.StaleTickSeconds) * time.Second
+	now := time.Now()
+
+	pm.mu.RLock()
+	codes := make([]string, 0, len(pm.positions))
+	for code := range pm.positions {
+		if last, ok := pm.lastTickAt[code]; ok && now.Sub(last) < staleAfter {
+			continue
+		}
+		codes = append(codes, code)
+	}
+	pm.mu.RUnlock()
+
+	for range codes {
+		pm.tickStats.recordFallback()
+	}
+
+	for _, signal := range pm.pollPositions(ctx, codes) {
+		pm.tickStats.recordSignal(contracts.ExitEvalPoll, 0)
+		pm.logger.WithFields(map[string]interface{}{
+			"code":     signal.Code,
+			"reason":   signal.Reason,
+			"quantity": signal.SellQuantity,
+			"pnl":      signal.PnLPercent,
+		}).Info("Exit signal generated from polling fallback")
+
+		pm.executeExit(ctx, signal)
+	}
+}
+
+// pollPositions 현재가 조회 후 평가 (조회는 락 밖에서 → 체결 처리/피드를 막지 않음)
+func (pm *PositionMonitor) pollPositions(ctx context.Context, codes []string) []*contracts.ExitSignal {
+	var signals []*contracts.ExitSignal
+	for _, code := range codes {
+		price, err := pm.priceFunc.GetCurrentPrice(ctx, code)
+		if err != nil {
+			pm.logger.WithFields(map[string]interface{}{
+				"code":  code,
+				"error": err.Error(),
+			}).Warn("Error checking position")
+			continue
+		}
+
+		pm.mu.Lock()
+		pos, ok := pm.positions[code]
+		if !ok {
+			pm.mu.Unlock()
+			continue
+		}
+		posSignals, err := pm.evaluatePosition(pos, int64(price), time.Now())
+		state := pos.State
+		pm.mu.Unlock()
+
+		if err != nil {
+			pm.logger.WithFields(map[string]interface{}{
+				"code":  code,
+				"error": err.Error(),
+			}).Warn("Error checking position")
+			continue
+		}
+
+		for _, signal := range posSignals {
+			signal.EvalSource = contracts.ExitEvalPoll
+			pm.logger.WithFields(map[string]interface{}{
+				"code":     signal.Code,
+				"reason":   signal.Reason,
+				"quantity": signal.SellQuantity,
+				"pnl":      signal.PnLPercent,
+				"state":    state,
+			}).Debug("Exit signal evaluated")
+		}
+		signals = append(signals, posSignals...)
+	}
+	return signals
+}
+
+// GetTickStats 실시간 평가 통계 (지연 시간 포함)
+func (pm *PositionMonitor) GetTickStats() ExitTickStats {
+	stats := pm.tickStats.snapshot()
+	stats.TickDriven = pm.config.TickDriven
+	if pm.tickSub != nil {
+		stats.TicksDropped = int64(pm.tickSub.Dropped())
+		stats.QueueLength = len(pm.tickSub.C())
+	}
+	return stats
+}
+
+// =============================================================================
+// Tick Statistics
+// =============================================================================
+
+// ExitTickStats 실시간 청산 평가 통계
+type ExitTickStats struct {
+	TickDriven     bool       `json:"tick_driven"`
+	TicksProcessed int64      `json:"ticks_processed"`
+	TicksDropped   int64      `json:"ticks_dropped"`
+	QueueLength    int        `json:"queue_length"`
+	FallbackChecks int64      `json:"fallback_checks"`
+	TickSignals    int64      `json:"tick_signals"`
+	PollSignals    int64      `json:"poll_signals"`
+	LatencyAvgMs   float64    `json:"latency_avg_ms"`
+	LatencyP50Ms   float64    `json:"latency_p50_ms"`
+	LatencyP95Ms   float64    `json:"latency_p95_ms"`
+	LatencyMaxMs   float64    `json:"latency_max_ms"`
+	LastTickAt     *time.Time `json:"last_tick_at,omitempty"`
+}
+
+// tickStats 실시간 평가 카운터 및 지연 샘플
+type tickStats struct {
+	mu             sync.Mutex
+	ticksProcessed int64
+	fallbackChecks int64
+	tickSignals    int64
+	pollSignals    int64
+	latencies      []time.Duration // 최근 latencySampleSize개 (ring)
+	next           int
+	lastTickAt     time.Time
+}
+
+func newTickStats() *tickStats {
+	return &tickStats{
+		latencies: make([]time.Duration, 0, latencySampleSize),
+	}
+}
+
+func (s *tickStats) recordTick(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ticksProcessed++
+	s.lastTickAt = at
+}
+
+func (s *tickStats) recordFallback() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fallbackChecks++
+}
+
+func (s *tickStats) recordSignal(source contracts.ExitEvalSource, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if source == contracts.ExitEvalPoll {
+		s.pollSignals++
+		return
+	}
+
+	s.tickSignals++
+	if len(s.latencies) < latencySampleSize {
+		s.latencies = append(s.latencies, latency)
+	} else {
+		s.latencies[s.next] = latency
+	}
+	s.next = (s.next + 1) % latencySampleSize
+}
+
+func (s *tickStats) snapshot() ExitTickStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := ExitTickStats{
+		TicksProcessed: s.ticksProcessed,
+		FallbackChecks: s.fallbackChecks,
+		TickSignals:    s.tickSignals,
+		PollSignals:    s.pollSignals,
+	}
+	if !s.lastTickAt.IsZero() {
+		last := s.lastTickAt
+		stats.LastTickAt = &last
+	}
+
+	if len(s.latencies) == 0 {
+		return stats
+	}
+
+	sorted := make([]time.Duration, len(s.latencies))
+	copy(sorted, s.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+
+	toMs := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+	stats.LatencyAvgMs = toMs(total / time.Duration(len(sorted)))
+	stats.LatencyP50Ms = toMs(sorted[len(sorted)/2])
+	stats.LatencyP95Ms = toMs(sorted[(len(sorted)*95)/100])
+	stats.LatencyMaxMs = toMs(sorted[len(sorted)-1])
+
+	return stats
+}
@@ -0,0 +1,323 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
+)
+
+// fakePrices is a PriceProvider that records calls and checks the monitor lock is free during I/O
+type fakePrices struct {
+	mu         sync.Mutex
+	prices     map[string]float64
+	calls      map[string]int
+	monitor    *PositionMonitor
+	lockedOnIO bool
+}
+
+func (f *fakePrices) GetCurrentPrice(ctx context.Context, code string) (float64, error) {
+	if f.monitor != nil {
+		if f.monitor.mu.TryLock() {
+			f.monitor.mu.Unlock()
+		} else {
+			f.mu.Lock()
+			f.lockedOnIO = true
+			f.mu.Unlock()
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[code]++
+	return f.prices[code], nil
+}
+
+func (f *fakePrices) callCount(code string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[code]
+}
+
+type countingNotifier struct {
+	mu      sync.Mutex
+	signals []*contracts.ExitSignal
+}
+
+func (n *countingNotifier) NotifyExitSignal(ctx context.Context, signal *contracts.ExitSignal) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.signals = append(n.signals, signal)
+	return nil
+}
+
+func (n *countingNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.signals)
+}
+
+type recordingOrders struct {
+	mu     sync.Mutex
+	orders []contracts.Order
+}
+
+func (r *recordingOrders) SaveOrder(ctx context.Context, order *contracts.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orders = append(r.orders, *order)
+	return nil
+}
+
+func newTickTestMonitor(prices *fakePrices) (*PositionMonitor, *countingNotifier) {
+	cfg := contracts.DefaultExitRulesConfig()
+	cfg.DelistingExit = false
+	cfg.CheckIntervalSeconds = 3600
+	cfg.StaleTickSeconds = 10
+
+	pm := newEventTestMonitor(cfg)
+	pm.priceFunc = prices
+	prices.monitor = pm
+
+	notifier := &countingNotifier{}
+	pm.SetNotifier(notifier)
+	return pm, notifier
+}
+
+func addTickTestPosition(t *testing.T, pm *PositionMonitor, code string) {
+	t.Helper()
+	require.NoError(t, pm.AddPosition(context.Background(), &contracts.MonitoredPosition{
+		ID:              "P-" + code,
+		Code:            code,
+		EntryPrice:      10000,
+		InitialQuantity: 100,
+		ATRPercent:      2.0,
+		EntryTime:       time.Now().Add(-time.Hour),
+	}))
+}
+
+func TestPositionMonitor_TickTriggersExit(t *testing.T) {
+	pm, notifier := newTickTestMonitor(&fakePrices{})
+	addTickTestPosition(t, pm, "005930")
+
+	priceCache := cache.NewPriceCache(time.Minute, pm.logger)
+	pm.SubscribeTicks(priceCache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, pm.Start(ctx))
+
+	// 보유하지 않은 종목 체결은 무시
+	priceCache.Update(&realtime.PriceTick{Code: "000660", Price: 1, Timestamp: time.Now()})
+	// 2차 손절 (-5%) 이하 체결
+	priceCache.Update(&realtime.PriceTick{Code: "005930", Price: 9400, Timestamp: time.Now(), Source: "KIS_WS"})
+
+	require.Eventually(t, func() bool { return notifier.count() == 1 }, time.Second, 5*time.Millisecond)
+
+	signal := pm.GetRecentSignals(1)[0]
+	assert.Equal(t, contracts.ExitReasonSecondStop, signal.Reason)
+	assert.Equal(t, contracts.ExitEvalTick, signal.EvalSource)
+	assert.Equal(t, 100, signal.SellQuantity)
+	assert.Empty(t, pm.GetPositions(), "fully closed position leaves the monitor")
+
+	cancel()
+	require.Eventually(t, func() bool { return priceCache.SubscriberCount() == 0 }, time.Second, 5*time.Millisecond,
+		"subscription is released when the monitor stops")
+
+	stats := pm.GetTickStats()
+	assert.Equal(t, int64(1), stats.TicksProcessed)
+	assert.Equal(t, int64(1), stats.TickSignals)
+}
+
+func TestPositionMonitor_DuplicateSignalDebounced(t *testing.T) {
+	pm, notifier := newTickTestMonitor(&fakePrices{})
+	addTickTestPosition(t, pm, "005930")
+
+	signal := &contracts.ExitSignal{
+		Code:         "005930",
+		Reason:       contracts.ExitReasonFirstStop,
+		CurrentPrice: 9650,
+		SellQuantity: 50,
+		IsPartial:    true,
+		TriggeredAt:  time.Now(),
+	}
+	pm.executeExit(context.Background(), signal)
+
+	again := *signal
+	again.TriggeredAt = time.Now()
+	pm.executeExit(context.Background(), &again)
+
+	assert.Equal(t, 1, notifier.count(), "same code/reason within 60s is sent once")
+	assert.Len(t, pm.GetRecentSignals(0), 1)
+	require.Len(t, pm.GetPositions(), 1)
+	assert.Equal(t, 50, pm.GetPositions()[0].RemainingQuantity)
+}
+
+func TestPositionMonitor_StaleFallbackPollsWithoutLock(t *testing.T) {
+	prices := &fakePrices{prices: map[string]float64{
+		"005930": 9400,  // 체결 끊김 → 폴링, 2차 손절
+		"000660": 10050, // 최근 체결 있음 → 폴링 안 함
+	}}
+	pm, notifier := newTickTestMonitor(prices)
+	addTickTestPosition(t, pm, "005930")
+	addTickTestPosition(t, pm, "000660")
+
+	pm.mu.Lock()
+	pm.lastTickAt["000660"] = time.Now()
+	pm.lastTickAt["005930"] = time.Now().Add(-time.Minute)
+	pm.mu.Unlock()
+
+	pm.checkStalePositions(context.Background())
+
+	assert.Equal(t, 1, prices.callCount("005930"))
+	assert.Equal(t, 0, prices.callCount("000660"))
+	assert.False(t, prices.lockedOnIO, "price fetch must not hold the monitor lock")
+
+	require.Equal(t, 1, notifier.count())
+	signal := pm.GetRecentSignals(1)[0]
+	assert.Equal(t, contracts.ExitReasonSecondStop, signal.Reason)
+	assert.Equal(t, contracts.ExitEvalPoll, signal.EvalSource)
+
+	stats := pm.GetTickStats()
+	assert.Equal(t, int64(1), stats.FallbackChecks)
+	assert.Equal(t, int64(1), stats.PollSignals)
+}
+
+func TestPositionMonitor_ExitSavesOrderWithReason(t *testing.T) {
+	pm, _ := newTickTestMonitor(&fakePrices{})
+	orders := &recordingOrders{}
+	pm.SetOrderStore(orders)
+	pm.SetOrderSubmitter(&fakeSubmitter{})
+	pm.SetAccountID("acc2")
+	addTickTestPosition(t, pm, "005930")
+
+	pm.executeExit(context.Background(), &contracts.ExitSignal{
+		Code:         "005930",
+		Reason:       contracts.ExitReasonHardStop,
+		CurrentPrice: 9000,
+		SellQuantity: 100,
+		TriggeredAt:  time.Now(),
+	})
+
+	require.Len(t, orders.orders, 1)
+	order := orders.orders[0]
+	assert.Equal(t, contracts.OrderSideSell, order.Side)
+	assert.Equal(t, contracts.ExitReasonHardStop, order.ExitReason, "closed lots take the exit reason from the order")
+	assert.Equal(t, 100, order.Qty)
+	assert.Equal(t, "acc2", order.AccountID)
+	assert.Equal(t, "0000012345", order.ID, "keyed by the broker order number so fills join this row")
+	assert.Equal(t, contracts.StatusSubmitted, order.Status)
+}
+
+func TestPositionMonitor_UnsentExitSavesNoOrder(t *testing.T) {
+	pm, notifier := newTickTestMonitor(&fakePrices{})
+	orders := &recordingOrders{}
+	pm.SetOrderStore(orders)
+	addTickTestPosition(t, pm, "005930")
+
+	pm.executeExit(context.Background(), &contracts.ExitSignal{
+		Code:         "005930",
+		Reason:       contracts.ExitReasonHardStop,
+		CurrentPrice: 9000,
+		SellQuantity: 100,
+		TriggeredAt:  time.Now(),
+	})
+
+	assert.Empty(t, orders.orders, "no broker order, no order row")
+	assert.Equal(t, 1, notifier.count())
+}
+
+type fakeSubmitter struct {
+	mu     sync.Mutex
+	orders []contracts.Order
+	err    error
+}
+
+func (f *fakeSubmitter) SubmitOrder(ctx context.Context, order *contracts.Order) (*OrderResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.orders = append(f.orders, *order)
+	return &OrderResult{OrderID: "0000012345", Status: contracts.StatusSubmitted}, nil
+}
+
+func TestPositionMonitor_ExitSubmitsSellAndWaitsForBroker(t *testing.T) {
+	pm, notifier := newTickTestMonitor(&fakePrices{})
+	submitter := &fakeSubmitter{}
+	pm.SetOrderSubmitter(submitter)
+	addTickTestPosition(t, pm, "005930")
+
+	pm.executeExit(context.Background(), &contracts.ExitSignal{
+		Code:         "005930",
+		Reason:       contracts.ExitReasonSecondStop,
+		CurrentPrice: 9400,
+		SellQuantity: 100,
+		TriggeredAt:  time.Now(),
+	})
+
+	require.Len(t, submitter.orders, 1)
+	assert.Equal(t, contracts.OrderSideSell, submitter.orders[0].Side)
+	assert.Equal(t, contracts.OrderTypeMarket, submitter.orders[0].OrderType)
+	assert.Equal(t, 100, submitter.orders[0].Qty)
+	assert.Equal(t, 1, notifier.count())
+	assert.Empty(t, pm.GetPositions())
+
+	_, pending := pm.ExitPendingSince("005930")
+	assert.True(t, pending, "fully closed position waits for the broker holding to clear")
+	assert.Equal(t, []string{"005930"}, pm.ExitPendingCodes())
+	pm.ClearExitPending("005930")
+	assert.Empty(t, pm.ExitPendingCodes())
+}
+
+func TestPositionMonitor_ExitSubmitFailureKeepsPosition(t *testing.T) {
+	pm, notifier := newTickTestMonitor(&fakePrices{})
+	pm.SetOrderSubmitter(&fakeSubmitter{err: assert.AnError})
+	addTickTestPosition(t, pm, "005930")
+
+	pm.executeExit(context.Background(), &contracts.ExitSignal{
+		Code:         "005930",
+		Reason:       contracts.ExitReasonSecondStop,
+		CurrentPrice: 9400,
+		SellQuantity: 100,
+		TriggeredAt:  time.Now(),
+	})
+
+	require.Len(t, pm.GetPositions(), 1, "position stays monitored when the sell is not sent")
+	assert.Equal(t, 100, pm.GetPositions()[0].RemainingQuantity)
+	assert.Empty(t, pm.ExitPendingCodes())
+	require.Equal(t, 1, notifier.count())
+	assert.Contains(t, notifier.signals[0].Message, "청산 차단")
+}
+
+func TestPositionMonitor_AutoSellOffOnlyNotifies(t *testing.T) {
+	pm, notifier := newTickTestMonitor(&fakePrices{})
+	submitter := &fakeSubmitter{}
+	pm.SetOrderSubmitter(submitter)
+	pm.SetAutoSell(false)
+	addTickTestPosition(t, pm, "005930")
+
+	pm.executeExit(context.Background(), &contracts.ExitSignal{
+		Code:         "005930",
+		Reason:       contracts.ExitReasonSecondStop,
+		CurrentPrice: 9400,
+		SellQuantity: 100,
+		TriggeredAt:  time.Now(),
+	})
+
+	assert.Empty(t, submitter.orders)
+	assert.Equal(t, 1, notifier.count())
+	_, pending := pm.ExitPendingSince("005930")
+	assert.True(t, pending, "manual sell is awaited the same way")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/wonny/aegis/v13/backend/internal/contracts"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // Monitor monitors order execution status
@@ -79,6 +80,7 @@ func (m *Monitor) MonitorOrders(ctx context.Context, orders []contracts.Order, c
 						UpdatedAt:   time.Now(),
 					}
 					results = append(results, result)
+					metrics.ObserveOrderCompletion(string(order.Side), string(status.Status), status.FilledQty, order.Qty)
 
 					// DB 저장
 					if err := m.repository.SaveExecutionResult(ctx, &result); err != nil {
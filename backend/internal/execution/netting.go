@@ -0,0 +1,116 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+// NetOrders offsets opposite-side orders of different strategies in the same account and stock
+// ⭐ SSOT: 전략 간 상계(self-crossing 방지) 규칙은 여기서만
+// - 같은 계좌 × 종목에서 전략 A 매도 / 전략 B 매수가 함께 나오면 min(매수, 매도) 수량만큼 상계
+// - 상계 수량은 브로커로 보내지 않고 NETTED 주문(leg)으로 남김 → 전략별 원장 귀속용
+// - 상계가(transfer price)는 상계된 매수/매도 쌍의 지정가 중간값 (한쪽만 있으면 그 가격)
+// - 양쪽 모두 시장가면 refPrices[종목](현재가) 사용, 기준가도 없으면 상계하지 않음 (0원 leg 방지)
+// - 같은 전략 안의 매수/매도는 상계하지 않음 (planner가 만들지 않는 조합)
+// 반환: net = 브로커 전송 대상 (잔여 수량), netted = 상계 leg
+func NetOrders(orders []contracts.Order, refPrices map[string]float64) (net, netted []contracts.Order) {
+	type key struct{ account, code string }
+
+	groups := make(map[key][]int)
+	keys := make([]key, 0)
+	for i, o := range orders {
+		k := key{o.Account(), o.Code}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	remaining := make([]int, len(orders))
+	for i, o := range orders {
+		remaining[i] = o.Qty
+	}
+	netted = make([]contracts.Order, 0)
+
+	for _, k := range keys {
+		var buys, sells []int
+		for _, i := range groups[k] {
+			switch orders[i].Side {
+			case contracts.OrderSideBuy:
+				buys = append(buys, i)
+			case contracts.OrderSideSell:
+				sells = append(sells, i)
+			}
+		}
+		if len(buys) == 0 || len(sells) == 0 {
+			continue
+		}
+
+		// 매수 × 매도 쌍을 순서대로 상계 (다른 전략끼리만)
+		for _, b := range buys {
+			for _, s := range sells {
+				if remaining[b] == 0 {
+					break
+				}
+				if remaining[s] == 0 || orders[b].StrategyID == orders[s].StrategyID {
+					continue
+				}
+				price := transferPrice(orders[b].Price, orders[s].Price)
+				if price <= 0 {
+					price = refPrices[k.code]
+				}
+				if price <= 0 {
+					continue
+				}
+
+				qty := min(remaining[b], remaining[s])
+				remaining[b] -= qty
+				remaining[s] -= qty
+				netted = append(netted,
+					nettedLeg(orders[b], orders[s], qty, price),
+					nettedLeg(orders[s], orders[b], qty, price),
+				)
+			}
+		}
+	}
+
+	net = make([]contracts.Order, 0, len(orders))
+	for i, o := range orders {
+		if remaining[i] == 0 {
+			continue
+		}
+		o.Qty = remaining[i]
+		net = append(net, o)
+	}
+
+	sort.SliceStable(netted, func(i, j int) bool {
+		return netted[i].ID < netted[j].ID
+	})
+	return net, netted
+}
+
+// nettedLeg builds the NETTED record of order o crossed against counterpart
+func nettedLeg(o, counterpart contracts.Order, qty int, price float64) contracts.Order {
+	leg := o
+	leg.ID = fmt.Sprintf("%s_net_%s", o.ID, counterpart.ID)
+	leg.Qty = qty
+	leg.Price = price
+	leg.OrderType = contracts.OrderTypeLimit
+	leg.Status = contracts.StatusNetted
+	return leg
+}
+
+// transferPrice returns the mid of buy/sell limit prices (0인 쪽은 무시)
+func transferPrice(buy, sell float64) float64 {
+	switch {
+	case buy > 0 && sell > 0:
+		return math.Round((buy + sell) / 2)
+	case buy > 0:
+		return buy
+	default:
+		return sell
+	}
+}
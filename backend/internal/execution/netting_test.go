@@ -0,0 +1,151 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+func netTestOrder(id, account, strategy, code string, side contracts.OrderSide, qty int, price float64) contracts.Order {
+	return contracts.Order{
+		ID:         id,
+		Code:       code,
+		Side:       side,
+		Qty:        qty,
+		Price:      price,
+		OrderType:  contracts.OrderTypeLimit,
+		Status:     contracts.StatusPending,
+		StrategyID: strategy,
+		AccountID:  account,
+	}
+}
+
+func TestNetOrders_CrossStrategySameAccount(t *testing.T) {
+	orders := []contracts.Order{
+		netTestOrder("a_sell", "main", "momentum", "005930", contracts.OrderSideSell, 30, 70000),
+		netTestOrder("b_buy", "main", "value", "005930", contracts.OrderSideBuy, 50, 70200),
+		netTestOrder("b_other", "main", "value", "000660", contracts.OrderSideBuy, 10, 150000),
+	}
+
+	net, netted := NetOrders(orders, nil)
+
+	require.Len(t, net, 2)
+	assert.Equal(t, "b_buy", net[0].ID)
+	assert.Equal(t, 20, net[0].Qty, "remaining buy after offset")
+	assert.Equal(t, "b_other", net[1].ID)
+	assert.Equal(t, 10, net[1].Qty)
+
+	require.Len(t, netted, 2)
+	for _, leg := range netted {
+		assert.Equal(t, contracts.StatusNetted, leg.Status)
+		assert.Equal(t, 30, leg.Qty)
+		assert.Equal(t, 70100.0, leg.Price, "transfer price is the mid of both limits")
+	}
+	assert.Equal(t, "a_sell_net_b_buy", netted[0].ID)
+	assert.Equal(t, "momentum", netted[0].StrategyID)
+	assert.Equal(t, contracts.OrderSideSell, netted[0].Side)
+	assert.Equal(t, "b_buy_net_a_sell", netted[1].ID)
+	assert.Equal(t, "value", netted[1].StrategyID)
+}
+
+func TestNetOrders_NoCrossing(t *testing.T) {
+	orders := []contracts.Order{
+		// 다른 계좌: 상계 안 함
+		netTestOrder("a_sell", "main", "momentum", "005930", contracts.OrderSideSell, 30, 70000),
+		netTestOrder("c_buy", "isa", "value", "005930", contracts.OrderSideBuy, 30, 70100),
+		// 같은 전략: 상계 안 함
+		netTestOrder("d_sell", "main", "value", "035420", contracts.OrderSideSell, 5, 200000),
+		netTestOrder("d_buy", "main", "value", "035420", contracts.OrderSideBuy, 5, 200500),
+	}
+
+	net, netted := NetOrders(orders, nil)
+
+	assert.Empty(t, netted)
+	require.Len(t, net, 4)
+	for i := range orders {
+		assert.Equal(t, orders[i].Qty, net[i].Qty)
+	}
+}
+
+func TestNetOrders_DefaultAccount(t *testing.T) {
+	orders := []contracts.Order{
+		netTestOrder("a_sell", "", "momentum", "005930", contracts.OrderSideSell, 10, 70000),
+		netTestOrder("b_buy", contracts.DefaultAccountID, "value", "005930", contracts.OrderSideBuy, 10, 0),
+	}
+
+	net, netted := NetOrders(orders, nil)
+
+	assert.Empty(t, net, "fully offset orders are not sent")
+	require.Len(t, netted, 2)
+	assert.Equal(t, 70000.0, netted[0].Price, "market buy uses the sell limit as transfer price")
+}
+
+func TestNetOrders_TransferPricePerPair(t *testing.T) {
+	orders := []contracts.Order{
+		netTestOrder("a1", "main", "momentum", "005930", contracts.OrderSideSell, 10, 70000),
+		netTestOrder("a2", "main", "growth", "005930", contracts.OrderSideSell, 10, 69000),
+		netTestOrder("b1", "main", "value", "005930", contracts.OrderSideBuy, 15, 70200),
+		netTestOrder("b2", "main", "dividend", "005930", contracts.OrderSideBuy, 5, 0),
+	}
+
+	net, netted := NetOrders(orders, nil)
+
+	assert.Empty(t, net, "sell 20 offsets buy 20")
+
+	prices := make(map[string]float64, len(netted))
+	qtys := make(map[string]int, len(netted))
+	for _, leg := range netted {
+		prices[leg.ID] = leg.Price
+		qtys[leg.ID] = leg.Qty
+	}
+	require.Len(t, prices, 6)
+
+	// 쌍마다 두 지정가의 중간값 (시장가 쪽은 상대 지정가)
+	assert.Equal(t, 70100.0, prices["a1_net_b1"])
+	assert.Equal(t, 70100.0, prices["b1_net_a1"])
+	assert.Equal(t, 10, qtys["a1_net_b1"])
+	assert.Equal(t, 69600.0, prices["a2_net_b1"])
+	assert.Equal(t, 69600.0, prices["b1_net_a2"])
+	assert.Equal(t, 5, qtys["a2_net_b1"])
+	assert.Equal(t, 69000.0, prices["a2_net_b2"])
+	assert.Equal(t, 69000.0, prices["b2_net_a2"])
+	assert.Equal(t, 5, qtys["b2_net_a2"])
+}
+
+func TestNetOrders_MarketPairUsesReferencePrice(t *testing.T) {
+	marketOrder := func(id, strategy string, side contracts.OrderSide, qty int) contracts.Order {
+		o := netTestOrder(id, "main", strategy, "005930", side, qty, 0)
+		o.OrderType = contracts.OrderTypeMarket
+		return o
+	}
+	orders := []contracts.Order{
+		marketOrder("a_sell", "momentum", contracts.OrderSideSell, 10),
+		marketOrder("b_buy", "value", contracts.OrderSideBuy, 10),
+	}
+
+	net, netted := NetOrders(orders, map[string]float64{"005930": 70300})
+
+	assert.Empty(t, net)
+	require.Len(t, netted, 2)
+	for _, leg := range netted {
+		assert.Equal(t, 70300.0, leg.Price, "market pair is netted at the reference price")
+		assert.Equal(t, contracts.StatusNetted, leg.Status)
+	}
+}
+
+func TestNetOrders_MarketPairWithoutPriceIsNotNetted(t *testing.T) {
+	orders := []contracts.Order{
+		netTestOrder("a_sell", "main", "momentum", "005930", contracts.OrderSideSell, 10, 0),
+		netTestOrder("b_buy", "main", "value", "005930", contracts.OrderSideBuy, 10, 0),
+	}
+
+	net, netted := NetOrders(orders, map[string]float64{"000660": 150000})
+
+	assert.Empty(t, netted, "no leg is recorded at price 0")
+	require.Len(t, net, 2)
+	assert.Equal(t, 10, net[0].Qty)
+	assert.Equal(t, 10, net[1].Qty)
+}
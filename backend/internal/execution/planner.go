@@ -12,17 +12,33 @@ import (
 // Planner implements S6: Execution planning
 // ⭐ SSOT: S6 주문 계획 로직은 여기서만
 type Planner struct {
-	broker Broker
-	config ExecutionConfig
-	logger *logger.Logger
+	broker   Broker
+	quotes   QuoteProvider    // nil 가능 → 현재가 + 슬리피지
+	preTrade *PreTradeChecker // nil 가능 → 주문 검사 생략
+	config   ExecutionConfig
+	logger   *logger.Logger
 }
 
+// QuoteProvider provides best bid/ask from the realtime order book (cache.OrderBookCache)
+type QuoteProvider interface {
+	BestBidAsk(code string) (bid, ask int64, ok bool)
+}
+
+// Limit price references (strategy execution.limit_policy)
+const (
+	PriceRefASK1 = "ASK1" // 최우선 매도호가
+	PriceRefBID1 = "BID1" // 최우선 매수호가
+	PriceRefLast = "LAST" // 현재가 + 슬리피지
+)
+
 // ExecutionConfig defines execution parameters
 type ExecutionConfig struct {
 	OrderType      contracts.OrderType // LIMIT or MARKET
 	SlippageBps    int                 // 슬리피지 (10 = 0.1%)
 	MaxOrderSize   int64               // 최대 주문 금액
 	SplitThreshold int64               // 분할 주문 기준
+	BuyPriceRef    string              // 매수 지정가 기준 (ASK1/BID1/LAST, 기본 LAST)
+	SellPriceRef   string              // 매도 지정가 기준 (ASK1/BID1/LAST, 기본 LAST)
 }
 
 // NewPlanner creates a new execution planner
@@ -34,9 +50,21 @@ func NewPlanner(broker Broker, config ExecutionConfig, logger *logger.Logger) *P
 	}
 }
 
+// SetQuoteProvider sets the order book source for ASK1/BID1 limit pricing
+func (p *Planner) SetQuoteProvider(quotes QuoteProvider) {
+	p.quotes = quotes
+}
+
+// SetPreTradeChecker drops planned orders that would fail pre-trade checks
+// 계획 주문은 Check만 수행 (기록 없음) → 같은 계획의 매수 금액을 일일 누적 한도에 합산
+func (p *Planner) SetPreTradeChecker(checker *PreTradeChecker) {
+	p.preTrade = checker
+}
+
 // Plan creates execution orders from target portfolio
 func (p *Planner) Plan(ctx context.Context, target *contracts.TargetPortfolio) ([]contracts.Order, error) {
 	orders := make([]contracts.Order, 0)
+	var plannedBuy int64 // 이 계획에서 통과한 매수 금액 (일일 누적 한도용)
 
 	// 1. 매도 주문 먼저 (자금 확보)
 	for _, pos := range target.Positions {
@@ -49,6 +77,9 @@ func (p *Planner) Plan(ctx context.Context, target *contracts.TargetPortfolio) (
 				}).Warn("Failed to create sell order")
 				continue
 			}
+			if _, ok := p.passesPreTrade(ctx, order, 0); !ok {
+				continue
+			}
 			orders = append(orders, order)
 		}
 	}
@@ -64,6 +95,11 @@ func (p *Planner) Plan(ctx context.Context, target *contracts.TargetPortfolio) (
 				}).Warn("Failed to create buy order")
 				continue
 			}
+			notional, ok := p.passesPreTrade(ctx, order, plannedBuy)
+			if !ok {
+				continue
+			}
+			plannedBuy += notional
 			orders = append(orders, order)
 		}
 	}
@@ -77,6 +113,44 @@ func (p *Planner) Plan(ctx context.Context, target *contracts.TargetPortfolio) (
 	return orders, nil
 }
 
+// ReferencePrice returns the current price used to value market orders (전략 간 상계가)
+func (p *Planner) ReferencePrice(ctx context.Context, code string) (float64, error) {
+	return p.broker.GetCurrentPrice(ctx, code)
+}
+
+// passesPreTrade checks a planned order against pre-trade rules and returns its notional
+// pendingBuy: 같은 계획에서 앞서 통과한 매수 금액, 검사 오류 시 주문 제외 (fail-closed)
+func (p *Planner) passesPreTrade(ctx context.Context, order contracts.Order, pendingBuy int64) (int64, bool) {
+	if p.preTrade == nil {
+		return 0, true
+	}
+
+	result, err := p.preTrade.Check(ctx, &PreTradeOrder{
+		Order:           order,
+		Source:          PreTradeSourcePlanner,
+		Actor:           "planner",
+		PendingNotional: pendingBuy,
+	})
+	if err != nil {
+		p.logger.WithFields(map[string]interface{}{
+			"code":  order.Code,
+			"error": err,
+		}).Warn("Pre-trade check failed, dropping planned order")
+		return 0, false
+	}
+
+	if !result.Passed {
+		p.logger.WithFields(map[string]interface{}{
+			"code":       order.Code,
+			"side":       order.Side,
+			"qty":        order.Qty,
+			"violations": result.Violations,
+		}).Warn("Planned order dropped by pre-trade checks")
+		return 0, false
+	}
+	return result.Notional, true
+}
+
 // createBuyOrder creates a buy order from target position
 // ⭐ P0 수정: TargetValue에서 수량 계산 (0 수량 방지)
 func (p *Planner) createBuyOrder(ctx context.Context, pos contracts.TargetPosition) (contracts.Order, error) {
@@ -146,12 +220,23 @@ func (p *Planner) createSellOrder(ctx context.Context, pos contracts.TargetPosit
 	}, nil
 }
 
-// getTargetPrice calculates target price with slippage
+// getTargetPrice calculates limit price
+// 1. limit_policy가 ASK1/BID1이고 신선한 호가가 있으면 해당 호가
+// 2. 그 외에는 현재가에 슬리피지 적용
 func (p *Planner) getTargetPrice(ctx context.Context, code string, side contracts.OrderSide) (float64, error) {
 	if p.config.OrderType == contracts.OrderTypeMarket {
 		return 0, nil // 시장가
 	}
 
+	ref := p.config.SellPriceRef
+	if side == contracts.OrderSideBuy {
+		ref = p.config.BuyPriceRef
+	}
+
+	if price, ok := p.quotePrice(code, ref); ok {
+		return price, nil
+	}
+
 	currentPrice, err := p.broker.GetCurrentPrice(ctx, code)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get current price: %w", err)
@@ -166,6 +251,27 @@ func (p *Planner) getTargetPrice(ctx context.Context, code string, side contract
 	return currentPrice * (1 - slippage), nil
 }
 
+// quotePrice resolves an ASK1/BID1 reference from the order book
+func (p *Planner) quotePrice(code, ref string) (float64, bool) {
+	if p.quotes == nil || (ref != PriceRefASK1 && ref != PriceRefBID1) {
+		return 0, false
+	}
+
+	bid, ask, ok := p.quotes.BestBidAsk(code)
+	if !ok {
+		p.logger.WithFields(map[string]interface{}{
+			"code": code,
+			"ref":  ref,
+		}).Debug("No fresh order book, falling back to last price with slippage")
+		return 0, false
+	}
+
+	if ref == PriceRefASK1 {
+		return float64(ask), true
+	}
+	return float64(bid), true
+}
+
 // splitOrder splits large order into smaller chunks
 // ⭐ P0 수정: Price=0(시장가)일 때 0 나눗셈 방지
 func (p *Planner) splitOrder(order contracts.Order) []contracts.Order {
@@ -0,0 +1,177 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+type fakeQuotes map[string][2]int64
+
+func (f fakeQuotes) BestBidAsk(code string) (int64, int64, bool) {
+	q, ok := f[code]
+	return q[0], q[1], ok
+}
+
+func TestPlanner_GetTargetPriceUsesOrderBook(t *testing.T) {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	p := NewPlanner(nil, ExecutionConfig{
+		OrderType:    contracts.OrderTypeLimit,
+		BuyPriceRef:  PriceRefASK1,
+		SellPriceRef: PriceRefBID1,
+	}, log)
+	p.SetQuoteProvider(fakeQuotes{"005930": {70000, 70100}})
+
+	ctx := context.Background()
+
+	buy, err := p.getTargetPrice(ctx, "005930", contracts.OrderSideBuy)
+	require.NoError(t, err)
+	assert.Equal(t, 70100.0, buy)
+
+	sell, err := p.getTargetPrice(ctx, "005930", contracts.OrderSideSell)
+	require.NoError(t, err)
+	assert.Equal(t, 70000.0, sell)
+
+	// 호가 없음 → 현재가 + 슬리피지 경로 (quotePrice 미사용)
+	_, ok := p.quotePrice("000660", PriceRefASK1)
+	assert.False(t, ok)
+
+	// LAST 기준은 호가를 사용하지 않음
+	_, ok = p.quotePrice("005930", PriceRefLast)
+	assert.False(t, ok)
+}
+
+type fakeLastPrices map[string]int64
+
+func (f fakeLastPrices) GetLatestByCode(ctx context.Context, code string) (*contracts.Price, error) {
+	return &contracts.Price{Code: code, Close: f[code]}, nil
+}
+
+type fakeSpreadStats struct {
+	avg   map[string]float64
+	loads int
+}
+
+func (f *fakeSpreadStats) GetAvgSpreads(ctx context.Context, asOf time.Time, days int) (map[string]float64, error) {
+	f.loads++
+	return f.avg, nil
+}
+
+func TestSpreadQuoteProvider_LiveThenRecordedSpread(t *testing.T) {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	spreads := &fakeSpreadStats{avg: map[string]float64{"000660": 0.002}}
+	q := NewSpreadQuoteProvider(
+		fakeQuotes{"005930": {70000, 70100}},
+		fakeLastPrices{"005930": 70000, "000660": 100000, "035720": 50000},
+		spreads, 5, log,
+	)
+
+	// 실시간 호가 우선
+	bid, ask, ok := q.BestBidAsk("005930")
+	require.True(t, ok)
+	assert.Equal(t, int64(70000), bid)
+	assert.Equal(t, int64(70100), ask)
+
+	// 실시간 호가 없음 → 종가 ± 평균 스프레드/2
+	bid, ask, ok = q.BestBidAsk("000660")
+	require.True(t, ok)
+	assert.Equal(t, int64(99900), bid)
+	assert.Equal(t, int64(100100), ask)
+
+	// 스프레드 통계 없음 → 호가 없음 (플래너는 현재가 + 슬리피지)
+	_, _, ok = q.BestBidAsk("035720")
+	assert.False(t, ok)
+
+	assert.Equal(t, 1, spreads.loads, "average spreads are loaded once per day")
+}
+
+func TestKRXTickRounding(t *testing.T) {
+	tests := []struct {
+		price       float64
+		floor, ceil int64
+	}{
+		{1_999.4, 1_999, 2_000},
+		{2_001, 2_000, 2_005},
+		{4_997, 4_995, 5_000},
+		{5_003, 5_000, 5_010},
+		{19_995, 19_990, 20_000},
+		{20_020, 20_000, 20_050},
+		{49_960, 49_950, 50_000},
+		{50_040, 50_000, 50_100},
+		{199_950, 199_900, 200_000},
+		{200_300, 200_000, 200_500},
+		{499_600, 499_500, 500_000},
+		{500_400, 500_000, 501_000},
+		{70_000, 70_000, 70_000},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.floor, FloorToTick(tt.price), "floor %.1f", tt.price)
+		assert.Equal(t, tt.ceil, CeilToTick(tt.price), "ceil %.1f", tt.price)
+	}
+}
+
+func TestSpreadQuoteProvider_EstimatedQuotesOnTickGrid(t *testing.T) {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	q := NewSpreadQuoteProvider(nil,
+		fakeLastPrices{"A": 4_990, "B": 19_980, "C": 49_900, "D": 70_000},
+		&fakeSpreadStats{avg: map[string]float64{"A": 0.004, "B": 0.002, "C": 0.003, "D": 0.0001}},
+		5, log,
+	)
+
+	tests := []struct {
+		code     string
+		bid, ask int64
+	}{
+		{"A", 4_980, 5_000},   // 4,980.02 / 4,999.98 → 5원 단위, 매도호가는 10원 구간 경계
+		{"B", 19_960, 20_000}, // 19,960.02 / 19,999.98
+		{"C", 49_800, 50_000}, // 49,825.15 / 49,974.85 → 50원 단위
+		{"D", 69_900, 70_100}, // 69,996.5 / 70,003.5 → 1틱 미만 스프레드도 양쪽 100원 단위로
+	}
+
+	for _, tt := range tests {
+		bid, ask, ok := q.BestBidAsk(tt.code)
+		require.True(t, ok, tt.code)
+		assert.Equal(t, tt.bid, bid, "bid %s", tt.code)
+		assert.Equal(t, tt.ask, ask, "ask %s", tt.code)
+		assert.Zero(t, bid%KRXTickSize(bid), "bid on tick %s", tt.code)
+		assert.Zero(t, ask%KRXTickSize(ask), "ask on tick %s", tt.code)
+	}
+}
+
+func TestPlanner_PlanSumsBuyNotionalAgainstDailyLimit(t *testing.T) {
+	refs := map[string]MarketReference{
+		"005930": {PrevClose: 50000, ADTV20: 1_000_000_000_000, Days: 20},
+		"000660": {PrevClose: 50000, ADTV20: 1_000_000_000_000, Days: 20},
+		"035420": {PrevClose: 50000, ADTV20: 1_000_000_000_000, Days: 20},
+	}
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	p := NewPlanner(NewMockBroker(), ExecutionConfig{OrderType: contracts.OrderTypeMarket}, log)
+	p.SetPreTradeChecker(newTestPreTradeChecker(&memPreTradeStore{refs: refs}))
+
+	// 주문당 6백만원 (한도 1천만원), 일일 누적 1천5백만원 → 세 번째 매수 제외
+	target := &contracts.TargetPortfolio{Positions: []contracts.TargetPosition{
+		{Code: "005930", TargetValue: 6_000_000, Action: contracts.ActionBuy},
+		{Code: "000660", TargetValue: 6_000_000, Action: contracts.ActionBuy},
+		{Code: "035420", TargetValue: 6_000_000, Action: contracts.ActionBuy},
+	}}
+
+	orders, err := p.Plan(context.Background(), target)
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, "005930", orders[0].Code)
+	assert.Equal(t, "000660", orders[1].Code)
+
+	// 기준 데이터 조회 실패 (검사 오류) → 주문 제외
+	p.SetPreTradeChecker(newTestPreTradeChecker(&memPreTradeStore{refs: refs, refErr: assert.AnError}))
+	orders, err = p.Plan(context.Background(), target)
+	require.NoError(t, err)
+	assert.Empty(t, orders, "checker errors fail closed")
+}
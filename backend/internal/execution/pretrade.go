@@ -0,0 +1,429 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// =============================================================================
+// PreTradeChecker - 브로커 전송 직전 주문 검사 (fat-finger / 킬 스위치)
+// =============================================================================
+
+// PreTradeSource 주문 출처
+type PreTradeSource string
+
+const (
+	PreTradeSourceManual      PreTradeSource = "manual"       // API 수동 주문
+	PreTradeSourcePlanner     PreTradeSource = "planner"      // S6 실행 계획
+	PreTradeSourceExitMonitor PreTradeSource = "exit_monitor" // 자동 청산
+)
+
+// PreTradeRule 검사 규칙
+type PreTradeRule string
+
+const (
+	RuleKillSwitch       PreTradeRule = "kill_switch"        // 전역 킬 스위치
+	RulePriceBand        PreTradeRule = "price_band"         // KRX 가격제한폭 (전일 종가 ±30%)
+	RuleMaxOrderNotional PreTradeRule = "max_order_notional" // 주문당 최대 금액
+	RuleMaxDailyNotional PreTradeRule = "max_daily_notional" // 일일 누적 매수 금액
+	RuleADTV             PreTradeRule = "adtv_participation" // 주문금액 / ADTV20
+	RuleDuplicate        PreTradeRule = "duplicate_order"    // 동일 주문 반복
+	RuleBuyingPower      PreTradeRule = "buying_power"       // 매수 가능 금액
+	RuleReferenceData    PreTradeRule = "reference_data"     // 기준 데이터 없음
+)
+
+// PreTradeConfig 주문 검사 한도
+type PreTradeConfig struct {
+	PriceLimitPct       float64       // 가격제한폭 (KRX 0.30)
+	MaxOrderNotional    int64         // 주문당 최대 금액 (원)
+	MaxDailyNotional    int64         // 계좌별 일일 누적 매수 최대 금액 (원)
+	MaxOrderToADTV20Pct float64       // 주문금액 / ADTV20 상한 (strategy liquidity_caps.max_order_to_adtv20_pct)
+	DuplicateWindow     time.Duration // 동일 종목/방향/수량/가격 주문 차단 구간
+}
+
+// DefaultPreTradeConfig 기본 한도
+func DefaultPreTradeConfig() PreTradeConfig {
+	return PreTradeConfig{
+		PriceLimitPct:       0.30,
+		MaxOrderNotional:    50_000_000,  // 5천만원 (Planner MaxOrderSize와 동일)
+		MaxDailyNotional:    300_000_000, // 3억원
+		MaxOrderToADTV20Pct: 0.02,        // 2%
+		DuplicateWindow:     60 * time.Second,
+	}
+}
+
+// PreTradeOrder 검사 대상 주문
+type PreTradeOrder struct {
+	Order    contracts.Order
+	Source   PreTradeSource
+	Actor    string  // api:<key>, scheduler 등
+	RefPrice float64 // 시장가 주문 금액 환산용 현재가 (0이면 전일 종가)
+
+	PendingNotional int64 // 기록되지 않은 선행 매수 금액 (같은 계획 내 주문), 일일 누적에 합산
+}
+
+// PreTradeViolation 규칙 위반
+type PreTradeViolation struct {
+	Rule    PreTradeRule `json:"rule"`
+	Message string       `json:"message"`
+}
+
+// PreTradeResult 검사 결과
+type PreTradeResult struct {
+	Passed     bool                `json:"passed"`
+	Violations []PreTradeViolation `json:"violations"`
+	Notional   int64               `json:"notional"`   // 주문금액 (시장가는 기준가 환산)
+	PrevClose  int64               `json:"prev_close"` // 가격제한폭 기준가
+	ADTV20     int64               `json:"adtv20"`
+	CheckedAt  time.Time           `json:"checked_at"`
+}
+
+// Message 위반 사유 요약
+func (r *PreTradeResult) Message() string {
+	if r.Passed {
+		return "Pre-trade checks passed"
+	}
+	msg := ""
+	for i, v := range r.Violations {
+		if i > 0 {
+			msg += "; "
+		}
+		msg += v.Message
+	}
+	return msg
+}
+
+// Blocked returns true if the rule is among the violations
+func (r *PreTradeResult) Blocked(rule PreTradeRule) bool {
+	for _, v := range r.Violations {
+		if v.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// MarketReference 종목 기준 데이터 (data.daily_prices, 당일 제외)
+type MarketReference struct {
+	PrevClose int64
+	ADTV20    int64 // 최근 20거래일 평균 거래대금
+	Days      int
+}
+
+// KillSwitch 전역 킬 스위치 상태
+type KillSwitch struct {
+	Active    bool      `json:"active"`
+	Reason    string    `json:"reason,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PreTradeStore 검사에 필요한 저장소 (Repository 구현, 테스트에서 대체)
+type PreTradeStore interface {
+	GetKillSwitch(ctx context.Context) (*KillSwitch, error)
+	GetMarketReference(ctx context.Context, code string, before time.Time) (*MarketReference, error)
+	SumPassedNotional(ctx context.Context, accountID string, side contracts.OrderSide, since time.Time) (int64, error)
+	HasRecentDuplicate(ctx context.Context, order contracts.Order, since time.Time) (bool, error)
+	SavePreTradeCheck(ctx context.Context, order *PreTradeOrder, result *PreTradeResult) error
+}
+
+// BuyingPowerProvider 매수 가능 금액 조회 (kis.Client)
+type BuyingPowerProvider interface {
+	GetBuyingPower(ctx context.Context) (int64, error)
+}
+
+// PreTradeChecker 주문 직전 검사
+// ⭐ SSOT: 브로커 전송 직전 주문 검사와 킬 스위치 판정은 여기서만
+// 수동 주문(API), S6 Planner, 청산 모니터가 공유
+type PreTradeChecker struct {
+	store       PreTradeStore
+	buyingPower BuyingPowerProvider            // nil이면 매수 가능 금액 검사 생략
+	accounts    map[string]BuyingPowerProvider // 계좌별 매수 가능 금액 (없으면 buyingPower)
+	config      PreTradeConfig
+	logger      *logger.Logger
+	now         func() time.Time
+
+	// 같은 프로세스 안에서 누적 금액/중복 판정과 기록을 직렬화
+	mu sync.Mutex
+}
+
+// NewPreTradeChecker 새 주문 검사기 생성
+func NewPreTradeChecker(store PreTradeStore, config PreTradeConfig, log *logger.Logger) *PreTradeChecker {
+	return &PreTradeChecker{
+		store:  store,
+		config: config,
+		logger: log,
+		now:    time.Now,
+	}
+}
+
+// SetBuyingPowerProvider 매수 가능 금액 조회 설정 (계좌 미지정 주문 / 기본 계좌)
+func (c *PreTradeChecker) SetBuyingPowerProvider(provider BuyingPowerProvider) {
+	c.buyingPower = provider
+}
+
+// SetAccountBuyingPower 계좌별 매수 가능 금액 조회 설정 (order.AccountID 기준)
+func (c *PreTradeChecker) SetAccountBuyingPower(accountID string, provider BuyingPowerProvider) {
+	if c.accounts == nil {
+		c.accounts = make(map[string]BuyingPowerProvider)
+	}
+	c.accounts[accountID] = provider
+}
+
+// buyingPowerFor returns the provider for the order account
+func (c *PreTradeChecker) buyingPowerFor(order contracts.Order) BuyingPowerProvider {
+	if p, ok := c.accounts[order.Account()]; ok {
+		return p
+	}
+	return c.buyingPower
+}
+
+// Check 주문을 검사만 함 (기록 없음, 계획 단계용)
+func (c *PreTradeChecker) Check(ctx context.Context, o *PreTradeOrder) (*PreTradeResult, error) {
+	return c.evaluate(ctx, o)
+}
+
+// Authorize 주문을 검사하고 결과를 execution.pretrade_checks에 기록
+// 통과한 주문만 브로커로 전송해야 함 (통과 기록은 일일 누적/중복 판정에 사용)
+func (c *PreTradeChecker) Authorize(ctx context.Context, o *PreTradeOrder) (*PreTradeResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, err := c.evaluate(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store.SavePreTradeCheck(ctx, o, result); err != nil {
+		return nil, fmt.Errorf("save pre-trade check: %w", err)
+	}
+
+	fields := map[string]interface{}{
+		"source":   o.Source,
+		"actor":    o.Actor,
+		"account":  o.Order.Account(),
+		"strategy": o.Order.StrategyID,
+		"code":     o.Order.Code,
+		"side":     o.Order.Side,
+		"qty":      o.Order.Qty,
+		"price":    o.Order.Price,
+		"notional": result.Notional,
+	}
+	if result.Passed {
+		c.logger.WithFields(fields).Debug("Pre-trade checks passed")
+	} else {
+		fields["violations"] = result.Violations
+		c.logger.WithFields(fields).Warn("Order blocked by pre-trade checks")
+	}
+
+	return result, nil
+}
+
+// evaluate runs every rule; store errors fail closed (주문 차단)
+func (c *PreTradeChecker) evaluate(ctx context.Context, o *PreTradeOrder) (*PreTradeResult, error) {
+	now := c.now()
+	order := o.Order
+	result := &PreTradeResult{CheckedAt: now}
+
+	if order.Qty <= 0 {
+		return nil, fmt.Errorf("invalid order qty %d", order.Qty)
+	}
+	isBuy := order.Side == contracts.OrderSideBuy
+	// 손절/강제 청산 매도는 금액·유동성·중복 한도 면제
+	riskReducing := !isBuy && order.ExitReason.IsRiskReducing()
+
+	// 1. 킬 스위치 (조회 실패 시 차단)
+	ks, err := c.store.GetKillSwitch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get kill switch: %w", err)
+	}
+	if ks.Active {
+		result.add(RuleKillSwitch, fmt.Sprintf("Kill switch active (%s, by %s)", ks.Reason, ks.UpdatedBy))
+	}
+
+	// 2. 기준 데이터 (전일 종가, ADTV20)
+	ref, err := c.store.GetMarketReference(ctx, order.Code, now)
+	if err != nil {
+		return nil, fmt.Errorf("get market reference for %s: %w", order.Code, err)
+	}
+	result.PrevClose = ref.PrevClose
+	result.ADTV20 = ref.ADTV20
+
+	// 3. 주문금액 (시장가: 현재가 → 전일 종가)
+	price := order.Price
+	if price <= 0 {
+		price = o.RefPrice
+	}
+	if price <= 0 {
+		price = float64(ref.PrevClose)
+	}
+	result.Notional = int64(math.Round(price * float64(order.Qty)))
+
+	if ref.PrevClose <= 0 {
+		// 기준 데이터 없는 종목: 매수 차단, 매도(청산)는 가격/유동성 검사 생략
+		if isBuy {
+			result.add(RuleReferenceData, fmt.Sprintf("No price history for %s", order.Code))
+		}
+	} else {
+		// 4. 가격제한폭 (지정가만)
+		if order.Price > 0 {
+			lower, upper := PriceBand(ref.PrevClose, c.config.PriceLimitPct)
+			if int64(order.Price) < lower || int64(order.Price) > upper {
+				result.add(RulePriceBand, fmt.Sprintf("Price %.0f outside daily limit band %d-%d (prev close %d)",
+					order.Price, lower, upper, ref.PrevClose))
+			}
+		}
+
+		// 5. ADTV20 대비 주문 비중
+		if !riskReducing && c.config.MaxOrderToADTV20Pct > 0 && ref.ADTV20 > 0 {
+			pct := float64(result.Notional) / float64(ref.ADTV20)
+			if pct > c.config.MaxOrderToADTV20Pct {
+				result.add(RuleADTV, fmt.Sprintf("Order is %.2f%% of ADTV20 (max %.2f%%)",
+					pct*100, c.config.MaxOrderToADTV20Pct*100))
+			}
+		}
+	}
+
+	// 6. 주문당 최대 금액
+	if !riskReducing && c.config.MaxOrderNotional > 0 && result.Notional > c.config.MaxOrderNotional {
+		result.add(RuleMaxOrderNotional, fmt.Sprintf("Order notional %d exceeds max %d",
+			result.Notional, c.config.MaxOrderNotional))
+	}
+
+	// 7. 계좌별 일일 누적 매수 금액 (매도는 보유수량으로 제한되므로 매수만, 계좌 내 전략 합산)
+	if isBuy && c.config.MaxDailyNotional > 0 {
+		used, err := c.store.SumPassedNotional(ctx, order.Account(), contracts.OrderSideBuy, startOfDay(now))
+		if err != nil {
+			return nil, fmt.Errorf("sum daily notional: %w", err)
+		}
+		used += o.PendingNotional
+		if used+result.Notional > c.config.MaxDailyNotional {
+			result.add(RuleMaxDailyNotional, fmt.Sprintf("Daily buy notional %d + %d exceeds max %d",
+				used, result.Notional, c.config.MaxDailyNotional))
+		}
+	}
+
+	// 8. 중복 주문 (청산 모니터가 사유별로 중복 억제)
+	if !riskReducing && c.config.DuplicateWindow > 0 {
+		dup, err := c.store.HasRecentDuplicate(ctx, order, now.Add(-c.config.DuplicateWindow))
+		if err != nil {
+			return nil, fmt.Errorf("check duplicate order: %w", err)
+		}
+		if dup {
+			result.add(RuleDuplicate, fmt.Sprintf("Same %s order for %s (qty %d, price %.0f) within %s",
+				order.Side, order.Code, order.Qty, order.Price, c.config.DuplicateWindow))
+		}
+	}
+
+	// 9. 매수 가능 금액 (주문 계좌 기준)
+	if bp := c.buyingPowerFor(order); isBuy && bp != nil {
+		available, err := bp.GetBuyingPower(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get buying power: %w", err)
+		}
+		if result.Notional > available {
+			result.add(RuleBuyingPower, fmt.Sprintf("Order notional %d exceeds buying power %d",
+				result.Notional, available))
+		}
+	}
+
+	result.Passed = len(result.Violations) == 0
+	if result.Violations == nil {
+		result.Violations = make([]PreTradeViolation, 0)
+	}
+	return result, nil
+}
+
+func (r *PreTradeResult) add(rule PreTradeRule, message string) {
+	r.Violations = append(r.Violations, PreTradeViolation{Rule: rule, Message: message})
+}
+
+// PriceBand KRX 일일 가격제한폭 (상한 내림 / 하한 올림, 호가단위 미반영)
+func PriceBand(prevClose int64, limitPct float64) (lower, upper int64) {
+	delta := float64(prevClose) * limitPct
+	return int64(math.Ceil(float64(prevClose) - delta)), int64(math.Floor(float64(prevClose) + delta))
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// =============================================================================
+// Kill Switch
+// =============================================================================
+
+// OpenOrderCanceller 미체결 주문 일괄 취소 (kis.Client)
+type OpenOrderCanceller interface {
+	CancelUnfilledOrders(ctx context.Context) ([]string, error)
+}
+
+// KillSwitchStore 킬 스위치 저장소 (Repository 구현)
+type KillSwitchStore interface {
+	SetKillSwitch(ctx context.Context, active bool, reason, changedBy string, cancelOpen bool) (int64, error)
+	RecordKillSwitchCancellations(ctx context.Context, eventID int64, cancelled int) error
+}
+
+// KillSwitchResult 킬 스위치 변경 결과
+type KillSwitchResult struct {
+	Active          bool     `json:"active"`
+	EventID         int64    `json:"event_id"`
+	CancelledOrders []string `json:"cancelled_orders"`
+	CancelError     string   `json:"cancel_error,omitempty"`
+}
+
+// ActivateKillSwitch 킬 스위치 발동 (즉시 신규 주문 차단), canceller가 있으면 미체결 주문 취소
+// 취소 실패는 발동을 되돌리지 않고 결과에 기록
+func ActivateKillSwitch(ctx context.Context, store KillSwitchStore, canceller OpenOrderCanceller, reason, changedBy string, log *logger.Logger) (*KillSwitchResult, error) {
+	eventID, err := store.SetKillSwitch(ctx, true, reason, changedBy, canceller != nil)
+	if err != nil {
+		return nil, fmt.Errorf("activate kill switch: %w", err)
+	}
+
+	result := &KillSwitchResult{Active: true, EventID: eventID, CancelledOrders: make([]string, 0)}
+
+	log.WithFields(map[string]interface{}{
+		"reason":      reason,
+		"changed_by":  changedBy,
+		"cancel_open": canceller != nil,
+	}).Warn("Kill switch activated")
+
+	if canceller == nil {
+		return result, nil
+	}
+
+	cancelled, cancelErr := canceller.CancelUnfilledOrders(ctx)
+	result.CancelledOrders = append(result.CancelledOrders, cancelled...)
+	if cancelErr != nil {
+		result.CancelError = cancelErr.Error()
+		log.WithError(cancelErr).Error("Failed to cancel some open orders on kill switch")
+	}
+
+	if err := store.RecordKillSwitchCancellations(ctx, eventID, len(cancelled)); err != nil {
+		log.WithError(err).Warn("Failed to record kill switch cancellations")
+	}
+
+	log.WithField("cancelled", len(cancelled)).Warn("Open orders cancelled by kill switch")
+	return result, nil
+}
+
+// DeactivateKillSwitch 킬 스위치 해제
+func DeactivateKillSwitch(ctx context.Context, store KillSwitchStore, reason, changedBy string, log *logger.Logger) (*KillSwitchResult, error) {
+	eventID, err := store.SetKillSwitch(ctx, false, reason, changedBy, false)
+	if err != nil {
+		return nil, fmt.Errorf("deactivate kill switch: %w", err)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"reason":     reason,
+		"changed_by": changedBy,
+	}).Warn("Kill switch deactivated")
+
+	return &KillSwitchResult{Active: false, EventID: eventID, CancelledOrders: make([]string, 0)}, nil
+}
@@ -0,0 +1,344 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// memPreTradeStore keeps kill switch, references and passed checks in memory
+type memPreTradeStore struct {
+	killSwitch KillSwitch
+	refs       map[string]MarketReference
+	refErr     error
+	checks     []memCheck
+}
+
+type memCheck struct {
+	order  contracts.Order
+	result PreTradeResult
+}
+
+func (s *memPreTradeStore) GetKillSwitch(ctx context.Context) (*KillSwitch, error) {
+	ks := s.killSwitch
+	return &ks, nil
+}
+
+func (s *memPreTradeStore) GetMarketReference(ctx context.Context, code string, before time.Time) (*MarketReference, error) {
+	if s.refErr != nil {
+		return nil, s.refErr
+	}
+	ref := s.refs[code]
+	return &ref, nil
+}
+
+func (s *memPreTradeStore) SumPassedNotional(ctx context.Context, accountID string, side contracts.OrderSide, since time.Time) (int64, error) {
+	var total int64
+	for _, c := range s.checks {
+		if c.result.Passed && c.order.Side == side && !c.result.CheckedAt.Before(since) && c.order.Account() == accountID {
+			total += c.result.Notional
+		}
+	}
+	return total, nil
+}
+
+func (s *memPreTradeStore) HasRecentDuplicate(ctx context.Context, order contracts.Order, since time.Time) (bool, error) {
+	for _, c := range s.checks {
+		if c.result.Passed && c.order.Code == order.Code && c.order.Side == order.Side &&
+			c.order.Qty == order.Qty && c.order.Price == order.Price && !c.result.CheckedAt.Before(since) &&
+			c.order.Account() == order.Account() && c.order.StrategyID == order.StrategyID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *memPreTradeStore) SavePreTradeCheck(ctx context.Context, o *PreTradeOrder, result *PreTradeResult) error {
+	s.checks = append(s.checks, memCheck{order: o.Order, result: *result})
+	return nil
+}
+
+type fixedBuyingPower int64
+
+func (f fixedBuyingPower) GetBuyingPower(ctx context.Context) (int64, error) {
+	return int64(f), nil
+}
+
+func newTestPreTradeChecker(store *memPreTradeStore) *PreTradeChecker {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	c := NewPreTradeChecker(store, PreTradeConfig{
+		PriceLimitPct:       0.30,
+		MaxOrderNotional:    10_000_000,
+		MaxDailyNotional:    15_000_000,
+		MaxOrderToADTV20Pct: 0.02,
+		DuplicateWindow:     time.Minute,
+	}, log)
+	c.SetBuyingPowerProvider(fixedBuyingPower(8_000_000))
+	c.now = func() time.Time { return time.Date(2026, 10, 16, 10, 0, 0, 0, time.Local) }
+	return c
+}
+
+func limitOrder(code string, side contracts.OrderSide, qty int, price float64) *PreTradeOrder {
+	return &PreTradeOrder{
+		Order: contracts.Order{
+			Code:      code,
+			Side:      side,
+			Qty:       qty,
+			Price:     price,
+			OrderType: contracts.OrderTypeLimit,
+		},
+		Source: PreTradeSourceManual,
+	}
+}
+
+func TestPreTradeChecker_Check(t *testing.T) {
+	refs := map[string]MarketReference{
+		"005930": {PrevClose: 70000, ADTV20: 1_000_000_000_000, Days: 20},
+		"123456": {PrevClose: 10000, ADTV20: 100_000_000, Days: 20}, // 저유동성
+	}
+
+	tests := []struct {
+		name       string
+		killSwitch bool
+		order      *PreTradeOrder
+		wantRules  []PreTradeRule
+	}{
+		{
+			name:  "passes",
+			order: limitOrder("005930", contracts.OrderSideBuy, 100, 70000),
+		},
+		{
+			name:       "kill switch blocks sells too",
+			killSwitch: true,
+			order:      limitOrder("005930", contracts.OrderSideSell, 10, 70000),
+			wantRules:  []PreTradeRule{RuleKillSwitch},
+		},
+		{
+			name:      "above upper limit",
+			order:     limitOrder("005930", contracts.OrderSideBuy, 10, 91001),
+			wantRules: []PreTradeRule{RulePriceBand},
+		},
+		{
+			name:  "at lower limit",
+			order: limitOrder("005930", contracts.OrderSideSell, 10, 49000),
+		},
+		{
+			name:      "fat finger qty",
+			order:     limitOrder("005930", contracts.OrderSideSell, 1000, 70000),
+			wantRules: []PreTradeRule{RuleMaxOrderNotional},
+		},
+		{
+			name:      "exceeds buying power",
+			order:     limitOrder("005930", contracts.OrderSideBuy, 140, 70000), // 9.8M > 8M
+			wantRules: []PreTradeRule{RuleBuyingPower},
+		},
+		{
+			name:      "adtv participation",
+			order:     limitOrder("123456", contracts.OrderSideBuy, 300, 10000), // 3M / 100M = 3%
+			wantRules: []PreTradeRule{RuleADTV},
+		},
+		{
+			name:      "no price history blocks buys",
+			order:     limitOrder("999999", contracts.OrderSideBuy, 1, 10000),
+			wantRules: []PreTradeRule{RuleReferenceData},
+		},
+		{
+			name:  "no price history allows sells",
+			order: limitOrder("999999", contracts.OrderSideSell, 1, 10000),
+		},
+		{
+			name: "market order uses ref price",
+			order: &PreTradeOrder{
+				Order:    contracts.Order{Code: "005930", Side: contracts.OrderSideBuy, Qty: 150, OrderType: contracts.OrderTypeMarket},
+				RefPrice: 70000, // 10.5M
+			},
+			wantRules: []PreTradeRule{RuleMaxOrderNotional, RuleBuyingPower},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memPreTradeStore{refs: refs, killSwitch: KillSwitch{Active: tt.killSwitch}}
+			c := newTestPreTradeChecker(store)
+
+			result, err := c.Check(context.Background(), tt.order)
+			require.NoError(t, err)
+
+			var rules []PreTradeRule
+			for _, v := range result.Violations {
+				rules = append(rules, v.Rule)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+			assert.Equal(t, len(tt.wantRules) == 0, result.Passed)
+			assert.Empty(t, store.checks, "Check must not record")
+		})
+	}
+}
+
+func TestPreTradeChecker_AuthorizeTracksDailyAndDuplicates(t *testing.T) {
+	store := &memPreTradeStore{refs: map[string]MarketReference{
+		"005930": {PrevClose: 70000, ADTV20: 1_000_000_000_000, Days: 20},
+		"000660": {PrevClose: 100000, ADTV20: 1_000_000_000_000, Days: 20},
+	}}
+	c := newTestPreTradeChecker(store)
+	ctx := context.Background()
+
+	// 1. 7.7M 매수 통과
+	first, err := c.Authorize(ctx, limitOrder("005930", contracts.OrderSideBuy, 110, 70000))
+	require.NoError(t, err)
+	assert.True(t, first.Passed)
+	assert.Equal(t, int64(7_700_000), first.Notional)
+
+	// 2. 같은 주문 반복 → 중복 (일일 한도도 초과)
+	dup, err := c.Authorize(ctx, limitOrder("005930", contracts.OrderSideBuy, 110, 70000))
+	require.NoError(t, err)
+	assert.True(t, dup.Blocked(RuleDuplicate))
+	assert.True(t, dup.Blocked(RuleMaxDailyNotional))
+
+	// 3. 다른 종목 7M → 누적 14.7M (한도 15M 이내)
+	second, err := c.Authorize(ctx, limitOrder("000660", contracts.OrderSideBuy, 70, 100000))
+	require.NoError(t, err)
+	assert.True(t, second.Passed)
+
+	// 4. 추가 1M → 일일 한도 초과 (거부된 주문은 누적에 포함 안 됨)
+	third, err := c.Authorize(ctx, limitOrder("000660", contracts.OrderSideBuy, 10, 100000))
+	require.NoError(t, err)
+	assert.Equal(t, []PreTradeViolation{{Rule: RuleMaxDailyNotional, Message: third.Violations[0].Message}}, third.Violations)
+
+	// 5. 매도는 일일 매수 한도와 무관
+	sell, err := c.Authorize(ctx, limitOrder("000660", contracts.OrderSideSell, 10, 100000))
+	require.NoError(t, err)
+	assert.True(t, sell.Passed)
+
+	assert.Len(t, store.checks, 5)
+}
+
+func TestPreTradeChecker_RiskReducingExitExempt(t *testing.T) {
+	store := &memPreTradeStore{refs: map[string]MarketReference{
+		"123456": {PrevClose: 10000, ADTV20: 100_000_000, Days: 20}, // 저유동성
+	}}
+	c := newTestPreTradeChecker(store)
+	ctx := context.Background()
+
+	exitOrder := func(reason contracts.ExitReason) *PreTradeOrder {
+		return &PreTradeOrder{
+			Order: contracts.Order{
+				Code:       "123456",
+				Side:       contracts.OrderSideSell,
+				Qty:        2000, // 2천만원: 주문당 한도(1천만원), ADTV20 2% 초과
+				OrderType:  contracts.OrderTypeMarket,
+				ExitReason: reason,
+			},
+			Source:   PreTradeSourceExitMonitor,
+			RefPrice: 10000,
+		}
+	}
+
+	// 손절 매도는 금액/유동성 한도와 무관하게 허용, 반복해도 중복 차단 없음
+	for i := 0; i < 2; i++ {
+		result, err := c.Authorize(ctx, exitOrder(contracts.ExitReasonHardStop))
+		require.NoError(t, err)
+		assert.True(t, result.Passed, "stop-loss above the notional cap must go through: %s", result.Message())
+	}
+
+	// 익절 매도는 기존 한도 적용
+	result, err := c.Check(ctx, exitOrder(contracts.ExitReasonTP1))
+	require.NoError(t, err)
+	assert.True(t, result.Blocked(RuleMaxOrderNotional))
+	assert.True(t, result.Blocked(RuleADTV))
+
+	// 킬 스위치는 손절도 차단
+	store.killSwitch = KillSwitch{Active: true, Reason: "test", UpdatedBy: "ops"}
+	result, err = c.Check(ctx, exitOrder(contracts.ExitReasonHardStop))
+	require.NoError(t, err)
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, RuleKillSwitch, result.Violations[0].Rule)
+}
+
+func TestPriceBand(t *testing.T) {
+	lower, upper := PriceBand(70000, 0.30)
+	assert.Equal(t, int64(49000), lower)
+	assert.Equal(t, int64(91000), upper)
+
+	lower, upper = PriceBand(1234, 0.30)
+	assert.Equal(t, int64(864), lower)  // 863.8 → 864
+	assert.Equal(t, int64(1604), upper) // 1604.2 → 1604
+}
+
+func TestPreTradeChecker_DailyNotionalPerAccount(t *testing.T) {
+	store := &memPreTradeStore{refs: map[string]MarketReference{
+		"005930": {PrevClose: 70000, ADTV20: 1_000_000_000_000, Days: 20},
+		"000660": {PrevClose: 100000, ADTV20: 1_000_000_000_000, Days: 20},
+	}}
+	c := newTestPreTradeChecker(store)
+	c.SetAccountBuyingPower("isa", fixedBuyingPower(8_000_000))
+	ctx := context.Background()
+
+	// 기본 계좌: 7.7M + 7M = 14.7M (한도 15M)
+	res, err := c.Authorize(ctx, limitOrder("005930", contracts.OrderSideBuy, 110, 70000))
+	require.NoError(t, err)
+	require.True(t, res.Passed)
+	res, err = c.Authorize(ctx, limitOrder("000660", contracts.OrderSideBuy, 70, 100000))
+	require.NoError(t, err)
+	require.True(t, res.Passed)
+
+	// isa 계좌 7.7M → 기본 계좌 누적과 무관하게 통과
+	isa := limitOrder("005930", contracts.OrderSideBuy, 110, 70000)
+	isa.Order.AccountID = "isa"
+	res, err = c.Authorize(ctx, isa)
+	require.NoError(t, err)
+	assert.True(t, res.Passed, "another account's buys do not count toward this account's daily limit")
+
+	// isa 계좌 7.7M 추가 → isa 누적 15.4M 초과 (다른 전략이어도 계좌 합산)
+	isaValue := limitOrder("005930", contracts.OrderSideBuy, 110, 70000)
+	isaValue.Order.AccountID = "isa"
+	isaValue.Order.StrategyID = "value"
+	res, err = c.Check(ctx, isaValue)
+	require.NoError(t, err)
+	assert.True(t, res.Blocked(RuleMaxDailyNotional))
+
+	// 기본 계좌 1M 추가 → 기본 계좌 누적 15.7M 초과
+	res, err = c.Check(ctx, limitOrder("000660", contracts.OrderSideBuy, 10, 100000))
+	require.NoError(t, err)
+	assert.True(t, res.Blocked(RuleMaxDailyNotional))
+}
+
+func TestPreTradeChecker_PerAccount(t *testing.T) {
+	store := &memPreTradeStore{refs: map[string]MarketReference{
+		"005930": {PrevClose: 70000, ADTV20: 1_000_000_000_000, Days: 20},
+	}}
+	c := newTestPreTradeChecker(store)
+	c.SetAccountBuyingPower("isa", fixedBuyingPower(1_000_000))
+	ctx := context.Background()
+
+	// 기본 계좌 (8백만) 통과, isa 계좌 (1백만) 매수 가능 금액 초과
+	main := limitOrder("005930", contracts.OrderSideBuy, 50, 70000)
+	res, err := c.Authorize(ctx, main)
+	require.NoError(t, err)
+	assert.True(t, res.Passed)
+
+	isa := limitOrder("005930", contracts.OrderSideBuy, 50, 70000)
+	isa.Order.AccountID = "isa"
+	res, err = c.Authorize(ctx, isa)
+	require.NoError(t, err)
+	assert.True(t, res.Blocked(RuleBuyingPower))
+	assert.False(t, res.Blocked(RuleDuplicate), "same order in another account is not a duplicate")
+
+	// 같은 계좌의 다른 전략도 중복 아님, 같은 전략은 중복
+	other := limitOrder("005930", contracts.OrderSideBuy, 50, 70000)
+	other.Order.StrategyID = "value"
+	res, err = c.Authorize(ctx, other)
+	require.NoError(t, err)
+	assert.False(t, res.Blocked(RuleDuplicate))
+
+	res, err = c.Check(ctx, limitOrder("005930", contracts.OrderSideBuy, 50, 70000))
+	require.NoError(t, err)
+	assert.True(t, res.Blocked(RuleDuplicate))
+}
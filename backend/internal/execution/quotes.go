@@ -0,0 +1,148 @@
+package execution
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// quoteLoadTimeout bounds the DB lookups behind a single BestBidAsk call
+const quoteLoadTimeout = 5 * time.Second
+
+// LastPriceProvider returns the latest daily price (s0_data.PriceRepository)
+type LastPriceProvider interface {
+	GetLatestByCode(ctx context.Context, code string) (*contracts.Price, error)
+}
+
+// SpreadStatsProvider returns average spreads recorded from the order book cache (s0_data.SpreadRepository)
+type SpreadStatsProvider interface {
+	GetAvgSpreads(ctx context.Context, asOf time.Time, days int) (map[string]float64, error)
+}
+
+// SpreadQuoteProvider resolves best bid/ask for the planner
+// 1. 신선한 실시간 호가 (cache.OrderBookCache, nil 가능)
+// 2. 최근 종가 ± 평균 스프레드/2 (OrderBookCache → data.daily_spread_stats 기록분)
+// 장 마감 후 의사결정(17:00)에는 실시간 호가가 없으므로 2번 추정치를 사용
+type SpreadQuoteProvider struct {
+	live         QuoteProvider
+	prices       LastPriceProvider
+	spreads      SpreadStatsProvider
+	lookbackDays int
+	logger       *logger.Logger
+
+	mu       sync.Mutex
+	loadedOn string // 평균 스프레드 조회일 (YYYY-MM-DD)
+	avg      map[string]float64
+}
+
+// NewSpreadQuoteProvider creates a quote provider backed by the live order book and recorded spreads
+func NewSpreadQuoteProvider(live QuoteProvider, prices LastPriceProvider, spreads SpreadStatsProvider, lookbackDays int, log *logger.Logger) *SpreadQuoteProvider {
+	if lookbackDays <= 0 {
+		lookbackDays = 5
+	}
+	return &SpreadQuoteProvider{
+		live:         live,
+		prices:       prices,
+		spreads:      spreads,
+		lookbackDays: lookbackDays,
+		logger:       log,
+	}
+}
+
+// BestBidAsk implements QuoteProvider
+func (q *SpreadQuoteProvider) BestBidAsk(code string) (bid, ask int64, ok bool) {
+	if q.live != nil {
+		if bid, ask, ok := q.live.BestBidAsk(code); ok {
+			return bid, ask, true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), quoteLoadTimeout)
+	defer cancel()
+
+	spread, ok := q.avgSpread(ctx, code)
+	if !ok {
+		return 0, 0, false
+	}
+
+	price, err := q.prices.GetLatestByCode(ctx, code)
+	if err != nil || price == nil || price.Close <= 0 {
+		return 0, 0, false
+	}
+
+	// 추정 호가도 KRX 호가단위에 맞춤 (매수호가 내림, 매도호가 올림)
+	half := float64(price.Close) * spread / 2
+	bid = FloorToTick(float64(price.Close) - half)
+	ask = CeilToTick(float64(price.Close) + half)
+	if bid <= 0 || ask < bid {
+		return 0, 0, false
+	}
+	return bid, ask, true
+}
+
+// avgSpread returns the recorded average spread, reloading once per day
+func (q *SpreadQuoteProvider) avgSpread(ctx context.Context, code string) (float64, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	today := time.Now().Format("2006-01-02")
+	if q.loadedOn != today {
+		avg, err := q.spreads.GetAvgSpreads(ctx, time.Now(), q.lookbackDays)
+		if err != nil {
+			q.logger.WithError(err).Warn("Failed to load average spreads for quotes")
+			return 0, false
+		}
+		q.avg = avg
+		q.loadedOn = today
+	}
+
+	spread, ok := q.avg[code]
+	return spread, ok && spread > 0
+}
+
+// KRXTickSize returns the KRX tick size for a price (2023-01 개편 후 KOSPI/KOSDAQ 공통)
+// ⭐ SSOT: 호가단위 표는 여기서만
+func KRXTickSize(price int64) int64 {
+	switch {
+	case price < 2_000:
+		return 1
+	case price < 5_000:
+		return 5
+	case price < 20_000:
+		return 10
+	case price < 50_000:
+		return 50
+	case price < 200_000:
+		return 100
+	case price < 500_000:
+		return 500
+	default:
+		return 1_000
+	}
+}
+
+// FloorToTick rounds a price down to a valid KRX tick
+func FloorToTick(price float64) int64 {
+	p := int64(math.Floor(price))
+	if p <= 0 {
+		return p
+	}
+	return p - p%KRXTickSize(p)
+}
+
+// CeilToTick rounds a price up to a valid KRX tick
+// 구간 경계는 상위 구간 호가단위의 배수이므로 올림 결과도 유효 호가
+func CeilToTick(price float64) int64 {
+	p := int64(math.Ceil(price))
+	if p <= 0 {
+		return p
+	}
+	if r := p % KRXTickSize(p); r != 0 {
+		p += KRXTickSize(p) - r
+	}
+	return p
+}
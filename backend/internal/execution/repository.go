@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,8 +27,9 @@ func (r *Repository) SaveOrder(ctx context.Context, order *contracts.Order) erro
 	query := `
 		INSERT INTO execution.orders (
 			order_id, stock_code, stock_name, order_date, order_action, order_type,
-			order_price, order_qty, status, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			order_price, order_qty, status, exit_reason, strategy_version, created_at, updated_at,
+			account_id, strategy_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), NULLIF($11, ''), $12, $13, $14, $15)
 		ON CONFLICT (order_id) DO UPDATE SET
 			status = EXCLUDED.status,
 			updated_at = EXCLUDED.updated_at
@@ -36,7 +38,8 @@ func (r *Repository) SaveOrder(ctx context.Context, order *contracts.Order) erro
 	_, err := r.pool.Exec(ctx, query,
 		order.ID, order.Code, order.Name, order.CreatedAt.Truncate(24*time.Hour),
 		order.Side, order.OrderType, order.Price, order.Qty,
-		order.Status, order.CreatedAt, order.UpdatedAt,
+		order.Status, string(order.ExitReason), order.StrategyVersion, order.CreatedAt, order.UpdatedAt,
+		order.Account(), order.StrategyID,
 	)
 
 	if err != nil {
@@ -66,7 +69,8 @@ func (r *Repository) UpdateOrderStatus(ctx context.Context, orderID string, stat
 func (r *Repository) GetOrder(ctx context.Context, orderID string) (*contracts.Order, error) {
 	query := `
 		SELECT order_id, stock_code, stock_name, order_action, order_qty, order_price,
-		       order_type, status, created_at, updated_at
+		       order_type, status, COALESCE(exit_reason, ''), COALESCE(strategy_version, ''),
+		       created_at, updated_at, account_id, strategy_id
 		FROM execution.orders
 		WHERE order_id = $1
 	`
@@ -74,7 +78,8 @@ func (r *Repository) GetOrder(ctx context.Context, orderID string) (*contracts.O
 	var order contracts.Order
 	err := r.pool.QueryRow(ctx, query, orderID).Scan(
 		&order.ID, &order.Code, &order.Name, &order.Side, &order.Qty, &order.Price,
-		&order.OrderType, &order.Status, &order.CreatedAt, &order.UpdatedAt,
+		&order.OrderType, &order.Status, &order.ExitReason, &order.StrategyVersion,
+		&order.CreatedAt, &order.UpdatedAt, &order.AccountID, &order.StrategyID,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -91,7 +96,8 @@ func (r *Repository) GetOrder(ctx context.Context, orderID string) (*contracts.O
 func (r *Repository) GetOrdersByDate(ctx context.Context, date time.Time) ([]contracts.Order, error) {
 	query := `
 		SELECT order_id, stock_code, stock_name, order_action, order_qty, order_price,
-		       order_type, status, created_at, updated_at
+		       order_type, status, COALESCE(exit_reason, ''), COALESCE(strategy_version, ''),
+		       created_at, updated_at, account_id, strategy_id
 		FROM execution.orders
 		WHERE order_date = $1
 		ORDER BY created_at ASC
@@ -109,7 +115,8 @@ func (r *Repository) GetOrdersByDate(ctx context.Context, date time.Time) ([]con
 		var order contracts.Order
 		err := rows.Scan(
 			&order.ID, &order.Code, &order.Name, &order.Side, &order.Qty, &order.Price,
-			&order.OrderType, &order.Status, &order.CreatedAt, &order.UpdatedAt,
+			&order.OrderType, &order.Status, &order.ExitReason, &order.StrategyVersion,
+			&order.CreatedAt, &order.UpdatedAt, &order.AccountID, &order.StrategyID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan order: %w", err)
@@ -125,20 +132,29 @@ func (r *Repository) GetOrdersByDate(ctx context.Context, date time.Time) ([]con
 }
 
 // SaveExecution saves an execution record
+// 체결은 execution.trades에 기록 (실현손익 원장의 입력), 종목/매매구분은 주문에서 가져옴
 func (r *Repository) SaveExecution(ctx context.Context, exec *Execution) error {
 	query := `
-		INSERT INTO execution.executions (
-			order_id, exec_qty, exec_price, exec_time, fee
-		) VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO execution.trades (
+			order_id, stock_code, trade_date, trade_time, trade_action,
+			trade_price, trade_qty, trade_amount, commission, tax
+		)
+		SELECT o.id, o.stock_code, $4::timestamptz::date, $4::timestamptz, o.order_action,
+		       $3::numeric, $2::int, ROUND($2::int * $3::numeric), ROUND($5::numeric), ROUND($6::numeric)
+		FROM execution.orders o
+		WHERE o.order_id = $1
 	`
 
-	_, err := r.pool.Exec(ctx, query,
-		exec.OrderID, exec.ExecQty, exec.ExecPrice, exec.ExecTime, exec.Fee,
+	tag, err := r.pool.Exec(ctx, query,
+		exec.OrderID, exec.ExecQty, exec.ExecPrice, exec.ExecTime, exec.Fee, exec.Tax,
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to save execution: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to save execution: order not found: %s", exec.OrderID)
+	}
 
 	return nil
 }
@@ -146,10 +162,12 @@ func (r *Repository) SaveExecution(ctx context.Context, exec *Execution) error {
 // GetExecutionsByOrderID retrieves executions for an order
 func (r *Repository) GetExecutionsByOrderID(ctx context.Context, orderID string) ([]Execution, error) {
 	query := `
-		SELECT id, order_id, exec_qty, exec_price, exec_time, fee, created_at
-		FROM execution.executions
-		WHERE order_id = $1
-		ORDER BY exec_time ASC
+		SELECT t.id, o.order_id, t.trade_qty, t.trade_price, t.trade_time,
+		       COALESCE(t.commission, 0), COALESCE(t.tax, 0), t.created_at
+		FROM execution.trades t
+		JOIN execution.orders o ON o.id = t.order_id
+		WHERE o.order_id = $1
+		ORDER BY t.trade_time ASC, t.id ASC
 	`
 
 	rows, err := r.pool.Query(ctx, query, orderID)
@@ -164,7 +182,7 @@ func (r *Repository) GetExecutionsByOrderID(ctx context.Context, orderID string)
 		var exec Execution
 		err := rows.Scan(
 			&exec.ID, &exec.OrderID, &exec.ExecQty, &exec.ExecPrice,
-			&exec.ExecTime, &exec.Fee, &exec.CreatedAt,
+			&exec.ExecTime, &exec.Fee, &exec.Tax, &exec.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan execution: %w", err)
@@ -210,7 +228,8 @@ type Execution struct {
 	ExecQty   int
 	ExecPrice float64
 	ExecTime  time.Time
-	Fee       float64
+	Fee       float64 // 매매 수수료
+	Tax       float64 // 거래세 (매도)
 	CreatedAt time.Time
 }
 
@@ -257,13 +276,20 @@ func (r *Repository) SaveGateEvent(ctx context.Context, event *GateEvent) error
 	query := `
 		INSERT INTO execution.risk_gate_events (
 			run_id, mode, passed, would_block,
-			violation_count, var_95, var_99, message, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			violation_count, var_95, var_99, message, created_at,
+			account_id, strategy_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
+	accountID := event.AccountID
+	if accountID == "" {
+		accountID = contracts.DefaultAccountID
+	}
+
 	_, err := r.pool.Exec(ctx, query,
 		event.RunID, event.Mode, event.Passed, event.WouldBlock,
 		event.ViolationCount, event.VaR95, event.VaR99, event.Message, event.CreatedAt,
+		accountID, event.StrategyID,
 	)
 
 	if err != nil {
@@ -277,7 +303,8 @@ func (r *Repository) SaveGateEvent(ctx context.Context, event *GateEvent) error
 func (r *Repository) GetGateEventsByDate(ctx context.Context, date time.Time) ([]GateEvent, error) {
 	query := `
 		SELECT id, run_id, mode, passed, would_block,
-		       violation_count, var_95, var_99, message, created_at
+		       violation_count, var_95, var_99, message, created_at,
+		       account_id, strategy_id
 		FROM execution.risk_gate_events
 		WHERE DATE(created_at) = $1
 		ORDER BY created_at DESC
@@ -296,6 +323,7 @@ func (r *Repository) GetGateEventsByDate(ctx context.Context, date time.Time) ([
 		err := rows.Scan(
 			&event.ID, &event.RunID, &mode, &event.Passed, &event.WouldBlock,
 			&event.ViolationCount, &event.VaR95, &event.VaR99, &event.Message, &event.CreatedAt,
+			&event.AccountID, &event.StrategyID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan gate event: %w", err)
@@ -349,3 +377,243 @@ type ShadowBlockStats struct {
 	AvgVaR95        float64
 	MaxVaR95        float64
 }
+
+// =============================================================================
+// Pre-trade Checks & Kill Switch
+// =============================================================================
+
+// KillSwitchEvent 킬 스위치 변경 이력
+type KillSwitchEvent struct {
+	ID              int64     `json:"id"`
+	Active          bool      `json:"active"`
+	Reason          string    `json:"reason,omitempty"`
+	ChangedBy       string    `json:"changed_by"`
+	CancelOpen      bool      `json:"cancel_open"`
+	CancelledOrders int       `json:"cancelled_orders"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// PreTradeCheckRecord 기록된 주문 검사 결과
+type PreTradeCheckRecord struct {
+	ID         int64               `json:"id"`
+	CheckedAt  time.Time           `json:"checked_at"`
+	Source     PreTradeSource      `json:"source"`
+	Actor      string              `json:"actor,omitempty"`
+	AccountID  string              `json:"account_id"`
+	StrategyID string              `json:"strategy_id,omitempty"`
+	Code       string              `json:"stock_code"`
+	Side       contracts.OrderSide `json:"side"`
+	OrderType  contracts.OrderType `json:"order_type"`
+	Qty        int                 `json:"qty"`
+	Price      int64               `json:"price"`
+	Notional   int64               `json:"notional"`
+	Passed     bool                `json:"passed"`
+	Violations []PreTradeViolation `json:"violations"`
+}
+
+// GetKillSwitch 킬 스위치 상태 조회
+func (r *Repository) GetKillSwitch(ctx context.Context) (*KillSwitch, error) {
+	var ks KillSwitch
+	var reason, updatedBy *string
+	err := r.pool.QueryRow(ctx, `
+		SELECT active, reason, updated_by, updated_at
+		FROM execution.kill_switch
+		WHERE id = 1
+	`).Scan(&ks.Active, &reason, &updatedBy, &ks.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return &KillSwitch{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kill switch: %w", err)
+	}
+	if reason != nil {
+		ks.Reason = *reason
+	}
+	if updatedBy != nil {
+		ks.UpdatedBy = *updatedBy
+	}
+	return &ks, nil
+}
+
+// SetKillSwitch 킬 스위치 상태 변경 + 이력 기록, 이력 ID 반환
+func (r *Repository) SetKillSwitch(ctx context.Context, active bool, reason, changedBy string, cancelOpen bool) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO execution.kill_switch (id, active, reason, updated_by, updated_at)
+		VALUES (1, $1, NULLIF($2, ''), $3, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			active = EXCLUDED.active,
+			reason = EXCLUDED.reason,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+	`, active, reason, changedBy)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update kill switch: %w", err)
+	}
+
+	var eventID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO execution.kill_switch_events (active, reason, changed_by, cancel_open)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+		RETURNING id
+	`, active, reason, changedBy, cancelOpen).Scan(&eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save kill switch event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit kill switch: %w", err)
+	}
+	return eventID, nil
+}
+
+// RecordKillSwitchCancellations 발동 시 취소한 미체결 주문 수 기록
+func (r *Repository) RecordKillSwitchCancellations(ctx context.Context, eventID int64, cancelled int) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE execution.kill_switch_events SET cancelled_orders = $2 WHERE id = $1
+	`, eventID, cancelled)
+	if err != nil {
+		return fmt.Errorf("failed to record kill switch cancellations: %w", err)
+	}
+	return nil
+}
+
+// ListKillSwitchEvents 킬 스위치 변경 이력 조회 (최신순)
+func (r *Repository) ListKillSwitchEvents(ctx context.Context, limit int) ([]KillSwitchEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, active, COALESCE(reason, ''), changed_by, cancel_open, cancelled_orders, created_at
+		FROM execution.kill_switch_events
+		ORDER BY id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query kill switch events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]KillSwitchEvent, 0)
+	for rows.Next() {
+		var e KillSwitchEvent
+		if err := rows.Scan(&e.ID, &e.Active, &e.Reason, &e.ChangedBy, &e.CancelOpen, &e.CancelledOrders, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan kill switch event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// GetMarketReference 전일 종가와 ADTV20 조회 (before 당일 제외, 수집 원본 기준)
+func (r *Repository) GetMarketReference(ctx context.Context, code string, before time.Time) (*MarketReference, error) {
+	var ref MarketReference
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			COALESCE((ARRAY_AGG(close_price ORDER BY trade_date DESC))[1], 0)::bigint,
+			COALESCE(AVG(trading_value), 0)::bigint,
+			COUNT(*)
+		FROM (
+			SELECT trade_date, close_price, trading_value
+			FROM data.daily_prices
+			WHERE stock_code = $1 AND trade_date < $2
+			ORDER BY trade_date DESC
+			LIMIT 20
+		) recent
+	`, code, startOfDay(before)).Scan(&ref.PrevClose, &ref.ADTV20, &ref.Days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market reference: %w", err)
+	}
+	return &ref, nil
+}
+
+// SumPassedNotional since 이후 계좌에서 통과한 주문금액 합계 (계좌 내 전략 합산)
+func (r *Repository) SumPassedNotional(ctx context.Context, accountID string, side contracts.OrderSide, since time.Time) (int64, error) {
+	var total int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(notional), 0)::bigint
+		FROM execution.pretrade_checks
+		WHERE passed AND side = $1 AND checked_at >= $2 AND account_id = $3
+	`, string(side), since, accountID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum passed notional: %w", err)
+	}
+	return total, nil
+}
+
+// HasRecentDuplicate since 이후 같은 계좌/전략/종목/방향/수량/가격으로 통과한 주문 존재 여부
+// (다른 전략이 같은 주문을 내는 것은 중복이 아님)
+func (r *Repository) HasRecentDuplicate(ctx context.Context, order contracts.Order, since time.Time) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM execution.pretrade_checks
+			WHERE passed AND stock_code = $1 AND side = $2
+			  AND qty = $3 AND price = $4 AND checked_at >= $5
+			  AND account_id = $6 AND strategy_id = $7
+		)
+	`, order.Code, string(order.Side), order.Qty, int64(order.Price), since,
+		order.Account(), order.StrategyID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check duplicate order: %w", err)
+	}
+	return exists, nil
+}
+
+// SavePreTradeCheck 주문 검사 결과 저장
+func (r *Repository) SavePreTradeCheck(ctx context.Context, o *PreTradeOrder, result *PreTradeResult) error {
+	violations, err := json.Marshal(result.Violations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal violations: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO execution.pretrade_checks (
+			checked_at, source, actor, stock_code, side, order_type,
+			qty, price, notional, passed, violations, account_id, strategy_id
+		) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, result.CheckedAt, string(o.Source), o.Actor, o.Order.Code, string(o.Order.Side), string(o.Order.OrderType),
+		o.Order.Qty, int64(o.Order.Price), result.Notional, result.Passed, violations,
+		o.Order.Account(), o.Order.StrategyID)
+	if err != nil {
+		return fmt.Errorf("failed to save pre-trade check: %w", err)
+	}
+	return nil
+}
+
+// ListPreTradeChecks 최근 주문 검사 결과 조회 (최신순, blockedOnly = 차단된 주문만)
+func (r *Repository) ListPreTradeChecks(ctx context.Context, blockedOnly bool, limit int) ([]PreTradeCheckRecord, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, checked_at, source, COALESCE(actor, ''), stock_code, side, order_type,
+		       qty, price, notional, passed, violations, account_id, strategy_id
+		FROM execution.pretrade_checks
+		WHERE NOT $1 OR NOT passed
+		ORDER BY id DESC
+		LIMIT $2
+	`, blockedOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pre-trade checks: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]PreTradeCheckRecord, 0)
+	for rows.Next() {
+		var rec PreTradeCheckRecord
+		var source, side, orderType string
+		var violations []byte
+		if err := rows.Scan(&rec.ID, &rec.CheckedAt, &source, &rec.Actor, &rec.Code, &side, &orderType,
+			&rec.Qty, &rec.Price, &rec.Notional, &rec.Passed, &violations, &rec.AccountID, &rec.StrategyID); err != nil {
+			return nil, fmt.Errorf("failed to scan pre-trade check: %w", err)
+		}
+		rec.Source = PreTradeSource(source)
+		rec.Side = contracts.OrderSide(side)
+		rec.OrderType = contracts.OrderType(orderType)
+		if err := json.Unmarshal(violations, &rec.Violations); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal violations: %w", err)
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
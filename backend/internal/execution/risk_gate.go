@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/notify"
 	"github.com/wonny/aegis/v13/backend/internal/risk"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // =============================================================================
@@ -32,6 +34,7 @@ type RiskGate struct {
 	logger     *logger.Logger
 	mode       GateMode
 	runID      string
+	notifier   notify.Emitter // 차단 알림 (nil이면 생략)
 }
 
 // PriceRepository 가격 조회 인터페이스 (의존성 역전)
@@ -71,6 +74,11 @@ func NewRiskGate(
 	}
 }
 
+// SetNotifier 차단 알림 설정 (enforce 차단, shadow 차단 예상)
+func (g *RiskGate) SetNotifier(notifier notify.Emitter) {
+	g.notifier = notifier
+}
+
 // =============================================================================
 // Gate Check
 // =============================================================================
@@ -80,6 +88,8 @@ type GateCheckInput struct {
 	Orders          []contracts.Order
 	CurrentHoldings []risk.Holding
 	TargetHoldings  []risk.Holding
+	AccountID       string // 검사 대상 계좌 (빈 값 = default)
+	StrategyID      string // 검사 대상 전략 (빈 값 = 계좌 전체)
 }
 
 // GateAction 게이트 조치 유형
@@ -103,6 +113,8 @@ type GateCheckResult struct {
 	Message       string                `json:"message"`
 	CheckedAt     time.Time             `json:"checked_at"`
 	RunID         string                `json:"run_id"`
+	AccountID     string                `json:"account_id"`
+	StrategyID    string                `json:"strategy_id,omitempty"`
 }
 
 // AdjustedOrder 축소된 주문 정보
@@ -116,9 +128,14 @@ type AdjustedOrder struct {
 // Check 주문 전 리스크 체크 실행
 func (g *RiskGate) Check(ctx context.Context, input GateCheckInput) (*GateCheckResult, error) {
 	result := &GateCheckResult{
-		Mode:      g.mode,
-		CheckedAt: time.Now(),
-		RunID:     g.runID,
+		Mode:       g.mode,
+		CheckedAt:  time.Now(),
+		RunID:      g.runID,
+		AccountID:  input.AccountID,
+		StrategyID: input.StrategyID,
+	}
+	if result.AccountID == "" {
+		result.AccountID = contracts.DefaultAccountID
 	}
 
 	// 게이트가 꺼져있으면 통과
@@ -142,6 +159,7 @@ func (g *RiskGate) Check(ctx context.Context, input GateCheckInput) (*GateCheckR
 		}).Warn("Failed to get historical returns, passing gate")
 		result.Passed = true
 		result.Message = "Historical data unavailable, gate passed"
+		metrics.ObserveRiskGate(string(result.Mode), "data_unavailable", false)
 		return result, nil
 	}
 
@@ -206,6 +224,9 @@ func (g *RiskGate) Check(ctx context.Context, input GateCheckInput) (*GateCheckR
 		}
 	}
 
+	metrics.ObserveRiskGate(string(result.Mode), string(result.Action), result.WouldBlock)
+	g.notifyBlock(ctx, result)
+
 	// 5. 결과 저장 (Shadow 모드용 분석)
 	if err := g.saveGateResult(ctx, result); err != nil {
 		g.logger.WithFields(map[string]interface{}{
@@ -246,6 +267,24 @@ func (g *RiskGate) logShadowBlock(ctx context.Context, result *GateCheckResult,
 	g.logger.WithFields(fields).Warn("🚨 SHADOW BLOCK: Would have blocked orders")
 }
 
+// notifyBlock Enforce 차단(critical) 또는 Shadow 차단 예상(warning) 알림
+// 알림 실패는 게이트 결과에 영향 없음
+func (g *RiskGate) notifyBlock(ctx context.Context, result *GateCheckResult) {
+	if g.notifier == nil {
+		return
+	}
+
+	enforced := result.Action == GateActionBlock
+	if !enforced && !(result.Mode == GateModeShadow && result.WouldBlock) {
+		return
+	}
+
+	event := notify.RiskGateBlockedEvent(g.runID, string(result.Mode), result.Message, result.BlockedOrders, enforced)
+	if err := g.notifier.Notify(ctx, event); err != nil {
+		g.logger.WithError(err).WithField("run_id", g.runID).Warn("Failed to queue risk gate notification")
+	}
+}
+
 // =============================================================================
 // Helper Methods
 // =============================================================================
@@ -437,6 +476,8 @@ func (g *RiskGate) logEnforceReduce(ctx context.Context, result *GateCheckResult
 type GateEvent struct {
 	ID            int64     `json:"id"`
 	RunID         string    `json:"run_id"`
+	AccountID     string    `json:"account_id"`
+	StrategyID    string    `json:"strategy_id,omitempty"`
 	Mode          GateMode  `json:"mode"`
 	Passed        bool      `json:"passed"`
 	WouldBlock    bool      `json:"would_block"`
@@ -455,6 +496,8 @@ func (g *RiskGate) saveGateResult(ctx context.Context, result *GateCheckResult)
 
 	event := GateEvent{
 		RunID:      result.RunID,
+		AccountID:  result.AccountID,
+		StrategyID: result.StrategyID,
 		Mode:       result.Mode,
 		Passed:     result.Passed,
 		WouldBlock: result.WouldBlock,
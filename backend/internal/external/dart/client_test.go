@@ -1,6 +1,8 @@
 package dart
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
 	"testing"
 )
@@ -128,3 +130,99 @@ func TestIsRetryableError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReportDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"2024년 03월 15일", "2024-03-15", false},
+		{"2024-03-15", "2024-03-15", false},
+		{"20240315", "2024-03-15", false},
+		{"-", "", true},
+		{"2024년 3월", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseReportDate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReportDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Format("2006-01-02") != tt.want {
+				t.Errorf("ParseReportDate(%q) = %s, want %s", tt.input, got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReportNumber(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"1,234,567", 1234567, false},
+		{" 0.5 ", 0.5, false},
+		{"-", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseReportNumber(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReportNumber(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReportNumber(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCorpCodeZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("CORPCODE.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<result>
+  <list><corp_code>00126380</corp_code><corp_name>삼성전자</corp_name><stock_code>005930</stock_code></list>
+  <list><corp_code>00999999</corp_code><corp_name>비상장</corp_name><stock_code> </stock_code></list>
+</result>`)
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	codes, err := parseCorpCodeZip(buf.Bytes())
+	if err != nil {
+		t.Fatalf("parseCorpCodeZip() error = %v", err)
+	}
+	if len(codes) != 1 {
+		t.Fatalf("parseCorpCodeZip() returned %d codes, want 1 (listed only)", len(codes))
+	}
+	if codes[0].CorpCode != "00126380" || codes[0].StockCode != "005930" {
+		t.Errorf("parseCorpCodeZip() = %+v", codes[0])
+	}
+
+	if _, err := parseCorpCodeZip([]byte("<result><status>020</status></result>")); err == nil {
+		t.Error("parseCorpCodeZip() expected error for non-zip body")
+	}
+}
+
+func TestReceiptDate(t *testing.T) {
+	got, err := ReceiptDate("20240515000123")
+	if err != nil {
+		t.Fatalf("ReceiptDate() error = %v", err)
+	}
+	if got.Format("2006-01-02") != "2024-05-15" {
+		t.Errorf("ReceiptDate() = %v, want 2024-05-15", got)
+	}
+	if _, err := ReceiptDate("2024"); err == nil {
+		t.Error("ReceiptDate() expected error for short receipt number")
+	}
+}
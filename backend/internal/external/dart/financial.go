@@ -0,0 +1,198 @@
+package dart
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ReportCode identifies the periodic report (reprt_code)
+type ReportCode string
+
+const (
+	ReportQ1     ReportCode = "11013" // 1분기보고서
+	ReportHalf   ReportCode = "11012" // 반기보고서
+	ReportQ3     ReportCode = "11014" // 3분기보고서
+	ReportAnnual ReportCode = "11011" // 사업보고서
+)
+
+// Quarter returns the fiscal quarter the report closes (1~4)
+func (r ReportCode) Quarter() int {
+	switch r {
+	case ReportQ1:
+		return 1
+	case ReportHalf:
+		return 2
+	case ReportQ3:
+		return 3
+	case ReportAnnual:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// ReportCodeForQuarter returns the report code closing the given quarter
+func ReportCodeForQuarter(quarter int) ReportCode {
+	switch quarter {
+	case 1:
+		return ReportQ1
+	case 2:
+		return ReportHalf
+	case 3:
+		return ReportQ3
+	default:
+		return ReportAnnual
+	}
+}
+
+// FSDiv selects consolidated or separate statements (fs_div)
+type FSDiv string
+
+const (
+	FSConsolidated FSDiv = "CFS" // 연결재무제표
+	FSSeparate     FSDiv = "OFS" // 별도재무제표
+)
+
+// FinancialAccount is one account line of fnlttSinglAcntAll
+type FinancialAccount struct {
+	RceptNo         string `json:"rcept_no"`
+	ReprtCode       string `json:"reprt_code"`
+	BsnsYear        string `json:"bsns_year"`
+	CorpCode        string `json:"corp_code"`
+	SjDiv           string `json:"sj_div"`     // BS, IS, CIS, CF, SCE
+	AccountID       string `json:"account_id"` // ifrs-full_Revenue 등
+	AccountNm       string `json:"account_nm"`
+	ThstrmNm        string `json:"thstrm_nm"`
+	ThstrmAmount    string `json:"thstrm_amount"`     // 당기 금액 (분기/반기 IS는 3개월)
+	ThstrmAddAmount string `json:"thstrm_add_amount"` // 당기 누적 금액 (분기/반기 IS)
+	Currency        string `json:"currency"`
+}
+
+type financialResponse struct {
+	Status  string             `json:"status"`
+	Message string             `json:"message"`
+	List    []FinancialAccount `json:"list"`
+}
+
+// FetchFinancialStatements fetches the full statement of one periodic report
+// 자료가 없으면 (status 013) nil 반환
+func (c *Client) FetchFinancialStatements(ctx context.Context, corpCode string, year int, report ReportCode, fsDiv FSDiv) ([]FinancialAccount, error) {
+	url := fmt.Sprintf(
+		"%s/api/fnlttSinglAcntAll.json?crtfc_key=%s&corp_code=%s&bsns_year=%d&reprt_code=%s&fs_div=%s",
+		c.baseURL, c.apiKey, corpCode, year, report, fsDiv,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result financialResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if result.Status != "000" {
+		if result.Status == "013" {
+			return nil, nil // No data
+		}
+		return nil, fmt.Errorf("API error: %s - %s", result.Status, result.Message)
+	}
+
+	return result.List, nil
+}
+
+// CorpCode maps a DART corp_code to a listed stock code
+type CorpCode struct {
+	CorpCode  string `xml:"corp_code"`
+	CorpName  string `xml:"corp_name"`
+	StockCode string `xml:"stock_code"`
+}
+
+// FetchCorpCodes downloads the DART corp_code master (상장사만 반환)
+func (c *Client) FetchCorpCodes(ctx context.Context) ([]CorpCode, error) {
+	url := fmt.Sprintf("%s/api/corpCode.xml?crtfc_key=%s", c.baseURL, c.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	return parseCorpCodeZip(body)
+}
+
+// parseCorpCodeZip extracts listed companies from the CORPCODE.xml zip
+func parseCorpCodeZip(body []byte) ([]CorpCode, error) {
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		// 오류 시 DART는 zip 대신 XML 상태 메시지를 반환
+		return nil, fmt.Errorf("open corp code archive: %w", err)
+	}
+	if len(zr.File) == 0 {
+		return nil, fmt.Errorf("empty corp code archive")
+	}
+
+	f, err := zr.File[0].Open()
+	if err != nil {
+		return nil, fmt.Errorf("open corp code file: %w", err)
+	}
+	defer f.Close()
+
+	var doc struct {
+		List []CorpCode `xml:"list"`
+	}
+	if err := xml.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode corp codes: %w", err)
+	}
+
+	listed := make([]CorpCode, 0, len(doc.List))
+	for _, cc := range doc.List {
+		cc.StockCode = strings.TrimSpace(cc.StockCode)
+		if cc.StockCode == "" {
+			continue
+		}
+		listed = append(listed, cc)
+	}
+	return listed, nil
+}
+
+// ReceiptDate returns the filing date embedded in a receipt number (YYYYMMDD + serial)
+func ReceiptDate(rceptNo string) (time.Time, error) {
+	if len(rceptNo) < 8 {
+		return time.Time{}, fmt.Errorf("invalid receipt number: %q", rceptNo)
+	}
+	return time.Parse("20060102", rceptNo[:8])
+}
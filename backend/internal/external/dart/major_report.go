@@ -0,0 +1,89 @@
+package dart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BonusIssueDecision represents a 무상증자결정 major-matter report (DS005 fricDecsn)
+type BonusIssueDecision struct {
+	RceptNo          string `json:"rcept_no"`
+	CorpCode         string `json:"corp_code"`
+	CorpName         string `json:"corp_name"`
+	NewCommonShares  string `json:"nstk_ostk_cnt"`      // 신주 보통주식 수
+	RecordDate       string `json:"nstk_asstd"`         // 신주배정기준일
+	SharesPerCommon  string `json:"nstk_ascnt_ps_ostk"` // 1주당 신주배정 주식수 (보통주)
+	ListingDate      string `json:"nstk_lstprd"`        // 신주 상장예정일
+	BoardDecisionDay string `json:"bddd"`               // 이사회결의일
+}
+
+type bonusIssueResponse struct {
+	Status  string               `json:"status"`
+	Message string               `json:"message"`
+	List    []BonusIssueDecision `json:"list"`
+}
+
+// FetchBonusIssueDecisions fetches 무상증자결정 reports filed by corp_code within date range
+func (c *Client) FetchBonusIssueDecisions(ctx context.Context, corpCode string, from, to time.Time) ([]BonusIssueDecision, error) {
+	url := fmt.Sprintf(
+		"%s/api/fricDecsn.json?crtfc_key=%s&corp_code=%s&bgn_de=%s&end_de=%s",
+		c.baseURL, c.apiKey, corpCode, from.Format("20060102"), to.Format("20060102"),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result bonusIssueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if result.Status != "000" {
+		if result.Status == "013" {
+			return nil, nil // No data
+		}
+		return nil, fmt.Errorf("API error: %s - %s", result.Status, result.Message)
+	}
+
+	return result.List, nil
+}
+
+// ParseReportDate parses DART report dates ("2024년 03월 15일", "2024-03-15", "20240315")
+func ParseReportDate(s string) (time.Time, error) {
+	var digits strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	if digits.Len() != 8 {
+		return time.Time{}, fmt.Errorf("invalid report date: %q", s)
+	}
+	return time.Parse("20060102", digits.String())
+}
+
+// ParseReportNumber parses DART numeric fields ("1,234", "0.5", "-" → error)
+func ParseReportNumber(s string) (float64, error) {
+	cleaned := strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	if cleaned == "" || cleaned == "-" {
+		return 0, fmt.Errorf("empty number: %q", s)
+	}
+	return strconv.ParseFloat(cleaned, 64)
+}
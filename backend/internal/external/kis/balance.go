@@ -16,6 +16,10 @@ const (
 	TRIDBalanceReal = "TTTC8434R"
 	// 모의
 	TRIDBalanceVirtual = "VTTC8434R"
+
+	// 매수가능조회
+	TRIDBuyingPowerReal    = "TTTC8908R"
+	TRIDBuyingPowerVirtual = "VTTC8908R"
 )
 
 // GetBalance returns account balance and positions
@@ -38,7 +42,7 @@ func (c *Client) GetBalance(ctx context.Context) (*Balance, []Position, error) {
 	params := fmt.Sprintf("?CANO=%s&ACNT_PRDT_CD=%s&AFHR_FLPR_YN=N&OFL_YN=&INQR_DVSN=02&UNPR_DVSN=01&FUND_STTL_ICLD_YN=N&FNCG_AMT_AUTO_RDPT_YN=N&PRCS_DVSN=00&CTX_AREA_FK100=&CTX_AREA_NK100=",
 		cano, acntPrdtCd)
 
-	resp, err := c.request(ctx, http.MethodGet, path+params, trID, nil)
+	resp, err := c.request(ctx, ClassAccount, http.MethodGet, path+params, trID, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("balance request: %w", err)
 	}
@@ -111,6 +115,49 @@ func (c *Client) GetPositions(ctx context.Context) ([]Position, error) {
 	return positions, err
 }
 
+// GetBuyingPower returns the cash available for new buy orders (주문가능현금, 미체결 매수 반영)
+func (c *Client) GetBuyingPower(ctx context.Context) (int64, error) {
+	path := "/uapi/domestic-stock/v1/trading/inquire-psbl-order"
+
+	trID := TRIDBuyingPowerReal
+	if c.cfg.IsVirtual {
+		trID = TRIDBuyingPowerVirtual
+	}
+
+	accountNo := strings.ReplaceAll(c.cfg.AccountNo, "-", "")
+	if len(accountNo) < 10 {
+		return 0, fmt.Errorf("invalid account number format: %s", c.cfg.AccountNo)
+	}
+	cano := accountNo[:8]
+	acntPrdtCd := accountNo[8:10]
+
+	// 종목/단가 미지정 + 시장가(01): 계좌 전체 주문가능현금
+	params := fmt.Sprintf("?CANO=%s&ACNT_PRDT_CD=%s&PDNO=&ORD_UNPR=&ORD_DVSN=01&CMA_EVLU_AMT_ICLD_YN=N&OVRS_ICLD_YN=N",
+		cano, acntPrdtCd)
+
+	resp, err := c.request(ctx, ClassAccount, http.MethodGet, path+params, trID, nil)
+	if err != nil {
+		return 0, fmt.Errorf("buying power request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("buying power API error status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result buyingPowerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decode buying power response: %w", err)
+	}
+
+	if result.RtCd != "0" {
+		return 0, fmt.Errorf("buying power API error: %s - %s", result.MsgCd, result.Msg1)
+	}
+
+	return parseIntSafe(result.Output.OrdPsblCash), nil
+}
+
 // Helper functions
 func parseIntSafe(s string) int64 {
 	if s == "" {
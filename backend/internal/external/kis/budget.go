@@ -0,0 +1,94 @@
+package kis
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
+)
+
+// RequestClass is the priority class of a KIS REST request
+type RequestClass string
+
+const (
+	ClassOrder   RequestClass = "order"   // 주문/취소 (최우선, 전체 예산 사용)
+	ClassAccount RequestClass = "account" // 잔고/주문내역/매매가능 조회
+	ClassQuote   RequestClass = "quote"   // 시세 조회, 실시간 폴링
+)
+
+// Default request rates (KIS 한도: 실전 20건/초, 모의 5건/초 → 여유분 확보)
+const (
+	defaultRateLimitReal    = 18
+	defaultRateLimitVirtual = 4
+)
+
+// Budget apportions the per-second KIS request budget between request classes
+// ⭐ SSOT: KIS REST 요청 속도 제한은 이 Budget에서만
+// 예산은 프로세스 메모리에 있으므로 앱키 한도를 프로세스별 몫(KIS_RATE_<PROCESS>_PCT)으로 나눠 사용
+// 모든 요청은 전체 한도를 거치고, 계좌/시세 조회는 비율 상한을 추가로 거침
+// 상한 합계가 100% 미만이므로 조회가 몰려도 전체 한도에 여유가 남아 주문은 대기하지 않음
+type Budget struct {
+	total  *rate.Limiter
+	caps   map[RequestClass]*rate.Limiter
+	limits map[RequestClass]float64
+}
+
+// NewBudget creates a budget of perSecond requests (계좌/시세는 각각 accountPct/quotePct % 상한)
+func NewBudget(perSecond, accountPct, quotePct int) *Budget {
+	if perSecond <= 0 {
+		perSecond = defaultRateLimitReal
+	}
+
+	b := &Budget{
+		total:  rate.NewLimiter(rate.Limit(perSecond), perSecond),
+		caps:   make(map[RequestClass]*rate.Limiter),
+		limits: map[RequestClass]float64{ClassOrder: float64(perSecond)},
+	}
+	for class, pct := range map[RequestClass]int{ClassAccount: accountPct, ClassQuote: quotePct} {
+		limit := float64(perSecond) * float64(pct) / 100
+		b.caps[class] = rate.NewLimiter(rate.Limit(limit), max(int(limit), 1))
+		b.limits[class] = limit
+	}
+	return b
+}
+
+// newBudgetFromConfig applies the per-mode default rate and the process's share (processPct, 0 = 전체)
+func newBudgetFromConfig(perSecond, accountPct, quotePct, processPct int, virtual bool) *Budget {
+	if perSecond <= 0 {
+		perSecond = defaultRateLimitReal
+		if virtual {
+			perSecond = defaultRateLimitVirtual
+		}
+	}
+	if processPct > 0 && processPct < 100 {
+		perSecond = max(perSecond*processPct/100, 1)
+	}
+	return NewBudget(perSecond, accountPct, quotePct)
+}
+
+// Wait blocks until a request of the class may be sent
+func (b *Budget) Wait(ctx context.Context, class RequestClass) error {
+	start := time.Now()
+
+	var err error
+	if limiter, ok := b.caps[class]; ok {
+		err = limiter.Wait(ctx)
+	}
+	if err == nil {
+		err = b.total.Wait(ctx)
+	}
+
+	metrics.ObserveRateLimitWait("kis:"+string(class), time.Since(start), err)
+	return err
+}
+
+// Limits returns the requests per second available to each class
+func (b *Budget) Limits() map[RequestClass]float64 {
+	limits := make(map[RequestClass]float64, len(b.limits))
+	for class, limit := range b.limits {
+		limits[class] = limit
+	}
+	return limits
+}
@@ -7,7 +7,6 @@ import (
 	"io"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/wonny/aegis/v13/backend/pkg/config"
@@ -17,26 +16,34 @@ import (
 
 // Client handles communication with KIS (한국투자증권) API
 // ⭐ SSOT: KIS API 호출은 이 클라이언트에서만
+// 토큰과 요청 예산은 Session을 통해 관리 (SetSession으로 프로세스 공용 세션 공유)
 type Client struct {
 	httpClient *httputil.Client
 	logger     *logger.Logger
 	cfg        config.KISConfig
-
-	// Token management
-	accessToken string
-	tokenExpiry time.Time
-	tokenMu     sync.RWMutex
+	session    *Session
 }
 
-// NewClient creates a new KIS API client
+// NewClient creates a new KIS API client with a process-local session
 func NewClient(cfg config.KISConfig, httpClient *httputil.Client, log *logger.Logger) *Client {
 	return &Client{
 		httpClient: httpClient,
 		logger:     log,
 		cfg:        cfg,
+		session:    NewSession(cfg, nil, log),
 	}
 }
 
+// SetSession shares a session (토큰 저장소, 요청 예산) with other KIS clients
+func (c *Client) SetSession(s *Session) {
+	c.session = s
+}
+
+// Session returns the client's session
+func (c *Client) Session() *Session {
+	return c.session
+}
+
 // TokenResponse represents the OAuth token response
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
@@ -44,61 +51,16 @@ type TokenResponse struct {
 	ExpiresIn   int    `json:"expires_in"`
 }
 
-// getToken gets a valid access token, refreshing if necessary
-func (c *Client) getToken(ctx context.Context) (string, error) {
-	c.tokenMu.RLock()
-	if c.accessToken != "" && time.Now().Before(c.tokenExpiry) {
-		token := c.accessToken
-		c.tokenMu.RUnlock()
-		return token, nil
-	}
-	c.tokenMu.RUnlock()
-
-	// Need to refresh token
-	c.tokenMu.Lock()
-	defer c.tokenMu.Unlock()
-
-	// Double-check after acquiring write lock
-	if c.accessToken != "" && time.Now().Before(c.tokenExpiry) {
-		return c.accessToken, nil
-	}
-
-	// Request new token
-	url := fmt.Sprintf("%s/oauth2/tokenP", c.cfg.BaseURL)
-	body := fmt.Sprintf(`{"grant_type":"client_credentials","appkey":"%s","appsecret":"%s"}`,
-		c.cfg.AppKey, c.cfg.AppSecret)
-
-	resp, err := c.httpClient.Post(ctx, url, "application/json", strings.NewReader(body))
-	if err != nil {
-		return "", fmt.Errorf("token request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(respBody))
-	}
-
-	var tokenResp TokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", fmt.Errorf("failed to decode token response: %w", err)
-	}
-
-	c.accessToken = tokenResp.AccessToken
-	c.tokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn-60) * time.Second) // 1분 여유
-
-	c.logger.WithFields(map[string]interface{}{
-		"expires_in": tokenResp.ExpiresIn,
-	}).Info("KIS access token refreshed")
-
-	return c.accessToken, nil
+// request makes an authenticated request to KIS API within the class budget
+func (c *Client) request(ctx context.Context, class RequestClass, method, path string, trID string, body io.Reader) (*http.Response, error) {
+	return c.send(ctx, class, method, path, trID, nil, body)
 }
 
-// request makes an authenticated request to KIS API
-func (c *Client) request(ctx context.Context, method, path string, trID string, body io.Reader) (*http.Response, error) {
-	token, err := c.getToken(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get token: %w", err)
+// send waits for the class budget, authorizes and sends a KIS request
+// 401 응답이면 토큰을 무효화해 다음 요청에서 재발급
+func (c *Client) send(ctx context.Context, class RequestClass, method, path, trID string, headers map[string]string, body io.Reader) (*http.Response, error) {
+	if err := c.session.Wait(ctx, class); err != nil {
+		return nil, fmt.Errorf("rate limit wait: %w", err)
 	}
 
 	url := fmt.Sprintf("%s%s", c.cfg.BaseURL, path)
@@ -109,15 +71,27 @@ func (c *Client) request(ctx context.Context, method, path string, trID string,
 	}
 
 	// Set required headers
+	if err := c.session.Authorize(ctx, req); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("appkey", c.cfg.AppKey)
-	req.Header.Set("appsecret", c.cfg.AppSecret)
-	req.Header.Set("tr_id", trID)
+	if trID != "" {
+		req.Header.Set("tr_id", trID)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 
 	// Use underlying http client directly for custom headers
 	client := &http.Client{Timeout: 30 * time.Second}
-	return client.Do(req)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.session.Invalidate(KindAccessToken, strings.TrimPrefix(req.Header.Get("authorization"), "Bearer "))
+	}
+	return resp, nil
 }
 
 // StockPrice represents a stock price from KIS
@@ -135,7 +109,8 @@ type StockPrice struct {
 	FetchedAt  time.Time `json:"-"`
 }
 
-// GetDailyPrice gets daily price for a stock
+// GetDailyPrice gets the daily bar for a stock on a date
+// 최근 30영업일 응답에서 date 행을 선택 (zero date = 최신 행)
 func (c *Client) GetDailyPrice(ctx context.Context, stockCode string, date time.Time) (*StockPrice, error) {
 	path := "/uapi/domestic-stock/v1/quotations/inquire-daily-price"
 	trID := "FHKST01010400" // 국내주식 일별 시세
@@ -143,7 +118,7 @@ func (c *Client) GetDailyPrice(ctx context.Context, stockCode string, date time.
 	params := fmt.Sprintf("?fid_cond_mrkt_div_code=J&fid_input_iscd=%s&fid_period_div_code=D&fid_org_adj_prc=0",
 		stockCode)
 
-	resp, err := c.request(ctx, http.MethodGet, path+params, trID, nil)
+	resp, err := c.request(ctx, ClassQuote, http.MethodGet, path+params, trID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +149,19 @@ func (c *Client) GetDailyPrice(ctx context.Context, stockCode string, date time.
 	}
 
 	price := &result.Output[0]
+	if !date.IsZero() {
+		price = nil
+		want := date.Format("20060102")
+		for i := range result.Output {
+			if result.Output[i].TradeDate == want {
+				price = &result.Output[i]
+				break
+			}
+		}
+		if price == nil {
+			return nil, fmt.Errorf("no price data for %s on %s", stockCode, date.Format("2006-01-02"))
+		}
+	}
 	price.StockCode = stockCode
 	price.FetchedAt = time.Now()
 
@@ -187,7 +175,7 @@ func (c *Client) GetCurrentPrice(ctx context.Context, stockCode string) (*StockP
 
 	params := fmt.Sprintf("?fid_cond_mrkt_div_code=J&fid_input_iscd=%s", stockCode)
 
-	resp, err := c.request(ctx, http.MethodGet, path+params, trID, nil)
+	resp, err := c.request(ctx, ClassQuote, http.MethodGet, path+params, trID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,186 @@
+package kis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CredentialKind identifies a KIS credential
+type CredentialKind string
+
+const (
+	KindAccessToken CredentialKind = "access_token" // REST 접근토큰 (Bearer)
+	KindApprovalKey CredentialKind = "approval_key" // WebSocket 접속키
+)
+
+// Credential is an issued KIS credential with its daily issuance count
+type Credential struct {
+	Kind       CredentialKind
+	Value      string
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+	IssueDay   string // YYYY-MM-DD (KST), IssueCount 기준일
+	IssueCount int
+}
+
+// Valid reports whether the credential can still be used at t
+func (c Credential) Valid(t time.Time) bool {
+	return c.Value != "" && t.Before(c.ExpiresAt)
+}
+
+// CredentialStore persists credentials across restarts and processes
+// Update는 (앱키, 종류) 단위로 잠근 상태에서 fn을 호출하고, fn이 true를 반환하면 변경 내용을 저장
+type CredentialStore interface {
+	Update(ctx context.Context, appKeyHash string, kind CredentialKind, fn func(cred *Credential) (bool, error)) (Credential, error)
+}
+
+// AppKeyHash identifies an app key without storing it
+func AppKeyHash(appKey string) string {
+	sum := sha256.Sum256([]byte(appKey))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// memoryCredentialStore keeps credentials in process memory (DB 없이 실행하는 CLI/테스트용)
+type memoryCredentialStore struct {
+	mu    sync.Mutex
+	creds map[string]Credential
+}
+
+// NewMemoryCredentialStore creates a process-local credential store
+func NewMemoryCredentialStore() CredentialStore {
+	return &memoryCredentialStore{creds: make(map[string]Credential)}
+}
+
+func (m *memoryCredentialStore) Update(ctx context.Context, appKeyHash string, kind CredentialKind, fn func(cred *Credential) (bool, error)) (Credential, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := appKeyHash + ":" + string(kind)
+	cred, ok := m.creds[key]
+	if !ok {
+		cred = Credential{Kind: kind}
+	}
+
+	changed, err := fn(&cred)
+	if err != nil {
+		return cred, err
+	}
+	if changed {
+		m.creds[key] = cred
+	}
+	return cred, nil
+}
+
+// CredentialRepository stores credentials in ops.kis_credentials
+// 행 잠금 안에서 발급하므로 여러 프로세스(api, scheduler, worker)가 동시에 재발급하지 않음
+type CredentialRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCredentialRepository creates a new credential repository
+func NewCredentialRepository(pool *pgxpool.Pool) *CredentialRepository {
+	return &CredentialRepository{pool: pool}
+}
+
+// Update locks the credential row, applies fn and saves the result if changed
+// 발급 요청(최대 10초) 동안 트랜잭션을 유지함
+func (r *CredentialRepository) Update(ctx context.Context, appKeyHash string, kind CredentialKind, fn func(cred *Credential) (bool, error)) (Credential, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return Credential{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO ops.kis_credentials (app_key_hash, kind)
+		VALUES ($1, $2)
+		ON CONFLICT (app_key_hash, kind) DO NOTHING
+	`, appKeyHash, string(kind))
+	if err != nil {
+		return Credential{}, fmt.Errorf("insert %s row: %w", kind, err)
+	}
+
+	cred := Credential{Kind: kind}
+	var issuedAt, expiresAt *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT value, issued_at, expires_at, COALESCE(issue_day::text, ''), issue_count
+		FROM ops.kis_credentials
+		WHERE app_key_hash = $1 AND kind = $2
+		FOR UPDATE
+	`, appKeyHash, string(kind)).Scan(&cred.Value, &issuedAt, &expiresAt, &cred.IssueDay, &cred.IssueCount)
+	if err != nil {
+		return Credential{}, fmt.Errorf("lock %s: %w", kind, err)
+	}
+	if issuedAt != nil {
+		cred.IssuedAt = *issuedAt
+	}
+	if expiresAt != nil {
+		cred.ExpiresAt = *expiresAt
+	}
+
+	changed, err := fn(&cred)
+	if err != nil {
+		return cred, err
+	}
+	if !changed {
+		return cred, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE ops.kis_credentials SET
+			value = $3,
+			issued_at = $4,
+			expires_at = $5,
+			issue_day = NULLIF($6, '')::date,
+			issue_count = $7,
+			updated_at = NOW()
+		WHERE app_key_hash = $1 AND kind = $2
+	`, appKeyHash, string(kind), cred.Value, cred.IssuedAt, cred.ExpiresAt, cred.IssueDay, cred.IssueCount)
+	if err != nil {
+		return cred, fmt.Errorf("save %s: %w", kind, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return cred, fmt.Errorf("commit %s: %w", kind, err)
+	}
+	return cred, nil
+}
+
+// List returns the stored credentials of an app key (상태 조회용)
+func (r *CredentialRepository) List(ctx context.Context, appKeyHash string) ([]Credential, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT kind, value, issued_at, expires_at, COALESCE(issue_day::text, ''), issue_count
+		FROM ops.kis_credentials
+		WHERE app_key_hash = $1
+		ORDER BY kind
+	`, appKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("list credentials: %w", err)
+	}
+	defer rows.Close()
+
+	creds := make([]Credential, 0)
+	for rows.Next() {
+		var cred Credential
+		var kind string
+		var issuedAt, expiresAt *time.Time
+		if err := rows.Scan(&kind, &cred.Value, &issuedAt, &expiresAt, &cred.IssueDay, &cred.IssueCount); err != nil {
+			return nil, fmt.Errorf("scan credential: %w", err)
+		}
+		cred.Kind = CredentialKind(kind)
+		if issuedAt != nil {
+			cred.IssuedAt = *issuedAt
+		}
+		if expiresAt != nil {
+			cred.ExpiresAt = *expiresAt
+		}
+		creds = append(creds, cred)
+	}
+	return creds, rows.Err()
+}
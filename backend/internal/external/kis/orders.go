@@ -56,7 +56,7 @@ func (c *Client) GetOrders(ctx context.Context, startDate, endDate string) ([]Or
 	params := fmt.Sprintf("?CANO=%s&ACNT_PRDT_CD=%s&INQR_STRT_DT=%s&INQR_END_DT=%s&SLL_BUY_DVSN_CD=00&INQR_DVSN=00&PDNO=&CCLD_DVSN=00&ORD_GNO_BRNO=&ODNO=&INQR_DVSN_3=00&INQR_DVSN_1=&CTX_AREA_FK100=&CTX_AREA_NK100=",
 		cano, acntPrdtCd, startDate, endDate)
 
-	resp, err := c.request(ctx, http.MethodGet, path+params, trID, nil)
+	resp, err := c.request(ctx, ClassAccount, http.MethodGet, path+params, trID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("orders request: %w", err)
 	}
@@ -290,33 +290,44 @@ func (c *Client) CancelOrder(ctx context.Context, orderNo string) (*PlaceOrderRe
 	return orderResult, nil
 }
 
-// getHashkey generates hashkey for POST requests
-func (c *Client) getHashkey(ctx context.Context, body interface{}) (string, error) {
-	path := "/uapi/hashkey"
-
-	jsonBody, err := json.Marshal(body)
+// CancelUnfilledOrders cancels every pending/partially filled order (킬 스위치 발동 시)
+// 개별 취소 실패는 계속 진행하고 첫 에러를 반환
+func (c *Client) CancelUnfilledOrders(ctx context.Context) ([]string, error) {
+	orders, err := c.GetUnfilledOrders(ctx)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("get unfilled orders: %w", err)
 	}
 
-	token, err := c.getToken(ctx)
-	if err != nil {
-		return "", err
+	cancelled := make([]string, 0, len(orders))
+	var firstErr error
+	for _, o := range orders {
+		result, err := c.CancelOrder(ctx, o.OrderNo)
+		if err == nil && !result.Success {
+			err = fmt.Errorf("cancel rejected: %s", result.Message)
+		}
+		if err != nil {
+			c.logger.WithError(err).WithField("order_no", o.OrderNo).Error("Failed to cancel unfilled order")
+			if firstErr == nil {
+				firstErr = fmt.Errorf("cancel order %s: %w", o.OrderNo, err)
+			}
+			continue
+		}
+		cancelled = append(cancelled, o.OrderNo)
 	}
 
-	url := fmt.Sprintf("%s%s", c.cfg.BaseURL, path)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBody))
+	return cancelled, firstErr
+}
+
+// getHashkey generates hashkey for POST requests
+func (c *Client) getHashkey(ctx context.Context, body interface{}) (string, error) {
+	path := "/uapi/hashkey"
+
+	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return "", err
 	}
 
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("appkey", c.cfg.AppKey)
-	req.Header.Set("appsecret", c.cfg.AppSecret)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := c.send(ctx, ClassOrder, http.MethodPost, path, "", nil, bytes.NewReader(jsonBody))
 	if err != nil {
 		return "", err
 	}
@@ -332,26 +343,7 @@ func (c *Client) getHashkey(ctx context.Context, body interface{}) (string, erro
 
 // requestWithHashkey makes a POST request with hashkey header
 func (c *Client) requestWithHashkey(ctx context.Context, method, path, trID, hashkey string, body io.Reader) (*http.Response, error) {
-	token, err := c.getToken(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get token: %w", err)
-	}
-
-	url := fmt.Sprintf("%s%s", c.cfg.BaseURL, path)
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("appkey", c.cfg.AppKey)
-	req.Header.Set("appsecret", c.cfg.AppSecret)
-	req.Header.Set("tr_id", trID)
-	req.Header.Set("hashkey", hashkey)
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	return client.Do(req)
+	return c.send(ctx, ClassOrder, method, path, trID, map[string]string{"hashkey": hashkey}, body)
 }
 
 // Helper functions
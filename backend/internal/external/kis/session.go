@@ -0,0 +1,323 @@
+package kis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// Credential lifetimes
+const (
+	approvalKeyTTL         = 24 * time.Hour
+	sessionRefreshInterval = time.Minute
+	issueTimeout           = 10 * time.Second
+)
+
+// ErrIssueLimit is returned when the daily issuance limit is reached and no valid credential remains
+var ErrIssueLimit = errors.New("KIS credential daily issuance limit reached")
+
+var kstZone = time.FixedZone("KST", 9*60*60)
+
+// Session manages KIS credentials and the request budget shared by Client, WSClient and the REST poller
+// ⭐ SSOT: KIS 접근토큰/접속키 발급·갱신은 이 Session에서만
+// - 발급한 자격 증명은 CredentialStore에 저장 → 재시작/다른 프로세스에서 재사용 (일일 발급 한도 보호)
+// - 만료 전 여유 구간에 들어가면 재발급 (Run이 사용 중인 종류를 주기적으로 선제 갱신)
+type Session struct {
+	cfg        config.KISConfig
+	store      CredentialStore
+	budget     *Budget
+	httpClient *http.Client
+	logger     *logger.Logger
+	keyHash    string
+	now        func() time.Time
+
+	mu       sync.Mutex
+	creds    map[CredentialKind]Credential
+	rejected map[CredentialKind]string    // KIS가 거부한 값 (저장소에 남아 있어도 재사용하지 않음)
+	holdTill map[CredentialKind]time.Time // 갱신을 미룬 자격 증명 (발급 한도) 재시도 시각
+
+	issueMu sync.Mutex // 프로세스 내 동시 발급 방지 (프로세스 간은 store 잠금)
+}
+
+// NewSession creates a KIS session; store nil = 프로세스 메모리에만 보관
+func NewSession(cfg config.KISConfig, store CredentialStore, log *logger.Logger) *Session {
+	if store == nil {
+		store = NewMemoryCredentialStore()
+	}
+
+	return &Session{
+		cfg:        cfg,
+		store:      store,
+		budget:     newBudgetFromConfig(cfg.RateLimit, cfg.AccountSharePct, cfg.QuoteSharePct, cfg.ProcessSharePct, cfg.IsVirtual),
+		httpClient: &http.Client{Timeout: issueTimeout},
+		logger:     log.WithField("component", "kis_session"),
+		keyHash:    AppKeyHash(cfg.AppKey),
+		now:        time.Now,
+		creds:      make(map[CredentialKind]Credential),
+		rejected:   make(map[CredentialKind]string),
+		holdTill:   make(map[CredentialKind]time.Time),
+	}
+}
+
+// Budget returns the shared request budget
+func (s *Session) Budget() *Budget {
+	return s.budget
+}
+
+// Wait blocks until a request of the class may be sent
+func (s *Session) Wait(ctx context.Context, class RequestClass) error {
+	return s.budget.Wait(ctx, class)
+}
+
+// AccessToken returns a valid REST access token
+func (s *Session) AccessToken(ctx context.Context) (string, error) {
+	cred, err := s.credential(ctx, KindAccessToken, "")
+	if err != nil {
+		return "", err
+	}
+	return cred.Value, nil
+}
+
+// ApprovalKey returns a valid WebSocket approval key
+// stale: 거부된 접속키 (같은 값이면 아직 유효해도 재발급)
+func (s *Session) ApprovalKey(ctx context.Context, stale string) (Credential, error) {
+	return s.credential(ctx, KindApprovalKey, stale)
+}
+
+// Invalidate drops a credential rejected by KIS so the next call reissues it
+func (s *Session) Invalidate(kind CredentialKind, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value != "" {
+		s.rejected[kind] = value
+	}
+}
+
+// Authorize sets the KIS authentication headers on a REST request
+func (s *Session) Authorize(ctx context.Context, req *http.Request) error {
+	token, err := s.AccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	req.Header.Set("authorization", "Bearer "+token)
+	req.Header.Set("appkey", s.cfg.AppKey)
+	req.Header.Set("appsecret", s.cfg.AppSecret)
+	return nil
+}
+
+// Run refreshes credentials in use before they expire, until ctx is cancelled
+func (s *Session) Run(ctx context.Context) {
+	ticker := time.NewTicker(sessionRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshInUse(ctx)
+		}
+	}
+}
+
+// refreshInUse reissues credentials this process has used once they enter the refresh window
+func (s *Session) refreshInUse(ctx context.Context) {
+	s.mu.Lock()
+	kinds := make([]CredentialKind, 0, len(s.creds))
+	for kind, cred := range s.creds {
+		if !s.fresh(cred, s.rejected[kind]) {
+			kinds = append(kinds, kind)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, kind := range kinds {
+		if _, err := s.credential(ctx, kind, ""); err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).WithField("kind", kind).Warn("Failed to refresh KIS credential")
+		}
+	}
+}
+
+// fresh reports whether a credential is usable without reissuing
+func (s *Session) fresh(cred Credential, stale string) bool {
+	if cred.Value == "" || (stale != "" && cred.Value == stale) {
+		return false
+	}
+	return s.now().Before(cred.ExpiresAt.Add(-s.refreshBefore(cred.Kind)))
+}
+
+// refreshBefore is the refresh margin before expiry
+func (s *Session) refreshBefore(kind CredentialKind) time.Duration {
+	if kind == KindApprovalKey {
+		return approvalKeyTTL - ApprovalKeyRefresh
+	}
+	return s.cfg.TokenRefreshBefore
+}
+
+// credential returns a fresh credential from memory, the store, or a new issuance
+func (s *Session) credential(ctx context.Context, kind CredentialKind, stale string) (Credential, error) {
+	s.mu.Lock()
+	cached, ok := s.creds[kind]
+	if stale == "" {
+		stale = s.rejected[kind]
+	}
+	held := s.now().Before(s.holdTill[kind])
+	s.mu.Unlock()
+	if ok && (s.fresh(cached, stale) || (held && cached.Valid(s.now()))) {
+		return cached, nil
+	}
+
+	s.issueMu.Lock()
+	defer s.issueMu.Unlock()
+
+	// 대기 중 다른 고루틴이 갱신했으면 그대로 사용
+	s.mu.Lock()
+	cached, ok = s.creds[kind]
+	s.mu.Unlock()
+	if ok && s.fresh(cached, stale) {
+		return cached, nil
+	}
+
+	cred, err := s.store.Update(ctx, s.keyHash, kind, func(cred *Credential) (bool, error) {
+		return s.renew(ctx, cred, stale)
+	})
+	if err != nil {
+		return Credential{}, err
+	}
+
+	s.mu.Lock()
+	s.creds[kind] = cred
+	if cred.Value != stale {
+		delete(s.rejected, kind)
+	}
+	// 한도로 갱신을 미뤘으면 다음 갱신 주기까지 저장소를 다시 조회하지 않음
+	if !s.fresh(cred, stale) {
+		s.holdTill[kind] = s.now().Add(sessionRefreshInterval)
+	} else {
+		delete(s.holdTill, kind)
+	}
+	s.mu.Unlock()
+	return cred, nil
+}
+
+// renew reissues a locked credential unless it is still fresh (다른 프로세스가 이미 갱신한 경우)
+func (s *Session) renew(ctx context.Context, cred *Credential, stale string) (bool, error) {
+	if s.fresh(*cred, stale) {
+		return false, nil
+	}
+
+	now := s.now()
+	day := now.In(kstZone).Format("2006-01-02")
+	if cred.IssueDay != day {
+		cred.IssueDay = day
+		cred.IssueCount = 0
+	}
+
+	log := s.logger.WithFields(map[string]interface{}{
+		"kind":        cred.Kind,
+		"issue_count": cred.IssueCount,
+	})
+
+	// 한도 소진: 만료 전이면 (거부된 값이라도) 갱신을 미루고 기존 값 사용
+	if s.cfg.TokenDailyLimit > 0 && cred.IssueCount >= s.cfg.TokenDailyLimit {
+		if cred.Valid(now) {
+			log.Warn("KIS credential daily issuance limit reached, keeping current credential")
+			return false, nil
+		}
+		return false, fmt.Errorf("%w (%s, %d/day)", ErrIssueLimit, cred.Kind, s.cfg.TokenDailyLimit)
+	}
+
+	value, ttl, err := s.issue(ctx, cred.Kind)
+	if err != nil {
+		return false, err
+	}
+
+	cred.Value = value
+	cred.IssuedAt = now
+	cred.ExpiresAt = now.Add(ttl)
+	cred.IssueCount++
+
+	log.WithFields(map[string]interface{}{
+		"expires_at":  cred.ExpiresAt.Format(time.RFC3339),
+		"issue_count": cred.IssueCount,
+	}).Info("KIS credential issued")
+	return true, nil
+}
+
+// issue requests a new credential from KIS
+func (s *Session) issue(ctx context.Context, kind CredentialKind) (string, time.Duration, error) {
+	switch kind {
+	case KindAccessToken:
+		var resp TokenResponse
+		if err := s.post(ctx, "/oauth2/tokenP", map[string]string{
+			"grant_type": "client_credentials",
+			"appkey":     s.cfg.AppKey,
+			"appsecret":  s.cfg.AppSecret,
+		}, &resp); err != nil {
+			return "", 0, fmt.Errorf("token request failed: %w", err)
+		}
+		if resp.AccessToken == "" {
+			return "", 0, fmt.Errorf("token request failed: empty access token")
+		}
+		return resp.AccessToken, time.Duration(resp.ExpiresIn) * time.Second, nil
+
+	case KindApprovalKey:
+		var resp struct {
+			ApprovalKey string `json:"approval_key"`
+		}
+		if err := s.post(ctx, "/oauth2/Approval", map[string]string{
+			"grant_type": "client_credentials",
+			"appkey":     s.cfg.AppKey,
+			"secretkey":  s.cfg.AppSecret,
+		}, &resp); err != nil {
+			return "", 0, fmt.Errorf("approval key request failed: %w", err)
+		}
+		if resp.ApprovalKey == "" {
+			return "", 0, fmt.Errorf("approval key request failed: empty approval key")
+		}
+		return resp.ApprovalKey, approvalKeyTTL, nil
+
+	default:
+		return "", 0, fmt.Errorf("unknown credential kind %q", kind)
+	}
+}
+
+// post sends a credential request and decodes the JSON response
+func (s *Session) post(ctx context.Context, path string, body map[string]string, out interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,194 @@
+package kis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// fakeTokenServer issues numbered access tokens and approval keys
+type fakeTokenServer struct {
+	*httptest.Server
+	tokens    atomic.Int32
+	approvals atomic.Int32
+}
+
+func newFakeTokenServer(t *testing.T) *fakeTokenServer {
+	t.Helper()
+
+	s := &fakeTokenServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/tokenP", func(w http.ResponseWriter, r *http.Request) {
+		n := s.tokens.Add(1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":86400}`, n)
+	})
+	mux.HandleFunc("/oauth2/Approval", func(w http.ResponseWriter, r *http.Request) {
+		n := s.approvals.Add(1)
+		fmt.Fprintf(w, `{"approval_key":"key-%d"}`, n)
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestSession(srv *fakeTokenServer, store CredentialStore, clock *time.Time) *Session {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	s := NewSession(config.KISConfig{
+		BaseURL:            srv.URL,
+		AppKey:             "app",
+		AppSecret:          "secret",
+		AccountSharePct:    20,
+		QuoteSharePct:      60,
+		TokenRefreshBefore: time.Hour,
+		TokenDailyLimit:    2,
+	}, store, log)
+	s.now = func() time.Time { return *clock }
+	return s
+}
+
+func TestSession_ReusesStoredToken(t *testing.T) {
+	srv := newFakeTokenServer(t)
+	store := NewMemoryCredentialStore()
+	clock := time.Date(2026, 10, 16, 9, 0, 0, 0, kstZone)
+	ctx := context.Background()
+
+	token, err := newTestSession(srv, store, &clock).AccessToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// 재시작(새 세션) 후에도 저장소의 토큰 재사용
+	restarted := newTestSession(srv, store, &clock)
+	token, err = restarted.AccessToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, int32(1), srv.tokens.Load())
+}
+
+func TestSession_RefreshesBeforeExpiry(t *testing.T) {
+	srv := newFakeTokenServer(t)
+	clock := time.Date(2026, 10, 16, 9, 0, 0, 0, kstZone)
+	s := newTestSession(srv, nil, &clock)
+	ctx := context.Background()
+
+	_, err := s.AccessToken(ctx)
+	require.NoError(t, err)
+
+	clock = clock.Add(22 * time.Hour) // 만료 2시간 전: 그대로 사용
+	s.refreshInUse(ctx)
+	assert.Equal(t, int32(1), srv.tokens.Load())
+
+	clock = clock.Add(90 * time.Minute) // 만료 30분 전: 선제 갱신
+	s.refreshInUse(ctx)
+	assert.Equal(t, int32(2), srv.tokens.Load())
+
+	token, err := s.AccessToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestSession_InvalidateReissues(t *testing.T) {
+	srv := newFakeTokenServer(t)
+	clock := time.Date(2026, 10, 16, 9, 0, 0, 0, kstZone)
+	s := newTestSession(srv, nil, &clock)
+	s.cfg.TokenDailyLimit = 0 // 한도 없음
+	ctx := context.Background()
+
+	key, err := s.ApprovalKey(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", key.Value)
+
+	// 거부된 접속키는 유효 기간 중이어도 재발급
+	key, err = s.ApprovalKey(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", key.Value)
+
+	s.Invalidate(KindApprovalKey, "key-2")
+	key, err = s.ApprovalKey(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "key-3", key.Value)
+}
+
+func TestSession_DailyIssueLimit(t *testing.T) {
+	srv := newFakeTokenServer(t)
+	clock := time.Date(2026, 10, 16, 9, 0, 0, 0, kstZone)
+	s := newTestSession(srv, nil, &clock)
+	ctx := context.Background()
+
+	_, err := s.ApprovalKey(ctx, "")
+	require.NoError(t, err)
+	_, err = s.ApprovalKey(ctx, "key-1")
+	require.NoError(t, err)
+
+	// 한도 소진: 유효한 접속키가 있으면 거부된 값이라도 계속 사용
+	key, err := s.ApprovalKey(ctx, "key-2")
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", key.Value)
+	assert.Equal(t, int32(2), srv.approvals.Load())
+
+	// 갱신 구간(12시간 경과)이어도 같은 날은 기존 값 유지
+	clock = clock.Add(13 * time.Hour) // 22:00 KST
+	key, err = s.ApprovalKey(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", key.Value)
+
+	// KST 날짜가 바뀌면 발급 횟수 초기화
+	clock = clock.Add(3 * time.Hour) // 다음 날 01:00 KST
+	key, err = s.ApprovalKey(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "key-3", key.Value)
+}
+
+func TestSession_IssueLimitWithoutValidCredential(t *testing.T) {
+	srv := newFakeTokenServer(t)
+	clock := time.Date(2026, 10, 16, 9, 0, 0, 0, kstZone)
+	store := NewMemoryCredentialStore()
+	s := newTestSession(srv, store, &clock)
+
+	// 오늘 한도를 이미 소진했고 저장된 값도 만료됨
+	_, err := store.Update(context.Background(), s.keyHash, KindAccessToken, func(cred *Credential) (bool, error) {
+		*cred = Credential{Kind: KindAccessToken, Value: "old", ExpiresAt: clock.Add(-time.Minute), IssueDay: "2026-10-16", IssueCount: 2}
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	_, err = s.AccessToken(context.Background())
+	assert.True(t, errors.Is(err, ErrIssueLimit))
+	assert.Zero(t, srv.tokens.Load())
+}
+
+func TestBudget_Limits(t *testing.T) {
+	b := NewBudget(20, 20, 60)
+	assert.Equal(t, map[RequestClass]float64{ClassOrder: 20, ClassAccount: 4, ClassQuote: 12}, b.Limits())
+
+	assert.Equal(t, float64(defaultRateLimitVirtual), newBudgetFromConfig(0, 20, 60, 0, true).Limits()[ClassOrder])
+	assert.Equal(t, float64(defaultRateLimitReal), newBudgetFromConfig(0, 20, 60, 0, false).Limits()[ClassOrder])
+
+	// 프로세스 몫: 실전 18건의 50% → 9건, 최소 1건
+	assert.Equal(t, float64(9), newBudgetFromConfig(0, 20, 60, 50, false).Limits()[ClassOrder])
+	assert.Equal(t, float64(1), newBudgetFromConfig(0, 20, 60, 10, true).Limits()[ClassOrder])
+}
+
+func TestBudget_OrdersNotStarvedByQuotes(t *testing.T) {
+	b := NewBudget(10, 20, 60)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// 시세 조회 상한(6)까지 소진해도 전체 한도에는 주문 여유가 남음
+	for i := 0; i < 6; i++ {
+		require.NoError(t, b.Wait(ctx, ClassQuote))
+	}
+	start := time.Now()
+	require.NoError(t, b.Wait(ctx, ClassOrder))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
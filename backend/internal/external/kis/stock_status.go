@@ -0,0 +1,84 @@
+package kis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// 종목상태구분코드 (iscd_stat_cls_code)
+const (
+	StatusAdmin       = "51" // 관리종목
+	StatusDanger      = "52" // 투자위험
+	StatusWarning     = "53" // 투자경고
+	StatusCaution     = "54" // 투자주의
+	StatusTradingHalt = "58" // 거래정지
+)
+
+// 시장경고코드 (mrkt_warn_cls_code)
+const (
+	MarketWarnCaution = "01" // 투자주의
+	MarketWarnWarning = "02" // 투자경고
+	MarketWarnDanger  = "03" // 투자위험
+)
+
+// StockStatus represents market-measure designations of a stock (현재가 조회 응답)
+type StockStatus struct {
+	StockCode     string
+	StatusCode    string // iscd_stat_cls_code
+	MarketWarning string // mrkt_warn_cls_code
+	AdminIssue    bool   // mang_issu_cls_code = Y
+	TempStop      bool   // temp_stop_yn = Y
+	Caution       bool   // invt_caful_yn = Y (투자유의)
+}
+
+// GetStockStatus gets designation flags of a stock via 국내주식 현재가
+func (c *Client) GetStockStatus(ctx context.Context, stockCode string) (*StockStatus, error) {
+	path := "/uapi/domestic-stock/v1/quotations/inquire-price"
+	trID := "FHKST01010100" // 국내주식 현재가
+
+	params := fmt.Sprintf("?fid_cond_mrkt_div_code=J&fid_input_iscd=%s", stockCode)
+
+	resp, err := c.request(ctx, ClassQuote, http.MethodGet, path+params, trID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Output struct {
+			StatusCode    string `json:"iscd_stat_cls_code"`
+			MarketWarning string `json:"mrkt_warn_cls_code"`
+			AdminIssue    string `json:"mang_issu_cls_code"`
+			TempStop      string `json:"temp_stop_yn"`
+			Caution       string `json:"invt_caful_yn"`
+		} `json:"output"`
+		RtCd  string `json:"rt_cd"`
+		MsgCd string `json:"msg_cd"`
+		Msg1  string `json:"msg1"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if result.RtCd != "0" {
+		return nil, fmt.Errorf("API error: %s - %s", result.MsgCd, result.Msg1)
+	}
+
+	return &StockStatus{
+		StockCode:     stockCode,
+		StatusCode:    result.Output.StatusCode,
+		MarketWarning: result.Output.MarketWarning,
+		AdminIssue:    result.Output.AdminIssue == "Y",
+		TempStop:      result.Output.TempStop == "Y",
+		Caution:       result.Output.Caution == "Y",
+	}, nil
+}
@@ -112,6 +112,20 @@ type TickData struct {
 	ReceivedAt time.Time `json:"received_at"`
 }
 
+// OrderBookData represents real-time 10-level order book (호가)
+// index 0 = 최우선 호가 (ASK1/BID1)
+type OrderBookData struct {
+	Symbol      string                `json:"symbol"`
+	Time        string                `json:"time"`
+	AskPrices   [OrderBookDepth]int64 `json:"ask_prices"`
+	BidPrices   [OrderBookDepth]int64 `json:"bid_prices"`
+	AskQtys     [OrderBookDepth]int64 `json:"ask_qtys"`
+	BidQtys     [OrderBookDepth]int64 `json:"bid_qtys"`
+	TotalAskQty int64                 `json:"total_ask_qty"`
+	TotalBidQty int64                 `json:"total_bid_qty"`
+	ReceivedAt  time.Time             `json:"received_at"`
+}
+
 // ExecutionNotice represents real-time execution notification
 type ExecutionNotice struct {
 	OrderNo       string    `json:"order_no"`
@@ -130,6 +144,19 @@ type ExecutionNotice struct {
 	ReceivedAt    time.Time `json:"received_at"`
 }
 
+// WSHealth represents KIS WebSocket connection health
+type WSHealth struct {
+	Connected              bool      `json:"connected"`
+	ConnectedSince         time.Time `json:"connected_since,omitempty"`
+	LastMessageAt          time.Time `json:"last_message_at,omitempty"`
+	LastMessageAgeSec      float64   `json:"last_message_age_sec"` // -1: 수신 이력 없음
+	ReconnectCount         int64     `json:"reconnect_count"`
+	TickSubscriptions      int       `json:"tick_subscriptions"`
+	OrderBookSubscriptions int       `json:"order_book_subscriptions"`
+	ExecutionSubscribed    bool      `json:"execution_subscribed"`
+	AvailableSlots         int       `json:"available_slots"` // 남은 종목 구독 예산
+}
+
 // ============================================================
 // KIS API Response Types (Internal)
 // ============================================================
@@ -161,6 +188,17 @@ type balanceResponse struct {
 	} `json:"output2"`
 }
 
+// buyingPowerResponse represents KIS 매수가능조회 API response
+type buyingPowerResponse struct {
+	RtCd   string `json:"rt_cd"`
+	MsgCd  string `json:"msg_cd"`
+	Msg1   string `json:"msg1"`
+	Output struct {
+		OrdPsblCash string `json:"ord_psbl_cash"` // 주문가능현금
+		MaxBuyAmt   string `json:"max_buy_amt"`   // 최대매수금액 (미수 포함)
+	} `json:"output"`
+}
+
 // ordersResponse represents KIS orders API response
 type ordersResponse struct {
 	RtCd         string `json:"rt_cd"`
@@ -7,16 +7,17 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // WebSocket URLs
@@ -26,175 +27,254 @@ const (
 
 	// TR IDs
 	TRIDTickReal      = "H0STCNT0" // 실시간 체결가
+	TRIDOrderBookReal = "H0STASP0" // 실시간 호가 (10단계)
 	TRIDExecutionReal = "H0STCNI0" // 실전 체결통보
 	TRIDExecutionDemo = "H0STCNI9" // 모의 체결통보
 
-	// Limits
+	// Limits (체결가 + 호가 + 체결통보 합산)
 	MaxSubscriptionsPerSession = 41
+	// MaxSymbolSubscriptions 체결통보 1건을 예약한 종목 구독 예산
+	MaxSymbolSubscriptions = MaxSubscriptionsPerSession - 1
+
+	// OrderBookDepth 호가 단계 수
+	OrderBookDepth = 10
 
 	// Timing
-	PingInterval         = 30 * time.Second
+	PingInterval          = 30 * time.Second
+	PongWait              = 90 * time.Second // 수신(메시지/pong) 없이 경과 시 연결 끊김으로 판단
+	WriteWait             = 10 * time.Second
 	ReconnectInitialDelay = 1 * time.Second
 	ReconnectMaxDelay     = 30 * time.Second
-	MaxReconnectAttempts  = 10
+	ApprovalKeyRefresh    = 12 * time.Hour // 접속키 유효기간 24시간 → 절반 경과 시 재발급
 )
 
-// WSClient handles KIS WebSocket connection
+// WSClient handles the single KIS WebSocket connection
+// ⭐ SSOT: KIS WebSocket 연결, 접속키, 세션 구독 예산(40종목 + 체결통보), 재연결 후 재구독은 이 클라이언트에서만
+// 구독 목록은 "원하는 상태"로 보관 → 연결 전/끊김 중 요청도 기록 후 (재)연결 시 결정적 순서로 재전송
 type WSClient struct {
-	cfg         config.KISConfig
-	logger      *logger.Logger
-	approvalKey string
-	htsID       string
-
-	conn      *websocket.Conn
-	connMu    sync.Mutex
-	connected bool
+	cfg            config.KISConfig
+	logger         *logger.Logger
+	wsURL          string
+	reconnectDelay time.Duration
+	htsID          string
+
+	session       *Session // 접속키 발급/저장 (Client와 공유 가능)
+	approvalKey   string
+	approvalKeyAt time.Time
+	keyMu         sync.Mutex
+
+	conn        *websocket.Conn
+	connMu      sync.Mutex // conn 상태 보호 + 쓰기 직렬화
+	connected   bool
+	connectedAt time.Time
 
 	subscriptions       map[string]bool
+	orderBookSubs       map[string]bool
 	executionSubscribed bool
 	subMu               sync.RWMutex
 
-	// Callbacks
-	onTick       func(*TickData)
-	onExecution  func(*ExecutionNotice)
-	onError      func(error)
-	onConnected  func()
-	onDisconnect func()
-
-	stopCh chan struct{}
-	wg     sync.WaitGroup
+	// Handlers (여러 소비자 등록 가능: API 캐시, FeedManager 등)
+	onTick       []func(*TickData)
+	onOrderBook  []func(*OrderBookData)
+	onExecution  []func(*ExecutionNotice)
+	onError      []func(error)
+	onConnected  []func()
+	onDisconnect []func()
+	handlerMu    sync.RWMutex
+
+	// Health
+	lastMessageAt  atomic.Int64 // unix nano
+	reconnectCount atomic.Int64
+
+	started  atomic.Bool
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
 }
 
 // NewWSClient creates a new WebSocket client
 func NewWSClient(cfg config.KISConfig, log *logger.Logger) *WSClient {
+	wsURL := WSURLReal
+	if cfg.IsVirtual {
+		wsURL = WSURLDemo
+	}
+
 	return &WSClient{
-		cfg:           cfg,
-		logger:        log,
-		subscriptions: make(map[string]bool),
-		stopCh:        make(chan struct{}),
+		cfg:            cfg,
+		logger:         log,
+		wsURL:          wsURL,
+		reconnectDelay: ReconnectInitialDelay,
+		session:        NewSession(cfg, nil, log),
+		subscriptions:  make(map[string]bool),
+		orderBookSubs:  make(map[string]bool),
+		stopCh:         make(chan struct{}),
 	}
 }
 
+// SetSession shares a session so the approval key is persisted and reused across restarts
+func (c *WSClient) SetSession(s *Session) {
+	c.session = s
+}
+
 // SetHtsID sets HTS ID for execution notifications
 func (c *WSClient) SetHtsID(htsID string) {
 	c.htsID = htsID
 }
 
-// Callback setters
-func (c *WSClient) OnTick(fn func(*TickData))             { c.onTick = fn }
-func (c *WSClient) OnExecution(fn func(*ExecutionNotice)) { c.onExecution = fn }
-func (c *WSClient) OnError(fn func(error))                { c.onError = fn }
-func (c *WSClient) OnConnected(fn func())                 { c.onConnected = fn }
-func (c *WSClient) OnDisconnect(fn func())                { c.onDisconnect = fn }
+// Handler registration (callbacks run on the read goroutine and must not block)
+func (c *WSClient) OnTick(fn func(*TickData)) {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+	c.onTick = append(c.onTick, fn)
+}
+
+func (c *WSClient) OnOrderBook(fn func(*OrderBookData)) {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+	c.onOrderBook = append(c.onOrderBook, fn)
+}
+
+func (c *WSClient) OnExecution(fn func(*ExecutionNotice)) {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+	c.onExecution = append(c.onExecution, fn)
+}
+
+func (c *WSClient) OnError(fn func(error)) {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+	c.onError = append(c.onError, fn)
+}
+
+func (c *WSClient) OnConnected(fn func()) {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+	c.onConnected = append(c.onConnected, fn)
+}
+
+func (c *WSClient) OnDisconnect(fn func()) {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+	c.onDisconnect = append(c.onDisconnect, fn)
+}
 
-// Connect establishes WebSocket connection
+// Connect establishes the connection and starts the supervisor
+// 이후 연결이 끊기면 Disconnect 호출 전까지 자동 재연결 + 재구독
 func (c *WSClient) Connect(ctx context.Context) error {
-	// Get approval key
-	if err := c.getApprovalKey(ctx); err != nil {
+	if !c.started.CompareAndSwap(false, true) {
+		return fmt.Errorf("websocket client already started")
+	}
+
+	if err := c.refreshApprovalKey(ctx, false); err != nil {
+		c.started.Store(false)
 		return fmt.Errorf("get approval key: %w", err)
 	}
 
-	// Connect WebSocket
 	if err := c.connect(ctx); err != nil {
+		c.started.Store(false)
 		return fmt.Errorf("websocket connect: %w", err)
 	}
 
-	// Start read loop
-	c.wg.Add(1)
-	go c.readLoop()
+	c.replaySubscriptions()
 
-	// Start ping loop
 	c.wg.Add(1)
-	go c.pingLoop()
+	go c.run()
 
 	c.logger.Info("KIS WebSocket connected")
 	return nil
 }
 
-// getApprovalKey gets WebSocket approval key
-func (c *WSClient) getApprovalKey(ctx context.Context) error {
-	url := c.cfg.BaseURL + "/oauth2/Approval"
-	body := map[string]string{
-		"grant_type": "client_credentials",
-		"appkey":     c.cfg.AppKey,
-		"secretkey":  c.cfg.AppSecret,
-	}
-
-	bodyBytes, _ := json.Marshal(body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(bodyBytes)))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+// refreshApprovalKey loads the approval key from the session
+// force: 현재 접속키가 거부된 경우 (아직 유효해도 재발급)
+func (c *WSClient) refreshApprovalKey(ctx context.Context, force bool) error {
+	stale := ""
+	if force {
+		stale = c.getApprovalKey()
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	cred, err := c.session.ApprovalKey(ctx, stale)
 	if err != nil {
 		return err
 	}
 
-	var result struct {
-		ApprovalKey string `json:"approval_key"`
-	}
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return err
-	}
-
-	c.approvalKey = result.ApprovalKey
+	c.keyMu.Lock()
+	c.approvalKey = cred.Value
+	c.approvalKeyAt = cred.IssuedAt
+	c.keyMu.Unlock()
 	return nil
 }
 
-// connect establishes WebSocket connection
-func (c *WSClient) connect(ctx context.Context) error {
-	c.connMu.Lock()
-	defer c.connMu.Unlock()
+// getApprovalKey returns the current approval key
+func (c *WSClient) getApprovalKey() string {
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	return c.approvalKey
+}
 
-	wsURL := WSURLReal
-	if c.cfg.IsVirtual {
-		wsURL = WSURLDemo
-	}
+// approvalKeyExpired reports whether the approval key should be reissued
+func (c *WSClient) approvalKeyExpired() bool {
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	return c.approvalKey == "" || time.Since(c.approvalKeyAt) > ApprovalKeyRefresh
+}
 
+// connect dials a new WebSocket connection
+func (c *WSClient) connect(ctx context.Context) error {
 	dialer := websocket.Dialer{
 		HandshakeTimeout: 10 * time.Second,
 	}
 
-	conn, _, err := dialer.DialContext(ctx, wsURL, nil)
+	conn, _, err := dialer.DialContext(ctx, c.wsURL, nil)
 	if err != nil {
 		return err
 	}
 
+	conn.SetReadDeadline(time.Now().Add(PongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(PongWait))
+	})
+
+	c.connMu.Lock()
+	select {
+	case <-c.stopCh:
+		// Disconnect와 경합: 새 연결을 남기지 않음
+		c.connMu.Unlock()
+		conn.Close()
+		return fmt.Errorf("websocket client stopped")
+	default:
+	}
 	c.conn = conn
 	c.connected = true
+	c.connectedAt = time.Now()
+	c.connMu.Unlock()
 
-	if c.onConnected != nil {
-		c.onConnected()
+	c.lastMessageAt.Store(time.Now().UnixNano())
+
+	c.handlerMu.RLock()
+	for _, fn := range c.onConnected {
+		fn()
 	}
+	c.handlerMu.RUnlock()
 
 	return nil
 }
 
-// Disconnect closes the connection
+// Disconnect stops the supervisor and closes the connection
 func (c *WSClient) Disconnect() error {
-	close(c.stopCh)
+	c.stopOnce.Do(func() { close(c.stopCh) })
 
 	c.connMu.Lock()
 	if c.conn != nil {
 		c.conn.Close()
-		c.connected = false
+		c.conn = nil
 	}
+	c.connected = false
 	c.connMu.Unlock()
 
 	c.wg.Wait()
+	c.started.Store(false)
 
-	if c.onDisconnect != nil {
-		c.onDisconnect()
-	}
+	c.notifyDisconnect()
 
 	c.logger.Info("KIS WebSocket disconnected")
 	return nil
@@ -207,7 +287,14 @@ func (c *WSClient) IsConnected() bool {
 	return c.connected
 }
 
+// IsStarted reports whether Connect succeeded and Disconnect has not been called
+// (재연결 중에는 IsConnected=false, IsStarted=true)
+func (c *WSClient) IsStarted() bool {
+	return c.started.Load()
+}
+
 // Subscribe subscribes to tick data for symbols
+// 미연결 상태면 구독 목록에만 기록 → (재)연결 시 전송
 func (c *WSClient) Subscribe(symbols ...string) error {
 	c.subMu.Lock()
 	defer c.subMu.Unlock()
@@ -217,14 +304,11 @@ func (c *WSClient) Subscribe(symbols ...string) error {
 			continue
 		}
 
-		if len(c.subscriptions) >= MaxSubscriptionsPerSession {
-			return fmt.Errorf("max subscriptions reached (%d)", MaxSubscriptionsPerSession)
-		}
-
-		if err := c.sendSubscribe(symbol, "1"); err != nil {
-			return fmt.Errorf("subscribe %s: %w", symbol, err)
+		if c.symbolSlotsUsed() >= MaxSymbolSubscriptions {
+			return fmt.Errorf("max subscriptions reached (%d)", MaxSymbolSubscriptions)
 		}
 
+		c.sendSubscribeTR(TRIDTickReal, symbol, "1")
 		c.subscriptions[symbol] = true
 		c.logger.WithFields(map[string]interface{}{
 			"symbol": symbol,
@@ -234,51 +318,71 @@ func (c *WSClient) Subscribe(symbols ...string) error {
 	return nil
 }
 
-// Unsubscribe removes symbol subscriptions
-func (c *WSClient) Unsubscribe(symbols ...string) error {
+// SubscribeOrderBook subscribes to 10-level order book (호가) for symbols
+// 체결가 구독과 종목 구독 예산(40건)을 공유
+func (c *WSClient) SubscribeOrderBook(symbols ...string) error {
 	c.subMu.Lock()
 	defer c.subMu.Unlock()
 
 	for _, symbol := range symbols {
-		if !c.subscriptions[symbol] {
+		if c.orderBookSubs[symbol] {
 			continue
 		}
 
-		if err := c.sendSubscribe(symbol, "2"); err != nil {
-			return fmt.Errorf("unsubscribe %s: %w", symbol, err)
+		if c.symbolSlotsUsed() >= MaxSymbolSubscriptions {
+			return fmt.Errorf("max subscriptions reached (%d)", MaxSymbolSubscriptions)
 		}
 
-		delete(c.subscriptions, symbol)
+		c.sendSubscribeTR(TRIDOrderBookReal, symbol, "1")
+		c.orderBookSubs[symbol] = true
+		c.logger.WithFields(map[string]interface{}{
+			"symbol": symbol,
+		}).Debug("Subscribed to order book")
 	}
 
 	return nil
 }
 
-// sendSubscribe sends subscription message
-func (c *WSClient) sendSubscribe(symbol, trType string) error {
-	msg := wsMessage{
-		Header: wsHeader{
-			ApprovalKey: c.approvalKey,
-			Custtype:    "P",
-			TrType:      trType,
-			ContentType: "utf-8",
-		},
-		Body: wsBody{
-			Input: wsInput{
-				TrID:  TRIDTickReal,
-				TrKey: symbol,
-			},
-		},
+// UnsubscribeOrderBook removes order book subscriptions
+func (c *WSClient) UnsubscribeOrderBook(symbols ...string) error {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	for _, symbol := range symbols {
+		if !c.orderBookSubs[symbol] {
+			continue
+		}
+
+		c.sendSubscribeTR(TRIDOrderBookReal, symbol, "2")
+		delete(c.orderBookSubs, symbol)
 	}
 
-	c.connMu.Lock()
-	defer c.connMu.Unlock()
+	return nil
+}
 
-	if c.conn == nil {
-		return fmt.Errorf("not connected")
+// GetOrderBookSubscriptions returns current order book subscriptions
+func (c *WSClient) GetOrderBookSubscriptions() []string {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+
+	return sortedSymbols(c.orderBookSubs)
+}
+
+// Unsubscribe removes symbol subscriptions
+func (c *WSClient) Unsubscribe(symbols ...string) error {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	for _, symbol := range symbols {
+		if !c.subscriptions[symbol] {
+			continue
+		}
+
+		c.sendSubscribeTR(TRIDTickReal, symbol, "2")
+		delete(c.subscriptions, symbol)
 	}
 
-	return c.conn.WriteJSON(msg)
+	return nil
 }
 
 // SubscribeExecution subscribes to execution notifications
@@ -294,37 +398,7 @@ func (c *WSClient) SubscribeExecution() error {
 		return nil
 	}
 
-	trID := TRIDExecutionReal
-	if c.cfg.IsVirtual {
-		trID = TRIDExecutionDemo
-	}
-
-	msg := wsMessage{
-		Header: wsHeader{
-			ApprovalKey: c.approvalKey,
-			Custtype:    "P",
-			TrType:      "1",
-			ContentType: "utf-8",
-		},
-		Body: wsBody{
-			Input: wsInput{
-				TrID:  trID,
-				TrKey: c.htsID,
-			},
-		},
-	}
-
-	c.connMu.Lock()
-	defer c.connMu.Unlock()
-
-	if c.conn == nil {
-		return fmt.Errorf("not connected")
-	}
-
-	if err := c.conn.WriteJSON(msg); err != nil {
-		return err
-	}
-
+	c.sendSubscribeTR(c.executionTrID(), c.htsID, "1")
 	c.executionSubscribed = true
 	c.logger.Info("Subscribed to execution notifications")
 	return nil
@@ -339,22 +413,33 @@ func (c *WSClient) UnsubscribeExecution() error {
 		return nil
 	}
 
-	trID := TRIDExecutionReal
+	c.sendSubscribeTR(c.executionTrID(), c.htsID, "2")
+	c.executionSubscribed = false
+	return nil
+}
+
+// executionTrID returns the execution notice TR ID for the account type
+func (c *WSClient) executionTrID() string {
 	if c.cfg.IsVirtual {
-		trID = TRIDExecutionDemo
+		return TRIDExecutionDemo
 	}
+	return TRIDExecutionReal
+}
 
+// sendSubscribeTR sends a (un)subscription message for the given TR ID
+// 미연결이면 전송 생략(재연결 시 replay), 쓰기 실패 시 연결을 닫아 재연결을 유도
+func (c *WSClient) sendSubscribeTR(trID, key, trType string) {
 	msg := wsMessage{
 		Header: wsHeader{
-			ApprovalKey: c.approvalKey,
+			ApprovalKey: c.getApprovalKey(),
 			Custtype:    "P",
-			TrType:      "2",
+			TrType:      trType,
 			ContentType: "utf-8",
 		},
 		Body: wsBody{
 			Input: wsInput{
 				TrID:  trID,
-				TrKey: c.htsID,
+				TrKey: key,
 			},
 		},
 	}
@@ -363,15 +448,65 @@ func (c *WSClient) UnsubscribeExecution() error {
 	defer c.connMu.Unlock()
 
 	if c.conn == nil {
-		return fmt.Errorf("not connected")
+		return
 	}
 
+	c.conn.SetWriteDeadline(time.Now().Add(WriteWait))
 	if err := c.conn.WriteJSON(msg); err != nil {
-		return err
+		c.logger.WithError(err).WithFields(map[string]interface{}{
+			"tr_id":   trID,
+			"tr_key":  key,
+			"tr_type": trType,
+		}).Warn("Failed to send subscription, forcing reconnect")
+		c.conn.Close()
 	}
+}
 
-	c.executionSubscribed = false
-	return nil
+// replaySubscriptions re-sends every registered subscription on a fresh connection
+// 순서 고정: 체결가(코드 오름차순) → 호가(코드 오름차순) → 체결통보
+func (c *WSClient) replaySubscriptions() {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	ticks := sortedSymbols(c.subscriptions)
+	books := sortedSymbols(c.orderBookSubs)
+
+	for _, symbol := range ticks {
+		c.sendSubscribeTR(TRIDTickReal, symbol, "1")
+	}
+	for _, symbol := range books {
+		c.sendSubscribeTR(TRIDOrderBookReal, symbol, "1")
+	}
+	if c.executionSubscribed {
+		c.sendSubscribeTR(c.executionTrID(), c.htsID, "1")
+	}
+
+	if len(ticks) > 0 || len(books) > 0 || c.executionSubscribed {
+		c.logger.WithFields(map[string]interface{}{
+			"ticks":      len(ticks),
+			"order_book": len(books),
+			"execution":  c.executionSubscribed,
+		}).Info("Restored WebSocket subscriptions")
+	}
+}
+
+// symbolSlotsUsed returns used symbol subscription slots (subMu held)
+func (c *WSClient) symbolSlotsUsed() int {
+	return len(c.subscriptions) + len(c.orderBookSubs)
+}
+
+// AvailableSymbolSlots returns the remaining symbol subscription budget
+func (c *WSClient) AvailableSymbolSlots() int {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+	return MaxSymbolSubscriptions - c.symbolSlotsUsed()
+}
+
+// IsSubscribed reports whether tick data is subscribed for the symbol
+func (c *WSClient) IsSubscribed(symbol string) bool {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+	return c.subscriptions[symbol]
 }
 
 // GetSubscriptions returns current subscriptions
@@ -379,11 +514,7 @@ func (c *WSClient) GetSubscriptions() []string {
 	c.subMu.RLock()
 	defer c.subMu.RUnlock()
 
-	symbols := make([]string, 0, len(c.subscriptions))
-	for symbol := range c.subscriptions {
-		symbols = append(symbols, symbol)
-	}
-	return symbols
+	return sortedSymbols(c.subscriptions)
 }
 
 // SubscriptionCount returns number of subscriptions
@@ -393,47 +524,121 @@ func (c *WSClient) SubscriptionCount() int {
 	return len(c.subscriptions)
 }
 
-// readLoop handles incoming messages
-func (c *WSClient) readLoop() {
+// Health returns connection health metrics
+func (c *WSClient) Health() WSHealth {
+	c.connMu.Lock()
+	health := WSHealth{
+		Connected:         c.connected,
+		LastMessageAgeSec: -1,
+		ReconnectCount:    c.reconnectCount.Load(),
+	}
+	if c.connected {
+		health.ConnectedSince = c.connectedAt
+	}
+	c.connMu.Unlock()
+
+	if last := c.lastMessageAt.Load(); last > 0 {
+		health.LastMessageAt = time.Unix(0, last)
+		health.LastMessageAgeSec = time.Since(health.LastMessageAt).Seconds()
+	}
+
+	c.subMu.RLock()
+	health.TickSubscriptions = len(c.subscriptions)
+	health.OrderBookSubscriptions = len(c.orderBookSubs)
+	health.ExecutionSubscribed = c.executionSubscribed
+	health.AvailableSlots = MaxSymbolSubscriptions - c.symbolSlotsUsed()
+	c.subMu.RUnlock()
+
+	return health
+}
+
+// run serves the current connection and reconnects until Disconnect
+func (c *WSClient) run() {
 	defer c.wg.Done()
 
 	for {
+		c.connMu.Lock()
+		conn := c.conn
+		c.connMu.Unlock()
+
+		if conn == nil {
+			return
+		}
+
+		err := c.serve(conn)
+
 		select {
 		case <-c.stopCh:
 			return
 		default:
 		}
 
-		c.connMu.Lock()
-		conn := c.conn
-		c.connMu.Unlock()
+		c.logger.WithError(err).Warn("KIS WebSocket connection lost")
+		c.dropConn(conn)
 
-		if conn == nil {
+		c.handlerMu.RLock()
+		for _, fn := range c.onError {
+			fn(fmt.Errorf("read error: %w", err))
+		}
+		c.handlerMu.RUnlock()
+
+		if !c.reconnect() {
 			return
 		}
+	}
+}
+
+// serve reads messages from conn until it fails
+func (c *WSClient) serve(conn *websocket.Conn) error {
+	done := make(chan struct{})
+	defer close(done)
 
+	c.wg.Add(1)
+	go c.pingLoop(conn, done)
+
+	for {
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-				return
-			}
-			if c.onError != nil {
-				c.onError(fmt.Errorf("read error: %w", err))
-			}
-			c.handleDisconnect()
-			return
+			return err
 		}
 
+		c.lastMessageAt.Store(time.Now().UnixNano())
+		conn.SetReadDeadline(time.Now().Add(PongWait))
+
 		c.handleMessage(message)
 	}
 }
 
+// dropConn clears a failed connection
+func (c *WSClient) dropConn(conn *websocket.Conn) {
+	c.connMu.Lock()
+	conn.Close()
+	if c.conn == conn {
+		c.conn = nil
+		c.connected = false
+	}
+	c.connMu.Unlock()
+
+	c.notifyDisconnect()
+}
+
+// notifyDisconnect runs disconnect handlers
+func (c *WSClient) notifyDisconnect() {
+	c.handlerMu.RLock()
+	defer c.handlerMu.RUnlock()
+
+	for _, fn := range c.onDisconnect {
+		fn()
+	}
+}
+
 // handleMessage processes incoming message
 func (c *WSClient) handleMessage(data []byte) {
 	// Handle PINGPONG
 	if strings.Contains(string(data), "PINGPONG") {
 		c.connMu.Lock()
 		if c.conn != nil {
+			c.conn.SetWriteDeadline(time.Now().Add(WriteWait))
 			c.conn.WriteMessage(websocket.TextMessage, data)
 		}
 		c.connMu.Unlock()
@@ -443,18 +648,35 @@ func (c *WSClient) handleMessage(data []byte) {
 	// KIS format: encrypted|TR_ID|count|data
 	parts := strings.Split(string(data), "|")
 	if len(parts) < 4 {
-		return // JSON response (subscription confirmation)
+		c.handleControlMessage(data) // JSON response (subscription confirmation)
+		return
 	}
 
 	encrypted := parts[0]
 	trID := parts[1]
 	body := parts[3]
 
+	c.handlerMu.RLock()
+	defer c.handlerMu.RUnlock()
+
 	// Tick data
 	if trID == TRIDTickReal {
 		tick := c.parseTickData(body)
-		if tick != nil && c.onTick != nil {
-			c.onTick(tick)
+		if tick != nil {
+			for _, fn := range c.onTick {
+				fn(tick)
+			}
+		}
+		return
+	}
+
+	// Order book (호가)
+	if trID == TRIDOrderBookReal {
+		book := c.parseOrderBookData(body)
+		if book != nil {
+			for _, fn := range c.onOrderBook {
+				fn(book)
+			}
 		}
 		return
 	}
@@ -473,12 +695,39 @@ func (c *WSClient) handleMessage(data []byte) {
 		}
 
 		exec := c.parseExecutionData(body)
-		if exec != nil && c.onExecution != nil {
-			c.onExecution(exec)
+		if exec != nil {
+			for _, fn := range c.onExecution {
+				fn(exec)
+			}
 		}
 	}
 }
 
+// handleControlMessage logs rejected subscription responses
+func (c *WSClient) handleControlMessage(data []byte) {
+	var resp struct {
+		Header struct {
+			TrID  string `json:"tr_id"`
+			TrKey string `json:"tr_key"`
+		} `json:"header"`
+		Body struct {
+			RtCd string `json:"rt_cd"`
+			Msg1 string `json:"msg1"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return
+	}
+
+	if resp.Body.RtCd != "" && resp.Body.RtCd != "0" {
+		c.logger.WithFields(map[string]interface{}{
+			"tr_id":  resp.Header.TrID,
+			"tr_key": resp.Header.TrKey,
+			"msg":    resp.Body.Msg1,
+		}).Warn("KIS WebSocket subscription rejected")
+	}
+}
+
 // parseTickData parses tick data from KIS format
 // Fields: symbol^time^price^sign^change^changeRate^...^volume^accVolume^...
 func (c *WSClient) parseTickData(body string) *TickData {
@@ -505,6 +754,37 @@ func (c *WSClient) parseTickData(body string) *TickData {
 	}
 }
 
+// parseOrderBookData parses order book data from KIS format (H0STASP0)
+// Fields: symbol^time^hourCls^ask1..ask10^bid1..bid10^askQty1..10^bidQty1..10^totalAskQty^totalBidQty^...
+func (c *WSClient) parseOrderBookData(body string) *OrderBookData {
+	fields := strings.Split(body, "^")
+	const totalIdx = 3 + OrderBookDepth*4
+	if len(fields) < totalIdx+2 {
+		return nil
+	}
+
+	parse := func(i int) int64 {
+		v, _ := strconv.ParseInt(fields[i], 10, 64)
+		return v
+	}
+
+	book := &OrderBookData{
+		Symbol:      fields[0],
+		Time:        fields[1],
+		TotalAskQty: parse(totalIdx),
+		TotalBidQty: parse(totalIdx + 1),
+		ReceivedAt:  time.Now(),
+	}
+	for i := 0; i < OrderBookDepth; i++ {
+		book.AskPrices[i] = parse(3 + i)
+		book.BidPrices[i] = parse(3 + OrderBookDepth + i)
+		book.AskQtys[i] = parse(3 + OrderBookDepth*2 + i)
+		book.BidQtys[i] = parse(3 + OrderBookDepth*3 + i)
+	}
+
+	return book
+}
+
 // parseExecutionData parses execution notification
 func (c *WSClient) parseExecutionData(body string) *ExecutionNotice {
 	fields := strings.Split(body, "^")
@@ -599,8 +879,8 @@ func (c *WSClient) decryptData(encrypted string) (string, error) {
 	return string(plaintext), nil
 }
 
-// pingLoop sends periodic pings
-func (c *WSClient) pingLoop() {
+// pingLoop sends periodic pings on conn until done
+func (c *WSClient) pingLoop(conn *websocket.Conn, done <-chan struct{}) {
 	defer c.wg.Done()
 
 	ticker := time.NewTicker(PingInterval)
@@ -608,48 +888,30 @@ func (c *WSClient) pingLoop() {
 
 	for {
 		select {
-		case <-c.stopCh:
+		case <-done:
 			return
 		case <-ticker.C:
 			c.connMu.Lock()
-			if c.conn != nil {
-				if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-					c.connMu.Unlock()
-					if c.onError != nil {
-						c.onError(fmt.Errorf("ping error: %w", err))
-					}
-					c.handleDisconnect()
-					return
-				}
-			}
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(WriteWait))
 			c.connMu.Unlock()
-		}
-	}
-}
 
-// handleDisconnect handles connection loss
-func (c *WSClient) handleDisconnect() {
-	c.connMu.Lock()
-	c.connected = false
-	if c.conn != nil {
-		c.conn.Close()
-		c.conn = nil
-	}
-	c.connMu.Unlock()
-
-	if c.onDisconnect != nil {
-		c.onDisconnect()
+			if err != nil {
+				// 읽기 루프가 에러를 받고 재연결 처리
+				conn.Close()
+				return
+			}
+		}
 	}
 }
 
-// Reconnect attempts to reconnect
-func (c *WSClient) Reconnect(ctx context.Context) error {
-	delay := ReconnectInitialDelay
+// reconnect redials with exponential backoff until success or Disconnect
+func (c *WSClient) reconnect() bool {
+	delay := c.reconnectDelay
 
-	for attempt := 1; attempt <= MaxReconnectAttempts; attempt++ {
+	for attempt := 1; ; attempt++ {
 		select {
-		case <-ctx.Done():
-			return ctx.Err()
+		case <-c.stopCh:
+			return false
 		case <-time.After(delay):
 		}
 
@@ -657,58 +919,57 @@ func (c *WSClient) Reconnect(ctx context.Context) error {
 			"attempt": attempt,
 		}).Info("Attempting WebSocket reconnection")
 
-		// Get new approval key
-		if err := c.getApprovalKey(ctx); err != nil {
-			delay = delay * 2
-			if delay > ReconnectMaxDelay {
-				delay = ReconnectMaxDelay
-			}
-			continue
-		}
+		err := c.redial(attempt)
+		metrics.ObserveWSReconnect(err)
+		if err != nil {
+			c.logger.WithError(err).WithFields(map[string]interface{}{
+				"attempt": attempt,
+				"delay":   delay.String(),
+			}).Warn("WebSocket reconnection failed")
 
-		// Reconnect
-		if err := c.connect(ctx); err != nil {
-			delay = delay * 2
+			delay *= 2
 			if delay > ReconnectMaxDelay {
 				delay = ReconnectMaxDelay
 			}
 			continue
 		}
 
-		// Restore subscriptions
-		c.subMu.RLock()
-		symbols := make([]string, 0, len(c.subscriptions))
-		for symbol := range c.subscriptions {
-			symbols = append(symbols, symbol)
-		}
-		wasExecutionSubscribed := c.executionSubscribed
-		c.subMu.RUnlock()
+		c.reconnectCount.Add(1)
+		c.logger.WithFields(map[string]interface{}{
+			"attempt": attempt,
+		}).Info("WebSocket reconnected successfully")
+		return true
+	}
+}
 
-		// Clear and resubscribe
-		c.subMu.Lock()
-		c.subscriptions = make(map[string]bool)
-		c.executionSubscribed = false
-		c.subMu.Unlock()
+// redial reconnects and restores subscriptions
+// 접속키는 만료 임박 시 세션에서 갱신, 첫 시도 실패 이후에는 강제 재발급
+func (c *WSClient) redial(attempt int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
 
-		for _, symbol := range symbols {
-			c.Subscribe(symbol)
+	if attempt > 1 || c.approvalKeyExpired() {
+		if err := c.refreshApprovalKey(ctx, attempt > 1); err != nil {
+			return fmt.Errorf("get approval key: %w", err)
 		}
+	}
 
-		if wasExecutionSubscribed {
-			c.SubscribeExecution()
-		}
+	if err := c.connect(ctx); err != nil {
+		return fmt.Errorf("websocket connect: %w", err)
+	}
 
-		// Restart loops
-		c.stopCh = make(chan struct{})
-		c.wg.Add(2)
-		go c.readLoop()
-		go c.pingLoop()
+	c.replaySubscriptions()
+	return nil
+}
 
-		c.logger.Info("WebSocket reconnected successfully")
-		return nil
+// sortedSymbols returns map keys in ascending order
+func sortedSymbols(m map[string]bool) []string {
+	symbols := make([]string, 0, len(m))
+	for symbol := range m {
+		symbols = append(symbols, symbol)
 	}
-
-	return fmt.Errorf("max reconnect attempts reached")
+	sort.Strings(symbols)
+	return symbols
 }
 
 // Internal message types
@@ -0,0 +1,161 @@
+package kis
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// fakeKISServer serves /oauth2/Approval and a WebSocket endpoint recording subscriptions
+type fakeKISServer struct {
+	*httptest.Server
+	received chan string // "tr_type:tr_id:tr_key"
+
+	mu    sync.Mutex
+	conns []*websocket.Conn
+}
+
+func newFakeKISServer(t *testing.T) *fakeKISServer {
+	s := &fakeKISServer{received: make(chan string, 256)}
+	upgrader := websocket.Upgrader{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/Approval", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"approval_key": "test-key"})
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		for {
+			var msg wsMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			s.received <- fmt.Sprintf("%s:%s:%s", msg.Header.TrType, msg.Body.Input.TrID, msg.Body.Input.TrKey)
+		}
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// dropAll closes every server-side connection (simulates network loss)
+func (s *fakeKISServer) dropAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *fakeKISServer) next(t *testing.T, n int) []string {
+	t.Helper()
+	got := make([]string, 0, n)
+	for len(got) < n {
+		select {
+		case m := <-s.received:
+			got = append(got, m)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for messages, got %v", got)
+		}
+	}
+	return got
+}
+
+func newTestWSClient(srv *fakeKISServer) *WSClient {
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+	c := NewWSClient(config.KISConfig{BaseURL: srv.URL, AppKey: "k", AppSecret: "s"}, log)
+	c.wsURL = "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+	c.reconnectDelay = 10 * time.Millisecond
+	return c
+}
+
+func TestWSClient_ReplaysSubscriptionsAfterReconnect(t *testing.T) {
+	srv := newFakeKISServer(t)
+	c := newTestWSClient(srv)
+
+	// 연결 전 구독 → Connect 시 전송
+	require.NoError(t, c.Subscribe("005930", "000660"))
+	require.NoError(t, c.SubscribeOrderBook("005930"))
+
+	require.NoError(t, c.Connect(t.Context()))
+	defer c.Disconnect()
+
+	expected := []string{
+		"1:H0STCNT0:000660",
+		"1:H0STCNT0:005930",
+		"1:H0STASP0:005930",
+	}
+	assert.Equal(t, expected, srv.next(t, 3))
+	assert.True(t, c.IsConnected())
+
+	// 연결 끊김 → 자동 재연결 + 동일 순서 재구독
+	srv.dropAll()
+	assert.Equal(t, expected, srv.next(t, 3))
+
+	require.Eventually(t, c.IsConnected, 5*time.Second, 10*time.Millisecond)
+	health := c.Health()
+	assert.Equal(t, int64(1), health.ReconnectCount)
+	assert.Equal(t, 2, health.TickSubscriptions)
+	assert.Equal(t, 1, health.OrderBookSubscriptions)
+	assert.Equal(t, MaxSymbolSubscriptions-3, health.AvailableSlots)
+	assert.GreaterOrEqual(t, health.LastMessageAgeSec, 0.0)
+
+	// 해제도 즉시 전송
+	require.NoError(t, c.Unsubscribe("000660"))
+	assert.Equal(t, []string{"2:H0STCNT0:000660"}, srv.next(t, 1))
+	assert.Equal(t, []string{"005930"}, c.GetSubscriptions())
+}
+
+func TestWSClient_SymbolBudget(t *testing.T) {
+	srv := newFakeKISServer(t)
+	c := newTestWSClient(srv)
+
+	symbols := make([]string, 0, MaxSymbolSubscriptions)
+	for i := 0; i < MaxSymbolSubscriptions-1; i++ {
+		symbols = append(symbols, fmt.Sprintf("%06d", i))
+	}
+	require.NoError(t, c.Subscribe(symbols...))
+	require.NoError(t, c.SubscribeOrderBook("005930"))
+	assert.Equal(t, 0, c.AvailableSymbolSlots())
+
+	assert.Error(t, c.Subscribe("999999"))
+	assert.Error(t, c.SubscribeOrderBook("000660"))
+
+	// 이미 구독 중인 종목은 예산을 소모하지 않음
+	assert.NoError(t, c.Subscribe(symbols[0]))
+}
+
+func TestWSClient_DispatchesToAllTickHandlers(t *testing.T) {
+	srv := newFakeKISServer(t)
+	c := newTestWSClient(srv)
+
+	var got []string
+	c.OnTick(func(tick *TickData) { got = append(got, "a:"+tick.Symbol) })
+	c.OnTick(func(tick *TickData) { got = append(got, "b:"+tick.Symbol) })
+
+	fields := []string{"005930", "093000", "70000", "2", "500", "0.72", "0", "0", "0", "0", "0", "0", "10", "1000"}
+	c.handleMessage([]byte("0|H0STCNT0|001|" + strings.Join(fields, "^")))
+
+	assert.Equal(t, []string{"a:005930", "b:005930"}, got)
+}
@@ -0,0 +1,66 @@
+package krx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// krxDataURL is the KRX 정보데이터시스템 JSON endpoint (pykrx와 동일)
+const krxDataURL = "http://data.krx.co.kr/comm/bldAttendant/getJsonData.cmd"
+
+// postDataPortal posts a bld query to the KRX data portal and returns the raw body
+// KRX는 봇 요청을 차단하므로 브라우저 헤더를 흉내냄
+func (c *Client) postDataPortal(ctx context.Context, form url.Values, menuID string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, krxDataURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "application/json, text/javascript, */*; q=0.01")
+	req.Header.Set("Accept-Language", "ko-KR,ko;q=0.9,en-US;q=0.8,en;q=0.7")
+	req.Header.Set("Origin", "http://data.krx.co.kr")
+	req.Header.Set("Referer", "http://data.krx.co.kr/contents/MDC/MDI/mdiLoader/index.cmd?menuId="+menuID)
+
+	// Make request using standard http client (bypass our wrapper for this special case)
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("KRX API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	c.logger.WithFields(map[string]interface{}{
+		"bld":         form.Get("bld"),
+		"status_code": resp.StatusCode,
+		"body_size":   len(body),
+	}).Debug("KRX API response received")
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("KRX API returned status %d: %s", resp.StatusCode, string(body[:min(200, len(body))]))
+	}
+
+	return body, nil
+}
+
+// marketID converts a market name to the KRX mktId parameter
+func marketID(market string) (string, error) {
+	switch strings.ToUpper(market) {
+	case "KOSPI":
+		return "STK", nil
+	case "KOSDAQ":
+		return "KSQ", nil
+	default:
+		return "", fmt.Errorf("unsupported market: %s", market)
+	}
+}
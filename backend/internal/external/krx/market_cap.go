@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -19,6 +17,10 @@ type MarketCapItem struct {
 	MarketCap         int64     `json:"MKTCAP"`           // 시가총액
 	SharesOutstanding int64     `json:"LIST_SHRS"`        // 상장주식수
 	ClosePrice        int64     `json:"TDD_CLSPRC"`       // 종가
+	OpenPrice         int64     `json:"TDD_OPNPRC"`       // 시가
+	HighPrice         int64     `json:"TDD_HGPRC"`        // 고가
+	LowPrice          int64     `json:"TDD_LWPRC"`        // 저가
+	Volume            int64     `json:"ACC_TRDVOL"`       // 거래량
 	TradeDate         time.Time `json:"-"`                // 거래일 (파싱 후 설정)
 }
 
@@ -32,6 +34,10 @@ type krxMarketCapRow struct {
 	ISU_SRT_CD  string `json:"ISU_SRT_CD"`  // 종목코드 (단축)
 	ISU_ABBRV   string `json:"ISU_ABBRV"`   // 종목명
 	TDD_CLSPRC  string `json:"TDD_CLSPRC"`  // 종가
+	TDD_OPNPRC  string `json:"TDD_OPNPRC"`  // 시가
+	TDD_HGPRC   string `json:"TDD_HGPRC"`   // 고가
+	TDD_LWPRC   string `json:"TDD_LWPRC"`   // 저가
+	ACC_TRDVOL  string `json:"ACC_TRDVOL"`  // 거래량
 	MKTCAP      string `json:"MKTCAP"`      // 시가총액
 	LIST_SHRS   string `json:"LIST_SHRS"`   // 상장주식수
 }
@@ -39,30 +45,16 @@ type krxMarketCapRow struct {
 // FetchMarketCaps fetches market cap and shares outstanding from KRX for all stocks
 // ⭐ SSOT: KRX 시가총액/상장주식수 조회는 이 함수에서만
 func (c *Client) FetchMarketCaps(ctx context.Context, market string) ([]MarketCapItem, error) {
-	// KRX API endpoint for market cap
-	// pykrx uses: http://data.krx.co.kr/comm/bldAttendant/getJsonData.cmd
-	krxURL := "http://data.krx.co.kr/comm/bldAttendant/getJsonData.cmd"
-
-	// Determine market code
-	var mktId string
-	switch strings.ToUpper(market) {
-	case "KOSPI":
-		mktId = "STK"
-	case "KOSDAQ":
-		mktId = "KSQ"
-	default:
-		return nil, fmt.Errorf("unsupported market: %s", market)
-	}
+	return c.FetchMarketCapsOn(ctx, market, latestMarketCapDate())
+}
 
-	// Use yesterday's date if today is before market close
-	tradeDate := time.Now()
-	if tradeDate.Hour() < 16 {
-		tradeDate = tradeDate.AddDate(0, 0, -1)
-	}
-	// Skip weekends
-	for tradeDate.Weekday() == time.Saturday || tradeDate.Weekday() == time.Sunday {
-		tradeDate = tradeDate.AddDate(0, 0, -1)
+// FetchMarketCapsOn fetches the MDCSTAT01501 snapshot (OHLCV, 시가총액, 상장주식수) for a trade date
+func (c *Client) FetchMarketCapsOn(ctx context.Context, market string, tradeDate time.Time) ([]MarketCapItem, error) {
+	mktId, err := marketID(market)
+	if err != nil {
+		return nil, err
 	}
+
 	trdDd := tradeDate.Format("20060102")
 
 	// Build form data
@@ -81,42 +73,9 @@ func (c *Client) FetchMarketCaps(ctx context.Context, market string) ([]MarketCa
 		"trade_date": trdDd,
 	}).Info("Fetching market caps from KRX")
 
-	// Create HTTP request with browser-like headers (KRX blocks bot requests)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, krxURL, strings.NewReader(formData.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers to mimic browser request (required by KRX)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json, text/javascript, */*; q=0.01")
-	req.Header.Set("Accept-Language", "ko-KR,ko;q=0.9,en-US;q=0.8,en;q=0.7")
-	req.Header.Set("Origin", "http://data.krx.co.kr")
-	req.Header.Set("Referer", "http://data.krx.co.kr/contents/MDC/MDI/mdiLoader/index.cmd?menuId=MDC0201020101")
-
-	// Make request using standard http client (bypass our wrapper for this special case)
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("KRX API request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.postDataPortal(ctx, formData, "MDC0201020101")
 	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
-	}
-
-	c.logger.WithFields(map[string]interface{}{
-		"status_code": resp.StatusCode,
-		"body_size":   len(body),
-	}).Debug("KRX API response received")
-
-	// Check for error response
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("KRX API returned status %d: %s", resp.StatusCode, string(body[:min(200, len(body))]))
+		return nil, err
 	}
 
 	// Parse response
@@ -157,6 +116,10 @@ func (c *Client) FetchMarketCaps(ctx context.Context, market string) ([]MarketCa
 			MarketCap:         marketCap,
 			SharesOutstanding: shares,
 			ClosePrice:        closePrice,
+			OpenPrice:         parseKRXNumber(row.TDD_OPNPRC),
+			HighPrice:         parseKRXNumber(row.TDD_HGPRC),
+			LowPrice:          parseKRXNumber(row.TDD_LWPRC),
+			Volume:            parseKRXNumber(row.ACC_TRDVOL),
 			TradeDate:         parsedDate,
 		})
 	}
@@ -171,17 +134,22 @@ func (c *Client) FetchMarketCaps(ctx context.Context, market string) ([]MarketCa
 
 // FetchAllMarketCaps fetches market caps for both KOSPI and KOSDAQ
 func (c *Client) FetchAllMarketCaps(ctx context.Context) ([]MarketCapItem, error) {
+	return c.FetchAllMarketCapsOn(ctx, latestMarketCapDate())
+}
+
+// FetchAllMarketCapsOn fetches market caps for both KOSPI and KOSDAQ on a trade date
+func (c *Client) FetchAllMarketCapsOn(ctx context.Context, tradeDate time.Time) ([]MarketCapItem, error) {
 	var allItems []MarketCapItem
 
 	// Fetch KOSPI
-	kospiItems, err := c.FetchMarketCaps(ctx, "KOSPI")
+	kospiItems, err := c.FetchMarketCapsOn(ctx, "KOSPI", tradeDate)
 	if err != nil {
 		return nil, fmt.Errorf("fetch KOSPI market caps: %w", err)
 	}
 	allItems = append(allItems, kospiItems...)
 
 	// Fetch KOSDAQ
-	kosdaqItems, err := c.FetchMarketCaps(ctx, "KOSDAQ")
+	kosdaqItems, err := c.FetchMarketCapsOn(ctx, "KOSDAQ", tradeDate)
 	if err != nil {
 		return nil, fmt.Errorf("fetch KOSDAQ market caps: %w", err)
 	}
@@ -196,6 +164,19 @@ func (c *Client) FetchAllMarketCaps(ctx context.Context) ([]MarketCapItem, error
 	return allItems, nil
 }
 
+// latestMarketCapDate returns the most recent closed session date
+// Use yesterday's date if today is before market close, skipping weekends
+func latestMarketCapDate() time.Time {
+	tradeDate := time.Now()
+	if tradeDate.Hour() < 16 {
+		tradeDate = tradeDate.AddDate(0, 0, -1)
+	}
+	for tradeDate.Weekday() == time.Saturday || tradeDate.Weekday() == time.Sunday {
+		tradeDate = tradeDate.AddDate(0, 0, -1)
+	}
+	return tradeDate
+}
+
 // parseKRXNumber parses KRX number format (with commas) to int64
 func parseKRXNumber(s string) int64 {
 	// Remove commas and whitespace
@@ -0,0 +1,180 @@
+package krx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// StockMasterItem represents one listed security from the KRX 전종목 기본정보
+type StockMasterItem struct {
+	Code          string    // 종목코드 (단축)
+	Name          string    // 종목명 (약명)
+	Market        string    // KOSPI, KOSDAQ
+	Sector        string    // 업종명 (업종분류 현황, 없으면 빈 문자열)
+	ListingDate   time.Time // 상장일
+	SecurityGroup string    // 증권구분 (주권, 외국주권, 부동산투자회사 등)
+	Section       string    // 소속부 (KOSDAQ: 우량기업부, 관리종목(소속부없음) 등)
+	StockType     string    // 주식종류 (보통주, 우선주 등)
+}
+
+// StockStatusItem represents one stock's trading state on a date (전종목 시세 기준)
+type StockStatusItem struct {
+	Code    string
+	Date    time.Time
+	Section string // 소속부
+	Open    int64  // 시가 (거래정지 시 0)
+	Volume  int64  // 거래량
+}
+
+// NoTrade reports whether the stock had no trade at all (정지 종목은 시가/거래량 0)
+func (s StockStatusItem) NoTrade() bool {
+	return s.Open == 0 && s.Volume == 0
+}
+
+type krxRowsResponse[T any] struct {
+	OutBlock1 []T `json:"OutBlock_1"`
+}
+
+type krxStockInfoRow struct {
+	ISU_SRT_CD         string `json:"ISU_SRT_CD"`
+	ISU_ABBRV          string `json:"ISU_ABBRV"`
+	LIST_DD            string `json:"LIST_DD"` // YYYY/MM/DD
+	MKT_TP_NM          string `json:"MKT_TP_NM"`
+	SECUGRP_NM         string `json:"SECUGRP_NM"`
+	SECT_TP_NM         string `json:"SECT_TP_NM"`
+	KIND_STKCERT_TP_NM string `json:"KIND_STKCERT_TP_NM"`
+}
+
+type krxSectorRow struct {
+	ISU_SRT_CD string `json:"ISU_SRT_CD"`
+	IDX_IND_NM string `json:"IDX_IND_NM"`
+}
+
+type krxDailyRow struct {
+	ISU_SRT_CD string `json:"ISU_SRT_CD"`
+	SECT_TP_NM string `json:"SECT_TP_NM"`
+	TDD_OPNPRC string `json:"TDD_OPNPRC"`
+	ACC_TRDVOL string `json:"ACC_TRDVOL"`
+}
+
+// FetchStockMaster fetches listing info and sector of every listed security in a market
+// ⭐ SSOT: KRX 종목 마스터 조회는 이 함수에서만
+func (c *Client) FetchStockMaster(ctx context.Context, market string, date time.Time) ([]StockMasterItem, error) {
+	mktId, err := marketID(market)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. 전종목 기본정보 (MDCSTAT01901)
+	body, err := c.postDataPortal(ctx, url.Values{
+		"bld":         {"dbms/MDC/STAT/standard/MDCSTAT01901"},
+		"locale":      {"ko_KR"},
+		"mktId":       {mktId},
+		"share":       {"1"},
+		"csvxls_isNo": {"false"},
+	}, "MDC0201020201")
+	if err != nil {
+		return nil, err
+	}
+
+	var info krxRowsResponse[krxStockInfoRow]
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("decode KRX stock info: %w", err)
+	}
+
+	// 2. 업종분류 현황 (MDCSTAT03901) - 실패해도 섹터만 비움
+	sectors := make(map[string]string)
+	body, err = c.postDataPortal(ctx, url.Values{
+		"bld":         {"dbms/MDC/STAT/standard/MDCSTAT03901"},
+		"locale":      {"ko_KR"},
+		"mktId":       {mktId},
+		"trdDd":       {date.Format("20060102")},
+		"money":       {"1"},
+		"csvxls_isNo": {"false"},
+	}, "MDC0201020506")
+	if err != nil {
+		c.logger.WithError(err).WithField("market", market).Warn("Failed to fetch KRX sector classification")
+	} else {
+		var sectorResp krxRowsResponse[krxSectorRow]
+		if err := json.Unmarshal(body, &sectorResp); err != nil {
+			c.logger.WithError(err).WithField("market", market).Warn("Failed to decode KRX sector classification")
+		}
+		for _, row := range sectorResp.OutBlock1 {
+			sectors[row.ISU_SRT_CD] = strings.TrimSpace(row.IDX_IND_NM)
+		}
+	}
+
+	items := make([]StockMasterItem, 0, len(info.OutBlock1))
+	for _, row := range info.OutBlock1 {
+		if row.ISU_SRT_CD == "" {
+			continue
+		}
+		listed, err := time.Parse("2006/01/02", strings.TrimSpace(row.LIST_DD))
+		if err != nil {
+			continue
+		}
+		items = append(items, StockMasterItem{
+			Code:          row.ISU_SRT_CD,
+			Name:          strings.TrimSpace(row.ISU_ABBRV),
+			Market:        strings.ToUpper(market),
+			Sector:        sectors[row.ISU_SRT_CD],
+			ListingDate:   listed,
+			SecurityGroup: strings.TrimSpace(row.SECUGRP_NM),
+			Section:       strings.TrimSpace(row.SECT_TP_NM),
+			StockType:     strings.TrimSpace(row.KIND_STKCERT_TP_NM),
+		})
+	}
+
+	c.logger.WithFields(map[string]interface{}{
+		"market":  market,
+		"count":   len(items),
+		"sectors": len(sectors),
+	}).Info("Fetched stock master from KRX")
+
+	return items, nil
+}
+
+// FetchStockStatuses fetches the per-stock trading state of a market on a date (MDCSTAT01501)
+func (c *Client) FetchStockStatuses(ctx context.Context, market string, date time.Time) ([]StockStatusItem, error) {
+	mktId, err := marketID(market)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.postDataPortal(ctx, url.Values{
+		"bld":         {"dbms/MDC/STAT/standard/MDCSTAT01501"},
+		"locale":      {"ko_KR"},
+		"mktId":       {mktId},
+		"trdDd":       {date.Format("20060102")},
+		"share":       {"1"},
+		"money":       {"1"},
+		"csvxls_isNo": {"false"},
+	}, "MDC0201020101")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp krxRowsResponse[krxDailyRow]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode KRX daily snapshot: %w", err)
+	}
+
+	items := make([]StockStatusItem, 0, len(resp.OutBlock1))
+	for _, row := range resp.OutBlock1 {
+		if row.ISU_SRT_CD == "" {
+			continue
+		}
+		items = append(items, StockStatusItem{
+			Code:    row.ISU_SRT_CD,
+			Date:    date,
+			Section: strings.TrimSpace(row.SECT_TP_NM),
+			Open:    parseKRXNumber(row.TDD_OPNPRC),
+			Volume:  parseKRXNumber(row.ACC_TRDVOL),
+		})
+	}
+	return items, nil
+}
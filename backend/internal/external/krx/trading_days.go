@@ -0,0 +1,65 @@
+package krx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type krxIndexDailyRow struct {
+	TRD_DD string `json:"TRD_DD"` // YYYY/MM/DD
+}
+
+// FetchTradingDays returns every KRX trading day in [from, to]
+// KOSPI 지수 일별 시세(MDCSTAT00301)가 존재하는 날 = 개장일
+// ⭐ SSOT: KRX 개장일 조회는 이 함수에서만
+func (c *Client) FetchTradingDays(ctx context.Context, from, to time.Time) ([]time.Time, error) {
+	body, err := c.postDataPortal(ctx, url.Values{
+		"bld":         {"dbms/MDC/STAT/standard/MDCSTAT00301"},
+		"locale":      {"ko_KR"},
+		"indIdx":      {"1"},
+		"indIdx2":     {"001"}, // 코스피
+		"strtDd":      {from.Format("20060102")},
+		"endDd":       {to.Format("20060102")},
+		"share":       {"2"},
+		"money":       {"3"},
+		"csvxls_isNo": {"false"},
+	}, "MDC0201010103")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Output    []krxIndexDailyRow `json:"output"`
+		OutBlock1 []krxIndexDailyRow `json:"OutBlock_1"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode KRX index history: %w", err)
+	}
+	rows := resp.Output
+	if len(rows) == 0 {
+		rows = resp.OutBlock1
+	}
+
+	days := make([]time.Time, 0, len(rows))
+	for _, row := range rows {
+		d, err := time.Parse("2006/01/02", strings.TrimSpace(row.TRD_DD))
+		if err != nil {
+			continue
+		}
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	c.logger.WithFields(map[string]interface{}{
+		"from":  from.Format("2006-01-02"),
+		"to":    to.Format("2006-01-02"),
+		"count": len(days),
+	}).Info("Fetched KRX trading days")
+
+	return days, nil
+}
@@ -0,0 +1,269 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/httputil"
+)
+
+// Channel names (NOTIFY_ROUTES에서 사용)
+const (
+	ChannelTelegram = "telegram"
+	ChannelSlack    = "slack"
+	ChannelWebhook  = "webhook"
+	ChannelEmail    = "email"
+)
+
+// Message is a rendered notification ready for delivery
+type Message struct {
+	EventType  EventType       `json:"event_type"`
+	Severity   Severity        `json:"severity"`
+	Title      string          `json:"title"`
+	Body       string          `json:"body"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Text returns title and body as one plain-text message
+func (m Message) Text() string {
+	return m.Title + "\n" + m.Body
+}
+
+// Channel delivers messages to one destination
+// 재시도는 outbox가 담당하므로 Send는 한 번만 시도
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewChannels builds the channels whose credentials are configured
+func NewChannels(cfg config.NotifyConfig, httpClient *httputil.Client) map[string]Channel {
+	channels := make(map[string]Channel)
+	if cfg.TelegramBotToken != "" && cfg.TelegramChatID != "" {
+		channels[ChannelTelegram] = NewTelegramChannel(cfg.TelegramBotToken, cfg.TelegramChatID, httpClient)
+	}
+	if cfg.SlackWebhookURL != "" {
+		channels[ChannelSlack] = NewSlackChannel(cfg.SlackWebhookURL, httpClient)
+	}
+	if cfg.WebhookURL != "" {
+		channels[ChannelWebhook] = NewWebhookChannel(cfg.WebhookURL, httpClient)
+	}
+	if cfg.SMTPHost != "" && cfg.SMTPFrom != "" && len(cfg.SMTPTo) > 0 {
+		channels[ChannelEmail] = NewEmailChannel(cfg)
+	}
+	return channels
+}
+
+// =============================================================================
+// HTTP channels (Telegram, Slack, Webhook)
+// =============================================================================
+
+// postJSON posts a JSON body and treats non-2xx responses as errors
+func postJSON(ctx context.Context, client *httputil.Client, url string, body interface{}) error {
+	resp, err := client.PostJSON(ctx, url, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// TelegramChannel sends messages through the Telegram Bot API
+type TelegramChannel struct {
+	url    string
+	chatID string
+	client *httputil.Client
+}
+
+// NewTelegramChannel creates a Telegram channel (sendMessage)
+func NewTelegramChannel(botToken, chatID string, client *httputil.Client) *TelegramChannel {
+	return &TelegramChannel{
+		url:    "https://api.telegram.org/bot" + botToken + "/sendMessage",
+		chatID: chatID,
+		client: client,
+	}
+}
+
+// Name implements Channel
+func (c *TelegramChannel) Name() string { return ChannelTelegram }
+
+// Send implements Channel
+func (c *TelegramChannel) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, c.client, c.url, map[string]interface{}{
+		"chat_id":                  c.chatID,
+		"text":                     msg.Text(),
+		"disable_web_page_preview": true,
+	})
+}
+
+// SlackChannel sends messages to a Slack incoming webhook
+type SlackChannel struct {
+	webhookURL string
+	client     *httputil.Client
+}
+
+// NewSlackChannel creates a Slack incoming webhook channel
+func NewSlackChannel(webhookURL string, client *httputil.Client) *SlackChannel {
+	return &SlackChannel{webhookURL: webhookURL, client: client}
+}
+
+// Name implements Channel
+func (c *SlackChannel) Name() string { return ChannelSlack }
+
+// Send implements Channel
+func (c *SlackChannel) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, c.client, c.webhookURL, map[string]interface{}{
+		"text": "*" + msg.Title + "*\n" + msg.Body,
+	})
+}
+
+// WebhookChannel posts the full message (rendered text + event data) as JSON
+type WebhookChannel struct {
+	url    string
+	client *httputil.Client
+}
+
+// NewWebhookChannel creates a generic JSON webhook channel
+func NewWebhookChannel(url string, client *httputil.Client) *WebhookChannel {
+	return &WebhookChannel{url: url, client: client}
+}
+
+// Name implements Channel
+func (c *WebhookChannel) Name() string { return ChannelWebhook }
+
+// Send implements Channel
+func (c *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, c.client, c.url, msg)
+}
+
+// =============================================================================
+// Email (SMTP)
+// =============================================================================
+
+// sendMailFunc matches smtp.SendMail (테스트에서 교체)
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailChannel sends messages over SMTP (서버가 지원하면 STARTTLS)
+type EmailChannel struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	to       []string
+	sendMail sendMailFunc
+}
+
+// NewEmailChannel creates an SMTP channel (SMTP_USER가 비어 있으면 인증 없이 발송)
+func NewEmailChannel(cfg config.NotifyConfig) *EmailChannel {
+	var auth smtp.Auth
+	if cfg.SMTPUser != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	return &EmailChannel{
+		addr:     cfg.SMTPHost + ":" + cfg.SMTPPort,
+		auth:     auth,
+		from:     cfg.SMTPFrom,
+		to:       cfg.SMTPTo,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Name implements Channel
+func (c *EmailChannel) Name() string { return ChannelEmail }
+
+// Send implements Channel
+func (c *EmailChannel) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.sendMail(c.addr, c.auth, c.from, c.to, buildMail(c.from, c.to, msg))
+}
+
+// buildMail formats a UTF-8 plain-text message (RFC 5322)
+func buildMail(from string, to []string, msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	b.WriteString("Subject: " + mimeHeader(msg.Title) + "\r\n")
+	b.WriteString("Date: " + msg.OccurredAt.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// mimeHeader encodes non-ASCII headers (한글 제목)
+func mimeHeader(s string) string {
+	for _, r := range s {
+		if r > 127 {
+			return mime.BEncoding.Encode("UTF-8", s)
+		}
+	}
+	return s
+}
+
+// =============================================================================
+// Fake (tests / local development)
+// =============================================================================
+
+// FakeChannel records messages instead of sending them
+// SetError로 실패를 주입 (재시도/dead-letter 검증용)
+type FakeChannel struct {
+	name string
+
+	mu   sync.Mutex
+	sent []Message
+	err  error
+}
+
+// NewFakeChannel creates a fake channel with the given name
+func NewFakeChannel(name string) *FakeChannel {
+	return &FakeChannel{name: name}
+}
+
+// Name implements Channel
+func (c *FakeChannel) Name() string { return c.name }
+
+// Send implements Channel
+func (c *FakeChannel) Send(ctx context.Context, msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+// SetError makes subsequent sends fail (nil = succeed)
+func (c *FakeChannel) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+// Sent returns a copy of the delivered messages
+func (c *FakeChannel) Sent() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Message(nil), c.sent...)
+}
@@ -0,0 +1,156 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+// EventType identifies what happened (라우팅/템플릿/중복 억제의 기준)
+type EventType string
+
+const (
+	EventExitSignal        EventType = "exit_signal"         // 청산 신호 (PositionMonitor)
+	EventPipelineFailed    EventType = "pipeline_failed"     // S0~S7 파이프라인 실패
+	EventDataQualityFailed EventType = "data_quality_failed" // S0 품질 게이트 미달
+	EventRiskGateBlocked   EventType = "risk_gate_blocked"   // 리스크 게이트 차단 (shadow: 차단 예상)
+	EventJobFailed         EventType = "job_failed"          // 스케줄러 작업 재시도 소진
+	EventTest              EventType = "test"                // quant notify test
+)
+
+// EventTypes lists all routable event types
+var EventTypes = []EventType{
+	EventExitSignal,
+	EventPipelineFailed,
+	EventDataQualityFailed,
+	EventRiskGateBlocked,
+	EventJobFailed,
+	EventTest,
+}
+
+// Severity is the urgency of an event
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Event is a notification request from a subsystem
+// Data는 템플릿 변수이며 웹훅 payload로 그대로 전달됨
+type Event struct {
+	Type       EventType              `json:"type"`
+	Severity   Severity               `json:"severity"`
+	Key        string                 `json:"key"` // 중복 억제 키 (비어 있으면 억제 안 함)
+	Data       map[string]interface{} `json:"data"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// DedupKey returns the outbox dedup key ("" = no dedup)
+func (e Event) DedupKey() string {
+	if e.Key == "" {
+		return ""
+	}
+	return string(e.Type) + ":" + e.Key
+}
+
+// Emitter sends events to the notification service
+// 호출부(스케줄러/오케스트레이터/리스크 게이트)는 nil이면 알림을 생략
+type Emitter interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// ExitSignalEvent builds an exit signal event (같은 종목/사유는 중복 억제 구간 내 1회)
+func ExitSignalEvent(signal *contracts.ExitSignal) Event {
+	return Event{
+		Type:     EventExitSignal,
+		Severity: SeverityWarning,
+		Key:      fmt.Sprintf("%s:%s", signal.Code, signal.Reason),
+		Data: map[string]interface{}{
+			"code":          signal.Code,
+			"name":          signal.Name,
+			"reason":        string(signal.Reason),
+			"current_price": signal.CurrentPrice,
+			"entry_price":   signal.EntryPrice,
+			"pnl_percent":   signal.PnLPercent,
+			"sell_quantity": signal.SellQuantity,
+			"is_partial":    signal.IsPartial,
+			"message":       signal.Message,
+		},
+		OccurredAt: signal.TriggeredAt,
+	}
+}
+
+// PipelineFailedEvent builds a pipeline failure event (run 단위 1회)
+func PipelineFailedEvent(runID string, date time.Time, stage string, err error) Event {
+	return Event{
+		Type:     EventPipelineFailed,
+		Severity: SeverityCritical,
+		Key:      runID,
+		Data: map[string]interface{}{
+			"run_id": runID,
+			"date":   date.Format("2006-01-02"),
+			"stage":  stage,
+			"error":  err.Error(),
+		},
+		OccurredAt: time.Now(),
+	}
+}
+
+// DataQualityFailedEvent builds a data quality gate failure event (날짜 단위 1회)
+func DataQualityFailedEvent(snapshot *contracts.DataQualitySnapshot) Event {
+	return Event{
+		Type:     EventDataQualityFailed,
+		Severity: SeverityCritical,
+		Key:      snapshot.Date.Format("2006-01-02"),
+		Data: map[string]interface{}{
+			"date":          snapshot.Date.Format("2006-01-02"),
+			"quality_score": snapshot.QualityScore,
+			"total_stocks":  snapshot.TotalStocks,
+			"valid_stocks":  snapshot.ValidStocks,
+			"anomalies":     len(snapshot.Anomalies),
+			"quarantined":   len(snapshot.Quarantined),
+		},
+		OccurredAt: time.Now(),
+	}
+}
+
+// RiskGateBlockedEvent builds a risk gate event (enforce 차단=critical, shadow 차단 예상=warning)
+func RiskGateBlockedEvent(runID, mode, message string, blockedOrders []string, enforced bool) Event {
+	severity := SeverityWarning
+	if enforced {
+		severity = SeverityCritical
+	}
+	return Event{
+		Type:     EventRiskGateBlocked,
+		Severity: severity,
+		Key:      runID + ":" + mode,
+		Data: map[string]interface{}{
+			"run_id":         runID,
+			"mode":           mode,
+			"enforced":       enforced,
+			"message":        message,
+			"blocked_orders": blockedOrders,
+		},
+		OccurredAt: time.Now(),
+	}
+}
+
+// JobFailedEvent builds a scheduler job failure event (작업/날짜 단위 1회)
+func JobFailedEvent(job, day string, attempts int, errMsg string) Event {
+	return Event{
+		Type:     EventJobFailed,
+		Severity: SeverityCritical,
+		Key:      job + ":" + day,
+		Data: map[string]interface{}{
+			"job":      job,
+			"day":      day,
+			"attempts": attempts,
+			"error":    errMsg,
+		},
+		OccurredAt: time.Now(),
+	}
+}
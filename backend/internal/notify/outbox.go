@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/queue"
+)
+
+// OutboxStatus is the delivery state of an outbox row
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending" // 발송 대기 (next_attempt_at 이후)
+	OutboxSending OutboxStatus = "sending" // 디스패처가 임대 중 (next_attempt_at까지)
+	OutboxSent    OutboxStatus = "sent"    // 발송 완료
+	OutboxDead    OutboxStatus = "dead"    // 재시도 소진 (dead-letter)
+)
+
+// OutboxEntry is one row of ops.notification_outbox (채널별 1건)
+type OutboxEntry struct {
+	ID            int64           `json:"id"`
+	EventType     EventType       `json:"event_type"`
+	Severity      Severity        `json:"severity"`
+	DedupKey      string          `json:"dedup_key,omitempty"`
+	Channel       string          `json:"channel"`
+	Title         string          `json:"title"`
+	Body          string          `json:"body"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        OutboxStatus    `json:"status"`
+	Attempts      int             `json:"attempts"`
+	MaxAttempts   int             `json:"max_attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	SentAt        *time.Time      `json:"sent_at,omitempty"`
+}
+
+// Message converts the entry back into a deliverable message
+func (e OutboxEntry) Message() Message {
+	return Message{
+		EventType:  e.EventType,
+		Severity:   e.Severity,
+		Title:      e.Title,
+		Body:       e.Body,
+		Data:       e.Payload,
+		OccurredAt: e.CreatedAt,
+	}
+}
+
+// OutboxFilter narrows List results
+type OutboxFilter struct {
+	Status  OutboxStatus
+	Channel string
+	Limit   int
+}
+
+// Store persists the notification outbox
+// ⭐ SSOT: 알림 발송 상태 전이는 Store 구현(Repository)에서만
+type Store interface {
+	// Enqueue inserts pending entries (Notify)
+	Enqueue(ctx context.Context, entries []OutboxEntry) error
+	// HasRecent reports whether dedupKey was enqueued since the given time
+	HasRecent(ctx context.Context, dedupKey string, since time.Time) (bool, error)
+	// Claim leases up to limit due entries (pending 또는 임대 만료된 sending)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxEntry, error)
+	// MarkSent records a successful delivery
+	MarkSent(ctx context.Context, id int64) error
+	// MarkFailed records a failed attempt (dead=true면 dead-letter)
+	MarkFailed(ctx context.Context, id int64, errMsg string, next time.Time, dead bool) error
+	// Defer returns a claimed entry without consuming an attempt (채널 rate limit)
+	Defer(ctx context.Context, id int64, next time.Time) error
+	// List returns recent entries, newest first
+	List(ctx context.Context, filter OutboxFilter) ([]OutboxEntry, error)
+}
+
+// NextAttempt decides the state after a failed attempt (dead=true면 dead-letter)
+// 백오프는 작업 큐와 동일 (queue.Backoff: 30s, 1m, 2m ... 최대 30m)
+func NextAttempt(attempts, maxAttempts int, now time.Time) (time.Time, bool) {
+	if attempts >= maxAttempts {
+		return now, true
+	}
+	return now.Add(queue.Backoff(attempts)), false
+}
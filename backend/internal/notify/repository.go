@@ -0,0 +1,198 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const outboxColumns = `
+	id, event_type, severity, dedup_key, channel, title, body, payload, status,
+	attempts, max_attempts, next_attempt_at, last_error, created_at, sent_at
+`
+
+// Repository stores the outbox in ops.notification_outbox
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new notification outbox repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// Enqueue inserts pending entries in one transaction
+func (r *Repository) Enqueue(ctx context.Context, entries []OutboxEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO ops.notification_outbox
+			(event_type, severity, dedup_key, channel, title, body, payload, max_attempts)
+		VALUES ($1, $2, NULLIF($3::text, ''), $4, $5, $6, $7, $8)
+	`
+	for _, e := range entries {
+		payload := e.Payload
+		if len(payload) == 0 {
+			payload = []byte("{}")
+		}
+		if _, err := tx.Exec(ctx, query,
+			string(e.EventType), string(e.Severity), e.DedupKey, e.Channel, e.Title, e.Body, payload, e.MaxAttempts,
+		); err != nil {
+			return fmt.Errorf("enqueue %s notification: %w", e.Channel, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// HasRecent reports whether dedupKey was enqueued since the given time
+func (r *Repository) HasRecent(ctx context.Context, dedupKey string, since time.Time) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM ops.notification_outbox
+			WHERE dedup_key = $1 AND created_at >= $2
+		)
+	`, dedupKey, since).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check notification dedup: %w", err)
+	}
+	return exists, nil
+}
+
+// Claim leases up to limit due entries
+// FOR UPDATE SKIP LOCKED로 여러 프로세스(api/scheduler/worker)가 같은 알림을 보내지 않음
+func (r *Repository) Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE ops.notification_outbox o SET
+			status = 'sending',
+			attempts = o.attempts + 1,
+			next_attempt_at = NOW() + make_interval(secs => $2::float8),
+			updated_at = NOW()
+		WHERE o.id IN (
+			SELECT id FROM ops.notification_outbox
+			WHERE status IN ('pending', 'sending')
+			  AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+outboxColumns, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim notifications: %w", err)
+	}
+	defer rows.Close()
+
+	return collectEntries(rows)
+}
+
+// MarkSent records a successful delivery
+func (r *Repository) MarkSent(ctx context.Context, id int64) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.notification_outbox SET
+			status = 'sent',
+			last_error = NULL,
+			sent_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'sending'
+	`, id)
+	if err != nil {
+		return fmt.Errorf("mark notification %d sent: %w", id, err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt (retry at next, or dead-letter)
+func (r *Repository) MarkFailed(ctx context.Context, id int64, errMsg string, next time.Time, dead bool) error {
+	status := OutboxPending
+	if dead {
+		status = OutboxDead
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.notification_outbox SET
+			status = $2,
+			next_attempt_at = $3,
+			last_error = $4,
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'sending'
+	`, id, string(status), next, errMsg)
+	if err != nil {
+		return fmt.Errorf("mark notification %d failed: %w", id, err)
+	}
+	return nil
+}
+
+// Defer returns a claimed entry to pending without consuming an attempt
+func (r *Repository) Defer(ctx context.Context, id int64, next time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.notification_outbox SET
+			status = 'pending',
+			attempts = GREATEST(attempts - 1, 0),
+			next_attempt_at = $2,
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'sending'
+	`, id, next)
+	if err != nil {
+		return fmt.Errorf("defer notification %d: %w", id, err)
+	}
+	return nil
+}
+
+// List returns recent entries, newest first
+func (r *Repository) List(ctx context.Context, filter OutboxFilter) ([]OutboxEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+
+	rows, err := r.pool.Query(ctx, `SELECT `+outboxColumns+` FROM ops.notification_outbox
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR channel = $2)
+		ORDER BY id DESC
+		LIMIT $3`, string(filter.Status), filter.Channel, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	return collectEntries(rows)
+}
+
+func collectEntries(rows pgx.Rows) ([]OutboxEntry, error) {
+	result := make([]OutboxEntry, 0)
+	for rows.Next() {
+		var e OutboxEntry
+		var eventType, severity, status string
+		var dedupKey, lastError *string
+
+		if err := rows.Scan(
+			&e.ID, &eventType, &severity, &dedupKey, &e.Channel, &e.Title, &e.Body, &e.Payload, &status,
+			&e.Attempts, &e.MaxAttempts, &e.NextAttemptAt, &lastError, &e.CreatedAt, &e.SentAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan notification: %w", err)
+		}
+
+		e.EventType = EventType(eventType)
+		e.Severity = Severity(severity)
+		e.Status = OutboxStatus(status)
+		if dedupKey != nil {
+			e.DedupKey = *dedupKey
+		}
+		if lastError != nil {
+			e.LastError = *lastError
+		}
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate notifications: %w", err)
+	}
+
+	return result, nil
+}
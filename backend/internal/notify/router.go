@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Router maps event types to channel names
+// NOTIFY_ROUTES 형식: "exit_signal=telegram,slack;job_failed=email;*=slack"
+//   - 이벤트 이름이 정확히 일치하는 규칙이 "*" 규칙보다 우선
+//   - 채널 "*" = 설정된 모든 채널, "none" 또는 빈 값 = 알림 안 함
+type Router struct {
+	rules    map[string][]string
+	channels []string // 설정된 채널 (정렬됨)
+}
+
+// ParseRoutes parses NOTIFY_ROUTES against the configured channel names
+// 알 수 없는 이벤트/채널 이름은 오타일 가능성이 높으므로 에러
+func ParseRoutes(spec string, configured []string) (*Router, error) {
+	channels := append([]string(nil), configured...)
+	sort.Strings(channels)
+
+	known := make(map[string]bool, len(channels))
+	for _, ch := range channels {
+		known[ch] = true
+	}
+
+	r := &Router{rules: make(map[string][]string), channels: channels}
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		event, targets, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid route %q (expected event=channel,...)", rule)
+		}
+		event = strings.TrimSpace(event)
+		if event != "*" && !isEventType(event) {
+			return nil, fmt.Errorf("unknown event type %q in route %q", event, rule)
+		}
+		if _, dup := r.rules[event]; dup {
+			return nil, fmt.Errorf("duplicate route for %q", event)
+		}
+
+		names := make([]string, 0)
+		for _, name := range strings.Split(targets, ",") {
+			name = strings.TrimSpace(name)
+			switch {
+			case name == "" || name == "none":
+				continue
+			case name == "*":
+				names = append(names, name)
+			case !isChannelName(name):
+				return nil, fmt.Errorf("unknown channel %q in route %q", name, rule)
+			default:
+				names = append(names, name)
+			}
+		}
+		r.rules[event] = names
+	}
+
+	return r, nil
+}
+
+// Route returns the configured channels for an event type
+// 규칙에 있지만 자격 증명이 설정되지 않은 채널은 제외
+func (r *Router) Route(event EventType) []string {
+	names, ok := r.rules[string(event)]
+	if !ok {
+		names = r.rules["*"]
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		candidates := []string{name}
+		if name == "*" {
+			candidates = r.channels
+		}
+		for _, ch := range candidates {
+			if seen[ch] || !r.configured(ch) {
+				continue
+			}
+			seen[ch] = true
+			result = append(result, ch)
+		}
+	}
+	return result
+}
+
+func (r *Router) configured(name string) bool {
+	for _, ch := range r.channels {
+		if ch == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isEventType(name string) bool {
+	for _, t := range EventTypes {
+		if string(t) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isChannelName(name string) bool {
+	switch name {
+	case ChannelTelegram, ChannelSlack, ChannelWebhook, ChannelEmail:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoutes(t *testing.T) {
+	configured := []string{ChannelTelegram, ChannelSlack, ChannelEmail}
+
+	tests := []struct {
+		name  string
+		spec  string
+		event EventType
+		want  []string
+	}{
+		{"wildcard to all", "*=*", EventJobFailed, []string{ChannelEmail, ChannelSlack, ChannelTelegram}},
+		{"exact match wins", "exit_signal=telegram;*=slack", EventExitSignal, []string{ChannelTelegram}},
+		{"fallback to wildcard", "exit_signal=telegram;*=slack", EventJobFailed, []string{ChannelSlack}},
+		{"none drops event", "test=none;*=slack", EventTest, []string{}},
+		{"no rule drops event", "exit_signal=telegram", EventJobFailed, []string{}},
+		{"unconfigured channel skipped", "*=webhook,slack", EventTest, []string{ChannelSlack}},
+		{"duplicates removed", "*=slack,*", EventTest, []string{ChannelSlack, ChannelEmail, ChannelTelegram}},
+		{"whitespace tolerated", " job_failed = email , slack ; ", EventJobFailed, []string{ChannelEmail, ChannelSlack}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRoutes(tt.spec, configured)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.Route(tt.event))
+		})
+	}
+}
+
+func TestParseRoutes_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"missing equals", "exit_signal"},
+		{"unknown event", "exit=slack"},
+		{"unknown channel", "*=discord"},
+		{"duplicate rule", "*=slack;*=email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRoutes(tt.spec, []string{ChannelSlack})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/time/rate"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/httputil"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
+)
+
+// Dispatcher settings
+const (
+	pollInterval = 5 * time.Second
+	claimBatch   = 20
+	claimLease   = 2 * time.Minute // 발송 중 프로세스가 죽으면 이후 다시 임대
+	sendTimeout  = 30 * time.Second
+)
+
+// Delivery results (metrics label)
+const (
+	resultSent     = "sent"
+	resultRetry    = "retry"
+	resultDead     = "dead"
+	resultDeferred = "deferred"
+)
+
+// Service routes events to channels through the persisted outbox
+// Notify는 outbox에 적재만 하고, 발송/재시도는 Run(디스패처)이 담당
+// ⭐ SSOT: 알림 발송은 이 Service를 통해서만 (ExitNotifier 구현 포함)
+type Service struct {
+	cfg      config.NotifyConfig
+	store    Store
+	channels map[string]Channel
+	router   *Router
+	limiters map[string]*rate.Limiter
+	logger   *logger.Logger
+	kick     chan struct{}
+	now      func() time.Time
+}
+
+// NewService creates a notification service
+// 라우팅 규칙(NOTIFY_ROUTES)이 잘못되면 에러
+func NewService(cfg config.NotifyConfig, store Store, channels map[string]Channel, log *logger.Logger) (*Service, error) {
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+
+	router, err := ParseRoutes(cfg.Routes, names)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFY_ROUTES: %w", err)
+	}
+
+	limit := rate.Inf
+	if cfg.RatePerMinute > 0 {
+		limit = rate.Every(time.Minute / time.Duration(cfg.RatePerMinute))
+	}
+	limiters := make(map[string]*rate.Limiter, len(channels))
+	for name := range channels {
+		limiters[name] = rate.NewLimiter(limit, max(cfg.RatePerMinute, 1))
+	}
+
+	return &Service{
+		cfg:      cfg,
+		store:    store,
+		channels: channels,
+		router:   router,
+		limiters: limiters,
+		logger:   log,
+		kick:     make(chan struct{}, 1),
+		now:      time.Now,
+	}, nil
+}
+
+// New wires the service from config (PostgreSQL outbox + configured channels)
+func New(cfg *config.Config, pool *pgxpool.Pool, log *logger.Logger) (*Service, error) {
+	// outbox가 재시도를 담당하므로 HTTP 재시도는 끔, URL의 토큰은 로그에서 가림
+	httpClient := httputil.New(cfg, log).DisableRetry().RedactURLs()
+	return NewService(cfg.Notify, NewRepository(pool), NewChannels(cfg.Notify, httpClient), log)
+}
+
+// Channels returns the configured channel names (sorted)
+func (s *Service) Channels() []string {
+	names := make([]string, 0, len(s.channels))
+	for name := range s.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Notify implements Emitter: dedup → route → render → enqueue
+// 알림 실패가 호출부의 본 작업을 막으면 안 되므로 호출부는 에러를 로그만 남김
+func (s *Service) Notify(ctx context.Context, event Event) error {
+	if !s.cfg.Enabled {
+		return nil
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.now()
+	}
+
+	log := s.logger.WithFields(map[string]interface{}{
+		"event": event.Type,
+		"key":   event.Key,
+	})
+
+	dedupKey := event.DedupKey()
+	if dedupKey != "" && s.cfg.DedupWindow > 0 {
+		dup, err := s.store.HasRecent(ctx, dedupKey, s.now().Add(-s.cfg.DedupWindow))
+		if err != nil {
+			return err
+		}
+		if dup {
+			log.Debug("Notification suppressed (duplicate)")
+			return nil
+		}
+	}
+
+	channels := s.router.Route(event.Type)
+	if len(channels) == 0 {
+		log.Debug("Notification not routed to any channel")
+		return nil
+	}
+
+	title, body, err := Render(event, s.cfg.Language)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("marshal %s data: %w", event.Type, err)
+	}
+
+	entries := make([]OutboxEntry, 0, len(channels))
+	for _, ch := range channels {
+		entries = append(entries, OutboxEntry{
+			EventType:   event.Type,
+			Severity:    event.Severity,
+			DedupKey:    dedupKey,
+			Channel:     ch,
+			Title:       title,
+			Body:        body,
+			Payload:     payload,
+			MaxAttempts: s.cfg.MaxAttempts,
+		})
+	}
+	if err := s.store.Enqueue(ctx, entries); err != nil {
+		return err
+	}
+
+	log.WithField("channels", channels).Info("Notification queued")
+
+	// 같은 프로세스에서 디스패처가 돌고 있으면 즉시 발송
+	select {
+	case s.kick <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// NotifyExitSignal implements execution.ExitNotifier
+func (s *Service) NotifyExitSignal(ctx context.Context, signal *contracts.ExitSignal) error {
+	return s.Notify(ctx, ExitSignalEvent(signal))
+}
+
+// SendTest sends a test message directly to one channel (outbox/중복 억제/rate limit 우회)
+func (s *Service) SendTest(ctx context.Context, channel string) error {
+	ch, ok := s.channels[channel]
+	if !ok {
+		return fmt.Errorf("channel %q is not configured (configured: %v)", channel, s.Channels())
+	}
+
+	event := Event{
+		Type:       EventTest,
+		Severity:   SeverityInfo,
+		Data:       map[string]interface{}{"channel": channel},
+		OccurredAt: s.now(),
+	}
+	title, body, err := Render(event, s.cfg.Language)
+	if err != nil {
+		return err
+	}
+	payload, _ := json.Marshal(event.Data)
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	return ch.Send(sendCtx, Message{
+		EventType:  event.Type,
+		Severity:   event.Severity,
+		Title:      title,
+		Body:       body,
+		Data:       payload,
+		OccurredAt: event.OccurredAt,
+	})
+}
+
+// Run dispatches the outbox until ctx is cancelled
+// 여러 프로세스에서 동시에 실행해도 Claim(SKIP LOCKED)으로 중복 발송 없음
+func (s *Service) Run(ctx context.Context) {
+	if !s.cfg.Enabled {
+		return
+	}
+
+	s.logger.WithField("channels", s.Channels()).Info("Notification dispatcher started")
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).Warn("Notification dispatch failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Notification dispatcher stopped")
+			return
+		case <-ticker.C:
+		case <-s.kick:
+		}
+	}
+}
+
+// Dispatch claims due entries and delivers them once; returns the number sent
+func (s *Service) Dispatch(ctx context.Context) (int, error) {
+	entries, err := s.store.Claim(ctx, claimBatch, claimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			// 남은 임대는 claimLease 이후 다시 발송 대상이 됨
+			return sent, ctx.Err()
+		}
+		ok, err := s.deliver(ctx, entry)
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// deliver sends one claimed entry and records the outcome
+func (s *Service) deliver(ctx context.Context, entry OutboxEntry) (bool, error) {
+	log := s.logger.WithFields(map[string]interface{}{
+		"id":       entry.ID,
+		"event":    entry.EventType,
+		"channel":  entry.Channel,
+		"attempts": entry.Attempts,
+	})
+
+	ch, ok := s.channels[entry.Channel]
+	if !ok {
+		// 적재 후 설정에서 채널이 빠진 경우: 시도를 소모해 결국 dead
+		return false, s.fail(ctx, log, entry, fmt.Errorf("channel %q is not configured", entry.Channel))
+	}
+
+	if limiter := s.limiters[entry.Channel]; limiter != nil {
+		r := limiter.ReserveN(s.now(), 1)
+		if delay := r.DelayFrom(s.now()); delay > 0 {
+			r.CancelAt(s.now())
+			metrics.ObserveNotification(entry.Channel, resultDeferred)
+			log.WithField("delay", delay).Debug("Notification deferred (rate limit)")
+			return false, s.store.Defer(ctx, entry.ID, s.now().Add(delay))
+		}
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	err := ch.Send(sendCtx, entry.Message())
+	cancel()
+	if err != nil {
+		return false, s.fail(ctx, log, entry, err)
+	}
+
+	if err := s.store.MarkSent(ctx, entry.ID); err != nil {
+		return false, err
+	}
+	metrics.ObserveNotification(entry.Channel, resultSent)
+	log.Debug("Notification sent")
+	return true, nil
+}
+
+// fail records a failed attempt: retry with backoff or dead-letter
+func (s *Service) fail(ctx context.Context, log *logger.Logger, entry OutboxEntry, sendErr error) error {
+	next, dead := NextAttempt(entry.Attempts, entry.MaxAttempts, s.now())
+	if err := s.store.MarkFailed(ctx, entry.ID, sendErr.Error(), next, dead); err != nil {
+		return err
+	}
+
+	if dead {
+		metrics.ObserveNotification(entry.Channel, resultDead)
+		log.WithError(sendErr).Error("Notification dead-lettered")
+		return nil
+	}
+	metrics.ObserveNotification(entry.Channel, resultRetry)
+	log.WithError(sendErr).WithField("next_attempt_at", next).Warn("Notification failed, will retry")
+	return nil
+}
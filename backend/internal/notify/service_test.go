@@ -0,0 +1,283 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// memStore is an in-memory Store with the same state transitions as Repository
+type memStore struct {
+	mu      sync.Mutex
+	entries []OutboxEntry
+	now     func() time.Time
+}
+
+func (m *memStore) Enqueue(ctx context.Context, entries []OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range entries {
+		e.ID = int64(len(m.entries) + 1)
+		e.Status = OutboxPending
+		e.NextAttemptAt = m.now()
+		e.CreatedAt = m.now()
+		m.entries = append(m.entries, e)
+	}
+	return nil
+}
+
+func (m *memStore) HasRecent(ctx context.Context, dedupKey string, since time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.entries {
+		if e.DedupKey == dedupKey && !e.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	claimed := make([]OutboxEntry, 0)
+	for i := range m.entries {
+		e := &m.entries[i]
+		if len(claimed) == limit {
+			break
+		}
+		if (e.Status == OutboxPending || e.Status == OutboxSending) && !e.NextAttemptAt.After(m.now()) {
+			e.Status = OutboxSending
+			e.Attempts++
+			e.NextAttemptAt = m.now().Add(lease)
+			claimed = append(claimed, *e)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *memStore) update(id int64, fn func(e *OutboxEntry)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.entries {
+		if m.entries[i].ID == id && m.entries[i].Status == OutboxSending {
+			fn(&m.entries[i])
+		}
+	}
+	return nil
+}
+
+func (m *memStore) MarkSent(ctx context.Context, id int64) error {
+	return m.update(id, func(e *OutboxEntry) { e.Status = OutboxSent })
+}
+
+func (m *memStore) MarkFailed(ctx context.Context, id int64, errMsg string, next time.Time, dead bool) error {
+	return m.update(id, func(e *OutboxEntry) {
+		e.Status = OutboxPending
+		if dead {
+			e.Status = OutboxDead
+		}
+		e.NextAttemptAt = next
+		e.LastError = errMsg
+	})
+}
+
+func (m *memStore) Defer(ctx context.Context, id int64, next time.Time) error {
+	return m.update(id, func(e *OutboxEntry) {
+		e.Status = OutboxPending
+		e.Attempts--
+		e.NextAttemptAt = next
+	})
+}
+
+func (m *memStore) List(ctx context.Context, filter OutboxFilter) ([]OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]OutboxEntry(nil), m.entries...), nil
+}
+
+type serviceFixture struct {
+	svc   *Service
+	store *memStore
+	slack *FakeChannel
+	email *FakeChannel
+	clock *time.Time
+}
+
+func newServiceFixture(t *testing.T, cfg config.NotifyConfig) *serviceFixture {
+	t.Helper()
+
+	clock := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+
+	store := &memStore{now: now}
+	slack := NewFakeChannel(ChannelSlack)
+	email := NewFakeChannel(ChannelEmail)
+	log := logger.New(&config.Config{LogLevel: "error", LogFormat: "json"})
+
+	svc, err := NewService(cfg, store, map[string]Channel{ChannelSlack: slack, ChannelEmail: email}, log)
+	require.NoError(t, err)
+	svc.now = now
+
+	return &serviceFixture{svc: svc, store: store, slack: slack, email: email, clock: &clock}
+}
+
+func testNotifyConfig() config.NotifyConfig {
+	return config.NotifyConfig{
+		Enabled:       true,
+		Language:      LanguageKorean,
+		Routes:        "job_failed=slack,email;*=slack",
+		DedupWindow:   10 * time.Minute,
+		RatePerMinute: 60,
+		MaxAttempts:   3,
+	}
+}
+
+func TestService_RoutesAndDelivers(t *testing.T) {
+	f := newServiceFixture(t, testNotifyConfig())
+	ctx := context.Background()
+
+	require.NoError(t, f.svc.Notify(ctx, JobFailedEvent("collect_prices", "2026-10-16", 3, "timeout")))
+	require.NoError(t, f.svc.Notify(ctx, PipelineFailedEvent("run-1", *f.clock, "S2", errors.New("boom"))))
+
+	sent, err := f.svc.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+
+	require.Len(t, f.slack.Sent(), 2)
+	require.Len(t, f.email.Sent(), 1)
+	assert.Equal(t, "[작업 실패] collect_prices (2026-10-16)", f.email.Sent()[0].Title)
+	assert.JSONEq(t, `{"job":"collect_prices","day":"2026-10-16","attempts":3,"error":"timeout"}`, string(f.email.Sent()[0].Data))
+}
+
+func TestService_Dedup(t *testing.T) {
+	f := newServiceFixture(t, testNotifyConfig())
+	ctx := context.Background()
+	event := JobFailedEvent("collect_prices", "2026-10-16", 3, "timeout")
+
+	require.NoError(t, f.svc.Notify(ctx, event))
+	require.NoError(t, f.svc.Notify(ctx, event)) // 중복 억제
+	assert.Len(t, f.store.entries, 2)
+
+	*f.clock = f.clock.Add(11 * time.Minute) // 억제 구간 경과
+	require.NoError(t, f.svc.Notify(ctx, event))
+	assert.Len(t, f.store.entries, 4)
+}
+
+func TestService_Disabled(t *testing.T) {
+	cfg := testNotifyConfig()
+	cfg.Enabled = false
+	f := newServiceFixture(t, cfg)
+
+	require.NoError(t, f.svc.Notify(context.Background(), JobFailedEvent("j", "d", 1, "e")))
+	assert.Empty(t, f.store.entries)
+}
+
+func TestService_RetryThenDead(t *testing.T) {
+	f := newServiceFixture(t, testNotifyConfig())
+	ctx := context.Background()
+	f.slack.SetError(errors.New("status 500"))
+
+	require.NoError(t, f.svc.Notify(ctx, PipelineFailedEvent("run-1", *f.clock, "S2", errors.New("boom"))))
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		_, err := f.svc.Dispatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, attempt, f.store.entries[0].Attempts)
+
+		// 백오프 전에는 다시 발송하지 않음
+		sent, err := f.svc.Dispatch(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+
+		*f.clock = f.clock.Add(time.Hour)
+	}
+
+	entry := f.store.entries[0]
+	assert.Equal(t, OutboxDead, entry.Status)
+	assert.Equal(t, "status 500", entry.LastError)
+	assert.Empty(t, f.slack.Sent())
+}
+
+func TestService_RecoversAfterFailure(t *testing.T) {
+	f := newServiceFixture(t, testNotifyConfig())
+	ctx := context.Background()
+	f.slack.SetError(errors.New("status 502"))
+
+	require.NoError(t, f.svc.Notify(ctx, PipelineFailedEvent("run-1", *f.clock, "S2", errors.New("boom"))))
+	_, err := f.svc.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxPending, f.store.entries[0].Status)
+
+	f.slack.SetError(nil)
+	*f.clock = f.clock.Add(time.Minute)
+	sent, err := f.svc.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, OutboxSent, f.store.entries[0].Status)
+	assert.Equal(t, 2, f.store.entries[0].Attempts)
+}
+
+func TestService_RateLimitDefers(t *testing.T) {
+	cfg := testNotifyConfig()
+	cfg.RatePerMinute = 2
+	cfg.DedupWindow = 0
+	f := newServiceFixture(t, cfg)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, f.svc.Notify(ctx, PipelineFailedEvent("run-1", *f.clock, "S2", errors.New("boom"))))
+	}
+
+	sent, err := f.svc.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+
+	// 한도 초과분은 시도를 소모하지 않고 미뤄짐
+	deferred := f.store.entries[2]
+	assert.Equal(t, OutboxPending, deferred.Status)
+	assert.Zero(t, deferred.Attempts)
+	assert.True(t, deferred.NextAttemptAt.After(*f.clock))
+
+	*f.clock = f.clock.Add(time.Minute)
+	sent, err = f.svc.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, f.slack.Sent(), 3)
+}
+
+func TestService_UnconfiguredChannelFails(t *testing.T) {
+	f := newServiceFixture(t, testNotifyConfig())
+	ctx := context.Background()
+
+	// 적재 후 채널 설정이 빠진 경우
+	require.NoError(t, f.store.Enqueue(ctx, []OutboxEntry{{EventType: EventTest, Channel: ChannelTelegram, MaxAttempts: 1}}))
+	_, err := f.svc.Dispatch(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, OutboxDead, f.store.entries[0].Status)
+	assert.Contains(t, f.store.entries[0].LastError, "not configured")
+}
+
+func TestService_SendTest(t *testing.T) {
+	f := newServiceFixture(t, testNotifyConfig())
+
+	require.NoError(t, f.svc.SendTest(context.Background(), ChannelEmail))
+	require.Len(t, f.email.Sent(), 1)
+	assert.Equal(t, "[테스트] Aegis 알림", f.email.Sent()[0].Title)
+
+	assert.Error(t, f.svc.SendTest(context.Background(), ChannelTelegram))
+}
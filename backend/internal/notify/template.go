@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Languages
+const (
+	LanguageKorean  = "ko"
+	LanguageEnglish = "en"
+)
+
+// messageTemplate is the title/body pair for one event type and language
+type messageTemplate struct {
+	title string
+	body  string
+}
+
+// templates: event type → language → message
+// ⭐ SSOT: 알림 문구는 여기서만 (채널은 렌더링된 Title/Body만 사용)
+var templates = map[EventType]map[string]messageTemplate{
+	EventExitSignal: {
+		LanguageKorean: {
+			title: `[청산 신호] {{.name}}({{.code}}) {{.reason}}`,
+			body: `사유: {{.reason}}{{if .is_partial}} (분할 청산){{end}}
+현재가: {{won .current_price}}원 / 진입가: {{won .entry_price}}원 ({{pct .pnl_percent}})
+매도 수량: {{.sell_quantity}}주{{if .message}}
+{{.message}}{{end}}`,
+		},
+		LanguageEnglish: {
+			title: `[Exit signal] {{.name}} ({{.code}}) {{.reason}}`,
+			body: `Reason: {{.reason}}{{if .is_partial}} (partial){{end}}
+Price: {{won .current_price}} KRW / entry: {{won .entry_price}} KRW ({{pct .pnl_percent}})
+Sell qty: {{.sell_quantity}}{{if .message}}
+{{.message}}{{end}}`,
+		},
+	},
+	EventPipelineFailed: {
+		LanguageKorean: {
+			title: `[파이프라인 실패] {{.date}} {{.stage}}`,
+			body: `실행 ID: {{.run_id}}
+실패 단계: {{.stage}}
+오류: {{.error}}`,
+		},
+		LanguageEnglish: {
+			title: `[Pipeline failed] {{.date}} {{.stage}}`,
+			body: `Run ID: {{.run_id}}
+Failed stage: {{.stage}}
+Error: {{.error}}`,
+		},
+	},
+	EventDataQualityFailed: {
+		LanguageKorean: {
+			title: `[데이터 품질 미달] {{.date}} 점수 {{printf "%.2f" .quality_score}}`,
+			body: `품질 점수: {{printf "%.2f" .quality_score}}
+유효 종목: {{.valid_stocks}} / {{.total_stocks}}
+이상치: {{.anomalies}}건, 격리: {{.quarantined}}종목
+의사결정 파이프라인이 중단되었습니다.`,
+		},
+		LanguageEnglish: {
+			title: `[Data quality failed] {{.date}} score {{printf "%.2f" .quality_score}}`,
+			body: `Quality score: {{printf "%.2f" .quality_score}}
+Valid stocks: {{.valid_stocks}} / {{.total_stocks}}
+Anomalies: {{.anomalies}}, quarantined: {{.quarantined}}
+The decision pipeline was stopped.`,
+		},
+	},
+	EventRiskGateBlocked: {
+		LanguageKorean: {
+			title: `[리스크 게이트] {{if .enforced}}주문 차단{{else}}차단 예상 ({{.mode}}){{end}}`,
+			body: `{{.message}}{{if .blocked_orders}}
+대상 종목: {{join .blocked_orders}}{{end}}{{if .run_id}}
+실행 ID: {{.run_id}}{{end}}`,
+		},
+		LanguageEnglish: {
+			title: `[Risk gate] {{if .enforced}}orders blocked{{else}}would block ({{.mode}}){{end}}`,
+			body: `{{.message}}{{if .blocked_orders}}
+Orders: {{join .blocked_orders}}{{end}}{{if .run_id}}
+Run ID: {{.run_id}}{{end}}`,
+		},
+	},
+	EventJobFailed: {
+		LanguageKorean: {
+			title: `[작업 실패] {{.job}} ({{.day}})`,
+			body: `작업: {{.job}}
+시도: {{.attempts}}회
+오류: {{.error}}`,
+		},
+		LanguageEnglish: {
+			title: `[Job failed] {{.job}} ({{.day}})`,
+			body: `Job: {{.job}}
+Attempts: {{.attempts}}
+Error: {{.error}}`,
+		},
+	},
+	EventTest: {
+		LanguageKorean: {
+			title: `[테스트] Aegis 알림`,
+			body:  `{{.channel}} 채널 연결 확인 메시지입니다.`,
+		},
+		LanguageEnglish: {
+			title: `[Test] Aegis notification`,
+			body:  `Connectivity check for the {{.channel}} channel.`,
+		},
+	},
+}
+
+// templateFuncs take interface{} so that missing keys (nil) render instead of failing
+var templateFuncs = template.FuncMap{
+	"won":  func(v interface{}) string { return formatWon(int64(toFloat(v))) },
+	"pct":  func(v interface{}) string { return fmt.Sprintf("%+.2f%%", toFloat(v)) },
+	"join": func(v []string) string { return strings.Join(v, ", ") },
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+// Render formats an event as a title/body in the given language (미지원 언어는 한국어)
+func Render(event Event, lang string) (string, string, error) {
+	byLang, ok := templates[event.Type]
+	if !ok {
+		return "", "", fmt.Errorf("no template for event %s", event.Type)
+	}
+	tmpl, ok := byLang[lang]
+	if !ok {
+		tmpl = byLang[LanguageKorean]
+	}
+
+	title, err := execute(string(event.Type)+".title", tmpl.title, event.Data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := execute(string(event.Type)+".body", tmpl.body, event.Data)
+	if err != nil {
+		return "", "", err
+	}
+	return title, body, nil
+}
+
+func execute(name, text string, data map[string]interface{}) (string, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// formatWon formats an amount with thousands separators (1234567 → 1,234,567)
+func formatWon(v int64) string {
+	s := fmt.Sprintf("%d", v)
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+
+	if neg {
+		return "-" + b.String()
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+func TestRender_ExitSignal(t *testing.T) {
+	event := ExitSignalEvent(&contracts.ExitSignal{
+		Code:         "005930",
+		Name:         "삼성전자",
+		Reason:       "STOP_LOSS",
+		CurrentPrice: 65000,
+		EntryPrice:   70000,
+		PnLPercent:   -7.14,
+		SellQuantity: 10,
+		TriggeredAt:  time.Now(),
+	})
+
+	title, body, err := Render(event, LanguageKorean)
+	require.NoError(t, err)
+	assert.Equal(t, "[청산 신호] 삼성전자(005930) STOP_LOSS", title)
+	assert.Contains(t, body, "현재가: 65,000원 / 진입가: 70,000원 (-7.14%)")
+	assert.Contains(t, body, "매도 수량: 10주")
+
+	title, body, err = Render(event, LanguageEnglish)
+	require.NoError(t, err)
+	assert.Equal(t, "[Exit signal] 삼성전자 (005930) STOP_LOSS", title)
+	assert.Contains(t, body, "Sell qty: 10")
+}
+
+func TestRender_FallsBackToKorean(t *testing.T) {
+	event := JobFailedEvent("collect_prices", "2026-10-16", 3, "timeout")
+
+	title, _, err := Render(event, "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "[작업 실패] collect_prices (2026-10-16)", title)
+}
+
+func TestRender_AllEventTypes(t *testing.T) {
+	for _, eventType := range EventTypes {
+		for _, lang := range []string{LanguageKorean, LanguageEnglish} {
+			// 데이터가 비어 있어도 (missingkey=zero) 렌더링 실패 없음
+			_, _, err := Render(Event{Type: eventType}, lang)
+			assert.NoError(t, err, "%s/%s", eventType, lang)
+		}
+	}
+}
+
+func TestFormatWon(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1,000"},
+		{1234567, "1,234,567"},
+		{-65000, "-65,000"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatWon(tt.in))
+	}
+}
@@ -23,7 +23,16 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 }
 
 // SaveTargetPortfolio saves target portfolio to database
+// 계좌 × 전략별로 저장 (같은 날짜의 다른 계좌/전략 목표는 유지)
 func (r *Repository) SaveTargetPortfolio(ctx context.Context, target *contracts.TargetPortfolio) error {
+	if target.StrategyID == "" {
+		return fmt.Errorf("target portfolio has no strategy_id")
+	}
+	accountID := target.AccountID
+	if accountID == "" {
+		accountID = contracts.DefaultAccountID
+	}
+
 	// Begin transaction
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -31,8 +40,11 @@ func (r *Repository) SaveTargetPortfolio(ctx context.Context, target *contracts.
 	}
 	defer tx.Rollback(ctx)
 
-	// Delete existing positions for the date
-	_, err = tx.Exec(ctx, "DELETE FROM portfolio.target_positions WHERE target_date = $1", target.Date)
+	// Delete existing positions for the date, account and strategy
+	_, err = tx.Exec(ctx,
+		"DELETE FROM portfolio.target_positions WHERE target_date = $1 AND account_id = $2 AND strategy_id = $3",
+		target.Date, accountID, target.StrategyID,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to delete old positions: %w", err)
 	}
@@ -41,13 +53,15 @@ func (r *Repository) SaveTargetPortfolio(ctx context.Context, target *contracts.
 	// ⭐ P0 수정: target_qty → target_value (금액 기반)
 	query := `
 		INSERT INTO portfolio.target_positions (
-			target_date, stock_code, stock_name, weight, target_value, action, reason
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			target_date, stock_code, stock_name, weight, target_value, action, reason,
+			account_id, strategy_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	for _, pos := range target.Positions {
 		_, err := tx.Exec(ctx, query,
 			target.Date, pos.Code, pos.Name, pos.Weight, pos.TargetValue, pos.Action, pos.Reason,
+			accountID, target.StrategyID,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert position: %w", err)
@@ -57,9 +71,9 @@ func (r *Repository) SaveTargetPortfolio(ctx context.Context, target *contracts.
 	// Save portfolio summary
 	summaryQuery := `
 		INSERT INTO portfolio.portfolio_snapshots (
-			snapshot_date, total_positions, total_weight, cash_reserve, created_at
-		) VALUES ($1, $2, $3, $4, NOW())
-		ON CONFLICT (snapshot_date) DO UPDATE SET
+			snapshot_date, strategy_id, account_id, total_positions, total_weight, cash_reserve, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (snapshot_date, account_id, strategy_id) DO UPDATE SET
 			total_positions = EXCLUDED.total_positions,
 			total_weight = EXCLUDED.total_weight,
 			cash_reserve = EXCLUDED.cash_reserve,
@@ -67,7 +81,7 @@ func (r *Repository) SaveTargetPortfolio(ctx context.Context, target *contracts.
 	`
 
 	_, err = tx.Exec(ctx, summaryQuery,
-		target.Date, len(target.Positions), target.TotalWeight(), target.Cash,
+		target.Date, target.StrategyID, accountID, len(target.Positions), target.TotalWeight(), target.Cash,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save portfolio snapshot: %w", err)
@@ -81,25 +95,27 @@ func (r *Repository) SaveTargetPortfolio(ctx context.Context, target *contracts.
 	return nil
 }
 
-// GetTargetPortfolio retrieves target portfolio for a date
+// GetTargetPortfolio retrieves an account strategy's target portfolio for a date
 // ⭐ P0 수정: target_qty → target_value
-func (r *Repository) GetTargetPortfolio(ctx context.Context, date time.Time) (*contracts.TargetPortfolio, error) {
+func (r *Repository) GetTargetPortfolio(ctx context.Context, accountID, strategyID string, date time.Time) (*contracts.TargetPortfolio, error) {
 	query := `
 		SELECT stock_code, stock_name, weight, target_value, action, reason
 		FROM portfolio.target_positions
-		WHERE target_date = $1
+		WHERE target_date = $1 AND account_id = $2 AND strategy_id = $3
 		ORDER BY weight DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, date)
+	rows, err := r.pool.Query(ctx, query, date, accountID, strategyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query target positions: %w", err)
 	}
 	defer rows.Close()
 
 	portfolio := &contracts.TargetPortfolio{
-		Date:      date,
-		Positions: make([]contracts.TargetPosition, 0),
+		Date:       date,
+		StrategyID: strategyID,
+		AccountID:  accountID,
+		Positions:  make([]contracts.TargetPosition, 0),
 	}
 
 	for rows.Next() {
@@ -118,8 +134,8 @@ func (r *Repository) GetTargetPortfolio(ctx context.Context, date time.Time) (*c
 	// Get cash reserve from snapshot
 	var cashReserve float64
 	err = r.pool.QueryRow(ctx,
-		"SELECT cash_reserve FROM portfolio.portfolio_snapshots WHERE snapshot_date = $1",
-		date,
+		"SELECT cash_reserve FROM portfolio.portfolio_snapshots WHERE snapshot_date = $1 AND account_id = $2 AND strategy_id = $3",
+		date, accountID, strategyID,
 	).Scan(&cashReserve)
 
 	if err != nil && err != pgx.ErrNoRows {
@@ -0,0 +1,186 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status is the lifecycle state of a queued job
+type Status string
+
+const (
+	StatusQueued    Status = "queued"    // 임대 대기 (run_at 이후)
+	StatusRunning   Status = "running"   // 워커가 임대 중 (leased_until까지)
+	StatusDone      Status = "done"      // 성공
+	StatusDead      Status = "dead"      // 재시도 소진 또는 영구 실패 (dead-letter)
+	StatusCancelled Status = "cancelled" // 취소 (실행 전, 또는 실행 중 취소 요청)
+)
+
+// IsTerminal reports whether the job will not run again without a manual retry
+func (s Status) IsTerminal() bool {
+	return s == StatusDone || s == StatusDead || s == StatusCancelled
+}
+
+// Job types
+const (
+	TypeScheduledJob = "scheduled_job" // 스케줄러 작업 실행 (ScheduledJobPayload)
+	TypeCollectData  = "collect_data"  // API 데이터 수집 요청 (CollectDataPayload)
+)
+
+// Priorities (클수록 먼저 임대)
+const (
+	PriorityLow    = -10
+	PriorityNormal = 0
+	PriorityHigh   = 10
+)
+
+// Retry policy defaults
+const (
+	DefaultMaxAttempts = 3
+	baseBackoff        = 30 * time.Second
+	maxBackoff         = 30 * time.Minute
+)
+
+// ScheduledJobPayload runs a registered scheduler job by name
+type ScheduledJobPayload struct {
+	Job         string    `json:"job"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Deadline    time.Time `json:"deadline,omitempty"` // 스케줄러 실행 기한 (워커도 같은 기한 적용)
+}
+
+// CollectDataPayload is a data collection request (POST /api/data/collect)
+type CollectDataPayload struct {
+	Type string `json:"type"` // all, prices, investor, disclosure, market_caps
+	From string `json:"from"` // YYYY-MM-DD
+	To   string `json:"to"`   // YYYY-MM-DD
+}
+
+// CollectTypes lists valid CollectDataPayload types
+var CollectTypes = []string{"all", "prices", "investor", "disclosure", "market_caps"}
+
+// Range validates the payload and returns the date range (기본: 최근 30일)
+func (p CollectDataPayload) Range(now time.Time) (time.Time, time.Time, error) {
+	valid := false
+	for _, t := range CollectTypes {
+		if p.Type == t {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid collection type %q (valid: all, prices, investor, disclosure, market_caps)", p.Type)
+	}
+
+	from, to := now.AddDate(0, 0, -30), now
+	var err error
+	if p.From != "" {
+		if from, err = time.Parse("2006-01-02", p.From); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'from' date format (expected YYYY-MM-DD)")
+		}
+	}
+	if p.To != "" {
+		if to, err = time.Parse("2006-01-02", p.To); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'to' date format (expected YYYY-MM-DD)")
+		}
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("'from' is after 'to'")
+	}
+	return from, to, nil
+}
+
+// Job is one row of ops.job_queue
+type Job struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Priority    int             `json:"priority"`
+	Status      Status          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LeasedUntil *time.Time      `json:"leased_until,omitempty"`
+	LockedBy    string          `json:"locked_by,omitempty"`
+	DedupKey    string          `json:"dedup_key,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+
+	Progress          json.RawMessage `json:"progress,omitempty"`            // 실행 중 보고된 진행률 (ReportProgress)
+	CancelRequestedAt *time.Time      `json:"cancel_requested_at,omitempty"` // 실행 중 취소 요청
+}
+
+// EnqueueOptions controls how a job is queued
+type EnqueueOptions struct {
+	Priority    int
+	MaxAttempts int       // 0 = DefaultMaxAttempts
+	RunAt       time.Time // zero = 즉시
+	DedupKey    string    // 같은 키가 대기/실행 중이면 기존 작업 반환
+}
+
+// Backoff returns the delay before retrying after the given attempt (1-based)
+// 30s, 1m, 2m, 4m ... 최대 30m
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := baseBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+// NextAttempt decides what happens to a failed job
+// ⭐ SSOT: 재시도/dead-letter 판정은 이 함수에서만
+func NextAttempt(attempts, maxAttempts int, permanent bool, now time.Time) (Status, time.Time) {
+	if permanent || attempts >= maxAttempts {
+		return StatusDead, now
+	}
+	return StatusQueued, now.Add(Backoff(attempts))
+}
+
+// Handler executes one job; the result is stored as JSON on success
+type Handler func(ctx context.Context, job *Job) (interface{}, error)
+
+// Typed adapts a handler taking a decoded payload
+// 페이로드 디코딩 실패는 재시도해도 같으므로 영구 실패로 처리
+func Typed[T any](fn func(ctx context.Context, payload T) (interface{}, error)) Handler {
+	return func(ctx context.Context, job *Job) (interface{}, error) {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, Permanent(fmt.Errorf("decode %s payload: %w", job.Type, err))
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// permanentError marks an error that must not be retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the job goes straight to dead-letter
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{6, 16 * time.Minute},
+		{7, 30 * time.Minute},
+		{50, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt_%d", tt.attempt), func(t *testing.T) {
+			assert.Equal(t, tt.want, Backoff(tt.attempt))
+		})
+	}
+}
+
+func TestNextAttempt(t *testing.T) {
+	now := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		attempts    int
+		maxAttempts int
+		permanent   bool
+		wantStatus  Status
+		wantRunAt   time.Time
+	}{
+		{"first failure retries", 1, 3, false, StatusQueued, now.Add(30 * time.Second)},
+		{"second failure backs off", 2, 3, false, StatusQueued, now.Add(time.Minute)},
+		{"attempts exhausted", 3, 3, false, StatusDead, now},
+		{"single attempt job", 1, 1, false, StatusDead, now},
+		{"permanent error", 1, 3, true, StatusDead, now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, runAt := NextAttempt(tt.attempts, tt.maxAttempts, tt.permanent, now)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantRunAt, runAt)
+		})
+	}
+}
+
+func TestStatus_IsTerminal(t *testing.T) {
+	assert.False(t, StatusQueued.IsTerminal())
+	assert.False(t, StatusRunning.IsTerminal())
+	assert.True(t, StatusDone.IsTerminal())
+	assert.True(t, StatusDead.IsTerminal())
+	assert.True(t, StatusCancelled.IsTerminal())
+}
+
+func TestTyped(t *testing.T) {
+	var got CollectDataPayload
+	h := Typed(func(ctx context.Context, p CollectDataPayload) (interface{}, error) {
+		got = p
+		return map[string]string{"type": p.Type}, nil
+	})
+
+	payload, err := json.Marshal(CollectDataPayload{Type: "prices", From: "2026-10-01", To: "2026-10-16"})
+	require.NoError(t, err)
+
+	result, err := h(context.Background(), &Job{Type: TypeCollectData, Payload: payload})
+	require.NoError(t, err)
+	assert.Equal(t, CollectDataPayload{Type: "prices", From: "2026-10-01", To: "2026-10-16"}, got)
+	assert.Equal(t, map[string]string{"type": "prices"}, result)
+
+	_, err = h(context.Background(), &Job{Type: TypeCollectData, Payload: json.RawMessage(`{"type": 1}`)})
+	require.Error(t, err)
+	assert.True(t, IsPermanent(err), "undecodable payload is not retried")
+}
+
+func TestPermanent(t *testing.T) {
+	base := errors.New("bad request")
+
+	assert.Nil(t, Permanent(nil))
+	assert.False(t, IsPermanent(base))
+
+	err := fmt.Errorf("collect: %w", Permanent(base))
+	assert.True(t, IsPermanent(err))
+	assert.ErrorIs(t, err, base)
+	assert.Equal(t, "collect: bad request", err.Error())
+}
+
+func TestCollectDataPayload_Range(t *testing.T) {
+	now := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+
+	from, to, err := CollectDataPayload{Type: "all"}.Range(now)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -30), from)
+	assert.Equal(t, now, to)
+
+	from, to, err = CollectDataPayload{Type: "prices", From: "2026-10-01", To: "2026-10-02"}.Range(now)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-01", from.Format("2006-01-02"))
+	assert.Equal(t, "2026-10-02", to.Format("2006-01-02"))
+
+	for _, p := range []CollectDataPayload{
+		{Type: "ticks"},
+		{Type: "prices", From: "10/01/2026"},
+		{Type: "prices", To: "yesterday"},
+		{Type: "prices", From: "2026-10-03", To: "2026-10-02"},
+	} {
+		_, _, err := p.Range(now)
+		assert.Error(t, err, "%+v", p)
+	}
+}
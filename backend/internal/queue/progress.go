@@ -0,0 +1,50 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// ErrCancelRequested is the cancel cause of a job whose cancellation was requested (Repository.Cancel)
+var ErrCancelRequested = errors.New("job cancellation requested")
+
+type progressKey struct{}
+
+// progressSink holds the latest progress of a running job until the worker stores it
+type progressSink struct {
+	mu     sync.Mutex
+	latest []byte
+	dirty  bool
+}
+
+// ReportProgress records the running job's progress (JSON, 최신 값만 주기적으로 저장)
+// 워커 밖(테스트, CLI 직접 실행)에서는 no-op
+func ReportProgress(ctx context.Context, v interface{}) {
+	sink, ok := ctx.Value(progressKey{}).(*progressSink)
+	if !ok {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	sink.mu.Lock()
+	sink.latest = data
+	sink.dirty = true
+	sink.mu.Unlock()
+}
+
+// take returns the progress reported since the last call (nil = 변경 없음)
+func (s *progressSink) take() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+	s.dirty = false
+	return s.latest
+}
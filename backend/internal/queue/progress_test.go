@@ -0,0 +1,23 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportProgress(t *testing.T) {
+	sink := &progressSink{}
+	ctx := context.WithValue(context.Background(), progressKey{}, sink)
+
+	assert.Nil(t, sink.take(), "nothing reported yet")
+
+	ReportProgress(ctx, map[string]int{"done": 1})
+	ReportProgress(ctx, map[string]int{"done": 2}) // 최신 값만 유지
+	assert.JSONEq(t, `{"done":2}`, string(sink.take()))
+	assert.Nil(t, sink.take(), "unchanged since last take")
+
+	// 워커 밖에서는 no-op
+	assert.NotPanics(t, func() { ReportProgress(context.Background(), map[string]int{"done": 3}) })
+}
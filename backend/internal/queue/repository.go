@@ -0,0 +1,453 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uniqueViolation is the PostgreSQL error code for unique index conflicts
+const uniqueViolation = "23505"
+
+const jobColumns = `
+	id, job_type, payload, priority, status, attempts, max_attempts, run_at,
+	leased_until, locked_by, dedup_key, last_error, result, created_at, started_at, finished_at,
+	progress, cancel_requested_at
+`
+
+// ListFilter narrows List results
+type ListFilter struct {
+	Status Status
+	Type   string
+	Limit  int
+}
+
+// Repository stores jobs in ops.job_queue
+// ⭐ SSOT: 작업 큐 상태 전이는 이 Repository에서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new job queue repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// Enqueue adds a job and returns its ID
+// DedupKey가 대기/실행 중인 작업과 겹치면 새로 만들지 않고 기존 ID를 반환 (created=false)
+func (r *Repository) Enqueue(ctx context.Context, jobType string, payload interface{}, opts EnqueueOptions) (int64, bool, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, false, fmt.Errorf("marshal %s payload: %w", jobType, err)
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.RunAt.IsZero() {
+		opts.RunAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO ops.job_queue (job_type, payload, priority, max_attempts, run_at, dedup_key)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6::text, ''))
+		ON CONFLICT (dedup_key) WHERE status IN ('queued', 'running') DO NOTHING
+		RETURNING id
+	`
+
+	var id int64
+	err = r.pool.QueryRow(ctx, query, jobType, body, opts.Priority, opts.MaxAttempts, opts.RunAt, opts.DedupKey).Scan(&id)
+	if err == nil {
+		return id, true, nil
+	}
+	if err != pgx.ErrNoRows {
+		return 0, false, fmt.Errorf("enqueue %s: %w", jobType, err)
+	}
+
+	// 중복: 대기/실행 중인 기존 작업
+	err = r.pool.QueryRow(ctx, `
+		SELECT id FROM ops.job_queue
+		WHERE dedup_key = $1 AND status IN ('queued', 'running')
+	`, opts.DedupKey).Scan(&id)
+	if err == pgx.ErrNoRows {
+		// 그 사이 기존 작업이 끝났으면 다시 시도
+		return r.Enqueue(ctx, jobType, payload, opts)
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("find duplicate %s: %w", jobType, err)
+	}
+	return id, false, nil
+}
+
+// Lease claims the next ready job for a worker (nil if none)
+// FOR UPDATE SKIP LOCKED로 여러 워커가 같은 작업을 잡지 않음
+func (r *Repository) Lease(ctx context.Context, workerID string, types []string, visibility time.Duration) (*Job, error) {
+	query := `
+		UPDATE ops.job_queue q SET
+			status = 'running',
+			attempts = q.attempts + 1,
+			locked_by = $1,
+			leased_until = NOW() + make_interval(secs => $2::float8),
+			started_at = NOW(),
+			progress = NULL,
+			cancel_requested_at = NULL,
+			updated_at = NOW()
+		WHERE q.id = (
+			SELECT id FROM ops.job_queue
+			WHERE status = 'queued'
+			  AND run_at <= NOW()
+			  AND (cardinality($3::text[]) = 0 OR job_type = ANY($3))
+			ORDER BY priority DESC, run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+	if types == nil {
+		types = []string{}
+	}
+
+	job, err := scanJob(r.pool.QueryRow(ctx, query, workerID, visibility.Seconds(), types))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lease job: %w", err)
+	}
+	return job, nil
+}
+
+// Heartbeat extends the lease; false means the lease was lost (reaped or cancelled)
+func (r *Repository) Heartbeat(ctx context.Context, id int64, workerID string, visibility time.Duration) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue SET
+			leased_until = NOW() + make_interval(secs => $3::float8),
+			updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`, id, workerID, visibility.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("heartbeat job %d: %w", id, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Complete marks a leased job as done and stores its result
+func (r *Repository) Complete(ctx context.Context, id int64, workerID string, result interface{}) error {
+	var body []byte
+	if result != nil {
+		var err error
+		if body, err = json.Marshal(result); err != nil {
+			return fmt.Errorf("marshal job %d result: %w", id, err)
+		}
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue SET
+			status = 'done',
+			result = $3,
+			last_error = NULL,
+			leased_until = NULL,
+			finished_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`, id, workerID, body)
+	if err != nil {
+		return fmt.Errorf("complete job %d: %w", id, err)
+	}
+	return nil
+}
+
+// Fail records a failed attempt: requeue with backoff or dead-letter
+func (r *Repository) Fail(ctx context.Context, job *Job, workerID string, jobErr error) (Status, error) {
+	status, runAt := NextAttempt(job.Attempts, job.MaxAttempts, IsPermanent(jobErr), time.Now())
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue SET
+			status = $3,
+			run_at = $4,
+			last_error = $5,
+			locked_by = NULL,
+			leased_until = NULL,
+			finished_at = CASE WHEN $3 = 'dead' THEN NOW() END,
+			updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`, job.ID, workerID, string(status), runAt, jobErr.Error())
+	if err != nil {
+		return "", fmt.Errorf("fail job %d: %w", job.ID, err)
+	}
+	return status, nil
+}
+
+// SyncProgress stores the latest progress (nil = unchanged) and reports whether cancellation was requested
+// 임대를 잃었으면 false (Heartbeat가 처리)
+func (r *Repository) SyncProgress(ctx context.Context, id int64, workerID string, progress []byte) (bool, error) {
+	var cancelRequested bool
+	err := r.pool.QueryRow(ctx, `
+		UPDATE ops.job_queue SET
+			progress = COALESCE($3, progress),
+			updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+		RETURNING cancel_requested_at IS NOT NULL
+	`, id, workerID, progress).Scan(&cancelRequested)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("sync job %d progress: %w", id, err)
+	}
+	return cancelRequested, nil
+}
+
+// MarkCancelled ends a running job whose cancellation was requested
+func (r *Repository) MarkCancelled(ctx context.Context, id int64, workerID string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue SET
+			status = 'cancelled',
+			last_error = 'cancelled by request',
+			locked_by = NULL,
+			leased_until = NULL,
+			finished_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`, id, workerID)
+	if err != nil {
+		return fmt.Errorf("mark job %d cancelled: %w", id, err)
+	}
+	return nil
+}
+
+// Release returns a leased job to the queue without consuming an attempt (shutdown)
+func (r *Repository) Release(ctx context.Context, id int64, workerID string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue SET
+			status = 'queued',
+			attempts = GREATEST(attempts - 1, 0),
+			locked_by = NULL,
+			leased_until = NULL,
+			run_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`, id, workerID)
+	if err != nil {
+		return fmt.Errorf("release job %d: %w", id, err)
+	}
+	return nil
+}
+
+// ReapExpired requeues (or dead-letters) jobs whose lease expired without a heartbeat
+func (r *Repository) ReapExpired(ctx context.Context) (int, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue SET
+			status = CASE WHEN attempts >= max_attempts THEN 'dead' ELSE 'queued' END,
+			finished_at = CASE WHEN attempts >= max_attempts THEN NOW() END,
+			last_error = 'visibility timeout expired (lease held by ' || COALESCE(locked_by, '?') || ')',
+			run_at = NOW(),
+			locked_by = NULL,
+			leased_until = NULL,
+			updated_at = NOW()
+		WHERE status = 'running' AND leased_until < NOW()
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("reap expired jobs: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// Cancel cancels a queued job immediately, or requests cancellation of a running job
+// 실행 중 작업은 워커가 다음 진행률 저장 때 요청을 확인하고 컨텍스트를 취소해 cancelled로 종료
+func (r *Repository) Cancel(ctx context.Context, id int64) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue SET
+			status = CASE WHEN status = 'queued' THEN 'cancelled' ELSE status END,
+			finished_at = CASE WHEN status = 'queued' THEN NOW() ELSE finished_at END,
+			cancel_requested_at = CASE WHEN status = 'running' THEN NOW() ELSE cancel_requested_at END,
+			updated_at = NOW()
+		WHERE id = $1
+		  AND (status = 'queued' OR (status = 'running' AND cancel_requested_at IS NULL))
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("cancel job %d: %w", id, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ErrActiveDuplicate is returned by Retry when another queued/running job holds the same dedup key
+var ErrActiveDuplicate = errors.New("active job with the same dedup key exists")
+
+// Retry requeues a dead or cancelled job with a fresh attempt budget
+// 같은 dedup_key의 대기/실행 중 작업이 있으면 재시도하지 않고 ErrActiveDuplicate (부분 유니크 인덱스 보호)
+func (r *Repository) Retry(ctx context.Context, id int64) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE ops.job_queue q SET
+			status = 'queued',
+			attempts = 0,
+			run_at = NOW(),
+			finished_at = NULL,
+			cancel_requested_at = NULL,
+			updated_at = NOW()
+		WHERE q.id = $1 AND q.status IN ('dead', 'cancelled')
+		  AND NOT EXISTS (
+			SELECT 1 FROM ops.job_queue d
+			WHERE d.dedup_key = q.dedup_key AND d.id <> q.id
+			  AND d.status IN ('queued', 'running')
+		  )
+	`, id)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		// 검사와 갱신 사이에 같은 키 작업이 등록됨
+		return false, r.activeDuplicateError(ctx, id)
+	}
+	if err != nil {
+		return false, fmt.Errorf("retry job %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 1 {
+		return true, nil
+	}
+	return false, r.activeDuplicateError(ctx, id)
+}
+
+// activeDuplicateError reports the queued/running job blocking a retry (nil if none)
+func (r *Repository) activeDuplicateError(ctx context.Context, id int64) error {
+	var activeID int64
+	var dedupKey string
+	err := r.pool.QueryRow(ctx, `
+		SELECT d.id, d.dedup_key
+		FROM ops.job_queue q
+		JOIN ops.job_queue d ON d.dedup_key = q.dedup_key AND d.id <> q.id
+		WHERE q.id = $1 AND d.status IN ('queued', 'running')
+		ORDER BY d.id
+		LIMIT 1
+	`, id).Scan(&activeID, &dedupKey)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find duplicate of job %d: %w", id, err)
+	}
+	return fmt.Errorf("retry job %d: %w (job %d, dedup_key=%s)", id, ErrActiveDuplicate, activeID, dedupKey)
+}
+
+// Get returns a job by ID (nil if not found)
+func (r *Repository) Get(ctx context.Context, id int64) (*Job, error) {
+	job, err := scanJob(r.pool.QueryRow(ctx, `SELECT `+jobColumns+` FROM ops.job_queue WHERE id = $1`, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get job %d: %w", id, err)
+	}
+	return job, nil
+}
+
+// List returns recent jobs, newest first
+func (r *Repository) List(ctx context.Context, filter ListFilter) ([]Job, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM ops.job_queue
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR job_type = $2)
+		ORDER BY id DESC
+		LIMIT $3`
+
+	rows, err := r.pool.Query(ctx, query, string(filter.Status), filter.Type, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]Job, 0)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan job: %w", err)
+		}
+		result = append(result, *job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate jobs: %w", err)
+	}
+
+	return result, nil
+}
+
+// Stats counts jobs per status
+func (r *Repository) Stats(ctx context.Context) (map[Status]int, error) {
+	rows, err := r.pool.Query(ctx, `SELECT status, COUNT(*) FROM ops.job_queue GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("query job stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[Status]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("scan job stats: %w", err)
+		}
+		stats[Status(status)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate job stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Wait polls until the job reaches a terminal status or ctx ends
+func (r *Repository) Wait(ctx context.Context, id int64, poll time.Duration) (*Job, error) {
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		job, err := r.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil {
+			return nil, fmt.Errorf("job %d not found", id)
+		}
+		if job.Status.IsTerminal() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func scanJob(row pgx.Row) (*Job, error) {
+	var job Job
+	var status string
+	var lockedBy, dedupKey, lastError *string
+
+	err := row.Scan(
+		&job.ID, &job.Type, &job.Payload, &job.Priority, &status, &job.Attempts, &job.MaxAttempts, &job.RunAt,
+		&job.LeasedUntil, &lockedBy, &dedupKey, &lastError, &job.Result, &job.CreatedAt, &job.StartedAt, &job.FinishedAt,
+		&job.Progress, &job.CancelRequestedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = Status(status)
+	if lockedBy != nil {
+		job.LockedBy = *lockedBy
+	}
+	if dedupKey != nil {
+		job.DedupKey = *dedupKey
+	}
+	if lastError != nil {
+		job.LastError = *lastError
+	}
+	return &job, nil
+}
@@ -0,0 +1,345 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// WorkerConfig controls leasing and shutdown
+type WorkerConfig struct {
+	Concurrency       int           // 동시 실행 작업 수
+	PollInterval      time.Duration // 빈 큐 폴링 간격
+	VisibilityTimeout time.Duration // 임대 유지 시간 (heartbeat로 연장)
+	ReapInterval      time.Duration // 만료 임대 회수 주기
+	ShutdownTimeout   time.Duration // 종료 시 실행 중 작업 대기 시간 (초과 시 취소 후 큐 반환)
+	ProgressInterval  time.Duration // 진행률 저장 및 취소 요청 확인 주기
+}
+
+// DefaultWorkerConfig returns the default worker settings
+func DefaultWorkerConfig() WorkerConfig {
+	return WorkerConfig{
+		Concurrency:       3,
+		PollInterval:      time.Second,
+		VisibilityTimeout: 5 * time.Minute,
+		ReapInterval:      30 * time.Second,
+		ShutdownTimeout:   30 * time.Second,
+		ProgressInterval:  2 * time.Second,
+	}
+}
+
+// Worker leases jobs from the queue and runs registered handlers
+// ⭐ SSOT: 큐 작업 실행은 이 Worker에서만
+type Worker struct {
+	repo     *Repository
+	config   WorkerConfig
+	id       string
+	handlers map[string]Handler
+	logger   *logger.Logger
+}
+
+// NewWorker creates a new queue worker
+func NewWorker(repo *Repository, cfg WorkerConfig, log *logger.Logger) *Worker {
+	def := DefaultWorkerConfig()
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = def.Concurrency
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = def.PollInterval
+	}
+	if cfg.VisibilityTimeout <= 0 {
+		cfg.VisibilityTimeout = def.VisibilityTimeout
+	}
+	if cfg.ReapInterval <= 0 {
+		cfg.ReapInterval = def.ReapInterval
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = def.ShutdownTimeout
+	}
+	if cfg.ProgressInterval <= 0 {
+		cfg.ProgressInterval = def.ProgressInterval
+	}
+
+	host, _ := os.Hostname()
+	id := fmt.Sprintf("%s-%d", host, os.Getpid())
+
+	return &Worker{
+		repo:     repo,
+		config:   cfg,
+		id:       id,
+		handlers: make(map[string]Handler),
+		logger:   log.WithField("worker_id", id),
+	}
+}
+
+// ID returns the worker identity stored in locked_by
+func (w *Worker) ID() string {
+	return w.id
+}
+
+// Register registers the handler for a job type
+func (w *Worker) Register(jobType string, h Handler) {
+	w.handlers[jobType] = h
+}
+
+// Types returns registered job types (sorted)
+func (w *Worker) Types() []string {
+	types := make([]string, 0, len(w.handlers))
+	for t := range w.handlers {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Run processes jobs until ctx is cancelled
+// 종료 시 새 임대를 멈추고 실행 중 작업을 ShutdownTimeout까지 기다린 뒤, 남은 작업은 취소해 큐로 반환
+func (w *Worker) Run(ctx context.Context) error {
+	types := w.Types()
+	if len(types) == 0 {
+		return fmt.Errorf("no job handlers registered")
+	}
+
+	// 작업 실행 컨텍스트는 ctx와 분리 (종료 신호에 바로 끊기지 않도록)
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	w.logger.WithFields(map[string]interface{}{
+		"concurrency": w.config.Concurrency,
+		"types":       types,
+		"visibility":  w.config.VisibilityTimeout.String(),
+	}).Info("Queue worker started")
+
+	var wg sync.WaitGroup
+	for slot := 0; slot < w.config.Concurrency; slot++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx, jobCtx, types)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.reapLoop(ctx)
+	}()
+
+	<-ctx.Done()
+	w.logger.Info("Queue worker stopping, waiting for in-flight jobs")
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(w.config.ShutdownTimeout):
+		w.logger.Warn("Shutdown timeout reached, cancelling in-flight jobs")
+		cancelJobs()
+		<-done
+	}
+
+	w.logger.Info("Queue worker stopped")
+	return nil
+}
+
+// loop leases and runs jobs one at a time until ctx is cancelled
+func (w *Worker) loop(ctx, jobCtx context.Context, types []string) {
+	for ctx.Err() == nil {
+		job, err := w.repo.Lease(ctx, w.id, types, w.config.VisibilityTimeout)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.WithError(err).Warn("Failed to lease job")
+			}
+			sleep(ctx, w.config.PollInterval)
+			continue
+		}
+		if job == nil {
+			sleep(ctx, w.config.PollInterval)
+			continue
+		}
+
+		w.process(jobCtx, job)
+	}
+}
+
+// process runs one leased job and records the outcome
+func (w *Worker) process(jobCtx context.Context, job *Job) {
+	log := w.logger.WithFields(map[string]interface{}{
+		"job_id":   job.ID,
+		"job_type": job.Type,
+		"attempt":  job.Attempts,
+	})
+
+	sink := &progressSink{}
+	runCtx, cancelCause := context.WithCancelCause(context.WithValue(jobCtx, progressKey{}, sink))
+	cancel := func() { cancelCause(context.Canceled) }
+	defer cancel()
+
+	// Heartbeat: 가시성 타임아웃의 1/3마다 임대 연장, 임대를 잃으면 실행 취소
+	hbDone := make(chan struct{})
+	go func() {
+		defer close(hbDone)
+		ticker := time.NewTicker(w.config.VisibilityTimeout / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				ok, err := w.repo.Heartbeat(runCtx, job.ID, w.id, w.config.VisibilityTimeout)
+				if err != nil {
+					log.WithError(err).Warn("Heartbeat failed")
+					continue
+				}
+				if !ok {
+					log.Warn("Lease lost, cancelling job")
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	// Progress: 보고된 진행률 저장, 취소 요청이 있으면 ErrCancelRequested로 실행 취소
+	progDone := make(chan struct{})
+	go func() {
+		defer close(progDone)
+		ticker := time.NewTicker(w.config.ProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				cancelRequested, err := w.repo.SyncProgress(runCtx, job.ID, w.id, sink.take())
+				if err != nil {
+					log.WithError(err).Warn("Progress sync failed")
+					continue
+				}
+				if cancelRequested {
+					log.Info("Cancellation requested, cancelling job")
+					cancelCause(ErrCancelRequested)
+					return
+				}
+			}
+		}
+	}()
+
+	start := time.Now()
+	log.Info("Job started")
+
+	result, err := w.execute(runCtx, job)
+	cancelled := errors.Is(context.Cause(runCtx), ErrCancelRequested)
+	cancel()
+	<-hbDone
+	<-progDone
+
+	// 상태 기록은 종료 취소와 무관하게 수행
+	dbCtx, dbCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer dbCancel()
+
+	// 마지막 진행률 저장 (완료/실패 후에도 조회 가능하도록)
+	if progress := sink.take(); progress != nil {
+		if _, syncErr := w.repo.SyncProgress(dbCtx, job.ID, w.id, progress); syncErr != nil {
+			log.WithError(syncErr).Warn("Progress sync failed")
+		}
+	}
+
+	if err == nil {
+		if err := w.repo.Complete(dbCtx, job.ID, w.id, result); err != nil {
+			log.WithError(err).Error("Failed to mark job done")
+			return
+		}
+		log.WithField("duration", time.Since(start).String()).Info("Job completed")
+		return
+	}
+
+	// 취소 요청으로 중단된 작업은 재시도 없이 cancelled
+	if cancelled {
+		if cErr := w.repo.MarkCancelled(dbCtx, job.ID, w.id); cErr != nil {
+			log.WithError(cErr).Error("Failed to mark job cancelled")
+			return
+		}
+		log.WithField("duration", time.Since(start).String()).Warn("Job cancelled by request")
+		return
+	}
+
+	// 종료 타임아웃으로 취소된 작업은 시도 횟수를 소모하지 않고 반환
+	if jobCtx.Err() != nil {
+		if relErr := w.repo.Release(dbCtx, job.ID, w.id); relErr != nil {
+			log.WithError(relErr).Error("Failed to release job")
+			return
+		}
+		log.Warn("Job interrupted by shutdown, released to queue")
+		return
+	}
+
+	status, failErr := w.repo.Fail(dbCtx, job, w.id, err)
+	if failErr != nil {
+		log.WithError(failErr).Error("Failed to record job failure")
+		return
+	}
+	log.WithError(err).WithFields(map[string]interface{}{
+		"status":   status,
+		"duration": time.Since(start).String(),
+	}).Warn("Job failed")
+}
+
+// execute runs the handler, converting panics into permanent failures
+func (w *Worker) execute(ctx context.Context, job *Job) (result interface{}, err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return nil, Permanent(fmt.Errorf("no handler for job type %q", job.Type))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			w.logger.WithField("stack", string(debug.Stack())).Error("Job handler panicked")
+			err = Permanent(fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// reapLoop periodically recovers jobs whose worker disappeared
+func (w *Worker) reapLoop(ctx context.Context) {
+	ticker := time.NewTicker(w.config.ReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := w.repo.ReapExpired(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					w.logger.WithError(err).Warn("Failed to reap expired jobs")
+				}
+				continue
+			}
+			if n > 0 {
+				w.logger.WithField("jobs", n).Warn("Reaped jobs with expired leases")
+			}
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
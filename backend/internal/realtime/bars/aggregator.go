@@ -0,0 +1,151 @@
+package bars
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+const (
+	// flushInterval 확정 대상 봉 확인 주기
+	flushInterval = 1 * time.Second
+	// flushGrace 분 경계 이후 지연 틱 허용 시간
+	flushGrace = 2 * time.Second
+	// aggregatorBuffer PriceCache 구독 버퍼 (전 종목 wildcard)
+	aggregatorBuffer = 4096
+	// maxPendingBars 저장 실패 시 보관할 최대 봉 수 (초과분은 오래된 것부터 폐기)
+	maxPendingBars = 100000
+)
+
+// BarStore persists finalized bars (Repository)
+type BarStore interface {
+	SaveBars(ctx context.Context, bars []Bar) (int64, error)
+}
+
+// Aggregator subscribes to PriceCache and stores finalized bars
+// ⭐ SSOT: 실시간 틱 → 분봉 집계/저장은 이 구조체에서만
+type Aggregator struct {
+	priceCache *cache.PriceCache
+	builder    *Builder
+	store      BarStore
+	logger     *logger.Logger
+
+	pending []Bar // 저장 실패 시 다음 주기에 재시도
+	saved   int64
+	mu      sync.Mutex
+}
+
+// NewAggregator creates a new bar aggregator (intervals in minutes, default 1 and 5)
+func NewAggregator(priceCache *cache.PriceCache, store BarStore, log *logger.Logger, intervals ...int) *Aggregator {
+	return &Aggregator{
+		priceCache: priceCache,
+		builder:    NewBuilder(intervals...),
+		store:      store,
+		logger:     log,
+	}
+}
+
+// Builder returns the underlying bar builder
+func (a *Aggregator) Builder() *Builder {
+	return a.builder
+}
+
+// Start consumes ticks until ctx is cancelled, then flushes remaining bars
+func (a *Aggregator) Start(ctx context.Context) {
+	sub := a.priceCache.Subscribe(aggregatorBuffer, cache.WildcardSymbol)
+	defer sub.Close()
+
+	a.logger.WithFields(map[string]interface{}{
+		"intervals": a.builder.Intervals(),
+	}).Info("Starting intraday bar aggregator")
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.enqueue(a.builder.FlushAll())
+			// ctx는 이미 취소됨 → 별도 타임아웃으로 마지막 봉 저장
+			saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			a.save(saveCtx)
+			cancel()
+
+			a.logger.WithFields(map[string]interface{}{
+				"saved":      a.Saved(),
+				"late_ticks": a.builder.LateTicks(),
+				"dropped":    sub.Dropped(),
+			}).Info("Intraday bar aggregator stopped")
+			return
+
+		case tick, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			a.enqueue(a.builder.AddTick(tick))
+
+		case now := <-ticker.C:
+			a.enqueue(a.builder.Flush(now.Add(-flushGrace)))
+			a.save(ctx)
+		}
+	}
+}
+
+// Saved returns the number of bars written so far
+func (a *Aggregator) Saved() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.saved
+}
+
+// enqueue buffers finalized bars for the next save
+func (a *Aggregator) enqueue(bars []Bar) {
+	if len(bars) == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pending = append(a.pending, bars...)
+}
+
+// save writes buffered bars in one COPY batch
+func (a *Aggregator) save(ctx context.Context) {
+	a.mu.Lock()
+	bars := a.pending
+	a.pending = nil
+	a.mu.Unlock()
+
+	if len(bars) == 0 {
+		return
+	}
+
+	n, err := a.store.SaveBars(ctx, bars)
+	if err != nil {
+		a.logger.WithError(err).WithFields(map[string]interface{}{
+			"count": len(bars),
+		}).Error("Failed to save intraday bars")
+
+		// 재시도를 위해 다시 적재
+		a.mu.Lock()
+		a.pending = append(bars, a.pending...)
+		if over := len(a.pending) - maxPendingBars; over > 0 {
+			a.pending = a.pending[over:]
+		}
+		a.mu.Unlock()
+		return
+	}
+
+	a.mu.Lock()
+	a.saved += n
+	a.mu.Unlock()
+
+	a.logger.WithFields(map[string]interface{}{
+		"count": n,
+	}).Debug("Saved intraday bars")
+}
@@ -0,0 +1,245 @@
+// Package bars aggregates realtime PriceTick streams into intraday OHLCV bars
+package bars
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+)
+
+// DefaultIntervals are the bar intervals (minutes) built by default
+var DefaultIntervals = []int{1, 5}
+
+// Bar represents an intraday OHLCV bar
+// ⭐ SSOT: 분봉 데이터 구조
+type Bar struct {
+	Code      string    `json:"code"`
+	Interval  int       `json:"interval"` // 분 단위 (1, 5)
+	Start     time.Time `json:"start"`    // 봉 시작 시각 (구간: [Start, End))
+	Open      int64     `json:"open"`
+	High      int64     `json:"high"`
+	Low       int64     `json:"low"`
+	Close     int64     `json:"close"`
+	Volume    int64     `json:"volume"` // 누적 거래량 차분 합계
+	Value     int64     `json:"value"`  // Σ(체결가 × 거래량)
+	VWAP      float64   `json:"vwap"`
+	TickCount int       `json:"tick_count"`
+
+	lastTick time.Time // 종가 갱신 기준 (역순 틱이 종가를 덮어쓰지 않도록)
+}
+
+// End returns the exclusive end time of the bar
+func (b *Bar) End() time.Time {
+	return b.Start.Add(time.Duration(b.Interval) * time.Minute)
+}
+
+// barKey identifies an in-progress bar
+type barKey struct {
+	code     string
+	interval int
+}
+
+// volumeState tracks cumulative volume per symbol
+type volumeState struct {
+	cumulative int64
+	day        string
+}
+
+// Builder aggregates ticks into bars
+// - PriceTick.Volume은 당일 누적 거래량 → 직전 누적값과의 차분을 봉 거래량으로 사용
+// - 봉 경계를 넘는 틱이 오거나 Flush 시각이 봉 종료 시각을 지나면 봉 확정
+type Builder struct {
+	intervals []int
+
+	current   map[barKey]*Bar
+	lastEnd   map[barKey]time.Time // 마지막으로 확정된 봉의 종료 시각 (지연 틱 판정)
+	volumes   map[string]volumeState
+	lateTicks int64
+
+	mu sync.Mutex
+}
+
+// NewBuilder creates a bar builder (intervals in minutes, default 1 and 5)
+func NewBuilder(intervals ...int) *Builder {
+	if len(intervals) == 0 {
+		intervals = DefaultIntervals
+	}
+
+	return &Builder{
+		intervals: intervals,
+		current:   make(map[barKey]*Bar),
+		lastEnd:   make(map[barKey]time.Time),
+		volumes:   make(map[string]volumeState),
+	}
+}
+
+// Intervals returns configured intervals (minutes)
+func (b *Builder) Intervals() []int {
+	return b.intervals
+}
+
+// AddTick adds a tick and returns bars finalized by crossing a bar boundary
+func (b *Builder) AddTick(tick *realtime.PriceTick) []Bar {
+	if tick == nil || tick.Price <= 0 || tick.Timestamp.IsZero() {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delta := b.volumeDelta(tick)
+
+	var finalized []Bar
+	for _, interval := range b.intervals {
+		key := barKey{code: tick.Code, interval: interval}
+		start := tick.Timestamp.Truncate(time.Duration(interval) * time.Minute)
+
+		bar, exists := b.current[key]
+
+		// 이미 확정된 구간의 지연 틱 → 가격 반영 불가
+		if (!exists && start.Before(b.lastEnd[key])) || (exists && start.Before(bar.Start)) {
+			b.lateTicks++
+			if exists && delta > 0 {
+				// 누적 거래량은 이미 반영됐으므로 진행 중인 봉에 귀속
+				bar.Volume += delta
+				bar.Value += delta * tick.Price
+			}
+			continue
+		}
+
+		// 새 구간 → 기존 봉 확정
+		if exists && !start.Equal(bar.Start) {
+			finalized = append(finalized, b.finalize(key, bar))
+			exists = false
+		}
+
+		if !exists {
+			bar = &Bar{
+				Code:     tick.Code,
+				Interval: interval,
+				Start:    start,
+				Open:     tick.Price,
+				High:     tick.Price,
+				Low:      tick.Price,
+				Close:    tick.Price,
+				lastTick: tick.Timestamp,
+			}
+			b.current[key] = bar
+		}
+
+		if tick.Price > bar.High {
+			bar.High = tick.Price
+		}
+		if tick.Price < bar.Low {
+			bar.Low = tick.Price
+		}
+		if !tick.Timestamp.Before(bar.lastTick) {
+			bar.Close = tick.Price
+			bar.lastTick = tick.Timestamp
+		}
+		bar.Volume += delta
+		bar.Value += delta * tick.Price
+		bar.TickCount++
+	}
+
+	return finalized
+}
+
+// Flush finalizes every bar whose end time is at or before now
+// 분 경계 직후 호출 (지연 틱 허용을 위해 호출 측에서 유예 시간을 뺀 시각 전달)
+func (b *Builder) Flush(now time.Time) []Bar {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var finalized []Bar
+	for key, bar := range b.current {
+		if !bar.End().After(now) {
+			finalized = append(finalized, b.finalize(key, bar))
+		}
+	}
+
+	sortBars(finalized)
+	return finalized
+}
+
+// FlushAll finalizes every in-progress bar (장 마감/종료 시)
+func (b *Builder) FlushAll() []Bar {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	finalized := make([]Bar, 0, len(b.current))
+	for key, bar := range b.current {
+		finalized = append(finalized, b.finalize(key, bar))
+	}
+
+	sortBars(finalized)
+	return finalized
+}
+
+// Pending returns the number of in-progress bars
+func (b *Builder) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.current)
+}
+
+// LateTicks returns the number of ticks older than their in-progress bar
+func (b *Builder) LateTicks() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.lateTicks
+}
+
+// finalize removes the bar from the in-progress set and computes VWAP (lock held)
+func (b *Builder) finalize(key barKey, bar *Bar) Bar {
+	delete(b.current, key)
+	b.lastEnd[key] = bar.End()
+
+	if bar.Volume > 0 {
+		bar.VWAP = float64(bar.Value) / float64(bar.Volume)
+	} else {
+		bar.VWAP = float64(bar.Close)
+	}
+
+	return *bar
+}
+
+// volumeDelta converts cumulative tick volume into a per-tick delta (lock held)
+// - 최초 관측(장중 시작): 기준값만 저장하고 0 반환 (과거 누적분이 한 봉에 몰리지 않도록)
+// - 날짜 변경: 누적값이 초기화되므로 현재 누적값 전체가 차분
+// - 누적값 감소(소스 간 불일치/역순 틱): 0 반환, 기준값 유지
+func (b *Builder) volumeDelta(tick *realtime.PriceTick) int64 {
+	day := tick.Timestamp.Format("2006-01-02")
+	prev, ok := b.volumes[tick.Code]
+
+	switch {
+	case !ok:
+		b.volumes[tick.Code] = volumeState{cumulative: tick.Volume, day: day}
+		return 0
+	case prev.day != day:
+		b.volumes[tick.Code] = volumeState{cumulative: tick.Volume, day: day}
+		return tick.Volume
+	case tick.Volume <= prev.cumulative:
+		return 0
+	default:
+		b.volumes[tick.Code] = volumeState{cumulative: tick.Volume, day: day}
+		return tick.Volume - prev.cumulative
+	}
+}
+
+// sortBars orders bars by start time, code, interval
+func sortBars(bars []Bar) {
+	sort.Slice(bars, func(i, j int) bool {
+		if !bars[i].Start.Equal(bars[j].Start) {
+			return bars[i].Start.Before(bars[j].Start)
+		}
+		if bars[i].Code != bars[j].Code {
+			return bars[i].Code < bars[j].Code
+		}
+		return bars[i].Interval < bars[j].Interval
+	})
+}
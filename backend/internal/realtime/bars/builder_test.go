@@ -0,0 +1,134 @@
+package bars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+)
+
+var kst = time.FixedZone("KST", 9*60*60)
+
+// at returns 2024-03-04 hh:mm:ss KST
+func at(hh, mm, ss int) time.Time {
+	return time.Date(2024, 3, 4, hh, mm, ss, 0, kst)
+}
+
+func tick(code string, price, cumVolume int64, ts time.Time) *realtime.PriceTick {
+	return &realtime.PriceTick{Code: code, Price: price, Volume: cumVolume, Timestamp: ts}
+}
+
+func TestBuilder_AggregatesOneMinuteBar(t *testing.T) {
+	b := NewBuilder(1)
+
+	assert.Empty(t, b.AddTick(tick("005930", 70000, 1000, at(9, 0, 5))))  // 기준값 (거래량 0)
+	assert.Empty(t, b.AddTick(tick("005930", 70300, 1100, at(9, 0, 20)))) // +100
+	assert.Empty(t, b.AddTick(tick("005930", 69900, 1400, at(9, 0, 40)))) // +300
+	assert.Empty(t, b.AddTick(tick("005930", 70100, 1500, at(9, 0, 59)))) // +100
+
+	// 다음 분 틱 → 09:00 봉 확정
+	finalized := b.AddTick(tick("005930", 70200, 1600, at(9, 1, 2)))
+	require.Len(t, finalized, 1)
+
+	bar := finalized[0]
+	assert.Equal(t, at(9, 0, 0), bar.Start)
+	assert.Equal(t, int64(70000), bar.Open)
+	assert.Equal(t, int64(70300), bar.High)
+	assert.Equal(t, int64(69900), bar.Low)
+	assert.Equal(t, int64(70100), bar.Close)
+	assert.Equal(t, int64(500), bar.Volume)
+	assert.Equal(t, 4, bar.TickCount)
+
+	// VWAP = (70300×100 + 69900×300 + 70100×100) / 500
+	assert.InDelta(t, 70020.0, bar.VWAP, 0.001)
+}
+
+func TestBuilder_FiveMinuteBarsAndFlush(t *testing.T) {
+	b := NewBuilder(1, 5)
+
+	b.AddTick(tick("005930", 70000, 1000, at(9, 0, 10)))
+	b.AddTick(tick("005930", 70500, 1200, at(9, 3, 10)))
+	assert.Equal(t, 2, b.Pending())
+
+	// 09:00 1분봉은 09:03 틱 도착 시 확정됨 → 09:04에는 09:03 1분봉만 확정
+	flushed := b.Flush(at(9, 4, 0))
+	require.Len(t, flushed, 1)
+	assert.Equal(t, 1, flushed[0].Interval)
+	assert.Equal(t, at(9, 3, 0), flushed[0].Start)
+
+	// 5분봉은 09:05 경계에서 확정
+	flushed = b.Flush(at(9, 5, 0))
+	require.Len(t, flushed, 1)
+	bar := flushed[0]
+	assert.Equal(t, 5, bar.Interval)
+	assert.Equal(t, at(9, 0, 0), bar.Start)
+	assert.Equal(t, int64(70000), bar.Open)
+	assert.Equal(t, int64(70500), bar.Close)
+	assert.Equal(t, int64(200), bar.Volume)
+	assert.Equal(t, 0, b.Pending())
+}
+
+func TestBuilder_VolumeDelta(t *testing.T) {
+	b := NewBuilder(1)
+
+	b.AddTick(tick("005930", 70000, 5000, at(10, 0, 0))) // 장중 시작: 기준값만
+	b.AddTick(tick("005930", 70000, 4900, at(10, 0, 1))) // 누적 감소 (소스 불일치) → 0
+	b.AddTick(tick("005930", 70000, 5200, at(10, 0, 2))) // 5000 기준 +200
+
+	bars := b.FlushAll()
+	require.Len(t, bars, 1)
+	assert.Equal(t, int64(200), bars[0].Volume)
+
+	// 다음 거래일: 누적값 초기화 → 현재 누적값 전체가 거래량
+	next := at(9, 0, 1).AddDate(0, 0, 1)
+	b.AddTick(tick("005930", 71000, 300, next))
+	bars = b.FlushAll()
+	require.Len(t, bars, 1)
+	assert.Equal(t, int64(300), bars[0].Volume)
+}
+
+func TestBuilder_LateTicks(t *testing.T) {
+	b := NewBuilder(1)
+
+	b.AddTick(tick("005930", 70000, 1000, at(9, 0, 10)))
+	b.AddTick(tick("005930", 70100, 1100, at(9, 1, 10))) // 09:00 봉 확정
+
+	// 확정된 09:00 구간 틱 → 가격 미반영
+	b.AddTick(tick("005930", 60000, 1100, at(9, 0, 50)))
+	// 진행 중 봉 내부의 역순 틱 → 고저만 반영, 종가 유지
+	b.AddTick(tick("005930", 70300, 1150, at(9, 1, 30)))
+	b.AddTick(tick("005930", 70200, 1150, at(9, 1, 20)))
+
+	assert.Equal(t, int64(1), b.LateTicks())
+
+	bars := b.FlushAll()
+	require.Len(t, bars, 1)
+	assert.Equal(t, int64(70100), bars[0].Low)
+	assert.Equal(t, int64(70300), bars[0].High)
+	assert.Equal(t, int64(70300), bars[0].Close)
+	assert.Equal(t, int64(150), bars[0].Volume)
+}
+
+func TestBuilder_NoVolumeVWAPFallsBackToClose(t *testing.T) {
+	b := NewBuilder(1)
+	b.AddTick(tick("005930", 70000, 1000, at(9, 0, 10)))
+
+	bars := b.FlushAll()
+	require.Len(t, bars, 1)
+	assert.Equal(t, 70000.0, bars[0].VWAP)
+}
+
+func TestDedupeBars(t *testing.T) {
+	bars := dedupeBars([]Bar{
+		{Code: "005930", Interval: 1, Start: at(9, 0, 0), Close: 1},
+		{Code: "005930", Interval: 5, Start: at(9, 0, 0), Close: 2},
+		{Code: "005930", Interval: 1, Start: at(9, 0, 0), Close: 3},
+	})
+
+	require.Len(t, bars, 2)
+	assert.Equal(t, int64(3), bars[0].Close)
+	assert.Equal(t, int64(2), bars[1].Close)
+}
@@ -0,0 +1,162 @@
+package bars
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// barColumns are the data.intraday_bars columns written via COPY
+var barColumns = []string{
+	"stock_code", "interval_minutes", "bar_time",
+	"open_price", "high_price", "low_price", "close_price",
+	"volume", "trade_value", "vwap", "tick_count",
+}
+
+// Repository handles intraday bar persistence
+// ⭐ SSOT: data.intraday_bars 접근은 이 구조체에서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new bar repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SaveBars bulk-upserts bars via COPY into a temp table
+// COPY는 ON CONFLICT를 지원하지 않으므로 임시 테이블에 적재 후 INSERT ... SELECT로 병합
+func (r *Repository) SaveBars(ctx context.Context, bars []Bar) (int64, error) {
+	bars = dedupeBars(bars)
+	if len(bars) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE tmp_intraday_bars
+			(LIKE data.intraday_bars INCLUDING DEFAULTS)
+			ON COMMIT DROP
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("create temp table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"tmp_intraday_bars"},
+		barColumns,
+		pgx.CopyFromSlice(len(bars), func(i int) ([]interface{}, error) {
+			b := bars[i]
+			return []interface{}{
+				b.Code, int16(b.Interval), b.Start,
+				b.Open, b.High, b.Low, b.Close,
+				b.Volume, b.Value, b.VWAP, int32(b.TickCount),
+			}, nil
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("copy bars: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO data.intraday_bars (
+			stock_code, interval_minutes, bar_time,
+			open_price, high_price, low_price, close_price,
+			volume, trade_value, vwap, tick_count
+		)
+		SELECT
+			stock_code, interval_minutes, bar_time,
+			open_price, high_price, low_price, close_price,
+			volume, trade_value, vwap, tick_count
+		FROM tmp_intraday_bars
+		ON CONFLICT (stock_code, interval_minutes, bar_time) DO UPDATE SET
+			open_price = EXCLUDED.open_price,
+			high_price = EXCLUDED.high_price,
+			low_price = EXCLUDED.low_price,
+			close_price = EXCLUDED.close_price,
+			volume = EXCLUDED.volume,
+			trade_value = EXCLUDED.trade_value,
+			vwap = EXCLUDED.vwap,
+			tick_count = EXCLUDED.tick_count,
+			updated_at = NOW()
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("merge bars: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return copied, nil
+}
+
+// GetBars retrieves bars for a code/interval within [from, to)
+func (r *Repository) GetBars(ctx context.Context, code string, interval int, from, to time.Time) ([]Bar, error) {
+	query := `
+		SELECT stock_code, interval_minutes, bar_time,
+		       open_price, high_price, low_price, close_price,
+		       volume, trade_value, COALESCE(vwap, 0), tick_count
+		FROM data.intraday_bars
+		WHERE stock_code = $1
+		  AND interval_minutes = $2
+		  AND bar_time >= $3 AND bar_time < $4
+		ORDER BY bar_time ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, code, interval, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query intraday bars: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Bar
+	for rows.Next() {
+		var b Bar
+		var interval16 int16
+		var tickCount int32
+		if err := rows.Scan(
+			&b.Code, &interval16, &b.Start,
+			&b.Open, &b.High, &b.Low, &b.Close,
+			&b.Volume, &b.Value, &b.VWAP, &tickCount,
+		); err != nil {
+			return nil, fmt.Errorf("scan intraday bar: %w", err)
+		}
+		b.Interval = int(interval16)
+		b.TickCount = int(tickCount)
+		result = append(result, b)
+	}
+
+	return result, rows.Err()
+}
+
+// dedupeBars keeps the last bar per (code, interval, start)
+// 같은 키가 한 번의 INSERT ... ON CONFLICT에 두 번 나오면 실패하므로 사전 제거
+func dedupeBars(bars []Bar) []Bar {
+	type key struct {
+		code     string
+		interval int
+		start    int64
+	}
+
+	index := make(map[key]int, len(bars))
+	result := make([]Bar, 0, len(bars))
+	for _, b := range bars {
+		k := key{b.Code, b.Interval, b.Start.UnixNano()}
+		if i, ok := index[k]; ok {
+			result[i] = b
+			continue
+		}
+		index[k] = len(result)
+		result = append(result, b)
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// kstZone 정규장 판정용 한국 시간대
+var kstZone = time.FixedZone("KST", 9*60*60)
+
+// spreadAccumulator accumulates spread samples for one stock/day
+type spreadAccumulator struct {
+	samples     int
+	sumSpread   float64
+	minSpread   float64
+	maxSpread   float64
+	sumBid1     int64
+	sumAsk1     int64
+	sumTotalBid int64
+	sumTotalAsk int64
+}
+
+// statKey identifies a stock/day accumulator
+type statKey struct {
+	code string
+	day  string // YYYY-MM-DD (KST)
+}
+
+// OrderBookCache is an in-memory cache for real-time order books
+// ⭐ SSOT: 실시간 호가(최우선 매수/매도, 잔량) 캐싱은 이 구조체에서만
+// 정규장 호가 스냅샷마다 스프레드 표본을 누적해 일별 통계를 제공
+type OrderBookCache struct {
+	mu     sync.RWMutex
+	books  map[string]*realtime.OrderBook
+	stats  map[statKey]*spreadAccumulator
+	ttl    time.Duration
+	logger *logger.Logger
+}
+
+// NewOrderBookCache creates a new order book cache
+func NewOrderBookCache(ttl time.Duration, log *logger.Logger) *OrderBookCache {
+	return &OrderBookCache{
+		books:  make(map[string]*realtime.OrderBook),
+		stats:  make(map[statKey]*spreadAccumulator),
+		ttl:    ttl,
+		logger: log,
+	}
+}
+
+// Update stores the order book if it is not older than the cached one
+func (c *OrderBookCache) Update(book *realtime.OrderBook) bool {
+	if book == nil || book.Code == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.books[book.Code]; ok && book.Timestamp.Before(existing.Timestamp) {
+		return false
+	}
+
+	book.IsStale = time.Since(book.Timestamp) > c.ttl
+	c.books[book.Code] = book
+
+	if book.IsValid() && isContinuousSession(book.Timestamp) {
+		c.accumulate(book)
+	}
+
+	return true
+}
+
+// Get retrieves the order book for a stock
+func (c *OrderBookCache) Get(code string) (*realtime.OrderBook, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	book, ok := c.books[code]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Since(book.Timestamp) > c.ttl {
+		book.IsStale = true
+	}
+	return book, true
+}
+
+// BestBidAsk returns BID1/ASK1 if a fresh, uncrossed book is cached
+func (c *OrderBookCache) BestBidAsk(code string) (bid, ask int64, ok bool) {
+	book, exists := c.Get(code)
+	if !exists || book.IsStale || !book.IsValid() {
+		return 0, 0, false
+	}
+	return book.BestBid(), book.BestAsk(), true
+}
+
+// SpreadPct returns the current spread of a fresh book
+func (c *OrderBookCache) SpreadPct(code string) (float64, bool) {
+	book, exists := c.Get(code)
+	if !exists || book.IsStale || !book.IsValid() {
+		return 0, false
+	}
+	return book.SpreadPct(), true
+}
+
+// Len returns the number of cached order books
+func (c *OrderBookCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.books)
+}
+
+// SpreadStats returns accumulated daily spread statistics (all held days)
+func (c *OrderBookCache) SpreadStats() []contracts.SpreadStat {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]contracts.SpreadStat, 0, len(c.stats))
+	for key, acc := range c.stats {
+		if acc.samples == 0 {
+			continue
+		}
+		date, _ := time.ParseInLocation("2006-01-02", key.day, kstZone)
+		n := int64(acc.samples)
+
+		result = append(result, contracts.SpreadStat{
+			Code:           key.code,
+			Date:           date,
+			AvgSpreadPct:   acc.sumSpread / float64(acc.samples),
+			MinSpreadPct:   acc.minSpread,
+			MaxSpreadPct:   acc.maxSpread,
+			SampleCount:    acc.samples,
+			AvgBid1Qty:     acc.sumBid1 / n,
+			AvgAsk1Qty:     acc.sumAsk1 / n,
+			AvgTotalBidQty: acc.sumTotalBid / n,
+			AvgTotalAskQty: acc.sumTotalAsk / n,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// PruneSpreadStats drops accumulators for days before the given date (KST)
+func (c *OrderBookCache) PruneSpreadStats(before time.Time) int {
+	cutoff := before.In(kstZone).Format("2006-01-02")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for key := range c.stats {
+		if key.day < cutoff {
+			delete(c.stats, key)
+			count++
+		}
+	}
+	return count
+}
+
+// accumulate adds a spread sample (lock held)
+func (c *OrderBookCache) accumulate(book *realtime.OrderBook) {
+	key := statKey{code: book.Code, day: book.Timestamp.In(kstZone).Format("2006-01-02")}
+	spread := book.SpreadPct()
+
+	acc, ok := c.stats[key]
+	if !ok {
+		acc = &spreadAccumulator{minSpread: spread, maxSpread: spread}
+		c.stats[key] = acc
+	}
+
+	acc.samples++
+	acc.sumSpread += spread
+	if spread < acc.minSpread {
+		acc.minSpread = spread
+	}
+	if spread > acc.maxSpread {
+		acc.maxSpread = spread
+	}
+	acc.sumBid1 += book.Bids[0].Qty
+	acc.sumAsk1 += book.Asks[0].Qty
+	acc.sumTotalBid += book.TotalBidQty
+	acc.sumTotalAsk += book.TotalAskQty
+}
+
+// isContinuousSession reports whether t is within 09:00~15:20 KST on a weekday
+// 동시호가(장 시작/마감) 구간의 예상 호가는 스프레드 통계에서 제외
+func isContinuousSession(t time.Time) bool {
+	k := t.In(kstZone)
+	if k.Weekday() == time.Saturday || k.Weekday() == time.Sunday {
+		return false
+	}
+	minutes := k.Hour()*60 + k.Minute()
+	return minutes >= 9*60 && minutes < 15*60+20
+}
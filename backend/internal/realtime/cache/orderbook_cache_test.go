@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+func newTestOrderBookCache(ttl time.Duration) *OrderBookCache {
+	return NewOrderBookCache(ttl, logger.New(&config.Config{LogLevel: "error", LogFormat: "json"}))
+}
+
+func book(code string, bid, ask, qty int64, at time.Time) *realtime.OrderBook {
+	return &realtime.OrderBook{
+		Code:        code,
+		Asks:        []realtime.OrderBookLevel{{Price: ask, Qty: qty}},
+		Bids:        []realtime.OrderBookLevel{{Price: bid, Qty: qty * 2}},
+		TotalAskQty: qty * 10,
+		TotalBidQty: qty * 20,
+		Timestamp:   at,
+	}
+}
+
+// session returns 2024-03-04 (월) hh:mm KST
+func session(hh, mm int) time.Time {
+	return time.Date(2024, 3, 4, hh, mm, 0, 0, kstZone)
+}
+
+func TestOrderBook_SpreadPct(t *testing.T) {
+	b := book("005930", 9990, 10010, 100, time.Now())
+	assert.InDelta(t, 20.0/10000.0, b.SpreadPct(), 1e-9)
+
+	crossed := book("005930", 10010, 9990, 100, time.Now())
+	assert.False(t, crossed.IsValid())
+	assert.Equal(t, 0.0, crossed.SpreadPct())
+}
+
+func TestOrderBookCache_BestBidAsk(t *testing.T) {
+	c := newTestOrderBookCache(10 * time.Second)
+
+	require.True(t, c.Update(book("005930", 70000, 70100, 100, time.Now())))
+	bid, ask, ok := c.BestBidAsk("005930")
+	require.True(t, ok)
+	assert.Equal(t, int64(70000), bid)
+	assert.Equal(t, int64(70100), ask)
+
+	// 오래된 호가는 거부
+	assert.False(t, c.Update(book("005930", 69000, 69100, 100, time.Now().Add(-time.Minute))))
+
+	// TTL 초과 호가는 사용하지 않음
+	require.True(t, c.Update(book("000660", 180000, 180500, 10, time.Now().Add(-time.Minute))))
+	_, _, ok = c.BestBidAsk("000660")
+	assert.False(t, ok)
+
+	_, _, ok = c.BestBidAsk("035420")
+	assert.False(t, ok)
+}
+
+func TestOrderBookCache_SpreadStats(t *testing.T) {
+	c := newTestOrderBookCache(time.Hour)
+
+	c.Update(book("005930", 8990, 9010, 100, session(8, 50)))   // 동시호가 → 제외
+	c.Update(book("005930", 9990, 10010, 100, session(9, 30)))  // 0.20%
+	c.Update(book("005930", 9980, 10020, 300, session(10, 0)))  // 0.40%
+	c.Update(book("005930", 9900, 10100, 100, session(15, 25))) // 마감 동시호가 → 제외
+
+	stats := c.SpreadStats()
+	require.Len(t, stats, 1)
+
+	st := stats[0]
+	assert.Equal(t, "005930", st.Code)
+	assert.Equal(t, 2, st.SampleCount)
+	assert.InDelta(t, 0.003, st.AvgSpreadPct, 1e-9)
+	assert.InDelta(t, 0.002, st.MinSpreadPct, 1e-9)
+	assert.InDelta(t, 0.004, st.MaxSpreadPct, 1e-9)
+	assert.Equal(t, int64(200), st.AvgAsk1Qty)
+	assert.Equal(t, int64(400), st.AvgBid1Qty)
+	assert.Equal(t, "2024-03-04", st.Date.Format("2006-01-02"))
+
+	// 다음 날 통계 추가 후 전일 정리
+	c.Update(book("005930", 9990, 10010, 100, session(9, 30).AddDate(0, 0, 1)))
+	assert.Len(t, c.SpreadStats(), 2)
+	assert.Equal(t, 1, c.PruneSpreadStats(session(0, 0).AddDate(0, 0, 1)))
+	assert.Len(t, c.SpreadStats(), 1)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/wonny/aegis/v13/backend/internal/realtime"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // PriceCache is an in-memory cache for real-time prices
@@ -15,20 +16,39 @@ type PriceCache struct {
 	prices  map[string]*realtime.PriceTick
 	ttl     time.Duration
 	logger  *logger.Logger
+
+	// Channel subscriptions (bounded, drop-oldest) - see subscription.go
+	// ⭐ SSOT: 틱 소비자(스트림, 봉 집계, 청산 모니터)는 모두 Subscribe로만 수신
+	symbolSubs   map[string]map[*Subscription]struct{}
+	wildcardSubs map[*Subscription]struct{}
+	subMu        sync.RWMutex
 }
 
 // NewPriceCache creates a new price cache
 func NewPriceCache(ttl time.Duration, log *logger.Logger) *PriceCache {
 	return &PriceCache{
-		prices: make(map[string]*realtime.PriceTick),
-		ttl:    ttl,
-		logger: log,
+		prices:       make(map[string]*realtime.PriceTick),
+		ttl:          ttl,
+		logger:       log,
+		symbolSubs:   make(map[string]map[*Subscription]struct{}),
+		wildcardSubs: make(map[*Subscription]struct{}),
 	}
 }
 
 // Update updates price in cache
 // Only accepts newer data from higher priority sources
 func (c *PriceCache) Update(tick *realtime.PriceTick) bool {
+	if !c.update(tick) {
+		return false
+	}
+
+	c.publish(tick)
+
+	return true
+}
+
+// update stores the tick if it passes the freshness/priority checks
+func (c *PriceCache) update(tick *realtime.PriceTick) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -204,10 +224,38 @@ func (c *PriceCache) Stats() CacheStats {
 	}
 
 	stats.FreshCount = stats.TotalCount - stats.StaleCount
+	stats.SubscriberCount = c.SubscriberCount()
 
 	return stats
 }
 
+// ExportMetrics exposes this cache's size and staleness on /metrics
+// 가장 오래된 틱의 경과 시간이 피드 중단 알림의 기준
+func (c *PriceCache) ExportMetrics() {
+	metrics.TrackPriceCache(c.metricsSnapshot)
+}
+
+// metricsSnapshot is read on every Prometheus scrape
+func (c *PriceCache) metricsSnapshot() metrics.PriceCacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshot := metrics.PriceCacheStats{Size: len(c.prices)}
+
+	now := time.Now()
+	for _, tick := range c.prices {
+		age := now.Sub(tick.Timestamp)
+		if age > c.ttl {
+			snapshot.Stale++
+		}
+		if age > snapshot.OldestAge {
+			snapshot.OldestAge = age
+		}
+	}
+
+	return snapshot
+}
+
 // CacheStats represents cache statistics
 type CacheStats struct {
 	TotalCount        int `json:"total_count"`
@@ -216,4 +264,5 @@ type CacheStats struct {
 	KISWebSocketCount int `json:"kis_websocket_count"`
 	KISRESTCount      int `json:"kis_rest_count"`
 	NaverCount        int `json:"naver_count"`
+	SubscriberCount   int `json:"subscriber_count"`
 }
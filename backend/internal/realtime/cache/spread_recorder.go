@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// defaultSpreadFlushInterval 일별 스프레드 통계 저장 주기 (장중 누적값 upsert)
+const defaultSpreadFlushInterval = 5 * time.Minute
+
+// SpreadStatsStore persists daily spread statistics (s0_data.SpreadRepository)
+type SpreadStatsStore interface {
+	SaveSpreadStats(ctx context.Context, stats []contracts.SpreadStat) error
+}
+
+// SpreadRecorder periodically upserts OrderBookCache spread statistics
+// 장중에도 누적값을 주기적으로 저장 → 프로세스 재시작 시에도 당일 통계 대부분 보존
+type SpreadRecorder struct {
+	books    *OrderBookCache
+	store    SpreadStatsStore
+	interval time.Duration
+	logger   *logger.Logger
+}
+
+// NewSpreadRecorder creates a new spread recorder
+func NewSpreadRecorder(books *OrderBookCache, store SpreadStatsStore, log *logger.Logger) *SpreadRecorder {
+	return &SpreadRecorder{
+		books:    books,
+		store:    store,
+		interval: defaultSpreadFlushInterval,
+		logger:   log,
+	}
+}
+
+// Start flushes statistics until ctx is cancelled (final flush on exit)
+func (r *SpreadRecorder) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			r.Flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			r.Flush(ctx)
+		}
+	}
+}
+
+// Flush saves all held statistics and prunes days before today
+func (r *SpreadRecorder) Flush(ctx context.Context) {
+	stats := r.books.SpreadStats()
+	if len(stats) == 0 {
+		return
+	}
+
+	if err := r.store.SaveSpreadStats(ctx, stats); err != nil {
+		r.logger.WithError(err).WithFields(map[string]interface{}{
+			"count": len(stats),
+		}).Error("Failed to save spread stats")
+		return
+	}
+
+	// 저장 완료된 전일 이전 누적값 정리
+	pruned := r.books.PruneSpreadStats(time.Now())
+
+	r.logger.WithFields(map[string]interface{}{
+		"count":  len(stats),
+		"pruned": pruned,
+	}).Debug("Saved spread stats")
+}
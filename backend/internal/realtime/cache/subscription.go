@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+)
+
+const (
+	// WildcardSymbol subscribes to every symbol
+	WildcardSymbol = "*"
+
+	// DefaultSubscriptionBuffer is the per-subscriber channel capacity
+	DefaultSubscriptionBuffer = 256
+)
+
+// Subscription is a bounded tick channel for a set of symbols (or all symbols)
+// 버퍼가 가득 차면 가장 오래된 틱을 버리고 최신 틱을 넣음 (drop-oldest)
+// → 느린 소비자가 피드 고루틴을 막지 않음
+//
+// 구독자: /api/v1/stream/prices (handlers.StreamHandler), 청산 모니터 (execution.PositionMonitor.SubscribeTicks)
+// 모의 체결(paper) 브로커는 아직 없음 — execution.Broker 구현이 생기면 같은 구독으로 체결가를 받도록 연결
+type Subscription struct {
+	cache   *PriceCache
+	codes   []string // nil = wildcard
+	ch      chan *realtime.PriceTick
+	mu      sync.Mutex
+	closed  bool
+	dropped atomic.Uint64
+}
+
+// C returns the tick channel (closed on Close)
+func (s *Subscription) C() <-chan *realtime.PriceTick {
+	return s.ch
+}
+
+// Codes returns subscribed symbols (nil for wildcard)
+func (s *Subscription) Codes() []string {
+	return s.codes
+}
+
+// IsWildcard reports whether the subscription receives every symbol
+func (s *Subscription) IsWildcard() bool {
+	return s.codes == nil
+}
+
+// Dropped returns the number of ticks discarded due to a full buffer
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close unsubscribes and closes the channel
+func (s *Subscription) Close() {
+	s.cache.Unsubscribe(s)
+}
+
+// publish delivers a tick without blocking, evicting the oldest tick if full
+func (s *Subscription) publish(tick *realtime.PriceTick) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	for {
+		select {
+		case s.ch <- tick:
+			return
+		default:
+		}
+
+		// 버퍼 가득 참 → 가장 오래된 틱 제거 후 재시도
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// close closes the channel once
+func (s *Subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.ch)
+}
+
+// Subscribe registers a subscription for the given symbols
+// codes가 비어 있거나 "*"를 포함하면 전체 종목 구독
+// bufferSize <= 0 이면 DefaultSubscriptionBuffer 사용
+func (c *PriceCache) Subscribe(bufferSize int, codes ...string) *Subscription {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriptionBuffer
+	}
+
+	sub := &Subscription{
+		cache: c,
+		codes: normalizeCodes(codes),
+		ch:    make(chan *realtime.PriceTick, bufferSize),
+	}
+
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	if sub.codes == nil {
+		c.wildcardSubs[sub] = struct{}{}
+	} else {
+		for _, code := range sub.codes {
+			subs, ok := c.symbolSubs[code]
+			if !ok {
+				subs = make(map[*Subscription]struct{})
+				c.symbolSubs[code] = subs
+			}
+			subs[sub] = struct{}{}
+		}
+	}
+
+	c.logger.WithFields(map[string]interface{}{
+		"codes":  sub.codes,
+		"buffer": bufferSize,
+	}).Debug("Price subscription added")
+
+	return sub
+}
+
+// Unsubscribe removes the subscription and closes its channel
+func (c *PriceCache) Unsubscribe(sub *Subscription) {
+	c.subMu.Lock()
+	if sub.codes == nil {
+		delete(c.wildcardSubs, sub)
+	} else {
+		for _, code := range sub.codes {
+			if subs, ok := c.symbolSubs[code]; ok {
+				delete(subs, sub)
+				if len(subs) == 0 {
+					delete(c.symbolSubs, code)
+				}
+			}
+		}
+	}
+	c.subMu.Unlock()
+
+	sub.close()
+
+	c.logger.WithFields(map[string]interface{}{
+		"codes":   sub.codes,
+		"dropped": sub.Dropped(),
+	}).Debug("Price subscription removed")
+}
+
+// SubscriberCount returns the number of active subscriptions
+func (c *PriceCache) SubscriberCount() int {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+
+	seen := make(map[*Subscription]struct{}, len(c.wildcardSubs))
+	for sub := range c.wildcardSubs {
+		seen[sub] = struct{}{}
+	}
+	for _, subs := range c.symbolSubs {
+		for sub := range subs {
+			seen[sub] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+// publish fans out an accepted tick to symbol and wildcard subscribers
+func (c *PriceCache) publish(tick *realtime.PriceTick) {
+	c.subMu.RLock()
+	targets := make([]*Subscription, 0, len(c.wildcardSubs)+len(c.symbolSubs[tick.Code]))
+	for sub := range c.symbolSubs[tick.Code] {
+		targets = append(targets, sub)
+	}
+	for sub := range c.wildcardSubs {
+		targets = append(targets, sub)
+	}
+	c.subMu.RUnlock()
+
+	for _, sub := range targets {
+		sub.publish(tick)
+	}
+}
+
+// normalizeCodes trims/dedupes codes; returns nil for wildcard
+func normalizeCodes(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	var result []string
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if code == WildcardSymbol {
+			return nil
+		}
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		result = append(result, code)
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/realtime"
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+func newTestCache() *PriceCache {
+	return NewPriceCache(time.Minute, logger.New(&config.Config{LogLevel: "error", LogFormat: "json"}))
+}
+
+func tick(code string, price int64, at time.Time) *realtime.PriceTick {
+	return &realtime.PriceTick{Code: code, Price: price, Timestamp: at, Source: string(realtime.SourceKISWebSocket)}
+}
+
+// drain reads everything currently buffered
+func drain(sub *Subscription) []*realtime.PriceTick {
+	var ticks []*realtime.PriceTick
+	for {
+		select {
+		case t, ok := <-sub.C():
+			if !ok {
+				return ticks
+			}
+			ticks = append(ticks, t)
+		default:
+			return ticks
+		}
+	}
+}
+
+func TestPriceCache_SubscribeRoutesBySymbol(t *testing.T) {
+	c := newTestCache()
+	now := time.Now()
+
+	samsung := c.Subscribe(0, "005930")
+	all := c.Subscribe(0)
+	star := c.Subscribe(0, "000660", "*")
+	defer samsung.Close()
+	defer all.Close()
+	defer star.Close()
+
+	assert.False(t, samsung.IsWildcard())
+	assert.True(t, all.IsWildcard())
+	assert.True(t, star.IsWildcard())
+	assert.Equal(t, 3, c.SubscriberCount())
+
+	c.Update(tick("005930", 70000, now))
+	c.Update(tick("000660", 180000, now))
+
+	got := drain(samsung)
+	require.Len(t, got, 1)
+	assert.Equal(t, "005930", got[0].Code)
+
+	assert.Len(t, drain(all), 2)
+	assert.Len(t, drain(star), 2)
+}
+
+func TestPriceCache_RejectedUpdateIsNotPublished(t *testing.T) {
+	c := newTestCache()
+	now := time.Now()
+
+	sub := c.Subscribe(0, "005930")
+	defer sub.Close()
+
+	require.True(t, c.Update(tick("005930", 70000, now)))
+	require.False(t, c.Update(tick("005930", 69000, now.Add(-time.Second)))) // 오래된 데이터
+
+	got := drain(sub)
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(70000), got[0].Price)
+}
+
+func TestSubscription_DropsOldestWhenFull(t *testing.T) {
+	c := newTestCache()
+	now := time.Now()
+
+	sub := c.Subscribe(2, "005930")
+	defer sub.Close()
+
+	for i := 0; i < 5; i++ {
+		c.Update(tick("005930", int64(70000+i), now.Add(time.Duration(i)*time.Second)))
+	}
+
+	got := drain(sub)
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(70003), got[0].Price)
+	assert.Equal(t, int64(70004), got[1].Price)
+	assert.Equal(t, uint64(3), sub.Dropped())
+}
+
+func TestSubscription_CloseUnsubscribes(t *testing.T) {
+	c := newTestCache()
+
+	sub := c.Subscribe(0, "005930", "005930", " ")
+	assert.Equal(t, []string{"005930"}, sub.Codes())
+	assert.Equal(t, 1, c.SubscriberCount())
+
+	sub.Close()
+	sub.Close() // 중복 호출 안전
+
+	_, ok := <-sub.C()
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.SubscriberCount())
+
+	// 해제 후 업데이트는 패닉 없이 무시
+	assert.True(t, c.Update(tick("005930", 70000, time.Now())))
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
 
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
 	"github.com/wonny/aegis/v13/backend/internal/realtime"
 	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
@@ -18,21 +20,20 @@ import (
 )
 
 const (
-	// KIS API Rate Limit: 10 req/sec
-	totalRateLimit = 10
-
-	// Tier allocations (60%, 30%, 10%)
+	// 티어별 폴링 한도 (req/sec, 60%, 30%, 10%)
+	// 실제 전송은 kis.Session 예산(시세 조회 상한)을 추가로 거침
 	tier1RateLimit = 6
 	tier2RateLimit = 3
 	tier3RateLimit = 1
 )
 
 // TieredRESTPoller manages tiered REST polling for KIS API
-// ⭐ SSOT: KIS REST 폴링 및 Rate Limit 관리는 이 폴러에서만
+// ⭐ SSOT: KIS REST 폴링 티어 배분은 이 폴러에서만 (전체 요청 예산/토큰은 kis.Session)
 type TieredRESTPoller struct {
 	config     *config.Config
 	logger     *logger.Logger
 	httpClient *httputil.Client
+	session    *kis.Session
 	cache      *cache.PriceCache
 
 	// Tier symbols
@@ -56,11 +57,13 @@ type TieredRESTPoller struct {
 }
 
 // NewTieredRESTPoller creates a new tiered REST poller
-func NewTieredRESTPoller(cfg *config.Config, log *logger.Logger, httpClient *httputil.Client, priceCache *cache.PriceCache) *TieredRESTPoller {
+// session: 주문/계좌 조회와 공유하는 KIS 세션 (시세 조회 예산으로 폴링)
+func NewTieredRESTPoller(cfg *config.Config, log *logger.Logger, httpClient *httputil.Client, session *kis.Session, priceCache *cache.PriceCache) *TieredRESTPoller {
 	return &TieredRESTPoller{
 		config:     cfg,
 		logger:     log,
 		httpClient: httpClient,
+		session:    session,
 		cache:      priceCache,
 
 		tier1Symbols: make(map[string]bool),
@@ -231,11 +234,15 @@ func (p *TieredRESTPoller) pollTier(ctx context.Context, tier realtime.Tier) {
 		default:
 		}
 
-		// Wait for rate limiter
+		// Wait for tier limiter, then the shared quote budget
 		if err := limiter.Wait(ctx); err != nil {
 			p.logger.WithError(err).Error("Rate limiter wait failed")
 			return
 		}
+		if err := p.session.Wait(ctx, kis.ClassQuote); err != nil {
+			p.logger.WithError(err).Error("KIS budget wait failed")
+			return
+		}
 
 		// Fetch price
 		tick, err := p.fetchPrice(ctx, code)
@@ -267,8 +274,16 @@ func (p *TieredRESTPoller) fetchPrice(ctx context.Context, code string) (*realti
 	url := fmt.Sprintf("%s/uapi/domestic-stock/v1/quotations/inquire-price?FID_COND_MRKT_DIV_CODE=J&FID_INPUT_ISCD=%s",
 		p.config.KIS.BaseURL, code)
 
-	// Use httpClient.Get() method
-	httpResp, err := p.httpClient.Get(ctx, url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if err := p.session.Authorize(ctx, req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("tr_id", "FHKST01010100") // 국내주식 현재가
+
+	httpResp, err := p.httpClient.Do(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
 	"github.com/wonny/aegis/v13/backend/internal/realtime"
 	"github.com/wonny/aegis/v13/backend/internal/realtime/cache"
 	"github.com/wonny/aegis/v13/backend/internal/realtime/queue"
@@ -20,7 +21,7 @@ type FeedManager struct {
 	logger     *logger.Logger
 
 	// Feed sources
-	wsClient   *KISWebSocketClient
+	wsFeed     *KISWebSocketFeed
 	restPoller *TieredRESTPoller
 	naverFeed  *NaverFeed
 
@@ -37,12 +38,14 @@ type FeedManager struct {
 }
 
 // NewFeedManager creates a new feed manager
-func NewFeedManager(cfg *config.Config, log *logger.Logger, httpClient *httputil.Client, priceCache *cache.PriceCache, syncQueue *queue.SyncQueue) *FeedManager {
+// wsClient는 프로세스 공용 KIS WebSocket 연결 (API 구독/체결통보와 공유)
+// kisSession은 프로세스 공용 KIS 세션 (토큰, 요청 예산)
+func NewFeedManager(cfg *config.Config, log *logger.Logger, httpClient *httputil.Client, wsClient *kis.WSClient, kisSession *kis.Session, priceCache *cache.PriceCache, syncQueue *queue.SyncQueue) *FeedManager {
 	return &FeedManager{
 		config:     cfg,
 		logger:     log,
-		wsClient:   NewKISWebSocketClient(cfg, log, priceCache),
-		restPoller: NewTieredRESTPoller(cfg, log, httpClient, priceCache),
+		wsFeed:     NewKISWebSocketFeed(wsClient, log, priceCache),
+		restPoller: NewTieredRESTPoller(cfg, log, httpClient, kisSession, priceCache),
 		naverFeed:  NewNaverFeed(httpClient, log, priceCache),
 		cache:      priceCache,
 		syncQueue:  syncQueue,
@@ -55,8 +58,8 @@ func NewFeedManager(cfg *config.Config, log *logger.Logger, httpClient *httputil
 func (m *FeedManager) Start(ctx context.Context) error {
 	m.logger.Info("Starting feed manager")
 
-	// Start WebSocket client
-	if err := m.wsClient.Start(ctx); err != nil {
+	// Start WebSocket feed
+	if err := m.wsFeed.Start(ctx); err != nil {
 		return err
 	}
 
@@ -92,7 +95,7 @@ func (m *FeedManager) Stop() {
 	close(m.stopCh)
 
 	// Stop feed sources
-	m.wsClient.Stop()
+	m.wsFeed.Stop()
 	m.restPoller.Stop()
 	m.naverFeed.Stop()
 
@@ -111,8 +114,8 @@ func (m *FeedManager) UpdateSymbolPriority(code string, priority *realtime.Symbo
 	m.priorities[code] = priority
 	m.priorityMu.Unlock()
 
-	// Update WebSocket client
-	m.wsClient.UpdatePriority(priority)
+	// Update WebSocket feed
+	m.wsFeed.UpdatePriority(priority)
 
 	m.logger.WithFields(map[string]interface{}{
 		"code":  code,
@@ -126,8 +129,8 @@ func (m *FeedManager) RemoveSymbol(code string) {
 	delete(m.priorities, code)
 	m.priorityMu.Unlock()
 
-	// Remove from WebSocket client
-	m.wsClient.RemoveSymbol(code)
+	// Remove from WebSocket feed
+	m.wsFeed.RemoveSymbol(code)
 
 	m.logger.WithField("code", code).Debug("Removed symbol from tracking")
 }
@@ -202,7 +205,7 @@ func (m *FeedManager) rebalanceTiers() {
 	tier2Symbols := make([]string, 0)
 	tier3Symbols := make([]string, 0)
 
-	wsSymbols := m.wsClient.GetActiveSymbols()
+	wsSymbols := m.wsFeed.GetActiveSymbols()
 	wsSymbolsMap := make(map[string]bool)
 	for _, code := range wsSymbols {
 		wsSymbolsMap[code] = true
@@ -294,32 +297,39 @@ func (m *FeedManager) GetStats(ctx context.Context) (*FeedStats, error) {
 		return nil, err
 	}
 
-	wsSymbols := m.wsClient.GetActiveSymbols()
+	wsSymbols := m.wsFeed.GetActiveSymbols()
+	wsHealth := m.wsFeed.Health()
 
 	return &FeedStats{
-		WebSocketSymbols: len(wsSymbols),
-		Tier1Symbols:     tierStats.Tier1Count,
-		Tier2Symbols:     tierStats.Tier2Count,
-		Tier3Symbols:     tierStats.Tier3Count,
-		CacheTotal:       cacheStats.TotalCount,
-		CacheFresh:       cacheStats.FreshCount,
-		CacheStale:       cacheStats.StaleCount,
-		QueuePending:     queueStats.Pending,
-		QueueDone:        queueStats.Done,
-		QueueFailed:      queueStats.Failed,
+		WebSocketSymbols:        len(wsSymbols),
+		WebSocketConnected:      wsHealth.Connected,
+		WebSocketLastMessageAge: wsHealth.LastMessageAgeSec,
+		WebSocketReconnects:     wsHealth.ReconnectCount,
+		Tier1Symbols:            tierStats.Tier1Count,
+		Tier2Symbols:            tierStats.Tier2Count,
+		Tier3Symbols:            tierStats.Tier3Count,
+		CacheTotal:              cacheStats.TotalCount,
+		CacheFresh:              cacheStats.FreshCount,
+		CacheStale:              cacheStats.StaleCount,
+		QueuePending:            queueStats.Pending,
+		QueueDone:               queueStats.Done,
+		QueueFailed:             queueStats.Failed,
 	}, nil
 }
 
 // FeedStats represents statistics for the feed manager
 type FeedStats struct {
-	WebSocketSymbols int `json:"websocket_symbols"`
-	Tier1Symbols     int `json:"tier1_symbols"`
-	Tier2Symbols     int `json:"tier2_symbols"`
-	Tier3Symbols     int `json:"tier3_symbols"`
-	CacheTotal       int `json:"cache_total"`
-	CacheFresh       int `json:"cache_fresh"`
-	CacheStale       int `json:"cache_stale"`
-	QueuePending     int `json:"queue_pending"`
-	QueueDone        int `json:"queue_done"`
-	QueueFailed      int `json:"queue_failed"`
+	WebSocketSymbols        int     `json:"websocket_symbols"`
+	WebSocketConnected      bool    `json:"websocket_connected"`
+	WebSocketLastMessageAge float64 `json:"websocket_last_message_age_sec"` // -1: 수신 이력 없음
+	WebSocketReconnects     int64   `json:"websocket_reconnects"`
+	Tier1Symbols            int     `json:"tier1_symbols"`
+	Tier2Symbols            int     `json:"tier2_symbols"`
+	Tier3Symbols            int     `json:"tier3_symbols"`
+	CacheTotal              int     `json:"cache_total"`
+	CacheFresh              int     `json:"cache_fresh"`
+	CacheStale              int     `json:"cache_stale"`
+	QueuePending            int     `json:"queue_pending"`
+	QueueDone               int     `json:"queue_done"`
+	QueueFailed             int     `json:"queue_failed"`
 }
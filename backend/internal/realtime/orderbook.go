@@ -0,0 +1,63 @@
+package realtime
+
+import "time"
+
+// OrderBookLevel represents a single order book price level
+type OrderBookLevel struct {
+	Price int64 `json:"price"`
+	Qty   int64 `json:"qty"`
+}
+
+// OrderBook represents a real-time order book (호가) snapshot
+// ⭐ SSOT: 실시간 호가 데이터 구조
+// Asks[0] = ASK1 (최우선 매도호가), Bids[0] = BID1 (최우선 매수호가)
+type OrderBook struct {
+	Code        string           `json:"code"`
+	Asks        []OrderBookLevel `json:"asks"`
+	Bids        []OrderBookLevel `json:"bids"`
+	TotalAskQty int64            `json:"total_ask_qty"` // 총 매도호가 잔량
+	TotalBidQty int64            `json:"total_bid_qty"` // 총 매수호가 잔량
+	Timestamp   time.Time        `json:"timestamp"`
+	Source      string           `json:"source"`
+	IsStale     bool             `json:"is_stale"`
+}
+
+// BestAsk returns ASK1 price (0 if empty)
+func (b *OrderBook) BestAsk() int64 {
+	if len(b.Asks) == 0 {
+		return 0
+	}
+	return b.Asks[0].Price
+}
+
+// BestBid returns BID1 price (0 if empty)
+func (b *OrderBook) BestBid() int64 {
+	if len(b.Bids) == 0 {
+		return 0
+	}
+	return b.Bids[0].Price
+}
+
+// IsValid reports whether both sides are quoted and not crossed
+func (b *OrderBook) IsValid() bool {
+	ask, bid := b.BestAsk(), b.BestBid()
+	return ask > 0 && bid > 0 && ask >= bid
+}
+
+// Mid returns (ASK1 + BID1) / 2 (0 if invalid)
+func (b *OrderBook) Mid() float64 {
+	if !b.IsValid() {
+		return 0
+	}
+	return float64(b.BestAsk()+b.BestBid()) / 2
+}
+
+// SpreadPct returns (ask1-bid1)/((ask1+bid1)/2) (0 if invalid)
+// ⭐ SSOT: universe.filters.spread.formula와 동일한 정의
+func (b *OrderBook) SpreadPct() float64 {
+	mid := b.Mid()
+	if mid <= 0 {
+		return 0
+	}
+	return float64(b.BestAsk()-b.BestBid()) / mid
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/wonny/aegis/v13/backend/internal/realtime"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // SyncJob represents a price synchronization job
@@ -125,6 +126,7 @@ func (q *SyncQueue) Start(ctx context.Context) {
 			if err := q.processBatch(ctx); err != nil {
 				q.logger.WithError(err).Error("Failed to process sync batch")
 			}
+			q.reportBacklog(ctx)
 		}
 	}
 }
@@ -273,6 +275,23 @@ func (q *SyncQueue) GetStats(ctx context.Context) (*QueueStats, error) {
 	return &stats, nil
 }
 
+// reportBacklog exports the pending job count and the oldest pending age
+func (q *SyncQueue) reportBacklog(ctx context.Context) {
+	var pending int
+	var oldest float64
+	err := q.db.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(created_at)), 0)
+		FROM realtime.sync_jobs
+		WHERE status = 'pending'
+	`).Scan(&pending, &oldest)
+	if err != nil {
+		q.logger.WithError(err).Warn("Failed to read sync queue backlog")
+		return
+	}
+
+	metrics.SetSyncQueueBacklog(pending, time.Duration(oldest*float64(time.Second)))
+}
+
 // QueueStats represents queue statistics
 type QueueStats struct {
 	Pending    int `json:"pending"`
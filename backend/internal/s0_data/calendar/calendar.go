@@ -0,0 +1,122 @@
+package calendar
+
+import (
+	"time"
+)
+
+// Source identifies who declared a calendar day
+const (
+	SourceKRX    = "KRX"    // KOSPI 지수 시세 존재 여부로 판정
+	SourceManual = "MANUAL" // 운영자가 등록한 휴장일 (미래 임시공휴일 등)
+)
+
+// fixedHolidays are KRX closures that fall on the same date every year
+// 음력 공휴일·대체공휴일은 data.trading_calendar에 등록해야 함
+var fixedHolidays = map[string]string{
+	"01-01": "신정",
+	"03-01": "삼일절",
+	"05-01": "근로자의 날",
+	"05-05": "어린이날",
+	"06-06": "현충일",
+	"08-15": "광복절",
+	"10-03": "개천절",
+	"10-09": "한글날",
+	"12-25": "성탄절",
+	"12-31": "연말 휴장일",
+}
+
+// Day is one calendar entry (data.trading_calendar 1행)
+type Day struct {
+	Date   time.Time `json:"date"`
+	IsOpen bool      `json:"is_open"`
+	Name   string    `json:"name,omitempty"` // 휴장 사유
+	Source string    `json:"source"`
+}
+
+// Calendar answers KRX trading-day questions
+// ⭐ SSOT: 개장일 판정은 이 타입에서만
+//
+// 등록된 날짜는 테이블 값을 따르고, 미등록 날짜는 주말·고정 공휴일 규칙으로 판정
+type Calendar struct {
+	days map[string]bool
+}
+
+// New creates a calendar from stored days
+func New(days []Day) *Calendar {
+	c := &Calendar{days: make(map[string]bool, len(days))}
+	for _, d := range days {
+		c.days[key(d.Date)] = d.IsOpen
+	}
+	return c
+}
+
+// IsTradingDay reports whether KRX is open on the date
+func (c *Calendar) IsTradingDay(date time.Time) bool {
+	if open, ok := c.days[key(date)]; ok {
+		return open
+	}
+	return defaultOpen(date)
+}
+
+// Known reports whether the date is explicitly registered
+func (c *Calendar) Known(date time.Time) bool {
+	_, ok := c.days[key(date)]
+	return ok
+}
+
+// TradingDays returns trading days in [from, to] (날짜만, UTC 자정)
+func (c *Calendar) TradingDays(from, to time.Time) []time.Time {
+	days := make([]time.Time, 0)
+	for d := Truncate(from); !d.After(Truncate(to)); d = d.AddDate(0, 0, 1) {
+		if c.IsTradingDay(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// PrevTradingDay returns the last trading day strictly before date
+func (c *Calendar) PrevTradingDay(date time.Time) time.Time {
+	d := Truncate(date).AddDate(0, 0, -1)
+	for !c.IsTradingDay(d) {
+		d = d.AddDate(0, 0, -1)
+	}
+	return d
+}
+
+// NextTradingDay returns the first trading day strictly after date
+func (c *Calendar) NextTradingDay(date time.Time) time.Time {
+	d := Truncate(date).AddDate(0, 0, 1)
+	for !c.IsTradingDay(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+// LastClosedSession returns the latest trading day whose session has closed
+// 장 마감(15:30) 후 데이터 확정 여유를 두고 16시 이후면 당일 포함
+func (c *Calendar) LastClosedSession(now time.Time) time.Time {
+	if now.Hour() >= 16 && c.IsTradingDay(now) {
+		return Truncate(now)
+	}
+	return c.PrevTradingDay(now)
+}
+
+// Truncate drops the time of day, keeping the local calendar date
+func Truncate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func key(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// defaultOpen applies the weekend and fixed-holiday rule
+func defaultOpen(date time.Time) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	_, holiday := fixedHolidays[date.Format("01-02")]
+	return !holiday
+}
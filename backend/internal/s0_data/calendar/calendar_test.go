@@ -0,0 +1,87 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+	d, _ := time.Parse("2006-01-02", s)
+	return d
+}
+
+func TestCalendar_IsTradingDay(t *testing.T) {
+	cal := New([]Day{
+		{Date: date("2026-09-24"), IsOpen: false, Name: "추석", Source: SourceManual},
+		{Date: date("2026-10-05"), IsOpen: true, Source: SourceKRX},
+	})
+
+	tests := []struct {
+		name string
+		date string
+		want bool
+	}{
+		{"ordinary weekday", "2026-10-14", true},
+		{"saturday", "2026-10-17", false},
+		{"sunday", "2026-10-18", false},
+		{"fixed holiday", "2026-10-09", false},
+		{"year-end closing", "2026-12-31", false},
+		{"registered holiday", "2026-09-24", false},
+		{"registered open day", "2026-10-05", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cal.IsTradingDay(date(tt.date)))
+		})
+	}
+}
+
+func TestCalendar_TradingDays(t *testing.T) {
+	cal := New([]Day{{Date: date("2026-10-05"), IsOpen: false, Source: SourceManual}})
+
+	days := cal.TradingDays(date("2026-10-02"), date("2026-10-12"))
+
+	want := []time.Time{
+		date("2026-10-02"), date("2026-10-06"), date("2026-10-07"), date("2026-10-08"), date("2026-10-12"),
+	}
+	assert.Equal(t, want, days)
+}
+
+func TestCalendar_PrevNextTradingDay(t *testing.T) {
+	cal := New(nil)
+
+	assert.Equal(t, date("2026-10-16"), cal.PrevTradingDay(date("2026-10-19")))
+	assert.Equal(t, date("2026-10-08"), cal.PrevTradingDay(date("2026-10-12")))
+	assert.Equal(t, date("2026-10-19"), cal.NextTradingDay(date("2026-10-16")))
+	assert.Equal(t, date("2026-10-12"), cal.NextTradingDay(date("2026-10-08")))
+}
+
+func TestCalendar_LastClosedSession(t *testing.T) {
+	cal := New(nil)
+
+	assert.Equal(t, date("2026-10-16"), cal.LastClosedSession(date("2026-10-16").Add(17*time.Hour)))
+	assert.Equal(t, date("2026-10-15"), cal.LastClosedSession(date("2026-10-16").Add(10*time.Hour)))
+	assert.Equal(t, date("2026-10-16"), cal.LastClosedSession(date("2026-10-18").Add(20*time.Hour)))
+}
+
+func TestTruncate_KeepsLocalDate(t *testing.T) {
+	kst := time.FixedZone("KST", 9*3600)
+	got := Truncate(time.Date(2026, 10, 16, 1, 30, 0, 0, kst))
+	assert.Equal(t, date("2026-10-16"), got)
+}
+
+func TestBuildDays(t *testing.T) {
+	open := []time.Time{date("2026-10-08"), date("2026-10-12")}
+
+	days := BuildDays(open, date("2026-10-08"), date("2026-10-14"), date("2026-10-13"))
+
+	want := []Day{
+		{Date: date("2026-10-08"), IsOpen: true, Source: SourceKRX},
+		{Date: date("2026-10-09"), IsOpen: false, Name: "한글날", Source: SourceKRX},
+		{Date: date("2026-10-12"), IsOpen: true, Source: SourceKRX},
+	}
+	assert.Equal(t, want, days)
+}
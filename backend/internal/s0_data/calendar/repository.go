@@ -0,0 +1,97 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository handles the KRX trading calendar
+// ⭐ SSOT: data.trading_calendar 접근은 여기서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new calendar repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// Load builds a Calendar from every stored day
+func (r *Repository) Load(ctx context.Context) (*Calendar, error) {
+	days, err := r.ListDays(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	return New(days), nil
+}
+
+// IsTradingDay loads the calendar and checks one date (scheduler.TradingCalendar)
+func (r *Repository) IsTradingDay(ctx context.Context, date time.Time) (bool, error) {
+	cal, err := r.Load(ctx)
+	if err != nil {
+		return false, err
+	}
+	return cal.IsTradingDay(date), nil
+}
+
+// ListDays returns stored days ordered by date (closedOnly=true면 휴장일만)
+func (r *Repository) ListDays(ctx context.Context, closedOnly bool) ([]Day, error) {
+	query := `
+		SELECT trade_date, is_open, COALESCE(name, ''), source
+		FROM data.trading_calendar
+		WHERE ($1 = FALSE OR is_open = FALSE)
+		ORDER BY trade_date
+	`
+	rows, err := r.pool.Query(ctx, query, closedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("query trading calendar: %w", err)
+	}
+	defer rows.Close()
+
+	days := make([]Day, 0)
+	for rows.Next() {
+		var d Day
+		if err := rows.Scan(&d.Date, &d.IsOpen, &d.Name, &d.Source); err != nil {
+			return nil, fmt.Errorf("scan calendar day: %w", err)
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+// SaveDays upserts calendar days
+// KRX 판정은 과거 사실이므로 MANUAL 등록을 덮어씀, MANUAL은 KRX 판정 행을 덮어쓰지 않음
+func (r *Repository) SaveDays(ctx context.Context, days []Day) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO data.trading_calendar (trade_date, is_open, name, source, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NOW())
+		ON CONFLICT (trade_date) DO UPDATE SET
+			is_open = EXCLUDED.is_open,
+			name = COALESCE(EXCLUDED.name, data.trading_calendar.name),
+			source = EXCLUDED.source,
+			updated_at = NOW()
+		WHERE EXCLUDED.source = 'KRX' OR data.trading_calendar.source <> 'KRX'`
+
+	for _, d := range days {
+		batch.Queue(query, Truncate(d.Date), d.IsOpen, d.Name, d.Source)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for _, d := range days {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("save calendar day %s: %w", key(d.Date), err)
+		}
+	}
+	return nil
+}
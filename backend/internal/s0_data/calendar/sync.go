@@ -0,0 +1,90 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// SyncResult summarizes a calendar sync
+type SyncResult struct {
+	Open   int `json:"open"`
+	Closed int `json:"closed"`
+}
+
+// Syncer derives the trading calendar from KRX index history
+type Syncer struct {
+	krx    *krx.Client
+	repo   *Repository
+	logger *logger.Logger
+}
+
+// NewSyncer creates a new calendar syncer
+func NewSyncer(krxClient *krx.Client, repo *Repository, log *logger.Logger) *Syncer {
+	return &Syncer{
+		krx:    krxClient,
+		repo:   repo,
+		logger: log.WithField("module", "calendar"),
+	}
+}
+
+// Sync records open/closed weekdays of [from, to]
+// 지수 시세가 없는 평일 = 휴장일, 단 오늘 이후는 장 마감 전이라 휴장 판정하지 않음
+func (s *Syncer) Sync(ctx context.Context, from, to time.Time) (*SyncResult, error) {
+	openDays, err := s.krx.FetchTradingDays(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("fetch trading days: %w", err)
+	}
+	if len(openDays) == 0 {
+		return nil, fmt.Errorf("no trading days returned for %s ~ %s", key(from), key(to))
+	}
+
+	days := BuildDays(openDays, from, to, Truncate(time.Now()))
+
+	if err := s.repo.SaveDays(ctx, days); err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	for _, d := range days {
+		if d.IsOpen {
+			result.Open++
+		} else {
+			result.Closed++
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"from":   key(from),
+		"to":     key(to),
+		"open":   result.Open,
+		"closed": result.Closed,
+	}).Info("Trading calendar synced")
+
+	return result, nil
+}
+
+// BuildDays turns observed open days into calendar rows for every weekday of [from, to]
+// today 이후 평일은 관측값이 없으면 판정 보류 (행 미생성)
+func BuildDays(openDays []time.Time, from, to, today time.Time) []Day {
+	open := make(map[string]bool, len(openDays))
+	for _, d := range openDays {
+		open[key(d)] = true
+	}
+
+	days := make([]Day, 0)
+	for d := Truncate(from); !d.After(Truncate(to)); d = d.AddDate(0, 0, 1) {
+		if open[key(d)] {
+			days = append(days, Day{Date: d, IsOpen: true, Source: SourceKRX})
+			continue
+		}
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday || !d.Before(today) {
+			continue
+		}
+		days = append(days, Day{Date: d, IsOpen: false, Name: fixedHolidays[d.Format("01-02")], Source: SourceKRX})
+	}
+	return days
+}
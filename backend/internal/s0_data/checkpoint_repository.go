@@ -0,0 +1,293 @@
+package s0_data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 수집 체크포인트 상태
+const (
+	CheckpointOK      = "ok"      // 계획된 날짜 모두 저장
+	CheckpointPartial = "partial" // 소스에 일부 날짜 없음
+	CheckpointFailed  = "failed"  // 조회/저장 실패
+)
+
+// 실패 구간 상태
+const (
+	FailurePending = "pending" // 재시도 대기
+	FailureDead    = "dead"    // 재시도 한도 초과 (계획에서 제외)
+)
+
+// datasetTables maps a collection dataset to its date-keyed table
+var datasetTables = map[string]string{
+	"prices":        "data.daily_prices",
+	"investor_flow": "data.investor_flow",
+}
+
+// CollectTarget is an active stock with its listing window
+type CollectTarget struct {
+	Code          string
+	ListingDate   time.Time
+	DelistingDate *time.Time
+}
+
+// Checkpoint is the last collection state of a stock×dataset
+type Checkpoint struct {
+	Dataset    string     `json:"dataset"`
+	StockCode  string     `json:"stock_code"`
+	LastDate   *time.Time `json:"last_date,omitempty"`
+	LastRunAt  time.Time  `json:"last_run_at"`
+	LastStatus string     `json:"last_status"`
+}
+
+// CollectionFailure is a pending or abandoned date range of a stock×dataset
+type CollectionFailure struct {
+	Dataset     string    `json:"dataset"`
+	StockCode   string    `json:"stock_code"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error"`
+	Status      string    `json:"status"`
+	NextRetryAt time.Time `json:"next_retry_at"`
+}
+
+// Blocks reports whether the failure suppresses collecting date at now
+// dead는 항상, pending은 재시도 시각 전까지 해당 구간을 계획에서 제외
+func (f CollectionFailure) Blocks(date, now time.Time) bool {
+	if date.Before(f.From) || date.After(f.To) {
+		return false
+	}
+	return f.Status == FailureDead || now.Before(f.NextRetryAt)
+}
+
+// CheckpointRepository handles incremental collection state
+// ⭐ SSOT: data.collection_checkpoints / data.collection_failures 접근은 여기서만
+type CheckpointRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCheckpointRepository creates a new checkpoint repository
+func NewCheckpointRepository(pool *pgxpool.Pool) *CheckpointRepository {
+	return &CheckpointRepository{pool: pool}
+}
+
+// GetCollectTargets returns active stocks with listing dates
+func (r *CheckpointRepository) GetCollectTargets(ctx context.Context, codes []string) ([]CollectTarget, error) {
+	query := `
+		SELECT code, listing_date, delisting_date
+		FROM data.stocks
+		WHERE status = 'active'
+		  AND (cardinality($1::text[]) = 0 OR code = ANY($1))
+		ORDER BY code
+	`
+	if codes == nil {
+		codes = []string{}
+	}
+
+	rows, err := r.pool.Query(ctx, query, codes)
+	if err != nil {
+		return nil, fmt.Errorf("query collect targets: %w", err)
+	}
+	defer rows.Close()
+
+	targets := make([]CollectTarget, 0)
+	for rows.Next() {
+		var t CollectTarget
+		if err := rows.Scan(&t.Code, &t.ListingDate, &t.DelistingDate); err != nil {
+			return nil, fmt.Errorf("scan collect target: %w", err)
+		}
+		targets = append(targets, t)
+	}
+	return targets, rows.Err()
+}
+
+// GetStoredDates returns dates already stored for a stock in [from, to]
+func (r *CheckpointRepository) GetStoredDates(ctx context.Context, dataset, code string, from, to time.Time) ([]time.Time, error) {
+	table, ok := datasetTables[dataset]
+	if !ok {
+		return nil, fmt.Errorf("unknown dataset: %s", dataset)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT trade_date FROM %s
+		WHERE stock_code = $1 AND trade_date BETWEEN $2 AND $3
+		ORDER BY trade_date
+	`, table)
+
+	return r.queryDates(ctx, query, code, from, to)
+}
+
+// GetHaltedDates returns dates the stock was flagged TRADING_HALT in [from, to]
+func (r *CheckpointRepository) GetHaltedDates(ctx context.Context, code string, from, to time.Time) ([]time.Time, error) {
+	query := `
+		SELECT status_date FROM data.stock_status_history
+		WHERE stock_code = $1 AND status_date BETWEEN $2 AND $3
+		  AND 'TRADING_HALT' = ANY(flags)
+		ORDER BY status_date
+	`
+	return r.queryDates(ctx, query, code, from, to)
+}
+
+func (r *CheckpointRepository) queryDates(ctx context.Context, query, code string, from, to time.Time) ([]time.Time, error) {
+	rows, err := r.pool.Query(ctx, query, code, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query dates for %s: %w", code, err)
+	}
+	defer rows.Close()
+
+	dates := make([]time.Time, 0)
+	for rows.Next() {
+		var d time.Time
+		if err := rows.Scan(&d); err != nil {
+			return nil, fmt.Errorf("scan date: %w", err)
+		}
+		dates = append(dates, d)
+	}
+	return dates, rows.Err()
+}
+
+// GetCheckpoints returns all checkpoints of a dataset keyed by stock code
+func (r *CheckpointRepository) GetCheckpoints(ctx context.Context, dataset string) (map[string]Checkpoint, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT dataset, stock_code, last_date, last_run_at, last_status
+		FROM data.collection_checkpoints
+		WHERE dataset = $1
+	`, dataset)
+	if err != nil {
+		return nil, fmt.Errorf("query checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	checkpoints := make(map[string]Checkpoint)
+	for rows.Next() {
+		var cp Checkpoint
+		if err := rows.Scan(&cp.Dataset, &cp.StockCode, &cp.LastDate, &cp.LastRunAt, &cp.LastStatus); err != nil {
+			return nil, fmt.Errorf("scan checkpoint: %w", err)
+		}
+		checkpoints[cp.StockCode] = cp
+	}
+	return checkpoints, rows.Err()
+}
+
+// SaveCheckpoint upserts a checkpoint (last_date는 뒤로 가지 않음)
+func (r *CheckpointRepository) SaveCheckpoint(ctx context.Context, cp Checkpoint) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO data.collection_checkpoints (dataset, stock_code, last_date, last_run_at, last_status)
+		VALUES ($1, $2, $3, NOW(), $4)
+		ON CONFLICT (dataset, stock_code) DO UPDATE SET
+			last_date = GREATEST(data.collection_checkpoints.last_date, EXCLUDED.last_date),
+			last_run_at = NOW(),
+			last_status = EXCLUDED.last_status
+	`, cp.Dataset, cp.StockCode, cp.LastDate, cp.LastStatus)
+	if err != nil {
+		return fmt.Errorf("save checkpoint %s/%s: %w", cp.Dataset, cp.StockCode, err)
+	}
+	return nil
+}
+
+// GetFailures returns failure ranges of a dataset grouped by stock code
+func (r *CheckpointRepository) GetFailures(ctx context.Context, dataset string) (map[string][]CollectionFailure, error) {
+	list, err := r.ListFailures(ctx, dataset, "")
+	if err != nil {
+		return nil, err
+	}
+	failures := make(map[string][]CollectionFailure)
+	for _, f := range list {
+		failures[f.StockCode] = append(failures[f.StockCode], f)
+	}
+	return failures, nil
+}
+
+// ListFailures returns failures filtered by dataset/status (빈 문자열이면 전체)
+func (r *CheckpointRepository) ListFailures(ctx context.Context, dataset, status string) ([]CollectionFailure, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT dataset, stock_code, from_date, to_date, attempts, COALESCE(last_error, ''), status, next_retry_at
+		FROM data.collection_failures
+		WHERE ($1 = '' OR dataset = $1) AND ($2 = '' OR status = $2)
+		ORDER BY dataset, stock_code, from_date
+	`, dataset, status)
+	if err != nil {
+		return nil, fmt.Errorf("query collection failures: %w", err)
+	}
+	defer rows.Close()
+
+	failures := make([]CollectionFailure, 0)
+	for rows.Next() {
+		var f CollectionFailure
+		if err := rows.Scan(&f.Dataset, &f.StockCode, &f.From, &f.To, &f.Attempts, &f.LastError, &f.Status, &f.NextRetryAt); err != nil {
+			return nil, fmt.Errorf("scan collection failure: %w", err)
+		}
+		failures = append(failures, f)
+	}
+	return failures, rows.Err()
+}
+
+// ResolveFailures deletes pending ranges overlapping [from, to] of a stock×dataset
+// 재수집을 시도한 구간의 이전 실패는 지우고, 여전히 비어 있는 날짜는 SaveFailure로 다시 기록
+func (r *CheckpointRepository) ResolveFailures(ctx context.Context, dataset, code string, from, to time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM data.collection_failures
+		WHERE dataset = $1 AND stock_code = $2 AND status = 'pending'
+		  AND from_date <= $4 AND to_date >= $3
+	`, dataset, code, from, to)
+	if err != nil {
+		return fmt.Errorf("resolve collection failures %s/%s: %w", dataset, code, err)
+	}
+	return nil
+}
+
+// SaveFailure upserts a failure range
+func (r *CheckpointRepository) SaveFailure(ctx context.Context, f CollectionFailure) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO data.collection_failures (
+			dataset, stock_code, from_date, to_date, attempts, last_error, status, next_retry_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (dataset, stock_code, from_date) DO UPDATE SET
+			to_date = EXCLUDED.to_date,
+			attempts = EXCLUDED.attempts,
+			last_error = EXCLUDED.last_error,
+			status = EXCLUDED.status,
+			next_retry_at = EXCLUDED.next_retry_at,
+			updated_at = NOW()
+	`, f.Dataset, f.StockCode, f.From, f.To, f.Attempts, f.LastError, f.Status, f.NextRetryAt)
+	if err != nil {
+		return fmt.Errorf("save collection failure %s/%s: %w", f.Dataset, f.StockCode, err)
+	}
+	return nil
+}
+
+// RequeueDeadFailures re-queues abandoned ranges overlapping [from, to] (백필 재시도)
+// codes가 비어 있으면 전체 종목
+func (r *CheckpointRepository) RequeueDeadFailures(ctx context.Context, dataset string, codes []string, from, to time.Time) (int64, error) {
+	if codes == nil {
+		codes = []string{}
+	}
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE data.collection_failures
+		SET status = 'pending', attempts = 0, next_retry_at = NOW(), updated_at = NOW()
+		WHERE status = 'dead' AND dataset = $1
+		  AND (cardinality($2::text[]) = 0 OR stock_code = ANY($2))
+		  AND from_date <= $4 AND to_date >= $3
+	`, dataset, codes, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("requeue dead failures %s: %w", dataset, err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ResetDeadFailures re-queues abandoned ranges (수동 재시도용)
+func (r *CheckpointRepository) ResetDeadFailures(ctx context.Context, dataset string) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE data.collection_failures
+		SET status = 'pending', attempts = 0, next_retry_at = NOW(), updated_at = NOW()
+		WHERE status = 'dead' AND ($1 = '' OR dataset = $1)
+	`, dataset)
+	if err != nil {
+		return 0, fmt.Errorf("reset dead failures: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
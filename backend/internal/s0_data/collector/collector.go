@@ -11,6 +11,7 @@ import (
 	"github.com/wonny/aegis/v13/backend/internal/external/naver"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
 // Collector orchestrates data collection from external sources
@@ -25,7 +26,8 @@ type Collector struct {
 
 // Config holds collector configuration
 type Config struct {
-	Workers int // Number of concurrent workers
+	Workers  int       // Number of concurrent workers
+	Progress *Progress // 종목별 진행률 (nil이면 보고 안 함)
 }
 
 // NewCollector creates a new Collector instance
@@ -67,6 +69,7 @@ func (c *Collector) FetchAllPrices(ctx context.Context, from, to time.Time, cfg
 		"to":          to.Format("2006-01-02"),
 		"workers":     cfg.Workers,
 	}).Info("Starting price collection")
+	cfg.Progress.Start(string(DatasetPrices), len(stocks))
 
 	// 2. Create worker pool
 	results := make([]FetchResult, 0, len(stocks))
@@ -101,6 +104,7 @@ func (c *Collector) FetchAllPrices(ctx context.Context, from, to time.Time, cfg
 	failCount := 0
 	for result := range resultCh {
 		results = append(results, result)
+		cfg.Progress.Record(string(DatasetPrices), result)
 		if result.Error != nil {
 			failCount++
 		} else {
@@ -114,25 +118,29 @@ func (c *Collector) FetchAllPrices(ctx context.Context, from, to time.Time, cfg
 		"total":   len(results),
 	}).Info("Price collection completed")
 
+	// 취소되면 남은 종목은 처리하지 않고 에러 반환 (호출부가 완료로 오인하지 않도록)
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
 	return results, nil
 }
 
 // priceWorker processes price fetching for stocks
 func (c *Collector) priceWorker(ctx context.Context, workerID int, stockCh <-chan s0_data.Stock, resultCh chan<- FetchResult, from, to time.Time) {
 	for stock := range stockCh {
-		select {
-		case <-ctx.Done():
-			resultCh <- FetchResult{
-				StockCode: stock.Code,
-				Error:     ctx.Err(),
-			}
+		// 취소: 남은 종목은 처리하지 않음
+		if ctx.Err() != nil {
 			return
-		default:
 		}
 
 		// Fetch prices
 		prices, err := c.naverClient.FetchPrices(ctx, stock.Code, from, to)
 		if err != nil {
+			if ctx.Err() != nil {
+				return // 진행 중 요청이 취소됨 (실패로 집계하지 않음)
+			}
+			metrics.ObserveCollect("naver", "prices", err)
 			c.logger.WithError(err).WithFields(map[string]interface{}{
 				"worker":     workerID,
 				"stock_code": stock.Code,
@@ -151,6 +159,7 @@ func (c *Collector) priceWorker(ctx context.Context, workerID int, stockCh <-cha
 
 		// Save to database
 		if err := c.repo.SavePrices(ctx, prices); err != nil {
+			metrics.ObserveCollect("naver", "prices", err)
 			c.logger.WithError(err).WithFields(map[string]interface{}{
 				"worker":     workerID,
 				"stock_code": stock.Code,
@@ -168,6 +177,7 @@ func (c *Collector) priceWorker(ctx context.Context, workerID int, stockCh <-cha
 			"stock_code": stock.Code,
 			"count":      len(prices),
 		}).Debug("Fetched prices")
+		metrics.ObserveCollect("naver", "prices", nil)
 
 		resultCh <- FetchResult{
 			StockCode:  stock.Code,
@@ -190,6 +200,7 @@ func (c *Collector) FetchAllInvestorFlow(ctx context.Context, from, to time.Time
 		"to":          to.Format("2006-01-02"),
 		"workers":     cfg.Workers,
 	}).Info("Starting investor flow collection")
+	cfg.Progress.Start(string(DatasetInvestorFlow), len(stocks))
 
 	// 2. Create worker pool
 	results := make([]FetchResult, 0, len(stocks))
@@ -224,6 +235,7 @@ func (c *Collector) FetchAllInvestorFlow(ctx context.Context, from, to time.Time
 	failCount := 0
 	for result := range resultCh {
 		results = append(results, result)
+		cfg.Progress.Record(string(DatasetInvestorFlow), result)
 		if result.Error != nil {
 			failCount++
 		} else {
@@ -237,25 +249,29 @@ func (c *Collector) FetchAllInvestorFlow(ctx context.Context, from, to time.Time
 		"total":   len(results),
 	}).Info("Investor flow collection completed")
 
+	// 취소되면 남은 종목은 처리하지 않고 에러 반환 (호출부가 완료로 오인하지 않도록)
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
 	return results, nil
 }
 
 // investorWorker processes investor flow fetching for stocks
 func (c *Collector) investorWorker(ctx context.Context, workerID int, stockCh <-chan s0_data.Stock, resultCh chan<- FetchResult, from, to time.Time) {
 	for stock := range stockCh {
-		select {
-		case <-ctx.Done():
-			resultCh <- FetchResult{
-				StockCode: stock.Code,
-				Error:     ctx.Err(),
-			}
+		// 취소: 남은 종목은 처리하지 않음
+		if ctx.Err() != nil {
 			return
-		default:
 		}
 
 		// Fetch investor flow
 		flows, err := c.naverClient.FetchInvestorFlow(ctx, stock.Code, from, to)
 		if err != nil {
+			if ctx.Err() != nil {
+				return // 진행 중 요청이 취소됨 (실패로 집계하지 않음)
+			}
+			metrics.ObserveCollect("naver", "investor_flow", err)
 			c.logger.WithError(err).WithFields(map[string]interface{}{
 				"worker":     workerID,
 				"stock_code": stock.Code,
@@ -274,6 +290,7 @@ func (c *Collector) investorWorker(ctx context.Context, workerID int, stockCh <-
 
 		// Save to database
 		if err := c.repo.SaveInvestorFlow(ctx, flows); err != nil {
+			metrics.ObserveCollect("naver", "investor_flow", err)
 			c.logger.WithError(err).WithFields(map[string]interface{}{
 				"worker":     workerID,
 				"stock_code": stock.Code,
@@ -291,6 +308,7 @@ func (c *Collector) investorWorker(ctx context.Context, workerID int, stockCh <-
 			"stock_code": stock.Code,
 			"count":      len(flows),
 		}).Debug("Fetched investor flow")
+		metrics.ObserveCollect("naver", "investor_flow", nil)
 
 		resultCh <- FetchResult{
 			StockCode:     stock.Code,
@@ -345,7 +363,9 @@ func (c *Collector) FetchAll(ctx context.Context, from, to time.Time, cfg Config
 
 // FetchDisclosures fetches disclosure data from DART for all active stocks
 // ⭐ SSOT: DART 공시 수집은 이 함수에서만
-func (c *Collector) FetchDisclosures(ctx context.Context, from, to time.Time) error {
+func (c *Collector) FetchDisclosures(ctx context.Context, from, to time.Time) (err error) {
+	defer func() { metrics.ObserveCollect("dart", "disclosures", err) }()
+
 	c.logger.WithFields(map[string]interface{}{
 		"from": from.Format("2006-01-02"),
 		"to":   to.Format("2006-01-02"),
@@ -396,7 +416,9 @@ func (c *Collector) FetchDisclosures(ctx context.Context, from, to time.Time) er
 
 // FetchMarketTrends fetches market trend data from KRX (via Naver)
 // ⭐ SSOT: KRX 시장 지표 수집은 이 함수에서만
-func (c *Collector) FetchMarketTrends(ctx context.Context) error {
+func (c *Collector) FetchMarketTrends(ctx context.Context) (err error) {
+	defer func() { metrics.ObserveCollect("krx", "market_trends", err) }()
+
 	c.logger.Info("Starting market trend collection")
 
 	// Fetch KOSPI trend
@@ -440,7 +462,9 @@ func (c *Collector) FetchMarketTrends(ctx context.Context) error {
 
 // FetchMarketCaps fetches market capitalization for all stocks (uses Naver API - legacy)
 // Deprecated: Use FetchMarketCapsFromKRX instead
-func (c *Collector) FetchMarketCaps(ctx context.Context) error {
+func (c *Collector) FetchMarketCaps(ctx context.Context) (err error) {
+	defer func() { metrics.ObserveCollect("naver", "market_caps", err) }()
+
 	c.logger.Info("Starting market cap collection (Naver)")
 
 	allCaps := []naver.MarketCapData{}
@@ -480,7 +504,9 @@ func (c *Collector) FetchMarketCaps(ctx context.Context) error {
 
 // FetchMarketCapsFromKRX fetches market cap and shares outstanding from KRX
 // ⭐ SSOT: KRX 시가총액/상장주식수 수집은 이 함수에서만
-func (c *Collector) FetchMarketCapsFromKRX(ctx context.Context) error {
+func (c *Collector) FetchMarketCapsFromKRX(ctx context.Context) (err error) {
+	defer func() { metrics.ObserveCollect("krx", "market_caps", err) }()
+
 	c.logger.Info("Starting market cap collection from KRX")
 
 	// Fetch all market caps from KRX (KOSPI + KOSDAQ)
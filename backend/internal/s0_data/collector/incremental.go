@@ -0,0 +1,361 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/s0_data"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// defaultIncrementalLookback is the window for stocks without a checkpoint
+const defaultIncrementalLookback = 30
+
+// PlanOptions controls an incremental or backfill run
+type PlanOptions struct {
+	Datasets  []Dataset
+	Codes     []string  // 비어 있으면 전체 활성 종목
+	Backfill  bool      // true: From 이후 전체 갭 탐지, false: 체크포인트 이후만
+	RetryDead bool      // true: 창 내 dead 실패 구간도 pending으로 되돌려 재시도 (백필 갭 복구)
+	From      time.Time // 백필 시작일 (Backfill=true일 때만 사용)
+	To        time.Time // 종료일 (기본 오늘)
+	Workers   int
+}
+
+// PlanResult summarizes one dataset of a run
+type PlanResult struct {
+	Dataset     Dataset `json:"dataset"`
+	Stocks      int     `json:"stocks"`       // 대상 종목 수
+	UpToDate    int     `json:"up_to_date"`   // 갭 없음 (요청 생략)
+	Fetched     int     `json:"fetched"`      // 모든 갭 채움
+	Partial     int     `json:"partial"`      // 소스에 일부 날짜 없음
+	Failed      int     `json:"failed"`       // 조회/저장 실패
+	MissingDays int     `json:"missing_days"` // 계획된 누락 거래일 합계
+	Rows        int     `json:"rows"`         // 저장된 행 수
+}
+
+// Planner runs gap-aware collection on top of the Collector's sources
+// ⭐ SSOT: 증분/백필 수집 계획은 이 타입에서만
+//
+// 종목 단위로 저장 → 체크포인트 갱신 순서로 진행하므로, 중단 후 재실행하면
+// 저장된 날짜는 갭에서 빠지고 남은 종목만 다시 수집됨
+type Planner struct {
+	collector   *Collector
+	checkpoints *s0_data.CheckpointRepository
+	calendar    *calendar.Repository
+	logger      *logger.Logger
+}
+
+// NewPlanner creates a new collection planner
+func NewPlanner(col *Collector, checkpoints *s0_data.CheckpointRepository, cal *calendar.Repository, log *logger.Logger) *Planner {
+	return &Planner{
+		collector:   col,
+		checkpoints: checkpoints,
+		calendar:    cal,
+		logger:      log.WithField("module", "planner"),
+	}
+}
+
+// stockJob is one stock×dataset unit of work
+type stockJob struct {
+	target   s0_data.CollectTarget
+	missing  []time.Time
+	failures []s0_data.CollectionFailure
+}
+
+// Run plans and collects every requested dataset
+func (p *Planner) Run(ctx context.Context, opts PlanOptions) ([]PlanResult, error) {
+	cal, err := p.calendar.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load trading calendar: %w", err)
+	}
+
+	targets, err := p.checkpoints.GetCollectTargets(ctx, opts.Codes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	to := calendar.Truncate(now)
+	if !opts.To.IsZero() {
+		to = calendar.Truncate(opts.To)
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 5
+	}
+	datasets := opts.Datasets
+	if len(datasets) == 0 {
+		datasets = []Dataset{DatasetPrices, DatasetInvestorFlow}
+	}
+
+	results := make([]PlanResult, 0, len(datasets))
+	for _, dataset := range datasets {
+		result, err := p.runDataset(ctx, dataset, cal, targets, opts, to, now)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", dataset, err)
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+func (p *Planner) runDataset(ctx context.Context, dataset Dataset, cal *calendar.Calendar, targets []s0_data.CollectTarget, opts PlanOptions, to, now time.Time) (*PlanResult, error) {
+	checkpoints, err := p.checkpoints.GetCheckpoints(ctx, string(dataset))
+	if err != nil {
+		return nil, err
+	}
+	if opts.RetryDead {
+		requeued, err := p.checkpoints.RequeueDeadFailures(ctx, string(dataset), opts.Codes, calendar.Truncate(opts.From), to)
+		if err != nil {
+			return nil, err
+		}
+		if requeued > 0 {
+			p.logger.WithFields(map[string]interface{}{
+				"dataset":  dataset,
+				"requeued": requeued,
+			}).Info("Dead failure ranges re-queued for retry")
+		}
+	}
+	failures, err := p.checkpoints.GetFailures(ctx, string(dataset))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PlanResult{Dataset: dataset, Stocks: len(targets)}
+
+	// 1. 계획: 종목별 누락 거래일
+	jobs := make([]stockJob, 0)
+	for _, target := range targets {
+		from := p.windowStart(opts, checkpoints, target.Code, to)
+		if from.Before(target.ListingDate) {
+			from = target.ListingDate
+		}
+		// 재시도 시각이 된 실패 구간이 창보다 앞서면 창을 넓혀 함께 처리
+		stockFailures := failures[target.Code]
+		for _, f := range stockFailures {
+			if f.Status == s0_data.FailurePending && !now.Before(f.NextRetryAt) && f.From.Before(from) {
+				from = f.From
+			}
+		}
+		if from.After(to) {
+			result.UpToDate++
+			continue
+		}
+
+		stored, err := p.checkpoints.GetStoredDates(ctx, string(dataset), target.Code, from, to)
+		if err != nil {
+			return nil, err
+		}
+		halted, err := p.checkpoints.GetHaltedDates(ctx, target.Code, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		missing := PlanGaps(GapInput{
+			TradingDays: cal.TradingDays(from, to),
+			Stored:      stored,
+			Halted:      halted,
+			ListingDate: target.ListingDate,
+			RefreshFrom: to, // 당일 일봉은 장중 수집분일 수 있어 항상 갱신
+			Failures:    stockFailures,
+			Now:         now,
+		})
+		if len(missing) == 0 {
+			result.UpToDate++
+			continue
+		}
+		result.MissingDays += len(missing)
+		jobs = append(jobs, stockJob{target: target, missing: missing, failures: stockFailures})
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"dataset":      dataset,
+		"backfill":     opts.Backfill,
+		"stocks":       len(targets),
+		"to_fetch":     len(jobs),
+		"missing_days": result.MissingDays,
+	}).Info("Collection plan built")
+
+	// 2. 수집: worker pool (종목 단위 저장 + 체크포인트)
+	type outcome struct {
+		status string
+		rows   int
+	}
+	jobCh := make(chan stockJob, len(jobs))
+	outCh := make(chan outcome, len(jobs))
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				if ctx.Err() != nil {
+					return
+				}
+				status, rows := p.collectStock(ctx, dataset, job, now)
+				outCh <- outcome{status: status, rows: rows}
+			}
+		}()
+	}
+	for _, job := range jobs {
+		jobCh <- job
+	}
+	close(jobCh)
+
+	go func() {
+		wg.Wait()
+		close(outCh)
+	}()
+
+	for out := range outCh {
+		result.Rows += out.rows
+		switch out.status {
+		case s0_data.CheckpointOK:
+			result.Fetched++
+		case s0_data.CheckpointPartial:
+			result.Partial++
+		default:
+			result.Failed++
+		}
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"dataset":    dataset,
+		"up_to_date": result.UpToDate,
+		"fetched":    result.Fetched,
+		"partial":    result.Partial,
+		"failed":     result.Failed,
+		"rows":       result.Rows,
+	}).Info("Incremental collection completed")
+
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	return result, nil
+}
+
+// windowStart picks the first date to inspect for a stock
+// 증분: 체크포인트 마지막 저장일부터 (없으면 최근 30일), 백필: opts.From부터
+func (p *Planner) windowStart(opts PlanOptions, checkpoints map[string]s0_data.Checkpoint, code string, to time.Time) time.Time {
+	if opts.Backfill {
+		if opts.From.IsZero() {
+			return to.AddDate(-1, 0, 0)
+		}
+		return calendar.Truncate(opts.From)
+	}
+	if cp, ok := checkpoints[code]; ok && cp.LastDate != nil {
+		return calendar.Truncate(*cp.LastDate)
+	}
+	return to.AddDate(0, 0, -defaultIncrementalLookback)
+}
+
+// collectStock fetches the span of missing days and records checkpoint/failure
+func (p *Planner) collectStock(ctx context.Context, dataset Dataset, job stockJob, now time.Time) (string, int) {
+	code := job.target.Code
+	from, to := job.missing[0], job.missing[len(job.missing)-1]
+
+	fetched, rows, err := p.fetchAndSave(ctx, dataset, code, from, to)
+	if err != nil {
+		p.logger.WithError(err).WithFields(map[string]interface{}{
+			"dataset":    dataset,
+			"stock_code": code,
+		}).Warn("Gap collection failed")
+		p.recordFailure(ctx, job, dataset, from, to, err.Error(), now)
+		p.saveCheckpoint(ctx, dataset, code, nil, s0_data.CheckpointFailed)
+		return s0_data.CheckpointFailed, 0
+	}
+
+	// 재수집한 구간의 이전 실패는 정리하고, 여전히 빈 날짜만 다시 기록
+	if err := p.checkpoints.ResolveFailures(ctx, string(dataset), code, from, to); err != nil {
+		p.logger.WithError(err).WithField("stock_code", code).Warn("Failed to resolve collection failures")
+	}
+
+	status := s0_data.CheckpointOK
+	if left := Unfilled(job.missing, fetched); len(left) > 0 {
+		status = s0_data.CheckpointPartial
+		msg := fmt.Sprintf("source returned no data for %d of %d days", len(left), len(job.missing))
+		p.recordFailure(ctx, job, dataset, left[0], left[len(left)-1], msg, now)
+	}
+
+	var last *time.Time
+	if len(fetched) > 0 {
+		latest := fetched[0]
+		for _, d := range fetched[1:] {
+			if d.After(latest) {
+				latest = d
+			}
+		}
+		last = &latest
+	}
+	p.saveCheckpoint(ctx, dataset, code, last, status)
+	return status, rows
+}
+
+// fetchAndSave calls the dataset source for [from, to] and stores the rows
+func (p *Planner) fetchAndSave(ctx context.Context, dataset Dataset, code string, from, to time.Time) ([]time.Time, int, error) {
+	c := p.collector
+	switch dataset {
+	case DatasetPrices:
+		prices, err := c.naverClient.FetchPrices(ctx, code, from, to)
+		if err != nil {
+			return nil, 0, fmt.Errorf("fetch prices: %w", err)
+		}
+		dates := make([]time.Time, 0, len(prices))
+		for i := range prices {
+			prices[i].StockCode = code
+			dates = append(dates, calendar.Truncate(prices[i].TradeDate))
+		}
+		if err := c.repo.SavePrices(ctx, prices); err != nil {
+			return nil, 0, fmt.Errorf("save prices: %w", err)
+		}
+		return dates, len(prices), nil
+
+	case DatasetInvestorFlow:
+		flows, err := c.naverClient.FetchInvestorFlow(ctx, code, from, to)
+		if err != nil {
+			return nil, 0, fmt.Errorf("fetch investor flow: %w", err)
+		}
+		dates := make([]time.Time, 0, len(flows))
+		for i := range flows {
+			flows[i].StockCode = code
+			dates = append(dates, calendar.Truncate(flows[i].TradeDate))
+		}
+		if err := c.repo.SaveInvestorFlow(ctx, flows); err != nil {
+			return nil, 0, fmt.Errorf("save investor flow: %w", err)
+		}
+		return dates, len(flows), nil
+
+	default:
+		return nil, 0, fmt.Errorf("unknown dataset: %s", dataset)
+	}
+}
+
+func (p *Planner) recordFailure(ctx context.Context, job stockJob, dataset Dataset, from, to time.Time, msg string, now time.Time) {
+	prev := Overlapping(job.failures, from, to)
+	f := NextFailure(prev, dataset, job.target.Code, from, to, msg, now)
+	if prev != nil && !prev.From.Equal(f.From) {
+		// 병합으로 시작일(키)이 바뀌면 이전 행 제거
+		if err := p.checkpoints.ResolveFailures(ctx, string(dataset), job.target.Code, prev.From, prev.From); err != nil {
+			p.logger.WithError(err).WithField("stock_code", job.target.Code).Warn("Failed to replace collection failure")
+		}
+	}
+	if err := p.checkpoints.SaveFailure(ctx, f); err != nil {
+		p.logger.WithError(err).WithField("stock_code", job.target.Code).Error("Failed to record collection failure")
+	}
+}
+
+func (p *Planner) saveCheckpoint(ctx context.Context, dataset Dataset, code string, last *time.Time, status string) {
+	cp := s0_data.Checkpoint{
+		Dataset:    string(dataset),
+		StockCode:  code,
+		LastDate:   last,
+		LastStatus: status,
+	}
+	if err := p.checkpoints.SaveCheckpoint(ctx, cp); err != nil {
+		p.logger.WithError(err).WithField("stock_code", code).Error("Failed to save checkpoint")
+	}
+}
@@ -0,0 +1,142 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/s0_data"
+)
+
+// Dataset identifies a per-stock, date-keyed collection target
+type Dataset string
+
+const (
+	DatasetPrices       Dataset = "prices"        // data.daily_prices (Naver 일봉)
+	DatasetInvestorFlow Dataset = "investor_flow" // data.investor_flow (Naver 수급)
+)
+
+// 실패 재시도 정책
+const (
+	maxFailureAttempts = 5
+	baseRetryBackoff   = 5 * time.Minute
+	maxRetryBackoff    = 24 * time.Hour
+)
+
+// GapInput is everything the planner needs to know about one stock×dataset
+type GapInput struct {
+	TradingDays []time.Time                 // 창 내 개장일 (calendar 판정 완료)
+	Stored      []time.Time                 // 이미 저장된 날짜
+	Halted      []time.Time                 // 거래정지 플래그 날짜 (소스에 데이터 없음)
+	ListingDate time.Time                   // 상장일 이전은 제외
+	RefreshFrom time.Time                   // 이 날짜 이후는 저장돼 있어도 재수집 (장중 부분 일봉 갱신)
+	Failures    []s0_data.CollectionFailure // 재시도 대기/포기 구간
+	Now         time.Time
+}
+
+// PlanGaps returns the trading days that must be fetched
+// ⭐ SSOT: 갭 판정 규칙은 이 함수에서만
+func PlanGaps(in GapInput) []time.Time {
+	stored := dateSet(in.Stored)
+	halted := dateSet(in.Halted)
+
+	missing := make([]time.Time, 0)
+	for _, d := range in.TradingDays {
+		if d.Before(in.ListingDate) || halted[dateKey(d)] {
+			continue
+		}
+		if stored[dateKey(d)] && (in.RefreshFrom.IsZero() || d.Before(in.RefreshFrom)) {
+			continue
+		}
+		if blocked(in.Failures, d, in.Now) {
+			continue
+		}
+		missing = append(missing, d)
+	}
+	return missing
+}
+
+// Unfilled returns planned dates the source did not return
+func Unfilled(planned, fetched []time.Time) []time.Time {
+	got := dateSet(fetched)
+	left := make([]time.Time, 0)
+	for _, d := range planned {
+		if !got[dateKey(d)] {
+			left = append(left, d)
+		}
+	}
+	return left
+}
+
+// Overlapping returns the first pending failure overlapping [from, to]
+func Overlapping(failures []s0_data.CollectionFailure, from, to time.Time) *s0_data.CollectionFailure {
+	for i := range failures {
+		f := &failures[i]
+		if f.Status == s0_data.FailurePending && !f.From.After(to) && !f.To.Before(from) {
+			return f
+		}
+	}
+	return nil
+}
+
+// NextFailure builds the failure row after an unsuccessful attempt
+// 겹치는 기존 구간(prev)과 병합해 시도 횟수를 이어가고 지수 백오프, 한도 초과 시 dead
+func NextFailure(prev *s0_data.CollectionFailure, dataset Dataset, code string, from, to time.Time, errMsg string, now time.Time) s0_data.CollectionFailure {
+	attempts := 1
+	if prev != nil {
+		attempts = prev.Attempts + 1
+		if prev.From.Before(from) {
+			from = prev.From
+		}
+		if prev.To.After(to) {
+			to = prev.To
+		}
+	}
+
+	status := s0_data.FailurePending
+	if attempts >= maxFailureAttempts {
+		status = s0_data.FailureDead
+	}
+
+	return s0_data.CollectionFailure{
+		Dataset:     string(dataset),
+		StockCode:   code,
+		From:        from,
+		To:          to,
+		Attempts:    attempts,
+		LastError:   errMsg,
+		Status:      status,
+		NextRetryAt: now.Add(retryBackoff(attempts)),
+	}
+}
+
+// retryBackoff doubles from 5 minutes up to a day
+func retryBackoff(attempts int) time.Duration {
+	backoff := baseRetryBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return backoff
+}
+
+func blocked(failures []s0_data.CollectionFailure, date, now time.Time) bool {
+	for _, f := range failures {
+		if f.Blocks(date, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func dateSet(dates []time.Time) map[string]bool {
+	set := make(map[string]bool, len(dates))
+	for _, d := range dates {
+		set[dateKey(d)] = true
+	}
+	return set
+}
+
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
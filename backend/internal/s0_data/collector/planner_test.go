@@ -0,0 +1,140 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wonny/aegis/v13/backend/internal/s0_data"
+)
+
+func d(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func days(ss ...string) []time.Time {
+	out := make([]time.Time, len(ss))
+	for i, s := range ss {
+		out[i] = d(s)
+	}
+	return out
+}
+
+func TestPlanGaps(t *testing.T) {
+	now := d("2026-10-16").Add(17 * time.Hour)
+	trading := days("2026-10-08", "2026-10-12", "2026-10-13", "2026-10-14", "2026-10-15", "2026-10-16")
+
+	tests := []struct {
+		name string
+		in   GapInput
+		want []time.Time
+	}{
+		{
+			name: "fully stored",
+			in: GapInput{
+				TradingDays: trading,
+				Stored:      trading,
+				Now:         now,
+			},
+			want: []time.Time{},
+		},
+		{
+			name: "interior gap and missing tail",
+			in: GapInput{
+				TradingDays: trading,
+				Stored:      days("2026-10-08", "2026-10-12", "2026-10-14"),
+				Now:         now,
+			},
+			want: days("2026-10-13", "2026-10-15", "2026-10-16"),
+		},
+		{
+			name: "halted days and pre-listing days are not gaps",
+			in: GapInput{
+				TradingDays: trading,
+				Stored:      days("2026-10-13", "2026-10-16"),
+				Halted:      days("2026-10-14", "2026-10-15"),
+				ListingDate: d("2026-10-13"),
+				Now:         now,
+			},
+			want: []time.Time{},
+		},
+		{
+			name: "refresh re-fetches stored tail",
+			in: GapInput{
+				TradingDays: trading,
+				Stored:      trading,
+				RefreshFrom: d("2026-10-16"),
+				Now:         now,
+			},
+			want: days("2026-10-16"),
+		},
+		{
+			name: "dead and backing-off failures are skipped, due ones retried",
+			in: GapInput{
+				TradingDays: trading,
+				Stored:      days("2026-10-16"),
+				Failures: []s0_data.CollectionFailure{
+					{From: d("2026-10-08"), To: d("2026-10-12"), Status: s0_data.FailureDead, NextRetryAt: now.Add(-time.Hour)},
+					{From: d("2026-10-13"), To: d("2026-10-13"), Status: s0_data.FailurePending, NextRetryAt: now.Add(time.Hour)},
+					{From: d("2026-10-14"), To: d("2026-10-14"), Status: s0_data.FailurePending, NextRetryAt: now.Add(-time.Minute)},
+				},
+				Now: now,
+			},
+			want: days("2026-10-14", "2026-10-15"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PlanGaps(tt.in))
+		})
+	}
+}
+
+func TestUnfilled(t *testing.T) {
+	planned := days("2026-10-13", "2026-10-14", "2026-10-15")
+	fetched := days("2026-10-12", "2026-10-13", "2026-10-15")
+
+	assert.Equal(t, days("2026-10-14"), Unfilled(planned, fetched))
+	assert.Empty(t, Unfilled(planned, planned))
+}
+
+func TestOverlapping(t *testing.T) {
+	failures := []s0_data.CollectionFailure{
+		{From: d("2026-10-01"), To: d("2026-10-05"), Status: s0_data.FailureDead},
+		{From: d("2026-10-08"), To: d("2026-10-12"), Status: s0_data.FailurePending},
+	}
+
+	assert.Nil(t, Overlapping(failures, d("2026-10-02"), d("2026-10-03")), "dead ranges are never merged")
+	assert.Equal(t, d("2026-10-08"), Overlapping(failures, d("2026-10-12"), d("2026-10-14")).From)
+	assert.Nil(t, Overlapping(failures, d("2026-10-13"), d("2026-10-14")))
+}
+
+func TestNextFailure(t *testing.T) {
+	now := d("2026-10-16")
+
+	first := NextFailure(nil, DatasetPrices, "005930", d("2026-10-14"), d("2026-10-15"), "timeout", now)
+	assert.Equal(t, 1, first.Attempts)
+	assert.Equal(t, s0_data.FailurePending, first.Status)
+	assert.Equal(t, now.Add(5*time.Minute), first.NextRetryAt)
+	assert.Equal(t, "prices", first.Dataset)
+
+	prev := &s0_data.CollectionFailure{From: d("2026-10-12"), To: d("2026-10-14"), Attempts: 3, Status: s0_data.FailurePending}
+	merged := NextFailure(prev, DatasetPrices, "005930", d("2026-10-14"), d("2026-10-16"), "no data", now)
+	assert.Equal(t, d("2026-10-12"), merged.From)
+	assert.Equal(t, d("2026-10-16"), merged.To)
+	assert.Equal(t, 4, merged.Attempts)
+	assert.Equal(t, now.Add(40*time.Minute), merged.NextRetryAt)
+
+	prev.Attempts = maxFailureAttempts - 1
+	dead := NextFailure(prev, DatasetPrices, "005930", d("2026-10-14"), d("2026-10-14"), "no data", now)
+	assert.Equal(t, s0_data.FailureDead, dead.Status)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, retryBackoff(1))
+	assert.Equal(t, 10*time.Minute, retryBackoff(2))
+	assert.Equal(t, 24*time.Hour, retryBackoff(20))
+}
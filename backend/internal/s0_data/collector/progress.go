@@ -0,0 +1,116 @@
+package collector
+
+import "sync"
+
+// maxProgressErrors caps the stock errors kept per dataset (나머지는 Failed 건수로만 집계)
+const maxProgressErrors = 20
+
+// StockError is one failed stock in a collection run
+type StockError struct {
+	Code  string `json:"code,omitempty"`
+	Error string `json:"error"`
+}
+
+// DatasetProgress is the per-stock progress of one dataset
+type DatasetProgress struct {
+	Total  int          `json:"total"`
+	Done   int          `json:"done"`   // 처리 완료 (성공 + 실패)
+	Failed int          `json:"failed"` // 실패
+	Errors []StockError `json:"errors,omitempty"`
+}
+
+// ProgressSnapshot is a point-in-time copy of a collection run's progress
+type ProgressSnapshot struct {
+	Datasets map[string]DatasetProgress `json:"datasets"`
+	Total    int                        `json:"total"`
+	Done     int                        `json:"done"`
+	Failed   int                        `json:"failed"`
+	Percent  float64                    `json:"percent"`
+}
+
+// Progress tracks per-stock collection progress (Config.Progress)
+// priceWorker/investorWorker 결과마다 갱신되고, onChange로 스냅샷을 전달 (작업 큐 진행률 저장용)
+type Progress struct {
+	mu       sync.Mutex
+	datasets map[string]*DatasetProgress
+	onChange func(ProgressSnapshot)
+}
+
+// NewProgress creates a tracker; onChange may be nil
+func NewProgress(onChange func(ProgressSnapshot)) *Progress {
+	return &Progress{
+		datasets: make(map[string]*DatasetProgress),
+		onChange: onChange,
+	}
+}
+
+// Start sets the number of items to collect for a dataset (nil-safe)
+// dataset: 종목 단위 수집은 Dataset 값, 일괄 수집(공시/시가총액)은 수집 타입 이름
+func (p *Progress) Start(dataset string, total int) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.datasets[dataset] = &DatasetProgress{Total: total}
+	snapshot := p.snapshotLocked()
+	p.mu.Unlock()
+
+	p.notify(snapshot)
+}
+
+// Record counts one finished item of a dataset (nil-safe)
+func (p *Progress) Record(dataset string, result FetchResult) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	d, ok := p.datasets[dataset]
+	if !ok {
+		d = &DatasetProgress{}
+		p.datasets[dataset] = d
+	}
+	d.Done++
+	if result.Error != nil {
+		d.Failed++
+		if len(d.Errors) < maxProgressErrors {
+			d.Errors = append(d.Errors, StockError{Code: result.StockCode, Error: result.Error.Error()})
+		}
+	}
+	snapshot := p.snapshotLocked()
+	p.mu.Unlock()
+
+	p.notify(snapshot)
+}
+
+// Snapshot returns the current progress
+func (p *Progress) Snapshot() ProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.snapshotLocked()
+}
+
+func (p *Progress) snapshotLocked() ProgressSnapshot {
+	s := ProgressSnapshot{Datasets: make(map[string]DatasetProgress, len(p.datasets))}
+
+	for name, progress := range p.datasets {
+		d := *progress
+		d.Errors = append([]StockError(nil), d.Errors...)
+		s.Datasets[name] = d
+		s.Total += d.Total
+		s.Done += d.Done
+		s.Failed += d.Failed
+	}
+	if s.Total > 0 {
+		s.Percent = float64(s.Done) / float64(s.Total) * 100
+	}
+	return s
+}
+
+func (p *Progress) notify(snapshot ProgressSnapshot) {
+	if p.onChange != nil {
+		p.onChange(snapshot)
+	}
+}
@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	var last ProgressSnapshot
+	p := NewProgress(func(s ProgressSnapshot) { last = s })
+
+	p.Start(string(DatasetPrices), 40)
+	p.Start(string(DatasetInvestorFlow), 10)
+	for i := 0; i < 30; i++ {
+		var err error
+		if i%2 == 0 {
+			err = errors.New("timeout")
+		}
+		p.Record(string(DatasetPrices), FetchResult{StockCode: fmt.Sprintf("%06d", i), Error: err})
+	}
+	p.Record(string(DatasetInvestorFlow), FetchResult{StockCode: "005930"})
+
+	prices := last.Datasets[string(DatasetPrices)]
+	assert.Equal(t, DatasetProgress{Total: 40, Done: 30, Failed: 15, Errors: prices.Errors}, prices)
+	assert.Len(t, prices.Errors, 15)
+	assert.Equal(t, StockError{Code: "000000", Error: "timeout"}, prices.Errors[0])
+
+	assert.Equal(t, 50, last.Total)
+	assert.Equal(t, 31, last.Done)
+	assert.Equal(t, 15, last.Failed)
+	assert.InDelta(t, 62.0, last.Percent, 1e-9)
+	assert.Equal(t, last, p.Snapshot())
+}
+
+func TestProgress_CapsErrors(t *testing.T) {
+	p := NewProgress(nil)
+	for i := 0; i < maxProgressErrors+5; i++ {
+		p.Record("disclosure", FetchResult{Error: errors.New("page failed")})
+	}
+
+	d := p.Snapshot().Datasets["disclosure"]
+	assert.Equal(t, maxProgressErrors+5, d.Failed)
+	assert.Len(t, d.Errors, maxProgressErrors)
+}
+
+func TestProgress_NilSafe(t *testing.T) {
+	var p *Progress
+	p.Start(string(DatasetPrices), 1)
+	p.Record(string(DatasetPrices), FetchResult{})
+}
@@ -0,0 +1,182 @@
+package corpaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// DART 공시 제목 키워드
+// 무상증자결정만 구조화 API(fricDecsn)로 자동 수집 가능
+// 나머지는 정정·조건 해석이 필요해 임포트 파일로 등록
+const (
+	keywordBonus = "무상증자결정"
+)
+
+var manualKeywords = []string{
+	"주식분할결정",
+	"주식병합결정",
+	"유상증자결정",
+	"현금ㆍ현물배당결정",
+	"현금배당결정",
+}
+
+// maxDisclosurePages limits disclosure list paging (collector와 동일)
+const maxDisclosurePages = 10
+
+// ManualReview is a disclosure that must be entered through the import file
+type ManualReview struct {
+	Code     string `json:"code"`
+	CorpName string `json:"corp_name"`
+	Title    string `json:"title"`
+	RceptNo  string `json:"rcept_no"`
+	URL      string `json:"url"`
+}
+
+// SyncResult summarizes a DART sync
+type SyncResult struct {
+	Imported int            `json:"imported"`
+	Manual   []ManualReview `json:"manual,omitempty"`
+}
+
+// DARTSync imports corporate actions from DART disclosures
+type DARTSync struct {
+	client *dart.Client
+	repo   *Repository
+	logger *logger.Logger
+}
+
+// NewDARTSync creates a new DART corporate action sync
+func NewDARTSync(client *dart.Client, repo *Repository, log *logger.Logger) *DARTSync {
+	return &DARTSync{client: client, repo: repo, logger: log}
+}
+
+// Sync scans disclosures filed in [from, to] and upserts bonus issues
+func (s *DARTSync) Sync(ctx context.Context, from, to time.Time) (*SyncResult, error) {
+	result := &SyncResult{}
+	actions := make([]Action, 0)
+
+	for page := 1; page <= maxDisclosurePages; page++ {
+		disclosures, totalPages, err := s.client.FetchDisclosuresForPage(ctx, from, to, page)
+		if err != nil {
+			return nil, fmt.Errorf("fetch disclosures page %d: %w", page, err)
+		}
+
+		for _, d := range disclosures {
+			if d.StockCode == "" {
+				continue
+			}
+
+			switch {
+			case strings.Contains(d.ReportNm, keywordBonus):
+				action, err := s.fetchBonus(ctx, d)
+				if err != nil {
+					s.logger.WithError(err).WithFields(map[string]interface{}{
+						"code":     d.StockCode,
+						"rcept_no": d.RceptNo,
+					}).Warn("Bonus issue decision not parsed, manual review required")
+					result.Manual = append(result.Manual, manualReview(d))
+					continue
+				}
+				actions = append(actions, action)
+			case isManualTitle(d.ReportNm):
+				result.Manual = append(result.Manual, manualReview(d))
+			}
+		}
+
+		if len(disclosures) == 0 || page >= totalPages {
+			break
+		}
+	}
+
+	if err := s.repo.UpsertActions(ctx, actions); err != nil {
+		return nil, err
+	}
+	result.Imported = len(actions)
+
+	s.logger.WithFields(map[string]interface{}{
+		"from":     from.Format("2006-01-02"),
+		"to":       to.Format("2006-01-02"),
+		"imported": result.Imported,
+		"manual":   len(result.Manual),
+	}).Info("DART corporate action sync completed")
+
+	return result, nil
+}
+
+// fetchBonus loads the structured 무상증자결정 report matching the disclosure
+func (s *DARTSync) fetchBonus(ctx context.Context, d dart.Disclosure) (Action, error) {
+	filed, err := time.Parse("20060102", d.RceptDt)
+	if err != nil {
+		return Action{}, fmt.Errorf("invalid rcept_dt: %w", err)
+	}
+
+	decisions, err := s.client.FetchBonusIssueDecisions(ctx, d.CorpCode, filed, filed)
+	if err != nil {
+		return Action{}, err
+	}
+
+	for _, dec := range decisions {
+		if dec.RceptNo != d.RceptNo {
+			continue
+		}
+		return bonusAction(d.StockCode, dec)
+	}
+	return Action{}, fmt.Errorf("report %s not found in fricDecsn", d.RceptNo)
+}
+
+// bonusAction converts a 무상증자결정 report into a BONUS action
+func bonusAction(code string, dec dart.BonusIssueDecision) (Action, error) {
+	ratio, err := dart.ParseReportNumber(dec.SharesPerCommon)
+	if err != nil {
+		return Action{}, fmt.Errorf("shares per common: %w", err)
+	}
+	recordDate, err := dart.ParseReportDate(dec.RecordDate)
+	if err != nil {
+		return Action{}, fmt.Errorf("record date: %w", err)
+	}
+
+	action := Action{
+		Code:      code,
+		ExDate:    exDateFromRecordDate(recordDate),
+		Type:      ActionBonus,
+		Ratio:     ratio,
+		Source:    SourceDART,
+		SourceRef: dec.RceptNo,
+		Memo:      "무상증자결정",
+	}
+	return action, action.Validate()
+}
+
+// exDateFromRecordDate returns the ex-rights date for a record date
+// T+2 결제: 권리락일 = 기준일 직전 영업일 (휴장일 미반영, 주말만 건너뜀)
+func exDateFromRecordDate(record time.Time) time.Time {
+	d := record.AddDate(0, 0, -1)
+	for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		d = d.AddDate(0, 0, -1)
+	}
+	return d
+}
+
+func isManualTitle(title string) bool {
+	for _, kw := range manualKeywords {
+		if strings.Contains(title, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func manualReview(d dart.Disclosure) ManualReview {
+	return ManualReview{
+		Code:     d.StockCode,
+		CorpName: d.CorpName,
+		Title:    strings.TrimSpace(d.ReportNm),
+		RceptNo:  d.RceptNo,
+		URL:      dart.GetDARTURL(d.RceptNo),
+	}
+}
@@ -0,0 +1,81 @@
+package corpaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// Engine derives adjustment factors from the corporate action ledger
+// ⭐ SSOT: 수정계수 갱신은 이 엔진에서만
+type Engine struct {
+	repo   *Repository
+	logger *logger.Logger
+}
+
+// NewEngine creates a new adjustment factor engine
+func NewEngine(repo *Repository, log *logger.Logger) *Engine {
+	return &Engine{repo: repo, logger: log}
+}
+
+// RebuildResult summarizes a factor rebuild
+type RebuildResult struct {
+	Computed int      `json:"computed"`
+	Skipped  int      `json:"skipped"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// Rebuild computes factors for actions without one (full=true면 전체 재계산)
+// 직전 종가가 아직 없는 행위는 건너뛰고 다음 실행에서 다시 시도
+func (e *Engine) Rebuild(ctx context.Context, full bool) (*RebuildResult, error) {
+	if full {
+		if err := e.repo.DeleteFactors(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	actions, err := e.repo.ListActionsWithoutFactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RebuildResult{}
+	for _, a := range actions {
+		var prevClose float64
+		if a.NeedsPrevClose() {
+			prevClose, err = e.repo.GetPrevClose(ctx, a.Code, a.ExDate)
+			if err != nil {
+				return nil, err
+			}
+			if prevClose == 0 {
+				result.Skipped++
+				e.logger.WithFields(map[string]interface{}{
+					"code":    a.Code,
+					"ex_date": a.ExDate.Format("2006-01-02"),
+					"type":    a.Type,
+				}).Warn("Previous close not available, factor skipped")
+				continue
+			}
+		}
+
+		factor, err := ComputeFactor(a, prevClose)
+		if err != nil {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("action %d: %v", a.ID, err))
+			continue
+		}
+		if err := e.repo.SaveFactor(ctx, factor); err != nil {
+			return nil, err
+		}
+		result.Computed++
+	}
+
+	e.logger.WithFields(map[string]interface{}{
+		"computed": result.Computed,
+		"skipped":  result.Skipped,
+		"full":     full,
+	}).Info("Adjustment factors rebuilt")
+
+	return result, nil
+}
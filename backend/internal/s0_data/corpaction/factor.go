@@ -0,0 +1,50 @@
+package corpaction
+
+import "fmt"
+
+// ComputeFactor derives the back-adjustment factor for one action
+// ⭐ SSOT: 수정계수 산식은 여기서만
+//
+//	SPLIT         : price × 1/r,      volume × r
+//	BONUS         : price × 1/(1+b),  volume × (1+b)
+//	RIGHTS        : price × TERP/P,   TERP = (P + b×S) / (1+b)
+//	CASH_DIVIDEND : price × (P-D)/P
+//
+// P = 적용일 직전 거래일 원종가 (RIGHTS/CASH_DIVIDEND만 필요)
+func ComputeFactor(a Action, prevClose float64) (Factor, error) {
+	if err := a.Validate(); err != nil {
+		return Factor{}, err
+	}
+
+	f := Factor{ActionID: a.ID, Code: a.Code, ExDate: a.ExDate, VolumeFactor: 1}
+	if a.NeedsPrevClose() {
+		if prevClose <= 0 {
+			return Factor{}, fmt.Errorf("%s %s requires previous close", a.Code, a.Type)
+		}
+		f.PrevClose = prevClose
+	}
+
+	switch a.Type {
+	case ActionSplit:
+		f.PriceFactor = 1 / a.Ratio
+		f.VolumeFactor = a.Ratio
+	case ActionBonus:
+		f.PriceFactor = 1 / (1 + a.Ratio)
+		f.VolumeFactor = 1 + a.Ratio
+	case ActionRights:
+		if a.SubscriptionPrice >= prevClose {
+			// 할인 없는 유상증자는 권리가치 없음
+			f.PriceFactor = 1
+			break
+		}
+		terp := (prevClose + a.Ratio*a.SubscriptionPrice) / (1 + a.Ratio)
+		f.PriceFactor = terp / prevClose
+	case ActionCashDividend:
+		if a.DividendAmount >= prevClose {
+			return Factor{}, fmt.Errorf("%s dividend %.0f exceeds previous close %.0f", a.Code, a.DividendAmount, prevClose)
+		}
+		f.PriceFactor = (prevClose - a.DividendAmount) / prevClose
+	}
+
+	return f, nil
+}
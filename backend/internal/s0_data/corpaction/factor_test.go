@@ -0,0 +1,158 @@
+package corpaction
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func TestComputeFactor(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     Action
+		prevClose  float64
+		wantPrice  float64
+		wantVolume float64
+		wantErr    bool
+	}{
+		{
+			name:       "split 50:1",
+			action:     Action{Code: "005930", ExDate: date("2018-05-04"), Type: ActionSplit, Ratio: 50},
+			wantPrice:  0.02,
+			wantVolume: 50,
+		},
+		{
+			name:       "reverse split 1:10",
+			action:     Action{Code: "000001", ExDate: date("2024-01-10"), Type: ActionSplit, Ratio: 0.1},
+			wantPrice:  10,
+			wantVolume: 0.1,
+		},
+		{
+			name:       "bonus 1 share per share",
+			action:     Action{Code: "000002", ExDate: date("2024-01-10"), Type: ActionBonus, Ratio: 1},
+			wantPrice:  0.5,
+			wantVolume: 2,
+		},
+		{
+			name:       "rights at discount",
+			action:     Action{Code: "000003", ExDate: date("2024-01-10"), Type: ActionRights, Ratio: 0.25, SubscriptionPrice: 8000},
+			prevClose:  10000,
+			wantPrice:  0.96, // TERP = (10000 + 0.25×8000) / 1.25 = 9600
+			wantVolume: 1,
+		},
+		{
+			name:       "rights above market has no value",
+			action:     Action{Code: "000004", ExDate: date("2024-01-10"), Type: ActionRights, Ratio: 0.25, SubscriptionPrice: 12000},
+			prevClose:  10000,
+			wantPrice:  1,
+			wantVolume: 1,
+		},
+		{
+			name:       "cash dividend",
+			action:     Action{Code: "000005", ExDate: date("2024-01-10"), Type: ActionCashDividend, DividendAmount: 500},
+			prevClose:  50000,
+			wantPrice:  0.99,
+			wantVolume: 1,
+		},
+		{
+			name:    "dividend requires previous close",
+			action:  Action{Code: "000006", ExDate: date("2024-01-10"), Type: ActionCashDividend, DividendAmount: 500},
+			wantErr: true,
+		},
+		{
+			name:      "dividend exceeding close",
+			action:    Action{Code: "000007", ExDate: date("2024-01-10"), Type: ActionCashDividend, DividendAmount: 12000},
+			prevClose: 10000,
+			wantErr:   true,
+		},
+		{
+			name:    "split ratio 1 rejected",
+			action:  Action{Code: "000008", ExDate: date("2024-01-10"), Type: ActionSplit, Ratio: 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ComputeFactor(tt.action, tt.prevClose)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.wantPrice, f.PriceFactor, 1e-9)
+			assert.InDelta(t, tt.wantVolume, f.VolumeFactor, 1e-9)
+		})
+	}
+}
+
+func TestParseImport(t *testing.T) {
+	input := `# 기업행위 임포트
+stock_code,ex_date,type,ratio,subscription_price,dividend_amount,memo
+005930,2018-05-04,SPLIT,50,,,액면분할
+000660,2024-03-28,cash_dividend,,,"1,200",결산배당
+123456,2024-06-10,RIGHTS,0.25,8000,,
+`
+	actions, err := ParseImport(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, actions, 3)
+
+	assert.Equal(t, ActionSplit, actions[0].Type)
+	assert.Equal(t, 50.0, actions[0].Ratio)
+	assert.Equal(t, "액면분할", actions[0].Memo)
+	assert.Equal(t, SourceImport, actions[0].Source)
+
+	assert.Equal(t, ActionCashDividend, actions[1].Type)
+	assert.Equal(t, 1200.0, actions[1].DividendAmount)
+
+	assert.Equal(t, 8000.0, actions[2].SubscriptionPrice)
+}
+
+func TestParseImport_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantMsg string
+	}{
+		{"missing column", "stock_code,type\n005930,SPLIT\n", "missing required column: ex_date"},
+		{"bad date", "stock_code,ex_date,type,ratio\n005930,2018/05/04,SPLIT,50\n", "line 2"},
+		{"bad type", "stock_code,ex_date,type\n005930,2018-05-04,MERGER\n", "unknown action type"},
+		{"missing ratio", "stock_code,ex_date,type\n005930,2018-05-04,BONUS\n", "ratio must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseImport(strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
+
+func TestBonusAction(t *testing.T) {
+	// 기준일 월요일 → 권리락일 직전 금요일
+	action, err := bonusAction("000100", dart.BonusIssueDecision{
+		RceptNo:         "20240301000123",
+		RecordDate:      "2024년 03월 18일",
+		SharesPerCommon: "0.5",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ActionBonus, action.Type)
+	assert.Equal(t, 0.5, action.Ratio)
+	assert.Equal(t, date("2024-03-15"), action.ExDate)
+	assert.Equal(t, SourceDART, action.Source)
+	assert.Equal(t, "20240301000123", action.SourceRef)
+
+	_, err = bonusAction("000100", dart.BonusIssueDecision{RecordDate: "2024-03-18", SharesPerCommon: "-"})
+	assert.Error(t, err)
+}
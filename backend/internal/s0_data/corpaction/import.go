@@ -0,0 +1,100 @@
+package corpaction
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 임포트 파일 형식 (CSV, 헤더 필수, 컬럼 순서 무관, # 주석 허용)
+//
+//	stock_code,ex_date,type,ratio,subscription_price,dividend_amount,memo
+//	005930,2018-05-04,SPLIT,50,,,액면분할 50:1
+//	035720,2021-04-15,SPLIT,5,,,
+//	000660,2024-03-28,CASH_DIVIDEND,,,300,결산배당
+//	123456,2024-06-10,RIGHTS,0.25,8000,,주주배정 유상증자
+var importRequiredColumns = []string{"stock_code", "ex_date", "type"}
+
+// ParseImport parses a corporate action import file
+// 형식 오류가 있으면 행 번호와 함께 전체 실패 (부분 임포트 없음)
+func ParseImport(r io.Reader) ([]Action, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column: %s", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	number := func(record []string, name string) (float64, error) {
+		v := strings.ReplaceAll(field(record, name), ",", "")
+		if v == "" {
+			return 0, nil
+		}
+		return strconv.ParseFloat(v, 64)
+	}
+
+	actions := make([]Action, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read record: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		exDate, err := time.Parse("2006-01-02", field(record, "ex_date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid ex_date: %w", line, err)
+		}
+
+		action := Action{
+			Code:   field(record, "stock_code"),
+			ExDate: exDate,
+			Type:   ActionType(strings.ToUpper(field(record, "type"))),
+			Source: SourceImport,
+			Memo:   field(record, "memo"),
+		}
+		if action.Ratio, err = number(record, "ratio"); err != nil {
+			return nil, fmt.Errorf("line %d: invalid ratio: %w", line, err)
+		}
+		if action.SubscriptionPrice, err = number(record, "subscription_price"); err != nil {
+			return nil, fmt.Errorf("line %d: invalid subscription_price: %w", line, err)
+		}
+		if action.DividendAmount, err = number(record, "dividend_amount"); err != nil {
+			return nil, fmt.Errorf("line %d: invalid dividend_amount: %w", line, err)
+		}
+		if err := action.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,169 @@
+package corpaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository handles corporate actions and adjustment factors
+// ⭐ SSOT: data.corporate_actions / data.price_adjustment_factors 접근은 여기서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new corporate action repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// UpsertActions inserts or updates actions keyed by (stock_code, ex_date, action_type)
+// 파라미터가 바뀐 행은 기존 수정계수를 삭제해 재계산 대상이 되도록 함
+func (r *Repository) UpsertActions(ctx context.Context, actions []Action) error {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO data.corporate_actions (
+			stock_code, ex_date, action_type, ratio, subscription_price, dividend_amount,
+			source, source_ref, memo
+		) VALUES ($1, $2, $3, NULLIF($4::numeric, 0), NULLIF($5::numeric, 0), NULLIF($6::numeric, 0), $7, NULLIF($8, ''), NULLIF($9, ''))
+		ON CONFLICT (stock_code, ex_date, action_type) DO UPDATE SET
+			ratio = EXCLUDED.ratio,
+			subscription_price = EXCLUDED.subscription_price,
+			dividend_amount = EXCLUDED.dividend_amount,
+			source = EXCLUDED.source,
+			source_ref = COALESCE(EXCLUDED.source_ref, data.corporate_actions.source_ref),
+			memo = COALESCE(EXCLUDED.memo, data.corporate_actions.memo),
+			updated_at = NOW()
+		RETURNING id
+	`
+
+	for i := range actions {
+		a := &actions[i]
+		if err := tx.QueryRow(ctx, query,
+			a.Code, a.ExDate, a.Type, a.Ratio, a.SubscriptionPrice, a.DividendAmount,
+			a.Source, a.SourceRef, a.Memo,
+		).Scan(&a.ID); err != nil {
+			return fmt.Errorf("failed to upsert action %s %s: %w", a.Code, a.ExDate.Format("2006-01-02"), err)
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM data.price_adjustment_factors WHERE action_id = $1", a.ID); err != nil {
+			return fmt.Errorf("failed to reset factor for action %d: %w", a.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListActions returns actions for a code (빈 문자열이면 전체), ex_date ascending
+func (r *Repository) ListActions(ctx context.Context, code string) ([]Action, error) {
+	query := `
+		SELECT id, stock_code, ex_date, action_type,
+		       COALESCE(ratio, 0), COALESCE(subscription_price, 0), COALESCE(dividend_amount, 0),
+		       source, COALESCE(source_ref, ''), COALESCE(memo, '')
+		FROM data.corporate_actions
+		WHERE ($1 = '' OR stock_code = $1)
+		ORDER BY ex_date ASC, stock_code ASC, id ASC
+	`
+	return r.queryActions(ctx, query, code)
+}
+
+// ListActionsWithoutFactor returns actions whose factor has not been computed
+func (r *Repository) ListActionsWithoutFactor(ctx context.Context) ([]Action, error) {
+	query := `
+		SELECT a.id, a.stock_code, a.ex_date, a.action_type,
+		       COALESCE(a.ratio, 0), COALESCE(a.subscription_price, 0), COALESCE(a.dividend_amount, 0),
+		       a.source, COALESCE(a.source_ref, ''), COALESCE(a.memo, '')
+		FROM data.corporate_actions a
+		LEFT JOIN data.price_adjustment_factors f ON f.action_id = a.id
+		WHERE f.action_id IS NULL
+		ORDER BY a.ex_date ASC, a.id ASC
+	`
+	return r.queryActions(ctx, query)
+}
+
+func (r *Repository) queryActions(ctx context.Context, query string, args ...interface{}) ([]Action, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query corporate actions: %w", err)
+	}
+	defer rows.Close()
+
+	actions := make([]Action, 0)
+	for rows.Next() {
+		var a Action
+		if err := rows.Scan(
+			&a.ID, &a.Code, &a.ExDate, &a.Type,
+			&a.Ratio, &a.SubscriptionPrice, &a.DividendAmount,
+			&a.Source, &a.SourceRef, &a.Memo,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan corporate action: %w", err)
+		}
+		actions = append(actions, a)
+	}
+	return actions, rows.Err()
+}
+
+// GetPrevClose returns the raw close of the last trading day before exDate
+func (r *Repository) GetPrevClose(ctx context.Context, code string, exDate time.Time) (float64, error) {
+	query := `
+		SELECT close_price
+		FROM data.daily_prices
+		WHERE stock_code = $1 AND trade_date < $2
+		ORDER BY trade_date DESC
+		LIMIT 1
+	`
+
+	var close float64
+	err := r.pool.QueryRow(ctx, query, code, exDate).Scan(&close)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get previous close: %w", err)
+	}
+	return close, nil
+}
+
+// SaveFactor upserts the adjustment factor of an action
+func (r *Repository) SaveFactor(ctx context.Context, f Factor) error {
+	query := `
+		INSERT INTO data.price_adjustment_factors (
+			action_id, stock_code, ex_date, price_factor, volume_factor, prev_close, computed_at
+		) VALUES ($1, $2, $3, $4, $5, NULLIF($6::numeric, 0), NOW())
+		ON CONFLICT (action_id) DO UPDATE SET
+			price_factor = EXCLUDED.price_factor,
+			volume_factor = EXCLUDED.volume_factor,
+			prev_close = EXCLUDED.prev_close,
+			computed_at = NOW()
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		f.ActionID, f.Code, f.ExDate, f.PriceFactor, f.VolumeFactor, f.PrevClose,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save adjustment factor: %w", err)
+	}
+	return nil
+}
+
+// DeleteFactors removes all computed factors (전체 재계산용)
+func (r *Repository) DeleteFactors(ctx context.Context) error {
+	if _, err := r.pool.Exec(ctx, "DELETE FROM data.price_adjustment_factors"); err != nil {
+		return fmt.Errorf("failed to delete adjustment factors: %w", err)
+	}
+	return nil
+}
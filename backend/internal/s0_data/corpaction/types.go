@@ -0,0 +1,87 @@
+package corpaction
+
+import (
+	"fmt"
+	"time"
+)
+
+// ActionType represents a corporate action category
+type ActionType string
+
+const (
+	ActionSplit        ActionType = "SPLIT"         // 액면분할/병합 (ratio = 구주 1주당 신주 수)
+	ActionBonus        ActionType = "BONUS"         // 무상증자/주식배당 (ratio = 구주 1주당 배정 주식 수)
+	ActionRights       ActionType = "RIGHTS"        // 유상증자 주주배정 (ratio + subscription_price)
+	ActionCashDividend ActionType = "CASH_DIVIDEND" // 현금배당 (dividend_amount = 주당 배당금)
+)
+
+// Source represents where an action entered the ledger
+type Source string
+
+const (
+	SourceDART   Source = "DART"
+	SourceImport Source = "IMPORT"
+)
+
+// Action represents one corporate action
+// ⭐ SSOT: 기업행위 원장 구조 (data.corporate_actions)
+type Action struct {
+	ID                int64      `json:"id"`
+	Code              string     `json:"code"`
+	ExDate            time.Time  `json:"ex_date"` // 이 날부터 새 기준 가격
+	Type              ActionType `json:"type"`
+	Ratio             float64    `json:"ratio,omitempty"`
+	SubscriptionPrice float64    `json:"subscription_price,omitempty"`
+	DividendAmount    float64    `json:"dividend_amount,omitempty"`
+	Source            Source     `json:"source"`
+	SourceRef         string     `json:"source_ref,omitempty"`
+	Memo              string     `json:"memo,omitempty"`
+}
+
+// NeedsPrevClose reports whether the factor depends on the prior close
+func (a Action) NeedsPrevClose() bool {
+	return a.Type == ActionRights || a.Type == ActionCashDividend
+}
+
+// Validate checks that the parameters required by the action type are present
+func (a Action) Validate() error {
+	if a.Code == "" {
+		return fmt.Errorf("stock code is required")
+	}
+	if a.ExDate.IsZero() {
+		return fmt.Errorf("ex date is required")
+	}
+
+	switch a.Type {
+	case ActionSplit:
+		if a.Ratio <= 0 || a.Ratio == 1 {
+			return fmt.Errorf("%s ratio must be positive and not 1: %v", a.Type, a.Ratio)
+		}
+	case ActionBonus:
+		if a.Ratio <= 0 {
+			return fmt.Errorf("%s ratio must be positive: %v", a.Type, a.Ratio)
+		}
+	case ActionRights:
+		if a.Ratio <= 0 || a.SubscriptionPrice <= 0 {
+			return fmt.Errorf("%s requires positive ratio and subscription price", a.Type)
+		}
+	case ActionCashDividend:
+		if a.DividendAmount <= 0 {
+			return fmt.Errorf("%s requires positive dividend amount", a.Type)
+		}
+	default:
+		return fmt.Errorf("unknown action type: %s", a.Type)
+	}
+	return nil
+}
+
+// Factor represents the adjustment applied to prices before ExDate
+// 수정가 = 원가 × PriceFactor, 수정 거래량 = 원 거래량 × VolumeFactor
+type Factor struct {
+	ActionID     int64     `json:"action_id"`
+	Code         string    `json:"code"`
+	ExDate       time.Time `json:"ex_date"`
+	PriceFactor  float64   `json:"price_factor"`
+	VolumeFactor float64   `json:"volume_factor"`
+	PrevClose    float64   `json:"prev_close,omitempty"`
+}
@@ -20,17 +20,19 @@ func NewFinancialRepository(pool *pgxpool.Pool) *FinancialRepository {
 	return &FinancialRepository{pool: pool}
 }
 
-// GetLatestByCode retrieves the most recent financial data for a code before given date
+// GetLatestByCode retrieves the most recent financial data available on given date
+// 공시 접수일(available_date) 기준 point-in-time, 레거시 행은 report_date 기준
 func (r *FinancialRepository) GetLatestByCode(ctx context.Context, code string, date time.Time) (*contracts.Financial, error) {
 	query := `
 		SELECT stock_code,
 		       EXTRACT(YEAR FROM report_date)::int as year,
 		       EXTRACT(QUARTER FROM report_date)::int as quarter,
 		       COALESCE(revenue, 0), COALESCE(operating_profit, 0), COALESCE(net_profit, 0),
-		       COALESCE(roe, 0), COALESCE(debt_ratio, 0),
-		       COALESCE(per, 0), COALESCE(pbr, 0)
+		       COALESCE(roe_ttm, roe, 0), COALESCE(op_margin_ttm, 0), COALESCE(debt_ratio, 0),
+		       COALESCE(per, 0), COALESCE(pbr, 0),
+		       COALESCE(total_assets, 0), COALESCE(total_equity, 0), COALESCE(total_liabilities, 0)
 		FROM data.fundamentals
-		WHERE stock_code = $1 AND report_date <= $2
+		WHERE stock_code = $1 AND COALESCE(available_date, report_date) <= $2
 		ORDER BY report_date DESC
 		LIMIT 1
 	`
@@ -38,15 +40,13 @@ func (r *FinancialRepository) GetLatestByCode(ctx context.Context, code string,
 	var f contracts.Financial
 	err := r.pool.QueryRow(ctx, query, code, date).Scan(
 		&f.Code, &f.Year, &f.Quarter, &f.Revenue, &f.OpProfit, &f.NetProfit,
-		&f.ROE, &f.DebtRatio, &f.PER, &f.PBR,
+		&f.ROE, &f.OpMargin, &f.DebtRatio, &f.PER, &f.PBR,
+		&f.Assets, &f.Equity, &f.Debt,
 	)
 	if err != nil {
 		return nil, err
 	}
-	// Assets, Equity, Debt, PSR are not available in current schema
-	f.Assets = 0
-	f.Equity = 0
-	f.Debt = 0
+	// PSR is not available in current schema
 	f.PSR = 0
 	return &f, nil
 }
@@ -64,8 +64,9 @@ func (r *FinancialRepository) GetByCodeAndQuarter(ctx context.Context, code stri
 		       EXTRACT(YEAR FROM report_date)::int as year,
 		       EXTRACT(QUARTER FROM report_date)::int as quarter,
 		       COALESCE(revenue, 0), COALESCE(operating_profit, 0), COALESCE(net_profit, 0),
-		       COALESCE(roe, 0), COALESCE(debt_ratio, 0),
-		       COALESCE(per, 0), COALESCE(pbr, 0)
+		       COALESCE(roe_ttm, roe, 0), COALESCE(op_margin_ttm, 0), COALESCE(debt_ratio, 0),
+		       COALESCE(per, 0), COALESCE(pbr, 0),
+		       COALESCE(total_assets, 0), COALESCE(total_equity, 0), COALESCE(total_liabilities, 0)
 		FROM data.fundamentals
 		WHERE stock_code = $1 AND report_date BETWEEN $2 AND $3
 		ORDER BY report_date DESC
@@ -75,15 +76,13 @@ func (r *FinancialRepository) GetByCodeAndQuarter(ctx context.Context, code stri
 	var f contracts.Financial
 	err := r.pool.QueryRow(ctx, query, code, startDate, endDate).Scan(
 		&f.Code, &f.Year, &f.Quarter, &f.Revenue, &f.OpProfit, &f.NetProfit,
-		&f.ROE, &f.DebtRatio, &f.PER, &f.PBR,
+		&f.ROE, &f.OpMargin, &f.DebtRatio, &f.PER, &f.PBR,
+		&f.Assets, &f.Equity, &f.Debt,
 	)
 	if err != nil {
 		return nil, err
 	}
-	// Assets, Equity, Debt, PSR are not available in current schema
-	f.Assets = 0
-	f.Equity = 0
-	f.Debt = 0
+	// PSR is not available in current schema
 	f.PSR = 0
 	return &f, nil
 }
@@ -0,0 +1,290 @@
+package financials
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// requestInterval throttles DART calls (일 20,000건 한도)
+const requestInterval = 150 * time.Millisecond
+
+// maxDisclosurePages limits disclosure list paging (collector와 동일)
+const maxDisclosurePages = 10
+
+// periodicTitle matches 정기보고서 titles such as "[기재정정]분기보고서 (2024.03)"
+var periodicTitle = regexp.MustCompile(`(사업보고서|반기보고서|분기보고서)\s*\((\d{4})\.(\d{2})\)`)
+
+// CollectResult summarizes a collection run
+type CollectResult struct {
+	Requested  int      `json:"requested"`
+	Saved      int      `json:"saved"`
+	NoData     int      `json:"no_data"`
+	Recomputed int      `json:"recomputed"` // fundamentals 재산출 종목 수
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// Collector ingests DART periodic reports and derives point-in-time fundamentals
+// ⭐ SSOT: DART 재무제표 수집은 이 Collector에서만
+type Collector struct {
+	client *dart.Client
+	repo   *Repository
+	logger *logger.Logger
+}
+
+// NewCollector creates a new financial statement collector
+func NewCollector(client *dart.Client, repo *Repository, log *logger.Logger) *Collector {
+	return &Collector{
+		client: client,
+		repo:   repo,
+		logger: log.WithField("module", "financials"),
+	}
+}
+
+// SyncCorpCodes refreshes data.stocks.corp_code from the DART corp_code master
+func (c *Collector) SyncCorpCodes(ctx context.Context) (int, error) {
+	codes, err := c.client.FetchCorpCodes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("fetch corp codes: %w", err)
+	}
+
+	updated, err := c.repo.UpdateCorpCodes(ctx, codes)
+	if err != nil {
+		return updated, err
+	}
+
+	c.logger.WithFields(map[string]interface{}{
+		"listed":  len(codes),
+		"updated": updated,
+	}).Info("DART corp codes synced")
+	return updated, nil
+}
+
+// CollectYears backfills every periodic report of [fromYear, toYear]
+// refresh=false면 이미 저장된 분기는 건너뜀
+func (c *Collector) CollectYears(ctx context.Context, codes []string, fromYear, toYear int, refresh bool) (*CollectResult, error) {
+	stocks, err := c.repo.GetCorpStocks(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.WithFields(map[string]interface{}{
+		"stocks":    len(stocks),
+		"from_year": fromYear,
+		"to_year":   toYear,
+		"refresh":   refresh,
+	}).Info("Starting financial statement backfill")
+
+	result := &CollectResult{}
+	now := time.Now()
+	for _, stock := range stocks {
+		for year := fromYear; year <= toYear; year++ {
+			for quarter := 1; quarter <= 4; quarter++ {
+				// 분기말 이전 보고서는 존재하지 않음
+				if time.Date(year, time.Month(quarter*3+1), 0, 0, 0, 0, 0, time.UTC).After(now) {
+					continue
+				}
+				if !refresh {
+					exists, err := c.repo.HasPeriod(ctx, stock.Code, year, quarter)
+					if err != nil {
+						return nil, err
+					}
+					if exists {
+						continue
+					}
+				}
+				if err := c.collectReport(ctx, stock, year, dart.ReportCodeForQuarter(quarter), result); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := c.recompute(ctx, stock.Code, result); err != nil {
+			return nil, err
+		}
+	}
+
+	c.logSummary("Financial statement backfill completed", result)
+	return result, nil
+}
+
+// CollectFilings collects periodic reports filed within [from, to] (일일 증분 수집)
+// 12월 결산 법인 기준: 보고서 제목의 (YYYY.MM)이 분기말과 맞지 않으면 건너뜀
+func (c *Collector) CollectFilings(ctx context.Context, from, to time.Time) (*CollectResult, error) {
+	type target struct {
+		stock  CorpStock
+		year   int
+		report dart.ReportCode
+	}
+	targets := make(map[string]target)
+
+	for page := 1; page <= maxDisclosurePages; page++ {
+		disclosures, totalPages, err := c.client.FetchDisclosuresForPage(ctx, from, to, page)
+		if err != nil {
+			return nil, fmt.Errorf("fetch disclosures page %d: %w", page, err)
+		}
+
+		for _, d := range disclosures {
+			if d.StockCode == "" {
+				continue
+			}
+			year, report, ok := ParsePeriodicTitle(d.ReportNm)
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%s-%d-%s", d.StockCode, year, report)
+			targets[key] = target{
+				stock:  CorpStock{Code: d.StockCode, CorpCode: d.CorpCode},
+				year:   year,
+				report: report,
+			}
+		}
+
+		if len(disclosures) == 0 || page >= totalPages {
+			break
+		}
+	}
+
+	result := &CollectResult{}
+	touched := make(map[string]bool)
+	for _, t := range targets {
+		if err := c.collectReport(ctx, t.stock, t.year, t.report, result); err != nil {
+			return nil, err
+		}
+		touched[t.stock.Code] = true
+	}
+	for code := range touched {
+		if err := c.recompute(ctx, code, result); err != nil {
+			return nil, err
+		}
+	}
+
+	c.logSummary("Financial statement filings collected", result)
+	return result, nil
+}
+
+// RecomputeFundamentals re-derives data.fundamentals from stored statements
+func (c *Collector) RecomputeFundamentals(ctx context.Context, codes []string) (*CollectResult, error) {
+	stocks, err := c.repo.GetCorpStocks(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CollectResult{}
+	for _, stock := range stocks {
+		if err := c.recompute(ctx, stock.Code, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// collectReport fetches one report (연결 우선, 없으면 별도) and stores it
+// 개별 보고서 실패는 result.Errors에 기록하고 계속 진행
+func (c *Collector) collectReport(ctx context.Context, stock CorpStock, year int, report dart.ReportCode, result *CollectResult) error {
+	for _, fsDiv := range []dart.FSDiv{dart.FSConsolidated, dart.FSSeparate} {
+		if err := wait(ctx); err != nil {
+			return err
+		}
+		result.Requested++
+
+		accounts, err := c.client.FetchFinancialStatements(ctx, stock.CorpCode, year, report, fsDiv)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s %d %s %s: %v", stock.Code, year, report, fsDiv, err))
+			return nil
+		}
+		if len(accounts) == 0 {
+			continue
+		}
+
+		stmt, err := Extract(stock.Code, stock.CorpCode, year, report, fsDiv, accounts)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s %d %s %s: %v", stock.Code, year, report, fsDiv, err))
+			return nil
+		}
+		if err := c.repo.SaveStatement(ctx, stmt); err != nil {
+			return err
+		}
+		result.Saved++
+		return nil
+	}
+
+	result.NoData++
+	return nil
+}
+
+// recompute rebuilds point-in-time fundamentals of a stock
+func (c *Collector) recompute(ctx context.Context, code string, result *CollectResult) error {
+	stmts, err := c.repo.GetStatements(ctx, code)
+	if err != nil {
+		return err
+	}
+	rows := BuildHistory(stmts)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	for i := range rows {
+		marketCap, err := c.repo.GetMarketCapAsOf(ctx, code, rows[i].AvailableDate)
+		if err != nil {
+			return err
+		}
+		rows[i].ApplyValuation(marketCap)
+	}
+
+	if err := c.repo.SaveFundamentals(ctx, rows); err != nil {
+		return err
+	}
+	result.Recomputed++
+	return nil
+}
+
+func (c *Collector) logSummary(msg string, result *CollectResult) {
+	c.logger.WithFields(map[string]interface{}{
+		"requested":  result.Requested,
+		"saved":      result.Saved,
+		"no_data":    result.NoData,
+		"recomputed": result.Recomputed,
+		"errors":     len(result.Errors),
+	}).Info(msg)
+}
+
+// ParsePeriodicTitle extracts fiscal year and report code from a 정기보고서 title
+func ParsePeriodicTitle(title string) (int, dart.ReportCode, bool) {
+	m := periodicTitle.FindStringSubmatch(strings.TrimSpace(title))
+	if m == nil {
+		return 0, "", false
+	}
+	year, _ := strconv.Atoi(m[2])
+	month, _ := strconv.Atoi(m[3])
+
+	var report dart.ReportCode
+	switch {
+	case m[1] == "사업보고서" && month == 12:
+		report = dart.ReportAnnual
+	case m[1] == "반기보고서" && month == 6:
+		report = dart.ReportHalf
+	case m[1] == "분기보고서" && month == 3:
+		report = dart.ReportQ1
+	case m[1] == "분기보고서" && month == 9:
+		report = dart.ReportQ3
+	default:
+		return 0, "", false
+	}
+	return year, report, true
+}
+
+func wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(requestInterval):
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+package financials
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+)
+
+type accountField int
+
+const (
+	fieldRevenue accountField = iota
+	fieldOperatingProfit
+	fieldNetProfit
+	fieldNetProfitOwners
+	fieldTotalAssets
+	fieldTotalLiabilities
+	fieldTotalEquity
+	fieldEquityOwners
+)
+
+// accountIDs maps XBRL account_id to statement fields
+var accountIDs = map[string]accountField{
+	"ifrs-full_Revenue":        fieldRevenue,
+	"ifrs_Revenue":             fieldRevenue,
+	"dart_OperatingIncomeLoss": fieldOperatingProfit,
+	"ifrs-full_ProfitLoss":     fieldNetProfit,
+	"ifrs_ProfitLoss":          fieldNetProfit,
+	"ifrs-full_ProfitLossAttributableToOwnersOfParent": fieldNetProfitOwners,
+	"ifrs_ProfitLossAttributableToOwnersOfParent":      fieldNetProfitOwners,
+	"ifrs-full_Assets":      fieldTotalAssets,
+	"ifrs_Assets":           fieldTotalAssets,
+	"ifrs-full_Liabilities": fieldTotalLiabilities,
+	"ifrs_Liabilities":      fieldTotalLiabilities,
+	"ifrs-full_Equity":      fieldTotalEquity,
+	"ifrs_Equity":           fieldTotalEquity,
+	"ifrs-full_EquityAttributableToOwnersOfParent": fieldEquityOwners,
+	"ifrs_EquityAttributableToOwnersOfParent":      fieldEquityOwners,
+}
+
+// accountNames is the fallback for companies without standard account ids (표준계정코드 미사용)
+var accountNames = map[string]accountField{
+	"매출액":       fieldRevenue,
+	"수익(매출액)":   fieldRevenue,
+	"영업수익":      fieldRevenue,
+	"영업이익":      fieldOperatingProfit,
+	"영업이익(손실)":  fieldOperatingProfit,
+	"당기순이익":     fieldNetProfit,
+	"당기순이익(손실)": fieldNetProfit,
+	"분기순이익":     fieldNetProfit,
+	"분기순이익(손실)": fieldNetProfit,
+	"반기순이익":     fieldNetProfit,
+	"반기순이익(손실)": fieldNetProfit,
+	"자산총계":      fieldTotalAssets,
+	"부채총계":      fieldTotalLiabilities,
+	"자본총계":      fieldTotalEquity,
+}
+
+// Extract converts fnlttSinglAcntAll lines into a Statement
+// 손익은 IS 우선, IS에 없으면 CIS (포괄손익계산서 단일 작성 회사)
+// 분기/반기 보고서는 누적(thstrm_add_amount), 없으면 당기(thstrm_amount) 사용
+func Extract(code, corpCode string, year int, report dart.ReportCode, fsDiv dart.FSDiv, accounts []dart.FinancialAccount) (Statement, error) {
+	if len(accounts) == 0 {
+		return Statement{}, fmt.Errorf("no accounts")
+	}
+
+	quarter := report.Quarter()
+	if quarter == 0 {
+		return Statement{}, fmt.Errorf("unknown report code: %s", report)
+	}
+
+	rceptNo := accounts[0].RceptNo
+	filed, err := dart.ReceiptDate(rceptNo)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	s := Statement{
+		Code:          code,
+		CorpCode:      corpCode,
+		FiscalYear:    year,
+		FiscalQuarter: quarter,
+		FSDiv:         fsDiv,
+		RceptNo:       rceptNo,
+		FiledDate:     filed,
+		PeriodEnd:     time.Date(year, time.Month(quarter*3+1), 0, 0, 0, 0, 0, time.UTC),
+	}
+
+	// 우선순위: IS(0) > CIS(1) / 표준 account_id > 계정명
+	type candidate struct {
+		value    int64
+		priority int
+	}
+	found := make(map[accountField]candidate)
+
+	for _, a := range accounts {
+		field, ok := accountIDs[a.AccountID]
+		priority := 0
+		if !ok {
+			field, ok = accountNames[strings.ReplaceAll(a.AccountNm, " ", "")]
+			priority = 2
+		}
+		if !ok {
+			continue
+		}
+
+		var amount string
+		switch a.SjDiv {
+		case "BS":
+			amount = a.ThstrmAmount
+		case "IS", "CIS":
+			if a.SjDiv == "CIS" {
+				priority++
+			}
+			amount = a.ThstrmAmount
+			if quarter < 4 && strings.TrimSpace(a.ThstrmAddAmount) != "" {
+				amount = a.ThstrmAddAmount
+			}
+		default:
+			continue
+		}
+
+		value, err := dart.ParseReportNumber(amount)
+		if err != nil {
+			continue
+		}
+		if prev, exists := found[field]; exists && prev.priority <= priority {
+			continue
+		}
+		found[field] = candidate{value: int64(value), priority: priority}
+	}
+
+	s.Revenue = found[fieldRevenue].value
+	s.OperatingProfit = found[fieldOperatingProfit].value
+	s.NetProfit = found[fieldNetProfit].value
+	s.NetProfitOwners = found[fieldNetProfitOwners].value
+	s.TotalAssets = found[fieldTotalAssets].value
+	s.TotalLiabilities = found[fieldTotalLiabilities].value
+	s.TotalEquity = found[fieldTotalEquity].value
+	s.EquityOwners = found[fieldEquityOwners].value
+
+	if s.TotalAssets == 0 && s.NetProfit == 0 {
+		return Statement{}, fmt.Errorf("%s %d Q%d: no recognizable accounts", code, year, quarter)
+	}
+	return s, nil
+}
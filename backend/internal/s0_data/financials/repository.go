@@ -0,0 +1,263 @@
+package financials
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+)
+
+// CorpStock is a listed stock with its DART corp_code
+type CorpStock struct {
+	Code     string
+	CorpCode string
+}
+
+// Repository handles financial statements and derived fundamentals
+// ⭐ SSOT: data.financial_statements 접근 및 DART 기반 data.fundamentals 갱신은 여기서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new financial statement repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// UpdateCorpCodes stores DART corp_codes on data.stocks, returns matched stock count
+func (r *Repository) UpdateCorpCodes(ctx context.Context, codes []dart.CorpCode) (int, error) {
+	if len(codes) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		UPDATE data.stocks
+		SET corp_code = $2, updated_at = NOW()
+		WHERE code = $1 AND corp_code IS DISTINCT FROM $2`
+
+	for _, c := range codes {
+		batch.Queue(query, c.StockCode, c.CorpCode)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	updated := 0
+	for _, c := range codes {
+		tag, err := br.Exec()
+		if err != nil {
+			return updated, fmt.Errorf("update corp code %s: %w", c.StockCode, err)
+		}
+		updated += int(tag.RowsAffected())
+	}
+	return updated, nil
+}
+
+// GetCorpStocks returns active stocks that have a corp_code
+// codes가 비어 있으면 전체
+func (r *Repository) GetCorpStocks(ctx context.Context, codes []string) ([]CorpStock, error) {
+	query := `
+		SELECT code, corp_code
+		FROM data.stocks
+		WHERE status = 'active' AND corp_code IS NOT NULL
+		  AND (cardinality($1::text[]) = 0 OR code = ANY($1))
+		ORDER BY code
+	`
+
+	if codes == nil {
+		codes = []string{}
+	}
+	rows, err := r.pool.Query(ctx, query, codes)
+	if err != nil {
+		return nil, fmt.Errorf("query corp stocks: %w", err)
+	}
+	defer rows.Close()
+
+	stocks := make([]CorpStock, 0)
+	for rows.Next() {
+		var s CorpStock
+		if err := rows.Scan(&s.Code, &s.CorpCode); err != nil {
+			return nil, fmt.Errorf("scan corp stock: %w", err)
+		}
+		stocks = append(stocks, s)
+	}
+	return stocks, rows.Err()
+}
+
+// HasPeriod reports whether any statement of the fiscal period is stored
+func (r *Repository) HasPeriod(ctx context.Context, code string, year, quarter int) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM data.financial_statements
+			WHERE stock_code = $1 AND fiscal_year = $2 AND fiscal_quarter = $3
+		)`, code, year, quarter,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check financial period: %w", err)
+	}
+	return exists, nil
+}
+
+// SaveStatement upserts one filed statement (정정공시는 새 rcept_no 행)
+func (r *Repository) SaveStatement(ctx context.Context, s Statement) error {
+	query := `
+		INSERT INTO data.financial_statements (
+			stock_code, corp_code, fiscal_year, fiscal_quarter, fs_div, rcept_no, filed_date, period_end,
+			revenue, operating_profit, net_profit, net_profit_owners,
+			total_assets, total_liabilities, total_equity, equity_owners
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8,
+			NULLIF($9::bigint, 0), NULLIF($10::bigint, 0), NULLIF($11::bigint, 0), NULLIF($12::bigint, 0),
+			NULLIF($13::bigint, 0), NULLIF($14::bigint, 0), NULLIF($15::bigint, 0), NULLIF($16::bigint, 0)
+		)
+		ON CONFLICT (stock_code, fiscal_year, fiscal_quarter, fs_div, rcept_no) DO UPDATE SET
+			revenue = EXCLUDED.revenue,
+			operating_profit = EXCLUDED.operating_profit,
+			net_profit = EXCLUDED.net_profit,
+			net_profit_owners = EXCLUDED.net_profit_owners,
+			total_assets = EXCLUDED.total_assets,
+			total_liabilities = EXCLUDED.total_liabilities,
+			total_equity = EXCLUDED.total_equity,
+			equity_owners = EXCLUDED.equity_owners
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		s.Code, s.CorpCode, s.FiscalYear, s.FiscalQuarter, s.FSDiv, s.RceptNo, s.FiledDate, s.PeriodEnd,
+		s.Revenue, s.OperatingProfit, s.NetProfit, s.NetProfitOwners,
+		s.TotalAssets, s.TotalLiabilities, s.TotalEquity, s.EquityOwners,
+	)
+	if err != nil {
+		return fmt.Errorf("save financial statement %s %d Q%d: %w", s.Code, s.FiscalYear, s.FiscalQuarter, err)
+	}
+	return nil
+}
+
+// GetStatements returns every stored filing of a stock
+func (r *Repository) GetStatements(ctx context.Context, code string) ([]Statement, error) {
+	query := `
+		SELECT stock_code, corp_code, fiscal_year, fiscal_quarter, fs_div, rcept_no, filed_date, period_end,
+		       COALESCE(revenue, 0), COALESCE(operating_profit, 0), COALESCE(net_profit, 0), COALESCE(net_profit_owners, 0),
+		       COALESCE(total_assets, 0), COALESCE(total_liabilities, 0), COALESCE(total_equity, 0), COALESCE(equity_owners, 0)
+		FROM data.financial_statements
+		WHERE stock_code = $1
+		ORDER BY fiscal_year, fiscal_quarter, filed_date, rcept_no
+	`
+
+	rows, err := r.pool.Query(ctx, query, code)
+	if err != nil {
+		return nil, fmt.Errorf("query financial statements: %w", err)
+	}
+	defer rows.Close()
+
+	stmts := make([]Statement, 0)
+	for rows.Next() {
+		var s Statement
+		if err := rows.Scan(
+			&s.Code, &s.CorpCode, &s.FiscalYear, &s.FiscalQuarter, &s.FSDiv, &s.RceptNo, &s.FiledDate, &s.PeriodEnd,
+			&s.Revenue, &s.OperatingProfit, &s.NetProfit, &s.NetProfitOwners,
+			&s.TotalAssets, &s.TotalLiabilities, &s.TotalEquity, &s.EquityOwners,
+		); err != nil {
+			return nil, fmt.Errorf("scan financial statement: %w", err)
+		}
+		stmts = append(stmts, s)
+	}
+	return stmts, rows.Err()
+}
+
+// GetMarketCapAsOf returns the latest market cap (원) on or before date, 0 if none
+func (r *Repository) GetMarketCapAsOf(ctx context.Context, code string, date time.Time) (int64, error) {
+	var marketCap int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT market_cap
+		FROM data.market_cap
+		WHERE stock_code = $1 AND trade_date <= $2
+		ORDER BY trade_date DESC
+		LIMIT 1`, code, date,
+	).Scan(&marketCap)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query market cap: %w", err)
+	}
+	return marketCap, nil
+}
+
+// SaveFundamentals upserts derived rows into data.fundamentals
+// roe 컬럼은 레거시 소비자를 위해 roe_ttm과 동일 값으로 채움
+func (r *Repository) SaveFundamentals(ctx context.Context, rows []Fundamental) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO data.fundamentals (
+			stock_code, report_date, available_date, fs_div, rcept_no,
+			revenue, operating_profit, net_profit,
+			revenue_ttm, operating_profit_ttm, net_profit_ttm,
+			total_assets, total_liabilities, total_equity,
+			roe, roe_ttm, op_margin_ttm, debt_ratio, per, pbr, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			NULLIF($6::bigint, 0), NULLIF($7::bigint, 0), NULLIF($8::bigint, 0),
+			$9, $10, $11,
+			NULLIF($12::bigint, 0), NULLIF($13::bigint, 0), NULLIF($14::bigint, 0),
+			$15::numeric, $15::numeric, $16::numeric,
+			NULLIF($17::numeric, 0), NULLIF($18::numeric, 0), NULLIF($19::numeric, 0), NOW()
+		)
+		ON CONFLICT (stock_code, report_date) DO UPDATE SET
+			available_date = EXCLUDED.available_date,
+			fs_div = EXCLUDED.fs_div,
+			rcept_no = EXCLUDED.rcept_no,
+			revenue = EXCLUDED.revenue,
+			operating_profit = EXCLUDED.operating_profit,
+			net_profit = EXCLUDED.net_profit,
+			revenue_ttm = EXCLUDED.revenue_ttm,
+			operating_profit_ttm = EXCLUDED.operating_profit_ttm,
+			net_profit_ttm = EXCLUDED.net_profit_ttm,
+			total_assets = EXCLUDED.total_assets,
+			total_liabilities = EXCLUDED.total_liabilities,
+			total_equity = EXCLUDED.total_equity,
+			roe = EXCLUDED.roe,
+			roe_ttm = EXCLUDED.roe_ttm,
+			op_margin_ttm = EXCLUDED.op_margin_ttm,
+			debt_ratio = EXCLUDED.debt_ratio,
+			per = EXCLUDED.per,
+			pbr = EXCLUDED.pbr,
+			updated_at = NOW()`
+
+	for _, f := range rows {
+		// TTM 미산출 행은 NULL (0과 구분)
+		var revenueTTM, opTTM, netTTM *int64
+		var roeTTM, opMarginTTM *float64
+		if f.HasTTM {
+			revenueTTM, opTTM, netTTM = &f.RevenueTTM, &f.OperatingProfitTTM, &f.NetProfitTTM
+			roeTTM, opMarginTTM = &f.ROETTM, &f.OpMarginTTM
+		}
+
+		batch.Queue(query,
+			f.Code, f.ReportDate, f.AvailableDate, f.FSDiv, f.RceptNo,
+			f.Revenue, f.OperatingProfit, f.NetProfit,
+			revenueTTM, opTTM, netTTM,
+			f.TotalAssets, f.TotalLiabilities, f.TotalEquity,
+			roeTTM, opMarginTTM, f.DebtRatio, f.PER, f.PBR,
+		)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for _, f := range rows {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("upsert fundamentals %s %s: %w", f.Code, f.ReportDate.Format("2006-01-02"), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package financials
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+)
+
+// maxRatio keeps ratios within data.fundamentals NUMERIC(10,2)
+const maxRatio = 99_999_999.99
+
+type periodKey struct {
+	year    int
+	quarter int
+}
+
+// PointInTime returns, per fiscal period, the latest statement filed on or before asOf
+// ⭐ SSOT: 재무 시점(point-in-time) 판정은 여기서만 (look-ahead 방지)
+func PointInTime(stmts []Statement, fsDiv dart.FSDiv, asOf time.Time) map[periodKey]Statement {
+	view := make(map[periodKey]Statement)
+	for _, s := range stmts {
+		if s.FSDiv != fsDiv || s.FiledDate.After(asOf) {
+			continue
+		}
+		key := periodKey{s.FiscalYear, s.FiscalQuarter}
+		if prev, ok := view[key]; ok && !laterFiling(s, prev) {
+			continue
+		}
+		view[key] = s
+	}
+	return view
+}
+
+// laterFiling reports whether a was filed after b (같은 날이면 접수번호 순)
+func laterFiling(a, b Statement) bool {
+	if !a.FiledDate.Equal(b.FiledDate) {
+		return a.FiledDate.After(b.FiledDate)
+	}
+	return a.RceptNo > b.RceptNo
+}
+
+// PreferredFSDiv returns CFS if the company files consolidated statements, else OFS
+func PreferredFSDiv(stmts []Statement) dart.FSDiv {
+	for _, s := range stmts {
+		if s.FSDiv == dart.FSConsolidated {
+			return dart.FSConsolidated
+		}
+	}
+	return dart.FSSeparate
+}
+
+// BuildHistory derives one fundamental row per fiscal period of a company
+// 각 분기는 최초 제출본 접수일 기준으로 산출 (이후 정정은 원장에만 보관)
+func BuildHistory(stmts []Statement) []Fundamental {
+	if len(stmts) == 0 {
+		return nil
+	}
+	fsDiv := PreferredFSDiv(stmts)
+
+	first := make(map[periodKey]Statement)
+	for _, s := range stmts {
+		if s.FSDiv != fsDiv {
+			continue
+		}
+		key := periodKey{s.FiscalYear, s.FiscalQuarter}
+		if prev, ok := first[key]; ok && laterFiling(s, prev) {
+			continue
+		}
+		first[key] = s
+	}
+
+	result := make([]Fundamental, 0, len(first))
+	for _, target := range first {
+		view := PointInTime(stmts, fsDiv, target.FiledDate)
+		result = append(result, Build(target, view))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ReportDate.Before(result[j].ReportDate)
+	})
+	return result
+}
+
+// Build derives TTM metrics for target from a point-in-time view
+//
+//	TTM(Q4)  = 사업연도 합계
+//	TTM(Qn)  = YTD(당해 Qn) + 전년 사업연도 - YTD(전년 Qn)
+//	ROE_TTM  = 지배주주 순이익 TTM / 평균 지배주주 자본 (당기말, 전년 동기말) × 100
+//	OP_MARGIN_TTM = 영업이익 TTM / 매출액 TTM × 100
+func Build(target Statement, view map[periodKey]Statement) Fundamental {
+	f := Fundamental{
+		Code:             target.Code,
+		ReportDate:       target.PeriodEnd,
+		AvailableDate:    target.FiledDate,
+		FSDiv:            target.FSDiv,
+		RceptNo:          target.RceptNo,
+		Revenue:          target.Revenue,
+		OperatingProfit:  target.OperatingProfit,
+		NetProfit:        target.NetProfit,
+		TotalAssets:      target.TotalAssets,
+		TotalLiabilities: target.TotalLiabilities,
+		TotalEquity:      target.TotalEquity,
+	}
+
+	f.ownersEquity = target.OwnersEquity()
+	if target.TotalEquity > 0 {
+		f.DebtRatio = clampRatio(float64(target.TotalLiabilities) / float64(target.TotalEquity) * 100)
+	}
+
+	prevSame, hasPrevSame := view[periodKey{target.FiscalYear - 1, target.FiscalQuarter}]
+
+	var netOwnersTTM int64
+	if target.FiscalQuarter == 4 {
+		f.RevenueTTM = target.Revenue
+		f.OperatingProfitTTM = target.OperatingProfit
+		f.NetProfitTTM = target.NetProfit
+		netOwnersTTM = target.OwnersNetProfit()
+		f.HasTTM = true
+	} else if prevFY, ok := view[periodKey{target.FiscalYear - 1, 4}]; ok && hasPrevSame {
+		f.RevenueTTM = target.Revenue + prevFY.Revenue - prevSame.Revenue
+		f.OperatingProfitTTM = target.OperatingProfit + prevFY.OperatingProfit - prevSame.OperatingProfit
+		f.NetProfitTTM = target.NetProfit + prevFY.NetProfit - prevSame.NetProfit
+		netOwnersTTM = target.OwnersNetProfit() + prevFY.OwnersNetProfit() - prevSame.OwnersNetProfit()
+		f.HasTTM = true
+	}
+
+	if !f.HasTTM {
+		return f
+	}
+
+	equity := float64(target.OwnersEquity())
+	if hasPrevSame && prevSame.OwnersEquity() > 0 {
+		equity = (equity + float64(prevSame.OwnersEquity())) / 2
+	}
+	if equity > 0 {
+		f.ROETTM = clampRatio(float64(netOwnersTTM) / equity * 100)
+	}
+	if f.RevenueTTM > 0 {
+		f.OpMarginTTM = clampRatio(float64(f.OperatingProfitTTM) / float64(f.RevenueTTM) * 100)
+	}
+
+	f.netOwnersTTM = netOwnersTTM
+	return f
+}
+
+// ApplyValuation sets PER/PBR from the market cap on the available date (원)
+func (f *Fundamental) ApplyValuation(marketCap int64) {
+	if marketCap <= 0 {
+		return
+	}
+	if f.HasTTM && f.netOwnersTTM != 0 {
+		f.PER = clampRatio(float64(marketCap) / float64(f.netOwnersTTM))
+	}
+	if f.ownersEquity > 0 {
+		f.PBR = clampRatio(float64(marketCap) / float64(f.ownersEquity))
+	}
+}
+
+func clampRatio(v float64) float64 {
+	if v > maxRatio {
+		return maxRatio
+	}
+	if v < -maxRatio {
+		return -maxRatio
+	}
+	return v
+}
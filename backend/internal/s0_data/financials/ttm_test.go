@@ -0,0 +1,154 @@
+package financials
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+)
+
+func stmt(year, quarter int, rceptNo string, revenue, op, net, equity int64) Statement {
+	filed, _ := dart.ReceiptDate(rceptNo)
+	return Statement{
+		Code:             "005930",
+		FiscalYear:       year,
+		FiscalQuarter:    quarter,
+		FSDiv:            dart.FSConsolidated,
+		RceptNo:          rceptNo,
+		FiledDate:        filed,
+		PeriodEnd:        time.Date(year, time.Month(quarter*3+1), 0, 0, 0, 0, 0, time.UTC),
+		Revenue:          revenue,
+		OperatingProfit:  op,
+		NetProfit:        net,
+		TotalAssets:      equity * 2,
+		TotalLiabilities: equity,
+		TotalEquity:      equity,
+	}
+}
+
+func TestExtract(t *testing.T) {
+	accounts := []dart.FinancialAccount{
+		{RceptNo: "20240515000123", SjDiv: "BS", AccountID: "ifrs-full_Assets", ThstrmAmount: "1,000"},
+		{RceptNo: "20240515000123", SjDiv: "BS", AccountID: "ifrs-full_Liabilities", ThstrmAmount: "400"},
+		{RceptNo: "20240515000123", SjDiv: "BS", AccountID: "ifrs-full_Equity", ThstrmAmount: "600"},
+		{RceptNo: "20240515000123", SjDiv: "BS", AccountID: "ifrs-full_EquityAttributableToOwnersOfParent", ThstrmAmount: "550"},
+		// 반기: 당기(3개월) 대신 누적 사용
+		{RceptNo: "20240515000123", SjDiv: "IS", AccountID: "ifrs-full_Revenue", ThstrmAmount: "300", ThstrmAddAmount: "500"},
+		{RceptNo: "20240515000123", SjDiv: "IS", AccountID: "-표준계정코드 미사용-", AccountNm: "영업이익", ThstrmAmount: "30", ThstrmAddAmount: "50"},
+		// CIS는 IS에 없을 때만
+		{RceptNo: "20240515000123", SjDiv: "CIS", AccountID: "ifrs-full_Revenue", ThstrmAmount: "999", ThstrmAddAmount: "999"},
+		{RceptNo: "20240515000123", SjDiv: "CIS", AccountID: "ifrs-full_ProfitLoss", ThstrmAmount: "20", ThstrmAddAmount: "40"},
+		{RceptNo: "20240515000123", SjDiv: "CF", AccountID: "ifrs-full_ProfitLoss", ThstrmAmount: "77"},
+	}
+
+	s, err := Extract("005930", "00126380", 2024, dart.ReportHalf, dart.FSConsolidated, accounts)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, s.FiscalQuarter)
+	assert.Equal(t, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), s.PeriodEnd)
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), s.FiledDate)
+	assert.Equal(t, int64(500), s.Revenue)
+	assert.Equal(t, int64(50), s.OperatingProfit)
+	assert.Equal(t, int64(40), s.NetProfit)
+	assert.Equal(t, int64(550), s.OwnersEquity())
+	assert.Equal(t, int64(40), s.OwnersNetProfit())
+
+	_, err = Extract("005930", "00126380", 2024, dart.ReportHalf, dart.FSConsolidated, nil)
+	assert.Error(t, err)
+}
+
+func TestBuildHistory_TTM(t *testing.T) {
+	stmts := []Statement{
+		stmt(2023, 1, "20230515000001", 100, 10, 8, 1000),
+		stmt(2023, 4, "20240315000001", 480, 48, 40, 1100),
+		stmt(2024, 1, "20240515000001", 150, 18, 12, 1200),
+	}
+
+	rows := BuildHistory(stmts)
+	require.Len(t, rows, 3)
+
+	// 2023 Q1: 전년 자료 없음 → TTM 미산출
+	assert.False(t, rows[0].HasTTM)
+	assert.InDelta(t, 100.0, rows[0].DebtRatio, 1e-9)
+
+	// 2023 Q4: 사업연도 합계
+	assert.True(t, rows[1].HasTTM)
+	assert.Equal(t, int64(480), rows[1].RevenueTTM)
+	assert.InDelta(t, 10.0, rows[1].OpMarginTTM, 1e-9)
+
+	// 2024 Q1: 150 + 480 - 100 = 530
+	q1 := rows[2]
+	assert.True(t, q1.HasTTM)
+	assert.Equal(t, int64(530), q1.RevenueTTM)
+	assert.Equal(t, int64(56), q1.OperatingProfitTTM)
+	assert.Equal(t, int64(44), q1.NetProfitTTM)
+	assert.InDelta(t, 44.0/1100*100, q1.ROETTM, 1e-9) // 평균 자본 (1200+1000)/2
+	assert.InDelta(t, 56.0/530*100, q1.OpMarginTTM, 1e-9)
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), q1.AvailableDate)
+
+	q1.ApplyValuation(4400)
+	assert.InDelta(t, 100.0, q1.PER, 1e-9)
+	assert.InDelta(t, 4400.0/1200, q1.PBR, 1e-9)
+}
+
+func TestBuildHistory_PointInTime(t *testing.T) {
+	// 2023 사업보고서가 2024 Q1 이후에 정정됨 → 2024 Q1 산출에는 원본 사용
+	stmts := []Statement{
+		stmt(2023, 1, "20230515000001", 100, 10, 8, 1000),
+		stmt(2023, 4, "20240315000001", 480, 48, 40, 1100),
+		stmt(2023, 4, "20240701000009", 400, 40, 30, 1100),
+		stmt(2024, 1, "20240515000001", 150, 18, 12, 1200),
+	}
+
+	rows := BuildHistory(stmts)
+	require.Len(t, rows, 3)
+
+	// 2023 Q4 행은 최초 제출본
+	assert.Equal(t, "20240315000001", rows[1].RceptNo)
+	assert.Equal(t, int64(480), rows[1].RevenueTTM)
+
+	// 2024 Q1은 5/15 시점 자료만 사용 (정정본 7/1 제외)
+	assert.Equal(t, int64(530), rows[2].RevenueTTM)
+
+	// 정정 이후 시점의 view는 정정본 사용
+	view := PointInTime(stmts, dart.FSConsolidated, time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, int64(400), view[periodKey{2023, 4}].Revenue)
+}
+
+func TestBuildHistory_PrefersConsolidated(t *testing.T) {
+	separate := stmt(2023, 4, "20240315000001", 300, 30, 20, 900)
+	separate.FSDiv = dart.FSSeparate
+
+	rows := BuildHistory([]Statement{separate, stmt(2023, 4, "20240315000001", 480, 48, 40, 1100)})
+	require.Len(t, rows, 1)
+	assert.Equal(t, dart.FSConsolidated, rows[0].FSDiv)
+	assert.Equal(t, int64(480), rows[0].Revenue)
+}
+
+func TestParsePeriodicTitle(t *testing.T) {
+	tests := []struct {
+		title  string
+		year   int
+		report dart.ReportCode
+		ok     bool
+	}{
+		{"분기보고서 (2024.03)", 2024, dart.ReportQ1, true},
+		{"반기보고서 (2024.06)", 2024, dart.ReportHalf, true},
+		{"[기재정정]분기보고서 (2024.09)", 2024, dart.ReportQ3, true},
+		{"사업보고서 (2023.12)", 2023, dart.ReportAnnual, true},
+		{"사업보고서 (2024.06)", 0, "", false}, // 6월 결산 법인
+		{"주요사항보고서(유상증자결정)", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			year, report, ok := ParsePeriodicTitle(tt.title)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.year, year)
+			assert.Equal(t, tt.report, report)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package financials
+
+import (
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/dart"
+)
+
+// Statement is one filed periodic report (제출본 단위)
+// ⭐ SSOT: 재무제표 원장 구조 (data.financial_statements)
+//
+// 손익 항목은 회계연도 누적(YTD), 재무상태 항목은 기말 잔액
+// 0은 미공시 항목 (금융업 매출액 등)
+type Statement struct {
+	Code          string     `json:"code"`
+	CorpCode      string     `json:"corp_code"`
+	FiscalYear    int        `json:"fiscal_year"`
+	FiscalQuarter int        `json:"fiscal_quarter"`
+	FSDiv         dart.FSDiv `json:"fs_div"`
+	RceptNo       string     `json:"rcept_no"`
+	FiledDate     time.Time  `json:"filed_date"`
+	PeriodEnd     time.Time  `json:"period_end"`
+
+	Revenue         int64 `json:"revenue"`
+	OperatingProfit int64 `json:"operating_profit"`
+	NetProfit       int64 `json:"net_profit"`
+	NetProfitOwners int64 `json:"net_profit_owners"`
+
+	TotalAssets      int64 `json:"total_assets"`
+	TotalLiabilities int64 `json:"total_liabilities"`
+	TotalEquity      int64 `json:"total_equity"`
+	EquityOwners     int64 `json:"equity_owners"`
+}
+
+// OwnersNetProfit returns 지배주주 순이익, falling back to 당기순이익 (별도재무제표)
+func (s Statement) OwnersNetProfit() int64 {
+	if s.NetProfitOwners != 0 {
+		return s.NetProfitOwners
+	}
+	return s.NetProfit
+}
+
+// OwnersEquity returns 지배주주 자본, falling back to 자본총계 (별도재무제표)
+func (s Statement) OwnersEquity() int64 {
+	if s.EquityOwners != 0 {
+		return s.EquityOwners
+	}
+	return s.TotalEquity
+}
+
+// Fundamental is the point-in-time derived row stored in data.fundamentals
+// AvailableDate 이전 시점의 조회에는 노출되지 않음
+type Fundamental struct {
+	Code          string     `json:"code"`
+	ReportDate    time.Time  `json:"report_date"` // 분기말
+	AvailableDate time.Time  `json:"available_date"`
+	FSDiv         dart.FSDiv `json:"fs_div"`
+	RceptNo       string     `json:"rcept_no"`
+
+	// 보고서 기준 (YTD)
+	Revenue         int64 `json:"revenue"`
+	OperatingProfit int64 `json:"operating_profit"`
+	NetProfit       int64 `json:"net_profit"`
+
+	// 최근 4분기 합산 (직전 보고서가 없으면 0)
+	RevenueTTM         int64 `json:"revenue_ttm"`
+	OperatingProfitTTM int64 `json:"operating_profit_ttm"`
+	NetProfitTTM       int64 `json:"net_profit_ttm"`
+
+	TotalAssets      int64 `json:"total_assets"`
+	TotalLiabilities int64 `json:"total_liabilities"`
+	TotalEquity      int64 `json:"total_equity"`
+
+	ROETTM      float64 `json:"roe_ttm"`       // %
+	OpMarginTTM float64 `json:"op_margin_ttm"` // %
+	DebtRatio   float64 `json:"debt_ratio"`    // %
+	PER         float64 `json:"per"`
+	PBR         float64 `json:"pbr"`
+
+	HasTTM bool `json:"has_ttm"`
+
+	netOwnersTTM int64 // 지배주주 순이익 TTM
+	ownersEquity int64 // 지배주주 자본
+}
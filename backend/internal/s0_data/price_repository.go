@@ -2,6 +2,7 @@ package s0_data
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,22 +12,34 @@ import (
 
 // PriceRepository implements contracts.PriceRepository
 // ⭐ SSOT: 가격 데이터 저장소는 여기서만
+// 조회는 basis(원주가/수정주가)에 따라, 저장은 항상 원주가 테이블에
 type PriceRepository struct {
-	pool *pgxpool.Pool
+	pool  *pgxpool.Pool
+	basis contracts.PriceBasis
 }
 
-// NewPriceRepository creates a new price repository
-func NewPriceRepository(pool *pgxpool.Pool) *PriceRepository {
-	return &PriceRepository{pool: pool}
+// NewPriceRepository creates a new price repository reading the given price basis
+func NewPriceRepository(pool *pgxpool.Pool, basis contracts.PriceBasis) *PriceRepository {
+	return &PriceRepository{pool: pool, basis: basis}
+}
+
+// Basis returns the price basis used for reads
+func (r *PriceRepository) Basis() contracts.PriceBasis {
+	return r.basis
+}
+
+// WithBasis returns a repository sharing the pool but reading the given basis
+func (r *PriceRepository) WithBasis(basis contracts.PriceBasis) *PriceRepository {
+	return &PriceRepository{pool: r.pool, basis: basis}
 }
 
 // GetByCodeAndDate retrieves price for a specific code and date
 func (r *PriceRepository) GetByCodeAndDate(ctx context.Context, code string, date time.Time) (*contracts.Price, error) {
-	query := `
+	query := fmt.Sprintf(`
 		SELECT stock_code, trade_date, open_price, high_price, low_price, close_price, volume
-		FROM data.daily_prices
+		FROM %s
 		WHERE stock_code = $1 AND trade_date = $2
-	`
+	`, r.basis.Table())
 
 	var p contracts.Price
 	err := r.pool.QueryRow(ctx, query, code, date).Scan(
@@ -40,12 +53,12 @@ func (r *PriceRepository) GetByCodeAndDate(ctx context.Context, code string, dat
 
 // GetByCodeAndDateRange retrieves prices for a code within date range
 func (r *PriceRepository) GetByCodeAndDateRange(ctx context.Context, code string, from, to time.Time) ([]*contracts.Price, error) {
-	query := `
+	query := fmt.Sprintf(`
 		SELECT stock_code, trade_date, open_price, high_price, low_price, close_price, volume
-		FROM data.daily_prices
+		FROM %s
 		WHERE stock_code = $1 AND trade_date BETWEEN $2 AND $3
 		ORDER BY trade_date ASC
-	`
+	`, r.basis.Table())
 
 	rows, err := r.pool.Query(ctx, query, code, from, to)
 	if err != nil {
@@ -66,13 +79,13 @@ func (r *PriceRepository) GetByCodeAndDateRange(ctx context.Context, code string
 
 // GetLatestByCode retrieves the most recent price for a code
 func (r *PriceRepository) GetLatestByCode(ctx context.Context, code string) (*contracts.Price, error) {
-	query := `
+	query := fmt.Sprintf(`
 		SELECT stock_code, trade_date, open_price, high_price, low_price, close_price, volume
-		FROM data.daily_prices
+		FROM %s
 		WHERE stock_code = $1
 		ORDER BY trade_date DESC
 		LIMIT 1
-	`
+	`, r.basis.Table())
 
 	var p contracts.Price
 	err := r.pool.QueryRow(ctx, query, code).Scan(
@@ -134,10 +147,10 @@ type PriceWithMeta struct {
 
 // GetDailyPrices 특정 날짜의 모든 가격 데이터 조회 (전일 종가, 섹터, 시가총액 포함)
 func (r *PriceRepository) GetDailyPrices(ctx context.Context, date time.Time) ([]PriceWithMeta, error) {
-	query := `
+	query := fmt.Sprintf(`
 		WITH prev_prices AS (
 			SELECT stock_code, close_price as prev_close
-			FROM data.daily_prices
+			FROM %[1]s
 			WHERE trade_date = $1::date - INTERVAL '1 day'
 		)
 		SELECT
@@ -151,12 +164,12 @@ func (r *PriceRepository) GetDailyPrices(ctx context.Context, date time.Time) ([
 			COALESCE(pp.prev_close, 0) as prev_close,
 			COALESCE(s.sector, '') as sector,
 			COALESCE(mc.market_cap, 0) as market_cap
-		FROM data.daily_prices dp
+		FROM %[1]s dp
 		LEFT JOIN prev_prices pp ON dp.stock_code = pp.stock_code
 		LEFT JOIN data.stocks s ON dp.stock_code = s.code
 		LEFT JOIN data.market_cap mc ON dp.stock_code = mc.stock_code AND mc.trade_date = $1
 		WHERE dp.trade_date = $1
-	`
+	`, r.basis.Table())
 
 	rows, err := r.pool.Query(ctx, query, date)
 	if err != nil {
@@ -188,13 +201,13 @@ func (r *PriceRepository) GetDailyPrices(ctx context.Context, date time.Time) ([
 
 // GetForwardPrices 이벤트 이후 N거래일 가격 조회
 func (r *PriceRepository) GetForwardPrices(ctx context.Context, code string, eventDate time.Time, days int) ([]PriceWithMeta, error) {
-	query := `
+	query := fmt.Sprintf(`
 		SELECT stock_code, trade_date, open_price, high_price, low_price, close_price, volume
-		FROM data.daily_prices
+		FROM %s
 		WHERE stock_code = $1 AND trade_date > $2
 		ORDER BY trade_date ASC
 		LIMIT $3
-	`
+	`, r.basis.Table())
 
 	rows, err := r.pool.Query(ctx, query, code, eventDate, days)
 	if err != nil {
@@ -221,11 +234,11 @@ func (r *PriceRepository) GetForwardPrices(ctx context.Context, code string, eve
 
 // GetPrice 특정 종목/날짜의 가격 조회
 func (r *PriceRepository) GetPrice(ctx context.Context, code string, date time.Time) (*PriceWithMeta, error) {
-	query := `
+	query := fmt.Sprintf(`
 		SELECT stock_code, trade_date, open_price, high_price, low_price, close_price, volume
-		FROM data.daily_prices
+		FROM %s
 		WHERE stock_code = $1 AND trade_date = $2
-	`
+	`, r.basis.Table())
 
 	var p PriceWithMeta
 	var openPrice, highPrice, lowPrice, closePrice int64
@@ -0,0 +1,151 @@
+package quality
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+// 이상치 판정 기본값
+const (
+	defaultMaxPriceChange  = 0.30 // KRX 가격제한폭 ±30%
+	defaultMaxMarketCapAge = 7    // 시가총액 허용 지연 (일)
+	priceChangeTolerance   = 1e-6 // 호가단위 절사 오차
+)
+
+// RecordInput is one stock's records around the snapshot date
+// 일봉/수급/시총이 없으면 해당 검사는 건너뜀 (누락은 커버리지에서 측정)
+type RecordInput struct {
+	Code string
+
+	// 기준일 일봉
+	HasPrice               bool
+	Open, High, Low, Close float64
+	Volume                 int64
+
+	// 직전 저장 일봉
+	HasPrev                                bool
+	PrevOpen, PrevHigh, PrevLow, PrevClose float64
+	PrevVolume                             int64
+
+	Halted        bool // 기준일 TRADING_HALT 플래그
+	HasCorpAction bool // 직전 일봉 이후 ~ 기준일 사이 분할/증자 기업행위
+
+	// 기준일 투자자 순매수 수량 (주)
+	HasFlow                                bool
+	ForeignNetQty, InstNetQty, IndivNetQty int64
+
+	MarketCapDate time.Time // 기준일 이전 최신 시가총액 일자 (zero = 없음)
+}
+
+// DetectAnomalies runs the per-record checks for one stock
+// ⭐ SSOT: S0 레코드 이상치 판정 규칙은 이 함수에서만
+func DetectAnomalies(in RecordInput, date time.Time, cfg Config) []contracts.DataAnomaly {
+	cfg = cfg.withDefaults()
+	anomalies := make([]contracts.DataAnomaly, 0)
+	add := func(t contracts.AnomalyType, format string, args ...interface{}) {
+		anomalies = append(anomalies, contracts.DataAnomaly{Code: in.Code, Type: t, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	if in.HasPrice {
+		// 1. OHLC 정합성
+		if in.Open <= 0 || in.High <= 0 || in.Low <= 0 || in.Close <= 0 ||
+			in.High < in.Low || in.High < in.Open || in.High < in.Close ||
+			in.Low > in.Open || in.Low > in.Close {
+			add(contracts.AnomalyOHLC, "O=%.0f H=%.0f L=%.0f C=%.0f", in.Open, in.High, in.Low, in.Close)
+		}
+
+		// 2. 거래정지 아닌데 거래량 0
+		if in.Volume == 0 && !in.Halted {
+			add(contracts.AnomalyZeroVolume, "volume=0 without TRADING_HALT")
+		}
+
+		if in.HasPrev {
+			// 3. 기업행위 없는 가격제한폭 초과
+			if in.PrevClose > 0 && in.Close > 0 && !in.HasCorpAction {
+				change := in.Close/in.PrevClose - 1
+				if change > cfg.MaxPriceChange+priceChangeTolerance || change < -cfg.MaxPriceChange-priceChangeTolerance {
+					add(contracts.AnomalyPriceJump, "close %.0f → %.0f (%+.1f%%)", in.PrevClose, in.Close, change*100)
+				}
+			}
+
+			// 4. 직전 일봉 복제 (소스 캐시/미갱신)
+			if in.Volume > 0 && in.Volume == in.PrevVolume &&
+				in.Open == in.PrevOpen && in.High == in.PrevHigh &&
+				in.Low == in.PrevLow && in.Close == in.PrevClose {
+				add(contracts.AnomalyDuplicateRow, "OHLCV identical to previous bar")
+			}
+		}
+
+		// 5. 순매수 수량이 거래량 초과 → 금액 단위 혼입 의심
+		if in.HasFlow {
+			for _, f := range []struct {
+				name string
+				qty  int64
+			}{
+				{"foreign", in.ForeignNetQty},
+				{"inst", in.InstNetQty},
+				{"indiv", in.IndivNetQty},
+			} {
+				if abs64(f.qty) > in.Volume {
+					add(contracts.AnomalyFlowOutOfRange, "%s_net_qty=%d > volume=%d", f.name, f.qty, in.Volume)
+					break
+				}
+			}
+		}
+	}
+
+	// 6. 시가총액 갱신 지연
+	if !in.MarketCapDate.IsZero() {
+		age := int(date.Sub(in.MarketCapDate).Hours() / 24)
+		if age > cfg.MaxMarketCapAgeDays {
+			add(contracts.AnomalyStaleMarketCap, "market cap as of %s (%d days old)", in.MarketCapDate.Format("2006-01-02"), age)
+		}
+	}
+
+	return anomalies
+}
+
+// Quarantine maps each stock with anomalies to its quarantine reason
+// 사유는 이상치 유형을 정렬·중복 제거해 쉼표로 연결
+func Quarantine(anomalies []contracts.DataAnomaly) map[string]string {
+	types := make(map[string]map[contracts.AnomalyType]bool)
+	for _, a := range anomalies {
+		if types[a.Code] == nil {
+			types[a.Code] = make(map[contracts.AnomalyType]bool)
+		}
+		types[a.Code][a.Type] = true
+	}
+
+	quarantined := make(map[string]string, len(types))
+	for code, set := range types {
+		names := make([]string, 0, len(set))
+		for t := range set {
+			names = append(names, string(t))
+		}
+		sort.Strings(names)
+		quarantined[code] = strings.Join(names, ",")
+	}
+	return quarantined
+}
+
+// withDefaults fills unset anomaly thresholds
+func (c Config) withDefaults() Config {
+	if c.MaxPriceChange <= 0 {
+		c.MaxPriceChange = defaultMaxPriceChange
+	}
+	if c.MaxMarketCapAgeDays <= 0 {
+		c.MaxMarketCapAgeDays = defaultMaxMarketCapAge
+	}
+	return c
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,152 @@
+package quality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	clean := func() RecordInput {
+		return RecordInput{
+			Code:     "005930",
+			HasPrice: true,
+			Open:     70000, High: 71000, Low: 69500, Close: 70500,
+			Volume:   1_000_000,
+			HasPrev:  true,
+			PrevOpen: 69000, PrevHigh: 70200, PrevLow: 68800, PrevClose: 70000,
+			PrevVolume:    900_000,
+			HasFlow:       true,
+			ForeignNetQty: 120_000, InstNetQty: -50_000, IndivNetQty: -70_000,
+			MarketCapDate: date,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(in *RecordInput)
+		want   []contracts.AnomalyType
+	}{
+		{
+			name:   "clean record",
+			modify: func(in *RecordInput) {},
+			want:   nil,
+		},
+		{
+			name:   "high below close",
+			modify: func(in *RecordInput) { in.High = 70400 },
+			want:   []contracts.AnomalyType{contracts.AnomalyOHLC},
+		},
+		{
+			name:   "zero price",
+			modify: func(in *RecordInput) { in.Low = 0 },
+			want:   []contracts.AnomalyType{contracts.AnomalyOHLC},
+		},
+		{
+			name: "zero volume without halt",
+			modify: func(in *RecordInput) {
+				in.Volume = 0
+				in.HasFlow = false
+			},
+			want: []contracts.AnomalyType{contracts.AnomalyZeroVolume},
+		},
+		{
+			name: "zero volume while halted",
+			modify: func(in *RecordInput) {
+				in.Volume = 0
+				in.Halted = true
+				in.HasFlow = false
+			},
+			want: nil,
+		},
+		{
+			name: "limit-up exactly 30% is allowed",
+			modify: func(in *RecordInput) {
+				in.Open, in.High, in.Low, in.Close = 91000, 91000, 90000, 91000
+			},
+			want: nil,
+		},
+		{
+			name: "jump beyond limit without corporate action",
+			modify: func(in *RecordInput) {
+				in.Open, in.High, in.Low, in.Close = 14000, 14200, 13900, 14000
+			},
+			want: []contracts.AnomalyType{contracts.AnomalyPriceJump},
+		},
+		{
+			name: "split explains jump",
+			modify: func(in *RecordInput) {
+				in.Open, in.High, in.Low, in.Close = 14000, 14200, 13900, 14000
+				in.HasCorpAction = true
+			},
+			want: nil,
+		},
+		{
+			name: "bar copied from previous day",
+			modify: func(in *RecordInput) {
+				in.Open, in.High, in.Low, in.Close, in.Volume = in.PrevOpen, in.PrevHigh, in.PrevLow, in.PrevClose, in.PrevVolume
+			},
+			want: []contracts.AnomalyType{contracts.AnomalyDuplicateRow},
+		},
+		{
+			name:   "flow stored in KRW",
+			modify: func(in *RecordInput) { in.ForeignNetQty = 8_400_000_000 },
+			want:   []contracts.AnomalyType{contracts.AnomalyFlowOutOfRange},
+		},
+		{
+			name:   "stale market cap",
+			modify: func(in *RecordInput) { in.MarketCapDate = date.AddDate(0, 0, -10) },
+			want:   []contracts.AnomalyType{contracts.AnomalyStaleMarketCap},
+		},
+		{
+			name: "no price row skips price checks",
+			modify: func(in *RecordInput) {
+				in.HasPrice = false
+				in.Open, in.High, in.Low, in.Close, in.Volume = 0, 0, 0, 0, 0
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := clean()
+			tt.modify(&in)
+
+			var got []contracts.AnomalyType
+			for _, a := range DetectAnomalies(in, date, Config{}) {
+				assert.Equal(t, "005930", a.Code)
+				got = append(got, a.Type)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDetectAnomalies_CustomThresholds(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	in := RecordInput{Code: "000660", MarketCapDate: date.AddDate(0, 0, -3)}
+
+	assert.Empty(t, DetectAnomalies(in, date, Config{}))
+	assert.Len(t, DetectAnomalies(in, date, Config{MaxMarketCapAgeDays: 2}), 1)
+}
+
+func TestQuarantine(t *testing.T) {
+	anomalies := []contracts.DataAnomaly{
+		{Code: "005930", Type: contracts.AnomalyZeroVolume},
+		{Code: "000660", Type: contracts.AnomalyStaleMarketCap},
+		{Code: "005930", Type: contracts.AnomalyDuplicateRow},
+		{Code: "005930", Type: contracts.AnomalyZeroVolume},
+	}
+
+	assert.Equal(t, map[string]string{
+		"005930": "DUPLICATE_ROW,ZERO_VOLUME",
+		"000660": "STALE_MARKET_CAP",
+	}, Quarantine(anomalies))
+	assert.Empty(t, Quarantine(nil))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/wonny/aegis/v13/backend/internal/contracts"
 )
@@ -20,14 +21,21 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 	return &Repository{pool: pool}
 }
 
-// SaveSnapshot saves a data quality snapshot
+// SaveSnapshot saves a data quality snapshot with its anomalies
+// 같은 일자 재실행 시 이상치는 교체
 func (r *Repository) SaveSnapshot(ctx context.Context, snapshot *contracts.DataQualitySnapshot) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		INSERT INTO audit.data_quality_snapshots (
 			snapshot_date, quality_score, total_stocks, valid_stocks,
 			price_coverage, volume_coverage, marketcap_coverage,
-			fundamentals_coverage, investor_coverage, passed
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			fundamentals_coverage, investor_coverage, passed, quarantined_stocks
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (snapshot_date) DO UPDATE SET
 			quality_score = EXCLUDED.quality_score,
 			total_stocks = EXCLUDED.total_stocks,
@@ -38,10 +46,11 @@ func (r *Repository) SaveSnapshot(ctx context.Context, snapshot *contracts.DataQ
 			fundamentals_coverage = EXCLUDED.fundamentals_coverage,
 			investor_coverage = EXCLUDED.investor_coverage,
 			passed = EXCLUDED.passed,
+			quarantined_stocks = EXCLUDED.quarantined_stocks,
 			updated_at = NOW()
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		snapshot.Date,
 		snapshot.QualityScore,
 		snapshot.TotalStocks,
@@ -52,12 +61,77 @@ func (r *Repository) SaveSnapshot(ctx context.Context, snapshot *contracts.DataQ
 		snapshot.Coverage["fundamentals"],
 		snapshot.Coverage["investor"],
 		snapshot.Passed,
+		len(snapshot.Quarantined),
 	)
 
 	if err != nil {
 		return fmt.Errorf("save quality snapshot: %w", err)
 	}
 
+	if _, err := tx.Exec(ctx, `DELETE FROM audit.data_quality_anomalies WHERE snapshot_date = $1`, snapshot.Date); err != nil {
+		return fmt.Errorf("clear quality anomalies: %w", err)
+	}
+
+	if len(snapshot.Anomalies) > 0 {
+		batch := &pgx.Batch{}
+		for _, a := range snapshot.Anomalies {
+			batch.Queue(`
+				INSERT INTO audit.data_quality_anomalies (snapshot_date, stock_code, anomaly_type, detail)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (snapshot_date, stock_code, anomaly_type) DO UPDATE SET detail = EXCLUDED.detail
+			`, snapshot.Date, a.Code, string(a.Type), a.Detail)
+		}
+
+		br := tx.SendBatch(ctx, batch)
+		for range snapshot.Anomalies {
+			if _, err := br.Exec(); err != nil {
+				br.Close()
+				return fmt.Errorf("save quality anomaly: %w", err)
+			}
+		}
+		if err := br.Close(); err != nil {
+			return fmt.Errorf("close anomaly batch: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// loadAnomalies attaches stored anomalies and the derived quarantine map
+func (r *Repository) loadAnomalies(ctx context.Context, snapshot *contracts.DataQualitySnapshot) error {
+	query := `
+		SELECT stock_code, anomaly_type, COALESCE(detail, '')
+		FROM audit.data_quality_anomalies
+		WHERE snapshot_date = $1
+		ORDER BY stock_code, anomaly_type
+	`
+
+	rows, err := r.pool.Query(ctx, query, snapshot.Date)
+	if err != nil {
+		return fmt.Errorf("query quality anomalies: %w", err)
+	}
+	defer rows.Close()
+
+	anomalies := make([]contracts.DataAnomaly, 0)
+	for rows.Next() {
+		var a contracts.DataAnomaly
+		var anomalyType string
+		if err := rows.Scan(&a.Code, &anomalyType, &a.Detail); err != nil {
+			return fmt.Errorf("scan quality anomaly: %w", err)
+		}
+		a.Type = contracts.AnomalyType(anomalyType)
+		anomalies = append(anomalies, a)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate quality anomalies: %w", err)
+	}
+
+	snapshot.Anomalies = anomalies
+	snapshot.Quarantined = Quarantine(anomalies)
 	return nil
 }
 
@@ -101,6 +175,10 @@ func (r *Repository) GetByDate(ctx context.Context, date time.Time) (*contracts.
 	snapshot.Coverage["fundamentals"] = fundamentalsCov
 	snapshot.Coverage["investor"] = investorCov
 
+	if err := r.loadAnomalies(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
 	return snapshot, nil
 }
 
@@ -145,5 +223,9 @@ func (r *Repository) GetLatest(ctx context.Context) (*contracts.DataQualitySnaps
 	snapshot.Coverage["fundamentals"] = fundamentalsCov
 	snapshot.Coverage["investor"] = investorCov
 
+	if err := r.loadAnomalies(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
 	return snapshot, nil
 }
@@ -24,6 +24,10 @@ type Config struct {
 	MinFinancialCoverage float64 `yaml:"min_financial_coverage"`  // 0.80
 	MinInvestorCoverage  float64 `yaml:"min_investor_coverage"`   // 0.80
 	MinDisclosureCoverage float64 `yaml:"min_disclosure_coverage"` // 0.70
+
+	// 레코드 이상치 임계값 (0 = 기본값)
+	MaxPriceChange      float64 `yaml:"max_price_change"`        // 0.30 (가격제한폭)
+	MaxMarketCapAgeDays int     `yaml:"max_market_cap_age_days"` // 7
 }
 
 // NewQualityGate creates a new QualityGate instance
@@ -60,6 +64,18 @@ func (g *QualityGate) Check(ctx context.Context, date time.Time) (*contracts.Dat
 	snapshot.QualityScore = g.calculateScore(coverage)
 	snapshot.ValidStocks = int(float64(totalStocks) * snapshot.QualityScore)
 
+	// 4. 레코드 이상치 → 종목 단위 격리 (하루 전체를 통과/실패시키지 않음)
+	anomalies, err := g.checkAnomalies(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("check anomalies: %w", err)
+	}
+	snapshot.Anomalies = anomalies
+	snapshot.Quarantined = Quarantine(anomalies)
+	snapshot.ValidStocks -= len(snapshot.Quarantined)
+	if snapshot.ValidStocks < 0 {
+		snapshot.ValidStocks = 0
+	}
+
 	return snapshot, nil
 }
 
@@ -218,6 +234,85 @@ func (g *QualityGate) checkInvestorCoverage(ctx context.Context, date time.Time)
 	return coverage, nil
 }
 
+// checkAnomalies loads each active stock's records around the date and runs DetectAnomalies
+func (g *QualityGate) checkAnomalies(ctx context.Context, date time.Time) ([]contracts.DataAnomaly, error) {
+	query := `
+		SELECT
+			s.code,
+			dp.stock_code IS NOT NULL,
+			COALESCE(dp.open_price, 0)::float8, COALESCE(dp.high_price, 0)::float8,
+			COALESCE(dp.low_price, 0)::float8, COALESCE(dp.close_price, 0)::float8,
+			COALESCE(dp.volume, 0),
+			prev.trade_date IS NOT NULL,
+			COALESCE(prev.open_price, 0)::float8, COALESCE(prev.high_price, 0)::float8,
+			COALESCE(prev.low_price, 0)::float8, COALESCE(prev.close_price, 0)::float8,
+			COALESCE(prev.volume, 0),
+			EXISTS (
+				SELECT 1 FROM data.stock_status_history sh
+				WHERE sh.stock_code = s.code AND sh.status_date = $1::date
+				  AND $2 = ANY(sh.flags)
+			),
+			EXISTS (
+				SELECT 1 FROM data.corporate_actions ca
+				WHERE ca.stock_code = s.code
+				  AND ca.action_type <> 'CASH_DIVIDEND'
+				  AND ca.ex_date > COALESCE(prev.trade_date, $1::date) AND ca.ex_date <= $1::date
+			),
+			ifl.stock_code IS NOT NULL,
+			COALESCE(ifl.foreign_net_qty, 0), COALESCE(ifl.inst_net_qty, 0), COALESCE(ifl.indiv_net_qty, 0),
+			mc.trade_date
+		FROM data.stocks s
+		LEFT JOIN data.daily_prices dp ON dp.stock_code = s.code AND dp.trade_date = $1::date
+		LEFT JOIN LATERAL (
+			SELECT trade_date, open_price, high_price, low_price, close_price, volume
+			FROM data.daily_prices
+			WHERE stock_code = s.code AND trade_date < $1::date
+			ORDER BY trade_date DESC LIMIT 1
+		) prev ON dp.stock_code IS NOT NULL
+		LEFT JOIN data.investor_flow ifl ON ifl.stock_code = s.code AND ifl.trade_date = $1::date
+		LEFT JOIN LATERAL (
+			SELECT trade_date FROM data.market_cap
+			WHERE stock_code = s.code AND trade_date <= $1::date
+			ORDER BY trade_date DESC LIMIT 1
+		) mc ON TRUE
+		WHERE s.status = 'active'
+		ORDER BY s.code
+	`
+
+	rows, err := g.db.Query(ctx, query, date, string(contracts.KRXFlagTradingHalt))
+	if err != nil {
+		return nil, fmt.Errorf("query anomaly inputs: %w", err)
+	}
+	defer rows.Close()
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	anomalies := make([]contracts.DataAnomaly, 0)
+	for rows.Next() {
+		var in RecordInput
+		var mcDate *time.Time
+		if err := rows.Scan(
+			&in.Code,
+			&in.HasPrice, &in.Open, &in.High, &in.Low, &in.Close, &in.Volume,
+			&in.HasPrev, &in.PrevOpen, &in.PrevHigh, &in.PrevLow, &in.PrevClose, &in.PrevVolume,
+			&in.Halted,
+			&in.HasCorpAction,
+			&in.HasFlow, &in.ForeignNetQty, &in.InstNetQty, &in.IndivNetQty,
+			&mcDate,
+		); err != nil {
+			return nil, fmt.Errorf("scan anomaly input: %w", err)
+		}
+		if mcDate != nil {
+			in.MarketCapDate = *mcDate
+		}
+		anomalies = append(anomalies, DetectAnomalies(in, day, g.config)...)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate anomaly inputs: %w", err)
+	}
+
+	return anomalies, nil
+}
+
 // calculateScore calculates overall quality score using weighted average
 func (g *QualityGate) calculateScore(coverage map[string]float64) float64 {
 	// 가중치 (합계 = 1.0)
@@ -0,0 +1,252 @@
+package reconcile
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Source identifies where an observation came from
+type Source string
+
+const (
+	SourceNaver  Source = "NAVER"  // Naver 차트 API (수집 파이프라인 기본 소스)
+	SourceKIS    Source = "KIS"    // KIS 일별 시세
+	SourceKRX    Source = "KRX"    // KRX 정보데이터시스템 MDCSTAT01501
+	SourceStored Source = "STORED" // DB 저장값 (승자 후보 아님)
+)
+
+// Field is a compared value
+type Field string
+
+const (
+	FieldOpen      Field = "open"
+	FieldHigh      Field = "high"
+	FieldLow       Field = "low"
+	FieldClose     Field = "close"
+	FieldVolume    Field = "volume"
+	FieldMarketCap Field = "market_cap"
+)
+
+// Fields lists compared fields in report order
+var Fields = []Field{FieldOpen, FieldHigh, FieldLow, FieldClose, FieldVolume, FieldMarketCap}
+
+// Tolerance allows a difference if it is within Abs or within Rel of the winner value
+type Tolerance struct {
+	Abs float64
+	Rel float64
+}
+
+// Config holds reconciliation rules
+type Config struct {
+	Priority   []Source // 승자 선택 순서 (앞일수록 우선)
+	Tolerances map[Field]Tolerance
+	SampleSize int // KIS/Naver 조회 종목 수 (0 = 전체)
+}
+
+// DefaultConfig returns the default rules
+// 가격은 정수 원 단위라 정확히 일치해야 하고, 거래량은 시간외 포함 여부, 시총은 상장주식수 반영 시점 차이를 허용
+func DefaultConfig() Config {
+	return Config{
+		Priority: []Source{SourceKRX, SourceKIS, SourceNaver},
+		Tolerances: map[Field]Tolerance{
+			FieldOpen:      {Abs: 0},
+			FieldHigh:      {Abs: 0},
+			FieldLow:       {Abs: 0},
+			FieldClose:     {Abs: 0},
+			FieldVolume:    {Rel: 0.01},
+			FieldMarketCap: {Rel: 0.005},
+		},
+		SampleSize: 100,
+	}
+}
+
+// Observation is one source's values for a stock on the reconciled date
+type Observation struct {
+	Source Source
+	Code   string
+	Values map[Field]float64
+}
+
+// Discrepancy is a field where sources disagree beyond tolerance
+type Discrepancy struct {
+	Date           time.Time
+	Code           string
+	Field          Field
+	Values         map[Source]float64
+	Winner         Source
+	WinnerValue    float64
+	MaxDiffPct     float64 // 승자 대비 최대 상대 차이
+	StoredMismatch bool    // DB 저장값이 승자와 다름 (--apply 교정 대상)
+	Applied        bool    // 승자 값으로 저장값 교정 완료
+}
+
+// Compare groups observations per stock and field and reports disagreements
+// ⭐ SSOT: 교차 소스 허용오차·승자 선택 규칙은 이 함수에서만
+func Compare(date time.Time, obs []Observation, cfg Config) []Discrepancy {
+	values := make(map[string]map[Field]map[Source]float64)
+	for _, o := range obs {
+		if values[o.Code] == nil {
+			values[o.Code] = make(map[Field]map[Source]float64)
+		}
+		for f, v := range o.Values {
+			if values[o.Code][f] == nil {
+				values[o.Code][f] = make(map[Source]float64)
+			}
+			values[o.Code][f][o.Source] = v
+		}
+	}
+
+	codes := make([]string, 0, len(values))
+	for code := range values {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	result := make([]Discrepancy, 0)
+	for _, code := range codes {
+		for _, field := range Fields {
+			bySource := values[code][field]
+			winner, ok := pickWinner(bySource, cfg.Priority)
+			if !ok || len(bySource) < 2 {
+				continue
+			}
+
+			d := Discrepancy{
+				Date:        date,
+				Code:        code,
+				Field:       field,
+				Values:      bySource,
+				Winner:      winner,
+				WinnerValue: bySource[winner],
+			}
+			tol := cfg.Tolerances[field]
+			disagree := false
+			for src, v := range bySource {
+				if within(v, d.WinnerValue, tol) {
+					continue
+				}
+				disagree = true
+				if src == SourceStored {
+					d.StoredMismatch = true
+				}
+				if pct := relDiff(v, d.WinnerValue); pct > d.MaxDiffPct {
+					d.MaxDiffPct = pct
+				}
+			}
+			if disagree {
+				result = append(result, d)
+			}
+		}
+	}
+	return result
+}
+
+// pickWinner returns the highest-priority source that has a value
+func pickWinner(bySource map[Source]float64, priority []Source) (Source, bool) {
+	for _, src := range priority {
+		if _, ok := bySource[src]; ok {
+			return src, true
+		}
+	}
+	return "", false
+}
+
+func within(v, winner float64, tol Tolerance) bool {
+	diff := math.Abs(v - winner)
+	if diff <= tol.Abs {
+		return true
+	}
+	return winner != 0 && diff/math.Abs(winner) <= tol.Rel
+}
+
+func relDiff(v, winner float64) float64 {
+	if winner == 0 {
+		if v == 0 {
+			return 0
+		}
+		return 1
+	}
+	return math.Abs(v-winner) / math.Abs(winner)
+}
+
+// FieldSummary counts disagreements for one field
+type FieldSummary struct {
+	Field          Field
+	Stocks         int            // 불일치 종목 수
+	StoredMismatch int            // DB 저장값이 승자와 다른 종목 수
+	BySource       map[Source]int // 승자와 어긋난 소스별 종목 수
+}
+
+// Summarize aggregates discrepancies per field in report order
+func Summarize(discrepancies []Discrepancy, cfg Config) []FieldSummary {
+	byField := make(map[Field]*FieldSummary)
+	for _, d := range discrepancies {
+		s := byField[d.Field]
+		if s == nil {
+			s = &FieldSummary{Field: d.Field, BySource: make(map[Source]int)}
+			byField[d.Field] = s
+		}
+		s.Stocks++
+		if d.StoredMismatch {
+			s.StoredMismatch++
+		}
+		tol := cfg.Tolerances[d.Field]
+		for src, v := range d.Values {
+			if !within(v, d.WinnerValue, tol) {
+				s.BySource[src]++
+			}
+		}
+	}
+
+	result := make([]FieldSummary, 0, len(byField))
+	for _, f := range Fields {
+		if s, ok := byField[f]; ok {
+			result = append(result, *s)
+		}
+	}
+	return result
+}
+
+// ParsePriority parses a comma-separated source priority (e.g. "KRX,KIS,NAVER")
+func ParsePriority(s string) ([]Source, error) {
+	priority := make([]Source, 0)
+	seen := make(map[Source]bool)
+	for _, part := range strings.Split(s, ",") {
+		src := Source(strings.ToUpper(strings.TrimSpace(part)))
+		if src == "" {
+			continue
+		}
+		switch src {
+		case SourceNaver, SourceKIS, SourceKRX:
+		default:
+			return nil, fmt.Errorf("unknown source %q (valid: NAVER, KIS, KRX)", src)
+		}
+		if !seen[src] {
+			seen[src] = true
+			priority = append(priority, src)
+		}
+	}
+	if len(priority) == 0 {
+		return nil, fmt.Errorf("empty source priority")
+	}
+	return priority, nil
+}
+
+// Sample picks n codes deterministically per seed (0 or n >= len = all)
+// 날짜를 시드로 쓰면 같은 날 재실행은 같은 표본, 날마다 다른 종목을 점검
+func Sample(codes []string, n int, seed int64) []string {
+	sorted := append([]string(nil), codes...)
+	sort.Strings(sorted)
+	if n <= 0 || n >= len(sorted) {
+		return sorted
+	}
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(sorted), func(i, j int) { sorted[i], sorted[j] = sorted[j], sorted[i] })
+	picked := sorted[:n]
+	sort.Strings(picked)
+	return picked
+}
@@ -0,0 +1,95 @@
+package reconcile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	cfg := DefaultConfig()
+
+	obs := []Observation{
+		{Source: SourceStored, Code: "005930", Values: map[Field]float64{FieldClose: 70400, FieldVolume: 1_000_000, FieldMarketCap: 420e12}},
+		{Source: SourceNaver, Code: "005930", Values: map[Field]float64{FieldClose: 70400, FieldVolume: 1_005_000}},
+		{Source: SourceKRX, Code: "005930", Values: map[Field]float64{FieldClose: 70500, FieldVolume: 1_000_000, FieldMarketCap: 421e12}},
+		{Source: SourceStored, Code: "000660", Values: map[Field]float64{FieldClose: 180000}},
+		{Source: SourceKIS, Code: "000660", Values: map[Field]float64{FieldClose: 180000}},
+		{Source: SourceStored, Code: "035420", Values: map[Field]float64{FieldClose: 200000}},
+	}
+
+	got := Compare(date, obs, cfg)
+	require.Len(t, got, 1, "volume and market cap are within tolerance, 000660 agrees, 035420 has no external source")
+
+	d := got[0]
+	assert.Equal(t, "005930", d.Code)
+	assert.Equal(t, FieldClose, d.Field)
+	assert.Equal(t, SourceKRX, d.Winner)
+	assert.Equal(t, 70500.0, d.WinnerValue)
+	assert.True(t, d.StoredMismatch)
+	assert.InDelta(t, 100.0/70500, d.MaxDiffPct, 1e-9)
+	assert.Equal(t, map[Source]float64{SourceStored: 70400, SourceNaver: 70400, SourceKRX: 70500}, d.Values)
+}
+
+func TestCompare_Priority(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	obs := []Observation{
+		{Source: SourceStored, Code: "005930", Values: map[Field]float64{FieldClose: 70400}},
+		{Source: SourceNaver, Code: "005930", Values: map[Field]float64{FieldClose: 70400}},
+		{Source: SourceKRX, Code: "005930", Values: map[Field]float64{FieldClose: 70500}},
+	}
+
+	cfg := DefaultConfig()
+	cfg.Priority = []Source{SourceNaver, SourceKRX}
+
+	got := Compare(date, obs, cfg)
+	require.Len(t, got, 1)
+	assert.Equal(t, SourceNaver, got[0].Winner)
+	assert.False(t, got[0].StoredMismatch, "stored value agrees with the winner")
+}
+
+func TestSummarize(t *testing.T) {
+	cfg := DefaultConfig()
+	discrepancies := []Discrepancy{
+		{Code: "005930", Field: FieldClose, Winner: SourceKRX, WinnerValue: 70500, StoredMismatch: true,
+			Values: map[Source]float64{SourceKRX: 70500, SourceNaver: 70400, SourceStored: 70400}},
+		{Code: "000660", Field: FieldClose, Winner: SourceKRX, WinnerValue: 180000,
+			Values: map[Source]float64{SourceKRX: 180000, SourceKIS: 179900, SourceStored: 180000}},
+		{Code: "000660", Field: FieldOpen, Winner: SourceKRX, WinnerValue: 179000,
+			Values: map[Source]float64{SourceKRX: 179000, SourceKIS: 178000}},
+	}
+
+	got := Summarize(discrepancies, cfg)
+	require.Len(t, got, 2)
+	assert.Equal(t, FieldOpen, got[0].Field)
+	assert.Equal(t, FieldClose, got[1].Field)
+	assert.Equal(t, 2, got[1].Stocks)
+	assert.Equal(t, 1, got[1].StoredMismatch)
+	assert.Equal(t, map[Source]int{SourceNaver: 1, SourceStored: 1, SourceKIS: 1}, got[1].BySource)
+}
+
+func TestParsePriority(t *testing.T) {
+	got, err := ParsePriority(" kis, KRX ,naver,KIS")
+	require.NoError(t, err)
+	assert.Equal(t, []Source{SourceKIS, SourceKRX, SourceNaver}, got)
+
+	_, err = ParsePriority("KRX,STORED")
+	assert.Error(t, err)
+	_, err = ParsePriority("")
+	assert.Error(t, err)
+}
+
+func TestSample(t *testing.T) {
+	codes := []string{"000660", "005930", "035420", "051910", "068270"}
+
+	assert.Equal(t, codes, Sample(codes, 0, 1))
+	assert.Equal(t, codes, Sample(codes, 10, 1))
+
+	first := Sample(codes, 3, 42)
+	assert.Len(t, first, 3)
+	assert.Equal(t, first, Sample(codes, 3, 42), "same seed, same sample")
+	assert.IsNonDecreasing(t, first)
+}
@@ -0,0 +1,244 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+	"github.com/wonny/aegis/v13/backend/internal/external/naver"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// kisRequestInterval throttles KIS 일별 시세 calls (초당 20건 한도)
+const kisRequestInterval = 60 * time.Millisecond
+
+// Options controls one reconciliation run
+type Options struct {
+	Date   time.Time
+	Codes  []string // 비어 있으면 저장된 전 종목 (KIS/Naver는 SampleSize만큼 표본)
+	Config Config
+	Apply  bool // 저장값을 승자 값으로 교정
+}
+
+// Reconciler compares stored daily bars and market caps against Naver, KIS and KRX
+// ⭐ SSOT: 교차 소스 대사는 이 Reconciler에서만
+type Reconciler struct {
+	naver  *naver.Client
+	kis    *kis.Client // nil이면 KIS 비교 생략
+	krx    *krx.Client
+	repo   *Repository
+	logger *logger.Logger
+}
+
+// NewReconciler creates a new Reconciler
+func NewReconciler(naverClient *naver.Client, kisClient *kis.Client, krxClient *krx.Client, repo *Repository, log *logger.Logger) *Reconciler {
+	return &Reconciler{
+		naver:  naverClient,
+		kis:    kisClient,
+		krx:    krxClient,
+		repo:   repo,
+		logger: log.WithField("module", "reconcile"),
+	}
+}
+
+// Run reconciles one trade date and saves the report
+// KRX는 1회 호출로 전 종목 비교, KIS/Naver는 종목별 호출이라 표본만 비교
+func (r *Reconciler) Run(ctx context.Context, opts Options) (*Run, []Discrepancy, error) {
+	cfg := opts.Config
+	if len(cfg.Priority) == 0 {
+		cfg.Priority = DefaultConfig().Priority
+	}
+	if cfg.Tolerances == nil {
+		cfg.Tolerances = DefaultConfig().Tolerances
+	}
+
+	stored, err := r.repo.LoadStored(ctx, opts.Date, opts.Codes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(stored) == 0 {
+		return nil, nil, fmt.Errorf("no stored prices or market caps on %s", opts.Date.Format("2006-01-02"))
+	}
+
+	codes := make([]string, 0, len(stored))
+	inScope := make(map[string]bool, len(stored))
+	for _, o := range stored {
+		codes = append(codes, o.Code)
+		inScope[o.Code] = true
+	}
+
+	obs := append([]Observation(nil), stored...)
+	sources := map[Source]bool{SourceStored: true}
+
+	// 1. KRX 전 종목
+	if r.krx != nil {
+		krxObs, err := r.fetchKRX(ctx, opts.Date, inScope)
+		if err != nil {
+			r.logger.WithError(err).Warn("KRX snapshot unavailable, skipping KRX comparison")
+		} else if len(krxObs) > 0 {
+			obs = append(obs, krxObs...)
+			sources[SourceKRX] = true
+		}
+	}
+
+	// 2. Naver / KIS 표본
+	sample := codes
+	if len(opts.Codes) == 0 {
+		sample = Sample(codes, cfg.SampleSize, opts.Date.Unix())
+	}
+	for _, code := range sample {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		if o, err := r.fetchNaver(ctx, code, opts.Date); err != nil {
+			r.logger.WithError(err).WithField("stock_code", code).Debug("Naver price unavailable")
+		} else if o != nil {
+			obs = append(obs, *o)
+			sources[SourceNaver] = true
+		}
+
+		if r.kis == nil {
+			continue
+		}
+		if o, err := r.fetchKIS(ctx, code, opts.Date); err != nil {
+			r.logger.WithError(err).WithField("stock_code", code).Debug("KIS price unavailable")
+		} else {
+			obs = append(obs, *o)
+			sources[SourceKIS] = true
+		}
+		if err := wait(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	discrepancies := Compare(opts.Date, obs, cfg)
+
+	run := &Run{
+		Date:           opts.Date,
+		Priority:       cfg.Priority,
+		StocksCompared: len(codes),
+		SampledStocks:  len(sample),
+		Discrepancies:  len(discrepancies),
+	}
+	for src := range sources {
+		run.Sources = append(run.Sources, src)
+	}
+	sort.Slice(run.Sources, func(i, j int) bool { return run.Sources[i] < run.Sources[j] })
+	for _, d := range discrepancies {
+		if d.StoredMismatch {
+			run.StoredMismatches++
+		}
+	}
+
+	if opts.Apply && run.StoredMismatches > 0 {
+		applied, err := r.repo.ApplyWinners(ctx, discrepancies)
+		if err != nil {
+			return nil, nil, err
+		}
+		run.Applied = applied
+	}
+
+	if err := r.repo.SaveRun(ctx, run, discrepancies); err != nil {
+		return nil, nil, err
+	}
+
+	r.logger.WithFields(map[string]interface{}{
+		"date":              opts.Date.Format("2006-01-02"),
+		"stocks":            run.StocksCompared,
+		"sampled":           run.SampledStocks,
+		"discrepancies":     run.Discrepancies,
+		"stored_mismatches": run.StoredMismatches,
+		"applied":           run.Applied,
+	}).Info("Source reconciliation completed")
+
+	return run, discrepancies, nil
+}
+
+// fetchKRX converts the KRX 전종목 시세 snapshot to observations
+func (r *Reconciler) fetchKRX(ctx context.Context, date time.Time, inScope map[string]bool) ([]Observation, error) {
+	items, err := r.krx.FetchAllMarketCapsOn(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	obs := make([]Observation, 0, len(items))
+	for _, item := range items {
+		if !inScope[item.StockCode] || item.ClosePrice == 0 {
+			continue
+		}
+		obs = append(obs, Observation{
+			Source: SourceKRX,
+			Code:   item.StockCode,
+			Values: map[Field]float64{
+				FieldOpen:      float64(item.OpenPrice),
+				FieldHigh:      float64(item.HighPrice),
+				FieldLow:       float64(item.LowPrice),
+				FieldClose:     float64(item.ClosePrice),
+				FieldVolume:    float64(item.Volume),
+				FieldMarketCap: float64(item.MarketCap),
+			},
+		})
+	}
+	return obs, nil
+}
+
+// fetchNaver re-reads the bar from Naver (nil if Naver has no bar for the date)
+// Naver 시가총액은 당일 스냅샷만 제공해 비교하지 않음
+func (r *Reconciler) fetchNaver(ctx context.Context, code string, date time.Time) (*Observation, error) {
+	prices, err := r.naver.FetchPrices(ctx, code, date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	want := date.Format("2006-01-02")
+	for _, p := range prices {
+		if p.TradeDate.Format("2006-01-02") != want {
+			continue
+		}
+		return &Observation{
+			Source: SourceNaver,
+			Code:   code,
+			Values: map[Field]float64{
+				FieldOpen:   float64(p.OpenPrice),
+				FieldHigh:   float64(p.HighPrice),
+				FieldLow:    float64(p.LowPrice),
+				FieldClose:  float64(p.ClosePrice),
+				FieldVolume: float64(p.Volume),
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// fetchKIS reads the bar from KIS 일별 시세
+func (r *Reconciler) fetchKIS(ctx context.Context, code string, date time.Time) (*Observation, error) {
+	p, err := r.kis.GetDailyPrice(ctx, code, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Observation{
+		Source: SourceKIS,
+		Code:   code,
+		Values: map[Field]float64{
+			FieldOpen:   p.OpenPrice,
+			FieldHigh:   p.HighPrice,
+			FieldLow:    p.LowPrice,
+			FieldClose:  p.ClosePrice,
+			FieldVolume: float64(p.Volume),
+		},
+	}, nil
+}
+
+// wait paces KIS calls and returns early on cancellation
+func wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(kisRequestInterval):
+		return nil
+	}
+}
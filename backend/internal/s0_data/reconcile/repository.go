@@ -0,0 +1,274 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// storedColumns maps fields to the stored table/column corrected by ApplyWinners
+var storedColumns = map[Field]struct{ table, column string }{
+	FieldOpen:      {"data.daily_prices", "open_price"},
+	FieldHigh:      {"data.daily_prices", "high_price"},
+	FieldLow:       {"data.daily_prices", "low_price"},
+	FieldClose:     {"data.daily_prices", "close_price"},
+	FieldVolume:    {"data.daily_prices", "volume"},
+	FieldMarketCap: {"data.market_cap", "market_cap"},
+}
+
+// Run summarizes one reconciliation date
+type Run struct {
+	Date             time.Time
+	Sources          []Source
+	Priority         []Source
+	StocksCompared   int
+	SampledStocks    int
+	Discrepancies    int
+	StoredMismatches int
+	Applied          int
+	FinishedAt       time.Time
+}
+
+// Repository persists reconciliation results
+// ⭐ SSOT: 교차 소스 대사 결과 저장/조회
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new reconciliation repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// LoadStored returns the stored daily bar and market cap per stock as STORED observations
+func (r *Repository) LoadStored(ctx context.Context, date time.Time, codes []string) ([]Observation, error) {
+	query := `
+		SELECT
+			s.code,
+			dp.open_price::float8, dp.high_price::float8, dp.low_price::float8, dp.close_price::float8,
+			dp.volume::float8,
+			mc.market_cap::float8
+		FROM data.stocks s
+		LEFT JOIN data.daily_prices dp ON dp.stock_code = s.code AND dp.trade_date = $1::date
+		LEFT JOIN data.market_cap mc ON mc.stock_code = s.code AND mc.trade_date = $1::date
+		WHERE s.status = 'active'
+		  AND (dp.stock_code IS NOT NULL OR mc.stock_code IS NOT NULL)
+		  AND (cardinality($2::text[]) = 0 OR s.code = ANY($2))
+		ORDER BY s.code
+	`
+	if codes == nil {
+		codes = []string{}
+	}
+
+	rows, err := r.pool.Query(ctx, query, date, codes)
+	if err != nil {
+		return nil, fmt.Errorf("query stored values: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]Observation, 0)
+	for rows.Next() {
+		var code string
+		var open, high, low, closePrice, volume, marketCap *float64
+		if err := rows.Scan(&code, &open, &high, &low, &closePrice, &volume, &marketCap); err != nil {
+			return nil, fmt.Errorf("scan stored values: %w", err)
+		}
+
+		values := make(map[Field]float64)
+		for f, v := range map[Field]*float64{
+			FieldOpen: open, FieldHigh: high, FieldLow: low, FieldClose: closePrice,
+			FieldVolume: volume, FieldMarketCap: marketCap,
+		} {
+			if v != nil {
+				values[f] = *v
+			}
+		}
+		result = append(result, Observation{Source: SourceStored, Code: code, Values: values})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stored values: %w", err)
+	}
+
+	return result, nil
+}
+
+// SaveRun replaces the results for the run date
+func (r *Repository) SaveRun(ctx context.Context, run *Run, discrepancies []Discrepancy) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO audit.reconciliation_runs (
+			recon_date, sources, priority, stocks_compared, sampled_stocks,
+			discrepancies, stored_mismatches, applied, finished_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (recon_date) DO UPDATE SET
+			sources = EXCLUDED.sources,
+			priority = EXCLUDED.priority,
+			stocks_compared = EXCLUDED.stocks_compared,
+			sampled_stocks = EXCLUDED.sampled_stocks,
+			discrepancies = EXCLUDED.discrepancies,
+			stored_mismatches = EXCLUDED.stored_mismatches,
+			applied = EXCLUDED.applied,
+			finished_at = NOW()
+	`, run.Date, sourceStrings(run.Sources), sourceStrings(run.Priority), run.StocksCompared, run.SampledStocks,
+		run.Discrepancies, run.StoredMismatches, run.Applied)
+	if err != nil {
+		return fmt.Errorf("save reconciliation run: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM audit.source_discrepancies WHERE recon_date = $1`, run.Date); err != nil {
+		return fmt.Errorf("clear discrepancies: %w", err)
+	}
+
+	if len(discrepancies) > 0 {
+		batch := &pgx.Batch{}
+		for _, d := range discrepancies {
+			values, err := json.Marshal(d.Values)
+			if err != nil {
+				return fmt.Errorf("marshal source values: %w", err)
+			}
+			batch.Queue(`
+				INSERT INTO audit.source_discrepancies (
+					recon_date, stock_code, field, source_values, winner, winner_value,
+					max_diff_pct, stored_mismatch, applied
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			`, run.Date, d.Code, string(d.Field), values, string(d.Winner), d.WinnerValue,
+				d.MaxDiffPct, d.StoredMismatch, d.Applied)
+		}
+
+		br := tx.SendBatch(ctx, batch)
+		for range discrepancies {
+			if _, err := br.Exec(); err != nil {
+				br.Close()
+				return fmt.Errorf("insert discrepancy: %w", err)
+			}
+		}
+		if err := br.Close(); err != nil {
+			return fmt.Errorf("close discrepancy batch: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ApplyWinners overwrites stored values that disagree with the winner
+// 교정된 항목은 Applied=true로 표시
+func (r *Repository) ApplyWinners(ctx context.Context, discrepancies []Discrepancy) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	applied := 0
+	for i := range discrepancies {
+		d := &discrepancies[i]
+		col, ok := storedColumns[d.Field]
+		if !ok || !d.StoredMismatch {
+			continue
+		}
+		query := fmt.Sprintf(`UPDATE %s SET %s = $3 WHERE stock_code = $1 AND trade_date = $2::date`, col.table, col.column)
+		if _, err := tx.Exec(ctx, query, d.Code, d.Date, int64(d.WinnerValue)); err != nil {
+			return 0, fmt.Errorf("apply %s %s: %w", d.Code, d.Field, err)
+		}
+		d.Applied = true
+		applied++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return applied, nil
+}
+
+// GetRun returns the run summary for a date (nil if not reconciled)
+func (r *Repository) GetRun(ctx context.Context, date time.Time) (*Run, error) {
+	query := `
+		SELECT recon_date, sources, priority, stocks_compared, sampled_stocks,
+		       discrepancies, stored_mismatches, applied, finished_at
+		FROM audit.reconciliation_runs
+		WHERE recon_date = $1::date
+	`
+
+	var run Run
+	var sources, priority []string
+	err := r.pool.QueryRow(ctx, query, date).Scan(
+		&run.Date, &sources, &priority, &run.StocksCompared, &run.SampledStocks,
+		&run.Discrepancies, &run.StoredMismatches, &run.Applied, &run.FinishedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get reconciliation run: %w", err)
+	}
+	run.Sources = toSources(sources)
+	run.Priority = toSources(priority)
+	return &run, nil
+}
+
+// ListDiscrepancies returns discrepancies for a date, optionally filtered by field
+func (r *Repository) ListDiscrepancies(ctx context.Context, date time.Time, field string) ([]Discrepancy, error) {
+	query := `
+		SELECT recon_date, stock_code, field, source_values, winner, winner_value::float8,
+		       max_diff_pct::float8, stored_mismatch, applied
+		FROM audit.source_discrepancies
+		WHERE recon_date = $1::date AND ($2 = '' OR field = $2)
+		ORDER BY max_diff_pct DESC, stock_code, field
+	`
+
+	rows, err := r.pool.Query(ctx, query, date, field)
+	if err != nil {
+		return nil, fmt.Errorf("query discrepancies: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]Discrepancy, 0)
+	for rows.Next() {
+		var d Discrepancy
+		var fieldName, winner string
+		var values []byte
+		if err := rows.Scan(&d.Date, &d.Code, &fieldName, &values, &winner, &d.WinnerValue,
+			&d.MaxDiffPct, &d.StoredMismatch, &d.Applied); err != nil {
+			return nil, fmt.Errorf("scan discrepancy: %w", err)
+		}
+		if err := json.Unmarshal(values, &d.Values); err != nil {
+			return nil, fmt.Errorf("unmarshal source values: %w", err)
+		}
+		d.Field = Field(fieldName)
+		d.Winner = Source(winner)
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate discrepancies: %w", err)
+	}
+
+	return result, nil
+}
+
+func sourceStrings(sources []Source) []string {
+	out := make([]string, len(sources))
+	for i, s := range sources {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func toSources(ss []string) []Source {
+	out := make([]Source, len(ss))
+	for i, s := range ss {
+		out[i] = Source(s)
+	}
+	return out
+}
@@ -189,6 +189,23 @@ func (r *Repository) GetActiveStocks(ctx context.Context) ([]Stock, error) {
 	return stocks, nil
 }
 
+// GetStockStatus retrieves listing status and scheduled delisting date of a stock
+func (r *Repository) GetStockStatus(ctx context.Context, code string) (string, *time.Time, error) {
+	query := `
+		SELECT COALESCE(status, 'active'), delisting_date
+		FROM data.stocks
+		WHERE code = $1
+	`
+
+	var status string
+	var delistingDate *time.Time
+	if err := r.db.QueryRow(ctx, query, code).Scan(&status, &delistingDate); err != nil {
+		return "", nil, fmt.Errorf("query stock status: %w", err)
+	}
+
+	return status, delistingDate, nil
+}
+
 // SavePrices saves price data to the database (bulk upsert)
 func (r *Repository) SavePrices(ctx context.Context, prices []naver.PriceData) error {
 	if len(prices) == 0 {
@@ -0,0 +1,96 @@
+package s0_data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+// SpreadRepository handles daily bid-ask spread statistics
+// ⭐ SSOT: data.daily_spread_stats 접근은 여기서만
+type SpreadRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSpreadRepository creates a new spread repository
+func NewSpreadRepository(pool *pgxpool.Pool) *SpreadRepository {
+	return &SpreadRepository{pool: pool}
+}
+
+// SaveSpreadStats upserts daily spread statistics
+func (r *SpreadRepository) SaveSpreadStats(ctx context.Context, stats []contracts.SpreadStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO data.daily_spread_stats (
+			stock_code, trade_date, avg_spread_pct, min_spread_pct, max_spread_pct, sample_count,
+			avg_bid1_qty, avg_ask1_qty, avg_total_bid_qty, avg_total_ask_qty
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (stock_code, trade_date) DO UPDATE SET
+			avg_spread_pct = EXCLUDED.avg_spread_pct,
+			min_spread_pct = EXCLUDED.min_spread_pct,
+			max_spread_pct = EXCLUDED.max_spread_pct,
+			sample_count = EXCLUDED.sample_count,
+			avg_bid1_qty = EXCLUDED.avg_bid1_qty,
+			avg_ask1_qty = EXCLUDED.avg_ask1_qty,
+			avg_total_bid_qty = EXCLUDED.avg_total_bid_qty,
+			avg_total_ask_qty = EXCLUDED.avg_total_ask_qty,
+			updated_at = NOW()`
+
+	for _, s := range stats {
+		batch.Queue(query, s.Code, s.Date.Format("2006-01-02"),
+			s.AvgSpreadPct, s.MinSpreadPct, s.MaxSpreadPct, s.SampleCount,
+			s.AvgBid1Qty, s.AvgAsk1Qty, s.AvgTotalBidQty, s.AvgTotalAskQty)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for _, s := range stats {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("upsert spread stat %s: %w", s.Code, err)
+		}
+	}
+
+	return nil
+}
+
+// GetAvgSpreads returns the sample-weighted average spread per stock
+// over the last `days` calendar days up to asOf (inclusive)
+func (r *SpreadRepository) GetAvgSpreads(ctx context.Context, asOf time.Time, days int) (map[string]float64, error) {
+	query := `
+		SELECT stock_code,
+		       SUM(avg_spread_pct * sample_count) / NULLIF(SUM(sample_count), 0)
+		FROM data.daily_spread_stats
+		WHERE trade_date BETWEEN ($1::date - $2::int) AND $1::date
+		GROUP BY stock_code
+	`
+
+	rows, err := r.pool.Query(ctx, query, asOf, days)
+	if err != nil {
+		return nil, fmt.Errorf("query spread stats: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]float64)
+	for rows.Next() {
+		var code string
+		var spread *float64
+		if err := rows.Scan(&code, &spread); err != nil {
+			return nil, fmt.Errorf("scan spread stat: %w", err)
+		}
+		if spread != nil {
+			result[code] = *spread
+		}
+	}
+
+	return result, rows.Err()
+}
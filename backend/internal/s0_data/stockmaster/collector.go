@@ -0,0 +1,178 @@
+package stockmaster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// kisRequestInterval throttles KIS 현재가 calls (초당 20건 한도)
+const kisRequestInterval = 60 * time.Millisecond
+
+// markets covered by the stock master
+var markets = []string{"KOSPI", "KOSDAQ"}
+
+// SyncResult summarizes a master sync run
+type SyncResult struct {
+	Listed   int   `json:"listed"`
+	Delisted int64 `json:"delisted"`
+}
+
+// StatusResult summarizes a status collection run
+type StatusResult struct {
+	Stocks  int            `json:"stocks"`
+	Flagged map[string]int `json:"flagged"` // 플래그별 종목 수
+	Errors  []string       `json:"errors,omitempty"`
+}
+
+// Collector syncs the KRX stock master and daily designation flags
+// ⭐ SSOT: 종목 마스터/시장조치 플래그 수집은 이 Collector에서만
+type Collector struct {
+	krx    *krx.Client
+	kis    *kis.Client // nil이면 KRX 시세 기반 플래그만 수집
+	repo   *Repository
+	logger *logger.Logger
+}
+
+// NewCollector creates a new stock master collector
+func NewCollector(krxClient *krx.Client, kisClient *kis.Client, repo *Repository, log *logger.Logger) *Collector {
+	return &Collector{
+		krx:    krxClient,
+		kis:    kisClient,
+		repo:   repo,
+		logger: log.WithField("module", "stockmaster"),
+	}
+}
+
+// SyncMaster refreshes data.stocks from the KRX 전종목 기본정보
+// 두 시장 모두 조회에 성공한 경우에만 누락 종목을 상장폐지 처리
+func (c *Collector) SyncMaster(ctx context.Context, date time.Time) (*SyncResult, error) {
+	result := &SyncResult{}
+	listed := make([]string, 0)
+
+	for _, market := range markets {
+		items, err := c.krx.FetchStockMaster(ctx, market, date)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %s master: %w", market, err)
+		}
+		if len(items) == 0 {
+			return nil, fmt.Errorf("empty %s master", market)
+		}
+		if err := c.repo.UpsertStocks(ctx, items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			listed = append(listed, item.Code)
+		}
+	}
+	result.Listed = len(listed)
+
+	delisted, err := c.repo.MarkDelisted(ctx, markets, listed, date)
+	if err != nil {
+		return nil, err
+	}
+	result.Delisted = delisted
+
+	c.logger.WithFields(map[string]interface{}{
+		"date":     date.Format("2006-01-02"),
+		"listed":   result.Listed,
+		"delisted": result.Delisted,
+	}).Info("Stock master synced")
+
+	return result, nil
+}
+
+// CollectStatuses derives and stores each active stock's flags on a date
+// withKIS=true면 종목별 KIS 현재가 조회로 투자주의/경고/위험까지 보강
+func (c *Collector) CollectStatuses(ctx context.Context, date time.Time, withKIS bool) (*StatusResult, error) {
+	krxItems := make(map[string]*krx.StockStatusItem)
+	for _, market := range markets {
+		items, err := c.krx.FetchStockStatuses(ctx, market, date)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %s statuses: %w", market, err)
+		}
+		for i := range items {
+			krxItems[items[i].Code] = &items[i]
+		}
+	}
+	if len(krxItems) == 0 {
+		// 휴장일: 전종목 시세가 비어 있으면 저장하지 않음 (이전 영업일 이력이 유지됨)
+		c.logger.WithField("date", date.Format("2006-01-02")).Warn("No KRX snapshot for date, skipping status collection")
+		return &StatusResult{Flagged: map[string]int{}}, nil
+	}
+
+	codes, err := c.repo.GetActiveCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	useKIS := withKIS && c.kis != nil
+	result := &StatusResult{Flagged: make(map[string]int)}
+	statuses := make([]Status, 0, len(codes))
+
+	for _, code := range codes {
+		krxItem := krxItems[code]
+		sources := make([]string, 0, 2)
+		if krxItem != nil {
+			sources = append(sources, SourceKRX)
+		}
+
+		var kisStatus *kis.StockStatus
+		if useKIS {
+			if err := wait(ctx); err != nil {
+				return nil, err
+			}
+			kisStatus, err = c.kis.GetStockStatus(ctx, code)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", code, err))
+				kisStatus = nil
+			} else {
+				sources = append(sources, SourceKIS)
+			}
+		}
+
+		// 어느 소스에도 없으면 판정 보류 (이력 미기록)
+		if len(sources) == 0 {
+			continue
+		}
+
+		flags := DeriveFlags(krxItem, kisStatus)
+		for _, f := range flags {
+			result.Flagged[string(f)]++
+		}
+		statuses = append(statuses, Status{
+			Code:    code,
+			Date:    date,
+			Flags:   flags,
+			Sources: sources,
+		})
+	}
+
+	if err := c.repo.SaveStatuses(ctx, statuses); err != nil {
+		return nil, err
+	}
+	result.Stocks = len(statuses)
+
+	c.logger.WithFields(map[string]interface{}{
+		"date":    date.Format("2006-01-02"),
+		"stocks":  result.Stocks,
+		"flagged": result.Flagged,
+		"kis":     useKIS,
+		"errors":  len(result.Errors),
+	}).Info("Stock statuses collected")
+
+	return result, nil
+}
+
+func wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(kisRequestInterval):
+		return nil
+	}
+}
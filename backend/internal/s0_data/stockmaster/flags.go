@@ -0,0 +1,97 @@
+package stockmaster
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+)
+
+// Source identifies where a status row came from
+const (
+	SourceKRX = "KRX"
+	SourceKIS = "KIS"
+)
+
+// Status is one stock's KRX designations on a date (data.stock_status_history 1행)
+type Status struct {
+	Code    string
+	Date    time.Time
+	Flags   []contracts.KRXFlag
+	Sources []string
+}
+
+// HasFlag reports whether the status carries the given designation
+func (s Status) HasFlag(flag contracts.KRXFlag) bool {
+	for _, f := range s.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// DeriveFlags maps raw KRX/KIS status fields to contracts.KRXFlag
+// ⭐ SSOT: 원천 상태코드 → KRXFlag 매핑은 여기서만
+//
+// krxItem: 전종목 시세 (시가/거래량 0 → 거래정지, 소속부 → 관리/투자주의환기)
+// kisStatus: 현재가 조회 종목상태/시장경고 코드 (nil이면 KRX만 사용)
+func DeriveFlags(krxItem *krx.StockStatusItem, kisStatus *kis.StockStatus) []contracts.KRXFlag {
+	set := make(map[contracts.KRXFlag]bool)
+
+	if krxItem != nil {
+		if krxItem.NoTrade() {
+			set[contracts.KRXFlagTradingHalt] = true
+		}
+		if strings.Contains(krxItem.Section, "관리종목") {
+			set[contracts.KRXFlagAdminIssue] = true
+		}
+		if strings.Contains(krxItem.Section, "투자주의환기") {
+			set[contracts.KRXFlagManagement] = true
+		}
+	}
+
+	if kisStatus != nil {
+		switch kisStatus.StatusCode {
+		case kis.StatusAdmin:
+			set[contracts.KRXFlagAdminIssue] = true
+		case kis.StatusDanger:
+			set[contracts.KRXFlagInvestmentDanger] = true
+		case kis.StatusWarning:
+			set[contracts.KRXFlagInvestmentWarning] = true
+		case kis.StatusCaution:
+			set[contracts.KRXFlagInvestmentCaution] = true
+		case kis.StatusTradingHalt:
+			set[contracts.KRXFlagTradingHalt] = true
+		}
+
+		switch kisStatus.MarketWarning {
+		case kis.MarketWarnCaution:
+			set[contracts.KRXFlagInvestmentCaution] = true
+		case kis.MarketWarnWarning:
+			set[contracts.KRXFlagInvestmentWarning] = true
+		case kis.MarketWarnDanger:
+			set[contracts.KRXFlagInvestmentDanger] = true
+		}
+
+		if kisStatus.AdminIssue {
+			set[contracts.KRXFlagAdminIssue] = true
+		}
+		if kisStatus.TempStop {
+			set[contracts.KRXFlagTradingHalt] = true
+		}
+		if kisStatus.Caution {
+			set[contracts.KRXFlagManagement] = true
+		}
+	}
+
+	flags := make([]contracts.KRXFlag, 0, len(set))
+	for f := range set {
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i] < flags[j] })
+	return flags
+}
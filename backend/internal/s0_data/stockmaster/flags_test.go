@@ -0,0 +1,78 @@
+package stockmaster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/external/kis"
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+)
+
+func TestDeriveFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		krxItem  *krx.StockStatusItem
+		kis      *kis.StockStatus
+		expected []contracts.KRXFlag
+	}{
+		{
+			name:     "normal trading",
+			krxItem:  &krx.StockStatusItem{Code: "005930", Section: "", Open: 70000, Volume: 1000},
+			expected: []contracts.KRXFlag{},
+		},
+		{
+			name:     "no trade means halt",
+			krxItem:  &krx.StockStatusItem{Code: "000001", Open: 0, Volume: 0},
+			expected: []contracts.KRXFlag{contracts.KRXFlagTradingHalt},
+		},
+		{
+			name:    "kosdaq admin section",
+			krxItem: &krx.StockStatusItem{Code: "000002", Section: "관리종목(소속부없음)", Open: 1000, Volume: 10},
+			expected: []contracts.KRXFlag{
+				contracts.KRXFlagAdminIssue,
+			},
+		},
+		{
+			name:     "kosdaq attention section",
+			krxItem:  &krx.StockStatusItem{Code: "000003", Section: "투자주의환기종목(소속부없음)", Open: 1000, Volume: 10},
+			expected: []contracts.KRXFlag{contracts.KRXFlagManagement},
+		},
+		{
+			name: "kis warning and temp stop",
+			kis:  &kis.StockStatus{StatusCode: kis.StatusWarning, MarketWarning: kis.MarketWarnWarning, TempStop: true},
+			expected: []contracts.KRXFlag{
+				contracts.KRXFlagInvestmentWarning,
+				contracts.KRXFlagTradingHalt,
+			},
+		},
+		{
+			name:    "merged sources are deduplicated and sorted",
+			krxItem: &krx.StockStatusItem{Code: "000004", Section: "관리종목(소속부없음)", Open: 0, Volume: 0},
+			kis:     &kis.StockStatus{StatusCode: kis.StatusAdmin, MarketWarning: kis.MarketWarnDanger, AdminIssue: true, Caution: true},
+			expected: []contracts.KRXFlag{
+				contracts.KRXFlagAdminIssue,
+				contracts.KRXFlagInvestmentDanger,
+				contracts.KRXFlagManagement,
+				contracts.KRXFlagTradingHalt,
+			},
+		},
+		{
+			name:     "no sources",
+			expected: []contracts.KRXFlag{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DeriveFlags(tt.krxItem, tt.kis))
+		})
+	}
+}
+
+func TestStatus_HasFlag(t *testing.T) {
+	s := Status{Flags: []contracts.KRXFlag{contracts.KRXFlagAdminIssue}}
+	assert.True(t, s.HasFlag(contracts.KRXFlagAdminIssue))
+	assert.False(t, s.HasFlag(contracts.KRXFlagTradingHalt))
+}
@@ -0,0 +1,160 @@
+package stockmaster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+	"github.com/wonny/aegis/v13/backend/internal/external/krx"
+)
+
+// Repository handles the stock master and daily status history
+// ⭐ SSOT: data.stocks 마스터 동기화 및 data.stock_status_history 접근은 여기서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new stock master repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// UpsertStocks inserts or refreshes listed securities (재상장 종목은 active로 복귀)
+func (r *Repository) UpsertStocks(ctx context.Context, items []krx.StockMasterItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO data.stocks (
+			code, name, market, sector, listing_date,
+			security_group, krx_section, stock_type, status, updated_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), 'active', NOW())
+		ON CONFLICT (code) DO UPDATE SET
+			name = EXCLUDED.name,
+			market = EXCLUDED.market,
+			sector = COALESCE(EXCLUDED.sector, data.stocks.sector),
+			listing_date = EXCLUDED.listing_date,
+			security_group = EXCLUDED.security_group,
+			krx_section = EXCLUDED.krx_section,
+			stock_type = EXCLUDED.stock_type,
+			status = 'active',
+			delisting_date = NULL,
+			updated_at = NOW()`
+
+	for _, item := range items {
+		batch.Queue(query,
+			item.Code, item.Name, item.Market, item.Sector, item.ListingDate,
+			item.SecurityGroup, item.Section, item.StockType,
+		)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for _, item := range items {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("upsert stock %s: %w", item.Code, err)
+		}
+	}
+	return nil
+}
+
+// MarkDelisted marks active stocks of the given markets missing from the master as delisted
+// listed: 마스터에 존재하는 종목코드 전체, 반환값은 상장폐지 처리된 종목 수
+func (r *Repository) MarkDelisted(ctx context.Context, markets []string, listed []string, date time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE data.stocks
+		SET status = 'delisted', delisting_date = $3, updated_at = NOW()
+		WHERE status = 'active'
+		  AND market = ANY($1)
+		  AND NOT (code = ANY($2))
+	`, markets, listed, date)
+	if err != nil {
+		return 0, fmt.Errorf("mark delisted: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetActiveCodes returns all active stock codes
+func (r *Repository) GetActiveCodes(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT code FROM data.stocks WHERE status = 'active' ORDER BY code`)
+	if err != nil {
+		return nil, fmt.Errorf("query active stocks: %w", err)
+	}
+	defer rows.Close()
+
+	codes := make([]string, 0)
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, fmt.Errorf("scan stock code: %w", err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+// SaveStatuses upserts daily status rows (플래그 없는 종목도 빈 배열로 저장)
+func (r *Repository) SaveStatuses(ctx context.Context, statuses []Status) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO data.stock_status_history (stock_code, status_date, flags, sources)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (stock_code, status_date) DO UPDATE SET
+			flags = EXCLUDED.flags,
+			sources = EXCLUDED.sources`
+
+	for _, s := range statuses {
+		flags := make([]string, len(s.Flags))
+		for i, f := range s.Flags {
+			flags[i] = string(f)
+		}
+		batch.Queue(query, s.Code, s.Date, flags, s.Sources)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for _, s := range statuses {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("save status %s %s: %w", s.Code, s.Date.Format("2006-01-02"), err)
+		}
+	}
+	return nil
+}
+
+// GetLatestFlags returns a stock's designations from the latest status row within maxAgeDays of asOf
+// 기간 내 상태 행이 없으면 nil (판정 불가 → 호출측에서 무시)
+func (r *Repository) GetLatestFlags(ctx context.Context, code string, asOf time.Time, maxAgeDays int) ([]contracts.KRXFlag, error) {
+	query := `
+		SELECT flags FROM data.stock_status_history
+		WHERE stock_code = $1
+		  AND status_date BETWEEN ($2::date - $3::int) AND $2
+		ORDER BY status_date DESC LIMIT 1
+	`
+
+	var raw []string
+	err := r.pool.QueryRow(ctx, query, code, asOf, maxAgeDays).Scan(&raw)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query status %s: %w", code, err)
+	}
+
+	flags := make([]contracts.KRXFlag, 0, len(raw))
+	for _, f := range raw {
+		flags = append(flags, contracts.KRXFlag(f))
+	}
+	return flags, nil
+}
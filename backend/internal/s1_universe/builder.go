@@ -30,6 +30,15 @@ type Config struct {
 	ExcludeHalt    bool     `yaml:"exclude_halt"`      // 거래정지 제외
 	ExcludeSPAC    bool     `yaml:"exclude_spac"`      // SPAC 제외
 	ExcludeSectors []string `yaml:"exclude_sectors"`   // 제외 섹터
+
+	// KRX 시장조치 플래그 필터 (universe.exclude_krx_flags)
+	// data.stock_status_history 기준일 이전 최신 이력 사용, 이력 없는 종목은 종목명 휴리스틱으로 대체
+	ExcludeKRXFlags []string `yaml:"exclude_krx_flags"` // 제외 플래그 (contracts.KRXFlag)
+
+	// 호가 스프레드 필터 (universe.filters.spread.max_pct)
+	// data.daily_spread_stats 최근 N일 표본가중 평균 기준, 통계 없는 종목은 통과
+	MaxSpreadPct       float64 `yaml:"max_spread_pct"`       // 최대 스프레드 비율 (0.006 = 0.6%, 0 = 비활성)
+	SpreadLookbackDays int     `yaml:"spread_lookback_days"` // 평균 기간 (일, 기본 5)
 }
 
 // Stock represents a stock with filter criteria
@@ -45,8 +54,14 @@ type Stock struct {
 	IsAdmin      bool   // 관리종목 여부
 	IsHalted     bool   // 거래정지 여부
 	IsSPAC       bool   // SPAC 여부
+	SpreadPct    float64 // 평균 호가 스프레드 비율 (0 = 통계 없음)
+	Flags        []string // KRX 시장조치 플래그 (기준일 시점)
+	HasStatus    bool     // 상태 이력 존재 여부
 }
 
+// statusStaleDays bounds how old a status row may be before it is ignored
+const statusStaleDays = 7
+
 // NewBuilder creates a new Universe Builder
 func NewBuilder(db *pgxpool.Pool, config Config) *Builder {
 	return &Builder{
@@ -75,8 +90,12 @@ func (b *Builder) Build(ctx context.Context, snapshot *contracts.DataQualitySnap
 		return nil, fmt.Errorf("get all stocks: %w", err)
 	}
 
-	// 필터링
+	// 필터링 (S0 이상치 격리 종목 우선 제외)
 	for _, stock := range stocks {
+		if quarantine := snapshot.QuarantineReason(stock.Code); quarantine != "" {
+			universe.Excluded[stock.Code] = fmt.Sprintf("데이터 격리 (%s)", quarantine)
+			continue
+		}
 		reason := b.checkExclusion(stock)
 		if reason != "" {
 			universe.Excluded[stock.Code] = reason
@@ -89,7 +108,8 @@ func (b *Builder) Build(ctx context.Context, snapshot *contracts.DataQualitySnap
 	return universe, nil
 }
 
-// getAllStocks retrieves all active stocks with necessary data
+// getAllStocks retrieves stocks listed as of date with necessary data
+// 기준일 시점 상장 여부 (listing_date ≤ 기준일 < delisting_date), 현재 status로 과거 유니버스를 판정하지 않음
 func (b *Builder) getAllStocks(ctx context.Context, date time.Time) ([]Stock, error) {
 	// Note: market_cap 데이터는 가장 최근 것을 사용 (시가총액은 매일 크게 변하지 않음)
 	query := `
@@ -101,7 +121,9 @@ func (b *Builder) getAllStocks(ctx context.Context, date time.Time) ([]Stock, er
 			s.listing_date,
 			COALESCE(mc.market_cap, 0),
 			COALESCE(avg_vol.avg_volume, 0),
-			($1::date - s.listing_date) as listing_days
+			($1::date - s.listing_date) as listing_days,
+			COALESCE(spr.avg_spread_pct, 0),
+			st.flags
 		FROM data.stocks s
 		LEFT JOIN LATERAL (
 			SELECT market_cap FROM data.market_cap
@@ -116,11 +138,34 @@ func (b *Builder) getAllStocks(ctx context.Context, date time.Time) ([]Stock, er
 			WHERE trade_date BETWEEN ($1::date - INTERVAL '20 days') AND $1
 			GROUP BY stock_code
 		) avg_vol ON s.code = avg_vol.stock_code
-		WHERE s.status = 'active'
+		LEFT JOIN (
+			SELECT
+				stock_code,
+				(SUM(avg_spread_pct * sample_count) / NULLIF(SUM(sample_count), 0))::float8 as avg_spread_pct
+			FROM data.daily_spread_stats
+			WHERE trade_date BETWEEN ($1::date - $2::int) AND $1
+			GROUP BY stock_code
+		) spr ON s.code = spr.stock_code
+		LEFT JOIN LATERAL (
+			SELECT flags FROM data.stock_status_history
+			WHERE stock_code = s.code
+			  AND status_date BETWEEN ($1::date - $3::int) AND $1
+			ORDER BY status_date DESC LIMIT 1
+		) st ON TRUE
+		WHERE (s.listing_date IS NULL OR s.listing_date <= $1)
+		  AND CASE
+		      WHEN s.delisting_date IS NOT NULL THEN s.delisting_date > $1
+		      ELSE s.status <> 'delisted'
+		  END
 		ORDER BY s.code
 	`
 
-	rows, err := b.db.Query(ctx, query, date)
+	lookback := b.config.SpreadLookbackDays
+	if lookback <= 0 {
+		lookback = 5
+	}
+
+	rows, err := b.db.Query(ctx, query, date, lookback, statusStaleDays)
 	if err != nil {
 		return nil, fmt.Errorf("query stocks: %w", err)
 	}
@@ -129,6 +174,7 @@ func (b *Builder) getAllStocks(ctx context.Context, date time.Time) ([]Stock, er
 	stocks := make([]Stock, 0)
 	for rows.Next() {
 		var stock Stock
+		var flags []string
 		err := rows.Scan(
 			&stock.Code,
 			&stock.Name,
@@ -138,6 +184,8 @@ func (b *Builder) getAllStocks(ctx context.Context, date time.Time) ([]Stock, er
 			&stock.MarketCap,
 			&stock.AvgVolume,
 			&stock.ListingDays,
+			&stock.SpreadPct,
+			&flags,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan stock: %w", err)
@@ -145,8 +193,15 @@ func (b *Builder) getAllStocks(ctx context.Context, date time.Time) ([]Stock, er
 
 		// 상태 플래그 설정
 		stock.IsSPAC = isSPAC(stock.Name)
-		stock.IsAdmin = isAdminStock(stock.Name)
-		stock.IsHalted = false // DB에서 별도 조회 필요 시 확장
+		stock.HasStatus = flags != nil
+		stock.Flags = flags
+		if stock.HasStatus {
+			stock.IsAdmin = stock.hasFlag(string(contracts.KRXFlagAdminIssue))
+			stock.IsHalted = stock.hasFlag(string(contracts.KRXFlagTradingHalt))
+		} else {
+			// 상태 이력 없음: 종목명 휴리스틱 (거래정지는 판정 불가)
+			stock.IsAdmin = isAdminStock(stock.Name)
+		}
 
 		stocks = append(stocks, stock)
 	}
@@ -172,29 +227,41 @@ func (b *Builder) checkExclusion(stock Stock) string {
 		return "관리종목"
 	}
 
-	// 3. SPAC
+	// 3. KRX 시장조치 플래그
+	for _, flag := range b.config.ExcludeKRXFlags {
+		if stock.hasFlag(flag) {
+			return fmt.Sprintf("KRX 플래그 (%s)", flag)
+		}
+	}
+
+	// 4. SPAC
 	if b.config.ExcludeSPAC && stock.IsSPAC {
 		return "SPAC"
 	}
 
-	// 4. 시가총액 미달
+	// 5. 시가총액 미달
 	minMarketCap := b.config.MinMarketCap * 100_000_000 // 억 → 원
 	if stock.MarketCap < minMarketCap {
 		return fmt.Sprintf("시가총액 미달 (%d억)", stock.MarketCap/100_000_000)
 	}
 
-	// 5. 거래대금 미달
+	// 6. 거래대금 미달
 	minVolume := b.config.MinVolume * 1_000_000 // 백만 → 원
 	if stock.AvgVolume < minVolume {
 		return fmt.Sprintf("거래대금 미달 (%d백만)", stock.AvgVolume/1_000_000)
 	}
 
-	// 6. 상장일수 미달
+	// 7. 호가 스프레드 과다 (통계 없는 종목은 판정 보류)
+	if b.config.MaxSpreadPct > 0 && stock.SpreadPct > b.config.MaxSpreadPct {
+		return fmt.Sprintf("스프레드 과다 (%.2f%%)", stock.SpreadPct*100)
+	}
+
+	// 8. 상장일수 미달
 	if stock.ListingDays < b.config.MinListingDays {
 		return fmt.Sprintf("상장일수 미달 (%d일)", stock.ListingDays)
 	}
 
-	// 7. 제외 섹터
+	// 9. 제외 섹터
 	for _, sector := range b.config.ExcludeSectors {
 		if stock.Sector == sector {
 			return fmt.Sprintf("제외 섹터 (%s)", sector)
@@ -204,6 +271,16 @@ func (b *Builder) checkExclusion(stock Stock) string {
 	return "" // 통과
 }
 
+// hasFlag reports whether the stock carries a KRX designation
+func (s Stock) hasFlag(flag string) bool {
+	for _, f := range s.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
 // isSPAC checks if a stock is a SPAC based on name pattern
 func isSPAC(name string) bool {
 	return spacPattern.MatchString(name)
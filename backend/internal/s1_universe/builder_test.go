@@ -92,6 +92,12 @@ func TestBuilder_checkExclusion(t *testing.T) {
 			ExcludeHalt:    true,
 			ExcludeSPAC:    true,
 			ExcludeSectors: []string{"금융"},
+			MaxSpreadPct:   0.006,
+			ExcludeKRXFlags: []string{
+				string(contracts.KRXFlagManagement),
+				string(contracts.KRXFlagInvestmentWarning),
+				string(contracts.KRXFlagInvestmentDanger),
+			},
 		},
 	}
 
@@ -132,6 +138,29 @@ func TestBuilder_checkExclusion(t *testing.T) {
 			},
 			want: "관리종목",
 		},
+		{
+			name: "investment warning flag",
+			stock: Stock{
+				Code:      "999990",
+				MarketCap: 200_000_000_000,
+				AvgVolume: 1_000_000_000,
+				Flags:     []string{string(contracts.KRXFlagInvestmentWarning)},
+				HasStatus: true,
+			},
+			want: "KRX 플래그 (INVESTMENT_WARNING)",
+		},
+		{
+			name: "flag not configured passes",
+			stock: Stock{
+				Code:        "999989",
+				MarketCap:   200_000_000_000,
+				AvgVolume:   1_000_000_000,
+				ListingDays: 100,
+				Flags:       []string{string(contracts.KRXFlagInvestmentCaution)},
+				HasStatus:   true,
+			},
+			want: "",
+		},
 		{
 			name: "SPAC",
 			stock: Stock{
@@ -160,6 +189,28 @@ func TestBuilder_checkExclusion(t *testing.T) {
 			},
 			want: "거래대금 미달 (100백만)",
 		},
+		{
+			name: "wide spread",
+			stock: Stock{
+				Code:        "999992",
+				MarketCap:   200_000_000_000,
+				AvgVolume:   1_000_000_000,
+				ListingDays: 100,
+				SpreadPct:   0.0125,
+			},
+			want: "스프레드 과다 (1.25%)",
+		},
+		{
+			name: "spread within limit",
+			stock: Stock{
+				Code:        "999991",
+				MarketCap:   200_000_000_000,
+				AvgVolume:   1_000_000_000,
+				ListingDays: 100,
+				SpreadPct:   0.004,
+			},
+			want: "",
+		},
 		{
 			name: "newly listed",
 			stock: Stock{
@@ -149,6 +149,7 @@ func (b *Builder) calculateStockSignals(ctx context.Context, code string, date t
 		if err == nil {
 			signals.Quality = score
 			signals.Details.ROE = details.ROE
+			signals.Details.OpMargin = details.OpMargin
 			signals.Details.DebtRatio = details.DebtRatio
 		}
 	}
@@ -224,7 +225,7 @@ func (b *Builder) fetchValueMetrics(ctx context.Context, code string, date time.
 	return metrics, nil
 }
 
-// fetchQualityMetrics fetches quality metrics (ROE, Debt Ratio)
+// fetchQualityMetrics fetches quality metrics (ROE_TTM, OP_MARGIN_TTM, Debt Ratio)
 func (b *Builder) fetchQualityMetrics(ctx context.Context, code string, date time.Time) (QualityMetrics, error) {
 	// Get latest financials before the date
 	financial, err := b.financialRepo.GetLatestByCode(ctx, code, date)
@@ -234,6 +235,7 @@ func (b *Builder) fetchQualityMetrics(ctx context.Context, code string, date tim
 
 	metrics := QualityMetrics{
 		ROE:       financial.ROE,
+		OpMargin:  financial.OpMargin,
 		DebtRatio: financial.DebtRatio,
 	}
 
@@ -284,8 +286,7 @@ func (b *Builder) fetchEvents(ctx context.Context, code string, date time.Time)
 	for _, d := range disclosures {
 		// Map disclosure title to event type and impact
 		// DB category field contains market type (KOSPI, KOSDAQ), so we parse title instead
-		eventType := mapDisclosureToEventType(d.Title)
-		impact := GetEventImpact(eventType)
+		eventType, impact := ClassifyDisclosure(d.Title)
 
 		// Debug: 공시 제목과 매핑된 이벤트 타입 로깅
 		b.logger.WithFields(map[string]interface{}{
@@ -3,6 +3,7 @@ package s2_signals
 import (
 	"context"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/wonny/aegis/v13/backend/internal/contracts"
@@ -171,3 +172,23 @@ func GetEventImpact(eventType EventType) float64 {
 
 	return 0.0 // Default neutral
 }
+
+// ClassifyDisclosure maps a DART disclosure title to event type and impact
+// ⭐ SSOT: 공시 분류는 S2 이벤트 시그널과 청산 규칙(execution)이 공유
+func ClassifyDisclosure(title string) (EventType, float64) {
+	eventType := mapDisclosureToEventType(title)
+	return eventType, GetEventImpact(eventType)
+}
+
+// IsDelistingRiskDisclosure checks if a disclosure signals delisting
+// 상장폐지 사유 발생/상장적격성 실질심사 관련 공시만 (주식분할 등 일반 거래정지는 제외)
+func IsDelistingRiskDisclosure(title string) bool {
+	return containsAny(title, "상장폐지", "상장적격성", "실질심사")
+}
+
+// IsAdminDesignationDisclosure checks if a disclosure designates the stock as 관리종목
+// "관리종목지정", "관리종목 지정(감사의견 거절)" 등 (지정 해제 공시는 제외)
+func IsAdminDesignationDisclosure(title string) bool {
+	compact := strings.ReplaceAll(title, " ", "")
+	return strings.Contains(compact, "관리종목지정") && !strings.Contains(compact, "해제")
+}
@@ -23,7 +23,8 @@ func NewQualityCalculator(log *logger.Logger) *QualityCalculator {
 
 // QualityMetrics represents quality metrics for a stock
 type QualityMetrics struct {
-	ROE       float64 // Return on Equity (%)
+	ROE       float64 // Return on Equity (%, ROE_TTM)
+	OpMargin  float64 // 영업이익률 (%, OP_MARGIN_TTM, 0이면 미산출)
 	DebtRatio float64 // 부채비율 (%)
 }
 
@@ -31,6 +32,7 @@ type QualityMetrics struct {
 func (c *QualityCalculator) Calculate(ctx context.Context, code string, metrics QualityMetrics) (float64, contracts.SignalDetails, error) {
 	details := contracts.SignalDetails{
 		ROE:       metrics.ROE,
+		OpMargin:  metrics.OpMargin,
 		DebtRatio: metrics.DebtRatio,
 	}
 
@@ -40,6 +42,7 @@ func (c *QualityCalculator) Calculate(ctx context.Context, code string, metrics
 	c.logger.WithFields(map[string]interface{}{
 		"code":       code,
 		"roe":        metrics.ROE,
+		"op_margin":  metrics.OpMargin,
 		"debt_ratio": metrics.DebtRatio,
 		"score":      score,
 	}).Debug("Calculated quality signal")
@@ -78,9 +81,17 @@ func (c *QualityCalculator) calculateScore(metrics QualityMetrics) float64 {
 	}
 
 	// Weight the factors
-	// ROE: 60%, DebtRatio: 40%
+	// ROE: 60%, DebtRatio: 40% (영업이익률 미산출 시)
 	score := roeScore*0.6 + debtScore*0.4
 
+	// OP margin score: Higher is better
+	// Normalize: 8% = 0.0, 20% = 1.0, -4% = -1.0
+	// ROE: 45%, OpMargin: 25%, DebtRatio: 30%
+	if metrics.OpMargin != 0 {
+		marginScore := math.Max(-1.0, math.Min(1.0, (metrics.OpMargin-8)/12))
+		score = roeScore*0.45 + marginScore*0.25 + debtScore*0.3
+	}
+
 	// Apply sigmoid to smooth the score
 	score = math.Tanh(score * 1.5)
 
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -20,16 +21,150 @@ type Job interface {
 	Schedule() string
 }
 
+// JobOptions declares how a job is triggered and bounded
+type JobOptions struct {
+	// DependsOn lists upstream jobs. A dependent job has no cron entry of its own:
+	// it runs once all upstreams succeeded on the same day, and is SKIPPED if any failed or was skipped.
+	DependsOn []string
+
+	// TradingDaysOnly skips the job on KRX holidays (수동 실행은 예외)
+	TradingDaysOnly bool
+
+	// NotBefore delays an earlier trigger until this local time ("HH:MM", e.g. meta.decision_time_local)
+	NotBefore string
+
+	// Timeout bounds the whole run including retries (0 = scheduler default)
+	Timeout time.Duration
+}
+
+// TradingCalendar answers whether the market is open on a date
+type TradingCalendar interface {
+	IsTradingDay(ctx context.Context, date time.Time) (bool, error)
+}
+
+// RunStatus is the outcome of a job run
+type RunStatus string
+
+const (
+	RunRunning RunStatus = "RUNNING" // 영속 이력에서만 (실행 중)
+	RunSuccess RunStatus = "SUCCESS"
+	RunFailed  RunStatus = "FAILED"
+	RunSkipped RunStatus = "SKIPPED" // 휴장일 또는 선행 작업 실패/건너뜀
+)
+
+// Triggers
+const (
+	TriggerCron     = "cron"
+	TriggerManual   = "manual"
+	TriggerUpstream = "upstream"
+)
+
 // JobResult represents the result of a job execution
 type JobResult struct {
-	JobName   string        `json:"job_name"`
-	StartTime time.Time     `json:"start_time"`
-	EndTime   time.Time     `json:"end_time"`
-	Duration  time.Duration `json:"duration"`
-	Success   bool          `json:"success"`
-	Error     string        `json:"error,omitempty"`
+	RunID     int64                  `json:"run_id,omitempty"` // ops.scheduler_runs.id (Store 사용 시)
+	JobName   string                 `json:"job_name"`
+	StartTime time.Time              `json:"start_time"`
+	EndTime   time.Time              `json:"end_time"`
+	Duration  time.Duration          `json:"duration"`
+	Success   bool                   `json:"success"`
+	Status    RunStatus              `json:"status"`
+	Trigger   string                 `json:"trigger"`
+	Attempts  int                    `json:"attempts"`
+	Reason    string                 `json:"reason,omitempty"` // SKIPPED 사유
+	Error     string                 `json:"error,omitempty"`
+	Output    map[string]interface{} `json:"output,omitempty"`     // Report로 보고된 결과 요약
+	RequestID int64                  `json:"request_id,omitempty"` // 수동 실행 요청
+}
+
+// Day returns the local date the run belongs to (DAG 판정 기준)
+func (r JobResult) Day() string {
+	return r.StartTime.Local().Format("2006-01-02")
+}
+
+// JobInfo describes a registered job (Store.SyncJobs)
+type JobInfo struct {
+	Name      string
+	Schedule  string
+	DependsOn []string
+	Timeout   time.Duration
+}
+
+// RunRequest is a manual run request from the API or CLI
+type RunRequest struct {
+	ID          int64      `json:"id"`
+	JobName     string     `json:"job_name"`
+	RequestedBy string     `json:"requested_by"`
+	Status      string     `json:"status"` // pending, started, rejected
+	Message     string     `json:"message,omitempty"`
+	RequestedAt time.Time  `json:"requested_at"`
+	ClaimedAt   *time.Time `json:"claimed_at,omitempty"`
+	ClaimedBy   string     `json:"claimed_by,omitempty"`
+}
+
+// Store persists the job registry, run history, pause state and run requests
+// 재시작 후에도 이력/DAG 판정이 유지되고, API/CLI는 리더 스케줄러와 DB로만 통신
+type Store interface {
+	// SyncJobs registers the leader's jobs (paused 상태는 유지)
+	SyncJobs(ctx context.Context, jobs []JobInfo) error
+
+	// AbandonRuns fails RUNNING runs left by a previous leader
+	AbandonRuns(ctx context.Context, reason string) (int64, error)
+
+	// LoadHistory returns the latest finished runs per job, oldest first
+	LoadHistory(ctx context.Context, perJob int) (map[string][]JobResult, error)
+
+	// SaveRun inserts the run (RunID == 0, RunID is set) or updates it
+	SaveRun(ctx context.Context, instanceID string, result *JobResult) error
+
+	// PausedJobs returns the paused job names
+	PausedJobs(ctx context.Context) (map[string]bool, error)
+
+	// ClaimRunRequests marks pending run requests as started and returns them
+	ClaimRunRequests(ctx context.Context, instanceID string) ([]RunRequest, error)
+
+	// RejectRunRequest marks a claimed request as rejected
+	RejectRunRequest(ctx context.Context, id int64, message string) error
 }
 
+// outputKey carries the run output collector in the job context
+type outputKey struct{}
+
+type output struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// WithOutput returns a context collecting Report calls and a function returning the summary
+func WithOutput(ctx context.Context) (context.Context, func() map[string]interface{}) {
+	out := &output{values: make(map[string]interface{})}
+	return context.WithValue(ctx, outputKey{}, out), func() map[string]interface{} {
+		out.mu.Lock()
+		defer out.mu.Unlock()
+		if len(out.values) == 0 {
+			return nil
+		}
+		summary := make(map[string]interface{}, len(out.values))
+		for k, v := range out.values {
+			summary[k] = v
+		}
+		return summary
+	}
+}
+
+// Report records a value in the current run's output summary (실행 컨텍스트 밖에서는 무시)
+func Report(ctx context.Context, key string, value interface{}) {
+	out, ok := ctx.Value(outputKey{}).(*output)
+	if !ok {
+		return
+	}
+	out.mu.Lock()
+	out.values[key] = value
+	out.mu.Unlock()
+}
+
+// historySize is how many results are kept in memory per job (재시작 시 Store에서 같은 수만큼 복원)
+const historySize = 100
+
 // JobHistory stores job execution history
 type JobHistory struct {
 	Results []JobResult
@@ -39,9 +174,9 @@ type JobHistory struct {
 func (h *JobHistory) AddResult(result JobResult) {
 	h.Results = append(h.Results, result)
 
-	// Keep only last 100 results
-	if len(h.Results) > 100 {
-		h.Results = h.Results[len(h.Results)-100:]
+	// Keep only last historySize results
+	if len(h.Results) > historySize {
+		h.Results = h.Results[len(h.Results)-historySize:]
 	}
 }
 
@@ -58,29 +193,53 @@ func (h *JobHistory) GetLatestResults(n int) []JobResult {
 	return h.Results[len(h.Results)-n:]
 }
 
-// GetFailedResults returns all failed results
+// GetFailedResults returns all failed results (skipped runs excluded)
 func (h *JobHistory) GetFailedResults() []JobResult {
 	failed := make([]JobResult, 0)
 	for _, result := range h.Results {
-		if !result.Success {
+		if !result.Success && result.Status != RunSkipped {
 			failed = append(failed, result)
 		}
 	}
 	return failed
 }
 
-// GetSuccessRate returns the success rate (0.0 - 1.0)
-func (h *JobHistory) GetSuccessRate() float64 {
-	if len(h.Results) == 0 {
-		return 0.0
+// GetSkippedResults returns all skipped results
+func (h *JobHistory) GetSkippedResults() []JobResult {
+	skipped := make([]JobResult, 0)
+	for _, result := range h.Results {
+		if result.Status == RunSkipped {
+			skipped = append(skipped, result)
+		}
 	}
+	return skipped
+}
 
+// GetSuccessRate returns the success rate over executed runs (0.0 - 1.0)
+func (h *JobHistory) GetSuccessRate() float64 {
+	executed := 0
 	successCount := 0
 	for _, result := range h.Results {
+		if result.Status == RunSkipped {
+			continue
+		}
+		executed++
 		if result.Success {
 			successCount++
 		}
 	}
 
-	return float64(successCount) / float64(len(h.Results))
+	if executed == 0 {
+		return 0.0
+	}
+	return float64(successCount) / float64(executed)
+}
+
+// Latest returns the most recent result (nil if never run)
+func (h *JobHistory) Latest() *JobResult {
+	if len(h.Results) == 0 {
+		return nil
+	}
+	latest := h.Results[len(h.Results)-1]
+	return &latest
 }
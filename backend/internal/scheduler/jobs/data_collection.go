@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/calendar"
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/collector"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/financials"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/reconcile"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/stockmaster"
 	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
 )
@@ -14,14 +18,18 @@ import (
 // ⭐ SSOT: 데이터 수집 스케줄은 이 Job에서만
 type DataCollectionJob struct {
 	collector *collector.Collector
+	planner   *collector.Planner
+	calendar  *calendar.Syncer
 	config    *config.Config
 	logger    *logger.Logger
 }
 
 // NewDataCollectionJob creates a new data collection job
-func NewDataCollectionJob(col *collector.Collector, cfg *config.Config, log *logger.Logger) *DataCollectionJob {
+func NewDataCollectionJob(col *collector.Collector, planner *collector.Planner, cal *calendar.Syncer, cfg *config.Config, log *logger.Logger) *DataCollectionJob {
 	return &DataCollectionJob{
 		collector: col,
+		planner:   planner,
+		calendar:  cal,
 		config:    cfg,
 		logger:    log,
 	}
@@ -41,21 +49,20 @@ func (j *DataCollectionJob) Schedule() string {
 func (j *DataCollectionJob) Run(ctx context.Context) error {
 	j.logger.Info("Starting scheduled data collection")
 
-	// Calculate date range (last 5 days)
 	to := time.Now()
-	from := to.AddDate(0, 0, -5)
 
-	// 1. Collect prices
-	j.logger.Info("Collecting prices")
-	collectorCfg := collector.Config{Workers: 5}
-	if _, err := j.collector.FetchAllPrices(ctx, from, to, collectorCfg); err != nil {
-		return fmt.Errorf("fetch prices: %w", err)
+	// 0. Refresh recent trading calendar (실패 시 주말·고정 공휴일 규칙으로 진행)
+	if _, err := j.calendar.Sync(ctx, to.AddDate(0, 0, -14), to); err != nil {
+		j.logger.WithError(err).Warn("Failed to sync trading calendar")
 	}
 
-	// 2. Collect investor flow
-	j.logger.Info("Collecting investor flow")
-	if _, err := j.collector.FetchAllInvestorFlow(ctx, from, to, collectorCfg); err != nil {
-		return fmt.Errorf("fetch investor flow: %w", err)
+	// 1-2. Collect prices and investor flow (체크포인트 이후 누락 거래일 + 재시도 대기 구간)
+	j.logger.Info("Collecting prices and investor flow")
+	if _, err := j.planner.Run(ctx, collector.PlanOptions{
+		Datasets: []collector.Dataset{collector.DatasetPrices, collector.DatasetInvestorFlow},
+		Workers:  5,
+	}); err != nil {
+		return fmt.Errorf("planned collection: %w", err)
 	}
 
 	// 3. Collect market caps
@@ -126,17 +133,17 @@ func (j *PriceCollectionJob) Run(ctx context.Context) error {
 
 // InvestorFlowJob collects investor flow data
 type InvestorFlowJob struct {
-	collector *collector.Collector
-	config    *config.Config
-	logger    *logger.Logger
+	planner *collector.Planner
+	config  *config.Config
+	logger  *logger.Logger
 }
 
 // NewInvestorFlowJob creates a new investor flow job
-func NewInvestorFlowJob(col *collector.Collector, cfg *config.Config, log *logger.Logger) *InvestorFlowJob {
+func NewInvestorFlowJob(planner *collector.Planner, cfg *config.Config, log *logger.Logger) *InvestorFlowJob {
 	return &InvestorFlowJob{
-		collector: col,
-		config:    cfg,
-		logger:    log,
+		planner: planner,
+		config:  cfg,
+		logger:  log,
 	}
 }
 
@@ -154,13 +161,12 @@ func (j *InvestorFlowJob) Schedule() string {
 func (j *InvestorFlowJob) Run(ctx context.Context) error {
 	j.logger.Info("Starting scheduled investor flow collection")
 
-	// Collect last 3 days
-	to := time.Now()
-	from := to.AddDate(0, 0, -3)
-
-	collectorCfg := collector.Config{Workers: 5}
-	if _, err := j.collector.FetchAllInvestorFlow(ctx, from, to, collectorCfg); err != nil {
-		return fmt.Errorf("fetch investor flow: %w", err)
+	// 16시 수집 때 미공시였던 당일 수급 등 누락 거래일만 수집
+	if _, err := j.planner.Run(ctx, collector.PlanOptions{
+		Datasets: []collector.Dataset{collector.DatasetInvestorFlow},
+		Workers:  5,
+	}); err != nil {
+		return fmt.Errorf("planned investor flow collection: %w", err)
 	}
 
 	j.logger.Info("Scheduled investor flow collection completed successfully")
@@ -206,3 +212,158 @@ func (j *DisclosureJob) Run(ctx context.Context) error {
 	j.logger.Info("Scheduled disclosure collection completed successfully")
 	return nil
 }
+
+// FinancialStatementJob collects DART periodic reports filed recently
+type FinancialStatementJob struct {
+	collector *financials.Collector
+	logger    *logger.Logger
+}
+
+// NewFinancialStatementJob creates a new financial statement job
+func NewFinancialStatementJob(col *financials.Collector, log *logger.Logger) *FinancialStatementJob {
+	return &FinancialStatementJob{
+		collector: col,
+		logger:    log,
+	}
+}
+
+// Name returns the job name
+func (j *FinancialStatementJob) Name() string {
+	return "financial_statement_collection"
+}
+
+// Schedule returns the cron schedule (every day at 7 PM KST)
+func (j *FinancialStatementJob) Schedule() string {
+	return "0 0 19 * * *" // 7 PM daily (정기보고서 마감일 18시 이후)
+}
+
+// Run executes the financial statement collection
+func (j *FinancialStatementJob) Run(ctx context.Context) error {
+	j.logger.Info("Starting scheduled financial statement collection")
+
+	// Collect last 3 days (주말 제출분 포함)
+	to := time.Now()
+	from := to.AddDate(0, 0, -3)
+
+	result, err := j.collector.CollectFilings(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("collect financial statements: %w", err)
+	}
+
+	j.logger.WithFields(map[string]interface{}{
+		"saved":      result.Saved,
+		"recomputed": result.Recomputed,
+		"errors":     len(result.Errors),
+	}).Info("Scheduled financial statement collection completed")
+	return nil
+}
+
+// StockMasterJob syncs the KRX stock master and today's designation flags
+type StockMasterJob struct {
+	collector *stockmaster.Collector
+	logger    *logger.Logger
+}
+
+// NewStockMasterJob creates a new stock master job
+func NewStockMasterJob(col *stockmaster.Collector, log *logger.Logger) *StockMasterJob {
+	return &StockMasterJob{
+		collector: col,
+		logger:    log,
+	}
+}
+
+// Name returns the job name
+func (j *StockMasterJob) Name() string {
+	return "stock_master_sync"
+}
+
+// Schedule returns the cron schedule (weekdays at 5:30 PM KST)
+func (j *StockMasterJob) Schedule() string {
+	return "0 30 17 * * MON-FRI" // 장 마감 후, universe_generation(18시) 이전
+}
+
+// Run executes the stock master sync and status collection
+func (j *StockMasterJob) Run(ctx context.Context) error {
+	j.logger.Info("Starting scheduled stock master sync")
+
+	today := time.Now()
+
+	master, err := j.collector.SyncMaster(ctx, today)
+	if err != nil {
+		return fmt.Errorf("sync stock master: %w", err)
+	}
+
+	statuses, err := j.collector.CollectStatuses(ctx, today, true)
+	if err != nil {
+		return fmt.Errorf("collect stock statuses: %w", err)
+	}
+
+	j.logger.WithFields(map[string]interface{}{
+		"listed":   master.Listed,
+		"delisted": master.Delisted,
+		"stocks":   statuses.Stocks,
+		"flagged":  statuses.Flagged,
+		"errors":   len(statuses.Errors),
+	}).Info("Scheduled stock master sync completed")
+	return nil
+}
+
+// ReconciliationJob compares stored bars and market caps against Naver/KIS/KRX
+type ReconciliationJob struct {
+	reconciler   *reconcile.Reconciler
+	calendarRepo *calendar.Repository
+	logger       *logger.Logger
+}
+
+// NewReconciliationJob creates a new source reconciliation job
+func NewReconciliationJob(rec *reconcile.Reconciler, calendarRepo *calendar.Repository, log *logger.Logger) *ReconciliationJob {
+	return &ReconciliationJob{
+		reconciler:   rec,
+		calendarRepo: calendarRepo,
+		logger:       log,
+	}
+}
+
+// Name returns the job name
+func (j *ReconciliationJob) Name() string {
+	return "source_reconciliation"
+}
+
+// Schedule returns the nominal schedule (scheduler triggers it after data_collection)
+func (j *ReconciliationJob) Schedule() string {
+	return "0 45 17 * * MON-FRI" // 수집 이후, universe_generation(18시) 이전
+}
+
+// Run reconciles the last closed session with default rules (표본 비교, 저장값 교정 없음)
+func (j *ReconciliationJob) Run(ctx context.Context) error {
+	cal, err := j.calendarRepo.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("load trading calendar: %w", err)
+	}
+	if !cal.IsTradingDay(time.Now()) {
+		j.logger.Info("Market closed today, skipping source reconciliation")
+		return nil
+	}
+
+	run, _, err := j.reconciler.Run(ctx, reconcile.Options{
+		Date:   cal.LastClosedSession(time.Now()),
+		Config: reconcile.DefaultConfig(),
+	})
+	if err != nil {
+		return fmt.Errorf("reconcile sources: %w", err)
+	}
+
+	entry := j.logger.WithFields(map[string]interface{}{
+		"date":              run.Date.Format("2006-01-02"),
+		"stocks":            run.StocksCompared,
+		"sampled":           run.SampledStocks,
+		"discrepancies":     run.Discrepancies,
+		"stored_mismatches": run.StoredMismatches,
+	})
+	if run.StoredMismatches > 0 {
+		entry.Warn("Stored data disagrees with higher-priority sources")
+		return nil
+	}
+	entry.Info("Scheduled source reconciliation completed")
+	return nil
+}
@@ -14,7 +14,7 @@ import (
 )
 
 // ForecastJob runs the forecast pipeline daily
-// Schedule: 6:30 PM (after universe generation at 6 PM)
+// Triggered after data_collection (prices) by the scheduler DAG
 type ForecastJob struct {
 	pool   *pgxpool.Pool
 	logger *logger.Logger
@@ -33,7 +33,7 @@ func (j *ForecastJob) Name() string {
 	return "forecast_pipeline"
 }
 
-// Schedule returns the cron schedule (6:30 PM daily, after universe generation)
+// Schedule returns the nominal schedule (scheduler triggers it after data_collection)
 func (j *ForecastJob) Schedule() string {
 	return "0 30 18 * * *" // 6:30 PM daily (with seconds)
 }
@@ -44,7 +44,7 @@ func (j *ForecastJob) Run(ctx context.Context) error {
 
 	// 저장소 초기화
 	forecastRepo := forecast.NewRepository(j.pool)
-	priceRepo := s0_data.NewPriceRepository(j.pool)
+	priceRepo := s0_data.NewPriceRepository(j.pool, contracts.PriceBasisAdjusted) // 이벤트 감지: 권리락/분할 갭 제외
 
 	// 오늘 날짜만 처리
 	today := time.Now()
@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/brain"
+	"github.com/wonny/aegis/v13/backend/internal/scheduler"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// PipelineJob runs the S0-S7 decision pipeline after the universe is built
+// 주문은 장중 실행 창에서 별도로 처리하므로 실행 계획만 생성 (DryRun)
+type PipelineJob struct {
+	orchestrator   *brain.Orchestrator
+	accountCapital map[string]int64 // 계좌별 자본 (전략 자본 = 계좌 자본 × capital_pct)
+	decisionTime   string           // HH:MM (meta.decision_time_local)
+	gitSHA         string
+	logger         *logger.Logger
+}
+
+// NewPipelineJob creates a new decision pipeline job
+func NewPipelineJob(orchestrator *brain.Orchestrator, accountCapital map[string]int64, decisionTime, gitSHA string, log *logger.Logger) *PipelineJob {
+	return &PipelineJob{
+		orchestrator:   orchestrator,
+		accountCapital: accountCapital,
+		decisionTime:   decisionTime,
+		gitSHA:         gitSHA,
+		logger:         log,
+	}
+}
+
+// Name returns the job name
+func (j *PipelineJob) Name() string {
+	return "selection_pipeline"
+}
+
+// Schedule returns the nominal schedule (triggered after universe_generation, not before decision time)
+func (j *PipelineJob) Schedule() string {
+	t, err := time.Parse("15:04", j.decisionTime)
+	if err != nil {
+		return "" // 잘못된 시각은 NotBefore 검증에서 등록 거부
+	}
+	return fmt.Sprintf("0 %d %d * * MON-FRI", t.Minute(), t.Hour())
+}
+
+// Run executes the decision pipeline for today
+func (j *PipelineJob) Run(ctx context.Context) error {
+	j.logger.Info("Starting scheduled selection pipeline")
+
+	result, err := j.orchestrator.Run(ctx, brain.RunConfig{
+		Date:           time.Now(),
+		RunID:          brain.GenerateRunID(),
+		GitSHA:         j.gitSHA,
+		FeatureVersion: "v1.0.0",
+		AccountCapital: j.accountCapital,
+		DryRun:         true,
+	})
+	if err != nil {
+		return fmt.Errorf("pipeline run: %w", err)
+	}
+
+	j.logger.WithFields(map[string]interface{}{
+		"run_id":   result.RunID,
+		"stages":   len(result.CompletedStages),
+		"ranked":   len(result.RankedStocks),
+		"duration": result.Duration.String(),
+	}).Info("Selection pipeline completed")
+
+	scheduler.Report(ctx, "run_id", result.RunID)
+	scheduler.Report(ctx, "ranked", len(result.RankedStocks))
+
+	return nil
+}
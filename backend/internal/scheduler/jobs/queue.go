@@ -0,0 +1,189 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/queue"
+	"github.com/wonny/aegis/v13/backend/internal/s0_data/collector"
+	"github.com/wonny/aegis/v13/backend/internal/scheduler"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// EnqueuedJob schedules a job by enqueueing it for `quant worker` instead of running it in-process
+// 워커 실행 결과를 기다려 스케줄러의 성공/실패 판정(의존 작업 트리거)에 그대로 반영
+// 같은 작업이 대기/실행 중이면 중복 적재하지 않고 기존 작업을 기다림 (dedup_key = scheduled_job:<name>)
+type EnqueuedJob struct {
+	job    scheduler.Job
+	queue  *queue.Repository
+	logger *logger.Logger
+}
+
+// enqueuedPollInterval is how often the scheduler polls the queued job status
+const enqueuedPollInterval = 5 * time.Second
+
+// NewEnqueuedJob wraps a job so its cron trigger enqueues it
+func NewEnqueuedJob(job scheduler.Job, q *queue.Repository, log *logger.Logger) *EnqueuedJob {
+	return &EnqueuedJob{
+		job:    job,
+		queue:  q,
+		logger: log,
+	}
+}
+
+// Name returns the wrapped job name
+func (j *EnqueuedJob) Name() string {
+	return j.job.Name()
+}
+
+// Schedule returns the wrapped job schedule
+func (j *EnqueuedJob) Schedule() string {
+	return j.job.Schedule()
+}
+
+// Run enqueues the wrapped job and waits for the worker outcome
+// 재시도는 스케줄러가 담당하므로 큐 작업은 1회 시도 (max_attempts = 1)
+func (j *EnqueuedJob) Run(ctx context.Context) error {
+	payload := queue.ScheduledJobPayload{
+		Job:         j.job.Name(),
+		ScheduledAt: time.Now(),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		payload.Deadline = deadline
+	}
+
+	id, created, err := j.queue.Enqueue(ctx, queue.TypeScheduledJob, payload, queue.EnqueueOptions{
+		Priority:    queue.PriorityNormal,
+		MaxAttempts: 1,
+		DedupKey:    queue.TypeScheduledJob + ":" + j.job.Name(),
+	})
+	if err != nil {
+		return fmt.Errorf("enqueue %s: %w", j.job.Name(), err)
+	}
+
+	log := j.logger.WithFields(map[string]interface{}{
+		"job":    j.job.Name(),
+		"job_id": id,
+	})
+	if created {
+		log.Info("Scheduled job enqueued, waiting for worker")
+	} else {
+		log.Warn("Previous run still queued or running, waiting for it")
+	}
+
+	job, err := j.queue.Wait(ctx, id, enqueuedPollInterval)
+	if err != nil {
+		// 기한 초과: 아직 대기 중이면 취소 (실행 중이면 워커가 끝까지 수행)
+		cancelCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if cancelled, cerr := j.queue.Cancel(cancelCtx, id); cerr == nil && cancelled {
+			log.Warn("Queued job cancelled before a worker picked it up")
+		}
+		return fmt.Errorf("wait for queued job %d: %w", id, err)
+	}
+
+	scheduler.Report(ctx, "queue_job_id", id)
+	if job.Status != queue.StatusDone {
+		return fmt.Errorf("queued job %d %s: %s", id, job.Status, job.LastError)
+	}
+
+	// 워커가 보고한 결과 요약을 스케줄러 실행 이력에 반영
+	var output map[string]interface{}
+	if len(job.Result) > 0 && json.Unmarshal(job.Result, &output) == nil {
+		for k, v := range output {
+			scheduler.Report(ctx, k, v)
+		}
+	}
+	return nil
+}
+
+// NewScheduledJobHandler runs scheduler jobs by name on the worker
+// 작업이 Report한 결과 요약을 큐 작업 결과로 저장 (EnqueuedJob이 스케줄러 이력으로 옮김)
+func NewScheduledJobHandler(registry []scheduler.Job) queue.Handler {
+	byName := make(map[string]scheduler.Job, len(registry))
+	for _, job := range registry {
+		byName[job.Name()] = job
+	}
+
+	return queue.Typed(func(ctx context.Context, p queue.ScheduledJobPayload) (interface{}, error) {
+		job, ok := byName[p.Job]
+		if !ok {
+			return nil, queue.Permanent(fmt.Errorf("unknown scheduled job %q", p.Job))
+		}
+		if !p.Deadline.IsZero() {
+			if time.Now().After(p.Deadline) {
+				return nil, queue.Permanent(fmt.Errorf("deadline %s passed before start", p.Deadline.Format(time.RFC3339)))
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, p.Deadline)
+			defer cancel()
+		}
+		ctx, summary := scheduler.WithOutput(ctx)
+		err := job.Run(ctx)
+		return summary(), err
+	})
+}
+
+// NewCollectDataHandler runs API collection requests on the worker
+// 종목별 진행률을 큐 작업 진행률로 보고하고 (GET /api/data/collect/{id}), 최종 진행률을 결과로 저장
+func NewCollectDataHandler(col *collector.Collector, log *logger.Logger) queue.Handler {
+	return queue.Typed(func(ctx context.Context, p queue.CollectDataPayload) (interface{}, error) {
+		from, to, err := p.Range(time.Now())
+		if err != nil {
+			return nil, queue.Permanent(err)
+		}
+
+		log.WithFields(map[string]interface{}{
+			"type": p.Type,
+			"from": from.Format("2006-01-02"),
+			"to":   to.Format("2006-01-02"),
+		}).Info("Collecting data for queued request")
+
+		progress := collector.NewProgress(func(s collector.ProgressSnapshot) {
+			queue.ReportProgress(ctx, s)
+		})
+		cfg := collector.Config{Workers: 5, Progress: progress}
+
+		// bulk runs a whole-market collection as a single progress item
+		bulk := func(name string, fetch func() error) error {
+			progress.Start(name, 1)
+			err := fetch()
+			progress.Record(name, collector.FetchResult{Error: err})
+			return err
+		}
+
+		switch p.Type {
+		case "prices":
+			_, err = col.FetchAllPrices(ctx, from, to, cfg)
+
+		case "investor":
+			_, err = col.FetchAllInvestorFlow(ctx, from, to, cfg)
+
+		case "disclosure":
+			err = bulk("disclosure", func() error { return col.FetchDisclosures(ctx, from, to) })
+
+		case "market_caps":
+			err = bulk("market_caps", func() error { return col.FetchMarketCaps(ctx) })
+
+		default: // all
+			if err = col.FetchAll(ctx, from, to, cfg); err != nil {
+				break
+			}
+
+			// Also collect market caps and disclosures
+			if err := bulk("market_caps", func() error { return col.FetchMarketCaps(ctx) }); err != nil {
+				log.WithError(err).Warn("Failed to collect market caps during 'all'")
+			}
+
+			dartFrom := to.AddDate(0, 0, -7)
+			if err := bulk("disclosure", func() error { return col.FetchDisclosures(ctx, dartFrom, to) }); err != nil {
+				log.WithError(err).Warn("Failed to collect disclosures during 'all'")
+			}
+			err = ctx.Err()
+		}
+
+		return progress.Snapshot(), err
+	})
+}
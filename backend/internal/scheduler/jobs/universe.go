@@ -7,6 +7,7 @@ import (
 
 	"github.com/wonny/aegis/v13/backend/internal/s0_data/quality"
 	"github.com/wonny/aegis/v13/backend/internal/s1_universe"
+	"github.com/wonny/aegis/v13/backend/internal/scheduler"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
 )
 
@@ -32,7 +33,7 @@ func (j *UniverseJob) Name() string {
 	return "universe_generation"
 }
 
-// Schedule returns the cron schedule (every day at 6 PM KST, after data collection)
+// Schedule returns the nominal schedule (scheduler triggers it after data_collection and stock_master_sync)
 func (j *UniverseJob) Schedule() string {
 	return "0 0 18 * * *" // 6 PM daily (with seconds)
 }
@@ -56,6 +57,13 @@ func (j *UniverseJob) Run(ctx context.Context) error {
 		}).Warn("Data quality below threshold, but continuing with universe generation")
 	}
 
+	if len(snapshot.Quarantined) > 0 {
+		j.logger.WithFields(map[string]interface{}{
+			"anomalies":   len(snapshot.Anomalies),
+			"quarantined": len(snapshot.Quarantined),
+		}).Warn("Stocks quarantined by data anomaly checks")
+	}
+
 	// 2. Build universe
 	j.logger.Info("Building universe")
 	universe, err := j.builder.Build(ctx, snapshot)
@@ -69,5 +77,10 @@ func (j *UniverseJob) Run(ctx context.Context) error {
 		"excluded_count": len(universe.Excluded),
 	}).Info("Universe generated successfully")
 
+	scheduler.Report(ctx, "quality_score", snapshot.QualityScore)
+	scheduler.Report(ctx, "quarantined", len(snapshot.Quarantined))
+	scheduler.Report(ctx, "included_count", len(universe.Stocks))
+	scheduler.Report(ctx, "excluded_count", len(universe.Excluded))
+
 	return nil
 }
@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// leaderLockKey is the session advisory lock held by the active scheduler
+const leaderLockKey int64 = 1_300_043
+
+// Leader elects a single scheduler instance with a Postgres advisory lock
+// 락은 전용 연결의 세션에 묶이므로 프로세스/연결이 죽으면 Postgres가 자동 해제하고 대기 중인 인스턴스가 인계
+type Leader struct {
+	pool       *pgxpool.Pool
+	instanceID string
+	logger     *logger.Logger
+
+	retryInterval time.Duration // 락 획득 재시도 간격
+	checkInterval time.Duration // 락 연결 점검 간격
+
+	conn *pgxpool.Conn
+	lost chan struct{}
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewLeader creates a leader elector for a scheduler instance
+func NewLeader(pool *pgxpool.Pool, instanceID string, log *logger.Logger) *Leader {
+	return &Leader{
+		pool:          pool,
+		instanceID:    instanceID,
+		logger:        log,
+		retryInterval: 10 * time.Second,
+		checkInterval: 5 * time.Second,
+		lost:          make(chan struct{}),
+		stop:          make(chan struct{}),
+	}
+}
+
+// TryAcquire attempts the leader lock once
+func (l *Leader) TryAcquire(ctx context.Context) (bool, error) {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, leaderLockKey).Scan(&acquired); err != nil {
+		conn.Release()
+		return false, err
+	}
+	if !acquired {
+		conn.Release()
+		return false, nil
+	}
+
+	l.conn = conn
+	l.wg.Add(1)
+	go l.watch()
+
+	l.logger.WithField("instance", l.instanceID).Info("Scheduler leader lock acquired")
+	return true, nil
+}
+
+// Acquire blocks until this instance holds the leader lock or ctx ends
+func (l *Leader) Acquire(ctx context.Context) error {
+	for {
+		acquired, err := l.TryAcquire(ctx)
+		if err != nil {
+			l.logger.WithError(err).Warn("Failed to try scheduler leader lock")
+		}
+		if acquired {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l.retryInterval):
+		}
+	}
+}
+
+// Lost is closed when the lock connection fails (다른 인스턴스가 인계할 수 있으므로 즉시 중단해야 함)
+func (l *Leader) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// watch pings the lock connection until Release
+func (l *Leader) watch() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), l.checkInterval)
+			_, err := l.conn.Exec(ctx, `SELECT 1`)
+			cancel()
+			if err != nil {
+				l.logger.WithError(err).Error("Scheduler leader lock connection lost")
+				close(l.lost)
+				return
+			}
+		}
+	}
+}
+
+// Release unlocks and returns the lock connection
+func (l *Leader) Release() {
+	if l.conn == nil {
+		return
+	}
+	close(l.stop)
+	l.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 풀로 돌려보내기 전에 반드시 해제 (실패하면 연결을 닫아 세션 락 제거)
+	if _, err := l.conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, leaderLockKey); err != nil {
+		l.logger.WithError(err).Warn("Failed to unlock scheduler leader lock, closing connection")
+		l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+	l.conn = nil
+
+	l.logger.WithField("instance", l.instanceID).Info("Scheduler leader lock released")
+}
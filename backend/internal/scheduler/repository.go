@@ -0,0 +1,476 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrJobNotFound is returned for jobs the leader scheduler has not registered
+var ErrJobNotFound = errors.New("scheduler job not found")
+
+const runColumns = `
+	id, job_name, trigger, status, attempts, started_at, finished_at,
+	duration_ms, error, reason, output, request_id
+`
+
+// JobState is a registered job with its pause state and latest run (API 조회용)
+type JobState struct {
+	JobName        string     `json:"job_name"`
+	Schedule       string     `json:"schedule"`
+	DependsOn      []string   `json:"depends_on,omitempty"`
+	TimeoutSeconds int        `json:"timeout_seconds"`
+	Paused         bool       `json:"paused"`
+	PausedBy       string     `json:"paused_by,omitempty"`
+	PausedAt       *time.Time `json:"paused_at,omitempty"`
+	LastRun        *JobResult `json:"last_run,omitempty"`
+}
+
+// Repository persists scheduler state in the ops schema
+// ⭐ SSOT: 스케줄러 이력/일시정지/실행 요청 저장은 이 Repository에서만
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new scheduler repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// SyncJobs upserts the registered jobs (paused 상태는 유지)
+func (r *Repository) SyncJobs(ctx context.Context, jobs []JobInfo) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO ops.scheduler_jobs (job_name, schedule, depends_on, timeout_seconds)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (job_name) DO UPDATE SET
+			schedule = EXCLUDED.schedule,
+			depends_on = EXCLUDED.depends_on,
+			timeout_seconds = EXCLUDED.timeout_seconds,
+			updated_at = NOW()
+	`
+
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		dependsOn := job.DependsOn
+		if dependsOn == nil {
+			dependsOn = []string{}
+		}
+		if _, err := tx.Exec(ctx, query, job.Name, job.Schedule, dependsOn, int(job.Timeout.Seconds())); err != nil {
+			return fmt.Errorf("register job %s: %w", job.Name, err)
+		}
+		names = append(names, job.Name)
+	}
+
+	// 더 이상 등록되지 않는 작업 제거 (이력은 유지)
+	if _, err := tx.Exec(ctx, `DELETE FROM ops.scheduler_jobs WHERE NOT (job_name = ANY($1))`, names); err != nil {
+		return fmt.Errorf("remove stale jobs: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// AbandonRuns fails RUNNING runs left by a previous leader
+func (r *Repository) AbandonRuns(ctx context.Context, reason string) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE ops.scheduler_runs SET
+			status = 'FAILED',
+			error = $1,
+			finished_at = NOW(),
+			duration_ms = (EXTRACT(EPOCH FROM (NOW() - started_at)) * 1000)::bigint
+		WHERE status = 'RUNNING'
+	`, reason)
+	if err != nil {
+		return 0, fmt.Errorf("abandon runs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// LoadHistory returns the latest finished runs per job, oldest first
+func (r *Repository) LoadHistory(ctx context.Context, perJob int) (map[string][]JobResult, error) {
+	query := `
+		SELECT ` + runColumns + `
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY job_name ORDER BY started_at DESC, id DESC) AS rn
+			FROM ops.scheduler_runs
+			WHERE status <> 'RUNNING'
+		) runs
+		WHERE rn <= $1
+		ORDER BY job_name, started_at, id
+	`
+
+	rows, err := r.pool.Query(ctx, query, perJob)
+	if err != nil {
+		return nil, fmt.Errorf("query job history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[string][]JobResult)
+	for rows.Next() {
+		result, err := scanRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan job run: %w", err)
+		}
+		history[result.JobName] = append(history[result.JobName], *result)
+	}
+	return history, rows.Err()
+}
+
+// SaveRun inserts a run (RunID == 0) or updates it
+func (r *Repository) SaveRun(ctx context.Context, instanceID string, result *JobResult) error {
+	var output []byte
+	if len(result.Output) > 0 {
+		var err error
+		if output, err = json.Marshal(result.Output); err != nil {
+			return fmt.Errorf("marshal %s output: %w", result.JobName, err)
+		}
+	}
+
+	var finishedAt *time.Time
+	var durationMs *int64
+	if result.Status != RunRunning {
+		finishedAt = &result.EndTime
+		ms := result.Duration.Milliseconds()
+		durationMs = &ms
+	}
+
+	if result.RunID == 0 {
+		err := r.pool.QueryRow(ctx, `
+			INSERT INTO ops.scheduler_runs (
+				job_name, trigger, status, attempts, started_at, finished_at,
+				duration_ms, error, reason, output, instance_id, request_id
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), $10, $11, NULLIF($12, 0))
+			RETURNING id
+		`, result.JobName, result.Trigger, string(result.Status), result.Attempts, result.StartTime, finishedAt,
+			durationMs, result.Error, result.Reason, output, instanceID, result.RequestID).Scan(&result.RunID)
+		if err != nil {
+			return fmt.Errorf("insert %s run: %w", result.JobName, err)
+		}
+		return nil
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.scheduler_runs SET
+			status = $2,
+			attempts = $3,
+			finished_at = $4,
+			duration_ms = $5,
+			error = NULLIF($6, ''),
+			reason = NULLIF($7, ''),
+			output = $8
+		WHERE id = $1
+	`, result.RunID, string(result.Status), result.Attempts, finishedAt, durationMs, result.Error, result.Reason, output)
+	if err != nil {
+		return fmt.Errorf("update %s run %d: %w", result.JobName, result.RunID, err)
+	}
+	return nil
+}
+
+// PausedJobs returns the paused job names
+func (r *Repository) PausedJobs(ctx context.Context) (map[string]bool, error) {
+	rows, err := r.pool.Query(ctx, `SELECT job_name FROM ops.scheduler_jobs WHERE paused`)
+	if err != nil {
+		return nil, fmt.Errorf("query paused jobs: %w", err)
+	}
+	defer rows.Close()
+
+	paused := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan paused job: %w", err)
+		}
+		paused[name] = true
+	}
+	return paused, rows.Err()
+}
+
+// SetPaused pauses or resumes a job; ErrJobNotFound if not registered
+func (r *Repository) SetPaused(ctx context.Context, jobName string, paused bool, by string) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE ops.scheduler_jobs SET
+			paused = $2,
+			paused_by = CASE WHEN $2 THEN $3 END,
+			paused_at = CASE WHEN $2 THEN NOW() END,
+			updated_at = NOW()
+		WHERE job_name = $1
+	`, jobName, paused, by)
+	if err != nil {
+		return fmt.Errorf("set %s paused: %w", jobName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+// RequestRun queues a manual run for the leader; ErrJobNotFound if not registered
+func (r *Repository) RequestRun(ctx context.Context, jobName, by string) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO ops.scheduler_run_requests (job_name, requested_by)
+		SELECT $1, $2
+		WHERE EXISTS (SELECT 1 FROM ops.scheduler_jobs WHERE job_name = $1)
+		RETURNING id
+	`, jobName, by).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return 0, ErrJobNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("request %s run: %w", jobName, err)
+	}
+	return id, nil
+}
+
+// ClaimRunRequests marks pending run requests as started and returns them (요청 순)
+func (r *Repository) ClaimRunRequests(ctx context.Context, instanceID string) ([]RunRequest, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE ops.scheduler_run_requests q SET
+			status = 'started',
+			claimed_at = NOW(),
+			claimed_by = $1
+		WHERE q.id IN (
+			SELECT id FROM ops.scheduler_run_requests
+			WHERE status = 'pending'
+			ORDER BY requested_at, id
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, job_name, requested_by, status, COALESCE(message, ''), requested_at, claimed_at, COALESCE(claimed_by, '')
+	`, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("claim run requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []RunRequest
+	for rows.Next() {
+		req, err := scanRunRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan run request: %w", err)
+		}
+		requests = append(requests, *req)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING은 순서를 보장하지 않음
+	sort.Slice(requests, func(i, j int) bool { return requests[i].ID < requests[j].ID })
+	return requests, nil
+}
+
+// RejectRunRequest marks a request as rejected
+func (r *Repository) RejectRunRequest(ctx context.Context, id int64, message string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ops.scheduler_run_requests SET status = 'rejected', message = $2
+		WHERE id = $1
+	`, id, message)
+	if err != nil {
+		return fmt.Errorf("reject run request %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetRunRequest returns a run request (nil if absent)
+func (r *Repository) GetRunRequest(ctx context.Context, id int64) (*RunRequest, error) {
+	req, err := scanRunRequest(r.pool.QueryRow(ctx, `
+		SELECT id, job_name, requested_by, status, COALESCE(message, ''), requested_at, claimed_at, COALESCE(claimed_by, '')
+		FROM ops.scheduler_run_requests
+		WHERE id = $1
+	`, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get run request %d: %w", id, err)
+	}
+	return req, nil
+}
+
+// GetRunByRequest returns the run started for a request (nil if not started yet)
+func (r *Repository) GetRunByRequest(ctx context.Context, requestID int64) (*JobResult, error) {
+	result, err := scanRun(r.pool.QueryRow(ctx, `
+		SELECT `+runColumns+`
+		FROM ops.scheduler_runs
+		WHERE request_id = $1
+		ORDER BY id DESC
+		LIMIT 1
+	`, requestID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get run for request %d: %w", requestID, err)
+	}
+	return result, nil
+}
+
+// ListJobs returns registered jobs with pause state and latest run
+func (r *Repository) ListJobs(ctx context.Context) ([]JobState, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT job_name, schedule, depends_on, timeout_seconds, paused, COALESCE(paused_by, ''), paused_at
+		FROM ops.scheduler_jobs
+		ORDER BY job_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query scheduler jobs: %w", err)
+	}
+	defer rows.Close()
+
+	states := make([]JobState, 0)
+	for rows.Next() {
+		var st JobState
+		if err := rows.Scan(&st.JobName, &st.Schedule, &st.DependsOn, &st.TimeoutSeconds,
+			&st.Paused, &st.PausedBy, &st.PausedAt); err != nil {
+			return nil, fmt.Errorf("scan scheduler job: %w", err)
+		}
+		states = append(states, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	lastRuns, err := r.pool.Query(ctx, `
+		SELECT DISTINCT ON (job_name) `+runColumns+`
+		FROM ops.scheduler_runs
+		ORDER BY job_name, started_at DESC, id DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query last runs: %w", err)
+	}
+	defer lastRuns.Close()
+
+	latest := make(map[string]*JobResult)
+	for lastRuns.Next() {
+		run, err := scanRun(lastRuns)
+		if err != nil {
+			return nil, fmt.Errorf("scan job run: %w", err)
+		}
+		latest[run.JobName] = run
+	}
+	if err := lastRuns.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range states {
+		states[i].LastRun = latest[states[i].JobName]
+	}
+	return states, nil
+}
+
+// ListRuns returns the latest runs of a job, newest first; ErrJobNotFound if never registered nor run
+func (r *Repository) ListRuns(ctx context.Context, jobName string, limit int) ([]JobResult, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+runColumns+`
+		FROM ops.scheduler_runs
+		WHERE job_name = $1
+		ORDER BY started_at DESC, id DESC
+		LIMIT $2
+	`, jobName, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query %s runs: %w", jobName, err)
+	}
+	defer rows.Close()
+
+	runs := make([]JobResult, 0)
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan job run: %w", err)
+		}
+		runs = append(runs, *run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(runs) == 0 {
+		var exists bool
+		if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM ops.scheduler_jobs WHERE job_name = $1)`, jobName).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("check job %s: %w", jobName, err)
+		}
+		if !exists {
+			return nil, ErrJobNotFound
+		}
+	}
+	return runs, nil
+}
+
+// LeaderActive reports whether some scheduler holds the leader lock
+func (r *Repository) LeaderActive(ctx context.Context) (bool, error) {
+	var active bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_locks
+			WHERE locktype = 'advisory' AND granted
+			  AND classid::bigint = ($1::bigint >> 32) AND objid::bigint = ($1::bigint & 4294967295) AND objsubid = 1
+		)
+	`, leaderLockKey).Scan(&active)
+	if err != nil {
+		return false, fmt.Errorf("check scheduler leader: %w", err)
+	}
+	return active, nil
+}
+
+func scanRun(row pgx.Row) (*JobResult, error) {
+	var result JobResult
+	var status string
+	var finishedAt *time.Time
+	var durationMs *int64
+	var errMsg, reason *string
+	var output []byte
+	var requestID *int64
+
+	err := row.Scan(
+		&result.RunID, &result.JobName, &result.Trigger, &status, &result.Attempts,
+		&result.StartTime, &finishedAt, &durationMs, &errMsg, &reason, &output, &requestID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Status = RunStatus(status)
+	result.Success = result.Status == RunSuccess
+	if finishedAt != nil {
+		result.EndTime = *finishedAt
+	}
+	if durationMs != nil {
+		result.Duration = time.Duration(*durationMs) * time.Millisecond
+	}
+	if errMsg != nil {
+		result.Error = *errMsg
+	}
+	if reason != nil {
+		result.Reason = *reason
+	}
+	if len(output) > 0 {
+		if err := json.Unmarshal(output, &result.Output); err != nil {
+			return nil, fmt.Errorf("decode run %d output: %w", result.RunID, err)
+		}
+	}
+	if requestID != nil {
+		result.RequestID = *requestID
+	}
+	return &result, nil
+}
+
+func scanRunRequest(row pgx.Row) (*RunRequest, error) {
+	var req RunRequest
+	err := row.Scan(&req.ID, &req.JobName, &req.RequestedBy, &req.Status, &req.Message,
+		&req.RequestedAt, &req.ClaimedAt, &req.ClaimedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
@@ -3,67 +3,176 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
 
+	"github.com/wonny/aegis/v13/backend/internal/notify"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 )
 
+// defaultTimeout bounds jobs registered without JobOptions.Timeout
+const defaultTimeout = 2 * time.Hour
+
+// storeTimeout bounds each Store call (이력 저장 실패가 작업 실행을 막지 않도록)
+const storeTimeout = 10 * time.Second
+
 // Scheduler manages scheduled jobs
 // ⭐ SSOT: 스케줄 관리는 이 스케줄러에서만
 type Scheduler struct {
-	cron    *cron.Cron
-	logger  *logger.Logger
-	jobs    map[string]Job
-	history map[string]*JobHistory
-	mu      sync.RWMutex
+	cron       *cron.Cron
+	logger     *logger.Logger
+	jobs       map[string]Job
+	options    map[string]JobOptions
+	dependents map[string][]string // upstream → downstream (등록 순서)
+	history    map[string]*JobHistory
+	running    map[string]bool
+	paused     map[string]bool
+	calendar   TradingCalendar
+	mu         sync.RWMutex
+
+	// 영속 이력/일시정지/수동 실행 요청 (nil이면 메모리에만 보관)
+	store           Store
+	instanceID      string
+	controlInterval time.Duration
+
+	// 재시도 소진 알림 (nil이면 생략)
+	notifier notify.Emitter
+
+	// 선행 작업 완료/수동 요청으로 실행된 작업과 제어 루프 (Stop에서 대기)
+	wg     sync.WaitGroup
+	stopCh chan struct{}
 
 	// Retry configuration
-	maxRetries   int
-	retryDelay   time.Duration
+	maxRetries int
+	retryDelay time.Duration
 }
 
 // New creates a new scheduler
 func New(log *logger.Logger) *Scheduler {
+	host, _ := os.Hostname()
 	return &Scheduler{
-		cron:         cron.New(cron.WithSeconds()),
-		logger:       log,
-		jobs:         make(map[string]Job),
-		history:      make(map[string]*JobHistory),
-		maxRetries:   3,
-		retryDelay:   1 * time.Minute,
+		cron:       cron.New(cron.WithSeconds()),
+		logger:     log,
+		jobs:       make(map[string]Job),
+		options:    make(map[string]JobOptions),
+		dependents: make(map[string][]string),
+		history:    make(map[string]*JobHistory),
+		running:    make(map[string]bool),
+		paused:     make(map[string]bool),
+		stopCh:     make(chan struct{}),
+		maxRetries: 3,
+		retryDelay: 1 * time.Minute,
+
+		instanceID:      fmt.Sprintf("%s-%d", host, os.Getpid()),
+		controlInterval: 5 * time.Second,
 	}
 }
 
+// SetCalendar sets the trading calendar used by TradingDaysOnly jobs
+func (s *Scheduler) SetCalendar(cal TradingCalendar) {
+	s.calendar = cal
+}
+
+// SetStore persists run history and enables pause/manual run requests
+func (s *Scheduler) SetStore(store Store) {
+	s.store = store
+}
+
+// SetNotifier sets the emitter for job failure alerts (재시도 소진 시)
+func (s *Scheduler) SetNotifier(notifier notify.Emitter) {
+	s.notifier = notifier
+}
+
+// InstanceID identifies this scheduler process (hostname-pid)
+func (s *Scheduler) InstanceID() string {
+	return s.instanceID
+}
+
+// Restore loads persisted history and pause state for the registered jobs
+// 재시작 직후에도 GetJobStats와 의존 작업 판정이 이전 실행을 반영
+func (s *Scheduler) Restore(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+
+	loaded, err := s.store.LoadHistory(ctx, historySize)
+	if err != nil {
+		return fmt.Errorf("load job history: %w", err)
+	}
+	paused, err := s.store.PausedJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("load paused jobs: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for jobName, results := range loaded {
+		if history, exists := s.history[jobName]; exists {
+			history.Results = results
+		}
+	}
+	s.paused = paused
+	return nil
+}
+
 // AddJob adds a job to the scheduler
-func (s *Scheduler) AddJob(job Job) error {
+// 선행 작업은 먼저 등록되어 있어야 함 (등록 순서가 곧 위상 정렬이라 순환 의존 불가)
+func (s *Scheduler) AddJob(job Job, opts ...JobOptions) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	jobName := job.Name()
+	var opt JobOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
 
 	// Check if job already exists
 	if _, exists := s.jobs[jobName]; exists {
 		return fmt.Errorf("job %s already exists", jobName)
 	}
 
-	// Add job to cron
-	_, err := s.cron.AddFunc(job.Schedule(), func() {
-		s.runJob(job)
-	})
-	if err != nil {
-		return fmt.Errorf("failed to schedule job %s: %w", jobName, err)
+	for _, dep := range opt.DependsOn {
+		if _, exists := s.jobs[dep]; !exists {
+			return fmt.Errorf("job %s depends on unregistered job %s", jobName, dep)
+		}
+	}
+
+	if opt.NotBefore != "" {
+		if _, err := time.Parse("15:04", opt.NotBefore); err != nil {
+			return fmt.Errorf("job %s: invalid not-before time %q (expected HH:MM)", jobName, opt.NotBefore)
+		}
+	}
+
+	// Add job to cron (dependent jobs are triggered by their upstreams)
+	if len(opt.DependsOn) == 0 {
+		_, err := s.cron.AddFunc(job.Schedule(), func() {
+			s.runJob(job, TriggerCron, false, 0)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to schedule job %s: %w", jobName, err)
+		}
 	}
 
 	// Store job
 	s.jobs[jobName] = job
+	s.options[jobName] = opt
 	s.history[jobName] = &JobHistory{}
+	for _, dep := range opt.DependsOn {
+		s.dependents[dep] = append(s.dependents[dep], jobName)
+	}
 
 	s.logger.WithFields(map[string]interface{}{
-		"job":      jobName,
-		"schedule": job.Schedule(),
+		"job":        jobName,
+		"schedule":   s.describeSchedule(jobName),
+		"depends_on": opt.DependsOn,
+		"timeout":    s.timeout(opt).String(),
 	}).Info("Job added to scheduler")
 
 	return nil
@@ -77,28 +186,57 @@ func (s *Scheduler) RemoveJob(jobName string) error {
 	if _, exists := s.jobs[jobName]; !exists {
 		return fmt.Errorf("job %s not found", jobName)
 	}
+	if len(s.dependents[jobName]) > 0 {
+		return fmt.Errorf("job %s has dependents: %s", jobName, strings.Join(s.dependents[jobName], ", "))
+	}
 
+	for _, dep := range s.options[jobName].DependsOn {
+		s.dependents[dep] = removeString(s.dependents[dep], jobName)
+	}
 	delete(s.jobs, jobName)
+	delete(s.options, jobName)
 	s.logger.WithField("job", jobName).Info("Job removed from scheduler")
 
 	return nil
 }
 
 // Start starts the scheduler
+// Store가 있으면 작업 목록을 등록하고 이전 리더의 미완료 실행을 정리한 뒤 제어 루프 시작
+// ⚠️ 리더(advisory lock 보유) 프로세스에서만 호출
 func (s *Scheduler) Start() {
-	s.logger.Info("Starting scheduler")
+	s.logger.WithField("instance", s.instanceID).Info("Starting scheduler")
+
+	if s.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		if err := s.store.SyncJobs(ctx, s.jobInfos()); err != nil {
+			s.logger.WithError(err).Warn("Failed to register jobs in store")
+		}
+		if n, err := s.store.AbandonRuns(ctx, "scheduler restarted before the run finished"); err != nil {
+			s.logger.WithError(err).Warn("Failed to close abandoned runs")
+		} else if n > 0 {
+			s.logger.WithField("runs", n).Warn("Closed runs abandoned by a previous scheduler")
+		}
+		cancel()
+
+		s.wg.Add(1)
+		go s.control()
+	}
+
 	s.cron.Start()
 }
 
-// Stop stops the scheduler
+// Stop stops the scheduler and waits for running jobs
 func (s *Scheduler) Stop() {
 	s.logger.Info("Stopping scheduler")
+	close(s.stopCh)
 	ctx := s.cron.Stop()
 	<-ctx.Done()
+	s.wg.Wait()
 	s.logger.Info("Scheduler stopped")
 }
 
 // RunJob runs a specific job immediately (outside of schedule)
+// 수동 실행은 휴장일/시작 시각 제한을 무시하고, 성공 시 후속 작업도 같은 방식으로 실행
 func (s *Scheduler) RunJob(jobName string) error {
 	s.mu.RLock()
 	job, exists := s.jobs[jobName]
@@ -108,24 +246,197 @@ func (s *Scheduler) RunJob(jobName string) error {
 		return fmt.Errorf("job %s not found", jobName)
 	}
 
-	go s.runJob(job)
+	s.spawn(job, TriggerManual, true, 0)
 	return nil
 }
 
-// runJob executes a job with retry logic
-func (s *Scheduler) runJob(job Job) {
+// spawn runs a job in the background, tracked for Stop
+func (s *Scheduler) spawn(job Job, trigger string, force bool, requestID int64) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runJob(job, trigger, force, requestID)
+	}()
+}
+
+// control applies pause changes and manual run requests from the store until Stop
+func (s *Scheduler) control() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.controlInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.poll()
+		}
+	}
+}
+
+// poll refreshes pause state and starts claimed run requests
+// 수동 요청은 RunJob과 같은 경로 (휴장일/시작 시각/일시정지 무시, 성공 시 후속 작업 실행)
+func (s *Scheduler) poll() {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if paused, err := s.store.PausedJobs(ctx); err != nil {
+		s.logger.WithError(err).Warn("Failed to refresh paused jobs")
+	} else {
+		s.setPaused(paused)
+	}
+
+	requests, err := s.store.ClaimRunRequests(ctx, s.instanceID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to claim run requests")
+		return
+	}
+
+	for _, req := range requests {
+		s.mu.RLock()
+		job, exists := s.jobs[req.JobName]
+		running := s.running[req.JobName]
+		s.mu.RUnlock()
+
+		reject := ""
+		switch {
+		case !exists:
+			reject = "job not registered"
+		case running:
+			reject = "job already running"
+		}
+
+		log := s.logger.WithFields(map[string]interface{}{
+			"job":          req.JobName,
+			"request_id":   req.ID,
+			"requested_by": req.RequestedBy,
+		})
+		if reject != "" {
+			if err := s.store.RejectRunRequest(ctx, req.ID, reject); err != nil {
+				log.WithError(err).Warn("Failed to reject run request")
+			}
+			log.WithField("reason", reject).Warn("Run request rejected")
+			continue
+		}
+
+		log.Info("Manual run requested")
+		s.spawn(job, TriggerManual+":"+req.RequestedBy, true, req.ID)
+	}
+}
+
+// setPaused replaces the pause state and logs changes
+func (s *Scheduler) setPaused(paused map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for jobName := range s.jobs {
+		if paused[jobName] != s.paused[jobName] {
+			s.logger.WithFields(map[string]interface{}{
+				"job":    jobName,
+				"paused": paused[jobName],
+			}).Info("Job pause state changed")
+		}
+	}
+	s.paused = paused
+}
+
+// runJob executes a job with pause/calendar gating, deadline and retry logic
+func (s *Scheduler) runJob(job Job, trigger string, force bool, requestID int64) {
 	jobName := job.Name()
+
+	s.mu.Lock()
+	if s.running[jobName] {
+		s.mu.Unlock()
+		s.logger.WithFields(map[string]interface{}{
+			"job":     jobName,
+			"trigger": trigger,
+		}).Warn("Job already running, trigger ignored")
+		return
+	}
+	s.running[jobName] = true
+	opt := s.options[jobName]
+	paused := s.paused[jobName]
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, jobName)
+		s.mu.Unlock()
+	}()
+
 	startTime := time.Now()
 
-	s.logger.WithField("job", jobName).Info("Job started")
+	// 1. 일시정지된 작업은 건너뜀 (후속 작업도 사유와 함께 건너뜀)
+	if paused && !force {
+		s.finish(job, JobResult{
+			JobName:   jobName,
+			StartTime: startTime,
+			EndTime:   time.Now(),
+			Status:    RunSkipped,
+			Trigger:   trigger,
+			Reason:    "job paused",
+		}, force)
+		return
+	}
+
+	// 2. KRX 휴장일 건너뜀
+	if opt.TradingDaysOnly && !force && !s.isTradingDay(startTime) {
+		s.finish(job, JobResult{
+			JobName:   jobName,
+			StartTime: startTime,
+			EndTime:   time.Now(),
+			Status:    RunSkipped,
+			Trigger:   trigger,
+			Reason:    "market closed (KRX holiday)",
+		}, force)
+		return
+	}
+
+	// 3. 시작 시각 이전 트리거는 대기 (예: 의사결정 시각 17:00)
+	if wait := notBeforeDelay(opt.NotBefore, startTime); wait > 0 && !force {
+		s.logger.WithFields(map[string]interface{}{
+			"job":        jobName,
+			"not_before": opt.NotBefore,
+			"wait":       wait.String(),
+		}).Info("Job triggered early, waiting for start time")
+
+		select {
+		case <-time.After(wait):
+		case <-s.stopCh:
+			return
+		}
+		startTime = time.Now()
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job":     jobName,
+		"trigger": trigger,
+	}).Info("Job started")
+
+	// 실행 시작을 기록 (프로세스가 죽으면 다음 리더가 FAILED로 정리)
+	result := JobResult{
+		JobName:   jobName,
+		StartTime: startTime,
+		Status:    RunRunning,
+		Trigger:   trigger,
+		RequestID: requestID,
+	}
+	s.save(&result)
+
+	// 4. 실행 기한: 재시도 포함 전체 실행에 적용
+	timeout := s.timeout(opt)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ctx, summary := WithOutput(ctx)
 
 	var lastErr error
 	var success bool
 
 	// Try running the job with retries
 	for attempt := 0; attempt <= s.maxRetries; attempt++ {
-		ctx := context.Background()
-
+		result.Attempts = attempt + 1
 		err := job.Run(ctx)
 		if err == nil {
 			success = true
@@ -133,6 +444,11 @@ func (s *Scheduler) runJob(job Job) {
 		}
 
 		lastErr = err
+		if ctx.Err() != nil {
+			lastErr = fmt.Errorf("deadline %s exceeded: %w", timeout, err)
+			break
+		}
+
 		s.logger.WithFields(map[string]interface{}{
 			"job":     jobName,
 			"attempt": attempt + 1,
@@ -141,46 +457,266 @@ func (s *Scheduler) runJob(job Job) {
 
 		// Wait before retry (except on last attempt)
 		if attempt < s.maxRetries {
-			time.Sleep(s.retryDelay)
+			select {
+			case <-time.After(s.retryDelay):
+			case <-ctx.Done():
+			}
 		}
 	}
 
 	endTime := time.Now()
-	duration := endTime.Sub(startTime)
 
-	// Create job result
-	result := JobResult{
-		JobName:   jobName,
-		StartTime: startTime,
-		EndTime:   endTime,
-		Duration:  duration,
-		Success:   success,
+	// Complete job result
+	result.EndTime = endTime
+	result.Duration = endTime.Sub(startTime)
+	result.Success = success
+	result.Status = RunSuccess
+	result.Output = summary()
+
+	if !success {
+		result.Status = RunFailed
+		if lastErr != nil {
+			result.Error = lastErr.Error()
+		}
 	}
 
-	if !success && lastErr != nil {
-		result.Error = lastErr.Error()
-	}
+	s.finish(job, result, force)
+}
 
-	// Store result in history
+// finish stores the result, logs it and triggers or skips dependent jobs
+func (s *Scheduler) finish(job Job, result JobResult, force bool) {
+	jobName := job.Name()
+
+	// Persist and store result in history
+	s.save(&result)
 	s.mu.Lock()
 	if history, exists := s.history[jobName]; exists {
 		history.AddResult(result)
 	}
 	s.mu.Unlock()
+	metrics.ObserveJob(jobName, string(result.Status), result.Duration)
 
 	// Log completion
-	if success {
-		s.logger.WithFields(map[string]interface{}{
-			"job":      jobName,
-			"duration": duration,
-		}).Info("Job completed successfully")
-	} else {
-		s.logger.WithFields(map[string]interface{}{
-			"job":      jobName,
-			"duration": duration,
-			"error":    lastErr.Error(),
-		}).Error("Job failed after all retries")
+	log := s.logger.WithFields(map[string]interface{}{
+		"job":      jobName,
+		"trigger":  result.Trigger,
+		"duration": result.Duration,
+	})
+	switch result.Status {
+	case RunSuccess:
+		log.Info("Job completed successfully")
+	case RunSkipped:
+		log.WithField("reason", result.Reason).Warn("Job skipped")
+	default:
+		log.WithField("error", result.Error).Error("Job failed after all retries")
+		s.notifyFailure(&result)
 	}
+
+	s.propagate(jobName, result.Day(), force)
+}
+
+// notifyFailure queues a job failure alert (알림 실패는 로그만)
+func (s *Scheduler) notifyFailure(result *JobResult) {
+	if s.notifier == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	event := notify.JobFailedEvent(result.JobName, result.Day(), result.Attempts, result.Error)
+	if err := s.notifier.Notify(ctx, event); err != nil {
+		s.logger.WithError(err).WithField("job", result.JobName).Warn("Failed to queue job failure notification")
+	}
+}
+
+// propagate runs dependents whose upstreams all succeeded today and skips those with a failed upstream
+func (s *Scheduler) propagate(upstream, day string, force bool) {
+	type decision struct {
+		job    Job
+		action dagAction
+		reason string
+	}
+
+	s.mu.RLock()
+	decisions := make([]decision, 0, len(s.dependents[upstream]))
+	for _, name := range s.dependents[upstream] {
+		if s.running[name] {
+			continue
+		}
+		latest := make(map[string]*JobResult)
+		for _, dep := range s.options[name].DependsOn {
+			latest[dep] = s.history[dep].Latest()
+		}
+		action, reason := decideDownstream(s.options[name].DependsOn, latest, s.history[name].Latest(), day)
+		decisions = append(decisions, decision{job: s.jobs[name], action: action, reason: reason})
+	}
+	s.mu.RUnlock()
+
+	for _, d := range decisions {
+		switch d.action {
+		case dagRun:
+			s.spawn(d.job, TriggerUpstream+":"+upstream, force, 0)
+		case dagSkip:
+			now := time.Now()
+			s.finish(d.job, JobResult{
+				JobName:   d.job.Name(),
+				StartTime: now,
+				EndTime:   now,
+				Status:    RunSkipped,
+				Trigger:   TriggerUpstream + ":" + upstream,
+				Reason:    d.reason,
+			}, force)
+		}
+	}
+}
+
+// save persists a run (Store 오류는 경고만 남기고 실행은 계속)
+func (s *Scheduler) save(result *JobResult) {
+	if s.store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := s.store.SaveRun(ctx, s.instanceID, result); err != nil {
+		s.logger.WithError(err).WithField("job", result.JobName).Warn("Failed to persist job run")
+	}
+}
+
+// jobInfos returns the registered jobs in a stable order
+func (s *Scheduler) jobInfos() []JobInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	infos := make([]JobInfo, 0, len(s.jobs))
+	for jobName := range s.jobs {
+		infos = append(infos, JobInfo{
+			Name:      jobName,
+			Schedule:  s.describeSchedule(jobName),
+			DependsOn: s.options[jobName].DependsOn,
+			Timeout:   s.timeout(s.options[jobName]),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// isTradingDay checks the calendar (열람 실패 시 실행 쪽으로 판단)
+func (s *Scheduler) isTradingDay(date time.Time) bool {
+	if s.calendar == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	open, err := s.calendar.IsTradingDay(ctx, date)
+	if err != nil {
+		s.logger.WithError(err).Warn("Trading calendar unavailable, assuming market open")
+		return true
+	}
+	return open
+}
+
+func (s *Scheduler) timeout(opt JobOptions) time.Duration {
+	if opt.Timeout > 0 {
+		return opt.Timeout
+	}
+	return defaultTimeout
+}
+
+// describeSchedule returns the cron expression or "after <upstreams>" for dependent jobs
+func (s *Scheduler) describeSchedule(jobName string) string {
+	opt := s.options[jobName]
+	desc := s.jobs[jobName].Schedule()
+	if len(opt.DependsOn) > 0 {
+		desc = "after " + strings.Join(opt.DependsOn, ", ")
+	}
+	if opt.NotBefore != "" {
+		desc += " (not before " + opt.NotBefore + ")"
+	}
+	if opt.TradingDaysOnly {
+		desc += " [trading days]"
+	}
+	return desc
+}
+
+// dagAction is what a dependent job does after an upstream finishes
+type dagAction int
+
+const (
+	dagWait dagAction = iota // 선행 작업 대기 중이거나 이미 처리됨
+	dagRun
+	dagSkip
+)
+
+// decideDownstream decides a dependent job's action for a day from the latest upstream results
+// ⭐ SSOT: 작업 의존성 판정 규칙은 이 함수에서만
+func decideDownstream(dependsOn []string, latest map[string]*JobResult, self *JobResult, day string) (dagAction, string) {
+	selfToday := self != nil && self.Day() == day
+	if selfToday && self.Status == RunSuccess {
+		return dagWait, ""
+	}
+
+	pending := false
+	for _, dep := range dependsOn {
+		r := latest[dep]
+		if r == nil || r.Day() != day {
+			pending = true
+			continue
+		}
+		if r.Status != RunSuccess {
+			if selfToday {
+				// 오늘 이미 건너뜀/실패로 기록됨
+				return dagWait, ""
+			}
+			return dagSkip, upstreamReason(dep, r)
+		}
+	}
+
+	if pending {
+		return dagWait, ""
+	}
+	return dagRun, ""
+}
+
+func upstreamReason(dep string, r *JobResult) string {
+	reason := fmt.Sprintf("upstream %s %s", dep, strings.ToLower(string(r.Status)))
+	if r.Status == RunSkipped && r.Reason != "" {
+		return reason + ": " + r.Reason
+	}
+	if r.Error != "" {
+		return reason + ": " + r.Error
+	}
+	return reason
+}
+
+// notBeforeDelay returns how long to wait until the local HH:MM start time today (0 if passed)
+func notBeforeDelay(notBefore string, now time.Time) time.Duration {
+	if notBefore == "" {
+		return 0
+	}
+	t, err := time.Parse("15:04", notBefore)
+	if err != nil {
+		return 0
+	}
+	start := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if now.Before(start) {
+		return start.Sub(now)
+	}
+	return 0
+}
+
+func removeString(list []string, v string) []string {
+	out := list[:0]
+	for _, s := range list {
+		if s != v {
+			out = append(out, s)
+		}
+	}
+	return out
 }
 
 // GetJobHistory returns the history for a specific job
@@ -217,34 +753,46 @@ func (s *Scheduler) GetJobStats() map[string]JobStats {
 	stats := make(map[string]JobStats)
 
 	for jobName, history := range s.history {
-		latestResults := history.GetLatestResults(10)
+		if _, exists := s.jobs[jobName]; !exists {
+			continue
+		}
+
 		failedResults := history.GetFailedResults()
+		skippedResults := history.GetSkippedResults()
 
 		var lastRun *time.Time
 		var lastSuccess *time.Time
 		var lastFailure *time.Time
-
-		if len(latestResults) > 0 {
-			lastResult := latestResults[len(latestResults)-1]
-			lastRun = &lastResult.StartTime
-
-			if lastResult.Success {
-				lastSuccess = &lastResult.StartTime
-			} else {
-				lastFailure = &lastResult.StartTime
+		var lastSkipReason string
+
+		if latest := history.Latest(); latest != nil {
+			lastRun = &latest.StartTime
+
+			switch latest.Status {
+			case RunSuccess:
+				lastSuccess = &latest.StartTime
+			case RunSkipped:
+				lastSkipReason = latest.Reason
+			default:
+				lastFailure = &latest.StartTime
 			}
 		}
 
 		stats[jobName] = JobStats{
-			JobName:       jobName,
-			Schedule:      s.jobs[jobName].Schedule(),
-			TotalRuns:     len(history.Results),
-			SuccessCount:  len(history.Results) - len(failedResults),
-			FailureCount:  len(failedResults),
-			SuccessRate:   history.GetSuccessRate(),
-			LastRun:       lastRun,
-			LastSuccess:   lastSuccess,
-			LastFailure:   lastFailure,
+			JobName:        jobName,
+			Schedule:       s.describeSchedule(jobName),
+			DependsOn:      s.options[jobName].DependsOn,
+			Timeout:        s.timeout(s.options[jobName]).String(),
+			Paused:         s.paused[jobName],
+			TotalRuns:      len(history.Results),
+			SuccessCount:   len(history.Results) - len(failedResults) - len(skippedResults),
+			FailureCount:   len(failedResults),
+			SkippedCount:   len(skippedResults),
+			SuccessRate:    history.GetSuccessRate(),
+			LastRun:        lastRun,
+			LastSuccess:    lastSuccess,
+			LastFailure:    lastFailure,
+			LastSkipReason: lastSkipReason,
 		}
 	}
 
@@ -253,13 +801,18 @@ func (s *Scheduler) GetJobStats() map[string]JobStats {
 
 // JobStats represents statistics for a job
 type JobStats struct {
-	JobName      string     `json:"job_name"`
-	Schedule     string     `json:"schedule"`
-	TotalRuns    int        `json:"total_runs"`
-	SuccessCount int        `json:"success_count"`
-	FailureCount int        `json:"failure_count"`
-	SuccessRate  float64    `json:"success_rate"`
-	LastRun      *time.Time `json:"last_run,omitempty"`
-	LastSuccess  *time.Time `json:"last_success,omitempty"`
-	LastFailure  *time.Time `json:"last_failure,omitempty"`
+	JobName        string     `json:"job_name"`
+	Schedule       string     `json:"schedule"`
+	DependsOn      []string   `json:"depends_on,omitempty"`
+	Timeout        string     `json:"timeout"`
+	Paused         bool       `json:"paused"`
+	TotalRuns      int        `json:"total_runs"`
+	SuccessCount   int        `json:"success_count"`
+	FailureCount   int        `json:"failure_count"`
+	SkippedCount   int        `json:"skipped_count"`
+	SuccessRate    float64    `json:"success_rate"`
+	LastRun        *time.Time `json:"last_run,omitempty"`
+	LastSuccess    *time.Time `json:"last_success,omitempty"`
+	LastFailure    *time.Time `json:"last_failure,omitempty"`
+	LastSkipReason string     `json:"last_skip_reason,omitempty"`
 }
@@ -0,0 +1,347 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+type fakeJob struct {
+	name string
+	err  error
+
+	mu   sync.Mutex
+	runs int
+}
+
+func (j *fakeJob) Name() string     { return j.name }
+func (j *fakeJob) Schedule() string { return "0 0 16 * * *" }
+func (j *fakeJob) Run(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.runs++
+	return j.err
+}
+func (j *fakeJob) count() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.runs
+}
+
+type fakeCalendar struct{ open bool }
+
+func (c fakeCalendar) IsTradingDay(ctx context.Context, date time.Time) (bool, error) {
+	return c.open, nil
+}
+
+func newTestScheduler() *Scheduler {
+	s := New(logger.New(&config.Config{LogLevel: "error", LogFormat: "json"}))
+	s.maxRetries = 0
+	s.retryDelay = 0
+	return s
+}
+
+func TestDecideDownstream(t *testing.T) {
+	day := "2026-10-16"
+	at := time.Date(2026, 10, 16, 16, 30, 0, 0, time.Local)
+	yesterday := at.AddDate(0, 0, -1)
+
+	ok := &JobResult{StartTime: at, Status: RunSuccess, Success: true}
+	failed := &JobResult{StartTime: at, Status: RunFailed, Error: "naver timeout"}
+	skipped := &JobResult{StartTime: at, Status: RunSkipped, Reason: "market closed (KRX holiday)"}
+	stale := &JobResult{StartTime: yesterday, Status: RunSuccess, Success: true}
+
+	deps := []string{"data_collection", "stock_master_sync"}
+
+	tests := []struct {
+		name       string
+		latest     map[string]*JobResult
+		self       *JobResult
+		wantAction dagAction
+		wantReason string
+	}{
+		{"all upstreams succeeded", map[string]*JobResult{"data_collection": ok, "stock_master_sync": ok}, nil, dagRun, ""},
+		{"one upstream pending", map[string]*JobResult{"data_collection": ok}, nil, dagWait, ""},
+		{"upstream ran yesterday only", map[string]*JobResult{"data_collection": ok, "stock_master_sync": stale}, nil, dagWait, ""},
+		{"upstream failed", map[string]*JobResult{"data_collection": failed}, nil, dagSkip, "upstream data_collection failed: naver timeout"},
+		{"upstream skipped", map[string]*JobResult{"data_collection": ok, "stock_master_sync": skipped}, nil, dagSkip,
+			"upstream stock_master_sync skipped: market closed (KRX holiday)"},
+		{"already succeeded today", map[string]*JobResult{"data_collection": ok, "stock_master_sync": ok}, ok, dagWait, ""},
+		{"already skipped today", map[string]*JobResult{"data_collection": failed}, skipped, dagWait, ""},
+		{"rerun after upstream recovered", map[string]*JobResult{"data_collection": ok, "stock_master_sync": ok}, skipped, dagRun, ""},
+		{"succeeded yesterday", map[string]*JobResult{"data_collection": ok, "stock_master_sync": ok}, stale, dagRun, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, reason := decideDownstream(deps, tt.latest, tt.self, day)
+			assert.Equal(t, tt.wantAction, action)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func TestNotBeforeDelay(t *testing.T) {
+	at := time.Date(2026, 10, 16, 16, 30, 0, 0, time.Local)
+
+	assert.Equal(t, 30*time.Minute, notBeforeDelay("17:00", at))
+	assert.Zero(t, notBeforeDelay("16:00", at))
+	assert.Zero(t, notBeforeDelay("", at))
+	assert.Zero(t, notBeforeDelay("5pm", at))
+}
+
+func TestAddJob_Dependencies(t *testing.T) {
+	s := newTestScheduler()
+
+	err := s.AddJob(&fakeJob{name: "universe_generation"}, JobOptions{DependsOn: []string{"data_collection"}})
+	assert.Error(t, err, "upstream must be registered first")
+
+	require.NoError(t, s.AddJob(&fakeJob{name: "data_collection"}))
+	require.NoError(t, s.AddJob(&fakeJob{name: "universe_generation"}, JobOptions{DependsOn: []string{"data_collection"}}))
+
+	assert.Error(t, s.AddJob(&fakeJob{name: "selection_pipeline"}, JobOptions{NotBefore: "5pm"}))
+	assert.Error(t, s.RemoveJob("data_collection"), "has dependents")
+	assert.Equal(t, "after data_collection", s.GetJobStats()["universe_generation"].Schedule)
+}
+
+func TestRunJob_PropagatesFailureAsSkipped(t *testing.T) {
+	s := newTestScheduler()
+
+	collect := &fakeJob{name: "data_collection", err: errors.New("naver timeout")}
+	universe := &fakeJob{name: "universe_generation"}
+	pipeline := &fakeJob{name: "selection_pipeline"}
+
+	require.NoError(t, s.AddJob(collect))
+	require.NoError(t, s.AddJob(universe, JobOptions{DependsOn: []string{"data_collection"}}))
+	require.NoError(t, s.AddJob(pipeline, JobOptions{DependsOn: []string{"universe_generation"}}))
+
+	s.runJob(collect, TriggerCron, false, 0)
+	s.wg.Wait()
+
+	assert.Equal(t, 0, universe.count())
+	assert.Equal(t, 0, pipeline.count())
+
+	stats := s.GetJobStats()
+	assert.Equal(t, 1, stats["data_collection"].FailureCount)
+	assert.Equal(t, 1, stats["universe_generation"].SkippedCount)
+	assert.Equal(t, "upstream data_collection failed: naver timeout", stats["universe_generation"].LastSkipReason)
+	assert.Equal(t, "upstream universe_generation skipped: upstream data_collection failed: naver timeout",
+		stats["selection_pipeline"].LastSkipReason)
+}
+
+func TestRunJob_TriggersDependents(t *testing.T) {
+	s := newTestScheduler()
+
+	collect := &fakeJob{name: "data_collection"}
+	master := &fakeJob{name: "stock_master_sync"}
+	universe := &fakeJob{name: "universe_generation"}
+
+	require.NoError(t, s.AddJob(collect))
+	require.NoError(t, s.AddJob(master))
+	require.NoError(t, s.AddJob(universe, JobOptions{DependsOn: []string{"data_collection", "stock_master_sync"}}))
+
+	s.runJob(collect, TriggerCron, false, 0)
+	s.wg.Wait()
+	assert.Equal(t, 0, universe.count(), "waits for stock_master_sync")
+
+	s.runJob(master, TriggerCron, false, 0)
+	s.wg.Wait()
+	assert.Equal(t, 1, universe.count())
+
+	s.runJob(master, TriggerCron, false, 0)
+	s.wg.Wait()
+	assert.Equal(t, 1, universe.count(), "runs once per day")
+}
+
+func TestRunJob_SkipsHoliday(t *testing.T) {
+	s := newTestScheduler()
+	s.SetCalendar(fakeCalendar{open: false})
+
+	collect := &fakeJob{name: "data_collection"}
+	forecast := &fakeJob{name: "forecast_pipeline"}
+	require.NoError(t, s.AddJob(collect, JobOptions{TradingDaysOnly: true}))
+	require.NoError(t, s.AddJob(forecast, JobOptions{DependsOn: []string{"data_collection"}}))
+
+	s.runJob(collect, TriggerCron, false, 0)
+	s.wg.Wait()
+
+	assert.Equal(t, 0, collect.count())
+	assert.Equal(t, 0, forecast.count())
+	assert.Equal(t, "market closed (KRX holiday)", s.GetJobStats()["data_collection"].LastSkipReason)
+	assert.Equal(t, 1, s.GetJobStats()["forecast_pipeline"].SkippedCount)
+
+	// 수동 실행은 휴장일에도 실행하고 후속 작업도 실행
+	s.runJob(collect, TriggerManual, true, 0)
+	s.wg.Wait()
+	assert.Equal(t, 1, collect.count())
+	assert.Equal(t, 1, forecast.count())
+}
+
+type slowJob struct{ fakeJob }
+
+func (j *slowJob) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRunJob_Deadline(t *testing.T) {
+	s := newTestScheduler()
+	s.maxRetries = 3
+
+	job := &slowJob{fakeJob{name: "financial_statement_collection"}}
+	require.NoError(t, s.AddJob(job, JobOptions{Timeout: 20 * time.Millisecond}))
+
+	start := time.Now()
+	s.runJob(job, TriggerCron, false, 0)
+
+	assert.Less(t, time.Since(start), time.Second, "no retries after the deadline")
+	stats := s.GetJobStats()["financial_statement_collection"]
+	assert.Equal(t, 1, stats.FailureCount)
+	assert.Equal(t, "20ms", stats.Timeout)
+}
+
+type memStore struct {
+	mu       sync.Mutex
+	runs     []JobResult
+	paused   map[string]bool
+	requests []RunRequest
+	rejected map[int64]string
+}
+
+func newMemStore() *memStore {
+	return &memStore{paused: make(map[string]bool), rejected: make(map[int64]string)}
+}
+
+func (m *memStore) SyncJobs(ctx context.Context, jobs []JobInfo) error { return nil }
+func (m *memStore) AbandonRuns(ctx context.Context, reason string) (int64, error) {
+	return 0, nil
+}
+func (m *memStore) LoadHistory(ctx context.Context, perJob int) (map[string][]JobResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	history := make(map[string][]JobResult)
+	for _, r := range m.runs {
+		if r.Status != RunRunning {
+			history[r.JobName] = append(history[r.JobName], r)
+		}
+	}
+	return history, nil
+}
+func (m *memStore) SaveRun(ctx context.Context, instanceID string, result *JobResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if result.RunID == 0 {
+		result.RunID = int64(len(m.runs) + 1)
+		m.runs = append(m.runs, *result)
+		return nil
+	}
+	m.runs[result.RunID-1] = *result
+	return nil
+}
+func (m *memStore) PausedJobs(ctx context.Context) (map[string]bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	paused := make(map[string]bool)
+	for k, v := range m.paused {
+		paused[k] = v
+	}
+	return paused, nil
+}
+func (m *memStore) ClaimRunRequests(ctx context.Context, instanceID string) ([]RunRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	claimed := m.requests
+	m.requests = nil
+	return claimed, nil
+}
+func (m *memStore) RejectRunRequest(ctx context.Context, id int64, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejected[id] = message
+	return nil
+}
+
+type reportingJob struct{ fakeJob }
+
+func (j *reportingJob) Run(ctx context.Context) error {
+	Report(ctx, "included_count", 312)
+	return j.fakeJob.Run(ctx)
+}
+
+func TestRunJob_PersistsRuns(t *testing.T) {
+	store := newMemStore()
+	s := newTestScheduler()
+	s.SetStore(store)
+
+	job := &reportingJob{fakeJob{name: "universe_generation"}}
+	require.NoError(t, s.AddJob(job))
+
+	s.runJob(job, TriggerCron, false, 0)
+
+	require.Len(t, store.runs, 1, "RUNNING row is updated in place")
+	run := store.runs[0]
+	assert.Equal(t, RunSuccess, run.Status)
+	assert.Equal(t, 1, run.Attempts)
+	assert.Equal(t, map[string]interface{}{"included_count": 312}, run.Output)
+
+	// 재시작 후 이력 복원
+	restarted := newTestScheduler()
+	restarted.SetStore(store)
+	require.NoError(t, restarted.AddJob(&fakeJob{name: "universe_generation"}))
+	require.NoError(t, restarted.Restore(context.Background()))
+	assert.Equal(t, 1, restarted.GetJobStats()["universe_generation"].SuccessCount)
+}
+
+func TestRunJob_Paused(t *testing.T) {
+	store := newMemStore()
+	store.paused["data_collection"] = true
+	s := newTestScheduler()
+	s.SetStore(store)
+
+	collect := &fakeJob{name: "data_collection"}
+	universe := &fakeJob{name: "universe_generation"}
+	require.NoError(t, s.AddJob(collect))
+	require.NoError(t, s.AddJob(universe, JobOptions{DependsOn: []string{"data_collection"}}))
+	require.NoError(t, s.Restore(context.Background()))
+
+	s.runJob(collect, TriggerCron, false, 0)
+	s.wg.Wait()
+
+	assert.Equal(t, 0, collect.count())
+	stats := s.GetJobStats()
+	assert.True(t, stats["data_collection"].Paused)
+	assert.Equal(t, "job paused", stats["data_collection"].LastSkipReason)
+	assert.Equal(t, "upstream data_collection skipped: job paused", stats["universe_generation"].LastSkipReason)
+}
+
+func TestPoll_RunRequests(t *testing.T) {
+	store := newMemStore()
+	store.paused["data_collection"] = true
+	store.requests = []RunRequest{
+		{ID: 1, JobName: "data_collection", RequestedBy: "api"},
+		{ID: 2, JobName: "unknown_job", RequestedBy: "api"},
+	}
+	s := newTestScheduler()
+	s.SetStore(store)
+
+	collect := &fakeJob{name: "data_collection"}
+	require.NoError(t, s.AddJob(collect))
+
+	s.poll()
+	s.wg.Wait()
+
+	assert.Equal(t, 1, collect.count(), "manual requests ignore pause")
+	assert.Equal(t, "job not registered", store.rejected[2])
+
+	require.Len(t, store.runs, 1)
+	assert.Equal(t, int64(1), store.runs[0].RequestID)
+	assert.Equal(t, "manual:api", store.runs[0].Trigger)
+}
@@ -168,6 +168,25 @@ func (r *Repository) GetRankingResults(ctx context.Context, date time.Time, limi
 	return results, nil
 }
 
+// GetLatestRankingResults retrieves top-N ranking results of the most recent rank date
+func (r *Repository) GetLatestRankingResults(ctx context.Context, limit int) ([]contracts.RankedStock, time.Time, error) {
+	var rankDate *time.Time
+	err := r.pool.QueryRow(ctx, `SELECT MAX(rank_date) FROM selection.ranking_results`).Scan(&rankDate)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to query latest rank date: %w", err)
+	}
+	if rankDate == nil {
+		return []contracts.RankedStock{}, time.Time{}, nil
+	}
+
+	results, err := r.GetRankingResults(ctx, *rankDate, limit)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return results, *rankDate, nil
+}
+
 // ScreeningResult represents screening result
 type ScreeningResult struct {
 	Date        time.Time
@@ -5,6 +5,7 @@ import "time"
 // Config는 종목 선정 전략의 전체 설정
 type Config struct {
 	Meta         Meta         `yaml:"meta" json:"meta"`
+	Account      Account      `yaml:"account" json:"account"`
 	Universe     Universe     `yaml:"universe" json:"universe"`
 	Signals      Signals      `yaml:"signals" json:"signals"`
 	Screening    Screening    `yaml:"screening" json:"screening"`
@@ -30,6 +31,12 @@ type Window struct {
 	End   string `yaml:"end" json:"end"`     // HH:MM
 }
 
+// Account 계좌 배분 (전략 하나 = 계좌 하나의 자본 일부)
+type Account struct {
+	ID         string  `yaml:"id" json:"id"`                   // KIS_ACCOUNTS 계좌 ID
+	CapitalPct float64 `yaml:"capital_pct" json:"capital_pct"` // 계좌 자본 중 배분 비율 (0 < pct ≤ 1)
+}
+
 // Universe S1: 투자 가능 풀
 type Universe struct {
 	ExcludeKRXFlags []string        `yaml:"exclude_krx_flags" json:"exclude_krx_flags"`
@@ -121,7 +128,10 @@ type Overheat struct {
 
 type Fundamentals struct {
 	NetIncomeTTMMin int64   `yaml:"net_income_ttm_min" json:"net_income_ttm_min"`
+	PERMin          float64 `yaml:"per_min" json:"per_min"`
 	PERMax          float64 `yaml:"per_max" json:"per_max"`
+	PBRMin          float64 `yaml:"pbr_min" json:"pbr_min"`
+	ROEMin          float64 `yaml:"roe_min" json:"roe_min"` // %
 }
 
 type Volatility struct {
@@ -241,9 +251,50 @@ type SlippageSegment struct {
 
 // Exit 청산 전략
 type Exit struct {
-	Mode  string    `yaml:"mode" json:"mode"` // FIXED | ATR
-	Fixed ExitFixed `yaml:"fixed" json:"fixed"`
-	ATR   ExitATR   `yaml:"atr" json:"atr"`
+	Mode        string         `yaml:"mode" json:"mode"` // FIXED | ATR
+	UseATRBased bool           `yaml:"use_atr_based" json:"use_atr_based"`
+	Fixed       ExitFixed      `yaml:"fixed" json:"fixed"`
+	ATR         ExitATR        `yaml:"atr" json:"atr"`
+	TakeProfit  ExitTakeProfit `yaml:"take_profit" json:"take_profit"`
+	StopLoss    ExitStopLoss   `yaml:"stop_loss" json:"stop_loss"`
+	Protection  ExitProtection `yaml:"protection" json:"protection"`
+
+	// 모니터링
+	CheckIntervalSeconds int  `yaml:"check_interval_seconds" json:"check_interval_seconds"`
+	TickDriven           bool `yaml:"tick_driven" json:"tick_driven"`
+	StaleTickSeconds     int  `yaml:"stale_tick_seconds" json:"stale_tick_seconds"`
+
+	TimeEvent ExitTimeEvent `yaml:"time_event" json:"time_event"`
+}
+
+// ExitTakeProfit 3단계 분할 익절 (ATR 배수, [min, max]% clamp)
+type ExitTakeProfit struct {
+	TP1 ExitTakeProfitLevel `yaml:"tp1" json:"tp1"`
+	TP2 ExitTakeProfitLevel `yaml:"tp2" json:"tp2"`
+	TP3 ExitTakeProfitLevel `yaml:"tp3" json:"tp3"`
+}
+
+type ExitTakeProfitLevel struct {
+	ATRMultiplier float64 `yaml:"atr_multiplier" json:"atr_multiplier"`
+	MinPercent    float64 `yaml:"min_percent" json:"min_percent"`
+	MaxPercent    float64 `yaml:"max_percent" json:"max_percent"`
+	SellPercent   float64 `yaml:"sell_percent" json:"sell_percent"`
+}
+
+// ExitStopLoss 2단계 손절 + 하드 스탑 (%)
+type ExitStopLoss struct {
+	FirstStopPercent     float64 `yaml:"first_stop_percent" json:"first_stop_percent"`
+	FirstStopSellPercent float64 `yaml:"first_stop_sell_percent" json:"first_stop_sell_percent"`
+	SecondStopPercent    float64 `yaml:"second_stop_percent" json:"second_stop_percent"`
+	HardStopPercent      float64 `yaml:"hard_stop_percent" json:"hard_stop_percent"`
+}
+
+// ExitProtection Stop Floor / HWM 트레일링
+type ExitProtection struct {
+	StopFloorBuffer    float64 `yaml:"stop_floor_buffer" json:"stop_floor_buffer"`
+	TrailATRMultiplier float64 `yaml:"trail_atr_multiplier" json:"trail_atr_multiplier"`
+	TrailMinPercent    float64 `yaml:"trail_min_percent" json:"trail_min_percent"`
+	TrailMaxPercent    float64 `yaml:"trail_max_percent" json:"trail_max_percent"`
 }
 
 type ExitFixed struct {
@@ -268,6 +319,36 @@ type ExitATR struct {
 	MinTrailPct float64 `yaml:"min_trail_pct" json:"min_trail_pct"`
 }
 
+// ExitTimeEvent 시간/이벤트 기반 청산 (가격 규칙과 별도로 적용)
+type ExitTimeEvent struct {
+	MaxHoldingDays int            `yaml:"max_holding_days" json:"max_holding_days"` // 0 = 비활성
+	Stale          ExitStale      `yaml:"stale" json:"stale"`
+	RankDrop       ExitRankDrop   `yaml:"rank_drop" json:"rank_drop"`
+	Disclosure     ExitDisclosure `yaml:"disclosure" json:"disclosure"`
+	Delisting      ExitDelisting  `yaml:"delisting" json:"delisting"`
+}
+
+type ExitStale struct {
+	Enable  bool    `yaml:"enable" json:"enable"`
+	Days    int     `yaml:"days" json:"days"`
+	BandPct float64 `yaml:"band_pct" json:"band_pct"` // |수익률| < band_pct 이면 정체 (0.02 = 2%)
+}
+
+type ExitRankDrop struct {
+	Enable bool `yaml:"enable" json:"enable"`
+	TopK   int  `yaml:"top_k" json:"top_k"`
+}
+
+type ExitDisclosure struct {
+	Enable        bool    `yaml:"enable" json:"enable"`
+	ImpactMax     float64 `yaml:"impact_max" json:"impact_max"` // S2 이벤트 임팩트 ≤ impact_max 이면 청산 (-1.0 ~ 0)
+	LookbackHours int     `yaml:"lookback_hours" json:"lookback_hours"`
+}
+
+type ExitDelisting struct {
+	Enable bool `yaml:"enable" json:"enable"`
+}
+
 // RiskOverlay 리스크 조정
 type RiskOverlay struct {
 	NasdaqAdjust NasdaqAdjust `yaml:"nasdaq_adjust" json:"nasdaq_adjust"`
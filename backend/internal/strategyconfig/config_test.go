@@ -4,6 +4,8 @@ import (
 	"math"
 	"os"
 	"testing"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
 )
 
 func TestLoad(t *testing.T) {
@@ -175,3 +177,114 @@ func TestValidateWeightsSum(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadDir(t *testing.T) {
+	strategies, err := LoadDir("../../config/strategy")
+	if err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	if len(strategies) == 0 {
+		t.Fatal("expected at least one strategy")
+	}
+
+	registry, err := NewRegistry(strategies, []string{"default"})
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	s, ok := registry.Get("korea_equity_v13")
+	if !ok {
+		t.Fatal("expected korea_equity_v13 in registry")
+	}
+	if b := s.Binding(); b.AccountID != "default" || b.CapitalPct != 1.0 {
+		t.Errorf("expected default account with full capital, got %+v", b)
+	}
+	if len(s.Hash) != 64 {
+		t.Errorf("expected 64 char hash, got %d", len(s.Hash))
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	strategy := func(id, account string, pct float64) *Strategy {
+		cfg := &Config{}
+		cfg.Meta.StrategyID = id
+		cfg.Account = Account{ID: account, CapitalPct: pct}
+		return &Strategy{Config: cfg, Path: id + ".yaml"}
+	}
+	accounts := []string{"main", "isa"}
+
+	tests := []struct {
+		name       string
+		strategies []*Strategy
+		valid      bool
+	}{
+		{"split account", []*Strategy{strategy("a", "main", 0.6), strategy("b", "main", 0.4), strategy("c", "isa", 1.0)}, true},
+		{"over-allocated", []*Strategy{strategy("a", "main", 0.7), strategy("b", "main", 0.4)}, false},
+		{"duplicate id", []*Strategy{strategy("a", "main", 0.5), strategy("a", "isa", 0.5)}, false},
+		{"unknown account", []*Strategy{strategy("a", "other", 0.5)}, false},
+		{"empty", nil, false},
+	}
+
+	for _, tc := range tests {
+		_, err := NewRegistry(tc.strategies, accounts)
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected valid, got error: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected error, got nil", tc.name)
+		}
+	}
+}
+
+func TestExitTimeEventApplyTo(t *testing.T) {
+	te := ExitTimeEvent{
+		MaxHoldingDays: 40,
+		Stale:          ExitStale{Enable: true, Days: 15, BandPct: 0.02},
+		RankDrop:       ExitRankDrop{Enable: true, TopK: 60},
+		Disclosure:     ExitDisclosure{Enable: true, ImpactMax: -0.8, LookbackHours: 48},
+		Delisting:      ExitDelisting{Enable: true},
+	}
+
+	rules := contracts.DefaultExitRulesConfig()
+	te.ApplyTo(rules)
+
+	if rules.MaxHoldingDays != 40 || rules.StaleDays != 15 || rules.RankExitTopK != 60 {
+		t.Errorf("unexpected time rules: max=%d stale=%d topK=%d", rules.MaxHoldingDays, rules.StaleDays, rules.RankExitTopK)
+	}
+	if math.Abs(rules.StaleBandPercent-2.0) > 1e-9 {
+		t.Errorf("expected stale band 2%%, got %v", rules.StaleBandPercent)
+	}
+	if !rules.DisclosureExit || rules.DisclosureImpactThreshold != -0.8 || rules.DisclosureLookbackHours != 48 {
+		t.Errorf("unexpected disclosure rules: %+v", rules)
+	}
+	if !rules.DelistingExit {
+		t.Error("expected delisting exit enabled")
+	}
+
+	// 비활성 규칙은 기본값을 덮어써서 끔
+	off := ExitTimeEvent{Stale: ExitStale{Days: 15, BandPct: 0.02}, RankDrop: ExitRankDrop{TopK: 60}}
+	off.ApplyTo(rules)
+	if rules.MaxHoldingDays != 0 || rules.StaleDays != 0 || rules.StaleBandPercent != 0 || rules.RankExitTopK != 0 {
+		t.Errorf("expected disabled time rules, got %+v", rules)
+	}
+	if rules.DisclosureExit || rules.DelistingExit {
+		t.Error("expected disabled event rules")
+	}
+}
+
+func TestExitApplyTo_ShippedStrategy(t *testing.T) {
+	cfg, _, err := Load("../../config/strategy/korea_equity_v13.yaml")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	rules := contracts.DefaultExitRulesConfig()
+	cfg.Exit.ApplyTo(rules)
+	cfg.Exit.TimeEvent.ApplyTo(rules)
+
+	if rules.SecondStopPercent != -5.0 || rules.TP1SellPercent != 25.0 || !rules.TickDriven {
+		t.Errorf("unexpected price rules: %+v", rules)
+	}
+	if rules.MaxHoldingDays != 40 || rules.RankExitTopK != 60 {
+		t.Errorf("unexpected time rules: max=%d topK=%d", rules.MaxHoldingDays, rules.RankExitTopK)
+	}
+}
@@ -0,0 +1,65 @@
+package strategyconfig
+
+import "github.com/wonny/aegis/v13/backend/internal/contracts"
+
+// ApplyTo는 시간/이벤트 청산 설정을 PositionMonitor 설정(contracts.ExitRulesConfig)에 반영
+// 비활성 규칙은 0/false로 설정 → 전략 YAML이 SSOT
+func (te ExitTimeEvent) ApplyTo(rules *contracts.ExitRulesConfig) {
+	rules.MaxHoldingDays = te.MaxHoldingDays
+
+	rules.StaleDays = 0
+	rules.StaleBandPercent = 0
+	if te.Stale.Enable {
+		rules.StaleDays = te.Stale.Days
+		rules.StaleBandPercent = te.Stale.BandPct * 100 // 0.02 → 2%
+	}
+
+	rules.RankExitTopK = 0
+	if te.RankDrop.Enable {
+		rules.RankExitTopK = te.RankDrop.TopK
+	}
+
+	rules.DisclosureExit = te.Disclosure.Enable
+	if te.Disclosure.Enable {
+		rules.DisclosureImpactThreshold = te.Disclosure.ImpactMax
+		rules.DisclosureLookbackHours = te.Disclosure.LookbackHours
+	}
+
+	rules.DelistingExit = te.Delisting.Enable
+}
+
+// ApplyTo는 가격 기반 청산 사다리(익절/손절/보호)와 모니터링 주기를 PositionMonitor 설정에 반영
+func (e Exit) ApplyTo(rules *contracts.ExitRulesConfig) {
+	rules.UseATRBased = e.UseATRBased
+
+	rules.TP1ATRMultiplier = e.TakeProfit.TP1.ATRMultiplier
+	rules.TP1MinPercent = e.TakeProfit.TP1.MinPercent
+	rules.TP1MaxPercent = e.TakeProfit.TP1.MaxPercent
+	rules.TP1SellPercent = e.TakeProfit.TP1.SellPercent
+	rules.TP2ATRMultiplier = e.TakeProfit.TP2.ATRMultiplier
+	rules.TP2MinPercent = e.TakeProfit.TP2.MinPercent
+	rules.TP2MaxPercent = e.TakeProfit.TP2.MaxPercent
+	rules.TP2SellPercent = e.TakeProfit.TP2.SellPercent
+	rules.TP3ATRMultiplier = e.TakeProfit.TP3.ATRMultiplier
+	rules.TP3MinPercent = e.TakeProfit.TP3.MinPercent
+	rules.TP3MaxPercent = e.TakeProfit.TP3.MaxPercent
+	rules.TP3SellPercent = e.TakeProfit.TP3.SellPercent
+
+	rules.FirstStopPercent = e.StopLoss.FirstStopPercent
+	rules.FirstStopSellPercent = e.StopLoss.FirstStopSellPercent
+	rules.SecondStopPercent = e.StopLoss.SecondStopPercent
+	rules.HardStopPercent = e.StopLoss.HardStopPercent
+
+	rules.StopFloorBuffer = e.Protection.StopFloorBuffer
+	rules.TrailATRMultiplier = e.Protection.TrailATRMultiplier
+	rules.TrailMinPercent = e.Protection.TrailMinPercent
+	rules.TrailMaxPercent = e.Protection.TrailMaxPercent
+
+	if e.CheckIntervalSeconds > 0 {
+		rules.CheckIntervalSeconds = e.CheckIntervalSeconds
+	}
+	rules.TickDriven = e.TickDriven
+	if e.StaleTickSeconds > 0 {
+		rules.StaleTickSeconds = e.StaleTickSeconds
+	}
+}
@@ -0,0 +1,134 @@
+package strategyconfig
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
+)
+
+// Strategy is a loaded strategy file
+type Strategy struct {
+	Config *Config
+	YAML   []byte
+	Path   string
+	Hash   string
+}
+
+// ID returns the strategy ID (meta.strategy_id)
+func (s *Strategy) ID() string {
+	return s.Config.Meta.StrategyID
+}
+
+// Binding returns the strategy's account binding
+func (s *Strategy) Binding() contracts.StrategyBinding {
+	return contracts.StrategyBinding{
+		StrategyID: s.Config.Meta.StrategyID,
+		Version:    s.Config.Meta.Version,
+		AccountID:  s.Config.Account.ID,
+		CapitalPct: s.Config.Account.CapitalPct,
+	}
+}
+
+// LoadDir loads every *.yaml/*.yml strategy in dir (파일명 순)
+func LoadDir(dir string) ([]*Strategy, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read strategy dir: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	strategies := make([]*Strategy, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		cfg, data, err := Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		hash, err := Hash(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: hash: %w", name, err)
+		}
+		strategies = append(strategies, &Strategy{Config: cfg, YAML: data, Path: path, Hash: hash})
+	}
+	return strategies, nil
+}
+
+// Registry holds the strategies bound to trading accounts
+// ⭐ SSOT: 운용 전략 목록과 계좌 배분 검증은 여기서만
+// - strategy_id 중복 금지
+// - account.id는 설정된 계좌(KIS_ACCOUNTS) 중 하나
+// - 같은 계좌 전략들의 capital_pct 합 ≤ 1.0
+type Registry struct {
+	strategies []*Strategy
+	byID       map[string]*Strategy
+}
+
+// NewRegistry validates the strategies against the configured account IDs
+func NewRegistry(strategies []*Strategy, accountIDs []string) (*Registry, error) {
+	if len(strategies) == 0 {
+		return nil, fmt.Errorf("no strategies configured")
+	}
+
+	known := make(map[string]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		known[id] = true
+	}
+
+	r := &Registry{
+		strategies: strategies,
+		byID:       make(map[string]*Strategy, len(strategies)),
+	}
+	allocated := make(map[string]float64)
+	for _, s := range strategies {
+		if _, dup := r.byID[s.ID()]; dup {
+			return nil, fmt.Errorf("duplicate strategy_id %q (%s)", s.ID(), s.Path)
+		}
+		r.byID[s.ID()] = s
+
+		account := s.Config.Account.ID
+		if !known[account] {
+			return nil, fmt.Errorf("strategy %q: unknown account %q (configured: %v)", s.ID(), account, accountIDs)
+		}
+		allocated[account] += s.Config.Account.CapitalPct
+	}
+
+	for account, pct := range allocated {
+		if pct > 1+1e-9 {
+			return nil, fmt.Errorf("account %q: strategies allocate %.1f%% of capital (max 100%%)", account, math.Round(pct*1000)/10)
+		}
+	}
+
+	return r, nil
+}
+
+// Strategies returns the strategies in load order
+func (r *Registry) Strategies() []*Strategy {
+	return r.strategies
+}
+
+// Get returns a strategy by ID
+func (r *Registry) Get(id string) (*Strategy, bool) {
+	s, ok := r.byID[id]
+	return s, ok
+}
+
+// Bindings returns the account bindings of all strategies
+func (r *Registry) Bindings() []contracts.StrategyBinding {
+	bindings := make([]contracts.StrategyBinding, len(r.strategies))
+	for i, s := range r.strategies {
+		bindings[i] = s.Binding()
+	}
+	return bindings
+}
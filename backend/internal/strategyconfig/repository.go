@@ -0,0 +1,143 @@
+package strategyconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AccountRecord is a trading account row (ops.accounts)
+type AccountRecord struct {
+	ID              string    `json:"account_id"`
+	AccountNoMasked string    `json:"account_no_masked"`
+	IsVirtual       bool      `json:"is_virtual"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// StrategyRecord is a registered strategy row (ops.strategies)
+type StrategyRecord struct {
+	ID         string    `json:"strategy_id"`
+	AccountID  string    `json:"account_id"`
+	CapitalPct float64   `json:"capital_pct"`
+	Version    string    `json:"version"`
+	ConfigHash string    `json:"config_hash"`
+	ConfigPath string    `json:"config_path"`
+	Enabled    bool      `json:"enabled"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Repository persists the account/strategy registry
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new registry repository
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// Sync upserts the configured accounts and strategies; strategies no longer configured are disabled
+func (r *Repository) Sync(ctx context.Context, accounts []AccountRecord, registry *Registry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, acc := range accounts {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO ops.accounts (account_id, account_no_masked, is_virtual)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (account_id) DO UPDATE SET
+				account_no_masked = EXCLUDED.account_no_masked,
+				is_virtual = EXCLUDED.is_virtual,
+				updated_at = NOW()
+		`, acc.ID, acc.AccountNoMasked, acc.IsVirtual)
+		if err != nil {
+			return fmt.Errorf("upsert account %s: %w", acc.ID, err)
+		}
+	}
+
+	ids := make([]string, 0, len(registry.Strategies()))
+	for _, s := range registry.Strategies() {
+		b := s.Binding()
+		_, err := tx.Exec(ctx, `
+			INSERT INTO ops.strategies (strategy_id, account_id, capital_pct, version, config_hash, config_path, enabled)
+			VALUES ($1, $2, $3, $4, $5, $6, TRUE)
+			ON CONFLICT (strategy_id) DO UPDATE SET
+				account_id = EXCLUDED.account_id,
+				capital_pct = EXCLUDED.capital_pct,
+				version = EXCLUDED.version,
+				config_hash = EXCLUDED.config_hash,
+				config_path = EXCLUDED.config_path,
+				enabled = TRUE,
+				updated_at = NOW()
+		`, b.StrategyID, b.AccountID, b.CapitalPct, b.Version, s.Hash, s.Path)
+		if err != nil {
+			return fmt.Errorf("upsert strategy %s: %w", b.StrategyID, err)
+		}
+		ids = append(ids, b.StrategyID)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE ops.strategies SET enabled = FALSE, updated_at = NOW()
+		WHERE enabled AND NOT (strategy_id = ANY($1))
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("disable removed strategies: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListAccounts returns the registered accounts
+func (r *Repository) ListAccounts(ctx context.Context) ([]AccountRecord, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT account_id, account_no_masked, is_virtual, updated_at
+		FROM ops.accounts
+		ORDER BY account_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]AccountRecord, 0)
+	for rows.Next() {
+		var a AccountRecord
+		if err := rows.Scan(&a.ID, &a.AccountNoMasked, &a.IsVirtual, &a.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan account: %w", err)
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, rows.Err()
+}
+
+// ListStrategies returns the registered strategies (enabledOnly = 현재 설정된 전략만)
+func (r *Repository) ListStrategies(ctx context.Context, enabledOnly bool) ([]StrategyRecord, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT strategy_id, account_id, capital_pct, version, config_hash, config_path, enabled, updated_at
+		FROM ops.strategies
+		WHERE enabled OR NOT $1
+		ORDER BY account_id, strategy_id
+	`, enabledOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query strategies: %w", err)
+	}
+	defer rows.Close()
+
+	strategies := make([]StrategyRecord, 0)
+	for rows.Next() {
+		var s StrategyRecord
+		if err := rows.Scan(&s.ID, &s.AccountID, &s.CapitalPct, &s.Version, &s.ConfigHash, &s.ConfigPath, &s.Enabled, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan strategy: %w", err)
+		}
+		strategies = append(strategies, s)
+	}
+	return strategies, rows.Err()
+}
@@ -6,6 +6,8 @@ import (
 	"math"
 	"regexp"
 	"time"
+
+	"github.com/wonny/aegis/v13/backend/internal/contracts"
 )
 
 // ValidationError 검증 실패 (프로그램 중단)
@@ -48,7 +50,20 @@ func Validate(cfg *Config) error {
 		return ValidationError{"meta.execution_window", "start must be before end"}
 	}
 
+	// === Account ===
+	if cfg.Account.ID == "" {
+		return ValidationError{"account.id", "required"}
+	}
+	if cfg.Account.CapitalPct <= 0 || cfg.Account.CapitalPct > 1 {
+		return ValidationError{"account.capital_pct", "must be in (0, 1]"}
+	}
+
 	// === Universe ===
+	for i, flag := range cfg.Universe.ExcludeKRXFlags {
+		if !contracts.IsKnownKRXFlag(flag) {
+			return ValidationError{fmt.Sprintf("universe.exclude_krx_flags[%d]", i), fmt.Sprintf("unknown flag '%s'", flag)}
+		}
+	}
 	if cfg.Universe.Filters.MarketcapMinKRW <= 0 {
 		return ValidationError{"universe.filters.marketcap_min_krw", "must be > 0"}
 	}
@@ -184,6 +199,9 @@ func Validate(cfg *Config) error {
 	if cfg.Exit.Mode != "FIXED" && cfg.Exit.Mode != "ATR" {
 		return ValidationError{"exit.mode", "must be FIXED or ATR"}
 	}
+	if err := validateExitTimeEvent(cfg.Exit.TimeEvent); err != nil {
+		return err
+	}
 
 	// === RiskOverlay ===
 	if cfg.RiskOverlay.NasdaqAdjust.Enable {
@@ -273,6 +291,36 @@ func validateWeightsSum(weights []float64, target float64, epsilon float64) erro
 	return nil
 }
 
+// validateExitTimeEvent는 시간/이벤트 기반 청산 설정을 검증
+func validateExitTimeEvent(te ExitTimeEvent) error {
+	if te.MaxHoldingDays < 0 {
+		return ValidationError{"exit.time_event.max_holding_days", "must be >= 0"}
+	}
+	if te.Stale.Enable {
+		if te.Stale.Days <= 0 {
+			return ValidationError{"exit.time_event.stale.days", "must be > 0"}
+		}
+		if err := validatePctRange(te.Stale.BandPct, "exit.time_event.stale.band_pct"); err != nil {
+			return err
+		}
+		if te.MaxHoldingDays > 0 && te.Stale.Days >= te.MaxHoldingDays {
+			return ValidationError{"exit.time_event.stale.days", "must be < max_holding_days"}
+		}
+	}
+	if te.RankDrop.Enable && te.RankDrop.TopK <= 0 {
+		return ValidationError{"exit.time_event.rank_drop.top_k", "must be > 0"}
+	}
+	if te.Disclosure.Enable {
+		if te.Disclosure.ImpactMax < -1 || te.Disclosure.ImpactMax >= 0 {
+			return ValidationError{"exit.time_event.disclosure.impact_max", "must be in [-1, 0)"}
+		}
+		if te.Disclosure.LookbackHours <= 0 {
+			return ValidationError{"exit.time_event.disclosure.lookback_hours", "must be > 0"}
+		}
+	}
+	return nil
+}
+
 // validatePctRange는 퍼센트 값이 0~1 범위인지 검증
 func validatePctRange(pct float64, field string) error {
 	if pct < 0 || pct > 1 {
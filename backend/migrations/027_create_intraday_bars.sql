@@ -0,0 +1,37 @@
+-- Migration: 027_create_intraday_bars
+-- Description: Create intraday OHLCV bars aggregated from realtime ticks
+-- Date: 2026-10-18
+
+-- 분봉 테이블 (1분/5분, 실시간 체결 집계)
+CREATE TABLE IF NOT EXISTS data.intraday_bars (
+    stock_code       VARCHAR(20) NOT NULL,
+    interval_minutes SMALLINT NOT NULL,        -- 1, 5
+    bar_time         TIMESTAMPTZ NOT NULL,     -- 봉 시작 시각
+    open_price       BIGINT NOT NULL,
+    high_price       BIGINT NOT NULL,
+    low_price        BIGINT NOT NULL,
+    close_price      BIGINT NOT NULL,
+    volume           BIGINT NOT NULL DEFAULT 0,  -- 누적 거래량 차분 합계
+    trade_value      BIGINT NOT NULL DEFAULT 0,  -- Σ(체결가 × 거래량)
+    vwap             NUMERIC(14,2),
+    tick_count       INT NOT NULL DEFAULT 0,
+    created_at       TIMESTAMPTZ DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (stock_code, interval_minutes, bar_time)
+);
+
+-- 인덱스 생성
+CREATE INDEX IF NOT EXISTS idx_intraday_bars_time
+    ON data.intraday_bars(interval_minutes, bar_time);
+
+-- 코멘트 추가
+COMMENT ON TABLE data.intraday_bars IS '실시간 체결(PriceTick) 집계 분봉 (VWAP 집행, 청산 백테스트, 분봉 차트)';
+COMMENT ON COLUMN data.intraday_bars.interval_minutes IS '봉 주기 (분)';
+COMMENT ON COLUMN data.intraday_bars.bar_time IS '봉 시작 시각 (구간: [bar_time, bar_time + interval))';
+COMMENT ON COLUMN data.intraday_bars.volume IS '누적 거래량 차분으로 계산한 봉 거래량';
+
+-- 검증
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 027: intraday_bars table created successfully';
+END $$;
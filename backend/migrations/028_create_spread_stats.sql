@@ -0,0 +1,35 @@
+-- Migration: 028_create_spread_stats
+-- Description: Create daily bid-ask spread statistics from realtime order book
+-- Date: 2026-10-18
+
+-- 일별 호가 스프레드 통계 (S1 spread 필터 입력)
+CREATE TABLE IF NOT EXISTS data.daily_spread_stats (
+    stock_code        VARCHAR(20) NOT NULL,
+    trade_date        DATE NOT NULL,
+    avg_spread_pct    NUMERIC(10,6) NOT NULL,   -- (ask1-bid1)/((ask1+bid1)/2) 평균
+    min_spread_pct    NUMERIC(10,6),
+    max_spread_pct    NUMERIC(10,6),
+    sample_count      INT NOT NULL DEFAULT 0,   -- 정규장 호가 스냅샷 수
+    avg_bid1_qty      BIGINT,
+    avg_ask1_qty      BIGINT,
+    avg_total_bid_qty BIGINT,
+    avg_total_ask_qty BIGINT,
+    created_at        TIMESTAMPTZ DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (stock_code, trade_date)
+);
+
+-- 인덱스 생성
+CREATE INDEX IF NOT EXISTS idx_daily_spread_stats_date
+    ON data.daily_spread_stats(trade_date);
+
+-- 코멘트 추가
+COMMENT ON TABLE data.daily_spread_stats IS '실시간 호가(H0STASP0) 기반 일별 스프레드 통계';
+COMMENT ON COLUMN data.daily_spread_stats.avg_spread_pct IS 'universe.filters.spread.formula 정의의 정규장 평균 스프레드';
+COMMENT ON COLUMN data.daily_spread_stats.sample_count IS '09:00~15:20 호가 스냅샷 표본 수 (동시호가 제외)';
+
+-- 검증
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 028: daily_spread_stats table created successfully';
+END $$;
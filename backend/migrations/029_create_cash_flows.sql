@@ -0,0 +1,48 @@
+-- Migration: 029_create_cash_flows
+-- Description: 외부 입출금 원장 (TWR/MWR 성과 계산용) + 스냅샷 현금흐름 컬럼
+-- Date: 2026-10-18
+
+-- ============================================================
+-- audit.cash_flows: 입금/출금/배당 원장
+-- amount 부호: 입금/배당 +, 출금 -
+-- DETECTED 항목은 PENDING으로 기록되며 CONFIRMED 이후에만 수익률 계산에 반영
+-- ============================================================
+CREATE TABLE IF NOT EXISTS audit.cash_flows (
+    id          BIGSERIAL PRIMARY KEY,
+    flow_date   DATE NOT NULL,
+    flow_type   VARCHAR(20) NOT NULL,               -- DEPOSIT, WITHDRAWAL, DIVIDEND
+    amount      NUMERIC(20,2) NOT NULL,
+    stock_code  VARCHAR(20),                        -- DIVIDEND 전용
+    source      VARCHAR(20) NOT NULL DEFAULT 'MANUAL', -- MANUAL, DETECTED
+    status      VARCHAR(20) NOT NULL DEFAULT 'CONFIRMED', -- CONFIRMED, PENDING, REJECTED
+    memo        TEXT,
+    created_at  TIMESTAMPTZ DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT chk_cash_flows_type CHECK (flow_type IN ('DEPOSIT', 'WITHDRAWAL', 'DIVIDEND')),
+    CONSTRAINT chk_cash_flows_status CHECK (status IN ('CONFIRMED', 'PENDING', 'REJECTED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_flows_date ON audit.cash_flows(flow_date);
+CREATE INDEX IF NOT EXISTS idx_cash_flows_status ON audit.cash_flows(status) WHERE status = 'PENDING';
+
+COMMENT ON TABLE audit.cash_flows IS '외부 현금흐름 원장 (입출금/배당)';
+COMMENT ON COLUMN audit.cash_flows.amount IS '금액 (입금/배당 +, 출금 -)';
+COMMENT ON COLUMN audit.cash_flows.source IS 'MANUAL: 수동 입력, DETECTED: 잔고 급변 자동 감지';
+COMMENT ON COLUMN audit.cash_flows.status IS 'CONFIRMED만 수익률 계산에 반영';
+
+-- ============================================================
+-- audit.daily_snapshots: 현금흐름 보정 컬럼
+-- ============================================================
+ALTER TABLE audit.daily_snapshots
+    ADD COLUMN IF NOT EXISTS net_flow  NUMERIC(20,2) DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS daily_pnl NUMERIC(20,2) DEFAULT 0;
+
+COMMENT ON COLUMN audit.daily_snapshots.net_flow IS '전일 스냅샷 이후 외부 순입금액 (확정분)';
+COMMENT ON COLUMN audit.daily_snapshots.daily_pnl IS '일일 손익 (평가액 변동 - 순입금)';
+COMMENT ON COLUMN audit.daily_snapshots.daily_return IS '일일 시간가중수익률 (Modified Dietz)';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 029 completed: audit.cash_flows created, daily_snapshots flow columns added';
+END $$;
@@ -0,0 +1,58 @@
+-- Migration: 030_create_trade_ledger
+-- Description: 실현손익 원장 (FIFO 랏 매칭) + 주문 청산사유/전략버전 컬럼
+-- Date: 2026-10-18
+
+-- ============================================================
+-- execution.orders: 청산 사유 / 전략 버전
+-- exit_reason: 매도 주문의 contracts.ExitReason (TP1, HARD_STOP 등)
+-- strategy_version: 주문을 생성한 파이프라인 버전 (매수 랏 귀속 기준)
+-- ============================================================
+ALTER TABLE execution.orders
+    ADD COLUMN IF NOT EXISTS exit_reason      VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS strategy_version VARCHAR(50);
+
+COMMENT ON COLUMN execution.orders.exit_reason IS '매도 청산 사유 (contracts.ExitReason)';
+COMMENT ON COLUMN execution.orders.strategy_version IS '주문 생성 전략 버전';
+
+-- ============================================================
+-- audit.closed_lots: 매수 랏 ↔ 매도 체결 FIFO 매칭 결과
+-- 매도 1건이 여러 매수 랏에 걸치면 랏별로 분할 기록
+-- 수수료/세금은 체결 수량 비율로 안분
+-- 원장은 execution.trades 전체로부터 재구성 (audit ledger rebuild)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS audit.closed_lots (
+    id               BIGSERIAL PRIMARY KEY,
+    buy_trade_id     BIGINT NOT NULL,
+    sell_trade_id    BIGINT NOT NULL,
+    stock_code       VARCHAR(20) NOT NULL,
+    strategy_version VARCHAR(50) NOT NULL DEFAULT '',
+    entry_time       TIMESTAMPTZ NOT NULL,
+    exit_time        TIMESTAMPTZ NOT NULL,
+    entry_price      NUMERIC(12,2) NOT NULL,
+    exit_price       NUMERIC(12,2) NOT NULL,
+    entry_qty        INT NOT NULL,                  -- 원 매수 랏 수량 (라운드트립 완결 판단)
+    quantity         INT NOT NULL,                  -- 이번 매칭 수량
+    holding_days     INT NOT NULL,
+    buy_fee          NUMERIC(14,2) NOT NULL DEFAULT 0,
+    sell_fee         NUMERIC(14,2) NOT NULL DEFAULT 0,
+    tax              NUMERIC(14,2) NOT NULL DEFAULT 0,
+    gross_pnl        NUMERIC(20,2) NOT NULL,
+    net_pnl          NUMERIC(20,2) NOT NULL,
+    exit_reason      VARCHAR(30) NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT uq_closed_lots_match UNIQUE (buy_trade_id, sell_trade_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_closed_lots_exit_time ON audit.closed_lots(exit_time);
+CREATE INDEX IF NOT EXISTS idx_closed_lots_buy ON audit.closed_lots(buy_trade_id);
+CREATE INDEX IF NOT EXISTS idx_closed_lots_strategy ON audit.closed_lots(strategy_version);
+
+COMMENT ON TABLE audit.closed_lots IS '실현손익 원장 (FIFO 랏 매칭)';
+COMMENT ON COLUMN audit.closed_lots.gross_pnl IS '(매도가 - 매수가) × 수량';
+COMMENT ON COLUMN audit.closed_lots.net_pnl IS 'gross_pnl - 매수수수료 - 매도수수료 - 세금';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 030 completed: audit.closed_lots created, orders exit_reason/strategy_version added';
+END $$;
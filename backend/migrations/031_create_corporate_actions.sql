@@ -0,0 +1,94 @@
+-- Migration: 031_create_corporate_actions
+-- Description: 기업행위(분할/무상·유상증자/배당) 원장 + 수정계수 + 수정주가 뷰
+-- Date: 2026-10-18
+
+-- ============================================================
+-- data.corporate_actions: 기업행위 원장 (DART 자동 수집 + 파일 임포트)
+-- ratio 의미:
+--   SPLIT         : 구주 1주당 신주 수 (액면분할 1→5 = 5, 병합 10→1 = 0.1)
+--   BONUS         : 구주 1주당 무상 배정 주식 수 (주식배당 포함)
+--   RIGHTS        : 구주 1주당 유상 배정 주식 수 (subscription_price 필수)
+--   CASH_DIVIDEND : 미사용 (dividend_amount 필수, 주당 배당금)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS data.corporate_actions (
+    id                 BIGSERIAL PRIMARY KEY,
+    stock_code         VARCHAR(20) NOT NULL,
+    ex_date            DATE NOT NULL,                   -- 권리락/배당락/분할 적용일 (이 날부터 새 기준 가격)
+    action_type        VARCHAR(20) NOT NULL,
+    ratio              NUMERIC(20,10),
+    subscription_price NUMERIC(14,2),
+    dividend_amount    NUMERIC(14,2),
+    source             VARCHAR(20) NOT NULL DEFAULT 'IMPORT', -- DART, IMPORT
+    source_ref         VARCHAR(50),                     -- DART 접수번호 등
+    memo               TEXT,
+    created_at         TIMESTAMPTZ DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT uq_corporate_actions UNIQUE (stock_code, ex_date, action_type),
+    CONSTRAINT chk_corporate_actions_type CHECK (action_type IN ('SPLIT', 'BONUS', 'RIGHTS', 'CASH_DIVIDEND'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_corporate_actions_ex_date ON data.corporate_actions(ex_date);
+
+COMMENT ON TABLE data.corporate_actions IS '기업행위 원장 (수정주가 산출 입력)';
+COMMENT ON COLUMN data.corporate_actions.ex_date IS '적용일: 이 날짜 이전 가격이 수정 대상';
+
+-- ============================================================
+-- data.price_adjustment_factors: 기업행위별 수정계수
+-- ex_date 이전 가격 × price_factor, 거래량 × volume_factor
+-- 배당/유상증자 계수는 직전 거래일 종가 기준 (adjustment engine이 계산)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS data.price_adjustment_factors (
+    action_id     BIGINT PRIMARY KEY REFERENCES data.corporate_actions(id) ON DELETE CASCADE,
+    stock_code    VARCHAR(20) NOT NULL,
+    ex_date       DATE NOT NULL,
+    price_factor  NUMERIC(20,12) NOT NULL,
+    volume_factor NUMERIC(20,12) NOT NULL DEFAULT 1,
+    prev_close    NUMERIC(12,2),
+    computed_at   TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT chk_adjustment_factors_positive CHECK (price_factor > 0 AND volume_factor > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_adjustment_factors_stock_date ON data.price_adjustment_factors(stock_code, ex_date);
+
+COMMENT ON TABLE data.price_adjustment_factors IS '수정계수 (기업행위 1건당 1행)';
+
+-- ============================================================
+-- data.cumulative_adjustment: 특정 일자 가격에 적용할 누적 수정계수
+-- (해당 일자 이후 적용일을 가진 모든 계수의 곱)
+-- ============================================================
+CREATE OR REPLACE FUNCTION data.cumulative_adjustment(p_code VARCHAR, p_date DATE)
+RETURNS TABLE (price_factor NUMERIC, volume_factor NUMERIC)
+LANGUAGE SQL STABLE AS $$
+    SELECT COALESCE(EXP(SUM(LN(f.price_factor))), 1)::NUMERIC,
+           COALESCE(EXP(SUM(LN(f.volume_factor))), 1)::NUMERIC
+    FROM data.price_adjustment_factors f
+    WHERE f.stock_code = p_code AND f.ex_date > p_date
+$$;
+
+-- ============================================================
+-- data.daily_prices_adjusted: 역산 수정주가 (최신 가격 = 원주가)
+-- 컬럼은 data.daily_prices와 동일 + adj_factor
+-- ============================================================
+CREATE OR REPLACE VIEW data.daily_prices_adjusted AS
+SELECT
+    p.stock_code,
+    p.trade_date,
+    ROUND(p.open_price * a.price_factor)::NUMERIC(12,2)  AS open_price,
+    ROUND(p.high_price * a.price_factor)::NUMERIC(12,2)  AS high_price,
+    ROUND(p.low_price * a.price_factor)::NUMERIC(12,2)   AS low_price,
+    ROUND(p.close_price * a.price_factor)::NUMERIC(12,2) AS close_price,
+    ROUND(p.volume * a.volume_factor)::BIGINT             AS volume,
+    p.trading_value,
+    p.created_at,
+    a.price_factor                                        AS adj_factor
+FROM data.daily_prices p
+CROSS JOIN LATERAL data.cumulative_adjustment(p.stock_code, p.trade_date) a;
+
+COMMENT ON VIEW data.daily_prices_adjusted IS '수정주가 일봉 (contracts.PriceBasisAdjusted)';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 031 completed: corporate_actions, price_adjustment_factors, daily_prices_adjusted created';
+END $$;
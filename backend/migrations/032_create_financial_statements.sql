@@ -0,0 +1,75 @@
+-- Migration: 032_create_financial_statements
+-- Description: DART 정기보고서 재무제표 원장 + fundamentals 시점(point-in-time)/TTM 컬럼
+-- Date: 2026-10-18
+
+-- ============================================================
+-- data.stocks.corp_code: DART 고유번호 (corpCode.xml 동기화)
+-- ============================================================
+ALTER TABLE data.stocks ADD COLUMN IF NOT EXISTS corp_code VARCHAR(8);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stocks_corp_code
+    ON data.stocks(corp_code) WHERE corp_code IS NOT NULL;
+
+COMMENT ON COLUMN data.stocks.corp_code IS 'DART 고유번호 (8자리)';
+
+-- ============================================================
+-- data.financial_statements: 정기보고서 재무제표 (제출본별 1행)
+-- 정정공시는 새 rcept_no로 별도 저장 → filed_date 기준 시점 조회 가능
+-- 손익 항목은 회계연도 누적(YTD), 재무상태 항목은 기말 잔액
+-- ============================================================
+CREATE TABLE IF NOT EXISTS data.financial_statements (
+    stock_code        VARCHAR(20) NOT NULL,
+    corp_code         VARCHAR(8) NOT NULL,
+    fiscal_year       INT NOT NULL,
+    fiscal_quarter    INT NOT NULL CHECK (fiscal_quarter BETWEEN 1 AND 4),
+    fs_div            VARCHAR(3) NOT NULL CHECK (fs_div IN ('CFS', 'OFS')),
+    rcept_no          VARCHAR(20) NOT NULL,
+    filed_date        DATE NOT NULL,                     -- 공시 접수일 (이 날부터 사용 가능)
+    period_end        DATE NOT NULL,                     -- 분기말
+
+    revenue           BIGINT,                            -- 매출액 (YTD)
+    operating_profit  BIGINT,                            -- 영업이익 (YTD)
+    net_profit        BIGINT,                            -- 당기순이익 (YTD)
+    net_profit_owners BIGINT,                            -- 지배주주 순이익 (YTD, 연결만)
+    total_assets      BIGINT,
+    total_liabilities BIGINT,
+    total_equity      BIGINT,
+    equity_owners     BIGINT,                            -- 지배주주 자본 (연결만)
+
+    created_at        TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (stock_code, fiscal_year, fiscal_quarter, fs_div, rcept_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_financial_statements_filed
+    ON data.financial_statements(filed_date);
+CREATE INDEX IF NOT EXISTS idx_financial_statements_period
+    ON data.financial_statements(stock_code, period_end);
+
+COMMENT ON TABLE data.financial_statements IS 'DART 정기보고서 재무제표 (제출본 단위, point-in-time)';
+
+-- ============================================================
+-- data.fundamentals 확장
+-- available_date: 최초 공시 접수일 (NULL이면 레거시 행 → report_date 사용)
+-- *_ttm: 최근 4분기 합산, roe_ttm/op_margin_ttm은 %
+-- ============================================================
+ALTER TABLE data.fundamentals
+    ADD COLUMN IF NOT EXISTS available_date       DATE,
+    ADD COLUMN IF NOT EXISTS fs_div               VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS rcept_no             VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS revenue_ttm          BIGINT,
+    ADD COLUMN IF NOT EXISTS operating_profit_ttm BIGINT,
+    ADD COLUMN IF NOT EXISTS net_profit_ttm       BIGINT,
+    ADD COLUMN IF NOT EXISTS total_assets         BIGINT,
+    ADD COLUMN IF NOT EXISTS total_liabilities    BIGINT,
+    ADD COLUMN IF NOT EXISTS total_equity         BIGINT,
+    ADD COLUMN IF NOT EXISTS roe_ttm              NUMERIC(10,2),
+    ADD COLUMN IF NOT EXISTS op_margin_ttm        NUMERIC(10,2),
+    ADD COLUMN IF NOT EXISTS updated_at           TIMESTAMPTZ DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_fundamentals_available
+    ON data.fundamentals(stock_code, available_date);
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 032 completed: stocks.corp_code, financial_statements, fundamentals TTM/point-in-time columns';
+END $$;
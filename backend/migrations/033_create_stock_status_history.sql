@@ -0,0 +1,37 @@
+-- Migration: 033_create_stock_status_history
+-- Description: KRX 종목 마스터 확장 + 일별 시장조치 플래그 이력 (거래정지/관리/투자경고 등)
+-- Date: 2026-10-18
+
+-- ============================================================
+-- data.stocks 확장: KRX 전종목 기본정보
+-- ============================================================
+ALTER TABLE data.stocks
+    ADD COLUMN IF NOT EXISTS security_group VARCHAR(50),   -- 증권구분 (주권, 외국주권 등)
+    ADD COLUMN IF NOT EXISTS krx_section    VARCHAR(50),   -- 소속부 (KOSDAQ)
+    ADD COLUMN IF NOT EXISTS stock_type     VARCHAR(20);   -- 주식종류 (보통주, 우선주 등)
+
+-- ============================================================
+-- data.stock_status_history: 일별 KRX 플래그 (종목당 1일 1행, 플래그 없으면 빈 배열)
+-- flags: contracts.KRXFlag 값 (TRADING_HALT, ADMIN_ISSUE, MANAGEMENT,
+--        INVESTMENT_CAUTION, INVESTMENT_WARNING, INVESTMENT_DANGER)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS data.stock_status_history (
+    stock_code    VARCHAR(20) NOT NULL,
+    status_date   DATE NOT NULL,
+    flags         TEXT[] NOT NULL DEFAULT '{}',
+    sources       TEXT[] NOT NULL DEFAULT '{}',      -- KRX, KIS
+    created_at    TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (stock_code, status_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_status_history_date
+    ON data.stock_status_history(status_date);
+CREATE INDEX IF NOT EXISTS idx_stock_status_history_flags
+    ON data.stock_status_history USING GIN (flags);
+
+COMMENT ON TABLE data.stock_status_history IS 'KRX 시장조치 플래그 일별 이력 (S1 유니버스 필터)';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 033 completed: stocks master columns, stock_status_history created';
+END $$;
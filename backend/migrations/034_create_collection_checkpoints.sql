@@ -0,0 +1,56 @@
+-- Migration: 034_create_collection_checkpoints
+-- Description: KRX 개장일 캘린더 + 데이터셋별 수집 체크포인트/실패 재시도 큐 (증분·갭 수집)
+-- Date: 2026-10-18
+
+-- ============================================================
+-- data.trading_calendar: KRX 개장/휴장일
+-- 미등록 날짜는 주말·고정 공휴일 규칙으로 판정 (calendar.Calendar)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS data.trading_calendar (
+    trade_date    DATE PRIMARY KEY,
+    is_open       BOOLEAN NOT NULL,
+    name          VARCHAR(100),                      -- 휴장 사유
+    source        VARCHAR(20) NOT NULL,              -- KRX, MANUAL
+    updated_at    TIMESTAMPTZ DEFAULT NOW()
+);
+
+COMMENT ON TABLE data.trading_calendar IS 'KRX 개장일 캘린더 (KRX 지수 시세 기반 + 수동 등록 휴장일)';
+
+-- ============================================================
+-- data.collection_checkpoints: 종목×데이터셋별 마지막 저장일
+-- 종목 단위로 저장 직후 갱신 → 중단 후 재실행 시 남은 종목만 수집
+-- ============================================================
+CREATE TABLE IF NOT EXISTS data.collection_checkpoints (
+    dataset       VARCHAR(30) NOT NULL,              -- prices, investor_flow
+    stock_code    VARCHAR(20) NOT NULL,
+    last_date     DATE,                              -- 저장된 마지막 거래일
+    last_run_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status   VARCHAR(20) NOT NULL,              -- ok, partial, failed
+    PRIMARY KEY (dataset, stock_code)
+);
+
+-- ============================================================
+-- data.collection_failures: 실패/미수집 구간 재시도 큐 (겹치는 구간은 병합)
+-- status: pending(재시도 대기) → 채워지면 삭제, 재시도 한도 초과 시 dead(계획에서 제외)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS data.collection_failures (
+    dataset       VARCHAR(30) NOT NULL,
+    stock_code    VARCHAR(20) NOT NULL,
+    from_date     DATE NOT NULL,
+    to_date       DATE NOT NULL,
+    attempts      INT NOT NULL DEFAULT 1,
+    last_error    TEXT,
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending',
+    next_retry_at TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (dataset, stock_code, from_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_failures_status
+    ON data.collection_failures(status, next_retry_at);
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 034 completed: trading_calendar, collection_checkpoints, collection_failures created';
+END $$;
@@ -0,0 +1,31 @@
+-- Migration: 035_create_quality_anomalies
+-- Description: S0 품질 게이트 레코드 이상치 + 종목 격리 이력
+-- Date: 2026-10-18
+
+-- ============================================================
+-- audit.data_quality_anomalies: 스냅샷 일자별 종목 이상치
+-- 이상치가 1건 이상인 종목은 해당 일자 S1 유니버스에서 격리(제외)
+-- anomaly_type: OHLC_INCONSISTENT, ZERO_VOLUME, PRICE_JUMP, DUPLICATE_ROW,
+--               FLOW_OUT_OF_RANGE, STALE_MARKET_CAP
+-- ============================================================
+CREATE TABLE IF NOT EXISTS audit.data_quality_anomalies (
+    snapshot_date DATE NOT NULL,
+    stock_code    VARCHAR(20) NOT NULL,
+    anomaly_type  VARCHAR(30) NOT NULL,
+    detail        TEXT,
+    created_at    TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (snapshot_date, stock_code, anomaly_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quality_anomalies_stock
+    ON audit.data_quality_anomalies(stock_code, snapshot_date DESC);
+
+ALTER TABLE audit.data_quality_snapshots
+    ADD COLUMN IF NOT EXISTS quarantined_stocks INTEGER NOT NULL DEFAULT 0;
+
+GRANT ALL ON audit.data_quality_anomalies TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 035 completed: audit.data_quality_anomalies created, quarantined_stocks added';
+END $$;
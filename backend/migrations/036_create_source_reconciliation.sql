@@ -0,0 +1,47 @@
+-- Migration: 036_create_source_reconciliation
+-- Description: Naver/KIS/KRX 교차 소스 대사 결과 (불일치 필드 + 승자 소스)
+-- Date: 2026-10-18
+
+-- ============================================================
+-- audit.reconciliation_runs: 일자별 대사 실행 요약
+-- ============================================================
+CREATE TABLE IF NOT EXISTS audit.reconciliation_runs (
+    recon_date        DATE PRIMARY KEY,
+    sources           TEXT[] NOT NULL,          -- 관측된 소스 (STORED 포함)
+    priority          TEXT[] NOT NULL,          -- 승자 선택 순서
+    stocks_compared   INT NOT NULL DEFAULT 0,
+    sampled_stocks    INT NOT NULL DEFAULT 0,   -- KIS/Naver 조회 종목 수
+    discrepancies     INT NOT NULL DEFAULT 0,
+    stored_mismatches INT NOT NULL DEFAULT 0,
+    applied           INT NOT NULL DEFAULT 0,   -- 승자 값으로 교정한 저장값 수
+    finished_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ============================================================
+-- audit.source_discrepancies: 허용오차를 넘는 필드 불일치
+-- source_values: {"KRX": 70500, "NAVER": 70400, "STORED": 70400}
+-- ============================================================
+CREATE TABLE IF NOT EXISTS audit.source_discrepancies (
+    recon_date      DATE NOT NULL,
+    stock_code      VARCHAR(20) NOT NULL,
+    field           VARCHAR(20) NOT NULL,     -- open, high, low, close, volume, market_cap
+    source_values   JSONB NOT NULL,
+    winner          VARCHAR(10) NOT NULL,
+    winner_value    NUMERIC(20,2) NOT NULL,
+    max_diff_pct    NUMERIC(10,6) NOT NULL DEFAULT 0,
+    stored_mismatch BOOLEAN NOT NULL DEFAULT FALSE,
+    applied         BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (recon_date, stock_code, field)
+);
+
+CREATE INDEX IF NOT EXISTS idx_source_discrepancies_stock
+    ON audit.source_discrepancies(stock_code, recon_date DESC);
+
+GRANT ALL ON audit.reconciliation_runs TO aegis_v13;
+GRANT ALL ON audit.source_discrepancies TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 036 completed: audit.reconciliation_runs, audit.source_discrepancies created';
+END $$;
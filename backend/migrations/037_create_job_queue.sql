@@ -0,0 +1,64 @@
+-- Migration: 037_create_job_queue
+-- Description: PostgreSQL 기반 작업 큐 (quant worker가 FOR UPDATE SKIP LOCKED로 임대)
+-- Date: 2026-10-18
+
+-- ============================================================
+-- ops 스키마: 운영 인프라 (작업 큐 등)
+-- ============================================================
+CREATE SCHEMA IF NOT EXISTS ops;
+
+-- ============================================================
+-- ops.job_queue: 작업 큐
+-- status: queued → running → done
+--                          ↘ queued (재시도, run_at = 백오프 이후)
+--                          ↘ dead (max_attempts 소진 또는 영구 실패)
+--         queued → cancelled
+-- leased_until: 가시성 타임아웃. 지나도록 heartbeat가 없으면 reaper가 회수
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.job_queue (
+    id            BIGSERIAL PRIMARY KEY,
+    job_type      VARCHAR(50) NOT NULL,
+    payload       JSONB NOT NULL DEFAULT '{}',
+    priority      INT NOT NULL DEFAULT 0,          -- 클수록 먼저
+    status        VARCHAR(20) NOT NULL DEFAULT 'queued'
+                  CHECK (status IN ('queued', 'running', 'done', 'dead', 'cancelled')),
+    attempts      INT NOT NULL DEFAULT 0,
+    max_attempts  INT NOT NULL DEFAULT 3,
+    run_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    leased_until  TIMESTAMPTZ,
+    locked_by     VARCHAR(100),
+    dedup_key     VARCHAR(200),
+    last_error    TEXT,
+    result        JSONB,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at    TIMESTAMPTZ,
+    finished_at   TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 임대 대상 조회 (priority DESC, run_at, id)
+CREATE INDEX IF NOT EXISTS idx_job_queue_ready
+    ON ops.job_queue(priority DESC, run_at, id)
+    WHERE status = 'queued';
+
+-- 만료 임대 회수
+CREATE INDEX IF NOT EXISTS idx_job_queue_leased
+    ON ops.job_queue(leased_until)
+    WHERE status = 'running';
+
+CREATE INDEX IF NOT EXISTS idx_job_queue_type_created
+    ON ops.job_queue(job_type, created_at DESC);
+
+-- 같은 dedup_key는 대기/실행 중 1건만
+CREATE UNIQUE INDEX IF NOT EXISTS uq_job_queue_dedup_active
+    ON ops.job_queue(dedup_key)
+    WHERE status IN ('queued', 'running');
+
+GRANT USAGE ON SCHEMA ops TO aegis_v13;
+GRANT ALL ON ops.job_queue TO aegis_v13;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA ops TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 037 completed: ops.job_queue created';
+END $$;
@@ -0,0 +1,79 @@
+-- Migration: 038_create_scheduler_state
+-- Description: 스케줄러 실행 이력 영속화, 작업 일시정지 상태, 수동 실행 요청
+-- Date: 2026-10-18
+
+-- ============================================================
+-- ops.scheduler_jobs: 리더 스케줄러가 시작 시 등록한 작업 목록과 일시정지 상태
+-- API/CLI는 이 테이블로 작업 존재 여부를 확인하고 paused를 변경
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.scheduler_jobs (
+    job_name        VARCHAR(100) PRIMARY KEY,
+    schedule        TEXT NOT NULL,                   -- cron 또는 "after a, b"
+    depends_on      TEXT[] NOT NULL DEFAULT '{}',
+    timeout_seconds INT NOT NULL,
+    paused          BOOLEAN NOT NULL DEFAULT FALSE,
+    paused_by       VARCHAR(100),
+    paused_at       TIMESTAMPTZ,
+    registered_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ============================================================
+-- ops.scheduler_runs: 작업 실행 이력
+-- status: RUNNING → SUCCESS / FAILED
+--         SKIPPED (휴장일, 일시정지, 선행 작업 실패/건너뜀)
+-- 리더가 바뀌면 이전 리더의 RUNNING 행은 FAILED로 정리
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.scheduler_runs (
+    id           BIGSERIAL PRIMARY KEY,
+    job_name     VARCHAR(100) NOT NULL,
+    trigger      VARCHAR(150) NOT NULL,              -- cron, manual, upstream:<job>
+    status       VARCHAR(20) NOT NULL
+                 CHECK (status IN ('RUNNING', 'SUCCESS', 'FAILED', 'SKIPPED')),
+    attempts     INT NOT NULL DEFAULT 0,
+    started_at   TIMESTAMPTZ NOT NULL,
+    finished_at  TIMESTAMPTZ,
+    duration_ms  BIGINT,
+    error        TEXT,
+    reason       TEXT,                               -- SKIPPED 사유
+    output       JSONB,                              -- 작업이 보고한 결과 요약
+    instance_id  VARCHAR(100) NOT NULL,
+    request_id   BIGINT                              -- 수동 실행 요청 (ops.scheduler_run_requests.id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_job_started
+    ON ops.scheduler_runs(job_name, started_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_request
+    ON ops.scheduler_runs(request_id)
+    WHERE request_id IS NOT NULL;
+
+-- ============================================================
+-- ops.scheduler_run_requests: 수동 실행 요청 (API, quant scheduler run)
+-- status: pending → started (리더가 실행 시작) / rejected (미등록 작업)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.scheduler_run_requests (
+    id            BIGSERIAL PRIMARY KEY,
+    job_name      VARCHAR(100) NOT NULL,
+    requested_by  VARCHAR(100) NOT NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending'
+                  CHECK (status IN ('pending', 'started', 'rejected')),
+    message       TEXT,
+    requested_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    claimed_at    TIMESTAMPTZ,
+    claimed_by    VARCHAR(100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_run_requests_pending
+    ON ops.scheduler_run_requests(requested_at)
+    WHERE status = 'pending';
+
+GRANT ALL ON ops.scheduler_jobs TO aegis_v13;
+GRANT ALL ON ops.scheduler_runs TO aegis_v13;
+GRANT ALL ON ops.scheduler_run_requests TO aegis_v13;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA ops TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 038 completed: ops.scheduler_jobs, ops.scheduler_runs, ops.scheduler_run_requests created';
+END $$;
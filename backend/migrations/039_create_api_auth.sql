@@ -0,0 +1,80 @@
+-- Migration: 039_create_api_auth
+-- Description: API 키(해시 저장)와 변경 요청 감사 로그 (append-only)
+-- Date: 2026-10-18
+
+-- ============================================================
+-- ops.api_keys: API 키
+-- 평문 키는 발급 시 한 번만 출력, DB에는 SHA-256 해시만 저장
+-- role: read_only ⊂ trader ⊂ admin
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.api_keys (
+    id            BIGSERIAL PRIMARY KEY,
+    name          VARCHAR(100) NOT NULL,
+    key_prefix    VARCHAR(16) NOT NULL,              -- 식별용 (aeg_<prefix>_...)
+    key_hash      CHAR(64) NOT NULL UNIQUE,
+    role          VARCHAR(20) NOT NULL
+                  CHECK (role IN ('read_only', 'trader', 'admin')),
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at  TIMESTAMPTZ,
+    revoked_at    TIMESTAMPTZ
+);
+
+-- 같은 이름의 활성 키는 1개
+CREATE UNIQUE INDEX IF NOT EXISTS uq_api_keys_active_name
+    ON ops.api_keys(name)
+    WHERE revoked_at IS NULL;
+
+-- ============================================================
+-- audit.api_audit_log: 변경 요청(POST/PUT/PATCH/DELETE) 감사 로그
+-- 인증 실패/권한 부족으로 거부된 요청도 기록
+-- response_body: 핸들러 응답 (주문 API는 브로커 응답 포함)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS audit.api_audit_log (
+    id             BIGSERIAL PRIMARY KEY,
+    occurred_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    key_id         BIGINT REFERENCES ops.api_keys(id),
+    actor          VARCHAR(100) NOT NULL,
+    role           VARCHAR(20),
+    method         VARCHAR(10) NOT NULL,
+    path           TEXT NOT NULL,
+    query          TEXT,
+    remote_addr    VARCHAR(100) NOT NULL,
+    request_body   JSONB,
+    status         INT NOT NULL,
+    response_body  JSONB,
+    duration_ms    BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_audit_log_occurred
+    ON audit.api_audit_log(occurred_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_api_audit_log_actor
+    ON audit.api_audit_log(actor, occurred_at DESC);
+
+-- append-only: 수정/삭제 차단 (권한과 별도로 트리거로도 보장)
+CREATE OR REPLACE FUNCTION audit.reject_api_audit_log_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit.api_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_api_audit_log_append_only ON audit.api_audit_log;
+CREATE TRIGGER trg_api_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit.api_audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit.reject_api_audit_log_change();
+
+DROP TRIGGER IF EXISTS trg_api_audit_log_no_truncate ON audit.api_audit_log;
+CREATE TRIGGER trg_api_audit_log_no_truncate
+    BEFORE TRUNCATE ON audit.api_audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit.reject_api_audit_log_change();
+
+GRANT ALL ON ops.api_keys TO aegis_v13;
+GRANT SELECT, INSERT ON audit.api_audit_log TO aegis_v13;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA ops TO aegis_v13;
+GRANT USAGE, SELECT ON SEQUENCE audit.api_audit_log_id_seq TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 039 completed: ops.api_keys, audit.api_audit_log created';
+END $$;
@@ -0,0 +1,77 @@
+-- Migration: 040_create_pretrade_controls
+-- Description: 주문 직전 검사(pre-trade check) 결과와 전역 킬 스위치
+-- Date: 2026-10-18
+
+-- ============================================================
+-- execution.kill_switch: 전역 킬 스위치 (단일 행)
+-- active = true 이면 수동/자동 모든 신규 주문 차단
+-- ============================================================
+CREATE TABLE IF NOT EXISTS execution.kill_switch (
+    id            SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    active        BOOLEAN NOT NULL DEFAULT FALSE,
+    reason        TEXT,
+    updated_by    VARCHAR(100),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO execution.kill_switch (id, active)
+VALUES (1, FALSE)
+ON CONFLICT (id) DO NOTHING;
+
+-- ============================================================
+-- execution.kill_switch_events: 킬 스위치 변경 이력
+-- cancelled_orders: 발동 시 취소한 미체결 주문 수 (cancel_open 옵션)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS execution.kill_switch_events (
+    id                BIGSERIAL PRIMARY KEY,
+    active            BOOLEAN NOT NULL,
+    reason            TEXT,
+    changed_by        VARCHAR(100) NOT NULL,
+    cancel_open       BOOLEAN NOT NULL DEFAULT FALSE,
+    cancelled_orders  INT NOT NULL DEFAULT 0,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kill_switch_events_created
+    ON execution.kill_switch_events(created_at DESC);
+
+-- ============================================================
+-- execution.pretrade_checks: 브로커 전송 직전 검사 결과
+-- source: manual(API 수동 주문) | planner(S6) | exit_monitor(자동 청산)
+-- passed = true 인 행은 당일 누적 주문금액/중복 주문 판정에 사용
+-- violations: [{"rule": "...", "message": "..."}]
+-- ============================================================
+CREATE TABLE IF NOT EXISTS execution.pretrade_checks (
+    id            BIGSERIAL PRIMARY KEY,
+    checked_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    source        VARCHAR(20) NOT NULL,
+    actor         VARCHAR(100),
+    stock_code    VARCHAR(20) NOT NULL,
+    side          VARCHAR(10) NOT NULL CHECK (side IN ('BUY', 'SELL')),
+    order_type    VARCHAR(10) NOT NULL,
+    qty           INT NOT NULL,
+    price         BIGINT NOT NULL,                   -- 0 = 시장가
+    notional      BIGINT NOT NULL,                   -- 시장가는 기준가로 환산
+    passed        BOOLEAN NOT NULL,
+    violations    JSONB NOT NULL DEFAULT '[]'
+);
+
+-- 당일 누적 주문금액
+CREATE INDEX IF NOT EXISTS idx_pretrade_checks_passed
+    ON execution.pretrade_checks(checked_at)
+    WHERE passed;
+
+-- 중복 주문 탐지
+CREATE INDEX IF NOT EXISTS idx_pretrade_checks_dup
+    ON execution.pretrade_checks(stock_code, side, checked_at DESC)
+    WHERE passed;
+
+GRANT ALL ON execution.kill_switch TO aegis_v13;
+GRANT ALL ON execution.kill_switch_events TO aegis_v13;
+GRANT ALL ON execution.pretrade_checks TO aegis_v13;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA execution TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 040 completed: execution.kill_switch, kill_switch_events, pretrade_checks created';
+END $$;
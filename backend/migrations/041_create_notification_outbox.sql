@@ -0,0 +1,52 @@
+-- Migration: 041_create_notification_outbox
+-- Description: 알림 outbox (채널별 발송 대기열, 재시도/dead-letter)
+-- Date: 2026-10-18
+
+-- ============================================================
+-- ops.notification_outbox: 채널별 알림 1건 = 1행
+-- status: pending → sending → sent
+--                         ↘ pending (재시도, next_attempt_at = 백오프 이후)
+--                         ↘ dead (max_attempts 소진)
+-- sending 상태에서 next_attempt_at이 지나면 (프로세스 중단) 다시 임대
+-- dedup_key: 같은 키가 중복 억제 구간 안에 있으면 새로 적재하지 않음
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.notification_outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    event_type      VARCHAR(50) NOT NULL,
+    severity        VARCHAR(20) NOT NULL,
+    dedup_key       VARCHAR(200),
+    channel         VARCHAR(20) NOT NULL,
+    title           TEXT NOT NULL,
+    body            TEXT NOT NULL,
+    payload         JSONB NOT NULL DEFAULT '{}',
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending', 'sending', 'sent', 'dead')),
+    attempts        INT NOT NULL DEFAULT 0,
+    max_attempts    INT NOT NULL DEFAULT 5,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error      TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 발송 대상 조회
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_ready
+    ON ops.notification_outbox(next_attempt_at, id)
+    WHERE status IN ('pending', 'sending');
+
+-- 중복 억제 조회
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_dedup
+    ON ops.notification_outbox(dedup_key, created_at DESC)
+    WHERE dedup_key IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_created
+    ON ops.notification_outbox(created_at DESC);
+
+GRANT ALL ON ops.notification_outbox TO aegis_v13;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA ops TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 041 completed: ops.notification_outbox created';
+END $$;
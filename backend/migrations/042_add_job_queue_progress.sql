@@ -0,0 +1,17 @@
+-- Migration: 042_add_job_queue_progress
+-- Description: 작업 큐 진행률 저장 및 실행 중 작업 취소 요청
+-- Date: 2026-10-18
+
+-- ============================================================
+-- progress: 실행 중 작업이 보고한 진행률 (예: 수집 종목 수/실패 목록)
+--           워커가 주기적으로 저장, 새 임대 시 초기화
+-- cancel_requested_at: 실행 중(running) 작업 취소 요청 시각
+--           워커가 진행률 저장 시 확인해 실행 컨텍스트를 취소하고 cancelled로 종료
+-- ============================================================
+ALTER TABLE ops.job_queue ADD COLUMN IF NOT EXISTS progress JSONB;
+ALTER TABLE ops.job_queue ADD COLUMN IF NOT EXISTS cancel_requested_at TIMESTAMPTZ;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 042 completed: ops.job_queue progress/cancel_requested_at added';
+END $$;
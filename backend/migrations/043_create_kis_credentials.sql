@@ -0,0 +1,29 @@
+-- Migration: 043_create_kis_credentials
+-- Description: KIS 접근토큰/웹소켓 접속키 저장 (재시작 간 재사용, 일일 발급 횟수 추적)
+-- Date: 2026-10-18
+
+-- ============================================================
+-- ops.kis_credentials: 앱키 × 종류별 현재 자격 증명 1행
+-- app_key_hash: 앱키 SHA-256 앞 16자 (앱키 원문은 저장하지 않음)
+-- kind: access_token (REST, 24시간) / approval_key (WebSocket, 24시간)
+-- issue_day/issue_count: KST 기준 일일 발급 횟수 (KIS 발급 한도 보호)
+-- 발급은 행 잠금(SELECT ... FOR UPDATE) 안에서 수행 → api/scheduler/worker 프로세스가 동시에 재발급하지 않음
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.kis_credentials (
+    app_key_hash VARCHAR(16) NOT NULL,
+    kind         VARCHAR(20) NOT NULL CHECK (kind IN ('access_token', 'approval_key')),
+    value        TEXT NOT NULL DEFAULT '',
+    issued_at    TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ,
+    issue_day    DATE,
+    issue_count  INT NOT NULL DEFAULT 0,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (app_key_hash, kind)
+);
+
+GRANT ALL ON ops.kis_credentials TO aegis_v13;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 043 completed: ops.kis_credentials created';
+END $$;
@@ -0,0 +1,90 @@
+-- Migration: 044_create_accounts_strategies
+-- Description: 계좌/전략 레지스트리, 주문·목표 포트폴리오·스냅샷·게이트 이벤트·성과에 계좌/전략 태그
+-- Date: 2026-10-18
+
+-- ============================================================
+-- ops.accounts: 거래 계좌 (KIS_ACCOUNTS, 미설정 시 default 하나)
+-- 계좌번호 원문은 저장하지 않음 (마스킹 값만)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.accounts (
+    account_id        VARCHAR(32) PRIMARY KEY,
+    account_no_masked VARCHAR(32) NOT NULL DEFAULT '',
+    is_virtual        BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ============================================================
+-- ops.strategies: 전략 YAML(config/strategy/*.yaml) ↔ 계좌 배분
+-- 파이프라인 실행 시 동기화, YAML이 제거된 전략은 enabled = false (이력 보존)
+-- ============================================================
+CREATE TABLE IF NOT EXISTS ops.strategies (
+    strategy_id  VARCHAR(50) PRIMARY KEY,
+    account_id   VARCHAR(32) NOT NULL REFERENCES ops.accounts(account_id),
+    capital_pct  NUMERIC(5,4) NOT NULL CHECK (capital_pct > 0 AND capital_pct <= 1),
+    version      VARCHAR(50) NOT NULL DEFAULT '',
+    config_hash  VARCHAR(64) NOT NULL DEFAULT '',
+    config_path  TEXT NOT NULL DEFAULT '',
+    enabled      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_strategies_account ON ops.strategies(account_id);
+
+GRANT ALL ON ops.accounts TO aegis_v13;
+GRANT ALL ON ops.strategies TO aegis_v13;
+
+-- ============================================================
+-- 계좌/전략 태그
+-- account_id: 기존 행은 default 계좌
+-- strategy_id: 기존 행은 단일 전략(korea_equity_v13) 시절 데이터, 수동/청산 주문은 빈 값 가능
+-- ============================================================
+
+-- execution.orders: 주문 계좌/전략
+ALTER TABLE execution.orders ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE execution.orders ADD COLUMN IF NOT EXISTS strategy_id VARCHAR(50) NOT NULL DEFAULT '';
+UPDATE execution.orders SET strategy_id = 'korea_equity_v13' WHERE strategy_id = '' AND strategy_version IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_account_strategy ON execution.orders(account_id, strategy_id, order_date);
+
+-- execution.risk_gate_events / pretrade_checks: 검사 대상 계좌/전략
+ALTER TABLE execution.risk_gate_events ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE execution.risk_gate_events ADD COLUMN IF NOT EXISTS strategy_id VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE execution.pretrade_checks ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE execution.pretrade_checks ADD COLUMN IF NOT EXISTS strategy_id VARCHAR(50) NOT NULL DEFAULT '';
+
+-- portfolio.target_positions / portfolio_snapshots: 전략별 목표 포트폴리오
+ALTER TABLE portfolio.target_positions ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE portfolio.target_positions ADD COLUMN IF NOT EXISTS strategy_id VARCHAR(50) NOT NULL DEFAULT 'korea_equity_v13';
+CREATE INDEX IF NOT EXISTS idx_target_positions_strategy ON portfolio.target_positions(account_id, strategy_id, target_date);
+
+ALTER TABLE portfolio.portfolio_snapshots ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE portfolio.portfolio_snapshots ADD COLUMN IF NOT EXISTS strategy_id VARCHAR(50) NOT NULL DEFAULT 'korea_equity_v13';
+ALTER TABLE portfolio.portfolio_snapshots DROP CONSTRAINT IF EXISTS portfolio_snapshots_pkey;
+ALTER TABLE portfolio.portfolio_snapshots ADD PRIMARY KEY (snapshot_date, account_id, strategy_id);
+
+-- audit.daily_snapshots / cash_flows: 계좌별 평가액·현금흐름
+ALTER TABLE audit.daily_snapshots ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE audit.daily_snapshots DROP CONSTRAINT IF EXISTS daily_snapshots_pkey;
+ALTER TABLE audit.daily_snapshots ADD PRIMARY KEY (account_id, date);
+
+ALTER TABLE audit.cash_flows ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_cash_flows_account ON audit.cash_flows(account_id, flow_date);
+
+-- audit.closed_lots: 실현손익 원장 (매칭은 계좌 × 전략 × 종목 단위)
+ALTER TABLE audit.closed_lots ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE audit.closed_lots ADD COLUMN IF NOT EXISTS strategy_id VARCHAR(50) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_closed_lots_account_strategy ON audit.closed_lots(account_id, strategy_id);
+
+-- audit.performance_reports: 계좌 단위 보고서 (strategy_id = '' 계좌 전체)
+ALTER TABLE audit.performance_reports ADD COLUMN IF NOT EXISTS account_id VARCHAR(32) NOT NULL DEFAULT 'default';
+ALTER TABLE audit.performance_reports ADD COLUMN IF NOT EXISTS strategy_id VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE audit.performance_reports DROP CONSTRAINT IF EXISTS performance_reports_pkey;
+ALTER TABLE audit.performance_reports ADD PRIMARY KEY (report_date, account_id, strategy_id);
+
+INSERT INTO ops.accounts (account_id) VALUES ('default') ON CONFLICT (account_id) DO NOTHING;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Migration 044 completed: ops.accounts/strategies created, account_id/strategy_id tags added';
+END $$;
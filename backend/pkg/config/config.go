@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -17,6 +19,9 @@ type Config struct {
 	Port string
 	Env  string // development, staging, production
 
+	// API security
+	API APIConfig
+
 	// Database
 	Database DatabaseConfig
 
@@ -34,7 +39,50 @@ type Config struct {
 
 	// Monitoring
 	MetricsEnabled bool
-	MetricsPort    string
+	MetricsPorts   MetricsPorts
+
+	// Notifications
+	Notify NotifyConfig
+
+	// Strategies (전략 YAML 디렉토리, 파일 하나 = 전략 하나)
+	StrategyDir string
+}
+
+// MetricsPorts holds the /metrics port of each process
+// api/scheduler/worker를 한 호스트에서 함께 실행하므로 기본 포트를 분리
+type MetricsPorts struct {
+	API       string // API_METRICS_PORT
+	Scheduler string // SCHEDULER_METRICS_PORT
+	Worker    string // WORKER_METRICS_PORT
+}
+
+// NotifyConfig holds notification channel, routing and delivery configuration
+// 채널은 자격 증명이 설정된 것만 활성화
+type NotifyConfig struct {
+	Enabled       bool
+	Language      string        // ko | en
+	Routes        string        // 이벤트별 채널 (예: "exit_signal=telegram;job_failed=slack,email;*=slack")
+	DedupWindow   time.Duration // 같은 이벤트 키 재발송 억제 구간
+	RatePerMinute int           // 채널별 분당 발송 한도
+	MaxAttempts   int           // 발송 실패 시 최대 시도 횟수 (이후 dead)
+
+	TelegramBotToken string
+	TelegramChatID   string
+	SlackWebhookURL  string
+	WebhookURL       string // 일반 웹훅 (JSON POST)
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTo       []string
+}
+
+// APIConfig holds API server authentication and CORS configuration
+type APIConfig struct {
+	AuthEnabled bool     // false면 인증 없이 admin 권한으로 처리 (development 전용)
+	CORSOrigins []string // 허용 Origin 목록 ("*" = 전체 허용, production 불가)
 }
 
 // RedisConfig holds Redis configuration
@@ -70,6 +118,96 @@ type KISConfig struct {
 	BaseURL   string
 	IsVirtual bool   // 모의투자 여부
 	HtsID     string // HTS ID (체결통보 구독용)
+
+	// 요청 예산: 앱키 한도(RateLimit)를 프로세스별 몫(RateShares)으로 나눔
+	// 프로세스 안에서는 주문이 몫 전체를 사용, 계좌 조회/시세 조회는 비율 상한
+	RateLimit       int           // 앱키 전체 초당 요청 수 (0 = 실전 18, 모의 4)
+	AccountSharePct int           // 계좌 조회(잔고/주문내역) 상한 %
+	QuoteSharePct   int           // 시세 조회(현재가/폴링) 상한 %
+	RateShares      KISRateShares // 프로세스별 몫 %
+	ProcessSharePct int           // 이 프로세스의 몫 % (ForProcess로 설정, 0 = 전체)
+
+	// 토큰/접속키 수명 관리
+	TokenRefreshBefore time.Duration // 만료 전 선제 갱신 여유
+	TokenDailyLimit    int           // 종류별 일일 발급 한도
+
+	// 거래 계좌 (KIS_ACCOUNTS 미설정 시 KIS_ACCOUNT_NO 하나를 DefaultAccountID로 사용)
+	Accounts []KISAccount
+}
+
+// KIS request budget processes (KIS_RATE_<PROCESS>_PCT)
+const (
+	KISProcessAPI       = "api"
+	KISProcessScheduler = "scheduler"
+	KISProcessWorker    = "worker"
+	KISProcessCLI       = "cli" // 일회성 명령 (kill switch, 대사, 종목 마스터 등)
+)
+
+// KISRateShares splits the app key's request budget between processes (%)
+// 프로세스마다 예산을 따로 두므로 동시에 실행되는 프로세스 몫의 합이 100 이하여야 앱키 한도를 넘지 않음
+type KISRateShares struct {
+	API       int // KIS_RATE_API_PCT
+	Scheduler int // KIS_RATE_SCHEDULER_PCT
+	Worker    int // KIS_RATE_WORKER_PCT
+	CLI       int // KIS_RATE_CLI_PCT
+}
+
+// DefaultAccountID is the account ID used when KIS_ACCOUNTS is not set
+const DefaultAccountID = "default"
+
+// KISAccount is a trading account; AppKey/AppSecret 미설정 시 KIS_APP_KEY/KIS_APP_SECRET 사용
+type KISAccount struct {
+	ID        string
+	AccountNo string
+	AppKey    string
+	AppSecret string
+	Capital   int64 // 운용 자본 (원, 0 = 미설정) → 전략 자본 = Capital × capital_pct
+}
+
+// Account returns the account with the given ID
+func (c KISConfig) Account(id string) (KISAccount, bool) {
+	for _, acc := range c.Accounts {
+		if acc.ID == id {
+			return acc, true
+		}
+	}
+	return KISAccount{}, false
+}
+
+// AccountIDs returns the configured account IDs in order
+func (c KISConfig) AccountIDs() []string {
+	ids := make([]string, len(c.Accounts))
+	for i, acc := range c.Accounts {
+		ids[i] = acc.ID
+	}
+	return ids
+}
+
+// ForAccount returns a copy of the config bound to the account (계좌번호/앱키 교체)
+func (c KISConfig) ForAccount(id string) (KISConfig, error) {
+	acc, ok := c.Account(id)
+	if !ok {
+		return KISConfig{}, fmt.Errorf("unknown KIS account %q", id)
+	}
+	c.AccountNo = acc.AccountNo
+	c.AppKey = acc.AppKey
+	c.AppSecret = acc.AppSecret
+	return c, nil
+}
+
+// ForProcess returns a copy of the config limited to the process's share of the request budget
+func (c KISConfig) ForProcess(process string) KISConfig {
+	switch process {
+	case KISProcessAPI:
+		c.ProcessSharePct = c.RateShares.API
+	case KISProcessScheduler:
+		c.ProcessSharePct = c.RateShares.Scheduler
+	case KISProcessWorker:
+		c.ProcessSharePct = c.RateShares.Worker
+	default:
+		c.ProcessSharePct = c.RateShares.CLI
+	}
+	return c
 }
 
 // DARTConfig holds DART (전자공시) API configuration
@@ -91,10 +229,18 @@ func Load() (*Config, error) {
 	// Try multiple paths for .env file
 	loadEnvFile()
 
+	env := getEnv("ENV", "development")
+
 	cfg := &Config{
 		// Server
 		Port: getEnv("PORT", "8089"),
-		Env:  getEnv("ENV", "development"),
+		Env:  env,
+
+		// API security (development 외 환경은 기본 인증)
+		API: APIConfig{
+			AuthEnabled: getEnvAsBool("API_AUTH_ENABLED", env != "development"),
+			CORSOrigins: getEnvAsList("API_CORS_ORIGINS", "http://localhost:3000"),
+		},
 
 		// Database
 		Database: DatabaseConfig{
@@ -127,8 +273,23 @@ func Load() (*Config, error) {
 			BaseURL:   getEnv("KIS_BASE_URL", "https://openapi.koreainvestment.com:9443"),
 			IsVirtual: getEnvAsBool("KIS_IS_VIRTUAL", false),
 			HtsID:     getEnv("KIS_HTS_ID", ""),
+
+			RateLimit:       getEnvAsInt("KIS_RATE_LIMIT", 0),
+			AccountSharePct: getEnvAsInt("KIS_RATE_ACCOUNT_PCT", 20),
+			QuoteSharePct:   getEnvAsInt("KIS_RATE_QUOTE_PCT", 60),
+			RateShares: KISRateShares{
+				API:       getEnvAsInt("KIS_RATE_API_PCT", 50),
+				Scheduler: getEnvAsInt("KIS_RATE_SCHEDULER_PCT", 20),
+				Worker:    getEnvAsInt("KIS_RATE_WORKER_PCT", 20),
+				CLI:       getEnvAsInt("KIS_RATE_CLI_PCT", 10),
+			},
+
+			TokenRefreshBefore: getEnvAsDuration("KIS_TOKEN_REFRESH_BEFORE", "1h"),
+			TokenDailyLimit:    getEnvAsInt("KIS_TOKEN_DAILY_LIMIT", 5),
 		},
 
+		StrategyDir: getEnv("STRATEGY_DIR", "config/strategy"),
+
 		DART: DARTConfig{
 			APIKey:  getEnv("DART_API_KEY", ""),
 			BaseURL: getEnv("DART_BASE_URL", "https://opendart.fss.or.kr/api"),
@@ -146,9 +307,35 @@ func Load() (*Config, error) {
 
 		// Monitoring
 		MetricsEnabled: getEnvAsBool("METRICS_ENABLED", true),
-		MetricsPort:    getEnv("METRICS_PORT", "9090"),
+		MetricsPorts: MetricsPorts{
+			API:       getEnv("API_METRICS_PORT", "9090"),
+			Scheduler: getEnv("SCHEDULER_METRICS_PORT", "9091"),
+			Worker:    getEnv("WORKER_METRICS_PORT", "9092"),
+		},
+
+		// Notifications
+		Notify: NotifyConfig{
+			Enabled:          getEnvAsBool("NOTIFY_ENABLED", true),
+			Language:         getEnv("NOTIFY_LANGUAGE", "ko"),
+			Routes:           getEnv("NOTIFY_ROUTES", "*=*"),
+			DedupWindow:      getEnvAsDuration("NOTIFY_DEDUP_WINDOW", "10m"),
+			RatePerMinute:    getEnvAsInt("NOTIFY_RATE_PER_MINUTE", 20),
+			MaxAttempts:      getEnvAsInt("NOTIFY_MAX_ATTEMPTS", 5),
+			TelegramBotToken: getEnv("TELEGRAM_BOT_TOKEN", ""),
+			TelegramChatID:   getEnv("TELEGRAM_CHAT_ID", ""),
+			SlackWebhookURL:  getEnv("SLACK_WEBHOOK_URL", ""),
+			WebhookURL:       getEnv("NOTIFY_WEBHOOK_URL", ""),
+			SMTPHost:         getEnv("SMTP_HOST", ""),
+			SMTPPort:         getEnv("SMTP_PORT", "587"),
+			SMTPUser:         getEnv("SMTP_USER", ""),
+			SMTPPassword:     getEnv("SMTP_PASSWORD", ""),
+			SMTPFrom:         getEnv("SMTP_FROM", ""),
+			SMTPTo:           getEnvAsList("SMTP_TO", ""),
+		},
 	}
 
+	cfg.KIS.Accounts = loadKISAccounts(cfg.KIS)
+
 	// Validate configuration
 	if err := cfg.validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -169,11 +356,97 @@ func (c *Config) validate() error {
 		return fmt.Errorf("ENV must be one of: development, staging, production")
 	}
 
+	// 주문용 예산이 남도록 조회 비율 합계는 100% 미만
+	if c.KIS.AccountSharePct <= 0 || c.KIS.QuoteSharePct <= 0 || c.KIS.AccountSharePct+c.KIS.QuoteSharePct >= 100 {
+		return fmt.Errorf("KIS_RATE_ACCOUNT_PCT and KIS_RATE_QUOTE_PCT must be positive and sum to less than 100")
+	}
+
+	// 프로세스별 예산 합이 앱키 한도를 넘지 않도록
+	shares := c.KIS.RateShares
+	if shares.API <= 0 || shares.Scheduler <= 0 || shares.Worker <= 0 || shares.CLI <= 0 ||
+		shares.API+shares.Scheduler+shares.Worker+shares.CLI > 100 {
+		return fmt.Errorf("KIS_RATE_API_PCT, KIS_RATE_SCHEDULER_PCT, KIS_RATE_WORKER_PCT and KIS_RATE_CLI_PCT must be positive and sum to at most 100")
+	}
+
+	if err := validateKISAccounts(c.KIS.Accounts); err != nil {
+		return err
+	}
+
+	if c.Notify.Language != "ko" && c.Notify.Language != "en" {
+		return fmt.Errorf("NOTIFY_LANGUAGE must be one of: ko, en")
+	}
+
+	// 외부 노출 환경은 인증/CORS 제한 필수
+	if c.Env == "production" {
+		if !c.API.AuthEnabled {
+			return fmt.Errorf("API_AUTH_ENABLED cannot be false in production")
+		}
+		for _, origin := range c.API.CORSOrigins {
+			if origin == "*" {
+				return fmt.Errorf("API_CORS_ORIGINS cannot contain * in production")
+			}
+		}
+	}
+
 	return nil
 }
 
 // Helper functions (private, only used within this file)
 
+// loadKISAccounts reads KIS_ACCOUNTS (예: "main,isa") and KIS_ACCOUNT_<ID>_NO/_APP_KEY/_APP_SECRET/_CAPITAL
+// KIS_ACCOUNTS 미설정 시 기본 계좌 자본은 KIS_CAPITAL
+func loadKISAccounts(kis KISConfig) []KISAccount {
+	ids := getEnvAsList("KIS_ACCOUNTS", "")
+	if len(ids) == 0 {
+		return []KISAccount{{
+			ID:        DefaultAccountID,
+			AccountNo: kis.AccountNo,
+			AppKey:    kis.AppKey,
+			AppSecret: kis.AppSecret,
+			Capital:   getEnvAsInt64("KIS_CAPITAL", 0),
+		}}
+	}
+
+	accounts := make([]KISAccount, 0, len(ids))
+	for _, id := range ids {
+		prefix := "KIS_ACCOUNT_" + strings.ToUpper(id) + "_"
+		accounts = append(accounts, KISAccount{
+			ID:        strings.ToLower(id),
+			AccountNo: getEnv(prefix+"NO", ""),
+			AppKey:    getEnv(prefix+"APP_KEY", kis.AppKey),
+			AppSecret: getEnv(prefix+"APP_SECRET", kis.AppSecret),
+			Capital:   getEnvAsInt64(prefix+"CAPITAL", 0),
+		})
+	}
+	return accounts
+}
+
+var accountIDPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// validateKISAccounts checks account IDs are unique and explicit accounts have a number
+func validateKISAccounts(accounts []KISAccount) error {
+	seen := make(map[string]bool, len(accounts))
+	for _, acc := range accounts {
+		if !accountIDPattern.MatchString(acc.ID) {
+			return fmt.Errorf("KIS_ACCOUNTS: invalid account ID %q (lowercase letters, digits, _)", acc.ID)
+		}
+		if seen[acc.ID] {
+			return fmt.Errorf("KIS_ACCOUNTS: duplicate account ID %q", acc.ID)
+		}
+		seen[acc.ID] = true
+
+		if acc.Capital < 0 {
+			return fmt.Errorf("KIS account %q: capital must not be negative", acc.ID)
+		}
+
+		// 기본 계좌는 KIS_ACCOUNT_NO 없이도 기동 가능 (데이터 수집 전용 환경)
+		if acc.ID != DefaultAccountID && acc.AccountNo == "" {
+			return fmt.Errorf("KIS_ACCOUNT_%s_NO is required", strings.ToUpper(acc.ID))
+		}
+	}
+	return nil
+}
+
 // loadEnvFile tries to load .env from multiple locations
 func loadEnvFile() {
 	// Try paths in order of priority
@@ -221,6 +494,20 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+func getEnvAsInt64(key string, defaultValue int64) int64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseInt(valueStr, 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
@@ -249,3 +536,14 @@ func getEnvAsDuration(key string, defaultValue string) time.Duration {
 
 	return duration
 }
+
+// getEnvAsList splits a comma-separated value, dropping empty items
+func getEnvAsList(key string, defaultValue string) []string {
+	list := make([]string, 0)
+	for _, item := range strings.Split(getEnv(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
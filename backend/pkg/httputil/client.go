@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/wonny/aegis/v13/backend/pkg/config"
 	"github.com/wonny/aegis/v13/backend/pkg/logger"
+	"github.com/wonny/aegis/v13/backend/pkg/metrics"
 	"github.com/wonny/aegis/v13/backend/pkg/redis"
 )
 
@@ -24,6 +26,7 @@ type Client struct {
 	retryConfig  RetryConfig
 	rateLimiter  *redis.RateLimiter
 	rateLimitCfg *redis.RateLimitConfig
+	redactURLs   bool // 로그에 host만 남김 (URL에 토큰이 포함된 API)
 }
 
 // RetryConfig holds retry configuration
@@ -79,6 +82,34 @@ func (c *Client) WithRateLimiter(limiter *redis.RateLimiter, cfg redis.RateLimit
 	return c
 }
 
+// RedactURLs logs only the scheme and host of request URLs
+// 경로/쿼리에 자격 증명이 들어가는 API(Telegram bot token, Slack webhook)에 사용
+func (c *Client) RedactURLs() *Client {
+	c.redactURLs = true
+	return c
+}
+
+// logURL returns the URL as it may appear in logs
+func (c *Client) logURL(u *url.URL) string {
+	if c.redactURLs {
+		return u.Scheme + "://" + u.Host + "/…"
+	}
+	return u.String()
+}
+
+// redactError strips the request URL from transport errors (*url.Error) when redacting
+func (c *Client) redactError(err error) error {
+	var urlErr *url.Error
+	if !c.redactURLs || !errors.As(err, &urlErr) {
+		return err
+	}
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil {
+		u = &url.URL{}
+	}
+	return fmt.Errorf("%s %s: %w", urlErr.Op, c.logURL(u), urlErr.Err)
+}
+
 // Get performs a GET request
 func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -126,12 +157,15 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 	var err error
 
 	startTime := time.Now()
-	url := req.URL.String()
+	url := c.logURL(req.URL)
 	method := req.Method
 
 	// Check rate limit
 	if c.rateLimiter != nil && c.rateLimitCfg != nil {
-		if err := c.rateLimiter.Wait(req.Context(), *c.rateLimitCfg); err != nil {
+		waitStart := time.Now()
+		err := c.rateLimiter.Wait(req.Context(), *c.rateLimitCfg)
+		metrics.ObserveRateLimitWait(c.rateLimitCfg.Key, time.Since(waitStart), err)
+		if err != nil {
 			return nil, fmt.Errorf("rate limit wait failed: %w", err)
 		}
 	}
@@ -154,6 +188,8 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 
 	// Log response
 	if err != nil {
+		err = c.redactError(err)
+		metrics.ObserveHTTPRequest(req.URL.Host, 0, err)
 		c.logger.WithFields(map[string]interface{}{
 			"method":   method,
 			"url":      url,
@@ -162,6 +198,7 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 		}).Error("HTTP request failed")
 		return nil, err
 	}
+	metrics.ObserveHTTPRequest(req.URL.Host, resp.StatusCode, nil)
 
 	c.logger.WithFields(map[string]interface{}{
 		"method":      method,
@@ -195,10 +232,11 @@ func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
 		}
 
 		// Log retry
+		metrics.IncHTTPRetry(req.URL.Host)
 		c.logger.WithFields(map[string]interface{}{
 			"attempt": attempt + 1,
 			"delay":   delay,
-			"url":     req.URL.String(),
+			"url":     c.logURL(req.URL),
 		}).Warn("Retrying HTTP request")
 
 		// Wait before retry
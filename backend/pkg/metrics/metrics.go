@@ -0,0 +1,390 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ⭐ SSOT: Prometheus 메트릭 이름/라벨은 이 파일에서만 정의
+// 호출부는 Observe*/Set* 헬퍼만 사용하고 collector를 직접 다루지 않음
+
+const namespace = "aegis"
+
+// Result labels
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+var registry = prometheus.NewRegistry()
+
+var factory = promauto.With(registry)
+
+var (
+	// Pipeline (S0~S7)
+	pipelineStageDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "stage_duration_seconds",
+		Help:      "Pipeline stage duration by stage and result.",
+		Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"stage", "result"})
+
+	pipelineStageItems = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "stage_items",
+		Help:      "Items produced by the last successful run of each stage (stocks, signals, orders).",
+	}, []string{"stage"})
+
+	pipelineRuns = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "runs_total",
+		Help:      "Pipeline runs by result.",
+	}, []string{"result"})
+
+	pipelineLastSuccess = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful pipeline run.",
+	})
+
+	// S0 collector
+	collectorFetches = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "collector",
+		Name:      "fetches_total",
+		Help:      "Collector fetches by source, dataset and result.",
+	}, []string{"source", "dataset", "result"})
+
+	// httputil
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_client",
+		Name:      "requests_total",
+		Help:      "Outbound HTTP requests by host and status class (2xx, 4xx, 5xx, error).",
+	}, []string{"host", "status"})
+
+	httpRetries = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_client",
+		Name:      "retries_total",
+		Help:      "Outbound HTTP retries by host.",
+	}, []string{"host"})
+
+	httpRateLimitWait = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http_client",
+		Name:      "rate_limit_wait_seconds",
+		Help:      "Time spent waiting on the rate limiter by limiter key.",
+		Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"key"})
+
+	httpRateLimitErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_client",
+		Name:      "rate_limit_errors_total",
+		Help:      "Rate limiter waits that failed (context cancelled or Redis error) by limiter key.",
+	}, []string{"key"})
+
+	// Realtime
+	syncQueueBacklog = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync_queue",
+		Name:      "backlog",
+		Help:      "Pending realtime.sync_jobs rows.",
+	})
+
+	syncQueueOldestPending = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync_queue",
+		Name:      "oldest_pending_seconds",
+		Help:      "Age of the oldest pending sync job (0 when empty).",
+	})
+
+	wsReconnects = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "websocket",
+		Name:      "reconnects_total",
+		Help:      "KIS WebSocket reconnect attempts by result.",
+	}, []string{"result"})
+
+	// Execution
+	orderLatency = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "order",
+		Name:      "submit_latency_seconds",
+		Help:      "Broker order submission latency by side and result (accepted, rejected, error).",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10},
+	}, []string{"side", "result"})
+
+	orderFillRatio = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "order",
+		Name:      "fill_ratio",
+		Help:      "Filled quantity / ordered quantity of completed orders by side.",
+		Buckets:   []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 1},
+	}, []string{"side"})
+
+	orderCompletions = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "order",
+		Name:      "completions_total",
+		Help:      "Completed orders by side and final status.",
+	}, []string{"side", "status"})
+
+	riskGateActions = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "risk_gate",
+		Name:      "actions_total",
+		Help:      "Risk gate decisions by mode, action and whether the gate would have blocked.",
+	}, []string{"mode", "action", "would_block"})
+
+	// Scheduler
+	schedulerJobRuns = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_runs_total",
+		Help:      "Scheduler job outcomes by job and status.",
+	}, []string{"job", "status"})
+
+	schedulerJobDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_duration_seconds",
+		Help:      "Scheduler job duration (including retries) by job.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 300, 600, 1800, 3600},
+	}, []string{"job"})
+
+	schedulerJobLastSuccess = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful run by job.",
+	}, []string{"job"})
+
+	// Notifications
+	notifications = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notify",
+		Name:      "deliveries_total",
+		Help:      "Notification delivery attempts by channel and result (sent, retry, dead, deferred).",
+	}, []string{"channel", "result"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		priceCache,
+	)
+}
+
+// Handler returns the /metrics HTTP handler
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Result converts an error into the result label
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
+
+// =============================================================================
+// Pipeline
+// =============================================================================
+
+// ObserveStage records a pipeline stage duration; items is recorded only on success
+func ObserveStage(stage string, start time.Time, err error, items int) {
+	pipelineStageDuration.WithLabelValues(stage, Result(err)).Observe(time.Since(start).Seconds())
+	if err == nil {
+		pipelineStageItems.WithLabelValues(stage).Set(float64(items))
+	}
+}
+
+// ObservePipelineRun records the outcome of a full pipeline run
+func ObservePipelineRun(err error) {
+	pipelineRuns.WithLabelValues(Result(err)).Inc()
+	if err == nil {
+		pipelineLastSuccess.SetToCurrentTime()
+	}
+}
+
+// =============================================================================
+// Collector
+// =============================================================================
+
+// ObserveCollect records one collector fetch (source: naver/dart/krx, dataset: prices/...)
+func ObserveCollect(source, dataset string, err error) {
+	collectorFetches.WithLabelValues(source, dataset, Result(err)).Inc()
+}
+
+// =============================================================================
+// HTTP client
+// =============================================================================
+
+// ObserveHTTPRequest records the final outcome of an outbound request (after retries)
+func ObserveHTTPRequest(host string, statusCode int, err error) {
+	httpRequests.WithLabelValues(host, statusClass(statusCode, err)).Inc()
+}
+
+// IncHTTPRetry counts one retry of an outbound request
+func IncHTTPRetry(host string) {
+	httpRetries.WithLabelValues(host).Inc()
+}
+
+// ObserveRateLimitWait records time spent waiting on the rate limiter
+func ObserveRateLimitWait(key string, wait time.Duration, err error) {
+	if err != nil {
+		httpRateLimitErrors.WithLabelValues(key).Inc()
+		return
+	}
+	httpRateLimitWait.WithLabelValues(key).Observe(wait.Seconds())
+}
+
+// statusClass maps a response to 2xx/3xx/4xx/5xx, or "error" for transport errors
+func statusClass(statusCode int, err error) string {
+	if err != nil || statusCode < 100 || statusCode > 599 {
+		return "error"
+	}
+	return string(rune('0'+statusCode/100)) + "xx"
+}
+
+// =============================================================================
+// Realtime
+// =============================================================================
+
+// SetSyncQueueBacklog records the pending sync job count and the oldest pending age
+func SetSyncQueueBacklog(pending int, oldest time.Duration) {
+	syncQueueBacklog.Set(float64(pending))
+	syncQueueOldestPending.Set(oldest.Seconds())
+}
+
+// ObserveWSReconnect records one WebSocket reconnect attempt
+func ObserveWSReconnect(err error) {
+	wsReconnects.WithLabelValues(Result(err)).Inc()
+}
+
+// PriceCacheStats is the price cache snapshot exported as gauges
+type PriceCacheStats struct {
+	Size      int
+	Stale     int
+	OldestAge time.Duration // 가장 오래된 틱의 경과 시간 (피드 중단 감지)
+}
+
+// priceCacheCollector reads the registered cache on every scrape
+type priceCacheCollector struct {
+	mu    sync.RWMutex
+	stats func() PriceCacheStats
+
+	size   *prometheus.Desc
+	stale  *prometheus.Desc
+	oldest *prometheus.Desc
+}
+
+var priceCache = &priceCacheCollector{
+	size: prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "price_cache", "size"),
+		"Symbols held in the realtime price cache.", nil, nil),
+	stale: prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "price_cache", "stale"),
+		"Symbols whose last tick is older than the cache TTL.", nil, nil),
+	oldest: prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "price_cache", "oldest_tick_age_seconds"),
+		"Age of the oldest tick in the price cache.", nil, nil),
+}
+
+// TrackPriceCache registers the price cache snapshot function (last call wins)
+func TrackPriceCache(fn func() PriceCacheStats) {
+	priceCache.mu.Lock()
+	defer priceCache.mu.Unlock()
+
+	priceCache.stats = fn
+}
+
+// Describe implements prometheus.Collector
+func (c *priceCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+	ch <- c.stale
+	ch <- c.oldest
+}
+
+// Collect implements prometheus.Collector (no cache registered → no samples)
+func (c *priceCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	fn := c.stats
+	c.mu.RUnlock()
+
+	if fn == nil {
+		return
+	}
+
+	stats := fn()
+	ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(stats.Size))
+	ch <- prometheus.MustNewConstMetric(c.stale, prometheus.GaugeValue, float64(stats.Stale))
+	ch <- prometheus.MustNewConstMetric(c.oldest, prometheus.GaugeValue, stats.OldestAge.Seconds())
+}
+
+// =============================================================================
+// Execution
+// =============================================================================
+
+// ObserveOrderSubmit records broker order submission latency (result: accepted/rejected/error)
+func ObserveOrderSubmit(side, result string, start time.Time) {
+	orderLatency.WithLabelValues(strings.ToUpper(side), result).Observe(time.Since(start).Seconds())
+}
+
+// ObserveOrderCompletion records the final status and fill ratio of a completed order
+func ObserveOrderCompletion(side, status string, filledQty, qty int) {
+	side = strings.ToUpper(side)
+	orderCompletions.WithLabelValues(side, status).Inc()
+	if qty > 0 {
+		orderFillRatio.WithLabelValues(side).Observe(float64(filledQty) / float64(qty))
+	}
+}
+
+// ObserveRiskGate records one risk gate decision
+func ObserveRiskGate(mode, action string, wouldBlock bool) {
+	wb := "false"
+	if wouldBlock {
+		wb = "true"
+	}
+	riskGateActions.WithLabelValues(mode, action, wb).Inc()
+}
+
+// =============================================================================
+// Scheduler
+// =============================================================================
+
+// ObserveJob records a scheduler job outcome (status: SUCCESS/FAILED/SKIPPED)
+func ObserveJob(job, status string, duration time.Duration) {
+	schedulerJobRuns.WithLabelValues(job, status).Inc()
+	if status == "SKIPPED" {
+		return
+	}
+	schedulerJobDuration.WithLabelValues(job).Observe(duration.Seconds())
+	if status == "SUCCESS" {
+		schedulerJobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+}
+
+// =============================================================================
+// Notifications
+// =============================================================================
+
+// ObserveNotification records one outbox delivery attempt
+func ObserveNotification(channel, result string) {
+	notifications.WithLabelValues(channel, result).Inc()
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   string
+	}{
+		{"ok", 200, nil, "2xx"},
+		{"not found", 404, nil, "4xx"},
+		{"server error", 503, nil, "5xx"},
+		{"transport error", 0, errors.New("timeout"), "error"},
+		{"invalid status", 42, nil, "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, statusClass(tt.status, tt.err))
+		})
+	}
+}
+
+func TestObserveJob(t *testing.T) {
+	ObserveJob("test_job", "SUCCESS", 2*time.Second)
+	ObserveJob("test_job", "SKIPPED", 0)
+	ObserveJob("test_job", "FAILED", time.Second)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(schedulerJobRuns.WithLabelValues("test_job", "SUCCESS")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(schedulerJobRuns.WithLabelValues("test_job", "SKIPPED")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(schedulerJobRuns.WithLabelValues("test_job", "FAILED")))
+	assert.Greater(t, testutil.ToFloat64(schedulerJobLastSuccess.WithLabelValues("test_job")), 0.0)
+
+	// 건너뛴 실행은 소요 시간에 포함하지 않음
+	assert.Equal(t, 1, testutil.CollectAndCount(schedulerJobDuration, "aegis_scheduler_job_duration_seconds"))
+}
+
+func TestObserveOrderCompletion_FillRatio(t *testing.T) {
+	ObserveOrderCompletion("buy", "FILLED", 10, 10)
+	ObserveOrderCompletion("buy", "CANCELED", 3, 10)
+	ObserveOrderCompletion("buy", "REJECTED", 0, 0) // 수량 0은 체결률 제외
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(orderCompletions.WithLabelValues("BUY", "CANCELED")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(orderCompletions.WithLabelValues("BUY", "REJECTED")))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, mf := range families {
+		if mf.GetName() != "aegis_order_fill_ratio" {
+			continue
+		}
+		h := mf.GetMetric()[0].GetHistogram()
+		assert.Equal(t, uint64(2), h.GetSampleCount())
+		assert.InDelta(t, 1.3, h.GetSampleSum(), 1e-9)
+		return
+	}
+	t.Fatal("aegis_order_fill_ratio not exported")
+}
+
+func TestHandler_ExportsPriceCache(t *testing.T) {
+	TrackPriceCache(func() PriceCacheStats {
+		return PriceCacheStats{Size: 30, Stale: 2, OldestAge: 90 * time.Second}
+	})
+	defer TrackPriceCache(nil)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "aegis_price_cache_size 30")
+	assert.Contains(t, body, "aegis_price_cache_stale 2")
+	assert.Contains(t, body, "aegis_price_cache_oldest_tick_age_seconds 90")
+	assert.True(t, strings.Contains(body, "go_goroutines"), "runtime metrics registered")
+}
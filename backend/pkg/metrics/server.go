@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wonny/aegis/v13/backend/pkg/config"
+	"github.com/wonny/aegis/v13/backend/pkg/logger"
+)
+
+// Process identifies the process exposing /metrics
+type Process string
+
+const (
+	ProcessAPI       Process = "api"
+	ProcessScheduler Process = "scheduler"
+	ProcessWorker    Process = "worker"
+)
+
+// port returns the process's metrics port (API/SCHEDULER/WORKER_METRICS_PORT)
+func (p Process) port(cfg *config.Config) string {
+	switch p {
+	case ProcessScheduler:
+		return cfg.MetricsPorts.Scheduler
+	case ProcessWorker:
+		return cfg.MetricsPorts.Worker
+	default:
+		return cfg.MetricsPorts.API
+	}
+}
+
+// Server exposes GET /metrics on the process's metrics port
+// API 서버/스케줄러/워커가 각각 띄우므로 프로세스별 포트 사용 (기본 9090/9091/9092)
+type Server struct {
+	httpServer *http.Server
+	port       string
+	logger     *logger.Logger
+}
+
+// StartServer starts the metrics server in the background
+// METRICS_ENABLED=false 이면 nil 반환 (nil Server의 Shutdown은 no-op)
+// 포트 충돌 등 리슨 실패는 경고만 남기고 본 프로세스는 계속 실행
+func StartServer(cfg *config.Config, process Process, log *logger.Logger) *Server {
+	if !cfg.MetricsEnabled {
+		return nil
+	}
+	port := process.port(cfg)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	s := &Server{
+		httpServer: &http.Server{
+			Addr:              ":" + port,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		port:   port,
+		logger: log,
+	}
+
+	go func() {
+		fields := map[string]interface{}{"process": process, "port": port}
+		log.WithFields(fields).Info("Starting metrics server")
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).WithFields(fields).Warn("Metrics server stopped")
+		}
+	}()
+
+	return s
+}
+
+// Port returns the port the server listens on
+func (s *Server) Port() string {
+	return s.port
+}
+
+// Shutdown gracefully stops the metrics server
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown metrics server: %w", err)
+	}
+
+	return nil
+}